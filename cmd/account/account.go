@@ -0,0 +1,178 @@
+package account
+
+import (
+	"fmt"
+	"intmax2-node/configs"
+	"intmax2-node/internal/keystore"
+	"intmax2-node/internal/logger"
+	"intmax2-node/internal/mnemonic_wallet"
+	"intmax2-node/internal/mnemonic_wallet/models"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const emptyKey = ""
+
+func NewAccountCmd(cfg *configs.Config, log logger.Logger) *cobra.Command {
+	const (
+		use   = "account"
+		short = "Manage the accounts of the encrypted keystore"
+	)
+
+	accountCmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+	}
+	accountCmd.AddCommand(importCmd(cfg, log))
+	accountCmd.AddCommand(listCmd(cfg, log))
+	accountCmd.AddCommand(exportCmd(cfg, log))
+
+	return accountCmd
+}
+
+func importCmd(cfg *configs.Config, log logger.Logger) *cobra.Command {
+	const (
+		use   = "import"
+		short = "Import Ethereum private key and derived INTMAX key into the keystore"
+
+		privateKeyInHexKey         = "private-key"
+		privateKeyInHexDescription = "user's Ethereum private key (read from the terminal if not set). use as --private-key \"__PRIVATE_KEY_IN_HEX_WITHOUT_0x__\""
+	)
+
+	cmd := cobra.Command{
+		Use:   use,
+		Short: short,
+	}
+
+	var privateKeyInHex string
+	cmd.PersistentFlags().StringVar(&privateKeyInHex, privateKeyInHexKey, emptyKey, privateKeyInHexDescription)
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		l := log.WithFields(logger.Fields{"module": "account_import"})
+
+		if privateKeyInHex == emptyKey {
+			fmt.Printf("Enter private key:")
+			bytePK, err := term.ReadPassword(syscall.Stdin)
+			fmt.Println()
+			if err != nil {
+				const msg = "failed to read private key: %+v"
+				l.Fatalf(msg, err)
+			}
+			privateKeyInHex = string(bytePK)
+		}
+
+		passphrase, err := keystore.Passphrase(cfg, true)
+		if err != nil {
+			const msg = "failed to get passphrase: %+v"
+			l.Fatalf(msg, err)
+		}
+
+		var acc *keystore.Account
+		acc, err = keystore.New(cfg).Import(privateKeyInHex, passphrase)
+		if err != nil {
+			const msg = "failed to import private key: %+v"
+			l.Fatalf(msg, err)
+		}
+
+		fmt.Println("Ethereum address:", acc.EthereumAddress.Hex())
+		fmt.Println("INTMAX address:", acc.IntMaxAddress)
+		fmt.Println("Key file:", acc.Path)
+	}
+
+	return &cmd
+}
+
+func listCmd(cfg *configs.Config, log logger.Logger) *cobra.Command {
+	const (
+		use   = "list"
+		short = "List the accounts of the keystore"
+	)
+
+	cmd := cobra.Command{
+		Use:   use,
+		Short: short,
+	}
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		l := log.WithFields(logger.Fields{"module": "account_list"})
+
+		list, err := keystore.New(cfg).List()
+		if err != nil {
+			const msg = "failed to list accounts: %+v"
+			l.Fatalf(msg, err)
+		}
+
+		for i := range list {
+			fmt.Printf("Account #%d: %s %s %s\n", i, list[i].EthereumAddress.Hex(), list[i].IntMaxAddress, list[i].Path)
+		}
+	}
+
+	return &cmd
+}
+
+func exportCmd(cfg *configs.Config, log logger.Logger) *cobra.Command {
+	const (
+		use   = "export"
+		short = "Export the Ethereum and INTMAX private keys of the keystore account"
+
+		developerModeKey         = "developer"
+		developerModeDescription = "Enable developer mode to output all information in JSON format."
+	)
+
+	cmd := cobra.Command{
+		Use:   use,
+		Short: short,
+	}
+
+	var account string
+	cmd.PersistentFlags().StringVar(&account, keystore.AccountFlagKey, emptyKey, keystore.AccountFlagDescription)
+
+	var developerMode bool
+	cmd.PersistentFlags().BoolVar(&developerMode, developerModeKey, false, developerModeDescription)
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		l := log.WithFields(logger.Fields{"module": "account_export"})
+
+		if account == emptyKey {
+			const msg = "account flag is not set"
+			l.Fatalf("%s", msg)
+		}
+
+		key, err := keystore.Open(cfg, account)
+		if err != nil {
+			const msg = "failed to unlock account: %+v"
+			l.Fatalf(msg, err)
+		}
+
+		var w *models.Wallet
+		w, err = mnemonic_wallet.New().WalletFromPrivateKeyHex(key.PrivateKeyHex())
+		if err != nil {
+			const msg = "failed to get wallet from private key: %+v"
+			l.Fatalf(msg, err)
+		}
+		w.IntMaxPublicKey = key.IntMaxPrivateKey.PublicKey.String()
+		w.IntMaxWalletAddress = key.IntMaxPrivateKey.ToAddress().String()
+		w.IntMaxPrivateKey = key.IntMaxPrivateKey.String()
+
+		if developerMode {
+			var wb []byte
+			wb, err = w.Marshal()
+			if err != nil {
+				const msg = "failed to marshal wallet: %+v"
+				l.Fatalf(msg, err)
+			}
+
+			print(string(wb))
+			return
+		}
+
+		fmt.Println("Ethereum address:", w.WalletAddress)
+		fmt.Println("Ethereum private key:", w.PrivateKey)
+		fmt.Println("INTMAX address:", w.IntMaxWalletAddress)
+		fmt.Println("INTMAX private key:", w.IntMaxPrivateKey)
+	}
+
+	return &cmd
+}
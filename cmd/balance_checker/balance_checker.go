@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"intmax2-node/configs"
+	"intmax2-node/internal/keystore"
 	"intmax2-node/internal/logger"
 	"os"
 
@@ -49,6 +50,9 @@ func getBalanceCmd(b *Balance) *cobra.Command {
 	var userEthPrivateKey string
 	cmd.PersistentFlags().StringVar(&userEthPrivateKey, userPrivateKeyKey, emptyKey, userAddressDescription)
 
+	var account string
+	cmd.PersistentFlags().StringVar(&account, keystore.AccountFlagKey, emptyKey, keystore.AccountFlagDescription)
+
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		err := b.SB.SetupEthereumNetworkChainID(b.Context)
 		if err != nil {
@@ -57,6 +61,13 @@ func getBalanceCmd(b *Balance) *cobra.Command {
 			os.Exit(1)
 		}
 
+		userEthPrivateKey, err = keystore.PrivateKeyHex(b.Config, account, userEthPrivateKey)
+		if err != nil {
+			const msg = "Fatal: %v\n"
+			_, _ = fmt.Fprintf(os.Stderr, msg, err)
+			os.Exit(1)
+		}
+
 		err = newCommands().GetBalance(b.Config, b.Log, b.SB).Do(b.Context, args, userEthPrivateKey)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Fatal: %v\n", err)
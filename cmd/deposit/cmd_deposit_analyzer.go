@@ -1,7 +1,11 @@
 package deposit
 
 import (
+	"context"
+	"intmax2-node/internal/daemon"
+	service "intmax2-node/internal/deposit_service"
 	"intmax2-node/internal/logger"
+	ucHealthCheck "intmax2-node/pkg/use_cases/health_check"
 	"intmax2-node/pkg/utils"
 
 	"github.com/spf13/cobra"
@@ -18,6 +22,14 @@ func analyzerCmd(d *Deposit) *cobra.Command {
 		Short: short,
 	}
 
+	const (
+		daemonKey         = "daemon"
+		daemonDescription = "daemon flag. use as --daemon to analyze deposits every DAEMON_DEPOSIT_ANALYZER_INTERVAL until stopped"
+	)
+
+	var daemonMode bool
+	cmd.PersistentFlags().BoolVar(&daemonMode, daemonKey, false, daemonDescription)
+
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		l := d.Log.WithFields(logger.Fields{"module": use})
 
@@ -33,6 +45,26 @@ func analyzerCmd(d *Deposit) *cobra.Command {
 			l.Fatalf(msg, err.Error())
 		}
 
+		if daemonMode {
+			const sqlDBApp = "sql-db-app"
+
+			dm := daemon.New(d.Config, l, d.DbApp, service.DepositAnalyzerJob, func(ctx context.Context) error {
+				return newCommands().DepositAnalyzer(d.Config, l, d.DbApp, d.SB).Do(ctx)
+			})
+			d.HC.AddChecker(sqlDBApp, d.DbApp)
+			d.HC.AddChecker(service.DepositAnalyzerJob, dm)
+
+			err = daemon.Run(
+				d.Context, l, d.Config.Daemon.HealthCheckAddr, ucHealthCheck.New(d.HC),
+				dm, d.Config.Daemon.DepositAnalyzerInterval,
+			)
+			if err != nil {
+				const msg = "failed to run deposit analyzer daemon: %v"
+				l.Fatalf(msg, err.Error())
+			}
+			return
+		}
+
 		err = d.DbApp.Exec(d.Context, nil, func(db interface{}, _ interface{}) (err error) {
 			q := db.(SQLDriverApp)
 			return newCommands().DepositAnalyzer(d.Config, l, q, d.SB).Do(d.Context)
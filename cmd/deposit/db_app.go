@@ -3,21 +3,40 @@ package deposit
 import (
 	"context"
 	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+
+	"github.com/dimiro1/health"
 )
 
 //go:generate mockgen -destination=mock_db_app.go -package=deposit -source=db_app.go
 
 type SQLDriverApp interface {
 	GenericCommandsApp
+	ServiceCommands
+	CtrlProcessingJobs
 	EventBlockNumbers
+	DepositAMLScreenings
 }
 
 type GenericCommandsApp interface {
 	Exec(ctx context.Context, input interface{}, executor func(d interface{}, input interface{}) error) (err error)
 }
 
+type ServiceCommands interface {
+	Check(ctx context.Context) health.Health
+}
+
+type CtrlProcessingJobs interface {
+	CreateCtrlProcessingJobs(name string) error
+	CtrlProcessingJobs(name string) (*mDBApp.CtrlProcessingJobs, error)
+}
+
 type EventBlockNumbers interface {
 	UpsertEventBlockNumber(eventName string, blockNumber uint64) (*mDBApp.EventBlockNumber, error)
 	EventBlockNumberByEventName(eventName string) (*mDBApp.EventBlockNumber, error)
 	EventBlockNumbersByEventNames(eventNames []string) ([]*mDBApp.EventBlockNumber, error)
 }
+
+type DepositAMLScreenings interface {
+	UpsertDepositAMLScreening(screening *mDBApp.DepositAMLScreening) (*mDBApp.DepositAMLScreening, error)
+	DepositAMLScreeningByDepositID(depositID uint64) (*mDBApp.DepositAMLScreening, error)
+}
@@ -5,6 +5,7 @@ import (
 	"intmax2-node/configs"
 	"intmax2-node/internal/logger"
 
+	"github.com/dimiro1/health"
 	"github.com/spf13/cobra"
 )
 
@@ -14,6 +15,7 @@ type Deposit struct {
 	Log     logger.Logger
 	DbApp   SQLDriverApp
 	SB      ServiceBlockchain
+	HC      *health.Handler
 }
 
 func NewDepositCmd(d *Deposit) *cobra.Command {
@@ -2,6 +2,8 @@ package ethereum_private_key_wallet
 
 import (
 	"fmt"
+	"intmax2-node/configs"
+	"intmax2-node/internal/keystore"
 	"intmax2-node/internal/logger"
 	"intmax2-node/internal/mnemonic_wallet"
 	"intmax2-node/internal/mnemonic_wallet/models"
@@ -9,7 +11,7 @@ import (
 	"github.com/spf13/cobra"
 )
 
-func NewCmd(log logger.Logger) *cobra.Command {
+func NewCmd(cfg *configs.Config, log logger.Logger) *cobra.Command {
 	const (
 		use   = "ethereum_private_key_wallet"
 		short = "Generate Ethereum and INTMAX wallets from Ethereum private key"
@@ -33,18 +35,27 @@ func NewCmd(log logger.Logger) *cobra.Command {
 	cmd.PersistentFlags().StringVar(&privateKeyInHex, privateKeyInHexKey, emptyKey, privateKeyInHexDescription)
 	cmd.PersistentFlags().BoolVar(&developerMode, developerModeKey, false, developerModeDescription)
 
+	var account string
+	cmd.PersistentFlags().StringVar(&account, keystore.AccountFlagKey, emptyKey, keystore.AccountFlagDescription)
+
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		l := log.WithFields(logger.Fields{"module": use})
 
+		var (
+			err error
+			w   *models.Wallet
+		)
+		privateKeyInHex, err = keystore.PrivateKeyHex(cfg, account, privateKeyInHex)
+		if err != nil {
+			const msg = "failed to get private key: %+v"
+			l.Fatalf(msg, err)
+		}
+
 		if privateKeyInHex == emptyKey {
 			const msg = "private_key flag is not set"
 			l.Fatalf("%s", msg)
 		}
 
-		var (
-			err error
-			w   *models.Wallet
-		)
 		w, err = mnemonic_wallet.New().WalletFromPrivateKeyHex(
 			privateKeyInHex,
 		)
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"intmax2-node/cmd/account"
 	"intmax2-node/cmd/balance_checker"
 	"intmax2-node/cmd/block_builder"
 	"intmax2-node/cmd/deposit"
@@ -21,6 +22,7 @@ import (
 	"intmax2-node/internal/block_validity_prover"
 	"intmax2-node/internal/blockchain"
 	"intmax2-node/internal/cli"
+	"intmax2-node/internal/deposit_indexer"
 	"intmax2-node/internal/deposit_synchronizer"
 	"intmax2-node/internal/network_service"
 	"intmax2-node/internal/open_telemetry"
@@ -80,7 +82,8 @@ func main() {
 	w := worker.New(cfg, log, dbApp)
 	bc := blockchain.New(ctx, cfg)
 	depositSynchronizer := deposit_synchronizer.New(cfg, log, dbApp, bc)
-	blockValidityProver := block_validity_prover.New(cfg, log, dbApp, bc)
+	depositIndexer := deposit_indexer.New(cfg, log, dbApp, bc)
+	blockValidityProver := block_validity_prover.New(cfg, log, dbApp, bc, depositIndexer)
 	ns := network_service.New(cfg)
 	hc := health.NewHandler()
 	bbr := block_builder_registry_service.New(cfg, log, bc)
@@ -89,6 +92,10 @@ func main() {
 	pw := pow.New(cfg.PoW.Difficulty)
 	pWorker := pow.NewWorker(cfg.PoW.Workers, pw)
 	pwNonce := pow.NewPoWNonce(pw, pWorker)
+	pwDifficulty := pow.NewDifficultyAdjuster(cfg, func() int {
+		load := w.Load()
+		return load.PendingTxs + load.SignatureBacklog
+	})
 
 	wg := sync.WaitGroup{}
 
@@ -106,8 +113,10 @@ func main() {
 			NS:                  ns,
 			HC:                  &hc,
 			PoW:                 pwNonce,
+			PoWDifficulty:       pwDifficulty,
 			Worker:              w,
 			DepositSynchronizer: depositSynchronizer,
+			DepositIndexer:      depositIndexer,
 			BlockValidityProver: blockValidityProver,
 			GPOStorage:          storeGPO,
 		}),
@@ -118,6 +127,7 @@ func main() {
 			Log:     log,
 			DbApp:   dbApp,
 			SB:      bc,
+			HC:      &hc,
 		}),
 		withdrawal.NewWithdrawCmd(&withdrawal.Withdrawal{
 			Context: ctx,
@@ -125,6 +135,7 @@ func main() {
 			Log:     log,
 			DbApp:   dbApp,
 			SB:      bc,
+			HC:      &hc,
 		}),
 		withdrawal_server.NewServerCmd(&withdrawal_server.WithdrawalServer{
 			Context: ctx,
@@ -147,7 +158,8 @@ func main() {
 		}),
 		generate_account.NewCmd(log),
 		mnemonic_account.NewCmd(log),
-		ethereum_private_key_wallet.NewCmd(log),
+		ethereum_private_key_wallet.NewCmd(cfg, log),
+		account.NewAccountCmd(cfg, log),
 		intmax_private_key_wallet.NewCmd(log),
 		balance_checker.NewBalanceCmd(&balance_checker.Balance{
 			Context: ctx,
@@ -1,6 +1,11 @@
 package server
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/dimiro1/health"
+)
 
 //go:generate mockgen -destination=mock_block_builder_registry_service.go -package=server -source=block_builder_registry_service.go
 
@@ -9,4 +14,10 @@ type BlockBuilderRegistryService interface {
 		ctx context.Context,
 		url string,
 	) error
+	Start(
+		ctx context.Context,
+		tickerEventWatcher *time.Ticker,
+	) (err error)
+	Check(ctx context.Context) (res health.Health)
+	Active() error
 }
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	intMaxAcc "intmax2-node/internal/accounts"
+	"intmax2-node/internal/block_validity_prover"
 	"math/big"
 	"time"
 )
@@ -15,4 +16,5 @@ type BlockValidityProver interface {
 	FetchAccountIDFromPublicKey(publicKey *intMaxAcc.PublicKey) (accountID uint64, err error)
 	FetchPublicKeyFromAddress(accountID uint64) (publicKey *intMaxAcc.PublicKey, err error)
 	FetchDepositMerkleProofFromDepositID(depositID *big.Int) (depositMerkleProof []string, err error)
+	GetBlockMerkleProof(blockNumber, rootBlockNumber uint32) (*block_validity_prover.BlockMerkleProof, error)
 }
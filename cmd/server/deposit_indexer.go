@@ -0,0 +1,13 @@
+package server
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -destination=mock_deposit_indexer.go -package=server -source=deposit_indexer.go
+
+type DepositIndexer interface {
+	Init(ctx context.Context) (err error)
+	Start(ctx context.Context, tickerEventWatcher *time.Ticker) error
+}
@@ -10,6 +10,7 @@ import (
 type GPOStorage interface {
 	Init(ctx context.Context) (err error)
 	Value(ctx context.Context, name string) (*big.Int, error)
+	QuotedValue(ctx context.Context, name string) (*big.Int, error)
 	UpdValue(ctx context.Context, name string) (err error)
 	UpdValues(ctx context.Context, name ...string) (err error)
 }
@@ -0,0 +1,10 @@
+package server
+
+import "intmax2-node/internal/pow"
+
+//go:generate mockgen -destination=mock_pow_difficulty.go -package=server -source=pow_difficulty.go
+
+type PoWDifficulty interface {
+	Current() *pow.Difficulty
+	Accepted() uint64
+}
@@ -39,8 +39,10 @@ type Server struct {
 	NS                  NetworkService
 	HC                  *health.Handler
 	PoW                 PoWNonce
+	PoWDifficulty       PoWDifficulty
 	Worker              Worker
 	DepositSynchronizer DepositSynchronizer
+	DepositIndexer      DepositIndexer
 	BlockValidityProver BlockValidityProver
 	GPOStorage          GPOStorage
 }
@@ -74,6 +76,12 @@ func NewServerCmd(s *Server) *cobra.Command {
 				s.Log.Fatalf(msg, err.Error())
 			}
 
+			err = s.DepositIndexer.Init(s.Context)
+			if err != nil {
+				const msg = "init the Deposit Indexer error occurred: %v"
+				s.Log.Fatalf(msg, err.Error())
+			}
+
 			err = s.GPOStorage.Init(s.Context)
 			if err != nil {
 				const msg = "init the gas price oracle storage error occurred: %v"
@@ -164,8 +172,6 @@ func NewServerCmd(s *Server) *cobra.Command {
 				}()
 			}
 
-			// TODO: add processing events of Rollup contract (example, BlockBuilderUpdated)
-
 			wg := sync.WaitGroup{}
 
 			wg.Add(1)
@@ -175,19 +181,26 @@ func NewServerCmd(s *Server) *cobra.Command {
 					wg.Done()
 					s.WG.Done()
 				}()
-				tickerCurrentFile := time.NewTicker(s.Config.Worker.TimeoutForCheckCurrentFile)
+				tickerEventWatcher := time.NewTicker(s.Config.BlockBuilderRegistry.TimeoutForEventWatcher)
 				defer func() {
-					if tickerCurrentFile != nil {
-						tickerCurrentFile.Stop()
+					if tickerEventWatcher != nil {
+						tickerEventWatcher.Stop()
 					}
 				}()
-				tickerSignaturesAvailableFiles := time.NewTicker(s.Config.Worker.TimeoutForSignaturesAvailableFiles)
+				if err = s.BBR.Start(s.Context, tickerEventWatcher); err != nil {
+					const msg = "failed to start Block Builder Registry event watcher: %+v"
+					s.Log.Fatalf(msg, err.Error())
+				}
+			}()
+
+			wg.Add(1)
+			s.WG.Add(1)
+			go func() {
 				defer func() {
-					if tickerSignaturesAvailableFiles != nil {
-						tickerSignaturesAvailableFiles.Stop()
-					}
+					wg.Done()
+					s.WG.Done()
 				}()
-				if err = s.Worker.Start(s.Context, tickerCurrentFile, tickerSignaturesAvailableFiles); err != nil {
+				if err = s.Worker.Start(s.Context); err != nil {
 					const msg = "failed to start worker: %+v"
 					s.Log.Fatalf(msg, err.Error())
 				}
@@ -220,7 +233,6 @@ func NewServerCmd(s *Server) *cobra.Command {
 				}
 			}()
 
-			// TODO: Occur error: Block range is too large
 			wg.Add(1)
 			s.WG.Add(1)
 			go func() {
@@ -240,7 +252,25 @@ func NewServerCmd(s *Server) *cobra.Command {
 				}
 			}()
 
-			// TODO: Occur error: Block range is too large
+			wg.Add(1)
+			s.WG.Add(1)
+			go func() {
+				defer func() {
+					wg.Done()
+					s.WG.Done()
+				}()
+				tickerEventWatcher := time.NewTicker(s.Config.DepositSynchronizer.TimeoutForEventWatcher)
+				defer func() {
+					if tickerEventWatcher != nil {
+						tickerEventWatcher.Stop()
+					}
+				}()
+				if err = s.DepositIndexer.Start(s.Context, tickerEventWatcher); err != nil {
+					const msg = "failed to start Deposit Indexer: %+v"
+					s.Log.Fatalf(msg, err.Error())
+				}
+			}()
+
 			wg.Add(1)
 			s.WG.Add(1)
 			go func() {
@@ -326,9 +356,12 @@ func (s *Server) Init() error {
 		s.Config.HTTP.CookieForAuthUse,
 		s.HC,
 		s.PoW,
+		s.PoWDifficulty,
 		s.Worker,
 		s.SB,
 		s.GPOStorage,
+		s.BBR,
+		s.BlockValidityProver,
 	)
 	ctx := context.WithValue(s.Context, consts.AppConfigs, s.Config)
 
@@ -340,6 +373,7 @@ func (s *Server) Init() error {
 		sqlDBApp  = "sql-db-app"
 		checkSB   = "blockchain_service"
 		checkNS   = "network_service"
+		checkBBR  = "block_builder_registry_service"
 	)
 
 	// run externals gRPC server listener
@@ -362,6 +396,7 @@ func (s *Server) Init() error {
 	})
 	s.HC.AddChecker(checkSB, s.SB)
 	s.HC.AddChecker(checkNS, s.NS)
+	s.HC.AddChecker(checkBBR, s.BBR)
 
 	// run web -> gRPC gateway
 	gw, grpcGwErr := gateway.Run(
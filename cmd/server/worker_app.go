@@ -3,22 +3,20 @@ package server
 import (
 	"context"
 	"intmax2-node/internal/worker"
-	"time"
 )
 
 type Worker interface {
 	Init() (err error)
-	Start(
-		ctx context.Context,
-		tickerCurrentFile, tickerSignaturesAvailableFiles *time.Ticker,
-	) error
+	Start(ctx context.Context) error
 	Receiver(input *worker.ReceiverWorker) error
 	TrHash(trHash string) (*worker.TransactionHashesWithSenderAndFile, error)
+	NextNonce(sender string) (nonce uint64, err error)
 	TxTreeByAvailableFile(sf *worker.TransactionHashesWithSenderAndFile) (txTreeRoot *worker.TxTree, err error)
 	SignTxTreeByAvailableFile(
 		signature string,
 		sf *worker.TransactionHashesWithSenderAndFile,
 		leafIndex uint64,
 	) error
+	Subscribe(key string) (events <-chan *worker.TxEvent, unsubscribe func())
 	// ExistsTxTreeRoot(txTreeRoot string) error
 }
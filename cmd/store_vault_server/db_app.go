@@ -4,6 +4,7 @@ import (
 	"context"
 	mFL "intmax2-node/internal/sql_filter/models"
 	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	"time"
 
 	"github.com/dimiro1/health"
 )
@@ -17,6 +18,7 @@ type SQLDriverApp interface {
 	BackupTransactions
 	BackupDeposits
 	BackupBalances
+	Deposits
 }
 
 type GenericCommandsApp interface {
@@ -37,6 +39,11 @@ type BackupTransfers interface {
 		recipient, transferDoubleHash string,
 	) (*mDBApp.BackupTransfer, error)
 	GetBackupTransfers(condition string, value interface{}) ([]*mDBApp.BackupTransfer, error)
+	GetBackupTransfersByRecipientFromSeq(
+		recipient string,
+		startSeq, limit uint64,
+		createdBefore time.Time,
+	) ([]*mDBApp.BackupTransfer, error)
 	GetBackupTransfersByRecipient(
 		recipient string,
 		pagination mDBApp.PaginationOfListOfBackupTransfersInput,
@@ -57,6 +64,11 @@ type BackupTransactions interface {
 	GetBackupTransaction(condition string, value string) (*mDBApp.BackupTransaction, error)
 	GetBackupTransactionBySenderAndTxDoubleHash(sender, txDoubleHash string) (*mDBApp.BackupTransaction, error)
 	GetBackupTransactions(condition string, value interface{}) ([]*mDBApp.BackupTransaction, error)
+	GetBackupTransactionsBySenderFromSeq(
+		sender string,
+		startSeq, limit uint64,
+		createdBefore time.Time,
+	) ([]*mDBApp.BackupTransaction, error)
 	GetBackupTransactionsBySender(
 		sender string,
 		pagination mDBApp.PaginationOfListOfBackupTransactionsInput,
@@ -79,6 +91,11 @@ type BackupDeposits interface {
 	) (*mDBApp.BackupDeposit, error)
 	GetBackupDeposit(conditions []string, values []interface{}) (*mDBApp.BackupDeposit, error)
 	GetBackupDeposits(condition string, value interface{}) ([]*mDBApp.BackupDeposit, error)
+	GetBackupDepositsByRecipientFromSeq(
+		recipient string,
+		startSeq, limit uint64,
+		createdBefore time.Time,
+	) ([]*mDBApp.BackupDeposit, error)
 	GetBackupDepositsByRecipient(
 		recipient string,
 		pagination mDBApp.PaginationOfListOfBackupDepositsInput,
@@ -100,3 +117,9 @@ type BackupBalances interface {
 	GetBackupBalance(conditions []string, values []interface{}) (*mDBApp.BackupBalance, error)
 	GetBackupBalances(condition string, value interface{}) ([]*mDBApp.BackupBalance, error)
 }
+
+type Deposits interface {
+	DepositByDepositID(depositID uint64) (*mDBApp.Deposit, error)
+	DepositByDepositHash(depositHash string) (*mDBApp.Deposit, error)
+	IndexedDepositsFromDepositIndex(depositIndex uint32) ([]*mDBApp.Deposit, error)
+}
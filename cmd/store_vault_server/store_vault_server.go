@@ -5,12 +5,14 @@ import (
 	"intmax2-node/configs"
 	"intmax2-node/configs/buildvars"
 	"intmax2-node/docs/swagger"
+	"intmax2-node/internal/deposit_indexer"
 	"intmax2-node/internal/logger"
 	"intmax2-node/internal/pb/gateway"
 	"intmax2-node/internal/pb/gateway/consts"
 	"intmax2-node/internal/pb/gateway/http_response_modifier"
 	node "intmax2-node/internal/pb/gen/store_vault_service/node"
 	"intmax2-node/internal/pb/listener"
+	storeVaultAuth "intmax2-node/internal/store_vault_auth"
 	server "intmax2-node/pkg/grpc_server/store_vault_server"
 	"intmax2-node/third_party"
 	"sync"
@@ -69,8 +71,18 @@ func (s *StoreVaultServer) Init() error {
 		})
 	}
 
+	if s.Config.StoreVaultAuth.Secret == "" && s.Config.StoreVaultAuth.DevMode {
+		s.Log.Warnf("The STORE_VAULT_AUTH_SECRET is empty, the sessions are lost on the restart")
+	}
+
+	auth, err := storeVaultAuth.New(s.Config)
+	if err != nil {
+		return err
+	}
+
 	srv := server.New(
-		s.Log, s.Config, s.DbApp, server.NewCommands(), s.SB, s.Config.HTTP.CookieForAuthUse, s.HC,
+		s.Log, s.Config, s.DbApp, server.NewCommands(), s.SB, auth, deposit_indexer.NewDepositTreeReader(s.DbApp),
+		s.Config.HTTP.CookieForAuthUse, s.HC,
 	)
 	ctx := context.WithValue(s.Context, consts.AppConfigs, s.Config)
 
@@ -144,7 +156,6 @@ func (s *StoreVaultServer) Init() error {
 	s.Log.Infof(start, appName, buildvars.Version, buildvars.BuildTime)
 	defer s.Log.Infof(finish, appName)
 
-	var err error
 	select {
 	case <-s.Context.Done():
 	case err = <-grpcErr:
@@ -2,6 +2,7 @@ package transaction
 
 import (
 	"fmt"
+	"intmax2-node/internal/keystore"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -24,8 +25,11 @@ func txClaimCmd(b *Transaction) *cobra.Command {
 	var userEthPrivateKey string
 	cmd.PersistentFlags().StringVar(&userEthPrivateKey, userEthPrivateKeyKey, emptyKey, userPrivateDescription)
 
+	var account string
+	cmd.PersistentFlags().StringVar(&account, keystore.AccountFlagKey, emptyKey, keystore.AccountFlagDescription)
+
 	cmd.Run = func(cmd *cobra.Command, args []string) {
-		err := newCommands().SendClaimWithdrawals(b.Config, b.Log, b.SB).Do(b.Context, args, userEthPrivateKey)
+		err := newCommands().SendClaimWithdrawals(b.Config, b.Log, b.SB).Do(b.Context, args, userPrivateKeyHex(b, account, userEthPrivateKey))
 		if err != nil {
 			const msg = "Fatal: %v\n"
 			fmt.Fprintf(os.Stderr, msg, err)
@@ -3,8 +3,8 @@ package transaction
 
 import (
 	"fmt"
+	"intmax2-node/internal/keystore"
 	txDepositListIncoming "intmax2-node/internal/use_cases/tx_deposits_list_incoming"
-	"intmax2-node/pkg/utils"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -71,6 +71,9 @@ func txDepositListIncomingCmd(b *Transaction) *cobra.Command {
 	var userEthPrivateKey string
 	cmd.PersistentFlags().StringVar(&userEthPrivateKey, userPrivateKeyKey, emptyKey, userPrivateDesc)
 
+	var account string
+	cmd.PersistentFlags().StringVar(&account, keystore.AccountFlagKey, emptyKey, keystore.AccountFlagDescription)
+
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		resp, err := newCommands().ReceiverDepositsListIncoming(
 			b.Config, b.Log, b.SB,
@@ -90,7 +93,7 @@ func txDepositListIncomingCmd(b *Transaction) *cobra.Command {
 					Condition: filterCondition,
 					Value:     filterValue,
 				},
-			}, utils.RemoveZeroX(userEthPrivateKey),
+			}, userPrivateKeyHex(b, account, userEthPrivateKey),
 		)
 		if err != nil {
 			const msg = "Fatal: %v\n"
@@ -2,7 +2,7 @@ package transaction
 
 import (
 	"fmt"
-	"intmax2-node/pkg/utils"
+	"intmax2-node/internal/keystore"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -34,6 +34,9 @@ func txDepositTokenCmd(b *Transaction, token string) *cobra.Command {
 	var userEthPrivateKey string
 	cmd.PersistentFlags().StringVar(&userEthPrivateKey, userPrivateKeyKey, emptyKey, userPrivateDescription)
 
+	var account string
+	cmd.PersistentFlags().StringVar(&account, keystore.AccountFlagKey, emptyKey, keystore.AccountFlagDescription)
+
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		err := b.SB.SetupEthereumNetworkChainID(b.Context)
 		if err != nil {
@@ -49,7 +52,7 @@ func txDepositTokenCmd(b *Transaction, token string) *cobra.Command {
 			append([]string{token}, args...),
 			recipientAddressStr,
 			amount,
-			utils.RemoveZeroX(userEthPrivateKey),
+			userPrivateKeyHex(b, account, userEthPrivateKey),
 		)
 		if err != nil {
 			const msg = "Fatal: %v\n"
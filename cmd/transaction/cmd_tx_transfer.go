@@ -26,6 +26,7 @@ func txTransferCmd(b *Transaction) *cobra.Command {
 	transferCmd.AddCommand(txTransferTokenCmd(b, erc20TokenType))
 	transferCmd.AddCommand(txTransferTokenCmd(b, erc721TokenType))
 	transferCmd.AddCommand(txTransferTokenCmd(b, erc1155TokenType))
+	transferCmd.AddCommand(txTransferBatchCmd(b))
 
 	return &transferCmd
 }
@@ -0,0 +1,73 @@
+package transaction
+
+import (
+	"fmt"
+	"intmax2-node/internal/keystore"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func txTransferBatchCmd(b *Transaction) *cobra.Command {
+	const (
+		use   = "batch"
+		short = "Send transfer transactions to the recipients of the CSV or JSON file"
+		long  = "Send transfer transactions to the recipients of the CSV or JSON file.\n" +
+			"The CSV file holds the rows of the recipient INTMAX address, the token index and the amount without decimals " +
+			"(the header row is optional), the JSON file holds the array of the objects " +
+			"{\"recipient\": \"0x...\", \"tokenIndex\": 0, \"amount\": \"10\"}.\n" +
+			"The transfers are sent by the transactions of up to 63 transfers each (the 64th is the transfer fee)."
+
+		emptyKey                 = ""
+		fileKey                  = "file"
+		fileDescription          = "specify the path of the CSV or JSON file of the transfers. use as --file \"transfers.csv\""
+		feeTokenIndexKey         = "fee-token-index"
+		defaultFeeTokenIndex     = 0
+		feeTokenIndexDescription = "specify the token index of the transfer fee. use as --fee-token-index 0"
+		userPrivateKeyKey        = "private-key"
+		userPrivateDescription   = "specify user's Ethereum private key. use as --private-key \"0x0000000000000000000000000000000000000000000000000000000000000000\""
+	)
+
+	cmd := cobra.Command{
+		Use:   use,
+		Short: short,
+		Long:  long,
+	}
+
+	var filePath string
+	cmd.PersistentFlags().StringVar(&filePath, fileKey, emptyKey, fileDescription)
+
+	var feeTokenIndex uint32
+	cmd.PersistentFlags().Uint32Var(&feeTokenIndex, feeTokenIndexKey, defaultFeeTokenIndex, feeTokenIndexDescription)
+
+	var userEthPrivateKey string
+	cmd.PersistentFlags().StringVar(&userEthPrivateKey, userPrivateKeyKey, emptyKey, userPrivateDescription)
+
+	var account string
+	cmd.PersistentFlags().StringVar(&account, keystore.AccountFlagKey, emptyKey, keystore.AccountFlagDescription)
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		err := b.SB.SetupEthereumNetworkChainID(b.Context)
+		if err != nil {
+			const msg = "Fatal: %v\n"
+			_, _ = fmt.Fprintf(os.Stderr, msg, err)
+			os.Exit(1)
+		}
+
+		err = newCommands().SendTransferBatchTransaction(
+			b.Config, b.Log, b.SB,
+		).Do(
+			b.Context,
+			filePath,
+			feeTokenIndex,
+			userPrivateKeyHex(b, account, userEthPrivateKey),
+		)
+		if err != nil {
+			const msg = "Fatal: %v\n"
+			_, _ = fmt.Fprintf(os.Stderr, msg, err)
+			os.Exit(1)
+		}
+	}
+
+	return &cmd
+}
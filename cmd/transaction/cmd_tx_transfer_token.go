@@ -2,7 +2,7 @@ package transaction
 
 import (
 	"fmt"
-	"intmax2-node/pkg/utils"
+	"intmax2-node/internal/keystore"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -35,6 +35,9 @@ func txTransferTokenCmd(b *Transaction, token string) *cobra.Command {
 	var userEthPrivateKey string
 	cmd.PersistentFlags().StringVar(&userEthPrivateKey, userPrivateKeyKey, emptyKey, userPrivateDescription)
 
+	var account string
+	cmd.PersistentFlags().StringVar(&account, keystore.AccountFlagKey, emptyKey, keystore.AccountFlagDescription)
+
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		err := b.SB.SetupEthereumNetworkChainID(b.Context)
 		if err != nil {
@@ -50,7 +53,7 @@ func txTransferTokenCmd(b *Transaction, token string) *cobra.Command {
 			append([]string{token}, args...),
 			amount,
 			recipientAddressStr,
-			utils.RemoveZeroX(userEthPrivateKey),
+			userPrivateKeyHex(b, account, userEthPrivateKey),
 		)
 		if err != nil {
 			const msg = "Fatal: %v\n"
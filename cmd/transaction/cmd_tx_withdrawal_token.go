@@ -2,7 +2,7 @@ package transaction
 
 import (
 	"fmt"
-	"intmax2-node/pkg/utils"
+	"intmax2-node/internal/keystore"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -38,6 +38,9 @@ func txWithdrawalTokenCmd(b *Transaction, token string) *cobra.Command {
 	var userEthPrivateKey string
 	cmd.PersistentFlags().StringVar(&userEthPrivateKey, userPrivateKeyKey, emptyKey, userPrivateDescription)
 
+	var account string
+	cmd.PersistentFlags().StringVar(&account, keystore.AccountFlagKey, emptyKey, keystore.AccountFlagDescription)
+
 	var resume bool
 	cmd.PersistentFlags().BoolVar(&resume, resumeKey, defaultResume, resumeDescription)
 
@@ -56,7 +59,7 @@ func txWithdrawalTokenCmd(b *Transaction, token string) *cobra.Command {
 			append([]string{token}, args...),
 			recipientAddressStr,
 			amount,
-			utils.RemoveZeroX(userEthPrivateKey),
+			userPrivateKeyHex(b, account, userEthPrivateKey),
 			resume,
 		)
 		if err != nil {
@@ -2,7 +2,7 @@ package transaction
 
 import (
 	"fmt"
-	"intmax2-node/pkg/utils"
+	"intmax2-node/internal/keystore"
 	"os"
 	"strings"
 
@@ -27,6 +27,9 @@ func txWithdrawalTransferInfoByHashCmd(b *Transaction) *cobra.Command {
 	var userEthPrivateKey string
 	cmd.PersistentFlags().StringVar(&userEthPrivateKey, userPrivateKeyKey, emptyKey, userPrivateDescription)
 
+	var account string
+	cmd.PersistentFlags().StringVar(&account, keystore.AccountFlagKey, emptyKey, keystore.AccountFlagDescription)
+
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		if len(args) != 1 {
 			const msg = "Fatal: hash must setup as argument #1\n"
@@ -37,7 +40,7 @@ func txWithdrawalTransferInfoByHashCmd(b *Transaction) *cobra.Command {
 		resp, err := newCommands().RecipientWithdrawalTransferByHash(
 			b.Config, b.Log, b.SB,
 		).Do(
-			b.Context, args, args[0], utils.RemoveZeroX(userEthPrivateKey),
+			b.Context, args, args[0], userPrivateKeyHex(b, account, userEthPrivateKey),
 		)
 		if err != nil {
 			if strings.Contains(err.Error(), "not found") {
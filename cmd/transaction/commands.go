@@ -10,6 +10,7 @@ import (
 	txTransactionByHash "intmax2-node/internal/use_cases/tx_transaction_by_hash"
 	txTransactionsList "intmax2-node/internal/use_cases/tx_transactions_list"
 	txTransfer "intmax2-node/internal/use_cases/tx_transfer"
+	txTransferBatch "intmax2-node/internal/use_cases/tx_transfer_batch"
 	txWithdrawal "intmax2-node/internal/use_cases/tx_withdrawal"
 	txWithdrawalTransferByHash "intmax2-node/internal/use_cases/tx_withdrawal_transfer_by_hash"
 	txWithdrawalTransfersList "intmax2-node/internal/use_cases/tx_withdrawal_transfers_list"
@@ -20,6 +21,7 @@ import (
 	ucTxTransactionByHash "intmax2-node/pkg/use_cases/tx_transaction_by_hash"
 	ucTxTransactionsList "intmax2-node/pkg/use_cases/tx_transactions_list"
 	ucTxTransfer "intmax2-node/pkg/use_cases/tx_transfer"
+	ucTxTransferBatch "intmax2-node/pkg/use_cases/tx_transfer_batch"
 	ucTxWithdrawal "intmax2-node/pkg/use_cases/tx_withdrawal"
 	ucTxWithdrawalTransferByHash "intmax2-node/pkg/use_cases/tx_withdrawal_transfer_by_hash"
 	ucTxWithdrawalTransfersList "intmax2-node/pkg/use_cases/tx_withdrawal_transfers_list"
@@ -31,6 +33,11 @@ type Commands interface {
 		log logger.Logger,
 		sb ServiceBlockchain,
 	) txTransfer.UseCaseTxTransfer
+	SendTransferBatchTransaction(
+		cfg *configs.Config,
+		log logger.Logger,
+		sb ServiceBlockchain,
+	) txTransferBatch.UseCaseTxTransferBatch
 	SenderTransactionsList(
 		cfg *configs.Config,
 		log logger.Logger,
@@ -92,6 +99,14 @@ func (c *commands) SendTransferTransaction(
 	return ucTxTransfer.New(cfg, log, sb)
 }
 
+func (c *commands) SendTransferBatchTransaction(
+	cfg *configs.Config,
+	log logger.Logger,
+	sb ServiceBlockchain,
+) txTransferBatch.UseCaseTxTransferBatch {
+	return ucTxTransferBatch.New(cfg, log, sb)
+}
+
 func (c *commands) SenderTransactionsList(
 	cfg *configs.Config,
 	log logger.Logger,
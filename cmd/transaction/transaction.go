@@ -2,8 +2,11 @@ package transaction
 
 import (
 	"context"
+	"fmt"
 	"intmax2-node/configs"
+	"intmax2-node/internal/keystore"
 	"intmax2-node/internal/logger"
+	"os"
 
 	"github.com/spf13/cobra"
 )
@@ -32,3 +35,15 @@ func NewTransactionCmd(b *Transaction) *cobra.Command {
 
 	return transactionCmd
 }
+
+// userPrivateKeyHex returns the user's Ethereum private key of the --account or --private-key flags.
+func userPrivateKeyHex(b *Transaction, account, userEthPrivateKey string) string {
+	pk, err := keystore.PrivateKeyHex(b.Config, account, userEthPrivateKey)
+	if err != nil {
+		const msg = "Fatal: %v\n"
+		_, _ = fmt.Fprintf(os.Stderr, msg, err)
+		os.Exit(1)
+	}
+
+	return pk
+}
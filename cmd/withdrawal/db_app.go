@@ -3,12 +3,16 @@ package withdrawal
 import (
 	"context"
 	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+
+	"github.com/dimiro1/health"
 )
 
 //go:generate mockgen -destination=mock_db_app.go -package=withdrawal -source=db_app.go
 
 type SQLDriverApp interface {
 	GenericCommandsApp
+	ServiceCommands
+	CtrlProcessingJobs
 	Withdrawals
 	EventBlockNumbers
 }
@@ -17,6 +21,15 @@ type GenericCommandsApp interface {
 	Exec(ctx context.Context, input interface{}, executor func(d interface{}, input interface{}) error) (err error)
 }
 
+type ServiceCommands interface {
+	Check(ctx context.Context) health.Health
+}
+
+type CtrlProcessingJobs interface {
+	CreateCtrlProcessingJobs(name string) error
+	CtrlProcessingJobs(name string) (*mDBApp.CtrlProcessingJobs, error)
+}
+
 type Withdrawals interface {
 	CreateWithdrawal(
 		id string,
@@ -30,6 +43,8 @@ type Withdrawals interface {
 		enoughBalanceProof *mDBApp.EnoughBalanceProof,
 	) (*mDBApp.Withdrawal, error)
 	UpdateWithdrawalsStatus(ids []string, status mDBApp.WithdrawalStatus) error
+	UpdateWithdrawalsProverJob(ids []string, status mDBApp.WithdrawalStatus, proverJobID string) error
+	UpdateWithdrawalProof(id string, withdrawalProof *mDBApp.WithdrawalProof) error
 	WithdrawalByID(id string) (*mDBApp.Withdrawal, error)
 	WithdrawalsByHashes(transferHashes []string) (*[]mDBApp.Withdrawal, error)
 	WithdrawalsByStatus(status mDBApp.WithdrawalStatus, limit *int) (*[]mDBApp.Withdrawal, error)
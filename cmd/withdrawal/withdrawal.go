@@ -3,9 +3,13 @@ package withdrawal
 import (
 	"context"
 	"intmax2-node/configs"
+	"intmax2-node/internal/daemon"
 	"intmax2-node/internal/logger"
+	service "intmax2-node/internal/withdrawal_service"
+	ucHealthCheck "intmax2-node/pkg/use_cases/health_check"
 	"intmax2-node/pkg/utils"
 
+	"github.com/dimiro1/health"
 	"github.com/spf13/cobra"
 )
 
@@ -15,6 +19,7 @@ type Withdrawal struct {
 	Log     logger.Logger
 	DbApp   SQLDriverApp
 	SB      ServiceBlockchain
+	HC      *health.Handler
 }
 
 func NewWithdrawCmd(w *Withdrawal) *cobra.Command {
@@ -43,6 +48,14 @@ func aggregatorCmd(w *Withdrawal) *cobra.Command {
 		Short: short,
 	}
 
+	const (
+		daemonKey         = "daemon"
+		daemonDescription = "daemon flag. use as --daemon to aggregate withdrawals every DAEMON_WITHDRAWAL_AGGREGATOR_INTERVAL until stopped"
+	)
+
+	var daemonMode bool
+	cmd.PersistentFlags().BoolVar(&daemonMode, daemonKey, false, daemonDescription)
+
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		l := w.Log.WithFields(logger.Fields{"module": use})
 
@@ -51,6 +64,26 @@ func aggregatorCmd(w *Withdrawal) *cobra.Command {
 			const msg = "check withdrawal private key error occurred: %v"
 			l.Fatalf(msg, err.Error())
 		}
+
+		if daemonMode {
+			const sqlDBApp = "sql-db-app"
+
+			dm := daemon.New(w.Config, l, w.DbApp, service.WithdrawalAggregatorJob, func(ctx context.Context) error {
+				return newCommands().WithdrawalAggregator(ctx, w.Config, l, w.DbApp, w.SB).Do(ctx)
+			})
+			w.HC.AddChecker(sqlDBApp, w.DbApp)
+			w.HC.AddChecker(service.WithdrawalAggregatorJob, dm)
+
+			err = daemon.Run(
+				w.Context, l, w.Config.Daemon.HealthCheckAddr, ucHealthCheck.New(w.HC),
+				dm, w.Config.Daemon.WithdrawalAggregatorInterval,
+			)
+			if err != nil {
+				const msg = "failed to run withdrawal aggregator daemon: %v"
+				l.Fatalf(msg, err.Error())
+			}
+			return
+		}
 		err = w.DbApp.Exec(w.Context, nil, func(db interface{}, _ interface{}) (err error) {
 			q := db.(SQLDriverApp)
 			return newCommands().WithdrawalAggregator(w.Context, w.Config, l, q, w.SB).Do(w.Context)
@@ -37,6 +37,8 @@ type Withdrawals interface {
 		enoughBalanceProof *mDBApp.EnoughBalanceProof,
 	) (*mDBApp.Withdrawal, error)
 	UpdateWithdrawalsStatus(ids []string, status mDBApp.WithdrawalStatus) error
+	UpdateWithdrawalsProverJob(ids []string, status mDBApp.WithdrawalStatus, proverJobID string) error
+	UpdateWithdrawalProof(id string, withdrawalProof *mDBApp.WithdrawalProof) error
 	WithdrawalByID(id string) (*mDBApp.Withdrawal, error)
 	WithdrawalsByHashes(transferHashes []string) (*[]mDBApp.Withdrawal, error)
 	WithdrawalsByStatus(status mDBApp.WithdrawalStatus, limit *int) (*[]mDBApp.Withdrawal, error)
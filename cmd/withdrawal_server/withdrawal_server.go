@@ -5,12 +5,14 @@ import (
 	"intmax2-node/configs"
 	"intmax2-node/configs/buildvars"
 	"intmax2-node/docs/swagger"
+	"intmax2-node/internal/balance_validity_prover"
 	"intmax2-node/internal/logger"
 	"intmax2-node/internal/pb/gateway"
 	"intmax2-node/internal/pb/gateway/consts"
 	"intmax2-node/internal/pb/gateway/http_response_modifier"
 	node "intmax2-node/internal/pb/gen/withdrawal_service/node"
 	"intmax2-node/internal/pb/listener"
+	"intmax2-node/internal/withdrawal_service"
 	server "intmax2-node/pkg/grpc_server/withdrawal_server"
 	"intmax2-node/third_party"
 	"sync"
@@ -68,8 +70,20 @@ func (s *WithdrawalServer) Init() error {
 		})
 	}
 
+	// The withdrawal requests can not be verified without the balance circuit and the balance validity prover.
+	err := withdrawal_service.CheckBalanceCircuitDigest(s.Config.Withdrawal.BalanceCircuitDigest)
+	if err != nil {
+		return err
+	}
+
+	var bvp balance_validity_prover.BalanceValidityProver
+	bvp, err = balance_validity_prover.New(s.Config, s.Log)
+	if err != nil {
+		return err
+	}
+
 	srv := server.New(
-		s.Log, s.Config, s.DbApp, server.NewCommands(), s.Config.HTTP.CookieForAuthUse, s.HC,
+		s.Log, s.Config, s.DbApp, server.NewCommands(), s.Config.HTTP.CookieForAuthUse, s.HC, bvp,
 	)
 	ctx := context.WithValue(s.Context, consts.AppConfigs, s.Config)
 
@@ -143,7 +157,6 @@ func (s *WithdrawalServer) Init() error {
 	s.Log.Infof(start, appName, buildvars.Version, buildvars.BuildTime)
 	defer s.Log.Infof(finish, appName)
 
-	var err error
 	select {
 	case <-s.Context.Done():
 	case err = <-grpcErr:
@@ -0,0 +1,18 @@
+package configs
+
+import "time"
+
+type AML struct {
+	Provider         string            `env:"AML_PROVIDER" envDefault:"static_list"`
+	DefaultThreshold uint32            `env:"AML_DEFAULT_THRESHOLD" envDefault:"70"`
+	TokenThresholds  map[uint32]uint32 `env:"AML_TOKEN_THRESHOLDS"`
+
+	StaticListFile         string `env:"AML_STATIC_LIST_FILE"`
+	StaticListDefaultScore uint32 `env:"AML_STATIC_LIST_DEFAULT_SCORE" envDefault:"50"`
+
+	HTTPUrl           string        `env:"AML_HTTP_URL"`
+	HTTPApiKey        string        `env:"AML_HTTP_API_KEY"`
+	HTTPTimeout       time.Duration `env:"AML_HTTP_TIMEOUT" envDefault:"10s"`
+	HTTPRetryCount    int           `env:"AML_HTTP_RETRY_COUNT" envDefault:"3"`
+	HTTPRetryWaitTime time.Duration `env:"AML_HTTP_RETRY_WAIT_TIME" envDefault:"1s"`
+}
@@ -1,9 +1,18 @@
 package configs
 
+import "time"
+
 type Api struct {
-	WithdrawalProverUrl string `env:"API_WITHDRAWAL_PROVER_URL"`
-	ScrollBridgeUrl     string `env:"API_SCROLL_BRIDGE_URL"`
-	BlockBuilderUrl     string `env:"API_BLOCK_BUILDER_URL" envDefault:"http://0.0.0.0"`
-	DataStoreVaultUrl   string `env:"API_DATA_STORE_VAULT_URL" envDefault:"http://0.0.0.0"`
-	WithdrawalServerUrl string `env:"API_WITHDRAWAL_SERVER_URL" envDefault:"http://0.0.0.0"`
+	WithdrawalProverUrl      string `env:"API_WITHDRAWAL_PROVER_URL"`
+	BalanceValidityProverUrl string `env:"API_BALANCE_VALIDITY_PROVER_URL"`
+	ScrollBridgeUrl          string `env:"API_SCROLL_BRIDGE_URL"`
+	BlockBuilderUrl          string `env:"API_BLOCK_BUILDER_URL" envDefault:"http://0.0.0.0"`
+	BlockBuilderDiscovery    bool   `env:"API_BLOCK_BUILDER_DISCOVERY" envDefault:"false"`
+	// BlockBuilderDiscoveryCache is the file of the block builders found in the registry and the last scanned block.
+	BlockBuilderDiscoveryCache string `env:"API_BLOCK_BUILDER_DISCOVERY_CACHE" envDefault:"${HOME}/.intmax2/block_builders.json" envExpand:"true"`
+	// BlockBuilderDiscoveryBlocks is the maximum number of the latest blocks of the registry scanned at once.
+	BlockBuilderDiscoveryBlocks uint64        `env:"API_BLOCK_BUILDER_DISCOVERY_BLOCKS" envDefault:"200000"`
+	BlockBuilderTimeout         time.Duration `env:"API_BLOCK_BUILDER_TIMEOUT" envDefault:"30s"`
+	DataStoreVaultUrl           string        `env:"API_DATA_STORE_VAULT_URL" envDefault:"http://0.0.0.0"`
+	WithdrawalServerUrl         string        `env:"API_WITHDRAWAL_SERVER_URL" envDefault:"http://0.0.0.0"`
 }
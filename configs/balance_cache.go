@@ -0,0 +1,7 @@
+package configs
+
+// BalanceCache describes the local encrypted cache of the decrypted backups of the CLI wallets.
+type BalanceCache struct {
+	Dir       string `env:"BALANCE_CACHE_DIR" envDefault:"${HOME}/.intmax2/balance_cache" envExpand:"true"`
+	SyncLimit uint64 `env:"BALANCE_CACHE_SYNC_LIMIT" envDefault:"100"`
+}
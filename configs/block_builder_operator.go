@@ -0,0 +1,7 @@
+package configs
+
+// BlockBuilderOperator describes the access of the operator to the internal endpoints of the block builder.
+// The internal endpoints are disabled, unless the token is set.
+type BlockBuilderOperator struct {
+	Token string `env:"BLOCK_BUILDER_OPERATOR_TOKEN"`
+}
@@ -0,0 +1,7 @@
+package configs
+
+import "time"
+
+type BlockBuilderRegistry struct {
+	TimeoutForEventWatcher time.Duration `env:"BLOCK_BUILDER_REGISTRY_EVENT_WATCHER_LIFETIME" envDefault:"1m"`
+}
@@ -16,10 +16,11 @@ type Blockchain struct {
 	EthereumNetworkChainID string `env:"BLOCKCHAIN_ETHEREUM_NETWORK_CHAIN_ID"`
 	EthereumNetworkRpcUrl  string `env:"BLOCKCHAIN_ETHEREUM_NETWORK_RPC_URL"`
 
-	BlockBuilderRegistryContractAddress  string `env:"BLOCKCHAIN_BLOCK_BUILDER_REGISTRY_CONTRACT_ADDRESS"`
-	LiquidityContractAddress             string `env:"BLOCKCHAIN_LIQUIDITY_CONTRACT_ADDRESS"`
-	LiquidityContractDeployedBlockNumber uint64 `env:"BLOCKCHAIN_LIQUIDITY_CONTRACT_DEPLOYED_BLOCK_NUMBER" envDefault:"0"`
-	WithdrawalContractAddress            string `env:"BLOCKCHAIN_WITHDRAWAL_CONTRACT_ADDRESS"`
+	BlockBuilderRegistryContractAddress             string `env:"BLOCKCHAIN_BLOCK_BUILDER_REGISTRY_CONTRACT_ADDRESS"`
+	BlockBuilderRegistryContractDeployedBlockNumber uint64 `env:"BLOCKCHAIN_BLOCK_BUILDER_REGISTRY_CONTRACT_DEPLOYED_BLOCK_NUMBER" envDefault:"0"`
+	LiquidityContractAddress                        string `env:"BLOCKCHAIN_LIQUIDITY_CONTRACT_ADDRESS"`
+	LiquidityContractDeployedBlockNumber            uint64 `env:"BLOCKCHAIN_LIQUIDITY_CONTRACT_DEPLOYED_BLOCK_NUMBER" envDefault:"0"`
+	WithdrawalContractAddress                       string `env:"BLOCKCHAIN_WITHDRAWAL_CONTRACT_ADDRESS"`
 
 	MaxCounterOfTransaction int `env:"BLOCKCHAIN_MAX_COUNTER_OF_TRANSACTION" envDefault:"128"`
 
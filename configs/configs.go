@@ -17,24 +17,35 @@ const (
 )
 
 type Config struct {
-	APP                 APP
-	API                 Api
-	GRPC                GRPC
-	HTTP                HTTP
-	LOG                 LOG
-	Wallet              Wallet
-	PoW                 PoW
-	Worker              Worker
-	DepositSynchronizer DepositSynchronizer
-	BlockPostService    BlockPostService
-	BlockValidityProver BlockValidityProver
-	GasPriceOracle      GasPriceOracle
-	Blockchain          Blockchain
-	Network             Network
-	StunServer          StunServer
-	Swagger             Swagger
-	OpenTelemetry       OpenTelemetry
-	SQLDb               SQLDb
+	APP                  APP
+	API                  Api
+	GRPC                 GRPC
+	Throttle             Throttle
+	HTTP                 HTTP
+	LOG                  LOG
+	Wallet               Wallet
+	PoW                  PoW
+	Worker               Worker
+	DepositSynchronizer  DepositSynchronizer
+	BlockPostService     BlockPostService
+	BlockValidityProver  BlockValidityProver
+	BlockBuilderRegistry BlockBuilderRegistry
+	BlockBuilderOperator BlockBuilderOperator
+	LogScanner           LogScanner
+	StoreVaultAuth       StoreVaultAuth
+	StoreVaultBalances   StoreVaultBalances
+	Keystore             Keystore
+	BalanceCache         BalanceCache
+	Withdrawal           Withdrawal
+	AML                  AML
+	Daemon               Daemon
+	GasPriceOracle       GasPriceOracle
+	Blockchain           Blockchain
+	Network              Network
+	StunServer           StunServer
+	Swagger              Swagger
+	OpenTelemetry        OpenTelemetry
+	SQLDb                SQLDb
 }
 
 var once sync.Once
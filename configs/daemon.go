@@ -0,0 +1,12 @@
+package configs
+
+import "time"
+
+type Daemon struct {
+	DepositAnalyzerInterval      time.Duration `env:"DAEMON_DEPOSIT_ANALYZER_INTERVAL" envDefault:"1m"`
+	WithdrawalAggregatorInterval time.Duration `env:"DAEMON_WITHDRAWAL_AGGREGATOR_INTERVAL" envDefault:"1m"`
+	MinBackoff                   time.Duration `env:"DAEMON_MIN_BACKOFF" envDefault:"10s"`
+	MaxBackoff                   time.Duration `env:"DAEMON_MAX_BACKOFF" envDefault:"10m"`
+	MaxFailures                  int           `env:"DAEMON_MAX_FAILURES" envDefault:"5"`
+	HealthCheckAddr              string        `env:"DAEMON_HEALTH_CHECK_ADDR" envDefault:"0.0.0.0:8081"`
+}
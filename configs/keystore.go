@@ -0,0 +1,8 @@
+package configs
+
+// Keystore describes the encrypted keys of the CLI wallets.
+type Keystore struct {
+	Dir         string `env:"KEYSTORE_DIR" envDefault:"${HOME}/.intmax2/keystore" envExpand:"true"`
+	Password    string `env:"KEYSTORE_PASSWORD"`
+	LightScrypt bool   `env:"KEYSTORE_LIGHT_SCRYPT" envDefault:"false"`
+}
@@ -0,0 +1,8 @@
+package configs
+
+type LogScanner struct {
+	MaxBlockRange         uint64 `env:"LOG_SCANNER_MAX_BLOCK_RANGE" envDefault:"1000"`
+	EthereumConfirmations uint64 `env:"LOG_SCANNER_ETHEREUM_CONFIRMATIONS" envDefault:"12"`
+	ScrollConfirmations   uint64 `env:"LOG_SCANNER_SCROLL_CONFIRMATIONS" envDefault:"5"`
+	MaxReorgDepth         uint64 `env:"LOG_SCANNER_MAX_REORG_DEPTH" envDefault:"64"`
+}
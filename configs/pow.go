@@ -1,6 +1,12 @@
 package configs
 
+import "time"
+
 type PoW struct {
-	Difficulty uint64 `env:"POW_DIFFICULTY" envDefault:"4000"`
-	Workers    int    `env:"POW_WORKERS" envDefault:"2"`
+	Difficulty         uint64        `env:"POW_DIFFICULTY" envDefault:"4000"`
+	Workers            int           `env:"POW_WORKERS" envDefault:"2"`
+	MaxDifficulty      uint64        `env:"POW_MAX_DIFFICULTY" envDefault:"16000"`
+	DifficultyFullLoad int           `env:"POW_DIFFICULTY_FULL_LOAD" envDefault:"1024"`
+	DifficultyPeriod   time.Duration `env:"POW_DIFFICULTY_PERIOD" envDefault:"30s"`
+	DifficultyValidity time.Duration `env:"POW_DIFFICULTY_VALIDITY" envDefault:"2m"`
 }
@@ -0,0 +1,13 @@
+package configs
+
+import "time"
+
+// StoreVaultAuth describes the sessions of the owners of the addresses reading the store vault.
+// The secret is required, unless the dev mode replaces the empty secret by the random one,
+// so the sessions do not survive the restart.
+type StoreVaultAuth struct {
+	Secret       string        `env:"STORE_VAULT_AUTH_SECRET"`
+	DevMode      bool          `env:"STORE_VAULT_AUTH_DEV_MODE" envDefault:"false"`
+	ChallengeTTL time.Duration `env:"STORE_VAULT_AUTH_CHALLENGE_TTL" envDefault:"5m"`
+	SessionTTL   time.Duration `env:"STORE_VAULT_AUTH_SESSION_TTL" envDefault:"1h"`
+}
@@ -0,0 +1,11 @@
+package configs
+
+import "time"
+
+// StoreVaultBalances describes the pages of the backups returned by the store vault.
+type StoreVaultBalances struct {
+	PageLimit uint64 `env:"STORE_VAULT_BALANCES_PAGE_LIMIT" envDefault:"500"`
+	// CommitLag is the time, within which the backup is expected to be committed after it is created.
+	// The backups created within the lag are not returned by the sequence number yet.
+	CommitLag time.Duration `env:"STORE_VAULT_BALANCES_COMMIT_LAG" envDefault:"30s"`
+}
@@ -0,0 +1,19 @@
+package configs
+
+import "time"
+
+// Throttle describes the admission control of the gRPC server.
+// The zero value turns off the limit.
+type Throttle struct {
+	MaxConcurrentRequests int           `env:"THROTTLE_MAX_CONCURRENT_REQUESTS" envDefault:"10"`
+	MaxConcurrentStreams  int           `env:"THROTTLE_MAX_CONCURRENT_STREAMS" envDefault:"1000"`
+	SenderRate            float64       `env:"THROTTLE_SENDER_RATE" envDefault:"1"`
+	SenderBurst           int           `env:"THROTTLE_SENDER_BURST" envDefault:"10"`
+	IPRate                float64       `env:"THROTTLE_IP_RATE" envDefault:"5"`
+	IPBurst               int           `env:"THROTTLE_IP_BURST" envDefault:"50"`
+	PoWMaxMultiplier      uint64        `env:"THROTTLE_POW_MAX_MULTIPLIER" envDefault:"4"`
+	BucketsLifetime       time.Duration `env:"THROTTLE_BUCKETS_LIFETIME" envDefault:"10m"`
+	// TrustedProxies are the networks of the proxies (the gateway, the load balancers, etc.)
+	// whose x-forwarded-for entries are used for the ip-address of the client.
+	TrustedProxies []string `env:"THROTTLE_TRUSTED_PROXIES" envSeparator:";" envDefault:"127.0.0.1/32;::1/128"`
+}
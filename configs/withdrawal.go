@@ -0,0 +1,13 @@
+package configs
+
+import "time"
+
+type Withdrawal struct {
+	BalanceCircuitDigest string `env:"WITHDRAWAL_BALANCE_CIRCUIT_DIGEST"`
+
+	ProverRequestTimeout  time.Duration `env:"WITHDRAWAL_PROVER_REQUEST_TIMEOUT" envDefault:"1m"`
+	ProverRetryCount      int           `env:"WITHDRAWAL_PROVER_RETRY_COUNT" envDefault:"3"`
+	ProverRetryWaitTime   time.Duration `env:"WITHDRAWAL_PROVER_RETRY_WAIT_TIME" envDefault:"1s"`
+	ProverPollingInterval time.Duration `env:"WITHDRAWAL_PROVER_POLLING_INTERVAL" envDefault:"5s"`
+	ProverTimeout         time.Duration `env:"WITHDRAWAL_PROVER_TIMEOUT" envDefault:"30m"`
+}
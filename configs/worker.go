@@ -11,4 +11,7 @@ type Worker struct {
 	TimeoutForCheckCurrentFile         time.Duration `env:"WORKER_TIMEOUT_FOR_CHECK_CURRENT_FILE" envDefault:"10s"`
 	TimeoutForSignaturesAvailableFiles time.Duration `env:"WORKER_TIMEOUT_FOR_SIGNATURES_AVAILABLE_FILES" envDefault:"15s"`
 	MaxCounterOfUsers                  int           `env:"WORKER_MAX_COUNTER_OF_USERS" envDefault:"128"`
+	EventsBufferSize                   int           `env:"WORKER_EVENTS_BUFFER_SIZE" envDefault:"256"`
+	BlockStatusPollInterval            time.Duration `env:"WORKER_BLOCK_STATUS_POLL_INTERVAL" envDefault:"5s"`
+	BlockStatusSubscriptionTimeout     time.Duration `env:"WORKER_BLOCK_STATUS_SUBSCRIPTION_TIMEOUT" envDefault:"10m"`
 }
@@ -0,0 +1,77 @@
+package aml
+
+import (
+	"context"
+	"fmt"
+	"intmax2-node/configs"
+	"intmax2-node/internal/logger"
+)
+
+const (
+	StaticListProvider = "static_list"
+	HTTPProvider       = "http"
+)
+
+const (
+	MinScore uint32 = 0
+	MaxScore uint32 = 100
+)
+
+// NewProvider returns the AML provider selected by the configuration.
+func NewProvider(cfg *configs.Config, log logger.Logger) (Provider, error) {
+	switch cfg.AML.Provider {
+	case StaticListProvider:
+		return NewStaticList(cfg)
+	case HTTPProvider:
+		return NewHTTP(cfg, log)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrProviderUnknown, cfg.AML.Provider)
+	}
+}
+
+type screener struct {
+	cfg      *configs.Config
+	log      logger.Logger
+	provider Provider
+}
+
+func NewScreener(cfg *configs.Config, log logger.Logger, provider Provider) Screener {
+	return &screener{
+		cfg:      cfg,
+		log:      log,
+		provider: provider,
+	}
+}
+
+// Screen rejects the deposit if the score of its sender exceeds the threshold of the deposited token.
+func (s *screener) Screen(ctx context.Context, deposit *Deposit) (*Screening, error) {
+	score, err := s.provider.Score(ctx, deposit.Sender, deposit.TokenAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	threshold := s.threshold(deposit.TokenIndex)
+	screening := Screening{
+		Deposit:   *deposit,
+		Provider:  s.provider.Name(),
+		Score:     score.Value,
+		Threshold: threshold,
+		Rejected:  score.Value > threshold,
+		Reason:    score.Reason,
+	}
+
+	if screening.Rejected && screening.Reason == "" {
+		screening.Reason = fmt.Sprintf("the score %d exceeds the threshold %d", score.Value, threshold)
+	}
+
+	return &screening, nil
+}
+
+// threshold returns the threshold of the token, or the default threshold if the token has no own threshold.
+func (s *screener) threshold(tokenIndex uint32) uint32 {
+	if threshold, ok := s.cfg.AML.TokenThresholds[tokenIndex]; ok {
+		return threshold
+	}
+
+	return s.cfg.AML.DefaultThreshold
+}
@@ -0,0 +1,219 @@
+package aml_test
+
+import (
+	"context"
+	"encoding/json"
+	"intmax2-node/configs"
+	"intmax2-node/internal/aml"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	allowed  = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	denied   = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	unlisted = common.HexToAddress("0x3333333333333333333333333333333333333333")
+	token    = common.HexToAddress("0x4444444444444444444444444444444444444444")
+)
+
+func writeStaticList(t *testing.T, list interface{}) string {
+	data, err := json.Marshal(list)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "aml.json")
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	return path
+}
+
+func TestStaticList(t *testing.T) {
+	ctx := context.Background()
+
+	cfg := configs.Config{}
+	cfg.AML.StaticListDefaultScore = 50
+	cfg.AML.StaticListFile = writeStaticList(t, aml.StaticListFile{
+		Allow: []string{allowed.Hex(), denied.Hex()},
+		Deny:  []string{denied.Hex()},
+	})
+
+	provider, err := aml.NewStaticList(&cfg)
+	require.NoError(t, err)
+	assert.Equal(t, aml.StaticListProvider, provider.Name())
+
+	cases := []struct {
+		desc   string
+		sender common.Address
+		score  uint32
+	}{
+		{desc: "Sender is in the allow list", sender: allowed, score: aml.MinScore},
+		{desc: "Sender is in the deny list and the allow list", sender: denied, score: aml.MaxScore},
+		{desc: "Sender is not listed", sender: unlisted, score: 50},
+	}
+
+	for i := range cases {
+		t.Run(cases[i].desc, func(t *testing.T) {
+			score, err := provider.Score(ctx, cases[i].sender, token)
+			require.NoError(t, err)
+			assert.Equal(t, cases[i].score, score.Value)
+		})
+	}
+
+	t.Run("Static list is invalid", func(t *testing.T) {
+		invalid := cfg
+		invalid.AML.StaticListFile = writeStaticList(t, aml.StaticListFile{Deny: []string{"0x12"}})
+
+		_, err := aml.NewStaticList(&invalid)
+		assert.ErrorIs(t, err, aml.ErrStaticListInvalid)
+	})
+
+	t.Run("Static list file is missing", func(t *testing.T) {
+		missing := cfg
+		missing.AML.StaticListFile = filepath.Join(t.TempDir(), "missing.json")
+
+		_, err := aml.NewStaticList(&missing)
+		assert.ErrorIs(t, err, aml.ErrStaticListReadFail)
+	})
+}
+
+func TestScreener(t *testing.T) {
+	ctx := context.Background()
+
+	cfg := configs.Config{}
+	cfg.AML.Provider = aml.StaticListProvider
+	cfg.AML.DefaultThreshold = 70
+	cfg.AML.TokenThresholds = map[uint32]uint32{1: 40}
+	cfg.AML.StaticListDefaultScore = 50
+	cfg.AML.StaticListFile = writeStaticList(t, aml.StaticListFile{Deny: []string{denied.Hex()}})
+
+	provider, err := aml.NewProvider(&cfg, nil)
+	require.NoError(t, err)
+	screener := aml.NewScreener(&cfg, nil, provider)
+
+	cases := []struct {
+		desc       string
+		deposit    aml.Deposit
+		threshold  uint32
+		rejected   bool
+		withReason bool
+	}{
+		{
+			desc:      "Score is under the default threshold",
+			deposit:   aml.Deposit{DepositID: 1, Sender: unlisted, TokenIndex: 0, TokenAddress: token},
+			threshold: 70,
+		},
+		{
+			desc:       "Score exceeds the threshold of the token",
+			deposit:    aml.Deposit{DepositID: 2, Sender: unlisted, TokenIndex: 1, TokenAddress: token},
+			threshold:  40,
+			rejected:   true,
+			withReason: true,
+		},
+		{
+			desc:       "Sender is denied",
+			deposit:    aml.Deposit{DepositID: 3, Sender: denied, TokenIndex: 0, TokenAddress: token},
+			threshold:  70,
+			rejected:   true,
+			withReason: true,
+		},
+	}
+
+	for i := range cases {
+		t.Run(cases[i].desc, func(t *testing.T) {
+			screening, err := screener.Screen(ctx, &cases[i].deposit)
+			require.NoError(t, err)
+			assert.Equal(t, cases[i].deposit, screening.Deposit)
+			assert.Equal(t, aml.StaticListProvider, screening.Provider)
+			assert.Equal(t, cases[i].threshold, screening.Threshold)
+			assert.Equal(t, cases[i].rejected, screening.Rejected)
+			assert.Equal(t, cases[i].withReason, screening.Reason != "")
+		})
+	}
+
+	t.Run("Provider is unknown", func(t *testing.T) {
+		unknown := cfg
+		unknown.AML.Provider = "unknown"
+
+		_, err := aml.NewProvider(&unknown, nil)
+		assert.ErrorIs(t, err, aml.ErrProviderUnknown)
+	})
+}
+
+func TestHTTP(t *testing.T) {
+	const apiKey = "secret"
+
+	ctx := context.Background()
+
+	var failures int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		if r.Header.Get("X-API-Key") != apiKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var req aml.HTTPScoreRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		res := aml.HTTPScoreResponse{Score: 10}
+		switch common.HexToAddress(req.Address) {
+		case denied:
+			res = aml.HTTPScoreResponse{Score: 95, Reason: "sanctioned address"}
+		case unlisted:
+			res = aml.HTTPScoreResponse{Score: 101}
+		}
+		_ = json.NewEncoder(w).Encode(&res)
+	}))
+	defer server.Close()
+
+	cfg := configs.Config{}
+	cfg.AML.Provider = aml.HTTPProvider
+	cfg.AML.HTTPUrl = server.URL
+	cfg.AML.HTTPApiKey = apiKey
+	cfg.AML.HTTPRetryCount = 1
+
+	provider, err := aml.NewProvider(&cfg, nil)
+	require.NoError(t, err)
+	assert.Equal(t, aml.HTTPProvider, provider.Name())
+
+	score, err := provider.Score(ctx, allowed, token)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(10), score.Value)
+
+	failures = 1
+	score, err = provider.Score(ctx, denied, token)
+	require.NoError(t, err)
+	assert.Equal(t, &aml.Score{Value: 95, Reason: "sanctioned address"}, score)
+
+	_, err = provider.Score(ctx, unlisted, token)
+	assert.ErrorIs(t, err, aml.ErrScoreOutOfRange)
+
+	failures = 2
+	_, err = provider.Score(ctx, allowed, token)
+	assert.ErrorIs(t, err, aml.ErrUnexpectedStatusCode)
+
+	unauthorized := cfg
+	unauthorized.AML.HTTPApiKey = ""
+	provider, err = aml.NewHTTP(&unauthorized, nil)
+	require.NoError(t, err)
+	_, err = provider.Score(ctx, allowed, token)
+	assert.ErrorIs(t, err, aml.ErrUnexpectedStatusCode)
+
+	unauthorized.AML.HTTPUrl = ""
+	_, err = aml.NewHTTP(&unauthorized, nil)
+	assert.ErrorIs(t, err, aml.ErrHTTPUrlEmpty)
+}
@@ -0,0 +1,27 @@
+package aml
+
+import "errors"
+
+// ErrProviderUnknown error: the AML provider is unknown.
+var ErrProviderUnknown = errors.New("the AML provider is unknown")
+
+// ErrStaticListReadFail error: failed to read the static list of the AML provider.
+var ErrStaticListReadFail = errors.New("failed to read the static list of the AML provider")
+
+// ErrStaticListInvalid error: the static list of the AML provider is invalid.
+var ErrStaticListInvalid = errors.New("the static list of the AML provider is invalid")
+
+// ErrHTTPUrlEmpty error: the URL of the AML HTTP provider must not be empty.
+var ErrHTTPUrlEmpty = errors.New("the URL of the AML HTTP provider must not be empty")
+
+// ErrSendRequestFail error: failed to send the request to the AML HTTP provider.
+var ErrSendRequestFail = errors.New("failed to send the request to the AML HTTP provider")
+
+// ErrUnexpectedStatusCode error: unexpected status code of the AML HTTP provider.
+var ErrUnexpectedStatusCode = errors.New("unexpected status code of the AML HTTP provider")
+
+// ErrUnmarshalResponseFail error: failed to unmarshal the response of the AML HTTP provider.
+var ErrUnmarshalResponseFail = errors.New("failed to unmarshal the response of the AML HTTP provider")
+
+// ErrScoreOutOfRange error: the AML score is out of range.
+var ErrScoreOutOfRange = errors.New("the AML score is out of range")
@@ -0,0 +1,97 @@
+package aml
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"intmax2-node/configs"
+	"intmax2-node/internal/logger"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-resty/resty/v2"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+// HTTPScoreRequest describes the request of the score to the AML HTTP provider.
+type HTTPScoreRequest struct {
+	Address      string `json:"address"`
+	TokenAddress string `json:"tokenAddress"`
+	ChainID      string `json:"chainId"`
+}
+
+// HTTPScoreResponse describes the score returned by the AML HTTP provider.
+type HTTPScoreResponse struct {
+	Score  uint32 `json:"score"`
+	Reason string `json:"reason"`
+}
+
+type httpProvider struct {
+	cfg    *configs.Config
+	log    logger.Logger
+	client *resty.Client
+}
+
+// NewHTTP returns the provider that requests the score of the sender from the external AML service
+// with POST of HTTPScoreRequest to AML_HTTP_URL.
+func NewHTTP(cfg *configs.Config, log logger.Logger) (Provider, error) {
+	const (
+		contentType = "Content-Type"
+		appJSON     = "application/json"
+	)
+
+	if cfg.AML.HTTPUrl == "" {
+		return nil, ErrHTTPUrlEmpty
+	}
+
+	client := resty.New().
+		SetHeader(contentType, appJSON).
+		SetTimeout(cfg.AML.HTTPTimeout).
+		SetRetryCount(cfg.AML.HTTPRetryCount).
+		SetRetryWaitTime(cfg.AML.HTTPRetryWaitTime).
+		AddRetryCondition(func(resp *resty.Response, err error) bool {
+			return err != nil || resp.StatusCode() >= http.StatusInternalServerError
+		})
+	if cfg.AML.HTTPApiKey != "" {
+		client.SetHeader(apiKeyHeader, cfg.AML.HTTPApiKey)
+	}
+
+	return &httpProvider{
+		cfg:    cfg,
+		log:    log,
+		client: client,
+	}, nil
+}
+
+func (p *httpProvider) Name() string {
+	return HTTPProvider
+}
+
+func (p *httpProvider) Score(ctx context.Context, sender, tokenAddress common.Address) (*Score, error) {
+	resp, err := p.client.R().SetContext(ctx).SetBody(&HTTPScoreRequest{
+		Address:      sender.Hex(),
+		TokenAddress: tokenAddress.Hex(),
+		ChainID:      p.cfg.Blockchain.EthereumNetworkChainID,
+	}).Post(p.cfg.AML.HTTPUrl)
+	if err != nil {
+		return nil, errors.Join(ErrSendRequestFail, err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("%w: %d", ErrUnexpectedStatusCode, resp.StatusCode())
+	}
+
+	var res HTTPScoreResponse
+	err = json.Unmarshal(resp.Body(), &res)
+	if err != nil {
+		return nil, errors.Join(ErrUnmarshalResponseFail, err)
+	}
+
+	if res.Score > MaxScore {
+		return nil, fmt.Errorf("%w: %d", ErrScoreOutOfRange, res.Score)
+	}
+
+	return &Score{Value: res.Score, Reason: res.Reason}, nil
+}
@@ -0,0 +1,44 @@
+package aml
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:generate mockgen -destination=../mocks/mock_aml.go -package=mocks -source=interface.go
+
+// Provider describes the source of the AML risk score of the depositors.
+// The score is between MinScore (no risk) and MaxScore (the highest risk).
+type Provider interface {
+	Name() string
+	Score(ctx context.Context, sender, tokenAddress common.Address) (*Score, error)
+}
+
+// Screener decides whether the deposit is rejected by the score of the provider
+// and the threshold of the deposited token.
+type Screener interface {
+	Screen(ctx context.Context, deposit *Deposit) (*Screening, error)
+}
+
+type Score struct {
+	Value  uint32
+	Reason string
+}
+
+type Deposit struct {
+	DepositID    uint64
+	Sender       common.Address
+	TokenIndex   uint32
+	TokenAddress common.Address
+}
+
+// Screening describes the decision about the deposit, which is recorded for the audit.
+type Screening struct {
+	Deposit
+	Provider  string
+	Score     uint32
+	Threshold uint32
+	Rejected  bool
+	Reason    string
+}
@@ -0,0 +1,94 @@
+package aml
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"intmax2-node/configs"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	reasonDenyList  = "the sender is in the deny list"
+	reasonAllowList = "the sender is in the allow list"
+)
+
+// StaticListFile describes the file of the static list provider, for example:
+//
+//	{"allow": ["0x..."], "deny": ["0x..."]}
+type StaticListFile struct {
+	Allow []string `json:"allow"`
+	Deny  []string `json:"deny"`
+}
+
+type staticList struct {
+	allow        map[common.Address]bool
+	deny         map[common.Address]bool
+	defaultScore uint32
+}
+
+// NewStaticList returns the provider that scores the senders of the deny list with MaxScore,
+// the senders of the allow list with MinScore and the other senders with the default score.
+// Without the file of the static list all the senders are scored with the default score.
+func NewStaticList(cfg *configs.Config) (Provider, error) {
+	if cfg.AML.StaticListDefaultScore > MaxScore {
+		return nil, fmt.Errorf("%w: default score %d", ErrScoreOutOfRange, cfg.AML.StaticListDefaultScore)
+	}
+
+	list := staticList{
+		allow:        make(map[common.Address]bool),
+		deny:         make(map[common.Address]bool),
+		defaultScore: cfg.AML.StaticListDefaultScore,
+	}
+
+	if cfg.AML.StaticListFile == "" {
+		return &list, nil
+	}
+
+	data, err := os.ReadFile(cfg.AML.StaticListFile)
+	if err != nil {
+		return nil, errors.Join(ErrStaticListReadFail, err)
+	}
+
+	var file StaticListFile
+	err = json.Unmarshal(data, &file)
+	if err != nil {
+		return nil, errors.Join(ErrStaticListInvalid, err)
+	}
+
+	for _, address := range file.Allow {
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("%w: address %q", ErrStaticListInvalid, address)
+		}
+		list.allow[common.HexToAddress(address)] = true
+	}
+
+	for _, address := range file.Deny {
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("%w: address %q", ErrStaticListInvalid, address)
+		}
+		list.deny[common.HexToAddress(address)] = true
+	}
+
+	return &list, nil
+}
+
+func (l *staticList) Name() string {
+	return StaticListProvider
+}
+
+// Score takes the deny list before the allow list, so the sender in both lists is denied.
+func (l *staticList) Score(_ context.Context, sender, _ common.Address) (*Score, error) {
+	if l.deny[sender] {
+		return &Score{Value: MaxScore, Reason: reasonDenyList}, nil
+	}
+
+	if l.allow[sender] {
+		return &Score{Value: MinScore, Reason: reasonAllowList}, nil
+	}
+
+	return &Score{Value: l.defaultScore}, nil
+}
@@ -0,0 +1,236 @@
+package balance_cache
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"intmax2-node/configs"
+	intMaxAcc "intmax2-node/internal/accounts"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	dirPerm     = 0o700
+	filePerm    = 0o600
+	openTimeout = time.Second
+	fileExt     = ".db"
+	keyDomain   = "INTMAX balance cache:"
+	keyDelim    = ":"
+	int8Key     = 8
+	firstSeq    = 1
+)
+
+var (
+	// seqsBucket replaces the "cursors" bucket of the block numbers, so the caches
+	// synced by the block numbers are synced again by the sequence numbers.
+	seqsBucket    = []byte("seqs")
+	entriesBucket = []byte("entries")
+)
+
+type balanceCache struct {
+	db   *bolt.DB
+	aead cipher.AEAD
+}
+
+// New opens the balance cache of the INTMAX account in the BALANCE_CACHE_DIR.
+// The entries are encrypted by the key derived from the INTMAX private key.
+func New(cfg *configs.Config, pk *intMaxAcc.PrivateKey) (BalanceCache, error) {
+	key := sha256.Sum256(append([]byte(keyDomain), pk.Marshal()...))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, errors.Join(ErrOpenFail, err)
+	}
+
+	var aead cipher.AEAD
+	aead, err = cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Join(ErrOpenFail, err)
+	}
+
+	err = os.MkdirAll(cfg.BalanceCache.Dir, dirPerm)
+	if err != nil {
+		return nil, errors.Join(ErrCreateDirFail, err)
+	}
+
+	var db *bolt.DB
+	db, err = bolt.Open(
+		filepath.Join(cfg.BalanceCache.Dir, pk.ToAddress().String()+fileExt),
+		filePerm,
+		&bolt.Options{Timeout: openTimeout},
+	)
+	if err != nil {
+		return nil, errors.Join(ErrOpenFail, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{seqsBucket, entriesBucket} {
+			if _, err = tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, errors.Join(ErrOpenFail, err)
+	}
+
+	return &balanceCache{
+		db:   db,
+		aead: aead,
+	}, nil
+}
+
+func (c *balanceCache) NextSeq(kind Kind) (nextSeq uint64, err error) {
+	nextSeq = firstSeq
+	err = c.db.View(func(tx *bolt.Tx) error {
+		if v := tx.Bucket(seqsBucket).Get([]byte(kind)); len(v) == int8Key {
+			nextSeq = binary.BigEndian.Uint64(v)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Join(ErrReadFail, err)
+	}
+
+	return nextSeq, nil
+}
+
+func (c *balanceCache) Store(kind Kind, entries []*Entry, nextSeq uint64) error {
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(entriesBucket)
+		for key := range entries {
+			entries[key].Kind = kind
+
+			k := entryKey(kind, entries[key].ID)
+			v, err := c.encrypt(k, entries[key])
+			if err != nil {
+				return err
+			}
+
+			err = b.Put(k, v)
+			if err != nil {
+				return err
+			}
+		}
+
+		seq := make([]byte, int8Key)
+		binary.BigEndian.PutUint64(seq, nextSeq)
+
+		return tx.Bucket(seqsBucket).Put([]byte(kind), seq)
+	})
+	if err != nil {
+		return errors.Join(ErrWriteFail, err)
+	}
+
+	return nil
+}
+
+func (c *balanceCache) PendingDeposits() ([]*Entry, error) {
+	var list []*Entry
+	err := c.forEach(func(entry *Entry) {
+		if entry.Kind == KindDeposit && entry.Pending {
+			list = append(list, entry)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (c *balanceCache) Balances() (map[uint32]*big.Int, error) {
+	balances := make(map[uint32]*big.Int)
+	err := c.forEach(func(entry *Entry) {
+		if entry.Invalid || entry.Pending {
+			return
+		}
+
+		for key := range entry.Amounts {
+			balance, ok := balances[entry.Amounts[key].TokenIndex]
+			if !ok {
+				balance = new(big.Int)
+				balances[entry.Amounts[key].TokenIndex] = balance
+			}
+			balance.Add(balance, entry.Amounts[key].Amount)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return balances, nil
+}
+
+func (c *balanceCache) Close() error {
+	return c.db.Close()
+}
+
+func (c *balanceCache) forEach(fn func(entry *Entry)) error {
+	err := c.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(entriesBucket).ForEach(func(k, v []byte) error {
+			entry, err := c.decrypt(k, v)
+			if err != nil {
+				return err
+			}
+			fn(entry)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return errors.Join(ErrReadFail, err)
+	}
+
+	return nil
+}
+
+func entryKey(kind Kind, id string) []byte {
+	return []byte(string(kind) + keyDelim + id)
+}
+
+// encrypt returns the nonce and the sealed JSON of the entry bound to its key.
+func (c *balanceCache) encrypt(k []byte, entry *Entry) ([]byte, error) {
+	plaintext, err := json.Marshal(entry)
+	if err != nil {
+		return nil, errors.Join(ErrEncryptFail, err)
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, errors.Join(ErrEncryptFail, err)
+	}
+
+	return c.aead.Seal(nonce, nonce, plaintext, k), nil
+}
+
+func (c *balanceCache) decrypt(k, v []byte) (*Entry, error) {
+	if len(v) < c.aead.NonceSize() {
+		return nil, ErrDecryptFail
+	}
+
+	plaintext, err := c.aead.Open(nil, v[:c.aead.NonceSize()], v[c.aead.NonceSize():], k)
+	if err != nil {
+		return nil, errors.Join(ErrDecryptFail, err)
+	}
+
+	entry := new(Entry)
+	err = json.Unmarshal(plaintext, entry)
+	if err != nil {
+		return nil, errors.Join(ErrDecryptFail, err)
+	}
+
+	return entry, nil
+}
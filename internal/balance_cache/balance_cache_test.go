@@ -0,0 +1,82 @@
+package balance_cache_test
+
+import (
+	"intmax2-node/configs"
+	intMaxAcc "intmax2-node/internal/accounts"
+	"intmax2-node/internal/balance_cache"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalanceCache(t *testing.T) {
+	cfg := &configs.Config{}
+	cfg.BalanceCache.Dir = t.TempDir()
+
+	pk, err := intMaxAcc.NewPrivateKey(big.NewInt(4))
+	require.NoError(t, err)
+
+	cache, err := balance_cache.New(cfg, pk)
+	require.NoError(t, err)
+
+	next, err := cache.NextSeq(balance_cache.KindDeposit)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), next)
+
+	err = cache.Store(balance_cache.KindDeposit, []*balance_cache.Entry{
+		{ID: "1", BlockNumber: 1, Amounts: []*balance_cache.TokenAmount{{TokenIndex: 0, Amount: big.NewInt(100)}}},
+		{ID: "2", BlockNumber: 2, Pending: true, Amounts: []*balance_cache.TokenAmount{{TokenIndex: 0, Amount: big.NewInt(7)}}},
+		{ID: "3", BlockNumber: 3, Invalid: true},
+	}, 4)
+	require.NoError(t, err)
+
+	err = cache.Store(balance_cache.KindTransaction, []*balance_cache.Entry{
+		{ID: "1", BlockNumber: 10, Amounts: []*balance_cache.TokenAmount{
+			{TokenIndex: 0, Amount: big.NewInt(-30)},
+			{TokenIndex: 1, Amount: big.NewInt(-5)},
+		}},
+	}, 11)
+	require.NoError(t, err)
+	require.NoError(t, cache.Close())
+
+	cache, err = balance_cache.New(cfg, pk)
+	require.NoError(t, err)
+	defer func() {
+		_ = cache.Close()
+	}()
+
+	next, err = cache.NextSeq(balance_cache.KindDeposit)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), next)
+
+	next, err = cache.NextSeq(balance_cache.KindTransaction)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(11), next)
+
+	balances, err := cache.Balances()
+	require.NoError(t, err)
+	assert.Equal(t, map[uint32]*big.Int{0: big.NewInt(70), 1: big.NewInt(-5)}, balances)
+
+	pending, err := cache.PendingDeposits()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "2", pending[0].ID)
+
+	pending[0].Pending = false
+	err = cache.Store(balance_cache.KindDeposit, pending, 4)
+	require.NoError(t, err)
+
+	balances, err = cache.Balances()
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(77), balances[0])
+
+	t.Run("the entries are encrypted", func(t *testing.T) {
+		buf, err := os.ReadFile(filepath.Join(cfg.BalanceCache.Dir, pk.ToAddress().String()+".db"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(buf), "tokenIndex")
+	})
+}
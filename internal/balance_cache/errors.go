@@ -0,0 +1,21 @@
+package balance_cache
+
+import "errors"
+
+// ErrCreateDirFail error: failed to create the balance cache directory.
+var ErrCreateDirFail = errors.New("failed to create the balance cache directory")
+
+// ErrOpenFail error: failed to open the balance cache.
+var ErrOpenFail = errors.New("failed to open the balance cache")
+
+// ErrReadFail error: failed to read the balance cache.
+var ErrReadFail = errors.New("failed to read the balance cache")
+
+// ErrWriteFail error: failed to write the balance cache.
+var ErrWriteFail = errors.New("failed to write the balance cache")
+
+// ErrEncryptFail error: failed to encrypt the entry of the balance cache.
+var ErrEncryptFail = errors.New("failed to encrypt the entry of the balance cache")
+
+// ErrDecryptFail error: failed to decrypt the entry of the balance cache (the cache of another key?).
+var ErrDecryptFail = errors.New("failed to decrypt the entry of the balance cache (the cache of another key?)")
@@ -0,0 +1,49 @@
+package balance_cache
+
+import "math/big"
+
+//go:generate mockgen -destination=../mocks/mock_balance_cache.go -package=mocks -source=interface.go
+
+// Kind is the kind of the backups of the store vault.
+type Kind string
+
+const (
+	KindDeposit     Kind = "deposit"
+	KindTransfer    Kind = "transfer"
+	KindTransaction Kind = "transaction"
+)
+
+// TokenAmount is the change of the balance of the token index,
+// the amounts of the sent transfers are negative.
+type TokenAmount struct {
+	TokenIndex uint32   `json:"tokenIndex"`
+	Amount     *big.Int `json:"amount"`
+}
+
+// Entry is the decrypted backup of the store vault.
+type Entry struct {
+	ID          string `json:"id"`
+	Kind        Kind   `json:"kind"`
+	BlockNumber uint64 `json:"blockNumber"`
+	// Invalid is true for the backups failed to decrypt, they are not decrypted again.
+	Invalid bool `json:"invalid,omitempty"`
+	// Pending is true for the deposits not confirmed yet, they are not counted in the balances.
+	Pending bool           `json:"pending,omitempty"`
+	Amounts []*TokenAmount `json:"amounts,omitempty"`
+}
+
+// BalanceCache keeps the decrypted backups of the INTMAX account in the local encrypted store,
+// so that only the new backups are downloaded and decrypted.
+type BalanceCache interface {
+	// NextSeq returns the sequence number of the store vault, from which the backups
+	// of the kind are not synced yet.
+	NextSeq(kind Kind) (uint64, error)
+	// Store stores the entries of the kind and the next sequence number of the kind at once.
+	// The stored entries with the same IDs are replaced.
+	Store(kind Kind, entries []*Entry, nextSeq uint64) error
+	// PendingDeposits returns the deposits not confirmed yet.
+	PendingDeposits() ([]*Entry, error)
+	// Balances returns the balances of the token indexes computed by the stored entries.
+	Balances() (map[uint32]*big.Int, error)
+	Close() error
+}
@@ -11,8 +11,8 @@ import (
 	"intmax2-node/internal/bindings"
 	"intmax2-node/internal/logger"
 	"intmax2-node/internal/mnemonic_wallet"
+	"intmax2-node/internal/store_vault_auth"
 	intMaxTypes "intmax2-node/internal/types"
-	errorsDB "intmax2-node/pkg/sql_db/errors"
 	"intmax2-node/pkg/utils"
 	"log"
 	"math/big"
@@ -233,48 +233,84 @@ func GetTokenIndexFromLiquidityContract(
 	return tokenIndex, nil
 }
 
+// GetUserBalance returns the balance of the token index of the user computed by the balance cache,
+// which is synced with the backups of the store vault since the last call.
 func GetUserBalance(
 	ctx context.Context,
 	cfg *configs.Config,
 	userPrivateKey *intMaxAcc.PrivateKey,
 	tokenIndex uint32,
 ) (*big.Int, error) {
-	userAllData, err := GetUserBalancesRawRequest(ctx, cfg, userPrivateKey.ToAddress().String())
+	balances, err := GetUserBalances(ctx, cfg, userPrivateKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user balances: %w", err)
 	}
-	balanceData, err := CalculateBalance(ctx, cfg, userAllData, tokenIndex, *userPrivateKey)
-	if err != nil && !errors.Is(err, errorsDB.ErrNotFound) {
-		return nil, ErrFetchBalanceByUserAddressAndTokenInfoWithDBApp
-	}
-	if errors.Is(err, errorsDB.ErrNotFound) {
+	balance, ok := balances[tokenIndex]
+	if !ok {
 		fmt.Printf("Balance not found for user %s and token index %d\n", userPrivateKey.ToAddress().String(), tokenIndex)
 		return big.NewInt(0), nil
 	}
-	if balanceData.Amount.Cmp(big.NewInt(0)) < 0 {
-		return nil, fmt.Errorf("balance is negative: %v", balanceData.Amount)
+	if balance.Cmp(big.NewInt(0)) < 0 {
+		return nil, fmt.Errorf("balance is negative: %v", balance)
 	}
 
-	return balanceData.Amount, nil
+	return balance, nil
 }
 
+// GetUserBalancesRawRequest returns the backups of the address of the signer,
+// which logs in to the store vault with its key. The pages are requested
+// following the next cursor until the last one.
 func GetUserBalancesRawRequest(
 	ctx context.Context,
 	cfg *configs.Config,
-	address string,
+	signer store_vault_auth.Signer,
+) (*GetBalancesResponse, error) {
+	result := new(GetBalancesResponse)
+
+	var cursor string
+	for {
+		page, err := getUserBalancesPageRawRequest(ctx, cfg, signer, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Deposits = append(result.Deposits, page.Deposits...)
+		result.Transfers = append(result.Transfers, page.Transfers...)
+		result.Transactions = append(result.Transactions, page.Transactions...)
+
+		if page.Meta == nil || !page.Meta.HasMore || page.Meta.NextCursor == cursor {
+			return result, nil
+		}
+		cursor = page.Meta.NextCursor
+	}
+}
+
+func getUserBalancesPageRawRequest(
+	ctx context.Context,
+	cfg *configs.Config,
+	signer store_vault_auth.Signer,
+	cursor string,
 ) (*GetBalancesResponse, error) {
 	const (
-		httpKey     = "http"
-		httpsKey    = "https"
 		contentType = "Content-Type"
 		appJSON     = "application/json"
+		cursorKey   = "cursor"
 	)
 
-	apiUrl := fmt.Sprintf("%s/v1/balances/%s", cfg.API.DataStoreVaultUrl, address)
+	apiUrl := fmt.Sprintf("%s/v1/balances/%s", cfg.API.DataStoreVaultUrl, signer.Address())
+
+	authorization, err := store_vault_auth.Authorization(ctx, cfg, signer)
+	if err != nil {
+		return nil, err
+	}
 
 	r := resty.New().R()
+	if cursor != "" {
+		r = r.SetQueryParam(cursorKey, cursor)
+	}
 	resp, err := r.SetContext(ctx).SetHeaders(map[string]string{
-		contentType: appJSON,
+		contentType:                appJSON,
+		store_vault_auth.HeaderKey: authorization,
 	}).Get(apiUrl)
 	if err != nil {
 		const msg = "failed to send of the transaction request: %w"
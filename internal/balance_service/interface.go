@@ -7,6 +7,15 @@ type GetBalancesResponse struct {
 	Transfers []*BackupTransfer `json:"transfers,omitempty"`
 	// The list of transactions
 	Transactions []*BackupTransaction `json:"transactions,omitempty"`
+	// The meta of the page
+	Meta *GetBalancesResponse_Meta `json:"meta,omitempty"`
+}
+
+type GetBalancesResponse_Meta struct {
+	// Indicates whether there are more backups after the page
+	HasMore bool `json:"hasMore,omitempty"`
+	// The cursor of the next page
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 type BackupDeposit struct {
@@ -0,0 +1,307 @@
+package balance_service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"intmax2-node/configs"
+	intMaxAcc "intmax2-node/internal/accounts"
+	"intmax2-node/internal/balance_cache"
+	"intmax2-node/internal/store_vault_auth"
+	intMaxTypes "intmax2-node/internal/types"
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type backupEntry struct {
+	ID                string `json:"id"`
+	BlockNumber       string `json:"blockNumber"`
+	Seq               string `json:"seq"`
+	EncryptedDeposit  string `json:"encryptedDeposit"`
+	EncryptedTransfer string `json:"encryptedTransfer"`
+	EncryptedTx       string `json:"encryptedTx"`
+}
+
+type getBackupsResponse struct {
+	Success bool `json:"success"`
+	Data    *struct {
+		Deposits     []*backupEntry `json:"deposits"`
+		Transfers    []*backupEntry `json:"transfers"`
+		Transactions []*backupEntry `json:"transactions"`
+	} `json:"data"`
+}
+
+// GetUserBalances syncs the balance cache of the user and returns the balances of all token indexes.
+func GetUserBalances(
+	ctx context.Context,
+	cfg *configs.Config,
+	userPrivateKey *intMaxAcc.PrivateKey,
+) (map[uint32]*big.Int, error) {
+	cache, err := balance_cache.New(cfg, userPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = cache.Close()
+	}()
+
+	err = SyncUserBalances(ctx, cfg, cache, userPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cache.Balances()
+}
+
+// SyncUserBalances downloads and decrypts the backups of the user stored since the last sync
+// and confirms the pending deposits of the balance cache.
+func SyncUserBalances(
+	ctx context.Context,
+	cfg *configs.Config,
+	cache balance_cache.BalanceCache,
+	userPrivateKey *intMaxAcc.PrivateKey,
+) error {
+	signer := store_vault_auth.NewINTMAXSigner(userPrivateKey)
+
+	for _, kind := range []balance_cache.Kind{
+		balance_cache.KindDeposit,
+		balance_cache.KindTransfer,
+		balance_cache.KindTransaction,
+	} {
+		err := syncBackups(ctx, cfg, cache, signer, userPrivateKey, kind)
+		if err != nil {
+			return err
+		}
+	}
+
+	pending, err := cache.PendingDeposits()
+	if err != nil {
+		return err
+	}
+
+	confirmed := make([]*balance_cache.Entry, 0, len(pending))
+	for key := range pending {
+		var ok bool
+		ok, err = GetDepositValidityRawRequest(ctx, cfg, strconv.FormatUint(pending[key].BlockNumber, 10))
+		if err != nil {
+			return errors.Join(ErrDepositValidity, err)
+		}
+		if !ok {
+			continue
+		}
+
+		pending[key].Pending = false
+		confirmed = append(confirmed, pending[key])
+	}
+
+	if len(confirmed) == 0 {
+		return nil
+	}
+
+	var nextSeq uint64
+	nextSeq, err = cache.NextSeq(balance_cache.KindDeposit)
+	if err != nil {
+		return err
+	}
+
+	return cache.Store(balance_cache.KindDeposit, confirmed, nextSeq)
+}
+
+// syncBackups stores the backups of the kind page by page starting from the next sequence number of the cache.
+// The backups are paged by the sequence numbers, since the transactions and the transfers are backed up
+// before their blocks are posted and the new backups may have the block numbers synced already.
+func syncBackups(
+	ctx context.Context,
+	cfg *configs.Config,
+	cache balance_cache.BalanceCache,
+	signer store_vault_auth.Signer,
+	userPrivateKey *intMaxAcc.PrivateKey,
+	kind balance_cache.Kind,
+) error {
+	nextSeq, err := cache.NextSeq(kind)
+	if err != nil {
+		return err
+	}
+
+	for {
+		var list []*backupEntry
+		list, err = getBackupsRawRequest(ctx, cfg, signer, kind, nextSeq)
+		if err != nil {
+			return fmt.Errorf("failed to get backups of %s: %w", kind, err)
+		}
+
+		if len(list) == 0 {
+			return nil
+		}
+
+		entries := make([]*balance_cache.Entry, 0, len(list))
+		for key := range list {
+			var blockNumber uint64
+			blockNumber, err = strconv.ParseUint(list[key].BlockNumber, 10, 64)
+			if err != nil {
+				return fmt.Errorf("failed to parse block number of %s: %w", kind, err)
+			}
+
+			var seq uint64
+			seq, err = strconv.ParseUint(list[key].Seq, 10, 64)
+			if err != nil {
+				return fmt.Errorf("failed to parse sequence number of %s: %w", kind, err)
+			}
+
+			entry := decryptBackup(userPrivateKey, kind, list[key])
+			entry.BlockNumber = blockNumber
+			entries = append(entries, entry)
+
+			if seq >= nextSeq {
+				nextSeq = seq + 1
+			}
+		}
+
+		err = cache.Store(kind, entries, nextSeq)
+		if err != nil {
+			return err
+		}
+
+		if uint64(len(list)) < cfg.BalanceCache.SyncLimit {
+			return nil
+		}
+	}
+}
+
+// decryptBackup returns the entry of the balance cache of the backup,
+// the backups failed to decrypt are marked as invalid.
+func decryptBackup(
+	userPrivateKey *intMaxAcc.PrivateKey,
+	kind balance_cache.Kind,
+	backup *backupEntry,
+) *balance_cache.Entry {
+	entry := balance_cache.Entry{
+		ID:      backup.ID,
+		Invalid: true,
+	}
+
+	var encrypted string
+	switch kind {
+	case balance_cache.KindDeposit:
+		encrypted = backup.EncryptedDeposit
+	case balance_cache.KindTransfer:
+		encrypted = backup.EncryptedTransfer
+	case balance_cache.KindTransaction:
+		encrypted = backup.EncryptedTx
+	}
+
+	encryptedBytes, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return &entry
+	}
+
+	var encoded []byte
+	encoded, err = userPrivateKey.DecryptECIES(encryptedBytes)
+	if err != nil {
+		return &entry
+	}
+
+	switch kind {
+	case balance_cache.KindDeposit:
+		var deposit intMaxTypes.Deposit
+		if err = deposit.Unmarshal(encoded); err != nil {
+			return &entry
+		}
+		entry.Pending = true
+		entry.Amounts = []*balance_cache.TokenAmount{{
+			TokenIndex: deposit.TokenIndex,
+			Amount:     deposit.Amount,
+		}}
+	case balance_cache.KindTransfer:
+		var transfer intMaxTypes.Transfer
+		if err = transfer.Unmarshal(encoded); err != nil {
+			return &entry
+		}
+		entry.Amounts = []*balance_cache.TokenAmount{{
+			TokenIndex: transfer.TokenIndex,
+			Amount:     transfer.Amount,
+		}}
+	case balance_cache.KindTransaction:
+		var tx intMaxTypes.TxDetails
+		if err = tx.Unmarshal(encoded); err != nil {
+			return &entry
+		}
+		entry.Amounts = make([]*balance_cache.TokenAmount, 0, len(tx.Transfers))
+		for key := range tx.Transfers {
+			entry.Amounts = append(entry.Amounts, &balance_cache.TokenAmount{
+				TokenIndex: tx.Transfers[key].TokenIndex,
+				Amount:     new(big.Int).Neg(tx.Transfers[key].Amount),
+			})
+		}
+	}
+
+	entry.Invalid = false
+
+	return &entry
+}
+
+func getBackupsRawRequest(
+	ctx context.Context,
+	cfg *configs.Config,
+	signer store_vault_auth.Signer,
+	kind balance_cache.Kind,
+	startSeq uint64,
+) ([]*backupEntry, error) {
+	const (
+		contentType = "Content-Type"
+		appJSON     = "application/json"
+	)
+
+	authorization, err := store_vault_auth.Authorization(ctx, cfg, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	apiUrl := fmt.Sprintf("%s/v1/backups/%s", cfg.API.DataStoreVaultUrl, kind)
+
+	r := resty.New().R()
+	resp, err := r.SetContext(ctx).SetHeaders(map[string]string{
+		contentType:                appJSON,
+		store_vault_auth.HeaderKey: authorization,
+	}).SetQueryParams(map[string]string{
+		"sender":    signer.Address(),
+		"start_seq": strconv.FormatUint(startSeq, 10),
+		"limit":     strconv.FormatUint(cfg.BalanceCache.SyncLimit, 10),
+	}).Get(apiUrl)
+	if err != nil {
+		const msg = "failed to send of the backups request: %w"
+		return nil, fmt.Errorf(msg, err)
+	}
+
+	if resp == nil {
+		const msg = "send request error occurred"
+		return nil, errors.New(msg)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("failed to get response: status %d", resp.StatusCode())
+	}
+
+	response := new(getBackupsResponse)
+	if err = json.Unmarshal(resp.Body(), response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if !response.Success || response.Data == nil {
+		return nil, fmt.Errorf("failed to get backups: %s", resp.Body())
+	}
+
+	switch kind {
+	case balance_cache.KindDeposit:
+		return response.Data.Deposits, nil
+	case balance_cache.KindTransfer:
+		return response.Data.Transfers, nil
+	default:
+		return response.Data.Transactions, nil
+	}
+}
@@ -0,0 +1,213 @@
+package balance_service_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"intmax2-node/configs"
+	intMaxAcc "intmax2-node/internal/accounts"
+	"intmax2-node/internal/balance_service"
+	"intmax2-node/internal/hash/goldenposeidon"
+	intMaxTypes "intmax2-node/internal/types"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUserBalances(t *testing.T) {
+	ethKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	userPk, err := intMaxAcc.NewINTMAXAccountFromECDSAKey(ethKey)
+	require.NoError(t, err)
+
+	encryptDeposit := func(tokenIndex uint32, amount int64) string {
+		salt := new(goldenposeidon.PoseidonHashOut)
+		require.NoError(t, salt.Unmarshal(make([]byte, 32)))
+
+		deposit := intMaxTypes.Deposit{
+			Recipient:  userPk.Public(),
+			TokenIndex: tokenIndex,
+			Amount:     big.NewInt(amount),
+			Salt:       salt,
+		}
+
+		encrypted, err := intMaxAcc.EncryptECIES(rand.Reader, userPk.Public(), deposit.Marshal())
+		require.NoError(t, err)
+
+		return base64.StdEncoding.EncodeToString(encrypted)
+	}
+
+	recipient, err := intMaxTypes.NewINTMAXAddress(userPk.ToAddress().Bytes())
+	require.NoError(t, err)
+
+	encryptTransfer := func(tokenIndex uint32, amount int64) string {
+		salt := new(goldenposeidon.PoseidonHashOut)
+		require.NoError(t, salt.Unmarshal(make([]byte, 32)))
+
+		transfer := intMaxTypes.NewTransfer(recipient, tokenIndex, big.NewInt(amount), salt)
+
+		encrypted, err := intMaxAcc.EncryptECIES(rand.Reader, userPk.Public(), transfer.Marshal())
+		require.NoError(t, err)
+
+		return base64.StdEncoding.EncodeToString(encrypted)
+	}
+
+	type backup struct {
+		ID                string `json:"id"`
+		BlockNumber       string `json:"blockNumber"`
+		Seq               string `json:"seq"`
+		EncryptedDeposit  string `json:"encryptedDeposit,omitempty"`
+		EncryptedTransfer string `json:"encryptedTransfer,omitempty"`
+	}
+
+	var (
+		mu        sync.Mutex
+		deposits  []*backup
+		transfers []*backup
+		confirmed = map[string]bool{}
+		requested []uint64
+	)
+	addDeposit := func(depositID uint64, tokenIndex uint32, amount int64, isConfirmed bool) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		id := strconv.FormatUint(depositID, 10)
+		deposits = append(deposits, &backup{
+			ID:               "deposit-" + id,
+			BlockNumber:      id,
+			Seq:              strconv.Itoa(len(deposits) + 1),
+			EncryptedDeposit: encryptDeposit(tokenIndex, amount),
+		})
+		confirmed[id] = isConfirmed
+	}
+	// The transfers are backed up before their blocks are posted, so all of them have the same block number.
+	addTransfer := func(encryptedTransfer string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		seq := strconv.Itoa(len(transfers) + 1)
+		transfers = append(transfers, &backup{
+			ID:                "transfer-" + seq,
+			BlockNumber:       "1",
+			Seq:               seq,
+			EncryptedTransfer: encryptedTransfer,
+		})
+	}
+	page := func(list []*backup, r *http.Request) []*backup {
+		start, _ := strconv.ParseUint(r.URL.Query().Get("start_seq"), 10, 64)
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		result := make([]*backup, 0)
+		for key := range list {
+			seq, _ := strconv.ParseUint(list[key].Seq, 10, 64)
+			if seq >= start && len(result) < limit {
+				result = append(result, list[key])
+			}
+		}
+
+		return result
+	}
+
+	expiresAt := time.Now().UTC().Add(time.Hour)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/auth/challenge", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"nonce": "nonce", "expiresAt": expiresAt},
+		})
+	})
+	mux.HandleFunc("/v1/auth/login", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"token": "token", "expiresAt": expiresAt},
+		})
+	})
+	mux.HandleFunc("/v1/backups/deposit", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		start, _ := strconv.ParseUint(r.URL.Query().Get("start_seq"), 10, 64)
+		requested = append(requested, start)
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"deposits": page(deposits, r)},
+		})
+	})
+	mux.HandleFunc("/v1/backups/transfer", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"transfers": page(transfers, r)},
+		})
+	})
+	mux.HandleFunc("/v1/backups/transaction", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"transactions": []string{}},
+		})
+	})
+	mux.HandleFunc("/v1/deposits/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		var id string
+		for key := range confirmed {
+			if r.URL.Path == "/v1/deposits/"+key+"/verify-confirmation" {
+				id = key
+			}
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"confirmed": confirmed[id]},
+		})
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	cfg := &configs.Config{}
+	cfg.API.DataStoreVaultUrl = srv.URL
+	cfg.BalanceCache.Dir = t.TempDir()
+	cfg.BalanceCache.SyncLimit = 2
+
+	addDeposit(1, 0, 300, true)
+	addDeposit(2, 1, 50, true)
+	addDeposit(3, 0, 200, false)
+	addTransfer("invalid")
+	addTransfer(encryptTransfer(0, 10))
+
+	balances, err := balance_service.GetUserBalances(context.Background(), cfg, userPk)
+	require.NoError(t, err)
+	assert.Equal(t, map[uint32]*big.Int{0: big.NewInt(310), 1: big.NewInt(50)}, balances)
+	assert.Equal(t, []uint64{1, 3}, requested)
+
+	mu.Lock()
+	confirmed["3"] = true
+	requested = nil
+	mu.Unlock()
+	addDeposit(4, 1, 25, true)
+	// The new transfer has the block number synced already.
+	addTransfer(encryptTransfer(1, 5))
+
+	balances, err = balance_service.GetUserBalances(context.Background(), cfg, userPk)
+	require.NoError(t, err)
+	assert.Equal(t, map[uint32]*big.Int{0: big.NewInt(510), 1: big.NewInt(80)}, balances)
+	assert.Equal(t, []uint64{4}, requested)
+
+	balance, err := balance_service.GetUserBalance(context.Background(), cfg, userPk, 1)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(80), balance)
+}
@@ -0,0 +1,144 @@
+package balance_validity_prover
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"intmax2-node/configs"
+	"intmax2-node/internal/logger"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type balanceValidityProver struct {
+	cfg         *configs.Config
+	log         logger.Logger
+	client      *resty.Client
+	proofClient *resty.Client
+}
+
+func New(cfg *configs.Config, log logger.Logger) (BalanceValidityProver, error) {
+	if cfg.API.BalanceValidityProverUrl == "" {
+		return nil, ErrProverURLEmpty
+	}
+
+	return &balanceValidityProver{
+		cfg:    cfg,
+		log:    log,
+		client: newClient(cfg, cfg.Withdrawal.ProverRequestTimeout),
+		// The proofs are generated in the request.
+		proofClient: newClient(cfg, cfg.Withdrawal.ProverTimeout),
+	}, nil
+}
+
+// newClient creates the client of the prover.
+// The transport errors and the server errors are retried, the rejected requests are not.
+func newClient(cfg *configs.Config, timeout time.Duration) *resty.Client {
+	const (
+		contentType = "Content-Type"
+		appJSON     = "application/json"
+	)
+
+	return resty.New().
+		SetBaseURL(strings.TrimSuffix(cfg.API.BalanceValidityProverUrl, "/")).
+		SetHeader(contentType, appJSON).
+		SetTimeout(timeout).
+		SetRetryCount(cfg.Withdrawal.ProverRetryCount).
+		SetRetryWaitTime(cfg.Withdrawal.ProverRetryWaitTime).
+		AddRetryCondition(func(resp *resty.Response, err error) bool {
+			return err != nil || resp.StatusCode() >= http.StatusInternalServerError
+		})
+}
+
+// VerifyBalanceProof verifies the plonky2 proof (base64 encoded) with the verifier of the balance validity prover.
+// The verifier of the spend circuit is the only verifier served by the prover: it checks that the balance
+// of the sender is enough for the transaction.
+func (p *balanceValidityProver) VerifyBalanceProof(ctx context.Context, proof string) error {
+	const path = "/verify/spend"
+
+	resp, err := p.client.R().SetContext(ctx).SetBody(&VerifyProofRequest{
+		Proof: proof,
+	}).Post(path)
+	if err != nil {
+		return errors.Join(ErrSendRequestFail, err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return statusCodeError(resp)
+	}
+
+	var res VerifyProofResponse
+	err = json.Unmarshal(resp.Body(), &res)
+	if err != nil {
+		return errors.Join(ErrUnmarshalResponseFail, err)
+	}
+
+	if !res.Success {
+		return ErrRequestRejected
+	}
+
+	return nil
+}
+
+// SingleWithdrawalProof proves the single withdrawal from the transfer witness and the balance proof of the sender.
+// The prover generates the proof in the request, so the request is limited by the timeout of the withdrawal prover.
+// The request of the job that already has the proof returns the proof generated before.
+func (p *balanceValidityProver) SingleWithdrawalProof(
+	ctx context.Context,
+	jobID string,
+	witness *SingleWithdrawalWitness,
+) (string, error) {
+	const path = "/proof/withdrawal"
+
+	resp, err := p.proofClient.R().SetContext(ctx).SetBody(&ProofWithdrawalRequest{
+		RequestID:       jobID,
+		BalanceProof:    witness.BalanceProof,
+		TransferWitness: &witness.TransferWitness,
+	}).Post(path)
+	if err != nil {
+		return "", errors.Join(ErrSendRequestFail, err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return "", statusCodeError(resp)
+	}
+
+	var res ProofResponse
+	err = json.Unmarshal(resp.Body(), &res)
+	if err != nil {
+		return "", errors.Join(ErrUnmarshalResponseFail, err)
+	}
+
+	if !res.Success {
+		if res.ErrorMessage == nil && res.Message != "" {
+			return "", rejected(&res.Message)
+		}
+		return "", rejected(res.ErrorMessage)
+	}
+
+	if res.Proof == nil {
+		return "", ErrProofEmpty
+	}
+
+	return *res.Proof, nil
+}
+
+func statusCodeError(resp *resty.Response) error {
+	if resp.StatusCode() >= http.StatusBadRequest && resp.StatusCode() < http.StatusInternalServerError {
+		return fmt.Errorf("%w: status code %d: %s", ErrRequestRejected, resp.StatusCode(), resp.String())
+	}
+
+	return fmt.Errorf("%w: %d", ErrUnexpectedStatusCode, resp.StatusCode())
+}
+
+func rejected(errorMessage *string) error {
+	if errorMessage == nil {
+		return ErrRequestRejected
+	}
+
+	return fmt.Errorf("%w: %s", ErrRequestRejected, *errorMessage)
+}
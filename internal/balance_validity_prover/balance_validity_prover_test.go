@@ -0,0 +1,316 @@
+package balance_validity_prover_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"intmax2-node/configs"
+	"intmax2-node/internal/balance_validity_prover"
+	"intmax2-node/pkg/logger"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBalanceValidityProver(t *testing.T, url string) balance_validity_prover.BalanceValidityProver {
+	const int2Key = 2
+	assert.NoError(t, configs.LoadDotEnv(int2Key))
+
+	cfg := *configs.New()
+	cfg.API.BalanceValidityProverUrl = url
+	cfg.Withdrawal.ProverRequestTimeout = time.Second
+	cfg.Withdrawal.ProverRetryCount = 2
+	cfg.Withdrawal.ProverRetryWaitTime = time.Millisecond
+
+	log := logger.New(cfg.LOG.Level, cfg.LOG.TimeFormat, cfg.LOG.JSON, cfg.LOG.IsLogLine)
+
+	prover, err := balance_validity_prover.New(&cfg, log)
+	require.NoError(t, err)
+
+	return prover
+}
+
+func TestBalanceValidityProver(t *testing.T) {
+	const proof = "proof"
+
+	ctx := context.Background()
+
+	var requests []balance_validity_prover.VerifyProofRequest
+	failures := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var req balance_validity_prover.VerifyProofRequest
+		if r.Method != http.MethodPost || r.URL.Path != "/verify/spend" ||
+			json.NewDecoder(r.Body).Decode(&req) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, req)
+
+		_ = json.NewEncoder(w).Encode(&balance_validity_prover.VerifyProofResponse{Success: req.Proof == proof})
+	}))
+	defer srv.Close()
+
+	prover := newBalanceValidityProver(t, srv.URL)
+
+	t.Run("Proof is verified", func(t *testing.T) {
+		requests = nil
+		failures = 1
+
+		err := prover.VerifyBalanceProof(ctx, proof)
+		assert.NoError(t, err)
+		require.Len(t, requests, 1)
+		assert.Equal(t, balance_validity_prover.VerifyProofRequest{Proof: proof}, requests[0])
+	})
+
+	t.Run("Proof is rejected", func(t *testing.T) {
+		err := prover.VerifyBalanceProof(ctx, "other")
+		assert.ErrorIs(t, err, balance_validity_prover.ErrRequestRejected)
+	})
+
+	t.Run("Prover is unavailable", func(t *testing.T) {
+		failures = 3
+
+		err := prover.VerifyBalanceProof(ctx, proof)
+		assert.ErrorIs(t, err, balance_validity_prover.ErrUnexpectedStatusCode)
+		assert.NotErrorIs(t, err, balance_validity_prover.ErrRequestRejected)
+	})
+
+	t.Run("Prover URL is empty", func(t *testing.T) {
+		cfg := configs.Config{}
+
+		_, err := balance_validity_prover.New(&cfg, nil)
+		assert.ErrorIs(t, err, balance_validity_prover.ErrProverURLEmpty)
+	})
+}
+
+func TestBalanceValidityProverSingleWithdrawalProof(t *testing.T) {
+	const (
+		jobID        = "job"
+		balanceProof = "balanceProof"
+		errorMessage = "Failed to generate proof"
+	)
+
+	ctx := context.Background()
+
+	witness := balance_validity_prover.SingleWithdrawalWitness{
+		TransferWitness: balance_validity_prover.TransferWitness{
+			Tx: balance_validity_prover.SingleWithdrawalTx{
+				TransferTreeRoot: "0x01",
+				Nonce:            1,
+			},
+			Transfer: balance_validity_prover.SingleWithdrawalTransfer{
+				Recipient: "0x02",
+				Amount:    "1",
+				Salt:      "0x03",
+			},
+			TransferMerkleProof: balance_validity_prover.TransferMerkleProof{
+				Siblings: []string{"0x04"},
+			},
+		},
+		BalanceProof: balanceProof,
+	}
+
+	var requests []balance_validity_prover.ProofWithdrawalRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req balance_validity_prover.ProofWithdrawalRequest
+		if r.Method != http.MethodPost || r.URL.Path != "/proof/withdrawal" ||
+			json.NewDecoder(r.Body).Decode(&req) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, req)
+
+		if req.BalanceProof != balanceProof {
+			_ = json.NewEncoder(w).Encode(&balance_validity_prover.ProofResponse{Message: errorMessage})
+			return
+		}
+
+		proof := "proof:" + req.RequestID
+		_ = json.NewEncoder(w).Encode(&balance_validity_prover.ProofResponse{
+			Success:   true,
+			RequestID: req.RequestID,
+			Proof:     &proof,
+		})
+	}))
+	defer srv.Close()
+
+	prover := newBalanceValidityProver(t, srv.URL)
+
+	t.Run("Proof is generated", func(t *testing.T) {
+		proof, err := prover.SingleWithdrawalProof(ctx, jobID, &witness)
+		assert.NoError(t, err)
+		assert.Equal(t, "proof:"+jobID, proof)
+		require.Len(t, requests, 1)
+		assert.Equal(t, balance_validity_prover.ProofWithdrawalRequest{
+			RequestID:       jobID,
+			BalanceProof:    balanceProof,
+			TransferWitness: &witness.TransferWitness,
+		}, requests[0])
+	})
+
+	t.Run("Proof is not generated", func(t *testing.T) {
+		other := witness
+		other.BalanceProof = "other"
+
+		_, err := prover.SingleWithdrawalProof(ctx, jobID, &other)
+		assert.ErrorIs(t, err, balance_validity_prover.ErrRequestRejected)
+		assert.ErrorContains(t, err, errorMessage)
+	})
+}
+
+// TestBalanceValidityProverRoutes checks that the requests of the client are served by the routes
+// of the balance validity prover and have the fields of their request types.
+func TestBalanceValidityProverRoutes(t *testing.T) {
+	const proverDir = "../../prover/balance-validity-prover/src"
+
+	routes := rustRoutes(t, proverDir)
+	fields := rustStructFields(t, filepath.Join(proverDir, "app", "interface.rs"))
+
+	type request struct {
+		method string
+		path   string
+		body   map[string]json.RawMessage
+	}
+
+	var (
+		mu       sync.Mutex
+		requests []request
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		var body map[string]json.RawMessage
+		_ = json.Unmarshal(b, &body)
+
+		mu.Lock()
+		requests = append(requests, request{method: r.Method, path: r.URL.Path, body: body})
+		mu.Unlock()
+
+		_, _ = w.Write([]byte(`{"success":true,"requestId":"job","proof":"proof"}`))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	prover := newBalanceValidityProver(t, srv.URL)
+
+	assert.NoError(t, prover.VerifyBalanceProof(ctx, "proof"))
+	_, err := prover.SingleWithdrawalProof(ctx, "job", &balance_validity_prover.SingleWithdrawalWitness{})
+	assert.NoError(t, err)
+
+	require.Len(t, requests, 2)
+	for key := range requests {
+		route, ok := routes.match(requests[key].method, requests[key].path)
+		if !assert.True(t, ok, "no route of %s %s", requests[key].method, requests[key].path) {
+			continue
+		}
+
+		var keys []string
+		for name := range requests[key].body {
+			keys = append(keys, name)
+		}
+		sort.Strings(keys)
+
+		expected, ok := fields[route.request]
+		if assert.True(t, ok, "no request type %s", route.request) {
+			assert.Equal(t, expected, keys, "fields of %s %s", route.method, route.path)
+		}
+	}
+}
+
+type rustRoute struct {
+	method  string
+	path    string
+	request string
+	pattern *regexp.Regexp
+}
+
+type rustRouteTable []rustRoute
+
+func (rt rustRouteTable) match(method, path string) (rustRoute, bool) {
+	for key := range rt {
+		if rt[key].method == method && rt[key].pattern.MatchString(path) {
+			return rt[key], true
+		}
+	}
+
+	return rustRoute{}, false
+}
+
+// rustRoutes returns the routes of the handlers registered in the route table of the prover.
+func rustRoutes(t *testing.T, dir string) rustRouteTable {
+	b, err := os.ReadFile(filepath.Join(dir, "app", "route.rs"))
+	require.NoError(t, err)
+
+	services := regexp.MustCompile(`^\s*(\w+)::(\w+)::(\w+),`)
+	handler := `#\[(get|post)\("([^"]+)"\)\]\s*(?:pub\s+)?async fn %s\(([^)]*)\)`
+	requestType := regexp.MustCompile(`web::Json<(\w+)>`)
+	param := regexp.MustCompile(`\{\w+\}`)
+
+	var routes rustRouteTable
+	for _, line := range strings.Split(string(b), "\n") {
+		m := services.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		var src []byte
+		src, err = os.ReadFile(filepath.Join(dir, "server", m[1], m[2]+".rs"))
+		require.NoError(t, err)
+
+		h := regexp.MustCompile(fmt.Sprintf(handler, m[3])).FindSubmatch(src)
+		require.NotNil(t, h, "handler %s", strings.TrimSpace(line))
+
+		route := rustRoute{
+			method:  strings.ToUpper(string(h[1])),
+			path:    string(h[2]),
+			pattern: regexp.MustCompile("^" + param.ReplaceAllString(string(h[2]), `[^/]+`) + "$"),
+		}
+		if r := requestType.FindSubmatch(h[3]); r != nil {
+			route.request = string(r[1])
+		}
+		routes = append(routes, route)
+	}
+	require.NotEmpty(t, routes)
+
+	return routes
+}
+
+// rustStructFields returns the sorted JSON fields of the camel cased structs.
+func rustStructFields(t *testing.T, name string) map[string][]string {
+	b, err := os.ReadFile(name)
+	require.NoError(t, err)
+
+	structs := regexp.MustCompile(`#\[serde\(rename_all = "camelCase"\)\]\s*pub struct (\w+) \{([^}]*)\}`)
+	field := regexp.MustCompile(`pub (\w+):`)
+	snake := regexp.MustCompile(`_(\w)`)
+
+	fields := make(map[string][]string)
+	for _, m := range structs.FindAllStringSubmatch(string(b), -1) {
+		var names []string
+		for _, f := range field.FindAllStringSubmatch(m[2], -1) {
+			names = append(names, snake.ReplaceAllStringFunc(f[1], func(s string) string {
+				return strings.ToUpper(s[1:])
+			}))
+		}
+		sort.Strings(names)
+		fields[m[1]] = names
+	}
+
+	return fields
+}
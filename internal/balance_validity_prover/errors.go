@@ -0,0 +1,21 @@
+package balance_validity_prover
+
+import "errors"
+
+// ErrProverURLEmpty error: the URL of the balance validity prover must not be empty.
+var ErrProverURLEmpty = errors.New("the URL of the balance validity prover must not be empty")
+
+// ErrSendRequestFail error: failed to send the request to the balance validity prover.
+var ErrSendRequestFail = errors.New("failed to send the request to the balance validity prover")
+
+// ErrUnexpectedStatusCode error: unexpected status code of the balance validity prover.
+var ErrUnexpectedStatusCode = errors.New("unexpected status code of the balance validity prover")
+
+// ErrUnmarshalResponseFail error: failed to unmarshal the response of the balance validity prover.
+var ErrUnmarshalResponseFail = errors.New("failed to unmarshal the response of the balance validity prover")
+
+// ErrRequestRejected error: the request was rejected by the balance validity prover.
+var ErrRequestRejected = errors.New("the request was rejected by the balance validity prover")
+
+// ErrProofEmpty error: the proof is empty in the response of the balance validity prover.
+var ErrProofEmpty = errors.New("the proof is empty in the response of the balance validity prover")
@@ -0,0 +1,71 @@
+package balance_validity_prover
+
+import (
+	"context"
+)
+
+//go:generate mockgen -destination=../mocks/mock_balance_validity_prover.go -package=mocks -source=interface.go
+
+// BalanceValidityProver describes the client of the balance validity prover,
+// which verifies the proofs of the sender and proves the single withdrawals from the balance proofs.
+// The single withdrawal proof is returned in the response of its request.
+type BalanceValidityProver interface {
+	VerifyBalanceProof(ctx context.Context, proof string) error
+	SingleWithdrawalProof(ctx context.Context, jobID string, witness *SingleWithdrawalWitness) (string, error)
+}
+
+type VerifyProofRequest struct {
+	Proof string `json:"proof"`
+}
+
+type VerifyProofResponse struct {
+	Success bool `json:"success"`
+}
+
+type SingleWithdrawalTransfer struct {
+	Recipient  string `json:"recipient"`
+	TokenIndex uint32 `json:"tokenIndex"`
+	Amount     string `json:"amount"`
+	Salt       string `json:"salt"`
+}
+
+type SingleWithdrawalTx struct {
+	TransferTreeRoot string `json:"transferTreeRoot"`
+	Nonce            uint64 `json:"nonce"`
+}
+
+type TransferMerkleProof struct {
+	Siblings []string `json:"siblings"`
+}
+
+// TransferWitness describes the transfer to the withdrawal recipient and its inclusion
+// in the transfer tree of the transaction.
+type TransferWitness struct {
+	Tx                  SingleWithdrawalTx       `json:"tx"`
+	Transfer            SingleWithdrawalTransfer `json:"transfer"`
+	TransferIndex       uint32                   `json:"transferIndex"`
+	TransferMerkleProof TransferMerkleProof      `json:"transferMerkleProof"`
+}
+
+// SingleWithdrawalWitness describes the witness of the single withdrawal circuit: the transfer witness
+// and the balance proof (base64 encoded) of the sender just after the transaction.
+type SingleWithdrawalWitness struct {
+	TransferWitness TransferWitness
+	BalanceProof    string
+}
+
+type ProofWithdrawalRequest struct {
+	RequestID       string           `json:"requestId"`
+	BalanceProof    string           `json:"balanceProof"`
+	TransferWitness *TransferWitness `json:"transferWitness"`
+}
+
+// ProofResponse describes the response of the proof request.
+// The failed request has the message instead of the error message.
+type ProofResponse struct {
+	Success      bool    `json:"success"`
+	RequestID    string  `json:"requestId"`
+	Proof        *string `json:"proof"`
+	ErrorMessage *string `json:"errorMessage"`
+	Message      string  `json:"message,omitempty"`
+}
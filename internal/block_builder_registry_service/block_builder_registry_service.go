@@ -27,9 +27,10 @@ import (
 )
 
 type blockBuilderRegistryService struct {
-	cfg *configs.Config
-	log logger.Logger
-	sb  ServiceBlockchain
+	cfg   *configs.Config
+	log   logger.Logger
+	sb    ServiceBlockchain
+	state blockBuilderState
 }
 
 func New(
@@ -180,6 +181,7 @@ func (bbr *blockBuilderRegistryService) UpdateBlockBuilder(
 	// If the stake is more than 0.1 ETH and the URL has not changed, the update function is not executed.
 	if res.StakeAmount.Cmp(&bbr.cfg.Blockchain.ScrollNetworkStakeBalance) >= 0 && res.BlockBuilderUrl == url {
 		bbr.log.Debugf("Since the staking amount is sufficient and the URL has not changed, the registry was not updated.\n")
+		bbr.state.setURL(url)
 		return nil
 	}
 
@@ -242,6 +244,7 @@ func (bbr *blockBuilderRegistryService) UpdateBlockBuilder(
 		bbr.log.Debugf("The receipt of UpdateBlockBuilder: %s\n", string(receiptJSON))
 
 		errorsB.InsufficientFunds = false
+		bbr.state.setURL(url)
 		bbr.log.Debugf("Complete UpdateBlockBuilder\n")
 
 		return nil
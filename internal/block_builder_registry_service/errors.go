@@ -51,3 +51,15 @@ var ErrProcessingFuncStopOfBlockBuilderRegistryFail = errors.New(
 var ErrProcessingFuncUnStakeOfBlockBuilderRegistryFail = errors.New(
 	"failed to processing the func 'unstake' of 'block-builder-registry contract'",
 )
+
+// ErrBlockBuilderStopped error: the block builder is stopped in the block builder registry contract.
+var ErrBlockBuilderStopped = errors.New("the block builder is stopped in the block builder registry contract")
+
+// ErrBlockBuilderUnderStaked error: the stake of the block builder is insufficient.
+var ErrBlockBuilderUnderStaked = errors.New("the stake of the block builder is insufficient")
+
+// ErrBlockNumberFail error: failed to get the number of the latest block.
+var ErrBlockNumberFail = errors.New("failed to get the number of the latest block")
+
+// ErrFilterLogsFail error: failed to filter the events of the block builder registry contract.
+var ErrFilterLogsFail = errors.New("failed to filter the events of the block builder registry contract")
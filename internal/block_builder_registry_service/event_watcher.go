@@ -0,0 +1,408 @@
+package block_builder_registry_service
+
+import (
+	"context"
+	"errors"
+	"intmax2-node/internal/bindings"
+	errorsB "intmax2-node/internal/blockchain/errors"
+	"intmax2-node/internal/logger"
+	"intmax2-node/internal/open_telemetry"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dimiro1/health"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// blockBuilderState keeps the state of the block builder of the node in the block builder registry contract.
+type blockBuilderState struct {
+	mu sync.RWMutex
+	// info is nil until the state is loaded from the block builder registry contract.
+	info *IBlockBuilderRegistryBlockBuilderInfo
+	// url is the URL of the block builder registered by the node.
+	url string
+}
+
+func (s *blockBuilderState) setURL(url string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.url = url
+}
+
+func (s *blockBuilderState) setInfo(info *IBlockBuilderRegistryBlockBuilderInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.info = info
+}
+
+func (s *blockBuilderState) get() (info *IBlockBuilderRegistryBlockBuilderInfo, url string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.info, s.url
+}
+
+// Active returns the error when the block builder of the node is stopped
+// or under-staked in the block builder registry contract.
+func (bbr *blockBuilderRegistryService) Active() error {
+	info, _ := bbr.state.get()
+
+	return bbr.active(info)
+}
+
+func (bbr *blockBuilderRegistryService) active(info *IBlockBuilderRegistryBlockBuilderInfo) error {
+	if info == nil {
+		return nil
+	}
+
+	if info.StopTime != nil && info.StopTime.Sign() != 0 {
+		return ErrBlockBuilderStopped
+	}
+
+	if !info.IsValid ||
+		info.StakeAmount == nil ||
+		info.StakeAmount.Cmp(&bbr.cfg.Blockchain.ScrollNetworkStakeBalance) < 0 {
+		return ErrBlockBuilderUnderStaked
+	}
+
+	return nil
+}
+
+// Check returns the state of the block builder of the node in the block builder registry contract.
+// The health is down when the block builder is stopped, under-staked or is registered with another URL.
+func (bbr *blockBuilderRegistryService) Check(_ context.Context) (res health.Health) {
+	const (
+		statusKey     = "status"
+		urlKey        = "url"
+		stakeKey      = "stake_amount"
+		numSlashesKey = "num_slashes"
+
+		unknownStatus     = "unknown"
+		activeStatus      = "active"
+		stoppedStatus     = "stopped"
+		underStakedStatus = "under_staked"
+		urlMismatchStatus = "url_mismatch"
+	)
+
+	info, url := bbr.state.get()
+	if info == nil {
+		res.AddInfo(statusKey, unknownStatus)
+		res.Down()
+		return res
+	}
+
+	res.AddInfo(urlKey, info.BlockBuilderUrl)
+	res.AddInfo(stakeKey, info.StakeAmount.String())
+	res.AddInfo(numSlashesKey, info.NumSlashes.String())
+
+	switch err := bbr.active(info); {
+	case errors.Is(err, ErrBlockBuilderStopped):
+		res.AddInfo(statusKey, stoppedStatus)
+		res.Down()
+	case errors.Is(err, ErrBlockBuilderUnderStaked):
+		res.AddInfo(statusKey, underStakedStatus)
+		res.Down()
+	case url != "" && info.BlockBuilderUrl != url:
+		res.AddInfo(statusKey, urlMismatchStatus)
+		res.Down()
+	default:
+		res.AddInfo(statusKey, activeStatus)
+		res.Up()
+	}
+
+	return res
+}
+
+// Start watches the BlockBuilderUpdated, BlockBuilderStopped and BlockBuilderSlashed events
+// of the block builder of the node, applies them to its state and reloads the state from
+// the block builder registry contract on every tick. The changes of the state are alerted through the logs.
+// The events are filtered by the ranges of LOG_SCANNER_MAX_BLOCK_RANGE blocks, so the range does not grow
+// while the node of the chain fails.
+func (bbr *blockBuilderRegistryService) Start(
+	ctx context.Context,
+	tickerEventWatcher *time.Ticker,
+) (err error) {
+	const (
+		hName = "BlockBuilderRegistryService func:Start"
+	)
+
+	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName)
+	defer span.End()
+
+	link, err := bbr.sb.ScrollNetworkChainLinkEvmJSONRPC(spanCtx)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return errors.Join(ErrScrollNetworkChainLinkEvmJSONRPCFail, err)
+	}
+
+	var client *ethclient.Client
+	client, err = ethclient.Dial(link)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return errors.Join(ErrCreateNewClientOfRPCEthFail, err)
+	}
+	defer func() {
+		client.Close()
+	}()
+
+	var registry *bindings.BlockBuilderRegistry
+	registry, err = bindings.NewBlockBuilderRegistry(
+		common.HexToAddress(bbr.cfg.Blockchain.BlockBuilderRegistryContractAddress),
+		client,
+	)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return errors.Join(ErrNewBlockBuilderRegistryCallerFail, err)
+	}
+
+	privateKey, err := crypto.HexToECDSA(bbr.cfg.Blockchain.BuilderPrivateKeyHex)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return errors.Join(ErrLoadPrivateKeyFail, err)
+	}
+	builderAddress := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	var latestBlock uint64
+	latestBlock, err = client.BlockNumber(spanCtx)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return errors.Join(ErrBlockNumberFail, err)
+	}
+
+	err = bbr.reloadState(ctx, registry, builderAddress)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return err
+	}
+
+	nextBlock := latestBlock + 1
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-tickerEventWatcher.C:
+			latestBlock, err = client.BlockNumber(ctx)
+			if err != nil {
+				bbr.log.WithError(errors.Join(ErrBlockNumberFail, err)).Warnf("Failed to watch the block builder registry")
+				continue
+			}
+
+			for nextBlock <= latestBlock && ctx.Err() == nil {
+				end := latestBlock
+				if maxBlockRange := bbr.cfg.LogScanner.MaxBlockRange; maxBlockRange > 0 && end-nextBlock >= maxBlockRange {
+					end = nextBlock + maxBlockRange - 1
+				}
+
+				err = bbr.watchEvents(ctx, client, registry, builderAddress, nextBlock, end)
+				if err != nil {
+					break
+				}
+				nextBlock = end + 1
+			}
+			if err != nil {
+				bbr.log.WithError(err).Warnf("Failed to watch the block builder registry")
+				continue
+			}
+
+			err = bbr.reloadState(ctx, registry, builderAddress)
+			if err != nil {
+				bbr.log.WithError(err).Warnf("Failed to watch the block builder registry")
+			}
+		}
+	}
+}
+
+// registryEvent describes the event of the block builder which changes its state.
+type registryEvent struct {
+	raw   types.Log
+	apply func(info *IBlockBuilderRegistryBlockBuilderInfo)
+}
+
+// watchEvents logs the events of the block builder from the start block to the end block inclusive
+// and applies them to the state of the block builder in the order of the logs.
+func (bbr *blockBuilderRegistryService) watchEvents(
+	ctx context.Context,
+	client *ethclient.Client,
+	registry *bindings.BlockBuilderRegistry,
+	builderAddress common.Address,
+	start, end uint64,
+) error {
+	opts := bind.FilterOpts{Start: start, End: &end, Context: ctx}
+	_, url := bbr.state.get()
+
+	var events []registryEvent
+
+	updated, err := registry.FilterBlockBuilderUpdated(&opts, []common.Address{builderAddress})
+	if err != nil {
+		return errors.Join(ErrFilterLogsFail, err)
+	}
+	for updated.Next() {
+		e := updated.Event
+		bbr.log.WithFields(logger.Fields{
+			"block_number": e.Raw.BlockNumber,
+			"url":          e.Url,
+			"stake_amount": e.StakeAmount.String(),
+		}).Infof("The block builder is updated in the block builder registry")
+		if url != "" && e.Url != url {
+			bbr.log.Warnf("The block builder is registered with the URL %q instead of %q", e.Url, url)
+		}
+		// the update restarts the block builder with the whole stake
+		events = append(events, registryEvent{raw: e.Raw, apply: func(info *IBlockBuilderRegistryBlockBuilderInfo) {
+			info.BlockBuilderUrl = e.Url
+			info.StakeAmount = new(big.Int).Set(e.StakeAmount)
+			info.StopTime = new(big.Int)
+			info.IsValid = true
+		}})
+	}
+	err = updated.Error()
+	_ = updated.Close()
+	if err != nil {
+		return errors.Join(ErrFilterLogsFail, err)
+	}
+
+	stopped, err := registry.FilterBlockBuilderStopped(&opts, []common.Address{builderAddress})
+	if err != nil {
+		return errors.Join(ErrFilterLogsFail, err)
+	}
+	for stopped.Next() {
+		e := stopped.Event
+		bbr.log.WithFields(logger.Fields{
+			"block_number": e.Raw.BlockNumber,
+		}).Warnf("The block builder is stopped in the block builder registry")
+
+		var header *types.Header
+		header, err = client.HeaderByNumber(ctx, new(big.Int).SetUint64(e.Raw.BlockNumber))
+		if err != nil {
+			_ = stopped.Close()
+			return errors.Join(ErrFilterLogsFail, err)
+		}
+		stopTime := new(big.Int).SetUint64(header.Time)
+		events = append(events, registryEvent{raw: e.Raw, apply: func(info *IBlockBuilderRegistryBlockBuilderInfo) {
+			info.StopTime = new(big.Int).Set(stopTime)
+		}})
+	}
+	err = stopped.Error()
+	_ = stopped.Close()
+	if err != nil {
+		return errors.Join(ErrFilterLogsFail, err)
+	}
+
+	slashed, err := registry.FilterBlockBuilderSlashed(&opts, []common.Address{builderAddress}, nil)
+	if err != nil {
+		return errors.Join(ErrFilterLogsFail, err)
+	}
+	for slashed.Next() {
+		e := slashed.Event
+		bbr.log.WithFields(logger.Fields{
+			"block_number": e.Raw.BlockNumber,
+			"challenger":   e.Challenger.String(),
+		}).Errorf("The block builder is slashed in the block builder registry")
+		events = append(events, registryEvent{raw: e.Raw, apply: func(info *IBlockBuilderRegistryBlockBuilderInfo) {
+			const int1Key = 1
+			info.NumSlashes = new(big.Int).Add(info.NumSlashes, big.NewInt(int1Key))
+		}})
+	}
+	err = slashed.Error()
+	_ = slashed.Close()
+	if err != nil {
+		return errors.Join(ErrFilterLogsFail, err)
+	}
+
+	bbr.applyEvents(events)
+
+	return nil
+}
+
+// applyEvents applies the events to the state of the block builder. The state is not changed
+// until it is loaded from the block builder registry contract.
+func (bbr *blockBuilderRegistryService) applyEvents(events []registryEvent) {
+	prevInfo, _ := bbr.state.get()
+	if prevInfo == nil || len(events) == 0 {
+		return
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].raw.BlockNumber != events[j].raw.BlockNumber {
+			return events[i].raw.BlockNumber < events[j].raw.BlockNumber
+		}
+		return events[i].raw.Index < events[j].raw.Index
+	})
+
+	newInfo := *prevInfo
+	if newInfo.NumSlashes == nil {
+		newInfo.NumSlashes = new(big.Int)
+	}
+	for key := range events {
+		events[key].apply(&newInfo)
+	}
+
+	bbr.setState(prevInfo, &newInfo)
+}
+
+// reloadState reads the state of the block builder from the block builder registry contract
+// and logs the start and the stop of the acceptance of the transactions.
+func (bbr *blockBuilderRegistryService) reloadState(
+	ctx context.Context,
+	registry *bindings.BlockBuilderRegistry,
+	builderAddress common.Address,
+) error {
+	var (
+		newInfo IBlockBuilderRegistryBlockBuilderInfo
+		err     error
+	)
+	for {
+		newInfo, err = registry.BlockBuilders(&bind.CallOpts{Context: ctx}, builderAddress)
+		if err != nil {
+			switch {
+			case
+				strings.Contains(err.Error(), errorsB.Err520ScrollWebServerStr),
+				strings.Contains(err.Error(), errorsB.Err502ScrollWebServerStr):
+				if ctx.Err() == nil {
+					<-time.After(time.Second)
+					continue
+				}
+			}
+
+			return errors.Join(ErrGetBlockBuilderInfoFail, err)
+		}
+
+		break
+	}
+
+	prevInfo, _ := bbr.state.get()
+	bbr.setState(prevInfo, &newInfo)
+
+	return nil
+}
+
+// setState replaces the state of the block builder
+// and logs the start and the stop of the acceptance of the transactions.
+func (bbr *blockBuilderRegistryService) setState(prevInfo, newInfo *IBlockBuilderRegistryBlockBuilderInfo) {
+	bbr.state.setInfo(newInfo)
+
+	prevErr, newErr := bbr.active(prevInfo), bbr.active(newInfo)
+	if prevInfo != nil && prevInfo.NumSlashes.Cmp(newInfo.NumSlashes) < 0 {
+		bbr.log.WithFields(logger.Fields{
+			"num_slashes":  newInfo.NumSlashes.String(),
+			"stake_amount": newInfo.StakeAmount.String(),
+		}).Errorf("The number of the slashes of the block builder is increased")
+	}
+	switch {
+	case newErr != nil && (prevInfo == nil || prevErr == nil || !errors.Is(newErr, prevErr)):
+		bbr.log.WithError(newErr).WithFields(logger.Fields{
+			"stake_amount": newInfo.StakeAmount.String(),
+			"stop_time":    newInfo.StopTime.String(),
+		}).Errorf("The block builder does not accept the transactions")
+	case newErr == nil && prevErr != nil:
+		bbr.log.Infof("The block builder accepts the transactions again")
+	}
+}
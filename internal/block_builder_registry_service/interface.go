@@ -3,6 +3,9 @@ package block_builder_registry_service
 import (
 	"context"
 	"math/big"
+	"time"
+
+	"github.com/dimiro1/health"
 )
 
 type IBlockBuilderRegistryBlockBuilderInfo struct {
@@ -27,4 +30,10 @@ type BlockBuilderRegistryService interface {
 	UnStakeBlockBuilder(
 		ctx context.Context,
 	) (err error)
+	Start(
+		ctx context.Context,
+		tickerEventWatcher *time.Ticker,
+	) (err error)
+	Check(ctx context.Context) (res health.Health)
+	Active() error
 }
@@ -8,7 +8,6 @@ import (
 	"intmax2-node/internal/logger"
 	intMaxTypes "intmax2-node/internal/types"
 	"intmax2-node/pkg/utils"
-	"math/big"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -23,17 +22,16 @@ const (
 	postRegistrationBlockMethod    = "postRegistrationBlock"
 	postNonRegistrationBlockMethod = "postNonRegistrationBlock"
 
-	int0Key   = 0
-	int1Key   = 1
-	int2Key   = 2
-	int3Key   = 3
-	int4Key   = 4
-	int5Key   = 5
-	int6Key   = 6
-	int8Key   = 8
-	int16Key  = 16
-	int32Key  = 32
-	minus1Key = -1
+	int0Key  = 0
+	int1Key  = 1
+	int2Key  = 2
+	int3Key  = 3
+	int4Key  = 4
+	int5Key  = 5
+	int6Key  = 6
+	int8Key  = 8
+	int16Key = 16
+	int32Key = 32
 )
 
 type blockPostService struct {
@@ -98,16 +96,18 @@ func (d *blockPostService) FetchLatestBlockNumber(ctx context.Context) (uint64,
 	return blockNumber, nil
 }
 
-func (d *blockPostService) FetchNewPostedBlocks(startBlock uint64) ([]*bindings.RollupBlockPosted, *big.Int, error) {
-	nextBlock := startBlock + int1Key
-
+// FetchNewPostedBlocks returns the BlockPosted events of the Scroll blocks from start to end inclusive.
+func (d *blockPostService) FetchNewPostedBlocks(
+	ctx context.Context,
+	startBlock, endBlock uint64,
+) ([]*bindings.RollupBlockPosted, error) {
 	iterator, err := d.rollup.FilterBlockPosted(&bind.FilterOpts{
-		Start:   nextBlock,
-		End:     nil,
-		Context: d.ctx,
+		Start:   startBlock,
+		End:     &endBlock,
+		Context: ctx,
 	}, [][int32Key]byte{}, []common.Address{})
 	if err != nil {
-		return nil, nil, errors.Join(ErrFilterLogsFail, err)
+		return nil, errors.Join(ErrFilterLogsFail, err)
 	}
 
 	defer func() {
@@ -115,22 +115,15 @@ func (d *blockPostService) FetchNewPostedBlocks(startBlock uint64) ([]*bindings.
 	}()
 
 	var events []*bindings.RollupBlockPosted
-	maxBlockNumber := new(big.Int).SetUint64(startBlock)
-
 	for iterator.Next() {
-		event := iterator.Event
-		events = append(events, event)
-		currBN := new(big.Int).SetUint64(event.Raw.BlockNumber)
-		if maxBlockNumber.Cmp(currBN) == minus1Key {
-			maxBlockNumber.Set(currBN)
-		}
+		events = append(events, iterator.Event)
 	}
 
 	if err = iterator.Error(); err != nil {
-		return nil, nil, errors.Join(ErrEncounteredWhileIterating, err)
+		return nil, errors.Join(ErrEncounteredWhileIterating, err)
 	}
 
-	return events, maxBlockNumber, nil
+	return events, nil
 }
 
 func (d *blockPostService) FetchScrollCalldataByHash(txHash common.Hash) ([]byte, error) {
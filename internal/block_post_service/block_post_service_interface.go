@@ -4,14 +4,13 @@ import (
 	"context"
 	intMaxAcc "intmax2-node/internal/accounts"
 	"intmax2-node/internal/bindings"
-	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 )
 
 type BlockPostService interface {
 	FetchLatestBlockNumber(ctx context.Context) (uint64, error)
-	FetchNewPostedBlocks(startBlock uint64) ([]*bindings.RollupBlockPosted, *big.Int, error)
+	FetchNewPostedBlocks(ctx context.Context, startBlock, endBlock uint64) ([]*bindings.RollupBlockPosted, error)
 	FetchScrollCalldataByHash(txHash common.Hash) ([]byte, error)
 	BackupTransaction(
 		sender intMaxAcc.Address,
@@ -7,12 +7,15 @@ import (
 	"fmt"
 	"intmax2-node/configs"
 	"intmax2-node/internal/bindings"
+	"intmax2-node/internal/log_scanner"
 	"intmax2-node/internal/logger"
 	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
 	errorsDB "intmax2-node/pkg/sql_db/errors"
+	"intmax2-node/pkg/utils"
 	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/holiman/uint256"
 )
 
@@ -102,6 +105,9 @@ func StartBlocksFetcher(
 	}
 }
 
+// ProcessingPostedBlocks registers the accounts of the blocks posted to the Rollup contract.
+// Since the account IDs are assigned in the order of the registration, the accounts registered
+// after the fork point are deleted when a reorg is detected and registered again from the fork point.
 func ProcessingPostedBlocks(
 	ctx context.Context,
 	cfg *configs.Config,
@@ -114,58 +120,83 @@ func ProcessingPostedBlocks(
 		return errors.Join(ErrNewBlockPostServiceFail, err)
 	}
 
-	var (
-		bn  uint64
-		ebn *mDBApp.EventBlockNumber
-	)
-
-	ebn, err = dbApp.EventBlockNumberByEventName(mDBApp.BlockPostedEvent)
-	switch {
-	case err != nil:
-		if !errors.Is(err, errorsDB.ErrNotFound) {
-			const msg = "error fetching event block number: %w"
-			return fmt.Errorf(msg, err)
-		}
-
-		fallthrough
-	default:
-		if ebn == nil {
-			ebn = &mDBApp.EventBlockNumber{
-				EventName:                mDBApp.BlockPostedEvent,
-				LastProcessedBlockNumber: 0,
+	var scrollClient *ethclient.Client
+	scrollClient, err = utils.NewClient(scrollNetworkRpcUrl)
+	if err != nil {
+		return errors.Join(ErrNewScrollClientFail, err)
+	}
+	defer scrollClient.Close()
+
+	scanner := log_scanner.New(cfg, lg, dbApp, scrollClient, cfg.LogScanner.ScrollConfirmations)
+
+	return scanner.Scan(ctx, &log_scanner.Handler{
+		EventName:           mDBApp.BlockPostedEvent,
+		DeployedBlockNumber: cfg.Blockchain.RollupContractDeployedBlockNumber,
+		Process: func(ctx context.Context, d interface{}, start, end uint64) error {
+			return processPostedBlocks(ctx, cfg, lg, d.(SQLDriverApp), bps, start, end)
+		},
+		Rollback: func(_ context.Context, d interface{}, blockNumber uint64) (uint64, error) {
+			err := rollbackAccounts(d.(SQLDriverApp), blockNumber)
+			if err != nil {
+				return 0, err
 			}
-		}
-		bn = ebn.LastProcessedBlockNumber
+
+			return blockNumber, nil
+		},
+	})
+}
+
+// rollbackAccounts deletes the accounts registered after the Scroll block number
+// and restarts the sequence of the account IDs after the remaining accounts.
+func rollbackAccounts(dbApp SQLDriverApp, blockNumber uint64) error {
+	err := dbApp.DelAccountsAfterBlockNumber(blockNumber)
+	if err != nil {
+		return errors.Join(ErrDelAccountsAfterBlockNumberFail, err)
 	}
 
-	if ebn.LastProcessedBlockNumber == 0 {
-		bn = cfg.Blockchain.RollupContractDeployedBlockNumber
+	err = dbApp.ResetSequenceByAccounts()
+	if err != nil {
+		return errors.Join(ErrResetSequenceByAccountsFail, err)
+	}
 
-		err = dbApp.DelAllAccounts()
-		if err != nil {
-			return errors.Join(ErrDelAllAccountsFail, err)
-		}
+	return nil
+}
 
-		err = dbApp.ResetSequenceByAccounts()
+func resetAccounts(dbApp SQLDriverApp) error {
+	err := dbApp.DelAllAccounts()
+	if err != nil {
+		return errors.Join(ErrDelAllAccountsFail, err)
+	}
+
+	err = dbApp.ResetSequenceByAccounts()
+	if err != nil {
+		return errors.Join(ErrResetSequenceByAccountsFail, err)
+	}
+
+	return nil
+}
+
+func processPostedBlocks(
+	ctx context.Context,
+	cfg *configs.Config,
+	lg logger.Logger,
+	dbApp SQLDriverApp,
+	bps BlockPostService,
+	start, end uint64,
+) (err error) {
+	if start == cfg.Blockchain.RollupContractDeployedBlockNumber+int1Key {
+		err = resetAccounts(dbApp)
 		if err != nil {
-			return errors.Join(ErrResetSequenceByAccountsFail, err)
+			return err
 		}
 	}
 
-	var (
-		events []*bindings.RollupBlockPosted
-		nextBN *big.Int
-	)
-
-	events, nextBN, err = bps.FetchNewPostedBlocks(bn)
+	var events []*bindings.RollupBlockPosted
+	events, err = bps.FetchNewPostedBlocks(ctx, start, end)
 	if err != nil {
 		return errors.Join(ErrFetchNewPostedBlocksFail, err)
 	}
 
-	if len(events) == 0 {
-		return nil
-	}
-
 	ai := NewAccountInfo(dbApp)
 	for key := range events {
 		var blN uint256.Int
@@ -179,6 +210,13 @@ func ProcessingPostedBlocks(
 
 		intMaxBlockNumber := events[key].BlockNumber
 		_, err = FetchIntMaxBlockContentByCalldata(cd, ai)
+
+		// The accounts registered by the block are rolled back by the Scroll block number on a reorg.
+		errUpd := dbApp.UpdateBlockNumberOfNewAccounts(events[key].Raw.BlockNumber)
+		if errUpd != nil {
+			return errors.Join(ErrUpdateBlockNumberOfNewAccountsFail, errUpd)
+		}
+
 		if err != nil {
 			err = errors.Join(ErrFetchIntMaxBlockContentByCalldataFail, err)
 			switch {
@@ -215,14 +253,5 @@ func ProcessingPostedBlocks(
 		lg.Debugf(msg, intMaxBlockNumber.String(), blN.String())
 	}
 
-	err = updateEventBlockNumber(dbApp, lg, mDBApp.BlockPostedEvent, nextBN.Uint64())
-	if err != nil {
-		const msg = "failed to update event block number: %v"
-		return fmt.Errorf(msg, err.Error())
-	}
-
-	const msg = "next Scroll block number %q"
-	lg.Debugf(msg, nextBN.String())
-
 	return nil
 }
@@ -24,8 +24,13 @@ type GenericCommandsApp interface {
 
 type EventBlockNumbers interface {
 	UpsertEventBlockNumber(eventName string, blockNumber uint64) (*mDBApp.EventBlockNumber, error)
+	UpsertEventBlockNumberAndHash(eventName string, blockNumber uint64, blockHash string) (*mDBApp.EventBlockNumber, error)
 	EventBlockNumberByEventName(eventName string) (*mDBApp.EventBlockNumber, error)
 	EventBlockNumbersByEventNames(eventNames []string) ([]*mDBApp.EventBlockNumber, error)
+	UpsertEventBlockHash(eventName string, blockNumber uint64, blockHash string) error
+	EventBlockHashesByEventName(eventName string) ([]*mDBApp.EventBlockHash, error)
+	DelEventBlockHashesAfterBlockNumber(eventName string, blockNumber uint64) error
+	DelEventBlockHashesBeforeBlockNumber(eventName string, blockNumber uint64) error
 }
 
 type CtrlEventBlockNumbersJobs interface {
@@ -60,5 +65,7 @@ type Accounts interface {
 	AccountBySenderID(senderID string) (*mDBApp.Account, error)
 	AccountByAccountID(accountID *uint256.Int) (*mDBApp.Account, error)
 	ResetSequenceByAccounts() error
+	UpdateBlockNumberOfNewAccounts(blockNumber uint64) error
+	DelAccountsAfterBlockNumber(blockNumber uint64) error
 	DelAllAccounts() error
 }
@@ -110,6 +110,12 @@ var ErrDecodeHexToPublicKeyFail = errors.New("failed to decode hex to public key
 // ErrDelAllAccountsFail error: failed to delete all accounts.
 var ErrDelAllAccountsFail = errors.New("failed to delete all accounts")
 
+// ErrDelAccountsAfterBlockNumberFail error: failed to delete the accounts registered after the block number.
+var ErrDelAccountsAfterBlockNumberFail = errors.New("failed to delete the accounts registered after the block number")
+
+// ErrUpdateBlockNumberOfNewAccountsFail error: failed to update the block number of the new accounts.
+var ErrUpdateBlockNumberOfNewAccountsFail = errors.New("failed to update the block number of the new accounts")
+
 // ErrResetSequenceByAccountsFail error: failed to reset sequence by accounts.
 var ErrResetSequenceByAccountsFail = errors.New("failed to reset sequence by accounts")
 
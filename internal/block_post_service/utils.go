@@ -6,7 +6,6 @@ import (
 	intMaxAcc "intmax2-node/internal/accounts"
 	"intmax2-node/internal/bindings"
 	"intmax2-node/internal/finite_field"
-	"intmax2-node/internal/logger"
 	intMaxTypes "intmax2-node/internal/types"
 	"io"
 	"math/big"
@@ -334,15 +333,9 @@ func recoverBlockContent(
 			return nil, fmt.Errorf("failed to validate block content: %w", err)
 		}
 
-		defaultAddress := intMaxAcc.NewDummyPublicKey().ToAddress().String()
-		for index := range blockContent.Senders {
-			address := blockContent.Senders[index].PublicKey.ToAddress().String()
-			if !strings.EqualFold(address, defaultAddress) {
-				err = ai.RegisterPublicKey(blockContent.Senders[index].PublicKey)
-				if err != nil {
-					return nil, errors.Join(ErrRegisterPublicKeyFail, err)
-				}
-			}
+		err = registerSigners(blockContent, ai)
+		if err != nil {
+			return nil, errors.Join(ErrRegisterPublicKeyFail, err)
 		}
 
 		return blockContent, nil
@@ -363,15 +356,9 @@ func recoverBlockContent(
 			return nil, fmt.Errorf("failed to validate block content: %w", err)
 		}
 
-		defaultAddress := intMaxAcc.NewDummyPublicKey().ToAddress().String()
-		for index := range blockContent.Senders {
-			address := blockContent.Senders[index].PublicKey.ToAddress().String()
-			if !strings.EqualFold(address, defaultAddress) {
-				err = ai.RegisterPublicKey(blockContent.Senders[index].PublicKey)
-				if err != nil {
-					return nil, errors.Join(ErrRegisterPublicKeyFail, err)
-				}
-			}
+		err = registerSigners(blockContent, ai)
+		if err != nil {
+			return nil, errors.Join(ErrRegisterPublicKeyFail, err)
 		}
 
 		return blockContent, nil
@@ -380,6 +367,29 @@ func recoverBlockContent(
 	}
 }
 
+// registerSigners registers the public keys of the senders who signed the block.
+// The senders whose signature bit is not set are not included in the account tree.
+func registerSigners(blockContent *intMaxTypes.BlockContent, ai AccountInfo) error {
+	defaultAddress := intMaxAcc.NewDummyPublicKey().ToAddress().String()
+	for index := range blockContent.Senders {
+		if !blockContent.Senders[index].IsSigned {
+			continue
+		}
+
+		address := blockContent.Senders[index].PublicKey.ToAddress().String()
+		if strings.EqualFold(address, defaultAddress) {
+			continue
+		}
+
+		err := ai.RegisterPublicKey(blockContent.Senders[index].PublicKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func decodePostRegistrationBlockCalldata(
 	method *abi.Method,
 	calldata []byte,
@@ -584,12 +594,3 @@ func recoverNonRegistrationBlockContent(
 
 	return blockContent, nil
 }
-
-func updateEventBlockNumber(db SQLDriverApp, log logger.Logger, eventName string, blockNumber uint64) error {
-	updatedEvent, err := db.UpsertEventBlockNumber(eventName, blockNumber)
-	if err != nil {
-		return err
-	}
-	log.Infof("Updated %s block number to %d", eventName, updatedEvent.LastProcessedBlockNumber)
-	return nil
-}
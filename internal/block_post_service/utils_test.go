@@ -0,0 +1,116 @@
+package block_post_service_test
+
+import (
+	"crypto/rand"
+	"intmax2-node/internal/accounts"
+	"intmax2-node/internal/bindings"
+	"intmax2-node/internal/block_post_service"
+	"intmax2-node/internal/finite_field"
+	intMaxTypes "intmax2-node/internal/types"
+	"math/big"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+type registeredAccounts struct {
+	publicKeys []*accounts.PublicKey
+}
+
+func (r *registeredAccounts) RegisterPublicKey(pk *accounts.PublicKey) error {
+	r.publicKeys = append(r.publicKeys, pk)
+	return nil
+}
+
+func (r *registeredAccounts) PublicKeyByAccountID(_ uint64) (*accounts.PublicKey, error) {
+	return nil, block_post_service.ErrRegisterPublicKeyFail
+}
+
+func (r *registeredAccounts) AccountBySenderAddress(_ string) (*uint256.Int, error) {
+	return nil, block_post_service.ErrRegisterPublicKeyFail
+}
+
+func TestFetchIntMaxBlockContentByCalldataRegistersSigners(t *testing.T) {
+	const numOfKeys = 3
+	keyPairs := make([]*accounts.PrivateKey, numOfKeys)
+	for i := range keyPairs {
+		privateKey, err := rand.Int(rand.Reader, new(big.Int).Sub(fr.Modulus(), big.NewInt(1)))
+		require.NoError(t, err)
+		privateKey.Add(privateKey, big.NewInt(1))
+		keyPairs[i], err = accounts.NewPrivateKeyWithReCalcPubKeyIfPkNegates(privateKey)
+		require.NoError(t, err)
+	}
+	sort.Slice(keyPairs, func(i, j int) bool {
+		return keyPairs[i].Pk.X.Cmp(&keyPairs[j].Pk.X) > 0
+	})
+
+	// The last sender is included in the block without signing it.
+	senders := make([]intMaxTypes.Sender, intMaxTypes.NumOfSenders)
+	for i, keyPair := range keyPairs {
+		senders[i] = intMaxTypes.Sender{
+			PublicKey: keyPair.Public(),
+			IsSigned:  i < numOfKeys-1,
+		}
+	}
+	for i := numOfKeys; i < len(senders); i++ {
+		senders[i] = intMaxTypes.NewDummySender()
+	}
+
+	txRoot, err := new(intMaxTypes.PoseidonHashOut).SetRandom()
+	require.NoError(t, err)
+
+	senderPublicKeysBytes := make([]byte, intMaxTypes.NumOfSenders*intMaxTypes.NumPublicKeyBytes)
+	for i := range senders {
+		senderPublicKey := senders[i].PublicKey.Pk.X.Bytes() // Only x coordinate is used
+		copy(senderPublicKeysBytes[32*i:32*(i+1)], senderPublicKey[:])
+	}
+	publicKeysHash := crypto.Keccak256(senderPublicKeysBytes)
+
+	message := finite_field.BytesToFieldElementSlice(txRoot.Marshal())
+	aggregatedSignature := new(bn254.G2Affine)
+	for i, keyPair := range keyPairs {
+		if senders[i].IsSigned {
+			signature, errSign := keyPair.WeightByHash(publicKeysHash).Sign(message)
+			require.NoError(t, errSign)
+			aggregatedSignature.Add(aggregatedSignature, signature)
+		}
+	}
+
+	blockContent := intMaxTypes.NewBlockContent(
+		intMaxTypes.PublicKeySenderType,
+		senders,
+		*txRoot,
+		aggregatedSignature,
+	)
+	input, err := intMaxTypes.MakePostRegistrationBlockInput(blockContent)
+	require.NoError(t, err)
+
+	parsedABI, err := abi.JSON(strings.NewReader(bindings.RollupMetaData.ABI))
+	require.NoError(t, err)
+	calldata, err := parsedABI.Pack(
+		"postRegistrationBlock",
+		input.TxTreeRoot,
+		input.SenderFlags,
+		input.AggregatedPublicKey,
+		input.AggregatedSignature,
+		input.MessagePoint,
+		input.SenderPublicKeys,
+	)
+	require.NoError(t, err)
+
+	ai := new(registeredAccounts)
+	_, err = block_post_service.FetchIntMaxBlockContentByCalldata(calldata, ai)
+	require.NoError(t, err)
+
+	require.Len(t, ai.publicKeys, numOfKeys-1)
+	for i := range ai.publicKeys {
+		require.True(t, ai.publicKeys[i].Equal(keyPairs[i].Public()))
+	}
+}
@@ -0,0 +1,176 @@
+package block_validity_prover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	intMaxAcc "intmax2-node/internal/accounts"
+	"intmax2-node/internal/bindings"
+	"intmax2-node/internal/block_post_service"
+	intMaxTree "intmax2-node/internal/tree"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/holiman/uint256"
+)
+
+// initAccountTree rebuilds the account tree from the registered accounts stored in the database.
+func (w *blockValidityProver) initAccountTree() error {
+	return w.loadAccountTree(w.dbApp)
+}
+
+// loadAccountTree rebuilds the account tree from the registered accounts read with the database driver q.
+func (w *blockValidityProver) loadAccountTree(q SQLDriverApp) error {
+	registeredAccounts, err := q.RegisteredAccounts()
+	if err != nil {
+		return errors.Join(ErrRegisteredAccountsFail, err)
+	}
+
+	var accountTree *intMaxTree.AccountTree
+	accountTree, err = NewAccountTreeFromRegisteredAccounts(registeredAccounts)
+	if err != nil {
+		return err
+	}
+
+	w.accountTreeMu.Lock()
+	defer w.accountTreeMu.Unlock()
+
+	w.accountTree = accountTree
+
+	return nil
+}
+
+// registerAccounts adds the public keys of the senders of the posted block to the account tree.
+// The account IDs are assigned in the order of the registration, so the blocks must be
+// registered in the order of their block numbers.
+func (w *blockValidityProver) registerAccounts(
+	ctx context.Context,
+	q SQLDriverApp,
+	scrollClient *ethclient.Client,
+	event *bindings.RollupBlockPosted,
+) error {
+	tx, _, err := scrollClient.TransactionByHash(ctx, event.Raw.TxHash)
+	if err != nil {
+		return errors.Join(ErrTransactionByHashFail, err)
+	}
+
+	w.accountTreeMu.Lock()
+	defer w.accountTreeMu.Unlock()
+
+	ai := accountTreeInfo{
+		w:                 w,
+		q:                 q,
+		blockNumber:       uint32(event.BlockNumber.Uint64()),
+		postedBlockNumber: event.Raw.BlockNumber,
+	}
+	_, err = block_post_service.FetchIntMaxBlockContentByCalldata(tx.Data(), &ai)
+	if errors.Is(err, block_post_service.ErrRegisterPublicKeyFail) {
+		return err
+	}
+	if err != nil {
+		// The invalid block does not register its senders.
+		const msg = "failed to recover the content of block %d, its senders are not registered"
+		w.log.WithError(err).Warnf(msg, ai.blockNumber)
+	}
+
+	return nil
+}
+
+func (w *blockValidityProver) accountIDByPublicKey(publicKey *intMaxAcc.PublicKey) (uint64, error) {
+	accountID, ok := w.accountTree.Index(publicKey.BigInt())
+	if !ok {
+		return 0, ErrAccountNotFound
+	}
+
+	return accountID, nil
+}
+
+func (w *blockValidityProver) publicKeyByAccountID(accountID uint64) (*intMaxAcc.PublicKey, error) {
+	if accountID == intMaxTree.DefaultAccountID {
+		return nil, ErrAccountNotFound
+	}
+
+	leaf, err := w.accountTree.Leaf(accountID)
+	if err != nil {
+		return nil, errors.Join(ErrAccountNotFound, err)
+	}
+
+	return intMaxAcc.NewPublicKeyFromAddressInt(leaf.Key)
+}
+
+// NewAccountTreeFromRegisteredAccounts builds the account tree from the registered accounts ordered by their account ID.
+func NewAccountTreeFromRegisteredAccounts(registeredAccounts []*mDBApp.RegisteredAccount) (*intMaxTree.AccountTree, error) {
+	accountTree, err := intMaxTree.NewAccountTree(intMaxTree.ACCOUNT_TREE_HEIGHT)
+	if err != nil {
+		return nil, errors.Join(ErrNewAccountTreeFail, err)
+	}
+
+	for key := range registeredAccounts {
+		var publicKey *intMaxAcc.PublicKey
+		publicKey, err = intMaxAcc.NewPublicKeyFromAddressHex(registeredAccounts[key].PublicKey)
+		if err != nil {
+			return nil, errors.Join(ErrNewAccountTreeFail, err)
+		}
+
+		var accountID uint64
+		accountID, err = accountTree.Insert(publicKey.BigInt(), uint64(registeredAccounts[key].BlockNumber))
+		if err != nil {
+			return nil, errors.Join(ErrInsertAccountFail, err)
+		}
+
+		if accountID != registeredAccounts[key].AccountID {
+			return nil, fmt.Errorf(
+				"%w: expected %d, got %d", ErrAccountIDInvalid, accountID, registeredAccounts[key].AccountID,
+			)
+		}
+	}
+
+	return accountTree, nil
+}
+
+// accountTreeInfo registers the senders of the posted block in the account tree and in the database.
+// The caller must hold the lock of the account tree.
+type accountTreeInfo struct {
+	w                 *blockValidityProver
+	q                 SQLDriverApp
+	blockNumber       uint32
+	postedBlockNumber uint64
+}
+
+func (ai *accountTreeInfo) RegisterPublicKey(pk *intMaxAcc.PublicKey) error {
+	if _, err := ai.w.accountIDByPublicKey(pk); err == nil {
+		return nil
+	}
+
+	accountID, err := ai.w.accountTree.Insert(pk.BigInt(), uint64(ai.blockNumber))
+	if err != nil {
+		return errors.Join(ErrInsertAccountFail, err)
+	}
+
+	_, err = ai.q.CreateRegisteredAccount(accountID, pk.ToAddress().String(), ai.blockNumber, ai.postedBlockNumber)
+	if err != nil {
+		return errors.Join(ErrCreateRegisteredAccountFail, err)
+	}
+
+	ai.w.log.Debugf("Registered account %d in block %d\n", accountID, ai.blockNumber)
+
+	return nil
+}
+
+func (ai *accountTreeInfo) PublicKeyByAccountID(accountID uint64) (*intMaxAcc.PublicKey, error) {
+	return ai.w.publicKeyByAccountID(accountID)
+}
+
+func (ai *accountTreeInfo) AccountBySenderAddress(senderAddress string) (*uint256.Int, error) {
+	publicKey, err := intMaxAcc.NewPublicKeyFromAddressHex(senderAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	accountID, err := ai.w.accountIDByPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return uint256.NewInt(accountID), nil
+}
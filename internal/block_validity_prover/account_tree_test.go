@@ -0,0 +1,54 @@
+package block_validity_prover_test
+
+import (
+	intMaxAcc "intmax2-node/internal/accounts"
+	"intmax2-node/internal/block_validity_prover"
+	intMaxTree "intmax2-node/internal/tree"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAccountTreeFromRegisteredAccounts(t *testing.T) {
+	registeredAccounts := make([]*mDBApp.RegisteredAccount, 3)
+	for i := range registeredAccounts {
+		privateKey, err := intMaxAcc.NewPrivateKeyWithReCalcPubKeyIfPkNegates(big.NewInt(int64(i + 2)))
+		require.NoError(t, err)
+
+		registeredAccounts[i] = &mDBApp.RegisteredAccount{
+			AccountID:   uint64(i + 2),
+			PublicKey:   privateKey.Public().ToAddress().String(),
+			BlockNumber: uint32(i + 1),
+		}
+	}
+
+	accountTree, err := block_validity_prover.NewAccountTreeFromRegisteredAccounts(registeredAccounts)
+	require.NoError(t, err)
+
+	_, count := accountTree.GetCurrentRootAndCount()
+	assert.Equal(t, uint64(len(registeredAccounts)+2), count)
+
+	for i := range registeredAccounts {
+		publicKey, errPK := intMaxAcc.NewPublicKeyFromAddressHex(registeredAccounts[i].PublicKey)
+		require.NoError(t, errPK)
+
+		accountID, ok := accountTree.Index(publicKey.BigInt())
+		assert.True(t, ok)
+		assert.Equal(t, registeredAccounts[i].AccountID, accountID)
+
+		leaf, errLeaf := accountTree.Leaf(accountID)
+		require.NoError(t, errLeaf)
+		assert.Equal(t, uint64(registeredAccounts[i].BlockNumber), leaf.Value)
+	}
+
+	dummyAccountID, ok := accountTree.Index(intMaxAcc.NewDummyPublicKey().BigInt())
+	assert.True(t, ok)
+	assert.Equal(t, uint64(intMaxTree.DummyAccountID), dummyAccountID)
+
+	// The account IDs must follow the order of the registration.
+	_, err = block_validity_prover.NewAccountTreeFromRegisteredAccounts(registeredAccounts[1:])
+	assert.ErrorIs(t, err, block_validity_prover.ErrAccountIDInvalid)
+}
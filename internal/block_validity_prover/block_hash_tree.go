@@ -0,0 +1,357 @@
+package block_validity_prover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"intmax2-node/internal/bindings"
+	"intmax2-node/internal/log_scanner"
+	intMaxTree "intmax2-node/internal/tree"
+	intMaxTypes "intmax2-node/internal/types"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	int1Key  = 1
+	int32Key = 32
+)
+
+// initBlockHashTree rebuilds the block hash tree from the block hashes stored in the database.
+func (w *blockValidityProver) initBlockHashTree() error {
+	return w.loadBlockHashTree(w.dbApp)
+}
+
+// loadBlockHashTree rebuilds the block hash tree from the block hashes read with the database driver q.
+func (w *blockValidityProver) loadBlockHashTree(q SQLDriverApp) error {
+	blockHashes, err := q.BlockHashes()
+	if err != nil {
+		return errors.Join(ErrBlockHashesFail, err)
+	}
+
+	var blockHashTree *intMaxTree.BlockHashTree
+	blockHashTree, err = NewBlockHashTreeFromBlockHashes(blockHashes)
+	if err != nil {
+		return err
+	}
+
+	w.blockHashTreeMu.Lock()
+	defer w.blockHashTreeMu.Unlock()
+
+	w.blockHashTree = blockHashTree
+
+	return nil
+}
+
+// syncBlockHashTree appends the hashes of the blocks posted to the Rollup contract to the block hash tree,
+// registers their senders in the account tree and compares the block hash tree with the block hashes
+// of the Rollup contract.
+func (w *blockValidityProver) syncBlockHashTree(
+	ctx context.Context,
+	scanner log_scanner.LogScanner,
+	scrollClient *ethclient.Client,
+	rollup *bindings.Rollup,
+) {
+	err := w.fetchNewBlockHashes(ctx, scanner, scrollClient, rollup)
+	if err != nil {
+		const msg = "failed to sync block hash tree"
+		w.log.WithError(err).Errorf(msg)
+
+		// The database transaction was rolled back, so the trees must follow it.
+		err = w.initBlockHashTree()
+		if err != nil {
+			const msg = "failed to init block hash tree"
+			w.log.WithError(err).Errorf(msg)
+		}
+
+		err = w.initAccountTree()
+		if err != nil {
+			const msg = "failed to init account tree"
+			w.log.WithError(err).Errorf(msg)
+		}
+
+		return
+	}
+
+	err = w.checkBlockHashTree(ctx, rollup)
+	if err != nil {
+		const msg = "failed to check block hash tree"
+		w.log.WithError(err).Warnf(msg)
+	}
+}
+
+// fetchNewBlockHashes stores the hashes and the registered accounts of the posted blocks.
+// The blocks posted in the reorganized blocks of the Scroll network are removed from the block hash tree
+// and the account tree.
+func (w *blockValidityProver) fetchNewBlockHashes(
+	ctx context.Context,
+	scanner log_scanner.LogScanner,
+	scrollClient *ethclient.Client,
+	rollup *bindings.Rollup,
+) error {
+	return scanner.Scan(ctx, &log_scanner.Handler{
+		EventName:           mDBApp.BlockHashTreeEvent,
+		DeployedBlockNumber: w.cfg.Blockchain.RollupContractDeployedBlockNumber,
+		Process: func(ctx context.Context, d interface{}, start, end uint64) error {
+			return w.storeNewBlockHashes(ctx, d.(SQLDriverApp), scrollClient, rollup, start, end)
+		},
+		Rollback: func(_ context.Context, d interface{}, blockNumber uint64) (uint64, error) {
+			q := d.(SQLDriverApp)
+
+			err := q.DelBlockHashesAfterPostedBlockNumber(blockNumber)
+			if err != nil {
+				return 0, errors.Join(ErrDelBlockHashesAfterPostedBlockNumberFail, err)
+			}
+
+			err = q.DelRegisteredAccountsAfterPostedBlockNumber(blockNumber)
+			if err != nil {
+				return 0, errors.Join(ErrDelRegisteredAccountsAfterPostedBlockNumberFail, err)
+			}
+
+			return blockNumber, nil
+		},
+		Reload: func(_ context.Context) error {
+			err := w.initBlockHashTree()
+			if err != nil {
+				return err
+			}
+
+			return w.initAccountTree()
+		},
+	})
+}
+
+func (w *blockValidityProver) storeNewBlockHashes(
+	ctx context.Context,
+	q SQLDriverApp,
+	scrollClient *ethclient.Client,
+	rollup *bindings.Rollup,
+	start, end uint64,
+) error {
+	iterator, err := rollup.FilterBlockPosted(&bind.FilterOpts{
+		Start:   start,
+		End:     &end,
+		Context: ctx,
+	}, [][int32Key]byte{}, []common.Address{})
+	if err != nil {
+		return errors.Join(ErrFilterLogsFail, err)
+	}
+
+	defer func() {
+		_ = iterator.Close()
+	}()
+
+	var events []*bindings.RollupBlockPosted
+	for iterator.Next() {
+		events = append(events, iterator.Event)
+	}
+
+	if err = iterator.Error(); err != nil {
+		return errors.Join(ErrEncounteredWhileIterating, err)
+	}
+
+	w.blockHashTreeMu.Lock()
+	defer w.blockHashTreeMu.Unlock()
+
+	_, count, _ := w.blockHashTree.GetCurrentRootCountAndSiblings()
+	if count == 0 {
+		// The genesis block is not announced by a BlockPosted event.
+		var genesisBlockHash [int32Key]byte
+		genesisBlockHash, err = rollup.GetBlockHash(&bind.CallOpts{Context: ctx}, 0)
+		if err != nil {
+			return errors.Join(ErrGetBlockHashFail, err)
+		}
+
+		err = w.appendBlockHash(q, &bindings.RollupBlockPosted{BlockNumber: common.Big0}, genesisBlockHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	for key := range events {
+		_, count, _ = w.blockHashTree.GetCurrentRootCountAndSiblings()
+		if uint32(events[key].BlockNumber.Uint64()) < count {
+			// The block has already been added.
+			continue
+		}
+
+		postedBlock := intMaxTypes.NewPostedBlock(
+			events[key].PrevBlockHash,
+			events[key].DepositTreeRoot,
+			uint32(events[key].BlockNumber.Uint64()),
+			events[key].SignatureHash,
+		)
+
+		err = w.appendBlockHash(q, events[key], postedBlock.Hash())
+		if err != nil {
+			return err
+		}
+
+		err = w.registerAccounts(ctx, q, scrollClient, events[key])
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(events) != 0 {
+		w.log.Debugf("Stored %d new block hashes\n", len(events))
+	}
+
+	return nil
+}
+
+// appendBlockHash stores the block hash and adds it to the block hash tree.
+// Every block hash commits to the previous one, so the chain of previous block hashes
+// is checked before the block hash is added.
+func (w *blockValidityProver) appendBlockHash(
+	q SQLDriverApp,
+	event *bindings.RollupBlockPosted,
+	blockHash common.Hash,
+) error {
+	_, count, _ := w.blockHashTree.GetCurrentRootCountAndSiblings()
+	blockNumber := uint32(event.BlockNumber.Uint64())
+	if blockNumber < count {
+		// The block hash has already been added.
+		return nil
+	}
+
+	if blockNumber != count {
+		return fmt.Errorf("%w: expected %d, got %d", ErrBlockNumberInvalid, count, blockNumber)
+	}
+
+	if count != 0 && common.Hash(event.PrevBlockHash) != common.Hash(w.blockHashTree.Leaves[count-int1Key]) {
+		return fmt.Errorf(
+			"%w: block number %d, previous block hash %s",
+			ErrPrevBlockHashMismatch, blockNumber, common.Hash(event.PrevBlockHash).Hex(),
+		)
+	}
+
+	_, err := q.CreateBlockHash(
+		blockNumber,
+		blockHash.Hex(),
+		common.Hash(event.PrevBlockHash).Hex(),
+		common.Hash(event.DepositTreeRoot).Hex(),
+		common.Hash(event.SignatureHash).Hex(),
+		event.Raw.BlockNumber,
+	)
+	if err != nil {
+		return errors.Join(ErrCreateBlockHashFail, err)
+	}
+
+	var root [int32Key]byte
+	root, err = w.blockHashTree.AddLeaf(blockNumber, blockHash)
+	if err != nil {
+		return errors.Join(ErrAddBlockHashFail, err)
+	}
+
+	w.log.Debugf("Added block %d to the block hash tree (root: %s)\n", blockNumber, common.Hash(root).Hex())
+
+	return nil
+}
+
+// checkBlockHashTree compares the last leaf of the block hash tree with the block hash stored
+// in the Rollup contract. Since every block hash commits to the previous one, a matching
+// last leaf means that the root of the block hash tree matches the block hashes of the Rollup contract.
+func (w *blockValidityProver) checkBlockHashTree(ctx context.Context, rollup *bindings.Rollup) error {
+	w.blockHashTreeMu.Lock()
+	defer w.blockHashTreeMu.Unlock()
+
+	root, count, _ := w.blockHashTree.GetCurrentRootCountAndSiblings()
+	if count == 0 {
+		return nil
+	}
+
+	latestBlockNumber, err := rollup.GetLatestBlockNumber(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return errors.Join(ErrGetLatestBlockNumberFail, err)
+	}
+
+	if latestBlockNumber+int1Key != count {
+		return fmt.Errorf(
+			"%w: expected %d blocks, got %d (root: %s)",
+			ErrBlockHashTreeRootMismatch, latestBlockNumber+int1Key, count, root.Hex(),
+		)
+	}
+
+	var blockHash [int32Key]byte
+	blockHash, err = rollup.GetBlockHash(&bind.CallOpts{Context: ctx}, latestBlockNumber)
+	if err != nil {
+		return errors.Join(ErrGetBlockHashFail, err)
+	}
+
+	if blockHash != w.blockHashTree.Leaves[count-int1Key] {
+		return fmt.Errorf(
+			"%w: expected block hash %s, got %s (root: %s)",
+			ErrBlockHashTreeRootMismatch,
+			common.Hash(blockHash).Hex(),
+			common.Hash(w.blockHashTree.Leaves[count-int1Key]).Hex(),
+			root.Hex(),
+		)
+	}
+
+	return nil
+}
+
+// GetBlockMerkleProof returns the inclusion proof of the block in the block hash tree
+// that contains the blocks up to the root block number.
+func (w *blockValidityProver) GetBlockMerkleProof(blockNumber, rootBlockNumber uint32) (*BlockMerkleProof, error) {
+	w.blockHashTreeMu.Lock()
+	defer w.blockHashTreeMu.Unlock()
+
+	return BlockMerkleProofFromBlockHashTree(w.blockHashTree, blockNumber, rootBlockNumber)
+}
+
+// NewBlockHashTreeFromBlockHashes builds the block hash tree from the block hashes ordered by their block number.
+func NewBlockHashTreeFromBlockHashes(blockHashes []*mDBApp.BlockHash) (*intMaxTree.BlockHashTree, error) {
+	leaves := make([][int32Key]byte, len(blockHashes))
+	for key := range blockHashes {
+		if blockHashes[key].BlockNumber != uint32(key) {
+			return nil, ErrBlockNumberInvalid
+		}
+
+		leaves[key] = common.HexToHash(blockHashes[key].BlockHash)
+	}
+
+	blockHashTree, err := intMaxTree.NewBlockHashTree(intMaxTree.BLOCK_HASH_TREE_HEIGHT, leaves)
+	if err != nil {
+		return nil, errors.Join(ErrNewBlockHashTreeFail, err)
+	}
+
+	return blockHashTree, nil
+}
+
+// BlockMerkleProofFromBlockHashTree returns the inclusion proof of the block against the root
+// of the block hash tree at the time the root block was posted.
+func BlockMerkleProofFromBlockHashTree(
+	blockHashTree *intMaxTree.BlockHashTree,
+	blockNumber, rootBlockNumber uint32,
+) (*BlockMerkleProof, error) {
+	_, count, _ := blockHashTree.GetCurrentRootCountAndSiblings()
+	if blockNumber > rootBlockNumber || rootBlockNumber >= count {
+		return nil, ErrBlockNumberOutOfRange
+	}
+
+	leaves := make([][int32Key]byte, rootBlockNumber+int1Key)
+	copy(leaves, blockHashTree.Leaves)
+
+	siblings, root, err := blockHashTree.ComputeMerkleProof(blockNumber, leaves)
+	if err != nil {
+		return nil, errors.Join(ErrComputeBlockMerkleProofFail, err)
+	}
+
+	proof := BlockMerkleProof{
+		BlockNumber:     blockNumber,
+		BlockHash:       leaves[blockNumber],
+		RootBlockNumber: rootBlockNumber,
+		Siblings:        make([]common.Hash, len(siblings)),
+		Root:            root,
+	}
+	for key := range siblings {
+		proof.Siblings[key] = siblings[key]
+	}
+
+	return &proof, nil
+}
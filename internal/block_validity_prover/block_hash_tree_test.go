@@ -0,0 +1,67 @@
+package block_validity_prover_test
+
+import (
+	"intmax2-node/internal/block_validity_prover"
+	intMaxTree "intmax2-node/internal/tree"
+	intMaxTypes "intmax2-node/internal/types"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockMerkleProofFromBlockHashTree(t *testing.T) {
+	blockHashes := make([]*mDBApp.BlockHash, 6)
+	prevBlockHash := common.Hash{}
+	for i := range blockHashes {
+		postedBlock := intMaxTypes.NewPostedBlock(
+			prevBlockHash,
+			common.Hash{byte(i)},
+			uint32(i),
+			common.Hash{byte(i), 1},
+		)
+		blockHashes[i] = &mDBApp.BlockHash{
+			BlockNumber: uint32(i),
+			BlockHash:   postedBlock.Hash().Hex(),
+		}
+		prevBlockHash = postedBlock.Hash()
+	}
+
+	blockHashTree, err := block_validity_prover.NewBlockHashTreeFromBlockHashes(blockHashes)
+	require.NoError(t, err)
+
+	const rootBlockNumber = 3
+	historicalTree, err := block_validity_prover.NewBlockHashTreeFromBlockHashes(blockHashes[:rootBlockNumber+1])
+	require.NoError(t, err)
+	historicalRoot, _, _ := historicalTree.GetCurrentRootCountAndSiblings()
+
+	for blockNumber := uint32(0); blockNumber <= rootBlockNumber; blockNumber++ {
+		proof, err := block_validity_prover.BlockMerkleProofFromBlockHashTree(
+			blockHashTree, blockNumber, rootBlockNumber,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, historicalRoot, proof.Root)
+		assert.Equal(t, blockHashes[blockNumber].BlockHash, proof.BlockHash.Hex())
+		assert.Len(t, proof.Siblings, intMaxTree.BLOCK_HASH_TREE_HEIGHT)
+
+		assert.Equal(t, historicalRoot, intMaxTree.ComputeKeccakMerkleRootFromProof(proof.BlockHash, blockNumber, proof.Siblings))
+	}
+
+	// The proof must not change the leaves of the block hash tree.
+	latestRoot, count, _ := blockHashTree.GetCurrentRootCountAndSiblings()
+	rebuiltTree, err := block_validity_prover.NewBlockHashTreeFromBlockHashes(blockHashes)
+	require.NoError(t, err)
+	rebuiltRoot, _, _ := rebuiltTree.GetCurrentRootCountAndSiblings()
+	assert.Equal(t, rebuiltRoot, latestRoot)
+	assert.Equal(t, uint32(len(blockHashes)), count)
+
+	_, err = block_validity_prover.BlockMerkleProofFromBlockHashTree(blockHashTree, 4, rootBlockNumber)
+	assert.ErrorIs(t, err, block_validity_prover.ErrBlockNumberOutOfRange)
+	_, err = block_validity_prover.BlockMerkleProofFromBlockHashTree(blockHashTree, 0, uint32(len(blockHashes)))
+	assert.ErrorIs(t, err, block_validity_prover.ErrBlockNumberOutOfRange)
+
+	_, err = block_validity_prover.NewBlockHashTreeFromBlockHashes(blockHashes[1:])
+	assert.ErrorIs(t, err, block_validity_prover.ErrBlockNumberInvalid)
+}
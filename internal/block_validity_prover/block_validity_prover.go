@@ -4,19 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"intmax2-node/configs"
 	intMaxAcc "intmax2-node/internal/accounts"
 	"intmax2-node/internal/bindings"
 	"intmax2-node/internal/block_post_service"
 	errorsB "intmax2-node/internal/blockchain/errors"
 	"intmax2-node/internal/hash/goldenposeidon"
+	"intmax2-node/internal/log_scanner"
 	"intmax2-node/internal/logger"
 	"intmax2-node/internal/open_telemetry"
+	intMaxTree "intmax2-node/internal/tree"
 	intMaxTypes "intmax2-node/internal/types"
 	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
 	"intmax2-node/pkg/utils"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/consensys/gnark-crypto/ecc/bn254"
@@ -37,6 +39,11 @@ type blockValidityProver struct {
 	sb                        ServiceBlockchain
 	lastSeenScrollBlockNumber uint64
 	accountInfoMap            block_post_service.AccountInfo
+	depositIndexer            DepositIndexer
+	blockHashTreeMu           sync.Mutex
+	blockHashTree             *intMaxTree.BlockHashTree
+	accountTreeMu             sync.RWMutex
+	accountTree               *intMaxTree.AccountTree
 }
 
 func New(
@@ -44,6 +51,7 @@ func New(
 	log logger.Logger,
 	dbApp SQLDriverApp,
 	sb ServiceBlockchain,
+	depositIndexer DepositIndexer,
 ) BlockValidityProver {
 	return &blockValidityProver{
 		cfg:                       cfg,
@@ -52,6 +60,7 @@ func New(
 		sb:                        sb,
 		lastSeenScrollBlockNumber: cfg.Blockchain.RollupContractDeployedBlockNumber,
 		accountInfoMap:            block_post_service.NewAccountInfo(dbApp),
+		depositIndexer:            depositIndexer,
 	}
 }
 
@@ -69,6 +78,18 @@ func (w *blockValidityProver) Init(ctx context.Context) (err error) {
 		return errors.Join(errorsB.ErrSetupScrollNetworkChainIDFail, err)
 	}
 
+	err = w.initBlockHashTree()
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return err
+	}
+
+	err = w.initAccountTree()
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return err
+	}
+
 	return nil
 }
 
@@ -106,11 +127,22 @@ func (w *blockValidityProver) Start(
 	}
 	defer scrollClient.Close()
 
+	var rollup *bindings.Rollup
+	rollup, err = bindings.NewRollup(common.HexToAddress(rollupCfg.RollupContractAddressHex), scrollClient)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return errors.Join(ErrNewRollupFail, err)
+	}
+
+	scanner := log_scanner.New(w.cfg, w.log, w.dbApp, scrollClient, w.cfg.LogScanner.ScrollConfirmations)
+
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
 		case <-tickerEventWatcher.C:
+			w.syncBlockHashTree(ctx, scanner, scrollClient, rollup)
+
 			/*
 				// d, err := block_post_service.NewBlockPostService(ctx, w.cfg)
 				// if err != nil {
@@ -174,9 +206,9 @@ func (w *blockValidityProver) Start(
 			for _, unprocessedBlock := range unprocessedBlocks {
 				var senderType string
 				if unprocessedBlock.SenderType == 0 {
-					senderType = "PUBLIC_KEY"
+					senderType = intMaxTypes.PublicKeySenderType
 				} else {
-					senderType = "ACCOUNT_ID"
+					senderType = intMaxTypes.AccountIDSenderType
 				}
 
 				var qSenders []intMaxTypes.ColumnSender
@@ -201,6 +233,13 @@ func (w *blockValidityProver) Start(
 					senders = append(senders, sender)
 				}
 
+				if senderType == intMaxTypes.AccountIDSenderType {
+					err = w.resolveAccountIDs(senders)
+					if err != nil {
+						return errors.Join(ErrResolveAccountIDsFail, err)
+					}
+				}
+
 				var txTreeRootBytes []byte
 				txTreeRootBytes, err = hexutil.Decode("0x" + unprocessedBlock.TxRoot)
 				if err != nil {
@@ -233,14 +272,7 @@ func (w *blockValidityProver) Start(
 					return innerErr
 				}
 
-				_, err = intMaxTypes.MakePostRegistrationBlockInput(
-					blockContent,
-				)
-				if err != nil {
-					return err
-				}
-
-				receipt, txErr := intMaxTypes.PostRegistrationBlock(rollupCfg, ctx, w.log, scrollClient, blockContent)
+				receipt, txErr := postBlock(ctx, w.log, rollupCfg, scrollClient, blockContent)
 				if txErr != nil {
 					return txErr
 				}
@@ -259,12 +291,6 @@ func (w *blockValidityProver) Start(
 					return errors.New("BlockPosted event not found")
 				}
 
-				var rollup *bindings.Rollup
-				rollup, err = bindings.NewRollup(common.HexToAddress(rollupCfg.RollupContractAddressHex), scrollClient)
-				if err != nil {
-					return fmt.Errorf("failed to instantiate a Liquidity contract: %w", err)
-				}
-
 				var eventData *bindings.RollupBlockPosted
 				eventData, err = rollup.ParseBlockPosted(*eventLog)
 				if err != nil {
@@ -287,20 +313,103 @@ func (w *blockValidityProver) Start(
 					return err
 				}
 
-				w.log.Infof("Posted registration block. The block number is %d.\n", blockNumber)
+				if senderType == intMaxTypes.AccountIDSenderType {
+					w.log.Infof("Posted non-registration block. The block number is %d.\n", blockNumber)
+				} else {
+					w.log.Infof("Posted registration block. The block number is %d.\n", blockNumber)
+				}
 			}
 		}
 	}
 }
 
+// resolveAccountIDs fills the account IDs of the senders that were stored without them
+// by the account tree, which the block is proven against.
+func (w *blockValidityProver) resolveAccountIDs(senders []intMaxTypes.Sender) error {
+	w.accountTreeMu.RLock()
+	defer w.accountTreeMu.RUnlock()
+
+	dummyPublicKey := intMaxAcc.NewDummyPublicKey()
+	for i := range senders {
+		if senders[i].AccountID != 0 || senders[i].PublicKey.Equal(dummyPublicKey) {
+			continue
+		}
+
+		accountID, err := w.accountIDByPublicKey(senders[i].PublicKey)
+		if err != nil {
+			return err
+		}
+
+		senders[i].AccountID = accountID
+	}
+
+	return nil
+}
+
+// postBlock posts the block on the Rollup contract, choosing the non-registration
+// path when every sender already has an account ID.
+func postBlock(
+	ctx context.Context,
+	log logger.Logger,
+	rollupCfg *intMaxTypes.RollupContractConfig,
+	scrollClient *ethclient.Client,
+	blockContent *intMaxTypes.BlockContent,
+) (*types.Receipt, error) {
+	if blockContent.SenderType == intMaxTypes.AccountIDSenderType {
+		_, err := intMaxTypes.MakePostNonRegistrationBlockInput(blockContent)
+		if err != nil {
+			return nil, errors.Join(ErrMakePostNonRegistrationBlockInputFail, err)
+		}
+
+		return intMaxTypes.PostNonRegistrationBlock(rollupCfg, ctx, log, scrollClient, blockContent)
+	}
+
+	_, err := intMaxTypes.MakePostRegistrationBlockInput(blockContent)
+	if err != nil {
+		return nil, errors.Join(ErrMakePostRegistrationBlockInputFail, err)
+	}
+
+	return intMaxTypes.PostRegistrationBlock(rollupCfg, ctx, log, scrollClient, blockContent)
+}
+
+// FetchAccountIDFromPublicKey returns the account ID of the public key, which is its leaf index in the account tree.
 func (w *blockValidityProver) FetchAccountIDFromPublicKey(publicKey *intMaxAcc.PublicKey) (accountID uint64, err error) {
-	return 0, nil
+	w.accountTreeMu.RLock()
+	defer w.accountTreeMu.RUnlock()
+
+	accountID, err = w.accountIDByPublicKey(publicKey)
+	if err != nil {
+		return 0, errors.Join(ErrAccountBySenderAddressFail, err)
+	}
+
+	return accountID, nil
 }
 
+// FetchPublicKeyFromAddress returns the public key of the account ID from the account tree.
 func (w *blockValidityProver) FetchPublicKeyFromAddress(accountID uint64) (publicKey *intMaxAcc.PublicKey, err error) {
-	return nil, nil
+	w.accountTreeMu.RLock()
+	defer w.accountTreeMu.RUnlock()
+
+	publicKey, err = w.publicKeyByAccountID(accountID)
+	if err != nil {
+		return nil, errors.Join(ErrPublicKeyByAccountIDFail, err)
+	}
+
+	return publicKey, nil
 }
 
+// FetchDepositMerkleProofFromDepositID returns the siblings of the deposit tree leaf
+// that corresponds to the given deposit ID.
 func (w *blockValidityProver) FetchDepositMerkleProofFromDepositID(depositID *big.Int) (depositMerkleProof []string, err error) {
-	return nil, nil
+	proof, err := w.depositIndexer.MerkleProofByDepositID(depositID.Uint64())
+	if err != nil {
+		return nil, errors.Join(ErrMerkleProofByDepositIDFail, err)
+	}
+
+	depositMerkleProof = make([]string, len(proof.Siblings))
+	for key := range proof.Siblings {
+		depositMerkleProof[key] = proof.Siblings[key].Hex()
+	}
+
+	return depositMerkleProof, nil
 }
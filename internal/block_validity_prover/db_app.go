@@ -17,6 +17,8 @@ type SQLDriverApp interface {
 	EventBlockNumbersErrors
 	Senders
 	Accounts
+	BlockHashes
+	RegisteredAccounts
 }
 
 type GenericCommandsApp interface {
@@ -30,8 +32,13 @@ type Blocks interface {
 
 type EventBlockNumbers interface {
 	UpsertEventBlockNumber(eventName string, blockNumber uint64) (*mDBApp.EventBlockNumber, error)
+	UpsertEventBlockNumberAndHash(eventName string, blockNumber uint64, blockHash string) (*mDBApp.EventBlockNumber, error)
 	EventBlockNumberByEventName(eventName string) (*mDBApp.EventBlockNumber, error)
 	EventBlockNumbersByEventNames(eventNames []string) ([]*mDBApp.EventBlockNumber, error)
+	UpsertEventBlockHash(eventName string, blockNumber uint64, blockHash string) error
+	EventBlockHashesByEventName(eventName string) ([]*mDBApp.EventBlockHash, error)
+	DelEventBlockHashesAfterBlockNumber(eventName string, blockNumber uint64) error
+	DelEventBlockHashesBeforeBlockNumber(eventName string, blockNumber uint64) error
 }
 
 type CtrlEventBlockNumbersJobs interface {
@@ -66,5 +73,28 @@ type Accounts interface {
 	AccountBySenderID(senderID string) (*mDBApp.Account, error)
 	AccountByAccountID(accountID *uint256.Int) (*mDBApp.Account, error)
 	ResetSequenceByAccounts() error
+	UpdateBlockNumberOfNewAccounts(blockNumber uint64) error
+	DelAccountsAfterBlockNumber(blockNumber uint64) error
 	DelAllAccounts() error
 }
+
+type BlockHashes interface {
+	CreateBlockHash(
+		blockNumber uint32,
+		blockHash, prevBlockHash, depositTreeRoot, signatureHash string,
+		postedBlockNumber uint64,
+	) (*mDBApp.BlockHash, error)
+	BlockHashes() ([]*mDBApp.BlockHash, error)
+	DelBlockHashesAfterPostedBlockNumber(postedBlockNumber uint64) error
+}
+
+type RegisteredAccounts interface {
+	CreateRegisteredAccount(
+		accountID uint64,
+		publicKey string,
+		blockNumber uint32,
+		postedBlockNumber uint64,
+	) (*mDBApp.RegisteredAccount, error)
+	RegisteredAccounts() ([]*mDBApp.RegisteredAccount, error)
+	DelRegisteredAccountsAfterPostedBlockNumber(postedBlockNumber uint64) error
+}
@@ -0,0 +1,9 @@
+package block_validity_prover
+
+import "intmax2-node/internal/deposit_indexer"
+
+//go:generate mockgen -destination=mock_deposit_indexer_test.go -package=block_validity_prover_test -source=deposit_indexer.go
+
+type DepositIndexer interface {
+	MerkleProofByDepositID(depositID uint64) (*deposit_indexer.DepositMerkleProof, error)
+}
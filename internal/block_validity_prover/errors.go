@@ -7,3 +7,99 @@ var ErrInitFail = errors.New("failed to init")
 
 // ErrNewClientFail error: failed to create new client.
 var ErrNewClientFail = errors.New("failed to create new client")
+
+// ErrResolveAccountIDsFail error: failed to resolve account IDs of senders.
+var ErrResolveAccountIDsFail = errors.New("failed to resolve account IDs of senders")
+
+// ErrMakePostRegistrationBlockInputFail error: failed to make post registration block input.
+var ErrMakePostRegistrationBlockInputFail = errors.New("failed to make post registration block input")
+
+// ErrMakePostNonRegistrationBlockInputFail error: failed to make post non-registration block input.
+var ErrMakePostNonRegistrationBlockInputFail = errors.New("failed to make post non-registration block input")
+
+// ErrMerkleProofByDepositIDFail error: failed to get deposit Merkle proof by deposit ID.
+var ErrMerkleProofByDepositIDFail = errors.New("failed to get deposit Merkle proof by deposit ID")
+
+// ErrAccountBySenderAddressFail error: failed to get account by sender address.
+var ErrAccountBySenderAddressFail = errors.New("failed to get account by sender address")
+
+// ErrPublicKeyByAccountIDFail error: failed to get public key by account ID.
+var ErrPublicKeyByAccountIDFail = errors.New("failed to get public key by account ID")
+
+// ErrBlockHashesFail error: failed to get block hashes.
+var ErrBlockHashesFail = errors.New("failed to get block hashes")
+
+// ErrNewBlockHashTreeFail error: failed to create new block hash tree.
+var ErrNewBlockHashTreeFail = errors.New("failed to create new block hash tree")
+
+// ErrBlockNumberInvalid error: the block numbers of the block hashes must be consecutive.
+var ErrBlockNumberInvalid = errors.New("the block numbers of the block hashes must be consecutive")
+
+// ErrPrevBlockHashMismatch error: the previous block hash does not match the last block hash of the block hash tree.
+var ErrPrevBlockHashMismatch = errors.New(
+	"the previous block hash does not match the last block hash of the block hash tree",
+)
+
+// ErrNewRollupFail error: failed to instantiate a Rollup contract.
+var ErrNewRollupFail = errors.New("failed to instantiate a Rollup contract")
+
+// ErrFilterLogsFail error: failed to filter logs.
+var ErrFilterLogsFail = errors.New("failed to filter logs")
+
+// ErrEncounteredWhileIterating error: encountered while iterating error occurred.
+var ErrEncounteredWhileIterating = errors.New("encountered while iterating error occurred")
+
+// ErrGetBlockHashFail error: failed to get block hash from the Rollup contract.
+var ErrGetBlockHashFail = errors.New("failed to get block hash from the Rollup contract")
+
+// ErrGetLatestBlockNumberFail error: failed to get latest block number from the Rollup contract.
+var ErrGetLatestBlockNumberFail = errors.New("failed to get latest block number from the Rollup contract")
+
+// ErrCreateBlockHashFail error: failed to create block hash.
+var ErrCreateBlockHashFail = errors.New("failed to create block hash")
+
+// ErrAddBlockHashFail error: failed to add block hash to the block hash tree.
+var ErrAddBlockHashFail = errors.New("failed to add block hash to the block hash tree")
+
+// ErrDelBlockHashesAfterPostedBlockNumberFail error: failed to delete block hashes after posted block number.
+var ErrDelBlockHashesAfterPostedBlockNumberFail = errors.New("failed to delete block hashes after posted block number")
+
+// ErrBlockHashTreeRootMismatch error: the block hash tree does not match the block hashes of the Rollup contract.
+var ErrBlockHashTreeRootMismatch = errors.New(
+	"the block hash tree does not match the block hashes of the Rollup contract",
+)
+
+// ErrBlockNumberOutOfRange error: the block number must not exceed the root block number
+// and the root block number must be included in the block hash tree.
+var ErrBlockNumberOutOfRange = errors.New(
+	"the block number must not exceed the root block number and the root block number must be included in the block hash tree",
+)
+
+// ErrComputeBlockMerkleProofFail error: failed to compute block Merkle proof.
+var ErrComputeBlockMerkleProofFail = errors.New("failed to compute block Merkle proof")
+
+// ErrRegisteredAccountsFail error: failed to get registered accounts.
+var ErrRegisteredAccountsFail = errors.New("failed to get registered accounts")
+
+// ErrNewAccountTreeFail error: failed to create new account tree.
+var ErrNewAccountTreeFail = errors.New("failed to create new account tree")
+
+// ErrInsertAccountFail error: failed to insert account to the account tree.
+var ErrInsertAccountFail = errors.New("failed to insert account to the account tree")
+
+// ErrAccountIDInvalid error: the account IDs of the registered accounts must be consecutive.
+var ErrAccountIDInvalid = errors.New("the account IDs of the registered accounts must be consecutive")
+
+// ErrCreateRegisteredAccountFail error: failed to create registered account.
+var ErrCreateRegisteredAccountFail = errors.New("failed to create registered account")
+
+// ErrDelRegisteredAccountsAfterPostedBlockNumberFail error: failed to delete registered accounts after posted block number.
+var ErrDelRegisteredAccountsAfterPostedBlockNumberFail = errors.New(
+	"failed to delete registered accounts after posted block number",
+)
+
+// ErrTransactionByHashFail error: failed to get transaction by hash.
+var ErrTransactionByHashFail = errors.New("failed to get transaction by hash")
+
+// ErrAccountNotFound error: the account is not found in the account tree.
+var ErrAccountNotFound = errors.New("the account is not found in the account tree")
@@ -5,6 +5,8 @@ import (
 	intMaxAcc "intmax2-node/internal/accounts"
 	"math/big"
 	"time"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
 type BlockValidityProver interface {
@@ -16,4 +18,15 @@ type BlockValidityProver interface {
 	FetchAccountIDFromPublicKey(publicKey *intMaxAcc.PublicKey) (accountID uint64, err error)
 	FetchPublicKeyFromAddress(accountID uint64) (publicKey *intMaxAcc.PublicKey, err error)
 	FetchDepositMerkleProofFromDepositID(depositID *big.Int) (depositMerkleProof []string, err error)
+	GetBlockMerkleProof(blockNumber, rootBlockNumber uint32) (*BlockMerkleProof, error)
+}
+
+// BlockMerkleProof is the inclusion proof of a block hash in the block hash tree
+// that contains the blocks up to the root block number.
+type BlockMerkleProof struct {
+	BlockNumber     uint32
+	BlockHash       common.Hash
+	RootBlockNumber uint32
+	Siblings        []common.Hash
+	Root            common.Hash
 }
@@ -0,0 +1,199 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"intmax2-node/configs"
+	"intmax2-node/internal/logger"
+	"intmax2-node/internal/open_telemetry"
+	errorsDB "intmax2-node/pkg/sql_db/errors"
+	"sync"
+	"time"
+
+	"github.com/dimiro1/health"
+)
+
+type daemon struct {
+	cfg   *configs.Config
+	log   logger.Logger
+	dbApp SQLDriverApp
+	name  string
+	job   Job
+
+	mu    sync.RWMutex
+	state State
+}
+
+func New(
+	cfg *configs.Config,
+	log logger.Logger,
+	dbApp SQLDriverApp,
+	name string,
+	job Job,
+) Daemon {
+	return &daemon{
+		cfg:   cfg,
+		log:   log,
+		dbApp: dbApp,
+		name:  name,
+		job:   job,
+	}
+}
+
+// Init creates the row of the job in ctrl_processing_jobs, which is locked by the leader.
+func (d *daemon) Init(ctx context.Context) (err error) {
+	return d.dbApp.Exec(ctx, nil, func(db interface{}, _ interface{}) (err error) {
+		q := db.(SQLDriverApp)
+
+		err = q.CreateCtrlProcessingJobs(d.name)
+		if err != nil {
+			return errors.Join(ErrNewCtrlProcessingJobsFail, err)
+		}
+
+		return nil
+	})
+}
+
+// Start runs the job at once and then on every tick until the context is done.
+// After a failure the ticks are skipped with the exponential backoff.
+func (d *daemon) Start(
+	ctx context.Context,
+	ticker *time.Ticker,
+) error {
+	d.log.Infof("Daemon %s is started", d.name)
+
+	d.tick(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			d.log.Infof("Daemon %s is stopped", d.name)
+			return nil
+		case <-ticker.C:
+			d.tick(ctx)
+		}
+	}
+}
+
+func (d *daemon) tick(ctx context.Context) {
+	if time.Now().Before(d.State().RetryAt) {
+		return
+	}
+
+	leader, err := d.runAsLeader(ctx)
+	if ctx.Err() != nil {
+		return
+	}
+
+	d.report(leader, err)
+}
+
+// runAsLeader runs the job while the row of the job is locked by the transaction.
+// The row locked by another replica is skipped, so the job is not run.
+func (d *daemon) runAsLeader(ctx context.Context) (leader bool, err error) {
+	hName := "Daemon " + d.name
+
+	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName)
+	defer span.End()
+
+	err = d.dbApp.Exec(spanCtx, nil, func(db interface{}, _ interface{}) (err error) {
+		q := db.(SQLDriverApp)
+
+		_, err = q.CtrlProcessingJobs(d.name)
+		if errors.Is(err, errorsDB.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return errors.Join(ErrCtrlProcessingJobsFail, err)
+		}
+
+		leader = true
+
+		err = d.job(spanCtx)
+		if err != nil {
+			return errors.Join(ErrJobFail, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return leader, err
+	}
+
+	return leader, nil
+}
+
+func (d *daemon) report(leader bool, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	d.state.Leader = leader
+	d.state.LastRunAt = now
+
+	if err != nil {
+		d.state.Failures++
+		d.state.LastError = err
+		backoff := d.backoff(d.state.Failures)
+		d.state.RetryAt = now.Add(backoff)
+		d.log.Errorf("Daemon %s failed (%d in a row), retry in %s: %v", d.name, d.state.Failures, backoff, err)
+		return
+	}
+
+	d.state.Failures = 0
+	d.state.LastError = nil
+	d.state.RetryAt = time.Time{}
+	if leader {
+		d.state.LastSuccessAt = now
+	}
+}
+
+// backoff doubles the minimal backoff on every failure in a row up to the maximal backoff.
+func (d *daemon) backoff(failures int) time.Duration {
+	backoff := d.cfg.Daemon.MinBackoff
+	for i := 1; i < failures && backoff < d.cfg.Daemon.MaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > d.cfg.Daemon.MaxBackoff {
+		return d.cfg.Daemon.MaxBackoff
+	}
+
+	return backoff
+}
+
+func (d *daemon) State() State {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.state
+}
+
+// Check reports the daemon down after DAEMON_MAX_FAILURES failures in a row.
+func (d *daemon) Check(_ context.Context) (res health.Health) {
+	const (
+		leaderKey        = "leader"
+		lastRunAtKey     = "last_run_at"
+		lastSuccessAtKey = "last_success_at"
+		failuresKey      = "failures"
+		errorKey         = "error"
+	)
+
+	state := d.State()
+	res.AddInfo(leaderKey, state.Leader)
+	res.AddInfo(lastRunAtKey, state.LastRunAt)
+	res.AddInfo(lastSuccessAtKey, state.LastSuccessAt)
+	res.AddInfo(failuresKey, state.Failures)
+	if state.LastError != nil {
+		res.AddInfo(errorKey, state.LastError.Error())
+	}
+
+	if state.Failures >= d.cfg.Daemon.MaxFailures {
+		res.Down()
+		return res
+	}
+
+	res.Up()
+
+	return res
+}
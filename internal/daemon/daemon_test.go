@@ -0,0 +1,144 @@
+package daemon_test
+
+import (
+	"context"
+	"errors"
+	"intmax2-node/configs"
+	"intmax2-node/internal/daemon"
+	"intmax2-node/pkg/logger"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	errorsDB "intmax2-node/pkg/sql_db/errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+const jobName = "test_job"
+
+func newDaemonTest(t *testing.T) (*configs.Config, *MockSQLDriverApp) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	cfg := configs.Config{}
+	cfg.Daemon.MinBackoff = time.Millisecond
+	cfg.Daemon.MaxBackoff = time.Millisecond
+	cfg.Daemon.MaxFailures = 2
+
+	dbApp := NewMockSQLDriverApp(ctrl)
+	dbApp.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, input interface{}, executor func(d interface{}, input interface{}) error) error {
+			return executor(dbApp, input)
+		}).AnyTimes()
+
+	return &cfg, dbApp
+}
+
+func startDaemon(ctx context.Context, t *testing.T, d daemon.Daemon) {
+	ticker := time.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+
+	done := make(chan error)
+	go func() {
+		done <- d.Start(ctx, ticker)
+	}()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the daemon is not stopped with the context")
+	}
+}
+
+func TestDaemon(t *testing.T) {
+	log := logger.New("error", time.RFC3339, false, false)
+
+	t.Run("Init creates the row of the job", func(t *testing.T) {
+		cfg, dbApp := newDaemonTest(t)
+		dbApp.EXPECT().CreateCtrlProcessingJobs(jobName).Return(nil)
+
+		d := daemon.New(cfg, log, dbApp, jobName, nil)
+		assert.NoError(t, d.Init(context.Background()))
+	})
+
+	t.Run("Leader runs the job on every tick", func(t *testing.T) {
+		const runs = 3
+
+		cfg, dbApp := newDaemonTest(t)
+		dbApp.EXPECT().CtrlProcessingJobs(jobName).
+			Return(&mDBApp.CtrlProcessingJobs{ProcessingJobName: jobName}, nil).AnyTimes()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var count atomic.Int32
+		d := daemon.New(cfg, log, dbApp, jobName, func(ctx context.Context) error {
+			if count.Add(1) == runs {
+				cancel()
+			}
+			return nil
+		})
+
+		startDaemon(ctx, t, d)
+
+		assert.Equal(t, int32(runs), count.Load())
+		state := d.State()
+		assert.True(t, state.Leader)
+		assert.Zero(t, state.Failures)
+		assert.False(t, state.LastSuccessAt.IsZero())
+		assert.True(t, d.Check(ctx).IsUp())
+	})
+
+	t.Run("Replica without the lock of the job skips the job", func(t *testing.T) {
+		cfg, dbApp := newDaemonTest(t)
+		dbApp.EXPECT().CtrlProcessingJobs(jobName).Return(nil, errorsDB.ErrNotFound).MinTimes(1)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		d := daemon.New(cfg, log, dbApp, jobName, func(ctx context.Context) error {
+			t.Error("the job must not be run without the lock")
+			return nil
+		})
+
+		startDaemon(ctx, t, d)
+
+		state := d.State()
+		assert.False(t, state.Leader)
+		assert.Zero(t, state.Failures)
+		assert.True(t, state.LastSuccessAt.IsZero())
+		assert.True(t, d.Check(ctx).IsUp())
+	})
+
+	t.Run("Failures back off and report the daemon down", func(t *testing.T) {
+		cfg, dbApp := newDaemonTest(t)
+		cfg.Daemon.MinBackoff = 40 * time.Millisecond
+		cfg.Daemon.MaxBackoff = 40 * time.Millisecond
+		dbApp.EXPECT().CtrlProcessingJobs(jobName).
+			Return(&mDBApp.CtrlProcessingJobs{ProcessingJobName: jobName}, nil).AnyTimes()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		errJob := errors.New("job error")
+		var count atomic.Int32
+		d := daemon.New(cfg, log, dbApp, jobName, func(ctx context.Context) error {
+			count.Add(1)
+			return errJob
+		})
+
+		startDaemon(ctx, t, d)
+
+		assert.GreaterOrEqual(t, count.Load(), int32(2))
+		assert.LessOrEqual(t, count.Load(), int32(4))
+
+		state := d.State()
+		assert.Equal(t, int(count.Load()), state.Failures)
+		assert.ErrorIs(t, state.LastError, errJob)
+		assert.ErrorIs(t, state.LastError, daemon.ErrJobFail)
+		assert.False(t, d.Check(ctx).IsUp())
+	})
+}
@@ -0,0 +1,22 @@
+package daemon
+
+import (
+	"context"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+)
+
+//go:generate mockgen -destination=mock_db_app_test.go -package=daemon_test -source=db_app.go
+
+type SQLDriverApp interface {
+	GenericCommandsApp
+	CtrlProcessingJobs
+}
+
+type GenericCommandsApp interface {
+	Exec(ctx context.Context, input interface{}, executor func(d interface{}, input interface{}) error) (err error)
+}
+
+type CtrlProcessingJobs interface {
+	CreateCtrlProcessingJobs(name string) error
+	CtrlProcessingJobs(name string) (*mDBApp.CtrlProcessingJobs, error)
+}
@@ -0,0 +1,12 @@
+package daemon
+
+import "errors"
+
+// ErrNewCtrlProcessingJobsFail error: failed to create new ctrl-processing-job row.
+var ErrNewCtrlProcessingJobsFail = errors.New("failed to create new ctrl-processing-job row")
+
+// ErrCtrlProcessingJobsFail error: failed to get ctrl-processing-job row.
+var ErrCtrlProcessingJobsFail = errors.New("failed to get ctrl-processing-job row")
+
+// ErrJobFail error: failed to run the job of the daemon.
+var ErrJobFail = errors.New("failed to run the job of the daemon")
@@ -0,0 +1,43 @@
+package daemon
+
+import (
+	"encoding/json"
+	"intmax2-node/internal/use_cases/health_check"
+	"net/http"
+	"time"
+)
+
+// NewHealthCheckServer returns the server of the health check of the daemon,
+// which responds to GET /health with the result of the health check use case.
+func NewHealthCheckServer(addr string, uc health_check.UseCaseHealthCheck) *http.Server {
+	const (
+		path              = "/health"
+		contentType       = "Content-Type"
+		appJSON           = "application/json"
+		readHeaderTimeout = 10 * time.Second
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		hc := uc.Do(r.Context())
+
+		w.Header().Set(contentType, appJSON)
+		if !hc.Success {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(&struct {
+			Success bool `json:"success"`
+		}{Success: hc.Success})
+	})
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+}
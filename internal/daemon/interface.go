@@ -0,0 +1,36 @@
+package daemon
+
+import (
+	"context"
+	"time"
+
+	"github.com/dimiro1/health"
+)
+
+//go:generate mockgen -destination=../mocks/mock_daemon.go -package=mocks -source=interface.go
+
+// Job describes one run of the daemon.
+type Job func(ctx context.Context) error
+
+// Daemon runs the job on every tick of the ticker by one replica only.
+// The replica that locks the row of the job in ctrl_processing_jobs is the leader of the tick,
+// the other replicas skip the tick.
+type Daemon interface {
+	Init(ctx context.Context) error
+	Start(
+		ctx context.Context,
+		ticker *time.Ticker,
+	) error
+	Check(ctx context.Context) health.Health
+	State() State
+}
+
+// State describes the last runs of the job by the daemon.
+type State struct {
+	Leader        bool
+	LastRunAt     time.Time
+	LastSuccessAt time.Time
+	Failures      int
+	LastError     error
+	RetryAt       time.Time
+}
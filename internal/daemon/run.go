@@ -0,0 +1,48 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"intmax2-node/internal/logger"
+	"intmax2-node/internal/use_cases/health_check"
+	"net/http"
+	"time"
+)
+
+// Run runs the daemon with the given interval until the context is done.
+// The health check of the daemon is served on healthCheckAddr unless it is empty.
+func Run(
+	ctx context.Context,
+	log logger.Logger,
+	healthCheckAddr string,
+	hc health_check.UseCaseHealthCheck,
+	d Daemon,
+	interval time.Duration,
+) error {
+	const shutdownTimeout = 5 * time.Second
+
+	err := d.Init(ctx)
+	if err != nil {
+		return err
+	}
+
+	if healthCheckAddr != "" {
+		srv := NewHealthCheckServer(healthCheckAddr, hc)
+		go func() {
+			if errSrv := srv.ListenAndServe(); errSrv != nil && !errors.Is(errSrv, http.ErrServerClosed) {
+				const msg = "failed to serve health check of daemon: %v"
+				log.Errorf(msg, errSrv.Error())
+			}
+		}()
+		defer func() {
+			ctxShutdown, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+			_ = srv.Shutdown(ctxShutdown)
+		}()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	return d.Start(ctx, ticker)
+}
@@ -0,0 +1,18 @@
+package deposit_indexer
+
+import (
+	"context"
+)
+
+//go:generate mockgen -destination=mock_blockchain_service_test.go -package=deposit_indexer_test -source=blockchain_service.go
+
+type ServiceBlockchain interface {
+	ChainSB
+}
+
+type ChainSB interface {
+	SetupEthereumNetworkChainID(ctx context.Context) error
+	EthereumNetworkChainLinkEvmJSONRPC(ctx context.Context) (string, error)
+	SetupScrollNetworkChainID(ctx context.Context) error
+	ScrollNetworkChainLinkEvmJSONRPC(ctx context.Context) (string, error)
+}
@@ -0,0 +1,57 @@
+package deposit_indexer
+
+import (
+	"context"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+
+	"github.com/holiman/uint256"
+)
+
+//go:generate mockgen -destination=mock_db_app_test.go -package=deposit_indexer_test -source=db_app.go
+
+type SQLDriverApp interface {
+	GenericCommandsApp
+	EventBlockNumbers
+	Deposits
+}
+
+type GenericCommandsApp interface {
+	Exec(ctx context.Context, input interface{}, executor func(d interface{}, input interface{}) error) (err error)
+}
+
+type EventBlockNumbers interface {
+	UpsertEventBlockNumber(eventName string, blockNumber uint64) (*mDBApp.EventBlockNumber, error)
+	UpsertEventBlockNumberAndHash(eventName string, blockNumber uint64, blockHash string) (*mDBApp.EventBlockNumber, error)
+	EventBlockNumberByEventName(eventName string) (*mDBApp.EventBlockNumber, error)
+	UpsertEventBlockHash(eventName string, blockNumber uint64, blockHash string) error
+	EventBlockHashesByEventName(eventName string) ([]*mDBApp.EventBlockHash, error)
+	DelEventBlockHashesAfterBlockNumber(eventName string, blockNumber uint64) error
+	DelEventBlockHashesBeforeBlockNumber(eventName string, blockNumber uint64) error
+}
+
+type Deposits interface {
+	CreateDeposit(
+		depositID uint64,
+		depositHash, recipientSaltHash string,
+		tokenIndex uint32,
+		amount *uint256.Int,
+		blockNumber uint64,
+	) (*mDBApp.Deposit, error)
+	UpdateDepositIndexByDepositHash(
+		depositHash string,
+		depositIndex uint32,
+		processedBlockNumber uint64,
+	) (*mDBApp.Deposit, error)
+	DelUnindexedDepositsAfterBlockNumber(blockNumber uint64) error
+	ResetDepositIndexesAfterProcessedBlockNumber(processedBlockNumber uint64) error
+	DepositByDepositID(depositID uint64) (*mDBApp.Deposit, error)
+	DepositByDepositHash(depositHash string) (*mDBApp.Deposit, error)
+	IndexedDeposits() ([]*mDBApp.Deposit, error)
+	IndexedDepositsFromDepositIndex(depositIndex uint32) ([]*mDBApp.Deposit, error)
+}
+
+type ReaderSQLDriverApp interface {
+	DepositByDepositID(depositID uint64) (*mDBApp.Deposit, error)
+	DepositByDepositHash(depositHash string) (*mDBApp.Deposit, error)
+	IndexedDepositsFromDepositIndex(depositIndex uint32) ([]*mDBApp.Deposit, error)
+}
@@ -0,0 +1,648 @@
+package deposit_indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"intmax2-node/configs"
+	"intmax2-node/internal/bindings"
+	errorsB "intmax2-node/internal/blockchain/errors"
+	"intmax2-node/internal/deposit_synchronizer"
+	"intmax2-node/internal/log_scanner"
+	"intmax2-node/internal/logger"
+	"intmax2-node/internal/open_telemetry"
+	intMaxTree "intmax2-node/internal/tree"
+	intMaxTypes "intmax2-node/internal/types"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	"intmax2-node/pkg/utils"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/holiman/uint256"
+)
+
+const (
+	processDepositsMethod = "processDeposits"
+
+	int1Key  = 1
+	int4Key  = 4
+	int32Key = 32
+)
+
+type depositIndexer struct {
+	cfg           *configs.Config
+	log           logger.Logger
+	dbApp         SQLDriverApp
+	sb            ServiceBlockchain
+	depositTreeMu sync.Mutex
+	depositTree   *intMaxTree.DepositTree
+}
+
+func New(
+	cfg *configs.Config,
+	log logger.Logger,
+	dbApp SQLDriverApp,
+	sb ServiceBlockchain,
+) DepositIndexer {
+	return &depositIndexer{
+		cfg:   cfg,
+		log:   log,
+		dbApp: dbApp,
+		sb:    sb,
+	}
+}
+
+// Init rebuilds the deposit tree from the deposits stored in the database.
+func (w *depositIndexer) Init(ctx context.Context) (err error) {
+	const (
+		hName = "DepositIndexer func:Init"
+	)
+
+	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName)
+	defer span.End()
+
+	err = w.loadDepositTree(w.dbApp)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return err
+	}
+
+	return nil
+}
+
+// loadDepositTree rebuilds the deposit tree from the indexed deposits read with the database driver q.
+func (w *depositIndexer) loadDepositTree(q SQLDriverApp) error {
+	deposits, err := q.IndexedDeposits()
+	if err != nil {
+		return errors.Join(ErrIndexedDepositsFail, err)
+	}
+
+	var depositTree *intMaxTree.DepositTree
+	depositTree, err = NewDepositTreeFromDeposits(deposits)
+	if err != nil {
+		return err
+	}
+
+	w.depositTreeMu.Lock()
+	defer w.depositTreeMu.Unlock()
+
+	w.depositTree = depositTree
+
+	return nil
+}
+
+// Start keeps the deposit tree in sync with the Liquidity and Rollup contracts.
+// The tree is synchronized on every tick and on every DepositsProcessed event
+// received from the subscription of the Rollup contract.
+func (w *depositIndexer) Start(
+	ctx context.Context,
+	tickerEventWatcher *time.Ticker,
+) (err error) {
+	const (
+		hName = "DepositIndexer func:Start"
+	)
+
+	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName)
+	defer span.End()
+
+	err = w.sb.SetupEthereumNetworkChainID(spanCtx)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return errors.Join(errorsB.ErrSetupEthereumNetworkChainIDFail, err)
+	}
+
+	err = w.sb.SetupScrollNetworkChainID(spanCtx)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return errors.Join(errorsB.ErrSetupScrollNetworkChainIDFail, err)
+	}
+
+	var ethLink string
+	ethLink, err = w.sb.EthereumNetworkChainLinkEvmJSONRPC(spanCtx)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return errors.Join(errorsB.ErrEthereumNetworkChainLinkEvmJSONRPCFail, err)
+	}
+
+	var scrollLink string
+	scrollLink, err = w.sb.ScrollNetworkChainLinkEvmJSONRPC(spanCtx)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return errors.Join(errorsB.ErrScrollNetworkChainLinkEvmJSONRPCFail, err)
+	}
+
+	rollupCfg := intMaxTypes.NewRollupContractConfigFromEnv(w.cfg, scrollLink)
+
+	var ethClient *ethclient.Client
+	ethClient, err = utils.NewClient(ethLink)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return errors.Join(ErrNewClientFail, err)
+	}
+	defer ethClient.Close()
+
+	var scrollClient *ethclient.Client
+	scrollClient, err = utils.NewClient(scrollLink)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return errors.Join(ErrNewClientFail, err)
+	}
+	defer scrollClient.Close()
+
+	var liquidity *bindings.Liquidity
+	liquidity, err = bindings.NewLiquidity(
+		common.HexToAddress(w.cfg.Blockchain.LiquidityContractAddress),
+		ethClient,
+	)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return errors.Join(ErrInstantiateLiquidityContractFail, err)
+	}
+
+	var rollup *bindings.Rollup
+	rollup, err = bindings.NewRollup(
+		common.HexToAddress(w.cfg.Blockchain.RollupContractAddress),
+		scrollClient,
+	)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return errors.Join(ErrInstantiateRollupContractFail, err)
+	}
+
+	// The subscription is not available over HTTP endpoints, in which case the tree
+	// is synchronized by the ticker only.
+	eventChan, sub, err := deposit_synchronizer.SubscribeDepositsProcessed(ctx, rollupCfg)
+	if err != nil {
+		const msg = "failed to subscribe to DepositsProcessed events, falling back to polling"
+		w.log.WithError(err).Warnf(msg)
+		eventChan = nil
+	} else {
+		defer sub.Unsubscribe()
+	}
+
+	var subErr <-chan error
+	if sub != nil {
+		subErr = sub.Err()
+	}
+
+	ethScanner := log_scanner.New(w.cfg, w.log, w.dbApp, ethClient, w.cfg.LogScanner.EthereumConfirmations)
+	scrollScanner := log_scanner.New(w.cfg, w.log, w.dbApp, scrollClient, w.cfg.LogScanner.ScrollConfirmations)
+
+	w.syncDepositTree(ctx, rollupCfg, ethScanner, scrollScanner, liquidity, rollup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-tickerEventWatcher.C:
+			w.syncDepositTree(ctx, rollupCfg, ethScanner, scrollScanner, liquidity, rollup)
+		case event := <-eventChan:
+			w.log.Debugf(
+				"Received DepositsProcessed event (last processed deposit ID: %s)\n",
+				event.LastProcessedDepositId.String(),
+			)
+			w.syncDepositTree(ctx, rollupCfg, ethScanner, scrollScanner, liquidity, rollup)
+		case err = <-subErr:
+			const msg = "subscription to DepositsProcessed events closed, falling back to polling"
+			w.log.WithError(err).Warnf(msg)
+			eventChan = nil
+			subErr = nil
+		}
+	}
+}
+
+func (w *depositIndexer) MerkleProofByDepositID(depositID uint64) (*DepositMerkleProof, error) {
+	deposit, err := w.dbApp.DepositByDepositID(depositID)
+	if err != nil {
+		return nil, errors.Join(ErrDepositByDepositIDFail, err)
+	}
+
+	return w.merkleProof(deposit)
+}
+
+func (w *depositIndexer) MerkleProofByDepositHash(depositHash common.Hash) (*DepositMerkleProof, error) {
+	deposit, err := w.dbApp.DepositByDepositHash(depositHash.Hex())
+	if err != nil {
+		return nil, errors.Join(ErrDepositByDepositHashFail, err)
+	}
+
+	return w.merkleProof(deposit)
+}
+
+func (w *depositIndexer) merkleProof(deposit *mDBApp.Deposit) (*DepositMerkleProof, error) {
+	if deposit.DepositIndex == nil {
+		return nil, ErrDepositNotIndexed
+	}
+
+	w.depositTreeMu.Lock()
+	defer w.depositTreeMu.Unlock()
+
+	if w.depositTree == nil {
+		return nil, ErrDepositNotIndexed
+	}
+
+	return DepositMerkleProofFromDepositTree(w.depositTree, deposit)
+}
+
+// syncDepositTree stores the deposits made in the Liquidity contract, appends the deposits
+// processed by the Rollup contract to the deposit tree and compares the resulting root
+// with the current deposit root of the Rollup contract.
+func (w *depositIndexer) syncDepositTree(
+	ctx context.Context,
+	rollupCfg *intMaxTypes.RollupContractConfig,
+	ethScanner, scrollScanner log_scanner.LogScanner,
+	liquidity *bindings.Liquidity,
+	rollup *bindings.Rollup,
+) {
+	err := w.fetchNewDeposits(ctx, ethScanner, liquidity)
+	if err != nil {
+		const msg = "failed to sync deposit tree"
+		w.log.WithError(errors.Join(ErrFetchNewDepositsFail, err)).Errorf(msg)
+		return
+	}
+
+	err = w.fetchDepositsProcessed(ctx, scrollScanner, liquidity, rollup)
+	if err != nil {
+		const msg = "failed to sync deposit tree"
+		w.log.WithError(errors.Join(ErrFetchDepositsProcessedFail, err)).Errorf(msg)
+
+		// The deposit tree may contain the leaves that were not committed.
+		err = w.Init(ctx)
+		if err != nil {
+			const msg = "failed to init deposit tree"
+			w.log.WithError(err).Errorf(msg)
+		}
+
+		return
+	}
+
+	err = w.checkDepositRoot(ctx, rollupCfg)
+	if err != nil {
+		const msg = "failed to check deposit tree root"
+		w.log.WithError(err).Warnf(msg)
+	}
+}
+
+// checkDepositRoot compares the root of the deposit tree with the deposit root of the Rollup contract.
+// A mismatch is expected while a DepositsProcessed event has not been fetched yet.
+func (w *depositIndexer) checkDepositRoot(ctx context.Context, rollupCfg *intMaxTypes.RollupContractConfig) error {
+	depositRoot, err := intMaxTypes.FetchDepositRoot(rollupCfg, ctx)
+	if err != nil {
+		return errors.Join(ErrFetchDepositRootFail, err)
+	}
+
+	w.depositTreeMu.Lock()
+	defer w.depositTreeMu.Unlock()
+
+	root, count, _ := w.depositTree.GetCurrentRootCountAndSiblings()
+	if root != depositRoot {
+		return fmt.Errorf(
+			"%w: expected %s, got %s (%d deposits)",
+			ErrDepositRootMismatch, common.Hash(depositRoot).Hex(), root.Hex(), count,
+		)
+	}
+
+	return nil
+}
+
+// fetchNewDeposits stores the deposits made in the Liquidity contract. The deposits made in the
+// reorganized blocks are deleted unless they have been included in the deposit tree.
+func (w *depositIndexer) fetchNewDeposits(
+	ctx context.Context,
+	scanner log_scanner.LogScanner,
+	liquidity *bindings.Liquidity,
+) error {
+	return scanner.Scan(ctx, &log_scanner.Handler{
+		EventName:           mDBApp.DepositedEvent,
+		DeployedBlockNumber: w.cfg.Blockchain.LiquidityContractDeployedBlockNumber,
+		Process: func(ctx context.Context, d interface{}, start, end uint64) error {
+			return w.storeNewDeposits(ctx, d.(SQLDriverApp), liquidity, start, end)
+		},
+		Rollback: func(_ context.Context, d interface{}, blockNumber uint64) (uint64, error) {
+			q := d.(SQLDriverApp)
+
+			err := q.DelUnindexedDepositsAfterBlockNumber(blockNumber)
+			if err != nil {
+				return 0, errors.Join(ErrDelUnindexedDepositsAfterBlockNumberFail, err)
+			}
+
+			return blockNumber, nil
+		},
+	})
+}
+
+func (w *depositIndexer) storeNewDeposits(
+	ctx context.Context,
+	q SQLDriverApp,
+	liquidity *bindings.Liquidity,
+	start, end uint64,
+) error {
+	iterator, err := liquidity.FilterDeposited(&bind.FilterOpts{
+		Start:   start,
+		End:     &end,
+		Context: ctx,
+	}, []*big.Int{}, []common.Address{}, [][int32Key]byte{})
+	if err != nil {
+		return errors.Join(ErrFilterLogsFail, err)
+	}
+
+	defer func() {
+		_ = iterator.Close()
+	}()
+
+	var events []*bindings.LiquidityDeposited
+	for iterator.Next() {
+		events = append(events, iterator.Event)
+	}
+
+	if err = iterator.Error(); err != nil {
+		return errors.Join(ErrEncounteredWhileIterating, err)
+	}
+
+	for key := range events {
+		depositLeaf := intMaxTypes.DepositLeaf{
+			RecipientSaltHash: events[key].RecipientSaltHash,
+			TokenIndex:        events[key].TokenIndex,
+			Amount:            events[key].Amount,
+		}
+
+		var amount uint256.Int
+		_ = amount.SetFromBig(events[key].Amount)
+
+		_, err = q.CreateDeposit(
+			events[key].DepositId.Uint64(),
+			depositLeaf.Hash().Hex(),
+			common.Hash(events[key].RecipientSaltHash).Hex(),
+			events[key].TokenIndex,
+			&amount,
+			events[key].Raw.BlockNumber,
+		)
+		if err != nil {
+			return errors.Join(ErrCreateDepositFail, err)
+		}
+	}
+
+	if len(events) != 0 {
+		w.log.Debugf("Stored %d new deposits\n", len(events))
+	}
+
+	return nil
+}
+
+// fetchDepositsProcessed appends the deposits processed by the Rollup contract to the deposit tree.
+// The deposits processed in the reorganized blocks are removed from the deposit tree.
+func (w *depositIndexer) fetchDepositsProcessed(
+	ctx context.Context,
+	scanner log_scanner.LogScanner,
+	liquidity *bindings.Liquidity,
+	rollup *bindings.Rollup,
+) error {
+	return scanner.Scan(ctx, &log_scanner.Handler{
+		EventName:           mDBApp.DepositsProcessedEvent,
+		DeployedBlockNumber: w.cfg.Blockchain.RollupContractDeployedBlockNumber,
+		Process: func(ctx context.Context, d interface{}, start, end uint64) error {
+			return w.processDeposits(ctx, d.(SQLDriverApp), liquidity, rollup, start, end)
+		},
+		Rollback: func(_ context.Context, d interface{}, blockNumber uint64) (uint64, error) {
+			q := d.(SQLDriverApp)
+
+			err := q.ResetDepositIndexesAfterProcessedBlockNumber(blockNumber)
+			if err != nil {
+				return 0, errors.Join(ErrResetDepositIndexesAfterProcessedBlockNumberFail, err)
+			}
+
+			return blockNumber, nil
+		},
+		Reload: func(_ context.Context) error {
+			return w.loadDepositTree(w.dbApp)
+		},
+	})
+}
+
+func (w *depositIndexer) processDeposits(
+	ctx context.Context,
+	q SQLDriverApp,
+	liquidity *bindings.Liquidity,
+	rollup *bindings.Rollup,
+	start, end uint64,
+) error {
+	iterator, err := rollup.FilterDepositsProcessed(&bind.FilterOpts{
+		Start:   start,
+		End:     &end,
+		Context: ctx,
+	}, []*big.Int{})
+	if err != nil {
+		return errors.Join(ErrFilterLogsFail, err)
+	}
+
+	defer func() {
+		_ = iterator.Close()
+	}()
+
+	var events []*bindings.RollupDepositsProcessed
+	for iterator.Next() {
+		events = append(events, iterator.Event)
+	}
+
+	if err = iterator.Error(); err != nil {
+		return errors.Join(ErrEncounteredWhileIterating, err)
+	}
+
+	for key := range events {
+		var depositHashes [][int32Key]byte
+		depositHashes, err = fetchProcessedDepositHashes(ctx, liquidity, events[key].LastProcessedDepositId)
+		if err != nil {
+			return err
+		}
+
+		err = w.appendProcessedDeposits(q, events[key], depositHashes)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// appendProcessedDeposits assigns the next deposit tree indices to the processed deposits
+// and checks that the resulting root is equal to the root of the DepositsProcessed event.
+func (w *depositIndexer) appendProcessedDeposits(
+	q SQLDriverApp,
+	event *bindings.RollupDepositsProcessed,
+	depositHashes [][int32Key]byte,
+) (err error) {
+	w.depositTreeMu.Lock()
+	defer w.depositTreeMu.Unlock()
+
+	root, nextIndex, _ := w.depositTree.GetCurrentRootCountAndSiblings()
+	for key := range depositHashes {
+		var deposit *mDBApp.Deposit
+		deposit, err = q.UpdateDepositIndexByDepositHash(
+			common.Hash(depositHashes[key]).Hex(), nextIndex, event.Raw.BlockNumber,
+		)
+		if err != nil {
+			return errors.Join(ErrUpdateDepositIndexByDepositHashFail, err)
+		}
+
+		var depositLeaf *intMaxTypes.DepositLeaf
+		depositLeaf, err = DepositLeafFromDeposit(deposit)
+		if err != nil {
+			return err
+		}
+
+		root, err = w.depositTree.AddLeaf(nextIndex, depositLeaf)
+		if err != nil {
+			return errors.Join(ErrAddDepositLeafFail, err)
+		}
+		nextIndex++
+	}
+
+	if root != event.DepositTreeRoot {
+		return fmt.Errorf(
+			"%w: expected %s, got %s",
+			ErrDepositTreeRootMismatch, common.Hash(event.DepositTreeRoot).Hex(), root.Hex(),
+		)
+	}
+
+	w.log.Debugf(
+		"Processed deposits up to deposit ID %s (deposit tree root: %s)\n",
+		event.LastProcessedDepositId.String(), root.Hex(),
+	)
+
+	return nil
+}
+
+// fetchProcessedDepositHashes returns the deposit hashes relayed to the Rollup contract
+// by the Liquidity contract up to the given deposit ID.
+func fetchProcessedDepositHashes(
+	ctx context.Context,
+	liquidity *bindings.Liquidity,
+	upToDepositID *big.Int,
+) ([][int32Key]byte, error) {
+	iterator, err := liquidity.FilterDepositsAnalyzedAndRelayed(&bind.FilterOpts{
+		Context: ctx,
+	}, []*big.Int{upToDepositID})
+	if err != nil {
+		return nil, errors.Join(ErrFilterLogsFail, err)
+	}
+
+	defer func() {
+		_ = iterator.Close()
+	}()
+
+	if !iterator.Next() {
+		if err = iterator.Error(); err != nil {
+			return nil, errors.Join(ErrEncounteredWhileIterating, err)
+		}
+
+		return nil, fmt.Errorf("%w: %s", ErrDepositsRelayedEventNotFound, upToDepositID.String())
+	}
+
+	return DecodeProcessDepositsMessage(iterator.Event.Message)
+}
+
+// DecodeProcessDepositsMessage returns the deposit hashes of the processDeposits call
+// that the Liquidity contract sends to the Rollup contract.
+func DecodeProcessDepositsMessage(message []byte) ([][int32Key]byte, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(bindings.RollupMetaData.ABI))
+	if err != nil {
+		return nil, errors.Join(ErrParseRollupABIFail, err)
+	}
+
+	if len(message) < int4Key {
+		return nil, ErrProcessDepositsMessageInvalid
+	}
+
+	var method *abi.Method
+	method, err = parsedABI.MethodById(message[:int4Key])
+	if err != nil || method.Name != processDepositsMethod {
+		return nil, errors.Join(ErrProcessDepositsMessageInvalid, err)
+	}
+
+	var args []interface{}
+	args, err = method.Inputs.Unpack(message[int4Key:])
+	if err != nil {
+		return nil, errors.Join(ErrProcessDepositsMessageInvalid, err)
+	}
+
+	depositHashes, ok := args[int1Key].([][int32Key]byte)
+	if !ok {
+		return nil, ErrProcessDepositsMessageInvalid
+	}
+
+	return depositHashes, nil
+}
+
+// NewDepositTreeFromDeposits builds the deposit tree from the deposits ordered by their deposit index.
+func NewDepositTreeFromDeposits(deposits []*mDBApp.Deposit) (*intMaxTree.DepositTree, error) {
+	leaves := make([]*intMaxTypes.DepositLeaf, len(deposits))
+	for key := range deposits {
+		if deposits[key].DepositIndex == nil || *deposits[key].DepositIndex != uint32(key) {
+			return nil, ErrDepositIndexInvalid
+		}
+
+		var err error
+		leaves[key], err = DepositLeafFromDeposit(deposits[key])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	depositTree, err := intMaxTree.NewDepositTree(intMaxTree.DEPOSIT_TREE_HEIGHT, leaves)
+	if err != nil {
+		return nil, errors.Join(ErrNewDepositTreeFail, err)
+	}
+
+	return depositTree, nil
+}
+
+// DepositMerkleProofFromDepositTree returns the inclusion proof of the indexed deposit.
+func DepositMerkleProofFromDepositTree(
+	depositTree *intMaxTree.DepositTree,
+	deposit *mDBApp.Deposit,
+) (*DepositMerkleProof, error) {
+	_, count, _ := depositTree.GetCurrentRootCountAndSiblings()
+	if deposit.DepositIndex == nil || *deposit.DepositIndex >= count {
+		return nil, ErrDepositNotIndexed
+	}
+
+	siblings, root, err := depositTree.ComputeMerkleProof(*deposit.DepositIndex)
+	if err != nil {
+		return nil, errors.Join(ErrComputeDepositMerkleProofFail, err)
+	}
+
+	proof := DepositMerkleProof{
+		DepositID:    deposit.DepositID,
+		DepositHash:  common.HexToHash(deposit.DepositHash),
+		DepositIndex: *deposit.DepositIndex,
+		Siblings:     make([]common.Hash, len(siblings)),
+		Root:         root,
+	}
+	for key := range siblings {
+		proof.Siblings[key] = siblings[key]
+	}
+
+	return &proof, nil
+}
+
+func DepositLeafFromDeposit(deposit *mDBApp.Deposit) (*intMaxTypes.DepositLeaf, error) {
+	recipientSaltHash, err := hexutil.Decode(deposit.RecipientSaltHash)
+	if err != nil || len(recipientSaltHash) != int32Key {
+		return nil, errors.Join(ErrDecodeRecipientSaltHashFail, err)
+	}
+
+	depositLeaf := intMaxTypes.DepositLeaf{
+		TokenIndex: deposit.TokenIndex,
+		Amount:     deposit.Amount.ToBig(),
+	}
+	copy(depositLeaf.RecipientSaltHash[:], recipientSaltHash)
+
+	return &depositLeaf, nil
+}
@@ -0,0 +1,57 @@
+package deposit_indexer_test
+
+import (
+	"intmax2-node/internal/deposit_indexer"
+	intMaxTree "intmax2-node/internal/tree"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepositMerkleProofFromDepositTree(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	deposits := make([]*mDBApp.Deposit, 4)
+	for i := range deposits {
+		var recipientSaltHash common.Hash
+		_, err := r.Read(recipientSaltHash[:])
+		require.NoError(t, err)
+
+		depositIndex := uint32(i)
+		deposits[i] = &mDBApp.Deposit{
+			DepositID:         uint64(i + 1),
+			RecipientSaltHash: recipientSaltHash.Hex(),
+			TokenIndex:        uint32(i),
+			Amount:            uint256.NewInt(r.Uint64()),
+			DepositIndex:      &depositIndex,
+		}
+		leaf, err := deposit_indexer.DepositLeafFromDeposit(deposits[i])
+		require.NoError(t, err)
+		deposits[i].DepositHash = leaf.Hash().Hex()
+	}
+
+	depositTree, err := deposit_indexer.NewDepositTreeFromDeposits(deposits)
+	require.NoError(t, err)
+	root, _, _ := depositTree.GetCurrentRootCountAndSiblings()
+
+	for i := range deposits {
+		proof, err := deposit_indexer.DepositMerkleProofFromDepositTree(depositTree, deposits[i])
+		require.NoError(t, err)
+		assert.Equal(t, root, proof.Root)
+		assert.Equal(t, deposits[i].DepositID, proof.DepositID)
+
+		assert.Equal(t, root, intMaxTree.ComputeKeccakMerkleRootFromProof(proof.DepositHash, proof.DepositIndex, proof.Siblings))
+	}
+
+	pending := &mDBApp.Deposit{DepositID: 5}
+	_, err = deposit_indexer.DepositMerkleProofFromDepositTree(depositTree, pending)
+	assert.ErrorIs(t, err, deposit_indexer.ErrDepositNotIndexed)
+
+	outOfOrder := []*mDBApp.Deposit{deposits[1]}
+	_, err = deposit_indexer.NewDepositTreeFromDeposits(outOfOrder)
+	assert.ErrorIs(t, err, deposit_indexer.ErrDepositIndexInvalid)
+}
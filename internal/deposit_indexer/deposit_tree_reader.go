@@ -0,0 +1,145 @@
+package deposit_indexer
+
+import (
+	"errors"
+	intMaxTree "intmax2-node/internal/tree"
+	intMaxTypes "intmax2-node/internal/types"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type depositTreeReader struct {
+	dbApp         ReaderSQLDriverApp
+	depositTreeMu sync.Mutex
+	depositTree   *intMaxTree.DepositTree
+}
+
+// NewDepositTreeReader returns the reader of the deposit tree that is written by the deposit indexer,
+// which may run in another process. The reader keeps the deposit tree in memory and appends
+// the deposits indexed since the last read, so the tree is rebuilt only after the deposit indexer
+// has rolled back the leaves of the tree.
+func NewDepositTreeReader(dbApp ReaderSQLDriverApp) DepositTreeReader {
+	return &depositTreeReader{
+		dbApp: dbApp,
+	}
+}
+
+func (r *depositTreeReader) MerkleProofByDepositID(depositID uint64) (*DepositMerkleProof, error) {
+	deposit, err := r.dbApp.DepositByDepositID(depositID)
+	if err != nil {
+		return nil, errors.Join(ErrDepositByDepositIDFail, err)
+	}
+
+	return r.merkleProof(deposit)
+}
+
+func (r *depositTreeReader) MerkleProofByDepositHash(depositHash common.Hash) (*DepositMerkleProof, error) {
+	deposit, err := r.dbApp.DepositByDepositHash(depositHash.Hex())
+	if err != nil {
+		return nil, errors.Join(ErrDepositByDepositHashFail, err)
+	}
+
+	return r.merkleProof(deposit)
+}
+
+func (r *depositTreeReader) merkleProof(deposit *mDBApp.Deposit) (*DepositMerkleProof, error) {
+	if deposit.DepositIndex == nil {
+		return nil, ErrDepositNotIndexed
+	}
+
+	r.depositTreeMu.Lock()
+	defer r.depositTreeMu.Unlock()
+
+	if !r.contains(deposit) {
+		err := r.update()
+		if err != nil {
+			return nil, err
+		}
+
+		if !r.contains(deposit) {
+			return nil, ErrDepositNotIndexed
+		}
+	}
+
+	return DepositMerkleProofFromDepositTree(r.depositTree, deposit)
+}
+
+// contains reports whether the deposit is the leaf of its deposit index.
+func (r *depositTreeReader) contains(deposit *mDBApp.Deposit) bool {
+	if r.depositTree == nil {
+		return false
+	}
+
+	_, count, _ := r.depositTree.GetCurrentRootCountAndSiblings()
+	if *deposit.DepositIndex >= count {
+		return false
+	}
+
+	return r.depositTree.Leaves[*deposit.DepositIndex].Hash() == common.HexToHash(deposit.DepositHash)
+}
+
+// update appends the deposits indexed since the last update to the deposit tree. The last leaf
+// of the deposit tree is read again to detect the rollback of the deposit indexer.
+func (r *depositTreeReader) update() error {
+	if r.depositTree == nil {
+		return r.rebuild()
+	}
+
+	_, count, _ := r.depositTree.GetCurrentRootCountAndSiblings()
+	if count == 0 {
+		return r.rebuild()
+	}
+
+	deposits, err := r.dbApp.IndexedDepositsFromDepositIndex(count - int1Key)
+	if err != nil {
+		return errors.Join(ErrIndexedDepositsFail, err)
+	}
+
+	if len(deposits) == 0 ||
+		*deposits[0].DepositIndex != count-int1Key ||
+		common.HexToHash(deposits[0].DepositHash) != r.depositTree.Leaves[count-int1Key].Hash() {
+		return r.rebuild()
+	}
+
+	for key := range deposits[int1Key:] {
+		deposit := deposits[key+int1Key]
+		if *deposit.DepositIndex != count {
+			return r.rebuild()
+		}
+
+		var depositLeaf *intMaxTypes.DepositLeaf
+		depositLeaf, err = DepositLeafFromDeposit(deposit)
+		if err != nil {
+			return err
+		}
+
+		_, err = r.depositTree.AddLeaf(count, depositLeaf)
+		if err != nil {
+			return errors.Join(ErrAddDepositLeafFail, err)
+		}
+		count++
+	}
+
+	return nil
+}
+
+func (r *depositTreeReader) rebuild() error {
+	deposits, err := r.dbApp.IndexedDepositsFromDepositIndex(0)
+	if err != nil {
+		return errors.Join(ErrIndexedDepositsFail, err)
+	}
+
+	var depositTree *intMaxTree.DepositTree
+	depositTree, err = NewDepositTreeFromDeposits(deposits)
+	if err != nil {
+		// The deposit tree is left to be rebuilt on the next read.
+		r.depositTree = nil
+		return err
+	}
+
+	r.depositTree = depositTree
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package deposit_indexer_test
+
+import (
+	"intmax2-node/internal/deposit_indexer"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDepositTreeReader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	dbApp := NewMockReaderSQLDriverApp(ctrl)
+
+	r := rand.New(rand.NewSource(0))
+	newDeposit := func(depositID uint64, depositIndex uint32) *mDBApp.Deposit {
+		var recipientSaltHash common.Hash
+		_, err := r.Read(recipientSaltHash[:])
+		require.NoError(t, err)
+
+		deposit := mDBApp.Deposit{
+			DepositID:         depositID,
+			RecipientSaltHash: recipientSaltHash.Hex(),
+			Amount:            uint256.NewInt(r.Uint64()),
+			DepositIndex:      &depositIndex,
+		}
+		leaf, err := deposit_indexer.DepositLeafFromDeposit(&deposit)
+		require.NoError(t, err)
+		deposit.DepositHash = leaf.Hash().Hex()
+
+		return &deposit
+	}
+
+	var indexed []*mDBApp.Deposit
+	reads := make(map[uint32]int)
+	dbApp.EXPECT().IndexedDepositsFromDepositIndex(gomock.Any()).DoAndReturn(
+		func(depositIndex uint32) ([]*mDBApp.Deposit, error) {
+			reads[depositIndex]++
+			if int(depositIndex) >= len(indexed) {
+				return nil, nil
+			}
+			return indexed[depositIndex:], nil
+		},
+	).AnyTimes()
+	dbApp.EXPECT().DepositByDepositID(gomock.Any()).DoAndReturn(
+		func(depositID uint64) (*mDBApp.Deposit, error) {
+			for key := range indexed {
+				if indexed[key].DepositID == depositID {
+					return indexed[key], nil
+				}
+			}
+			return &mDBApp.Deposit{DepositID: depositID}, nil
+		},
+	).AnyTimes()
+
+	reader := deposit_indexer.NewDepositTreeReader(dbApp)
+
+	indexed = []*mDBApp.Deposit{newDeposit(1, 0), newDeposit(2, 1)}
+	proof, err := reader.MerkleProofByDepositID(2)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), proof.DepositIndex)
+	assert.Equal(t, 1, reads[0])
+
+	// The proof of a known deposit is served without reading the deposits again.
+	_, err = reader.MerkleProofByDepositID(1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reads[0])
+
+	_, err = reader.MerkleProofByDepositID(3)
+	assert.ErrorIs(t, err, deposit_indexer.ErrDepositNotIndexed)
+
+	// The new deposits are appended from the last leaf.
+	indexed = append(indexed, newDeposit(3, 2), newDeposit(4, 3))
+	proof, err = reader.MerkleProofByDepositID(4)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reads[0])
+	expected, err := deposit_indexer.NewDepositTreeFromDeposits(indexed)
+	require.NoError(t, err)
+	root, _, _ := expected.GetCurrentRootCountAndSiblings()
+	assert.Equal(t, root, proof.Root)
+
+	// The deposit tree is rebuilt after the deposit indexer has rolled back the last leaves.
+	indexed = append(indexed[:2], newDeposit(5, 2))
+	proof, err = reader.MerkleProofByDepositID(5)
+	require.NoError(t, err)
+	assert.Equal(t, 2, reads[0])
+	expected, err = deposit_indexer.NewDepositTreeFromDeposits(indexed)
+	require.NoError(t, err)
+	root, _, _ = expected.GetCurrentRootCountAndSiblings()
+	assert.Equal(t, root, proof.Root)
+}
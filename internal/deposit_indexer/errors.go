@@ -0,0 +1,81 @@
+package deposit_indexer
+
+import "errors"
+
+// ErrNewClientFail error: failed to create new client.
+var ErrNewClientFail = errors.New("failed to create new client")
+
+// ErrIndexedDepositsFail error: failed to get indexed deposits.
+var ErrIndexedDepositsFail = errors.New("failed to get indexed deposits")
+
+// ErrDepositIndexInvalid error: the deposit index must be equal to the position of the deposit.
+var ErrDepositIndexInvalid = errors.New("the deposit index must be equal to the position of the deposit")
+
+// ErrNewDepositTreeFail error: failed to create new deposit tree.
+var ErrNewDepositTreeFail = errors.New("failed to create new deposit tree")
+
+// ErrInstantiateLiquidityContractFail error: failed to instantiate a Liquidity contract.
+var ErrInstantiateLiquidityContractFail = errors.New("failed to instantiate a Liquidity contract")
+
+// ErrInstantiateRollupContractFail error: failed to instantiate a Rollup contract.
+var ErrInstantiateRollupContractFail = errors.New("failed to instantiate a Rollup contract")
+
+// ErrFetchNewDepositsFail error: failed to fetch new deposits.
+var ErrFetchNewDepositsFail = errors.New("failed to fetch new deposits")
+
+// ErrFetchDepositsProcessedFail error: failed to fetch processed deposits.
+var ErrFetchDepositsProcessedFail = errors.New("failed to fetch processed deposits")
+
+// ErrFilterLogsFail error: failed to filter logs.
+var ErrFilterLogsFail = errors.New("failed to filter logs")
+
+// ErrEncounteredWhileIterating error: encountered while iterating error occurred.
+var ErrEncounteredWhileIterating = errors.New("encountered while iterating error occurred")
+
+// ErrCreateDepositFail error: failed to create deposit.
+var ErrCreateDepositFail = errors.New("failed to create deposit")
+
+// ErrDelUnindexedDepositsAfterBlockNumberFail error: failed to delete unindexed deposits after block number.
+var ErrDelUnindexedDepositsAfterBlockNumberFail = errors.New("failed to delete unindexed deposits after block number")
+
+// ErrResetDepositIndexesAfterProcessedBlockNumberFail error: failed to reset deposit indexes after processed block number.
+var ErrResetDepositIndexesAfterProcessedBlockNumberFail = errors.New("failed to reset deposit indexes after processed block number")
+
+// ErrUpdateDepositIndexByDepositHashFail error: failed to update deposit index by deposit hash.
+var ErrUpdateDepositIndexByDepositHashFail = errors.New("failed to update deposit index by deposit hash")
+
+// ErrAddDepositLeafFail error: failed to add leaf into deposit tree.
+var ErrAddDepositLeafFail = errors.New("failed to add leaf into deposit tree")
+
+// ErrDepositTreeRootMismatch error: the deposit tree root does not match the root of the DepositsProcessed event.
+var ErrDepositTreeRootMismatch = errors.New("the deposit tree root does not match the root of the DepositsProcessed event")
+
+// ErrDepositsRelayedEventNotFound error: the DepositsAnalyzedAndRelayed event not found.
+var ErrDepositsRelayedEventNotFound = errors.New("the DepositsAnalyzedAndRelayed event not found")
+
+// ErrParseRollupABIFail error: failed to parse the Rollup contract ABI.
+var ErrParseRollupABIFail = errors.New("failed to parse the Rollup contract ABI")
+
+// ErrProcessDepositsMessageInvalid error: the processDeposits message must be valid.
+var ErrProcessDepositsMessageInvalid = errors.New("the processDeposits message must be valid")
+
+// ErrDecodeRecipientSaltHashFail error: failed to decode recipient salt hash.
+var ErrDecodeRecipientSaltHashFail = errors.New("failed to decode recipient salt hash")
+
+// ErrDepositNotIndexed error: the deposit is not included in the deposit tree yet.
+var ErrDepositNotIndexed = errors.New("the deposit is not included in the deposit tree yet")
+
+// ErrDepositByDepositIDFail error: failed to get deposit by deposit ID.
+var ErrDepositByDepositIDFail = errors.New("failed to get deposit by deposit ID")
+
+// ErrDepositByDepositHashFail error: failed to get deposit by deposit hash.
+var ErrDepositByDepositHashFail = errors.New("failed to get deposit by deposit hash")
+
+// ErrComputeDepositMerkleProofFail error: failed to compute deposit Merkle proof.
+var ErrComputeDepositMerkleProofFail = errors.New("failed to compute deposit Merkle proof")
+
+// ErrFetchDepositRootFail error: failed to fetch deposit root from the Rollup contract.
+var ErrFetchDepositRootFail = errors.New("failed to fetch deposit root from the Rollup contract")
+
+// ErrDepositRootMismatch error: the deposit tree root does not match the current root of the Rollup contract.
+var ErrDepositRootMismatch = errors.New("the deposit tree root does not match the current root of the Rollup contract")
@@ -0,0 +1,32 @@
+package deposit_indexer
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type DepositIndexer interface {
+	Init(ctx context.Context) error
+	Start(
+		ctx context.Context,
+		tickerEventWatcher *time.Ticker,
+	) error
+	MerkleProofByDepositID(depositID uint64) (*DepositMerkleProof, error)
+	MerkleProofByDepositHash(depositHash common.Hash) (*DepositMerkleProof, error)
+}
+
+type DepositTreeReader interface {
+	MerkleProofByDepositID(depositID uint64) (*DepositMerkleProof, error)
+	MerkleProofByDepositHash(depositHash common.Hash) (*DepositMerkleProof, error)
+}
+
+// DepositMerkleProof is the inclusion proof of the deposit in the deposit tree.
+type DepositMerkleProof struct {
+	DepositID    uint64
+	DepositHash  common.Hash
+	DepositIndex uint32
+	Siblings     []common.Hash
+	Root         common.Hash
+}
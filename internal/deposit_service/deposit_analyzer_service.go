@@ -6,7 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"intmax2-node/configs"
+	"intmax2-node/internal/aml"
 	"intmax2-node/internal/bindings"
+	"intmax2-node/internal/log_scanner"
 	"intmax2-node/internal/logger"
 	errorsDB "intmax2-node/pkg/sql_db/errors"
 	"time"
@@ -23,7 +25,7 @@ import (
 )
 
 const fixedDepositValueInWei = 1e17 // 0.1 ETH in Wei
-const amlRejectionThreshold = 70
+const DepositAnalyzerJob = "deposit_analyzer"
 const noDepositEventsFoundError = "No deposit events found"
 
 type DepositAnalyzerService struct {
@@ -32,6 +34,7 @@ type DepositAnalyzerService struct {
 	log       logger.Logger
 	client    *ethclient.Client
 	liquidity *bindings.Liquidity
+	aml       aml.Screener
 }
 
 type DepositEventInfo struct {
@@ -59,23 +62,29 @@ func newDepositAnalyzerService(ctx context.Context, cfg *configs.Config, log log
 		return nil, fmt.Errorf("failed to instantiate a Liquidity contract: %w", err)
 	}
 
+	amlProvider, err := aml.NewProvider(cfg, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AML provider: %w", err)
+	}
+
 	return &DepositAnalyzerService{
 		ctx:       ctx,
 		cfg:       cfg,
 		log:       log,
 		client:    client,
 		liquidity: liquidity,
+		aml:       aml.NewScreener(cfg, log, amlProvider),
 	}, nil
 }
 
 // TODO: TxManager Class that stops processing if there are any pending transactions.
-func DepositAnalyzer(ctx context.Context, cfg *configs.Config, log logger.Logger, db SQLDriverApp, sb ServiceBlockchain) {
+func DepositAnalyzer(ctx context.Context, cfg *configs.Config, log logger.Logger, db SQLDriverApp, sb ServiceBlockchain) error {
 	depositAnalyzerService, err := newDepositAnalyzerService(ctx, cfg, log, sb)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to initialize DepositAnalyzerService: %v", err.Error()))
+		return fmt.Errorf("failed to initialize DepositAnalyzerService: %w", err)
 	}
 
-	_ = db.Exec(ctx, nil, func(d interface{}, _ interface{}) (err error) {
+	return db.Exec(ctx, nil, func(d interface{}, _ interface{}) (err error) {
 		q := d.(SQLDriverApp)
 
 		event, err := q.EventBlockNumberByEventName(mDBApp.DepositsAndAnalyzedReleyedEvent)
@@ -86,7 +95,7 @@ func DepositAnalyzer(ctx context.Context, cfg *configs.Config, log logger.Logger
 					LastProcessedBlockNumber: cfg.Blockchain.LiquidityContractDeployedBlockNumber,
 				}
 			} else {
-				panic(fmt.Sprintf("Error fetching event block number: %v", err.Error()))
+				return fmt.Errorf("error fetching event block number: %w", err)
 			}
 		} else if event == nil {
 			event = &mDBApp.EventBlockNumber{
@@ -97,11 +106,11 @@ func DepositAnalyzer(ctx context.Context, cfg *configs.Config, log logger.Logger
 
 		lastEventInfo, err := depositAnalyzerService.fetchLastDepositsAndAnalyzedReleyedEvent(event.LastProcessedBlockNumber)
 		if err != nil {
-			panic(fmt.Sprintf("Failed to get last deposit analyzed block number: %v", err.Error()))
+			return fmt.Errorf("failed to get last deposit analyzed block number: %w", err)
 		}
 
 		if lastEventInfo == nil || lastEventInfo.BlockNumber == nil {
-			panic("Last event info or block number is nil")
+			return errors.New("last event info or block number is nil")
 		}
 
 		if *lastEventInfo.BlockNumber == uint64(0) {
@@ -110,7 +119,7 @@ func DepositAnalyzer(ctx context.Context, cfg *configs.Config, log logger.Logger
 
 		_, err = q.UpsertEventBlockNumber(mDBApp.DepositsAndAnalyzedReleyedEvent, *lastEventInfo.BlockNumber)
 		if err != nil {
-			panic(fmt.Sprintf("Error updating event block number: %v", err.Error()))
+			return fmt.Errorf("error updating event block number: %w", err)
 		}
 
 		var (
@@ -122,7 +131,7 @@ func DepositAnalyzer(ctx context.Context, cfg *configs.Config, log logger.Logger
 		events, maxDepositIndex, tokenIndexMap, err =
 			depositAnalyzerService.fetchNewDeposits(*lastEventInfo.BlockNumber)
 		if err != nil {
-			panic(fmt.Sprintf("Failed to fetch new deposits: %v", err.Error()))
+			return fmt.Errorf("failed to fetch new deposits: %w", err)
 		}
 
 		shouldSubmit, err := depositAnalyzerService.shouldProcessDepositAnalyzer(
@@ -130,7 +139,7 @@ func DepositAnalyzer(ctx context.Context, cfg *configs.Config, log logger.Logger
 			*lastEventInfo.BlockNumber,
 		)
 		if err != nil {
-			panic(fmt.Sprintf("Error in threshold and time diff check: %v", err.Error()))
+			return fmt.Errorf("error in threshold and time diff check: %w", err)
 		}
 
 		if !shouldSubmit {
@@ -144,40 +153,43 @@ func DepositAnalyzer(ctx context.Context, cfg *configs.Config, log logger.Logger
 
 		tokenInfoMap, err := depositAnalyzerService.getTokenInfoMap(tokenIndexMap)
 		if err != nil {
-			panic(fmt.Sprintf("Failed to get token info map: %v", err.Error()))
+			return fmt.Errorf("failed to get token info map: %w", err)
 		}
 
 		var rejectDepositIndices []*big.Int
 		for _, event := range events {
-			contractAddress := tokenInfoMap[event.TokenIndex]
-			score := fetchAMLScore(event.Sender.Hex(), contractAddress.Hex())
-			if score > amlRejectionThreshold {
+			var rejected bool
+			rejected, err = depositAnalyzerService.screenDeposit(q, event, tokenInfoMap[event.TokenIndex])
+			if err != nil {
+				return fmt.Errorf("failed to screen deposit %d: %w", event.DepositId.Uint64(), err)
+			}
+			if rejected {
 				rejectDepositIndices = append(rejectDepositIndices, new(big.Int).SetUint64(event.DepositId.Uint64()))
 			}
 		}
 
 		lastRelayedDepositId, err := depositAnalyzerService.getLastRelayedDepositId()
 		if err != nil {
-			panic(fmt.Sprintf("Failed to get last relayed deposit id: %v", err.Error()))
+			return fmt.Errorf("failed to get last relayed deposit id: %w", err)
 		}
 
 		pendingDepositsCount := maxDepositIndex.Uint64() - lastRelayedDepositId - uint64(len(rejectDepositIndices))
 		receipt, err := depositAnalyzerService.analyzeAndRelayDeposits(maxDepositIndex, rejectDepositIndices, pendingDepositsCount)
 		if err != nil {
-			panic(fmt.Sprintf("Failed to analyze and relay deposits: %v", err.Error()))
+			return fmt.Errorf("failed to analyze and relay deposits: %w", err)
 		}
 
 		if receipt == nil {
-			panic("Received nil receipt for transaction")
+			return errors.New("received nil receipt for transaction")
 		}
 
 		switch receipt.Status {
 		case types.ReceiptStatusSuccessful:
 			log.Infof("Successfully analyzed and relayed deposits %d deposits, %d rejections. Transaction Hash: %v", len(events), len(rejectDepositIndices), receipt.TxHash.Hex())
 		case types.ReceiptStatusFailed:
-			panic(fmt.Sprintf("Transaction failed: analyzed and relayed deposits unsuccessful. Transaction Hash: %v", receipt.TxHash.Hex()))
+			return fmt.Errorf("transaction failed: analyzed and relayed deposits unsuccessful. Transaction Hash: %v", receipt.TxHash.Hex())
 		default:
-			panic(fmt.Sprintf("Unexpected transaction status: %d. Transaction Hash: %v", receipt.Status, receipt.TxHash.Hex()))
+			return fmt.Errorf("unexpected transaction status: %d. Transaction Hash: %v", receipt.Status, receipt.TxHash.Hex())
 		}
 
 		return nil
@@ -186,33 +198,38 @@ func DepositAnalyzer(ctx context.Context, cfg *configs.Config, log logger.Logger
 
 func (d *DepositAnalyzerService) fetchLastDepositsAndAnalyzedReleyedEvent(startBlockNumber uint64) (*DepositEventInfo, error) {
 	nextBlock := startBlockNumber + 1
-	iterator, err := d.liquidity.FilterDepositsAnalyzedAndRelayed(&bind.FilterOpts{
-		Start:   nextBlock,
-		End:     nil,
-		Context: d.ctx,
-	}, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to filter logs: %v", err)
-	}
-
-	defer func() {
-		_ = iterator.Close()
-	}()
 
 	var lastEvent *DepositEventInfo
+	err := log_scanner.FilterByRanges(d.ctx, d.client, nextBlock, d.cfg.LogScanner.MaxBlockRange,
+		func(opts *bind.FilterOpts) (bool, error) {
+			iterator, err := d.liquidity.FilterDepositsAnalyzedAndRelayed(opts, nil)
+			if err != nil {
+				return false, fmt.Errorf("failed to filter logs: %w", err)
+			}
 
-	for iterator.Next() {
-		if iterator.Error() != nil {
-			return nil, fmt.Errorf("error encountered while iterating: %v", iterator.Error())
-		}
+			defer func() {
+				_ = iterator.Close()
+			}()
 
-		currentId := iterator.Event.UpToDepositId.Uint64()
-		currentBlockNumber := iterator.Event.Raw.BlockNumber
+			for iterator.Next() {
+				currentId := iterator.Event.UpToDepositId.Uint64()
+				currentBlockNumber := iterator.Event.Raw.BlockNumber
 
-		lastEvent = &DepositEventInfo{
-			LastDepositId: &currentId,
-			BlockNumber:   &currentBlockNumber,
-		}
+				lastEvent = &DepositEventInfo{
+					LastDepositId: &currentId,
+					BlockNumber:   &currentBlockNumber,
+				}
+			}
+
+			if err = iterator.Error(); err != nil {
+				return false, fmt.Errorf("error encountered while iterating: %w", err)
+			}
+
+			return false, nil
+		},
+	)
+	if err != nil {
+		return nil, err
 	}
 
 	if lastEvent == nil {
@@ -231,32 +248,40 @@ func (d *DepositAnalyzerService) fetchNewDeposits(
 	startBlock uint64,
 ) ([]*bindings.LiquidityDeposited, *big.Int, map[uint32]bool, error) {
 	nextBlock := startBlock + 1
-	iterator, err := d.liquidity.FilterDeposited(&bind.FilterOpts{
-		Start:   nextBlock,
-		End:     nil,
-		Context: d.ctx,
-	}, []*big.Int{}, []common.Address{}, [][32]byte{})
-	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to filter logs: %w", err)
-	}
-
-	defer iterator.Close()
 
 	var events []*bindings.LiquidityDeposited
 	maxDepositIndex := new(big.Int)
 	tokenIndexMap := make(map[uint32]bool)
 
-	for iterator.Next() {
-		event := iterator.Event
-		events = append(events, event)
-		tokenIndexMap[event.TokenIndex] = true
-		if event.DepositId.Cmp(maxDepositIndex) > 0 {
-			maxDepositIndex.Set(event.DepositId)
-		}
-	}
+	err := log_scanner.FilterByRanges(d.ctx, d.client, nextBlock, d.cfg.LogScanner.MaxBlockRange,
+		func(opts *bind.FilterOpts) (bool, error) {
+			iterator, err := d.liquidity.FilterDeposited(opts, []*big.Int{}, []common.Address{}, [][32]byte{})
+			if err != nil {
+				return false, fmt.Errorf("failed to filter logs: %w", err)
+			}
+
+			defer func() {
+				_ = iterator.Close()
+			}()
 
-	if err = iterator.Error(); err != nil {
-		return nil, nil, nil, fmt.Errorf("error encountered while iterating: %w", err)
+			for iterator.Next() {
+				event := iterator.Event
+				events = append(events, event)
+				tokenIndexMap[event.TokenIndex] = true
+				if event.DepositId.Cmp(maxDepositIndex) > 0 {
+					maxDepositIndex.Set(event.DepositId)
+				}
+			}
+
+			if err = iterator.Error(); err != nil {
+				return false, fmt.Errorf("error encountered while iterating: %w", err)
+			}
+
+			return false, nil
+		},
+	)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
 	return events, maxDepositIndex, tokenIndexMap, nil
@@ -273,7 +298,9 @@ func (d *DepositAnalyzerService) shouldProcessDepositAnalyzer(events []*bindings
 	}
 
 	depositIds := []*big.Int{}
-	eventInfo, err := fetchDepositEvent(d.ctx, d.liquidity, lastBlockNumber, depositIds)
+	eventInfo, err := fetchDepositEvent(
+		d.ctx, d.client, d.liquidity, lastBlockNumber, d.cfg.LogScanner.MaxBlockRange, depositIds,
+	)
 	if err != nil {
 		if err.Error() == noDepositEventsFoundError {
 			fmt.Println("No deposit events found, skipping process")
@@ -372,10 +399,45 @@ func (d *DepositAnalyzerService) analyzeAndRelayDeposits(upToDepositId *big.Int,
 	return receipt, nil
 }
 
-func fetchAMLScore(sender string, contractAddress string) uint32 { // nolint:gocritic
-	const int50Key = 50
-	// TODO: Implement a real AML score fetching function
-	return int50Key
+// screenDeposit screens the sender of the deposit by the AML provider and records the decision.
+func (d *DepositAnalyzerService) screenDeposit(
+	db SQLDriverApp,
+	event *bindings.LiquidityDeposited,
+	tokenAddress common.Address,
+) (bool, error) {
+	screening, err := d.aml.Screen(d.ctx, &aml.Deposit{
+		DepositID:    event.DepositId.Uint64(),
+		Sender:       event.Sender,
+		TokenIndex:   event.TokenIndex,
+		TokenAddress: tokenAddress,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get AML score: %w", err)
+	}
+
+	_, err = db.UpsertDepositAMLScreening(&mDBApp.DepositAMLScreening{
+		DepositID:    screening.DepositID,
+		Sender:       screening.Sender.Hex(),
+		TokenIndex:   screening.TokenIndex,
+		TokenAddress: screening.TokenAddress.Hex(),
+		Provider:     screening.Provider,
+		Score:        screening.Score,
+		Threshold:    screening.Threshold,
+		Rejected:     screening.Rejected,
+		Reason:       screening.Reason,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to record AML screening: %w", err)
+	}
+
+	if screening.Rejected {
+		d.log.Warnf(
+			"Deposit %d of %s is rejected by %s: %s",
+			screening.DepositID, screening.Sender.Hex(), screening.Provider, screening.Reason,
+		)
+	}
+
+	return screening.Rejected, nil
 }
 
 func calculateAnalyzeAndRelayGasLimit(numDepositsToRelay uint64) uint64 {
@@ -403,34 +465,45 @@ func isBlockTimeExceeded(ctx context.Context, client *ethclient.Client, blockNum
 	return diff > duration, nil
 }
 
-func fetchDepositEvent(ctx context.Context, liquidity *bindings.Liquidity, startBlockNumber uint64, depositIds []*big.Int) (*DepositEventInfo, error) {
+func fetchDepositEvent(
+	ctx context.Context,
+	client log_scanner.ChainReader,
+	liquidity *bindings.Liquidity,
+	startBlockNumber, maxBlockRange uint64,
+	depositIds []*big.Int,
+) (*DepositEventInfo, error) {
 	nextBlock := startBlockNumber + 1
-	iterator, err := liquidity.FilterDeposited(&bind.FilterOpts{
-		Start:   nextBlock,
-		End:     nil,
-		Context: ctx,
-	}, depositIds, []common.Address{}, [][32]byte{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to filter logs: %v", err)
-	}
-	defer func() {
-		_ = iterator.Close()
-	}()
 
 	var event *DepositEventInfo
+	err := log_scanner.FilterByRanges(ctx, client, nextBlock, maxBlockRange,
+		func(opts *bind.FilterOpts) (bool, error) {
+			iterator, err := liquidity.FilterDeposited(opts, depositIds, []common.Address{}, [][32]byte{})
+			if err != nil {
+				return false, fmt.Errorf("failed to filter logs: %w", err)
+			}
+			defer func() {
+				_ = iterator.Close()
+			}()
 
-	for iterator.Next() {
-		if iterator.Error() != nil {
-			return nil, fmt.Errorf("error encountered while iterating: %v", iterator.Error())
-		}
+			for iterator.Next() {
+				currentId := iterator.Event.DepositId.Uint64()
+				currentBlockNumber := iterator.Event.Raw.BlockNumber
 
-		currentId := iterator.Event.DepositId.Uint64()
-		currentBlockNumber := iterator.Event.Raw.BlockNumber
+				event = &DepositEventInfo{
+					LastDepositId: &currentId,
+					BlockNumber:   &currentBlockNumber,
+				}
+			}
 
-		event = &DepositEventInfo{
-			LastDepositId: &currentId,
-			BlockNumber:   &currentBlockNumber,
-		}
+			if err = iterator.Error(); err != nil {
+				return false, fmt.Errorf("error encountered while iterating: %w", err)
+			}
+
+			return false, nil
+		},
+	)
+	if err != nil {
+		return nil, err
 	}
 
 	if event == nil {
@@ -245,6 +245,8 @@ func (w *depositSynchronizer) Start(
 	}
 }
 
+// SubscribeDepositsProcessed subscribes to the DepositsProcessed events of the Rollup contract.
+// The connection to the network stays open until the subscription is unsubscribed or fails.
 func SubscribeDepositsProcessed(
 	ctx context.Context,
 	cfg *intMaxTypes.RollupContractConfig,
@@ -257,20 +259,34 @@ func SubscribeDepositsProcessed(
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create new client: %w", err)
 	}
-	defer client.Close()
 
 	rollup, err := bindings.NewRollup(common.HexToAddress(cfg.RollupContractAddressHex), client)
 	if err != nil {
+		client.Close()
 		return nil, nil, fmt.Errorf("failed to instantiate a Liquidity contract: %w", err)
 	}
 
-	opts := &bind.WatchOpts{Context: context.Background()}
+	opts := &bind.WatchOpts{Context: ctx}
 	eventChan = make(chan *bindings.RollupDepositsProcessed)
 
-	subscription, err = rollup.WatchDepositsProcessed(opts, eventChan, []*big.Int{})
+	var sub event.Subscription
+	sub, err = rollup.WatchDepositsProcessed(opts, eventChan, []*big.Int{})
 	if err != nil {
+		client.Close()
 		return nil, nil, fmt.Errorf("failed to subscribe to event: %w", err)
 	}
 
+	subscription = event.NewSubscription(func(quit <-chan struct{}) error {
+		defer client.Close()
+		defer sub.Unsubscribe()
+
+		select {
+		case err := <-sub.Err():
+			return err
+		case <-quit:
+			return nil
+		}
+	})
+
 	return eventChan, subscription, nil
 }
@@ -0,0 +1,45 @@
+package keystore
+
+import "errors"
+
+// ErrCreateDirFail error: failed to create the keystore directory.
+var ErrCreateDirFail = errors.New("failed to create the keystore directory")
+
+// ErrReadDirFail error: failed to read the keystore directory.
+var ErrReadDirFail = errors.New("failed to read the keystore directory")
+
+// ErrReadKeyFileFail error: failed to read the key file.
+var ErrReadKeyFileFail = errors.New("failed to read the key file")
+
+// ErrWriteKeyFileFail error: failed to write the key file.
+var ErrWriteKeyFileFail = errors.New("failed to write the key file")
+
+// ErrPrivateKeyInvalid error: the Ethereum private key is invalid.
+var ErrPrivateKeyInvalid = errors.New("the Ethereum private key is invalid")
+
+// ErrEncryptKeyFail error: failed to encrypt the key.
+var ErrEncryptKeyFail = errors.New("failed to encrypt the key")
+
+// ErrDecryptKeyFail error: failed to decrypt the key (wrong passphrase?).
+var ErrDecryptKeyFail = errors.New("failed to decrypt the key (wrong passphrase?)")
+
+// ErrAccountExists error: the account already exists in the keystore.
+var ErrAccountExists = errors.New("the account already exists in the keystore")
+
+// ErrAccountNotFound error: the account not found in the keystore.
+var ErrAccountNotFound = errors.New("the account not found in the keystore")
+
+// ErrIntMaxKeyMismatch error: the INTMAX key does not match the INTMAX address of the key file.
+var ErrIntMaxKeyMismatch = errors.New("the INTMAX key does not match the INTMAX address of the key file")
+
+// ErrPassphraseEmpty error: the passphrase must not be empty.
+var ErrPassphraseEmpty = errors.New("the passphrase must not be empty")
+
+// ErrPassphraseMismatch error: the passphrases do not match.
+var ErrPassphraseMismatch = errors.New("the passphrases do not match")
+
+// ErrReadPassphraseFail error: failed to read the passphrase.
+var ErrReadPassphraseFail = errors.New("failed to read the passphrase")
+
+// ErrAccountAndPrivateKey error: only one of the account and the private key must be specified.
+var ErrAccountAndPrivateKey = errors.New("only one of the account and the private key must be specified")
@@ -0,0 +1,39 @@
+package keystore
+
+import (
+	"crypto/ecdsa"
+	intMaxAcc "intmax2-node/internal/accounts"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:generate mockgen -destination=../mocks/mock_keystore.go -package=mocks -source=interface.go
+
+// Account is the key file of the keystore.
+type Account struct {
+	EthereumAddress common.Address
+	// IntMaxAddress is empty for the key files without the INTMAX key,
+	// the INTMAX key of such files is derived from the Ethereum key.
+	IntMaxAddress string
+	Path          string
+}
+
+// Key is the decrypted key file of the keystore.
+type Key struct {
+	Account            *Account
+	EthereumPrivateKey *ecdsa.PrivateKey
+	IntMaxPrivateKey   *intMaxAcc.PrivateKey
+}
+
+// Keystore keeps the Ethereum keys and the INTMAX keys derived from them in the files
+// of the Web3 Secret Storage format, one file per account.
+type Keystore interface {
+	// Import encrypts the Ethereum private key in hex and the derived INTMAX key with the passphrase.
+	Import(privateKeyHex, passphrase string) (*Account, error)
+	// List returns the accounts of the keystore in the order of creation.
+	List() ([]*Account, error)
+	// Find returns the account by the Ethereum or INTMAX address.
+	Find(address string) (*Account, error)
+	// Unlock decrypts the key file of the account with the passphrase.
+	Unlock(account *Account, passphrase string) (*Key, error)
+}
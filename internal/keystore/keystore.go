@@ -0,0 +1,261 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"intmax2-node/configs"
+	intMaxAcc "intmax2-node/internal/accounts"
+	"intmax2-node/pkg/utils"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	ethKeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+const (
+	dirPerm          = 0o700
+	filePerm         = 0o600
+	fileNameTemplate = "UTC--%s--%s"
+	fileTimeFormat   = "2006-01-02T15-04-05.000000000Z"
+)
+
+// keyFile is the key file of the Web3 Secret Storage format version 3 with the encrypted INTMAX key.
+// The go-ethereum tools read these files ignoring the INTMAX fields.
+type keyFile struct {
+	Address       string                  `json:"address"`
+	Crypto        ethKeystore.CryptoJSON  `json:"crypto"`
+	ID            string                  `json:"id"`
+	Version       int                     `json:"version"`
+	IntMaxAddress string                  `json:"intmaxAddress,omitempty"`
+	IntMaxCrypto  *ethKeystore.CryptoJSON `json:"intmaxCrypto,omitempty"`
+}
+
+type keystore struct {
+	dir     string
+	scryptN int
+	scryptP int
+}
+
+// New returns the Keystore of the KEYSTORE_DIR.
+func New(cfg *configs.Config) Keystore {
+	ks := keystore{
+		dir:     cfg.Keystore.Dir,
+		scryptN: ethKeystore.StandardScryptN,
+		scryptP: ethKeystore.StandardScryptP,
+	}
+	if cfg.Keystore.LightScrypt {
+		ks.scryptN = ethKeystore.LightScryptN
+		ks.scryptP = ethKeystore.LightScryptP
+	}
+
+	return &ks
+}
+
+func (ks *keystore) Import(privateKeyHex, passphrase string) (*Account, error) {
+	if passphrase == "" {
+		return nil, ErrPassphraseEmpty
+	}
+
+	pk, err := crypto.HexToECDSA(utils.RemoveZeroX(strings.TrimSpace(privateKeyHex)))
+	if err != nil {
+		return nil, errors.Join(ErrPrivateKeyInvalid, err)
+	}
+
+	var intMaxPK *intMaxAcc.PrivateKey
+	intMaxPK, err = intMaxAcc.NewINTMAXAccountFromECDSAKey(pk)
+	if err != nil {
+		return nil, errors.Join(ErrPrivateKeyInvalid, err)
+	}
+
+	address := crypto.PubkeyToAddress(pk.PublicKey)
+	if _, err = ks.Find(address.Hex()); err == nil {
+		return nil, ErrAccountExists
+	} else if !errors.Is(err, ErrAccountNotFound) {
+		return nil, err
+	}
+
+	var keyJSON []byte
+	keyJSON, err = ethKeystore.EncryptKey(&ethKeystore.Key{
+		Id:         uuid.New(),
+		Address:    address,
+		PrivateKey: pk,
+	}, passphrase, ks.scryptN, ks.scryptP)
+	if err != nil {
+		return nil, errors.Join(ErrEncryptKeyFail, err)
+	}
+
+	var kf keyFile
+	err = json.Unmarshal(keyJSON, &kf)
+	if err != nil {
+		return nil, errors.Join(ErrEncryptKeyFail, err)
+	}
+
+	var intMaxCrypto ethKeystore.CryptoJSON
+	intMaxCrypto, err = ethKeystore.EncryptDataV3(intMaxPK.Marshal(), []byte(passphrase), ks.scryptN, ks.scryptP)
+	if err != nil {
+		return nil, errors.Join(ErrEncryptKeyFail, err)
+	}
+	kf.IntMaxAddress = intMaxPK.ToAddress().String()
+	kf.IntMaxCrypto = &intMaxCrypto
+
+	keyJSON, err = json.Marshal(&kf)
+	if err != nil {
+		return nil, errors.Join(ErrEncryptKeyFail, err)
+	}
+
+	err = os.MkdirAll(ks.dir, dirPerm)
+	if err != nil {
+		return nil, errors.Join(ErrCreateDirFail, err)
+	}
+
+	path := filepath.Join(ks.dir, fmt.Sprintf(
+		fileNameTemplate,
+		time.Now().UTC().Format(fileTimeFormat),
+		strings.ToLower(utils.RemoveZeroX(address.Hex())),
+	))
+	err = os.WriteFile(path, keyJSON, filePerm)
+	if err != nil {
+		return nil, errors.Join(ErrWriteKeyFileFail, err)
+	}
+
+	return &Account{
+		EthereumAddress: address,
+		IntMaxAddress:   kf.IntMaxAddress,
+		Path:            path,
+	}, nil
+}
+
+func (ks *keystore) List() ([]*Account, error) {
+	entries, err := os.ReadDir(ks.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, errors.Join(ErrReadDirFail, err)
+	}
+
+	list := make([]*Account, 0, len(entries))
+	for i := range entries {
+		if entries[i].IsDir() || strings.HasPrefix(entries[i].Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(ks.dir, entries[i].Name())
+		kf, rErr := readKeyFile(path)
+		if rErr != nil || !common.IsHexAddress(kf.Address) {
+			// skip the files that are not the key files
+			continue
+		}
+
+		list = append(list, &Account{
+			EthereumAddress: common.HexToAddress(kf.Address),
+			IntMaxAddress:   kf.IntMaxAddress,
+			Path:            path,
+		})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Path < list[j].Path
+	})
+
+	return list, nil
+}
+
+func (ks *keystore) Find(address string) (*Account, error) {
+	address = strings.TrimSpace(address)
+
+	list, err := ks.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range list {
+		if strings.EqualFold(list[i].EthereumAddress.Hex(), address) ||
+			(list[i].IntMaxAddress != "" && strings.EqualFold(list[i].IntMaxAddress, address)) {
+			return list[i], nil
+		}
+	}
+
+	return nil, ErrAccountNotFound
+}
+
+func (ks *keystore) Unlock(account *Account, passphrase string) (*Key, error) {
+	keyJSON, err := os.ReadFile(account.Path)
+	if err != nil {
+		return nil, errors.Join(ErrReadKeyFileFail, err)
+	}
+
+	var kf keyFile
+	err = json.Unmarshal(keyJSON, &kf)
+	if err != nil {
+		return nil, errors.Join(ErrReadKeyFileFail, err)
+	}
+
+	var key *ethKeystore.Key
+	key, err = ethKeystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, errors.Join(ErrDecryptKeyFail, err)
+	}
+
+	var intMaxPK *intMaxAcc.PrivateKey
+	if kf.IntMaxCrypto == nil {
+		intMaxPK, err = intMaxAcc.NewINTMAXAccountFromECDSAKey(key.PrivateKey)
+		if err != nil {
+			return nil, errors.Join(ErrPrivateKeyInvalid, err)
+		}
+	} else {
+		var buf []byte
+		buf, err = ethKeystore.DecryptDataV3(*kf.IntMaxCrypto, passphrase)
+		if err != nil {
+			return nil, errors.Join(ErrDecryptKeyFail, err)
+		}
+
+		intMaxPK = new(intMaxAcc.PrivateKey)
+		err = intMaxPK.Unmarshal(buf)
+		if err != nil {
+			return nil, errors.Join(ErrPrivateKeyInvalid, err)
+		}
+
+		if !strings.EqualFold(intMaxPK.ToAddress().String(), kf.IntMaxAddress) {
+			return nil, ErrIntMaxKeyMismatch
+		}
+	}
+
+	return &Key{
+		Account: &Account{
+			EthereumAddress: key.Address,
+			IntMaxAddress:   intMaxPK.ToAddress().String(),
+			Path:            account.Path,
+		},
+		EthereumPrivateKey: key.PrivateKey,
+		IntMaxPrivateKey:   intMaxPK,
+	}, nil
+}
+
+func readKeyFile(path string) (*keyFile, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Join(ErrReadKeyFileFail, err)
+	}
+
+	var kf keyFile
+	err = json.Unmarshal(buf, &kf)
+	if err != nil {
+		return nil, errors.Join(ErrReadKeyFileFail, err)
+	}
+
+	return &kf, nil
+}
+
+// PrivateKeyHex returns the Ethereum private key in hex without 0x.
+func (k *Key) PrivateKeyHex() string {
+	return hex.EncodeToString(crypto.FromECDSA(k.EthereumPrivateKey))
+}
@@ -0,0 +1,117 @@
+package keystore_test
+
+import (
+	"encoding/hex"
+	"intmax2-node/configs"
+	intMaxAcc "intmax2-node/internal/accounts"
+	"intmax2-node/internal/keystore"
+	"os"
+	"testing"
+
+	ethKeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeystore(t *testing.T) {
+	const passphrase = "passphrase"
+
+	cfg := &configs.Config{
+		Keystore: configs.Keystore{
+			Dir:         t.TempDir(),
+			LightScrypt: true,
+		},
+	}
+	ks := keystore.New(cfg)
+
+	list, err := ks.List()
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	privateKeyHex := hex.EncodeToString(crypto.FromECDSA(pk))
+	intMaxPK, err := intMaxAcc.NewINTMAXAccountFromECDSAKey(pk)
+	require.NoError(t, err)
+
+	acc, err := ks.Import("0x"+privateKeyHex, passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(pk.PublicKey), acc.EthereumAddress)
+	assert.Equal(t, intMaxPK.ToAddress().String(), acc.IntMaxAddress)
+
+	info, err := os.Stat(acc.Path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	_, err = ks.Import(privateKeyHex, passphrase)
+	assert.ErrorIs(t, err, keystore.ErrAccountExists)
+
+	list, err = ks.List()
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, acc, list[0])
+
+	t.Run("find by the Ethereum and INTMAX addresses", func(t *testing.T) {
+		found, err := ks.Find(acc.EthereumAddress.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, acc, found)
+
+		found, err = ks.Find(acc.IntMaxAddress)
+		require.NoError(t, err)
+		assert.Equal(t, acc, found)
+
+		_, err = ks.Find("0x0000000000000000000000000000000000000000")
+		assert.ErrorIs(t, err, keystore.ErrAccountNotFound)
+	})
+
+	t.Run("unlock", func(t *testing.T) {
+		key, err := ks.Unlock(acc, passphrase)
+		require.NoError(t, err)
+		assert.Equal(t, privateKeyHex, key.PrivateKeyHex())
+		assert.True(t, intMaxPK.Equal(key.IntMaxPrivateKey))
+
+		_, err = ks.Unlock(acc, "wrong")
+		assert.ErrorIs(t, err, keystore.ErrDecryptKeyFail)
+	})
+
+	t.Run("the file is readable by go-ethereum", func(t *testing.T) {
+		buf, err := os.ReadFile(acc.Path)
+		require.NoError(t, err)
+
+		key, err := ethKeystore.DecryptKey(buf, passphrase)
+		require.NoError(t, err)
+		assert.Equal(t, acc.EthereumAddress, key.Address)
+	})
+
+	t.Run("the go-ethereum key file without the INTMAX key", func(t *testing.T) {
+		ethAcc, err := ethKeystore.StoreKey(cfg.Keystore.Dir, passphrase, ethKeystore.LightScryptN, ethKeystore.LightScryptP)
+		require.NoError(t, err)
+
+		found, err := ks.Find(ethAcc.Address.Hex())
+		require.NoError(t, err)
+		assert.Empty(t, found.IntMaxAddress)
+
+		key, err := ks.Unlock(found, passphrase)
+		require.NoError(t, err)
+
+		derived, err := intMaxAcc.NewINTMAXAccountFromECDSAKey(key.EthereumPrivateKey)
+		require.NoError(t, err)
+		assert.Equal(t, derived.ToAddress().String(), key.Account.IntMaxAddress)
+	})
+
+	t.Run("the account and the private key", func(t *testing.T) {
+		cfg.Keystore.Password = passphrase
+
+		got, err := keystore.PrivateKeyHex(cfg, acc.IntMaxAddress, "")
+		require.NoError(t, err)
+		assert.Equal(t, privateKeyHex, got)
+
+		got, err = keystore.PrivateKeyHex(cfg, "", "0x"+privateKeyHex)
+		require.NoError(t, err)
+		assert.Equal(t, privateKeyHex, got)
+
+		_, err = keystore.PrivateKeyHex(cfg, acc.IntMaxAddress, privateKeyHex)
+		assert.ErrorIs(t, err, keystore.ErrAccountAndPrivateKey)
+	})
+}
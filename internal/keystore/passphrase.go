@@ -0,0 +1,101 @@
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"intmax2-node/configs"
+	"intmax2-node/pkg/utils"
+	"strings"
+	"syscall"
+
+	"golang.org/x/term"
+)
+
+const (
+	// AccountFlagKey is the flag of the CLI commands to use the key of the keystore account.
+	AccountFlagKey = "account"
+	// AccountFlagDescription is the description of the AccountFlagKey flag.
+	AccountFlagDescription = "specify the Ethereum or INTMAX address of the keystore account (instead of the private key)." +
+		" The passphrase is read from KEYSTORE_PASSWORD or the terminal"
+)
+
+// Passphrase returns the KEYSTORE_PASSWORD or reads the passphrase from the terminal.
+// The passphrase read from the terminal is asked twice, if the confirm is true.
+func Passphrase(cfg *configs.Config, confirm bool) (string, error) {
+	if cfg.Keystore.Password != "" {
+		return cfg.Keystore.Password, nil
+	}
+
+	passphrase, err := readPassword("Enter passphrase:")
+	if err != nil {
+		return "", err
+	}
+
+	if passphrase == "" {
+		return "", ErrPassphraseEmpty
+	}
+
+	if confirm {
+		var repeated string
+		repeated, err = readPassword("Repeat passphrase:")
+		if err != nil {
+			return "", err
+		}
+
+		if repeated != passphrase {
+			return "", ErrPassphraseMismatch
+		}
+	}
+
+	return passphrase, nil
+}
+
+func readPassword(prompt string) (string, error) {
+	fmt.Print(prompt)
+	buf, err := term.ReadPassword(syscall.Stdin)
+	fmt.Println()
+	if err != nil {
+		return "", errors.Join(ErrReadPassphraseFail, err)
+	}
+
+	return string(buf), nil
+}
+
+// PrivateKeyHex returns the Ethereum private key in hex without 0x of the CLI commands.
+// The key of the keystore account is unlocked, if the account is specified,
+// otherwise the private key is returned as is.
+func PrivateKeyHex(cfg *configs.Config, account, privateKeyHex string) (string, error) {
+	account = strings.TrimSpace(account)
+	if account == "" {
+		return utils.RemoveZeroX(privateKeyHex), nil
+	}
+
+	if privateKeyHex != "" {
+		return "", ErrAccountAndPrivateKey
+	}
+
+	key, err := Open(cfg, account)
+	if err != nil {
+		return "", err
+	}
+
+	return key.PrivateKeyHex(), nil
+}
+
+// Open unlocks the key of the keystore account with the passphrase of Passphrase.
+func Open(cfg *configs.Config, account string) (*Key, error) {
+	ks := New(cfg)
+
+	acc, err := ks.Find(account)
+	if err != nil {
+		return nil, err
+	}
+
+	var passphrase string
+	passphrase, err = Passphrase(cfg, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return ks.Unlock(acc, passphrase)
+}
@@ -0,0 +1,16 @@
+package log_scanner
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//go:generate mockgen -destination=mock_chain_reader_test.go -package=log_scanner_test -source=chain_reader.go
+
+// ChainReader reads the blocks of the chain. It is implemented by the ethclient.Client.
+type ChainReader interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
@@ -0,0 +1,26 @@
+package log_scanner
+
+import (
+	"context"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+)
+
+//go:generate mockgen -destination=mock_db_app_test.go -package=log_scanner_test -source=db_app.go
+
+type SQLDriverApp interface {
+	GenericCommandsApp
+	EventBlockNumbers
+}
+
+type GenericCommandsApp interface {
+	Exec(ctx context.Context, input interface{}, executor func(d interface{}, input interface{}) error) (err error)
+}
+
+type EventBlockNumbers interface {
+	UpsertEventBlockNumberAndHash(eventName string, blockNumber uint64, blockHash string) (*mDBApp.EventBlockNumber, error)
+	EventBlockNumberByEventName(eventName string) (*mDBApp.EventBlockNumber, error)
+	UpsertEventBlockHash(eventName string, blockNumber uint64, blockHash string) error
+	EventBlockHashesByEventName(eventName string) ([]*mDBApp.EventBlockHash, error)
+	DelEventBlockHashesAfterBlockNumber(eventName string, blockNumber uint64) error
+	DelEventBlockHashesBeforeBlockNumber(eventName string, blockNumber uint64) error
+}
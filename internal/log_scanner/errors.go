@@ -0,0 +1,33 @@
+package log_scanner
+
+import "errors"
+
+// ErrBlockNumberFail error: failed to get the latest block number.
+var ErrBlockNumberFail = errors.New("failed to get the latest block number")
+
+// ErrHeaderByNumberFail error: failed to get the block header.
+var ErrHeaderByNumberFail = errors.New("failed to get the block header")
+
+// ErrEventBlockNumberByEventNameFail error: failed to get the last processed block number of the event.
+var ErrEventBlockNumberByEventNameFail = errors.New("failed to get the last processed block number of the event")
+
+// ErrUpsertEventBlockNumberAndHashFail error: failed to update the last processed block of the event.
+var ErrUpsertEventBlockNumberAndHashFail = errors.New("failed to update the last processed block of the event")
+
+// ErrUpsertEventBlockHashFail error: failed to store the hash of the last block of the scanned range.
+var ErrUpsertEventBlockHashFail = errors.New("failed to store the hash of the last block of the scanned range")
+
+// ErrEventBlockHashesByEventNameFail error: failed to get the stored block hashes of the event.
+var ErrEventBlockHashesByEventNameFail = errors.New("failed to get the stored block hashes of the event")
+
+// ErrDelEventBlockHashesFail error: failed to delete the stored block hashes of the event.
+var ErrDelEventBlockHashesFail = errors.New("failed to delete the stored block hashes of the event")
+
+// ErrProcessLogsFail error: failed to process the logs.
+var ErrProcessLogsFail = errors.New("failed to process the logs")
+
+// ErrRollbackFail error: failed to roll back the rows derived from the reorganized blocks.
+var ErrRollbackFail = errors.New("failed to roll back the rows derived from the reorganized blocks")
+
+// ErrReloadFail error: failed to reload the state after the rollback.
+var ErrReloadFail = errors.New("failed to reload the state after the rollback")
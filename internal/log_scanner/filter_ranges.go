@@ -0,0 +1,49 @@
+package log_scanner
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// FilterByRanges calls the filter for the ranges of the maxBlockRange blocks (LOG_SCANNER_MAX_BLOCK_RANGE)
+// from the start block up to the latest block, so that the logs are never requested up to the unknown
+// latest block. It is used where the last processed block is not stored.
+// The scanning is stopped as soon as the filter returns done or the error.
+func FilterByRanges(
+	ctx context.Context,
+	chain ChainReader,
+	start, maxBlockRange uint64,
+	filter func(opts *bind.FilterOpts) (done bool, err error),
+) error {
+	latestBN, err := chain.BlockNumber(ctx)
+	if err != nil {
+		return errors.Join(ErrBlockNumberFail, err)
+	}
+
+	for start <= latestBN {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		end := start + maxBlockRange - 1
+		if maxBlockRange == 0 || end > latestBN || end < start {
+			end = latestBN
+		}
+
+		var done bool
+		done, err = filter(&bind.FilterOpts{
+			Start:   start,
+			End:     &end,
+			Context: ctx,
+		})
+		if err != nil || done {
+			return err
+		}
+
+		start = end + 1
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package log_scanner_test
+
+import (
+	"context"
+	"errors"
+	"intmax2-node/internal/log_scanner"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFilterByRanges(t *testing.T) {
+	const (
+		start         = 101
+		latestBN      = 350
+		maxBlockRange = 100
+	)
+
+	type scanRange struct {
+		Start, End uint64
+	}
+
+	errFilter := errors.New("filter error")
+
+	cases := []struct {
+		desc     string
+		doneAt   uint64
+		errAt    uint64
+		ranges   []scanRange
+		expected error
+	}{
+		{
+			desc:   "filter by the ranges up to the latest block",
+			ranges: []scanRange{{101, 200}, {201, 300}, {301, 350}},
+		},
+		{
+			desc:   "stop when the filter is done",
+			doneAt: 201,
+			ranges: []scanRange{{101, 200}, {201, 300}},
+		},
+		{
+			desc:     "stop on the error of the filter",
+			errAt:    101,
+			ranges:   []scanRange{{101, 200}},
+			expected: errFilter,
+		},
+	}
+
+	for i := range cases {
+		t.Run(cases[i].desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			chain := NewMockChainReader(ctrl)
+			chain.EXPECT().BlockNumber(gomock.Any()).Return(uint64(latestBN), nil)
+
+			var ranges []scanRange
+			err := log_scanner.FilterByRanges(context.Background(), chain, start, maxBlockRange,
+				func(opts *bind.FilterOpts) (bool, error) {
+					ranges = append(ranges, scanRange{opts.Start, *opts.End})
+					if opts.Start == cases[i].errAt {
+						return false, errFilter
+					}
+					return opts.Start == cases[i].doneAt, nil
+				},
+			)
+			assert.ErrorIs(t, err, cases[i].expected)
+			assert.Equal(t, cases[i].ranges, ranges)
+		})
+	}
+}
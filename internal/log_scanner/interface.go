@@ -0,0 +1,35 @@
+package log_scanner
+
+import (
+	"context"
+)
+
+//go:generate mockgen -destination=../mocks/mock_log_scanner.go -package=mocks -source=interface.go
+
+// Handler describes the processing of the logs of one event by the LogScanner.
+// The rows derived from the logs are stored in the same database transaction as the number
+// and the hash of the last processed block of the event, which is kept in event_block_numbers.
+// The hashes of the last blocks of the scanned ranges are kept in event_block_hashes to find the fork point.
+type Handler struct {
+	// EventName is the name of the event in event_block_numbers.
+	EventName string
+	// DeployedBlockNumber is the number of the block in which the contract was deployed.
+	// The scanning starts from the next block.
+	DeployedBlockNumber uint64
+	// Process fetches the logs of the blocks from start to end inclusive and stores the rows derived
+	// from the logs with the database driver q of the transaction.
+	Process func(ctx context.Context, q interface{}, start, end uint64) error
+	// Rollback deletes the rows derived from the logs of the blocks after the block number
+	// with the database driver q of the transaction. It returns the number of the block after which
+	// the scanning is resumed, which is not greater than the given block number.
+	Rollback func(ctx context.Context, q interface{}, blockNumber uint64) (uint64, error)
+	// Reload rebuilds the in-memory state derived from the stored rows after the database transaction
+	// of the rollback is committed. It is optional.
+	Reload func(ctx context.Context) error
+}
+
+// LogScanner scans the logs of the chain in bounded block ranges up to the confirmed block
+// and rolls back the rows derived from the logs of the reorganized blocks.
+type LogScanner interface {
+	Scan(ctx context.Context, h *Handler) error
+}
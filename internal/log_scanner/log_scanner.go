@@ -0,0 +1,248 @@
+package log_scanner
+
+import (
+	"context"
+	"errors"
+	"intmax2-node/configs"
+	"intmax2-node/internal/logger"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	errorsDB "intmax2-node/pkg/sql_db/errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type logScanner struct {
+	cfg           *configs.Config
+	log           logger.Logger
+	dbApp         SQLDriverApp
+	chain         ChainReader
+	confirmations uint64
+}
+
+// New returns the LogScanner of the chain. The logs of the block are processed
+// once the block has the given number of the blocks on top of it.
+func New(
+	cfg *configs.Config,
+	log logger.Logger,
+	dbApp SQLDriverApp,
+	chain ChainReader,
+	confirmations uint64,
+) LogScanner {
+	return &logScanner{
+		cfg:           cfg,
+		log:           log,
+		dbApp:         dbApp,
+		chain:         chain,
+		confirmations: confirmations,
+	}
+}
+
+// Scan processes the logs of the event from the last processed block up to the confirmed block
+// by the ranges of the LOG_SCANNER_MAX_BLOCK_RANGE blocks, one database transaction per range.
+// When the hash of the last processed block does not match the chain, the rows derived from
+// the blocks after the fork point are rolled back and the blocks are scanned again.
+func (s *logScanner) Scan(ctx context.Context, h *Handler) error {
+	latestBN, err := s.chain.BlockNumber(ctx)
+	if err != nil {
+		return errors.Join(ErrBlockNumberFail, err)
+	}
+
+	if latestBN < s.confirmations {
+		return nil
+	}
+	confirmedBN := latestBN - s.confirmations
+
+	var ebn *mDBApp.EventBlockNumber
+	ebn, err = s.dbApp.EventBlockNumberByEventName(h.EventName)
+	if err != nil && !errors.Is(err, errorsDB.ErrNotFound) {
+		return errors.Join(ErrEventBlockNumberByEventNameFail, err)
+	}
+
+	lastBN := h.DeployedBlockNumber
+	if ebn != nil && ebn.LastProcessedBlockNumber > lastBN {
+		lastBN = ebn.LastProcessedBlockNumber
+
+		if ebn.LastProcessedBlockHash != "" {
+			var header *types.Header
+			header, err = s.headerByNumber(ctx, lastBN)
+			if err != nil {
+				return err
+			}
+
+			if header.Hash().Hex() != ebn.LastProcessedBlockHash {
+				s.log.Warnf(
+					"Reorg of the block %d is detected for the event %s: expected hash %s, got %s",
+					lastBN, h.EventName, ebn.LastProcessedBlockHash, header.Hash().Hex(),
+				)
+
+				lastBN, err = s.rollback(ctx, h, lastBN)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for lastBN < confirmedBN {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		start, end := lastBN+1, lastBN+s.cfg.LogScanner.MaxBlockRange
+		if end > confirmedBN || end < start {
+			end = confirmedBN
+		}
+
+		var header *types.Header
+		header, err = s.headerByNumber(ctx, end)
+		if err != nil {
+			return err
+		}
+
+		err = s.dbApp.Exec(ctx, nil, func(d interface{}, _ interface{}) (err error) {
+			q := d.(SQLDriverApp)
+
+			err = h.Process(ctx, d, start, end)
+			if err != nil {
+				return errors.Join(ErrProcessLogsFail, err)
+			}
+
+			_, err = q.UpsertEventBlockNumberAndHash(h.EventName, end, header.Hash().Hex())
+			if err != nil {
+				return errors.Join(ErrUpsertEventBlockNumberAndHashFail, err)
+			}
+
+			err = q.UpsertEventBlockHash(h.EventName, end, header.Hash().Hex())
+			if err != nil {
+				return errors.Join(ErrUpsertEventBlockHashFail, err)
+			}
+
+			if end > s.cfg.LogScanner.MaxReorgDepth {
+				err = q.DelEventBlockHashesBeforeBlockNumber(h.EventName, end-s.cfg.LogScanner.MaxReorgDepth)
+				if err != nil {
+					return errors.Join(ErrDelEventBlockHashesFail, err)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		s.log.Debugf("Scanned the blocks from %d to %d for the event %s", start, end, h.EventName)
+
+		lastBN = end
+	}
+
+	return nil
+}
+
+// rollback rolls back the rows derived from the logs of the blocks after the fork point
+// and returns the number of the block after which the scanning is resumed.
+// The handler reloads its state once the database transaction is committed.
+func (s *logScanner) rollback(ctx context.Context, h *Handler, lastBN uint64) (resumeBN uint64, err error) {
+	var (
+		rollbackBN   uint64
+		rollbackHash string
+	)
+	rollbackBN, rollbackHash, err = s.forkPoint(ctx, h, lastBN)
+	if err != nil {
+		return 0, err
+	}
+
+	err = s.dbApp.Exec(ctx, nil, func(d interface{}, _ interface{}) (err error) {
+		q := d.(SQLDriverApp)
+
+		resumeBN, err = h.Rollback(ctx, d, rollbackBN)
+		if err != nil {
+			return errors.Join(ErrRollbackFail, err)
+		}
+		if resumeBN > rollbackBN {
+			resumeBN = rollbackBN
+		}
+
+		// The hash is known only for the fork point.
+		blockHash := ""
+		if resumeBN == rollbackBN {
+			blockHash = rollbackHash
+		}
+
+		_, err = q.UpsertEventBlockNumberAndHash(h.EventName, resumeBN, blockHash)
+		if err != nil {
+			return errors.Join(ErrUpsertEventBlockNumberAndHashFail, err)
+		}
+
+		err = q.DelEventBlockHashesAfterBlockNumber(h.EventName, resumeBN)
+		if err != nil {
+			return errors.Join(ErrDelEventBlockHashesFail, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	s.log.Warnf("Rolled back the event %s to the block %d", h.EventName, resumeBN)
+
+	if h.Reload != nil {
+		err = h.Reload(ctx)
+		if err != nil {
+			return 0, errors.Join(ErrReloadFail, err)
+		}
+	}
+
+	return resumeBN, nil
+}
+
+// forkPoint walks back through the stored hashes of the scanned ranges of the event and returns
+// the latest block before the last processed block whose hash matches the chain.
+// Without the stored hashes, the block LOG_SCANNER_MAX_REORG_DEPTH blocks before the last processed block
+// is returned with the empty hash. When none of the stored hashes matches, the reorg is deeper than
+// the stored hashes, so the deployed block is returned.
+func (s *logScanner) forkPoint(ctx context.Context, h *Handler, lastBN uint64) (uint64, string, error) {
+	hashes, err := s.dbApp.EventBlockHashesByEventName(h.EventName)
+	if err != nil {
+		return 0, "", errors.Join(ErrEventBlockHashesByEventNameFail, err)
+	}
+
+	var checked bool
+	for key := range hashes {
+		if hashes[key].BlockNumber >= lastBN || hashes[key].BlockNumber <= h.DeployedBlockNumber {
+			continue
+		}
+
+		var header *types.Header
+		header, err = s.headerByNumber(ctx, hashes[key].BlockNumber)
+		if err != nil {
+			return 0, "", err
+		}
+		if header.Hash().Hex() == hashes[key].BlockHash {
+			return hashes[key].BlockNumber, hashes[key].BlockHash, nil
+		}
+
+		checked = true
+	}
+
+	if checked {
+		s.log.Warnf("No stored block hash of the event %s matches the chain", h.EventName)
+		return h.DeployedBlockNumber, "", nil
+	}
+
+	if lastBN > h.DeployedBlockNumber+s.cfg.LogScanner.MaxReorgDepth {
+		return lastBN - s.cfg.LogScanner.MaxReorgDepth, "", nil
+	}
+
+	return h.DeployedBlockNumber, "", nil
+}
+
+func (s *logScanner) headerByNumber(ctx context.Context, blockNumber uint64) (*types.Header, error) {
+	header, err := s.chain.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return nil, errors.Join(ErrHeaderByNumberFail, err)
+	}
+
+	return header, nil
+}
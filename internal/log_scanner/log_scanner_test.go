@@ -0,0 +1,268 @@
+package log_scanner_test
+
+import (
+	"context"
+	"intmax2-node/configs"
+	"intmax2-node/internal/log_scanner"
+	"intmax2-node/pkg/logger"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	errorsDB "intmax2-node/pkg/sql_db/errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestScan(t *testing.T) {
+	const (
+		eventName      = "TestEvent"
+		deployedBN     = 100
+		maxBlockRange  = 100
+		maxReorgDepth  = 64
+		confirmations  = 10
+		reorgedExtra   = "reorged"
+		canonicalExtra = ""
+	)
+
+	type scanRange struct {
+		Start, End uint64
+	}
+
+	type upsert struct {
+		BlockNumber uint64
+		BlockHash   string
+	}
+
+	header := func(blockNumber uint64, extra string) *types.Header {
+		return &types.Header{Number: new(big.Int).SetUint64(blockNumber), Extra: []byte(extra)}
+	}
+
+	cases := []struct {
+		desc       string
+		latestBN   uint64
+		ebn        *mDBApp.EventBlockNumber
+		hashes     []*mDBApp.EventBlockHash
+		resumeBN   func(blockNumber uint64) uint64
+		ranges     []scanRange
+		rollbackBN []uint64
+		upserts    []upsert
+		reloads    int
+	}{
+		{
+			desc:     "scan from the deployed block by the ranges up to the confirmed block",
+			latestBN: 350,
+			ranges:   []scanRange{{101, 200}, {201, 300}, {301, 340}},
+			upserts: []upsert{
+				{200, header(200, canonicalExtra).Hash().Hex()},
+				{300, header(300, canonicalExtra).Hash().Hex()},
+				{340, header(340, canonicalExtra).Hash().Hex()},
+			},
+		},
+		{
+			desc:     "the blocks are not confirmed yet",
+			latestBN: 345,
+			ebn: &mDBApp.EventBlockNumber{
+				EventName:                eventName,
+				LastProcessedBlockNumber: 340,
+				LastProcessedBlockHash:   header(340, canonicalExtra).Hash().Hex(),
+			},
+		},
+		{
+			desc:     "scan from the last processed block",
+			latestBN: 360,
+			ebn: &mDBApp.EventBlockNumber{
+				EventName:                eventName,
+				LastProcessedBlockNumber: 340,
+			},
+			ranges:  []scanRange{{341, 350}},
+			upserts: []upsert{{350, header(350, canonicalExtra).Hash().Hex()}},
+		},
+		{
+			desc:     "roll back the reorganized blocks",
+			latestBN: 510,
+			ebn: &mDBApp.EventBlockNumber{
+				EventName:                eventName,
+				LastProcessedBlockNumber: 500,
+				LastProcessedBlockHash:   header(500, reorgedExtra).Hash().Hex(),
+			},
+			rollbackBN: []uint64{436},
+			ranges:     []scanRange{{437, 500}},
+			upserts: []upsert{
+				{436, ""},
+				{500, header(500, canonicalExtra).Hash().Hex()},
+			},
+			reloads: 1,
+		},
+		{
+			desc:     "roll back to the fork point found by the stored block hashes",
+			latestBN: 510,
+			ebn: &mDBApp.EventBlockNumber{
+				EventName:                eventName,
+				LastProcessedBlockNumber: 500,
+				LastProcessedBlockHash:   header(500, reorgedExtra).Hash().Hex(),
+			},
+			hashes: []*mDBApp.EventBlockHash{
+				{EventName: eventName, BlockNumber: 500, BlockHash: header(500, reorgedExtra).Hash().Hex()},
+				{EventName: eventName, BlockNumber: 400, BlockHash: header(400, reorgedExtra).Hash().Hex()},
+				{EventName: eventName, BlockNumber: 300, BlockHash: header(300, canonicalExtra).Hash().Hex()},
+				{EventName: eventName, BlockNumber: 200, BlockHash: header(200, canonicalExtra).Hash().Hex()},
+			},
+			rollbackBN: []uint64{300},
+			ranges:     []scanRange{{301, 400}, {401, 500}},
+			upserts: []upsert{
+				{300, header(300, canonicalExtra).Hash().Hex()},
+				{400, header(400, canonicalExtra).Hash().Hex()},
+				{500, header(500, canonicalExtra).Hash().Hex()},
+			},
+			reloads: 1,
+		},
+		{
+			desc:     "roll back to the deployed block when no stored block hash matches",
+			latestBN: 510,
+			ebn: &mDBApp.EventBlockNumber{
+				EventName:                eventName,
+				LastProcessedBlockNumber: 500,
+				LastProcessedBlockHash:   header(500, reorgedExtra).Hash().Hex(),
+			},
+			hashes: []*mDBApp.EventBlockHash{
+				{EventName: eventName, BlockNumber: 400, BlockHash: header(400, reorgedExtra).Hash().Hex()},
+			},
+			rollbackBN: []uint64{deployedBN},
+			ranges:     []scanRange{{101, 200}, {201, 300}, {301, 400}, {401, 500}},
+			upserts: []upsert{
+				{deployedBN, ""},
+				{200, header(200, canonicalExtra).Hash().Hex()},
+				{300, header(300, canonicalExtra).Hash().Hex()},
+				{400, header(400, canonicalExtra).Hash().Hex()},
+				{500, header(500, canonicalExtra).Hash().Hex()},
+			},
+			reloads: 1,
+		},
+		{
+			desc:     "roll back to the block chosen by the handler",
+			latestBN: 210,
+			ebn: &mDBApp.EventBlockNumber{
+				EventName:                eventName,
+				LastProcessedBlockNumber: 200,
+				LastProcessedBlockHash:   header(200, reorgedExtra).Hash().Hex(),
+			},
+			resumeBN: func(uint64) uint64 {
+				return deployedBN
+			},
+			rollbackBN: []uint64{136},
+			ranges:     []scanRange{{101, 200}},
+			upserts: []upsert{
+				{deployedBN, ""},
+				{200, header(200, canonicalExtra).Hash().Hex()},
+			},
+			reloads: 1,
+		},
+	}
+
+	cfg := &configs.Config{
+		LogScanner: configs.LogScanner{
+			MaxBlockRange: maxBlockRange,
+			MaxReorgDepth: maxReorgDepth,
+		},
+	}
+	log := logger.New("error", time.RFC3339, false, false)
+
+	for i := range cases {
+		t.Run(cases[i].desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dbApp := NewMockSQLDriverApp(ctrl)
+			chain := NewMockChainReader(ctrl)
+
+			chain.EXPECT().BlockNumber(gomock.Any()).Return(cases[i].latestBN, nil)
+			chain.EXPECT().HeaderByNumber(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, number *big.Int) (*types.Header, error) {
+					return header(number.Uint64(), canonicalExtra), nil
+				},
+			).AnyTimes()
+
+			if cases[i].ebn == nil {
+				dbApp.EXPECT().EventBlockNumberByEventName(eventName).Return(nil, errorsDB.ErrNotFound)
+			} else {
+				dbApp.EXPECT().EventBlockNumberByEventName(eventName).Return(cases[i].ebn, nil)
+			}
+
+			dbApp.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, input interface{}, executor func(d interface{}, input interface{}) error) error {
+					return executor(dbApp, input)
+				},
+			).AnyTimes()
+
+			var upserts []upsert
+			dbApp.EXPECT().UpsertEventBlockNumberAndHash(eventName, gomock.Any(), gomock.Any()).DoAndReturn(
+				func(name string, blockNumber uint64, blockHash string) (*mDBApp.EventBlockNumber, error) {
+					upserts = append(upserts, upsert{blockNumber, blockHash})
+					return &mDBApp.EventBlockNumber{
+						EventName:                name,
+						LastProcessedBlockNumber: blockNumber,
+						LastProcessedBlockHash:   blockHash,
+					}, nil
+				},
+			).AnyTimes()
+
+			dbApp.EXPECT().EventBlockHashesByEventName(eventName).Return(cases[i].hashes, nil).AnyTimes()
+			dbApp.EXPECT().UpsertEventBlockHash(eventName, gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ string, blockNumber uint64, blockHash string) error {
+					assert.Equal(t, header(blockNumber, canonicalExtra).Hash().Hex(), blockHash)
+					return nil
+				},
+			).AnyTimes()
+			dbApp.EXPECT().DelEventBlockHashesBeforeBlockNumber(eventName, gomock.Any()).DoAndReturn(
+				func(_ string, blockNumber uint64) error {
+					assert.Greater(t, blockNumber, uint64(0))
+					return nil
+				},
+			).AnyTimes()
+			dbApp.EXPECT().DelEventBlockHashesAfterBlockNumber(eventName, gomock.Any()).DoAndReturn(
+				func(_ string, blockNumber uint64) error {
+					assert.Equal(t, upserts[len(upserts)-1].BlockNumber, blockNumber)
+					return nil
+				},
+			).Times(len(cases[i].rollbackBN))
+
+			var (
+				ranges     []scanRange
+				rollbackBN []uint64
+				reloads    int
+			)
+			scanner := log_scanner.New(cfg, log, dbApp, chain, confirmations)
+			err := scanner.Scan(context.Background(), &log_scanner.Handler{
+				EventName:           eventName,
+				DeployedBlockNumber: deployedBN,
+				Process: func(_ context.Context, d interface{}, start, end uint64) error {
+					assert.Equal(t, dbApp, d)
+					ranges = append(ranges, scanRange{start, end})
+					return nil
+				},
+				Rollback: func(_ context.Context, _ interface{}, blockNumber uint64) (uint64, error) {
+					rollbackBN = append(rollbackBN, blockNumber)
+					if cases[i].resumeBN != nil {
+						return cases[i].resumeBN(blockNumber), nil
+					}
+					return blockNumber, nil
+				},
+				Reload: func(_ context.Context) error {
+					assert.Len(t, rollbackBN, reloads+1)
+					reloads++
+					return nil
+				},
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, cases[i].ranges, ranges)
+			assert.Equal(t, cases[i].rollbackBN, rollbackBN)
+			assert.Equal(t, cases[i].upserts, upserts)
+			assert.Equal(t, cases[i].reloads, reloads)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package gateway
+
+import (
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+const mimeEventStream = "text/event-stream"
+
+// eventStreamMarshaler sends the messages of the server streams as the Server-Sent Events.
+// It is chosen by the header `Accept: text/event-stream`.
+type eventStreamMarshaler struct {
+	runtime.Marshaler
+}
+
+func (m *eventStreamMarshaler) ContentType(_ interface{}) string {
+	return mimeEventStream
+}
+
+func (m *eventStreamMarshaler) Marshal(v interface{}) ([]byte, error) {
+	const dataKey = "data: "
+
+	b, err := m.Marshaler.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(dataKey), b...), nil
+}
+
+func (m *eventStreamMarshaler) Delimiter() []byte {
+	const delimiter = "\n\n"
+
+	return []byte(delimiter)
+}
@@ -138,19 +138,24 @@ func Run(ctx context.Context, config *Params) (Gateway, chan error) { // nolint:
 	httpRespM := http_response_modifier.NewProcessing(gw.config.Cookies)
 	httpErrM := http_response_modifier.NewHTTPErrorHandler(httpRespM, gw.config.Cookies)
 
+	jsonPb := &runtime.JSONPb{
+		MarshalOptions: protojson.MarshalOptions{
+			EmitUnpopulated: true,
+		},
+		UnmarshalOptions: protojson.UnmarshalOptions{
+			DiscardUnknown: true,
+		},
+	}
+
 	gwMux := runtime.NewServeMux(
 		runtime.WithIncomingHeaderMatcher(http_request_modifier.Middleware),
 		runtime.WithForwardResponseOption(httpRespM.Middleware),
 		runtime.WithErrorHandler(httpErrM.HTTPErrorHandler),
 		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.HTTPBodyMarshaler{
-			Marshaler: &runtime.JSONPb{
-				MarshalOptions: protojson.MarshalOptions{
-					EmitUnpopulated: true,
-				},
-				UnmarshalOptions: protojson.UnmarshalOptions{
-					DiscardUnknown: true,
-				},
-			},
+			Marshaler: jsonPb,
+		}),
+		runtime.WithMarshalerOption(mimeEventStream, &eventStreamMarshaler{
+			Marshaler: jsonPb,
 		}),
 	)
 
@@ -7,6 +7,13 @@ const (
 
 const OutHTTPCode = "out-http-code"
 
+const OutRetryAfter = "out-retry-after"
+
+const OutPoWDifficulty = "out-pow-difficulty"
+
+// PoWDifficultyHeader is the http-header with the PoW difficulty required from the sender of the request.
+const PoWDifficultyHeader = "X-Pow-Difficulty"
+
 const OutDelCookieWalletAddress = "out-del-cookie-wallet-address"
 
 const OutDelCookieAccessToken = "out-del-cookie-access-token"
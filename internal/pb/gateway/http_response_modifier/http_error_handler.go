@@ -93,6 +93,10 @@ func (h *httpErrorHandler) HTTPErrorHandler(
 
 	if s.Code() == codes.InvalidArgument ||
 		s.Code() == codes.Unknown {
+		// the difficulty is returned with the rejected transaction to retry it with the required PoW
+		if v := md.HeaderMD.Get(OutPoWDifficulty); len(v) > 0 {
+			w.Header().Set(PoWDifficultyHeader, v[0])
+		}
 		st := runtime.HTTPStatusFromCode(s.Code())
 		w.WriteHeader(st)
 	} else {
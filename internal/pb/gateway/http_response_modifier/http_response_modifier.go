@@ -110,6 +110,12 @@ func (m *httpResponseModifier) Apply() error {
 	if err := m.outDelCookieWalletAddress(); err != nil {
 		return err
 	}
+	if err := m.retryAfter(); err != nil {
+		return err
+	}
+	if err := m.powDifficulty(); err != nil {
+		return err
+	}
 	if err := m.httpCode(); err != nil {
 		return err
 	}
@@ -143,6 +149,31 @@ func (m *httpResponseModifier) outDelCookieWalletAddress() (err error) {
 	return nil
 }
 
+func (m *httpResponseModifier) retryAfter() (err error) {
+	defer m.autoRemove(OutRetryAfter)
+	h := m.md.HeaderMD.Get(OutRetryAfter)
+	if len(h) == 0 {
+		return nil
+	}
+
+	const retryAfterKey = "Retry-After"
+	m.w.Header().Set(retryAfterKey, h[0])
+
+	return nil
+}
+
+func (m *httpResponseModifier) powDifficulty() (err error) {
+	defer m.autoRemove(OutPoWDifficulty)
+	h := m.md.HeaderMD.Get(OutPoWDifficulty)
+	if len(h) == 0 {
+		return nil
+	}
+
+	m.w.Header().Set(PoWDifficultyHeader, h[0])
+
+	return nil
+}
+
 func (m *httpResponseModifier) httpCode() error {
 	h := m.md.HeaderMD.Get(OutHTTPCode)
 	if len(h) == 0 {
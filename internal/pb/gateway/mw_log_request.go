@@ -21,6 +21,11 @@ func (w *rw) WriteError(err error) {
 	w.Err = err
 }
 
+// Unwrap gives access to the http.Flusher of the server streams.
+func (w *rw) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 func logRequest(log logger.Logger) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
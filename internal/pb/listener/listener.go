@@ -61,6 +61,11 @@ func Run(
 
 	prm := prometheus.NewServerMetrics()
 
+	th, err := newThrottling(cfg)
+	if err != nil {
+		log.Fatalf("%screate throttling error: %+v", name, err)
+	}
+
 	interceptorOpt := otelgrpc.WithTracerProvider(otel.GetTracerProvider())
 
 	s := grpc.NewServer(
@@ -68,14 +73,15 @@ func Run(
 			prm.StreamServerInterceptor(),
 			otelgrpc.StreamServerInterceptor(interceptorOpt),
 			// keep it last in the interceptor chain
-			throttle.StreamServerInterceptor(throttleFn),
+			throttle.StreamServerInterceptor(th.streamThrottleFn),
 		)),
 		grpc.UnaryInterceptor(mw.ChainUnaryServer(
 			recovery.UnaryServerInterceptor(opts...),
 			prm.UnaryServerInterceptor(),
 			otelgrpc.UnaryServerInterceptor(interceptorOpt),
+			th.UnaryServerInterceptor(),
 			// keep it last in the interceptor chain
-			throttle.UnaryServerInterceptor(throttleFn),
+			throttle.UnaryServerInterceptor(th.throttleFn),
 		)),
 		grpc.Creds(credentials.NewTLS(&tls.Config{
 			ClientAuth:   tls.RequireAndVerifyClientCert,
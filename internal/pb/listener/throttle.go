@@ -2,11 +2,223 @@ package listener
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"intmax2-node/configs"
+	node "intmax2-node/internal/pb/gen/block_builder_service/node"
+	"intmax2-node/internal/rate_limiter"
+	"intmax2-node/pkg/grpc_server/utils"
+	"net"
+	"strings"
+	"sync"
+	"time"
 
 	throttle "github.com/yaronsumel/grpc-throttle"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
-func throttleFn(_ context.Context, _ string) (throttle.Semaphore, bool) {
-	const throttleMax = 10
-	return make(throttle.Semaphore, throttleMax), true
+// ErrTooManyRequestsFromSender error: too many requests from the sender.
+var ErrTooManyRequestsFromSender = errors.New("too many requests from the sender")
+
+// ErrTooManyRequestsFromIP error: too many requests from the ip-address.
+var ErrTooManyRequestsFromIP = errors.New("too many requests from the ip-address")
+
+// ErrParseTrustedProxyFail error: failed to parse the trusted proxy.
+var ErrParseTrustedProxyFail = errors.New("failed to parse the trusted proxy")
+
+// admissionMethods are the methods limited by the sender and by the ip-address.
+var admissionMethods = map[string]bool{
+	node.BlockBuilderService_Transaction_FullMethodName:    true,
+	node.BlockBuilderService_BlockProposed_FullMethodName:  true,
+	node.BlockBuilderService_BlockSignature_FullMethodName: true,
+}
+
+type sender interface {
+	GetSender() string
+}
+
+type throttling struct {
+	cfg *configs.Config
+
+	mu         sync.Mutex
+	semaphores map[string]throttle.Semaphore
+
+	senders rate_limiter.RateLimiter
+	ips     rate_limiter.RateLimiter
+
+	trustedProxies []*net.IPNet
+}
+
+func newThrottling(cfg *configs.Config) (*throttling, error) {
+	trustedProxies := make([]*net.IPNet, 0, len(cfg.Throttle.TrustedProxies))
+	for key := range cfg.Throttle.TrustedProxies {
+		proxy := strings.TrimSpace(cfg.Throttle.TrustedProxies[key])
+		if proxy == "" {
+			continue
+		}
+
+		if ip := net.ParseIP(proxy); ip != nil {
+			const (
+				ipV4Bits = 32
+				ipV6Bits = 128
+			)
+			bits := ipV6Bits
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), ipV4Bits
+			}
+			trustedProxies = append(trustedProxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrParseTrustedProxyFail, proxy, err)
+		}
+		trustedProxies = append(trustedProxies, network)
+	}
+
+	return &throttling{
+		cfg:        cfg,
+		semaphores: make(map[string]throttle.Semaphore),
+		senders: rate_limiter.New(
+			cfg.Throttle.SenderRate, cfg.Throttle.SenderBurst, cfg.Throttle.BucketsLifetime,
+		),
+		ips: rate_limiter.New(
+			cfg.Throttle.IPRate, cfg.Throttle.IPBurst, cfg.Throttle.BucketsLifetime,
+		),
+		trustedProxies: trustedProxies,
+	}, nil
+}
+
+// throttleFn limits the number of the concurrent requests of the method.
+func (t *throttling) throttleFn(_ context.Context, fullMethod string) (throttle.Semaphore, bool) {
+	return t.semaphore(fullMethod, t.cfg.Throttle.MaxConcurrentRequests)
+}
+
+// streamThrottleFn limits the number of the open streams of the method.
+func (t *throttling) streamThrottleFn(_ context.Context, fullMethod string) (throttle.Semaphore, bool) {
+	return t.semaphore(fullMethod, t.cfg.Throttle.MaxConcurrentStreams)
+}
+
+func (t *throttling) semaphore(fullMethod string, size int) (throttle.Semaphore, bool) {
+	if size <= 0 {
+		return nil, false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	sem, ok := t.semaphores[fullMethod]
+	if !ok {
+		sem = make(throttle.Semaphore, size)
+		t.semaphores[fullMethod] = sem
+	}
+
+	return sem, true
+}
+
+// UnaryServerInterceptor rejects the requests over the token buckets of the sender and of the ip-address
+// and passes the load of the sender to the Transaction method for the PoW difficulty.
+// The token of the sender is taken before the handler, so the concurrent requests cannot pass the bucket together.
+// The sender is not authenticated before the handler, so the token is refunded for the rejected requests;
+// otherwise anyone could exhaust the bucket of the other sender with the invalid signatures.
+func (t *throttling) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if !admissionMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		if ip := t.ip(ctx); ip != "" {
+			if ok, retryAfter := t.ips.Allow(ip); !ok {
+				return nil, utils.TooManyRequests(ctx, retryAfter, t.retryAfterErr(ErrTooManyRequestsFromIP, retryAfter))
+			}
+		}
+
+		var senderKey string
+		if s, ok := req.(sender); ok {
+			senderKey = strings.ToLower(s.GetSender())
+		}
+		if senderKey == "" {
+			return handler(ctx, req)
+		}
+
+		// the load of the sender before the current request
+		ok, load, retryAfter := t.senders.Take(senderKey)
+		if !ok {
+			return nil, utils.TooManyRequests(ctx, retryAfter, t.retryAfterErr(ErrTooManyRequestsFromSender, retryAfter))
+		}
+
+		if info.FullMethod == node.BlockBuilderService_Transaction_FullMethodName {
+			ctx = rate_limiter.WithSenderLoad(ctx, load)
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			t.senders.Refund(senderKey)
+		}
+
+		return resp, err
+	}
+}
+
+func (t *throttling) retryAfterErr(err error, retryAfter time.Duration) error {
+	const msg = "%w: retry after %s"
+	return fmt.Errorf(msg, err, retryAfter)
+}
+
+// ip returns the client ip-address. The x-forwarded-for entries are used only behind the trusted proxies:
+// the hops are checked from the peer address back to the client, and the first untrusted hop is the client.
+func (t *throttling) ip(ctx context.Context) string {
+	const xForwardedFor = "x-forwarded-for"
+
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	client, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		client = p.Addr.String()
+	}
+
+	var hops []string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		const delimiter = ","
+		values := md.Get(xForwardedFor)
+		for key := range values {
+			hops = append(hops, strings.Split(values[key], delimiter)...)
+		}
+	}
+
+	for key := len(hops) - 1; key >= 0 && t.trustedProxy(client); key-- {
+		hop := strings.TrimSpace(hops[key])
+		if hop == "" {
+			break
+		}
+		client = hop
+	}
+
+	return client
+}
+
+func (t *throttling) trustedProxy(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for key := range t.trustedProxies {
+		if t.trustedProxies[key].Contains(ip) {
+			return true
+		}
+	}
+
+	return false
 }
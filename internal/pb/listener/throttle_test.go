@@ -0,0 +1,174 @@
+package listener
+
+import (
+	"context"
+	"errors"
+	"intmax2-node/configs"
+	node "intmax2-node/internal/pb/gen/block_builder_service/node"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestThrottlingIP(t *testing.T) {
+	t.Parallel()
+
+	cfg := configs.Config{}
+	cfg.Throttle.TrustedProxies = []string{"127.0.0.1/32", "10.0.0.0/8"}
+
+	th, err := newThrottling(&cfg)
+	require.NoError(t, err)
+
+	incoming := func(peerAddr string, xForwardedFor ...string) context.Context {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP(peerAddr), Port: 443},
+		})
+		if len(xForwardedFor) > 0 {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", xForwardedFor[0]))
+		}
+		return ctx
+	}
+
+	cases := []struct {
+		desc          string
+		peerAddr      string
+		xForwardedFor []string
+		ip            string
+	}{
+		{
+			desc:     "Direct call",
+			peerAddr: "1.2.3.4",
+			ip:       "1.2.3.4",
+		},
+		{
+			desc:          "Forwarded header of the untrusted peer is ignored",
+			peerAddr:      "1.2.3.4",
+			xForwardedFor: []string{"5.6.7.8"},
+			ip:            "1.2.3.4",
+		},
+		{
+			desc:          "Client behind the gateway",
+			peerAddr:      "127.0.0.1",
+			xForwardedFor: []string{"5.6.7.8"},
+			ip:            "5.6.7.8",
+		},
+		{
+			desc:          "Spoofed entry of the client is ignored",
+			peerAddr:      "127.0.0.1",
+			xForwardedFor: []string{"9.9.9.9, 5.6.7.8"},
+			ip:            "5.6.7.8",
+		},
+		{
+			desc:          "Client behind the load balancer and the gateway",
+			peerAddr:      "127.0.0.1",
+			xForwardedFor: []string{"9.9.9.9, 5.6.7.8, 10.0.0.1"},
+			ip:            "5.6.7.8",
+		},
+		{
+			desc:     "Gateway without the forwarded header",
+			peerAddr: "127.0.0.1",
+			ip:       "127.0.0.1",
+		},
+	}
+
+	for key := range cases {
+		tc := cases[key]
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.ip, th.ip(incoming(tc.peerAddr, tc.xForwardedFor...)))
+		})
+	}
+}
+
+func TestThrottlingTrustedProxyInvalid(t *testing.T) {
+	t.Parallel()
+
+	cfg := configs.Config{}
+	cfg.Throttle.TrustedProxies = []string{"gateway"}
+
+	_, err := newThrottling(&cfg)
+	assert.ErrorIs(t, err, ErrParseTrustedProxyFail)
+}
+
+func TestThrottlingSenderChargedByAcceptedRequests(t *testing.T) {
+	t.Parallel()
+
+	const sender = "0xSender"
+
+	cfg := configs.Config{}
+	cfg.Throttle.SenderRate = 0.001
+	cfg.Throttle.SenderBurst = 1
+	cfg.Throttle.BucketsLifetime = time.Hour
+
+	th, err := newThrottling(&cfg)
+	require.NoError(t, err)
+
+	interceptor := th.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: node.BlockBuilderService_Transaction_FullMethodName}
+	req := &node.TransactionRequest{Sender: sender}
+
+	errSignature := errors.New("invalid signature")
+	rejected := func(_ context.Context, _ any) (any, error) { return nil, errSignature }
+	accepted := func(_ context.Context, _ any) (any, error) { return &node.TransactionResponse{}, nil }
+
+	// The requests with the invalid signature do not spend the bucket of the sender.
+	for i := 0; i < 3; i++ {
+		_, err = interceptor(context.Background(), req, info, rejected)
+		assert.ErrorIs(t, err, errSignature)
+	}
+
+	_, err = interceptor(context.Background(), req, info, accepted)
+	assert.NoError(t, err)
+
+	_, err = interceptor(context.Background(), req, info, accepted)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.ErrorContains(t, err, ErrTooManyRequestsFromSender.Error())
+}
+
+func TestThrottlingSenderConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	const sender = "0xSender"
+
+	cfg := configs.Config{}
+	cfg.Throttle.SenderRate = 0.001
+	cfg.Throttle.SenderBurst = 1
+	cfg.Throttle.BucketsLifetime = time.Hour
+
+	th, err := newThrottling(&cfg)
+	require.NoError(t, err)
+
+	interceptor := th.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: node.BlockBuilderService_Transaction_FullMethodName}
+	req := &node.TransactionRequest{Sender: sender}
+
+	started, release := make(chan struct{}), make(chan struct{})
+	pending := func(_ context.Context, _ any) (any, error) {
+		close(started)
+		<-release
+		return &node.TransactionResponse{}, nil
+	}
+	accepted := func(_ context.Context, _ any) (any, error) { return &node.TransactionResponse{}, nil }
+
+	done := make(chan error)
+	go func() {
+		_, err := interceptor(context.Background(), req, info, pending)
+		done <- err
+	}()
+	<-started
+
+	// The request in progress holds the token of the sender.
+	_, err = interceptor(context.Background(), req, info, accepted)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	close(release)
+	assert.NoError(t, <-done)
+}
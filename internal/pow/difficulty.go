@@ -0,0 +1,105 @@
+package pow
+
+import (
+	"intmax2-node/configs"
+	"sync"
+	"time"
+)
+
+// Difficulty describes the PoW difficulty published by the block builder.
+type Difficulty struct {
+	Value       uint64
+	PublishedAt time.Time
+	ValidUntil  time.Time
+}
+
+// WorkerLoad returns the number of the transactions waiting in the worker of the block builder.
+type WorkerLoad func() int
+
+type DifficultyAdjuster interface {
+	// Current returns the difficulty for the new nonces.
+	Current() *Difficulty
+	// Accepted returns the least difficulty which is still valid.
+	Accepted() uint64
+}
+
+type difficultyAdjuster struct {
+	sync.Mutex
+	cfg       *configs.Config
+	load      WorkerLoad
+	published []*Difficulty
+}
+
+// NewDifficultyAdjuster creates the difficulty which grows linearly with the load of the worker
+// from the POW_DIFFICULTY to the POW_MAX_DIFFICULTY (reached by the POW_DIFFICULTY_FULL_LOAD transactions).
+// The difficulty is recomputed once per the POW_DIFFICULTY_PERIOD and every published value
+// is valid during the POW_DIFFICULTY_VALIDITY.
+func NewDifficultyAdjuster(cfg *configs.Config, load WorkerLoad) DifficultyAdjuster {
+	return &difficultyAdjuster{
+		cfg:  cfg,
+		load: load,
+	}
+}
+
+func (d *difficultyAdjuster) Current() *Difficulty {
+	d.Lock()
+	defer d.Unlock()
+
+	return d.current(time.Now().UTC())
+}
+
+func (d *difficultyAdjuster) Accepted() uint64 {
+	d.Lock()
+	defer d.Unlock()
+
+	now := time.Now().UTC()
+
+	accepted := d.current(now).Value
+	for key := range d.published {
+		if d.published[key].ValidUntil.After(now) && d.published[key].Value < accepted {
+			accepted = d.published[key].Value
+		}
+	}
+
+	return accepted
+}
+
+func (d *difficultyAdjuster) current(now time.Time) *Difficulty {
+	if l := len(d.published); l > 0 && now.Sub(d.published[l-1].PublishedAt) < d.cfg.PoW.DifficultyPeriod {
+		return d.published[l-1]
+	}
+
+	// the expired values are not needed anymore
+	var published []*Difficulty
+	for key := range d.published {
+		if d.published[key].ValidUntil.After(now) {
+			published = append(published, d.published[key])
+		}
+	}
+
+	current := Difficulty{
+		Value:       d.value(),
+		PublishedAt: now,
+		ValidUntil:  now.Add(d.cfg.PoW.DifficultyValidity),
+	}
+	d.published = append(published, &current)
+
+	return &current
+}
+
+func (d *difficultyAdjuster) value() uint64 {
+	minDifficulty, maxDifficulty := d.cfg.PoW.Difficulty, d.cfg.PoW.MaxDifficulty
+	if maxDifficulty <= minDifficulty || d.cfg.PoW.DifficultyFullLoad <= 0 || d.load == nil {
+		return minDifficulty
+	}
+
+	load := d.load()
+	if load >= d.cfg.PoW.DifficultyFullLoad {
+		return maxDifficulty
+	}
+	if load <= 0 {
+		return minDifficulty
+	}
+
+	return minDifficulty + (maxDifficulty-minDifficulty)*uint64(load)/uint64(d.cfg.PoW.DifficultyFullLoad)
+}
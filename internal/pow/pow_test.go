@@ -4,13 +4,16 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/binary"
+	"fmt"
 	"intmax2-node/configs"
 	"intmax2-node/internal/pow"
 	"math"
 	"math/big"
 	mrd "math/rand"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/iotaledger/iota.go/consts"
 	"github.com/iotaledger/iota.go/trinary"
@@ -37,6 +40,47 @@ func TestWorker_Mine(t *testing.T) {
 	t.Log(nonce, score)
 }
 
+func TestWorker_MineParallel(t *testing.T) {
+	const (
+		difficulty = 1000
+		numWorkers = 4
+	)
+
+	var calls, hashes uint64
+	p := pow.New(difficulty)
+	testWorker := pow.NewWorker(numWorkers, p, pow.WithProgress(time.Millisecond, func(h uint64, _ time.Duration) {
+		calls++
+		hashes = h
+	}))
+
+	for i := 0; i < numWorkers; i++ {
+		msg := append([]byte(fmt.Sprintf("Hello, World! %d", i)), make([]byte, p.NonceBytes())...)
+		nonce, err := testWorker.Mine(context.Background(), msg[:len(msg)-p.NonceBytes()])
+		require.NoError(t, err)
+
+		binary.LittleEndian.PutUint64(msg[len(msg)-p.NonceBytes():], nonce)
+		score, err := p.Score(msg)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, score, uint64(difficulty))
+	}
+
+	assert.Greater(t, calls, uint64(0))
+	assert.Greater(t, hashes, uint64(0))
+}
+
+func TestWorker_MineCancel(t *testing.T) {
+	const numWorkers = 2
+
+	p := pow.New(math.MaxUint64 / 1024)
+	testWorker := pow.NewWorker(numWorkers, p)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := testWorker.Mine(ctx, []byte("Hello, World!"))
+	assert.ErrorIs(t, err, pow.ErrCancelled)
+}
+
 func TestToInt(t *testing.T) {
 	const int2Key = 2
 	assert.NoError(t, configs.LoadDotEnv(int2Key))
@@ -153,3 +197,76 @@ func randomTrytes(n int) trinary.Trytes {
 	}
 	return result.String()
 }
+
+func TestPoWNonce_VerifyWithDifficulty(t *testing.T) {
+	const difficulty = 100
+
+	p := pow.New(difficulty)
+	pn := pow.NewPoWNonce(p, pow.NewWorker(1, p))
+	msg := []byte("Hello, World!")
+
+	nonce, err := pn.Nonce(context.Background(), msg)
+	require.NoError(t, err)
+
+	assert.NoError(t, pn.Verify(nonce, msg))
+	assert.NoError(t, pn.VerifyWithDifficulty(nonce, msg, difficulty))
+	assert.ErrorIs(t, pn.VerifyWithDifficulty(nonce, msg, math.MaxUint64), pow.ErrPoWNonceInvalid)
+}
+
+func TestDifficultyAdjuster(t *testing.T) {
+	cfg := &configs.Config{
+		PoW: configs.PoW{
+			Difficulty:         1000,
+			MaxDifficulty:      5000,
+			DifficultyFullLoad: 100,
+			DifficultyPeriod:   0,
+			DifficultyValidity: time.Hour,
+		},
+	}
+
+	var load int
+	d := pow.NewDifficultyAdjuster(cfg, func() int { return load })
+
+	current := d.Current()
+	assert.Equal(t, uint64(1000), current.Value)
+	assert.True(t, current.ValidUntil.After(current.PublishedAt))
+
+	load = 50
+	assert.Equal(t, uint64(3000), d.Current().Value)
+	// the nonces of the previous difficulty are still valid
+	assert.Equal(t, uint64(1000), d.Accepted())
+
+	load = 1000
+	assert.Equal(t, uint64(5000), d.Current().Value)
+
+	cfg.PoW.DifficultyValidity = 0
+	load = 0
+	assert.Equal(t, uint64(1000), d.Current().Value)
+	assert.Equal(t, uint64(1000), d.Accepted())
+}
+
+func BenchmarkWorker_Mine(b *testing.B) {
+	const difficulty = 4000
+
+	for _, numWorkers := range []int{1, 2, 4, 8, 16} {
+		if numWorkers > runtime.NumCPU() {
+			break
+		}
+
+		b.Run(fmt.Sprintf("workers=%d", numWorkers), func(b *testing.B) {
+			var hashes uint64
+			p := pow.New(difficulty)
+			testWorker := pow.NewWorker(numWorkers, p, pow.WithProgress(time.Hour, func(h uint64, _ time.Duration) {
+				hashes += h
+			}))
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, err := testWorker.Mine(context.Background(), []byte(fmt.Sprintf("Hello, World! %d", i)))
+				require.NoError(b, err)
+			}
+
+			b.ReportMetric(float64(hashes)/b.Elapsed().Seconds(), "hashes/s")
+		})
+	}
+}
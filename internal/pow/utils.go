@@ -35,7 +35,12 @@ func (p *powNonce) Nonce(ctx context.Context, msg []byte) (string, error) {
 	return n.Hex(), nil
 }
 
-func (p *powNonce) Verify(nonce string, msg []byte) (err error) {
+func (p *powNonce) Verify(nonce string, msg []byte) error {
+	return p.VerifyWithDifficulty(nonce, msg, p.pow.TargetScore())
+}
+
+// VerifyWithDifficulty checks the nonce against the difficulty instead of the target score of PoW.
+func (p *powNonce) VerifyWithDifficulty(nonce string, msg []byte, difficulty uint64) (err error) {
 	var pwNonce uint256.Int
 	err = pwNonce.SetFromHex(nonce)
 	if err != nil {
@@ -50,7 +55,7 @@ func (p *powNonce) Verify(nonce string, msg []byte) (err error) {
 		return errors.Join(ErrScoreFail, err)
 	}
 
-	if score < p.pow.TargetScore() {
+	if score < difficulty {
 		return ErrPoWNonceInvalid
 	}
 
@@ -6,8 +6,10 @@ import (
 	"math"
 	"math/big"
 	"math/bits"
+	"runtime"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/iotaledger/iota.go/consts"
 	"github.com/iotaledger/iota.go/curl/bct"
@@ -27,32 +29,53 @@ type Worker interface {
 	SufficientTrailingZeros(data []byte) (int, error)
 }
 
+// Progress receives the number of the hashes computed since the start of the mining.
+// It is called once per the progress interval and at the end of the mining.
+type Progress func(hashes uint64, elapsed time.Duration)
+
+// WorkerOption configures the PoW worker.
+type WorkerOption func(w *worker)
+
+// WithProgress sets the progress callback of the mining called once per the interval.
+func WithProgress(interval time.Duration, progress Progress) WorkerOption {
+	return func(w *worker) {
+		w.progressInterval = interval
+		w.progress = progress
+	}
+}
+
 // The worker performs the PoW.
 type worker struct {
-	numWorkers int
-	pow        PoW
+	numWorkers       int
+	pow              PoW
+	progressInterval time.Duration
+	progress         Progress
 }
 
 // NewWorker creates a new PoW worker.
-// The optional numWorkers specifies how many go routines should be used to perform the PoW.
-func NewWorker(numWorkers int, pow PoW) Worker {
-	const (
-		int0Key = 0
-		int1Key = 1
-	)
+// The numWorkers specifies how many go routines should be used to perform the PoW,
+// the non-positive value means the number of CPUs.
+func NewWorker(numWorkers int, pow PoW, opts ...WorkerOption) Worker {
+	const int0Key = 0
 
-	if numWorkers > int0Key {
-		numWorkers = int1Key
+	if numWorkers <= int0Key {
+		numWorkers = runtime.NumCPU()
 	}
 
-	return &worker{
+	w := worker{
 		numWorkers: numWorkers,
 		pow:        pow,
 	}
+	for key := range opts {
+		opts[key](&w)
+	}
+
+	return &w
 }
 
 // Mine performs the PoW for data.
 // It returns a nonce that appended to data results in a PoW score of at least targetScore.
+// The nonce range is split into the equal parts searched by the go routines in parallel.
 // The computation can be canceled anytime using ctx.
 func (w *worker) Mine(ctx context.Context, data []byte) (uint64, error) {
 	const (
@@ -65,13 +88,19 @@ func (w *worker) Mine(ctx context.Context, data []byte) (uint64, error) {
 		return int0Key, nil
 	}
 
+	sufficientTrailing, err := w.SufficientTrailingZeros(data)
+	if err != nil {
+		return int0Key, errors.Join(ErrSufficientTrailingZerosFail, err)
+	}
+
 	var (
 		done      uint32
 		counter   uint64
 		wg        sync.WaitGroup
 		results   = make(chan uint64, w.numWorkers)
+		errorChan = make(chan error, w.numWorkers)
 		closing   = make(chan struct{})
-		errorChan = make(chan error)
+		start     = time.Now()
 	)
 
 	// compute the digest
@@ -87,10 +116,32 @@ func (w *worker) Mine(ctx context.Context, data []byte) (uint64, error) {
 		}
 	}()
 
-	sufficientTrailing, err := w.SufficientTrailingZeros(data)
-	if err != nil {
-		return int0Key, errors.Join(ErrSufficientTrailingZerosFail, err)
-	}
+	// report the progress until the end of the mining
+	progressDone := make(chan struct{})
+	go func() {
+		defer close(progressDone)
+
+		if w.progress == nil {
+			return
+		}
+
+		var tick <-chan time.Time
+		if w.progressInterval > 0 {
+			ticker := time.NewTicker(w.progressInterval)
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+
+		for {
+			select {
+			case <-tick:
+				w.progress(atomic.LoadUint64(&counter), time.Since(start))
+			case <-closing:
+				w.progress(atomic.LoadUint64(&counter), time.Since(start))
+				return
+			}
+		}
+	}()
 
 	target := w.targetHash(data)
 
@@ -102,8 +153,11 @@ func (w *worker) Mine(ctx context.Context, data []byte) (uint64, error) {
 			defer wg.Done()
 
 			nonce, workerErr := w.worker(powDigest[:], startNonce, sufficientTrailing, target, &done, &counter)
-			if workerErr != nil && !errors.Is(workerErr, ErrDone) {
-				errorChan <- workerErr
+			if workerErr != nil {
+				// the worker is stopped by the other one or by the context
+				if !errors.Is(workerErr, ErrDone) {
+					errorChan <- workerErr
+				}
 				return
 			}
 			atomic.StoreUint32(&done, int1Key)
@@ -111,19 +165,20 @@ func (w *worker) Mine(ctx context.Context, data []byte) (uint64, error) {
 		}()
 	}
 	wg.Wait()
-	close(results)
 	close(closing)
+	<-progressDone
 
-	for {
-		select {
-		case nonce, ok := <-results:
-			if !ok {
-				return int0Key, ErrCancelled
-			}
-			return nonce, nil
-		case errCh := <-errorChan:
-			return int1Key, errors.Join(ErrCancelled, errCh)
-		}
+	select {
+	case nonce := <-results:
+		return nonce, nil
+	default:
+	}
+
+	select {
+	case errCh := <-errorChan:
+		return int0Key, errors.Join(ErrCancelled, errCh)
+	default:
+		return int0Key, ErrCancelled
 	}
 }
 
@@ -0,0 +1,37 @@
+package rate_limiter
+
+import (
+	"context"
+	"math"
+)
+
+type senderLoadKey struct{}
+
+// WithSenderLoad returns the context with the part of the burst spent by the sender before the request.
+func WithSenderLoad(ctx context.Context, load float64) context.Context {
+	return context.WithValue(ctx, senderLoadKey{}, load)
+}
+
+// SenderLoad returns the part of the burst spent by the sender before the request, if it is set.
+func SenderLoad(ctx context.Context) (load float64, ok bool) {
+	load, ok = ctx.Value(senderLoadKey{}).(float64)
+	return load, ok
+}
+
+// ScalePoWDifficulty keeps the difficulty while the sender spends up to half of the burst (load is 0.5)
+// and raises it linearly up to maxMultiplier times for the empty bucket (load is 1).
+func ScalePoWDifficulty(difficulty, maxMultiplier uint64, load float64) uint64 {
+	const (
+		int1Key  = 1
+		freeLoad = 0.5
+	)
+
+	if maxMultiplier <= int1Key || load <= freeLoad {
+		return difficulty
+	}
+
+	load = math.Min(load, int1Key)
+	multiplier := int1Key + (load-freeLoad)/(int1Key-freeLoad)*float64(maxMultiplier-int1Key)
+
+	return uint64(float64(difficulty) * multiplier)
+}
@@ -0,0 +1,160 @@
+package rate_limiter
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimiter describes the token buckets by the keys (the sender address, the ip-address, etc.).
+type RateLimiter interface {
+	// Allow takes the token from the bucket of the key.
+	// The retryAfter is the time until the next token when the bucket is empty.
+	Allow(key string) (ok bool, retryAfter time.Duration)
+	// Take takes the token from the bucket of the key like Allow
+	// and returns the part of the bucket which is spent before the token is taken.
+	Take(key string) (ok bool, load float64, retryAfter time.Duration)
+	// Refund returns the token taken from the bucket of the key.
+	Refund(key string)
+	// Load returns the part of the bucket of the key which is spent (from 0 to 1).
+	Load(key string) float64
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+type rateLimiter struct {
+	sync.Mutex
+	rate      float64
+	burst     float64
+	lifetime  time.Duration
+	cleanedAt time.Time
+	buckets   map[string]*bucket
+}
+
+// New creates the token buckets which are refilled with the rate of tokens per second up to the burst.
+// The buckets without the requests during the lifetime are removed. The zero rate turns off the limit.
+func New(rate float64, burst int, lifetime time.Duration) RateLimiter {
+	const int1Key = 1
+
+	if burst < int1Key {
+		burst = int1Key
+	}
+
+	return &rateLimiter{
+		rate:      rate,
+		burst:     float64(burst),
+		lifetime:  lifetime,
+		cleanedAt: time.Now().UTC(),
+		buckets:   make(map[string]*bucket),
+	}
+}
+
+func (r *rateLimiter) Allow(key string) (ok bool, retryAfter time.Duration) {
+	const int1Key = 1
+
+	if r.rate <= 0 {
+		return true, 0
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	b := r.refill(key)
+	if b.tokens >= int1Key {
+		b.tokens -= int1Key
+		return true, 0
+	}
+
+	return false, r.retryAfter(b)
+}
+
+func (r *rateLimiter) Take(key string) (ok bool, load float64, retryAfter time.Duration) {
+	const int1Key = 1
+
+	if r.rate <= 0 {
+		return true, 0, 0
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	b := r.refill(key)
+	load = (r.burst - b.tokens) / r.burst
+	if b.tokens >= int1Key {
+		b.tokens -= int1Key
+		return true, load, 0
+	}
+
+	return false, load, r.retryAfter(b)
+}
+
+func (r *rateLimiter) Refund(key string) {
+	const int1Key = 1
+
+	if r.rate <= 0 {
+		return
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	b := r.refill(key)
+	b.tokens = math.Min(r.burst, b.tokens+int1Key)
+}
+
+func (r *rateLimiter) retryAfter(b *bucket) time.Duration {
+	const int1Key = 1
+
+	wait := (int1Key - b.tokens) / r.rate * float64(time.Second)
+
+	return time.Duration(math.Ceil(wait))
+}
+
+func (r *rateLimiter) Load(key string) float64 {
+	if r.rate <= 0 {
+		return 0
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	b := r.refill(key)
+
+	return (r.burst - b.tokens) / r.burst
+}
+
+func (r *rateLimiter) refill(key string) *bucket {
+	now := time.Now().UTC()
+	r.clean(now)
+
+	b, ok := r.buckets[key]
+	if !ok {
+		b = &bucket{
+			tokens:    r.burst,
+			updatedAt: now,
+		}
+		r.buckets[key] = b
+		return b
+	}
+
+	b.tokens = math.Min(r.burst, b.tokens+now.Sub(b.updatedAt).Seconds()*r.rate)
+	b.updatedAt = now
+
+	return b
+}
+
+func (r *rateLimiter) clean(now time.Time) {
+	if now.Sub(r.cleanedAt) < r.lifetime {
+		return
+	}
+
+	for key := range r.buckets {
+		if now.Sub(r.buckets[key].updatedAt) >= r.lifetime {
+			delete(r.buckets, key)
+		}
+	}
+	r.cleanedAt = now
+}
@@ -0,0 +1,75 @@
+package rate_limiter_test
+
+import (
+	"intmax2-node/internal/rate_limiter"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	const (
+		rate     = 0.001
+		burst    = 2
+		lifetime = time.Hour
+		sender   = "sender"
+		other    = "other"
+	)
+
+	rl := rate_limiter.New(rate, burst, lifetime)
+	assert.Equal(t, float64(0), rl.Load(sender))
+
+	for i := 0; i < burst; i++ {
+		ok, load, retryAfter := rl.Take(sender)
+		assert.True(t, ok)
+		assert.InDelta(t, float64(i)/burst, load, 0.01)
+		assert.Equal(t, time.Duration(0), retryAfter)
+	}
+	assert.InDelta(t, 1, rl.Load(sender), 0.01)
+
+	ok, load, retryAfter := rl.Take(sender)
+	assert.False(t, ok)
+	assert.InDelta(t, 1, load, 0.01)
+	assert.Greater(t, retryAfter, 999*time.Second)
+
+	rl.Refund(sender)
+	assert.InDelta(t, 0.5, rl.Load(sender), 0.01)
+	ok, _ = rl.Allow(sender)
+	assert.True(t, ok)
+
+	ok, retryAfter = rl.Allow(sender)
+	assert.False(t, ok)
+	assert.Greater(t, retryAfter, 999*time.Second)
+	assert.LessOrEqual(t, retryAfter, 1000*time.Second)
+
+	ok, _ = rl.Allow(other)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.5, rl.Load(other), 0.01)
+}
+
+func TestRateLimiterOff(t *testing.T) {
+	t.Parallel()
+
+	rl := rate_limiter.New(0, 1, time.Hour)
+
+	for i := 0; i < 10; i++ {
+		ok, _ := rl.Allow("sender")
+		assert.True(t, ok)
+	}
+	assert.Equal(t, float64(0), rl.Load("sender"))
+}
+
+func TestScalePoWDifficulty(t *testing.T) {
+	t.Parallel()
+
+	const difficulty = 4000
+
+	assert.Equal(t, uint64(difficulty), rate_limiter.ScalePoWDifficulty(difficulty, 4, 0))
+	assert.Equal(t, uint64(difficulty), rate_limiter.ScalePoWDifficulty(difficulty, 4, 0.5))
+	assert.Equal(t, uint64(10000), rate_limiter.ScalePoWDifficulty(difficulty, 4, 0.75))
+	assert.Equal(t, uint64(16000), rate_limiter.ScalePoWDifficulty(difficulty, 4, 1))
+	assert.Equal(t, uint64(difficulty), rate_limiter.ScalePoWDifficulty(difficulty, 1, 1))
+}
@@ -6,6 +6,7 @@ import (
 	mFL "intmax2-node/internal/sql_filter/models"
 	intMaxTypes "intmax2-node/internal/types"
 	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	"time"
 
 	"github.com/dimiro1/health"
 	"github.com/holiman/uint256"
@@ -31,6 +32,11 @@ type PGX interface {
 	BackupBalances
 	CtrlProcessingJobs
 	GasPriceOracle
+	Deposits
+	BlockHashes
+	RegisteredAccounts
+	DepositAMLScreenings
+	BlockFees
 }
 
 type GenericCommands interface {
@@ -85,8 +91,13 @@ type TxMerkleProofs interface {
 
 type EventBlockNumbers interface {
 	UpsertEventBlockNumber(eventName string, blockNumber uint64) (*mDBApp.EventBlockNumber, error)
+	UpsertEventBlockNumberAndHash(eventName string, blockNumber uint64, blockHash string) (*mDBApp.EventBlockNumber, error)
 	EventBlockNumberByEventName(eventName string) (*mDBApp.EventBlockNumber, error)
 	EventBlockNumbersByEventNames(eventNames []string) ([]*mDBApp.EventBlockNumber, error)
+	UpsertEventBlockHash(eventName string, blockNumber uint64, blockHash string) error
+	EventBlockHashesByEventName(eventName string) ([]*mDBApp.EventBlockHash, error)
+	DelEventBlockHashesAfterBlockNumber(eventName string, blockNumber uint64) error
+	DelEventBlockHashesBeforeBlockNumber(eventName string, blockNumber uint64) error
 }
 
 type Balances interface {
@@ -110,6 +121,8 @@ type Withdrawals interface {
 		enoughBalanceProof *mDBApp.EnoughBalanceProof,
 	) (*mDBApp.Withdrawal, error)
 	UpdateWithdrawalsStatus(ids []string, status mDBApp.WithdrawalStatus) error
+	UpdateWithdrawalsProverJob(ids []string, status mDBApp.WithdrawalStatus, proverJobID string) error
+	UpdateWithdrawalProof(id string, withdrawalProof *mDBApp.WithdrawalProof) error
 	WithdrawalByID(id string) (*mDBApp.Withdrawal, error)
 	WithdrawalsByHashes(transferHashes []string) (*[]mDBApp.Withdrawal, error)
 	WithdrawalsByStatus(status mDBApp.WithdrawalStatus, limit *int) (*[]mDBApp.Withdrawal, error)
@@ -125,6 +138,11 @@ type BackupTransfers interface {
 		recipient, transferDoubleHash string,
 	) (*mDBApp.BackupTransfer, error)
 	GetBackupTransfers(condition string, value interface{}) ([]*mDBApp.BackupTransfer, error)
+	GetBackupTransfersByRecipientFromSeq(
+		recipient string,
+		startSeq, limit uint64,
+		createdBefore time.Time,
+	) ([]*mDBApp.BackupTransfer, error)
 	GetBackupTransfersByRecipient(
 		recipient string,
 		pagination mDBApp.PaginationOfListOfBackupTransfersInput,
@@ -145,6 +163,11 @@ type BackupTransactions interface {
 	GetBackupTransaction(condition string, value string) (*mDBApp.BackupTransaction, error)
 	GetBackupTransactionBySenderAndTxDoubleHash(sender, txDoubleHash string) (*mDBApp.BackupTransaction, error)
 	GetBackupTransactions(condition string, value interface{}) ([]*mDBApp.BackupTransaction, error)
+	GetBackupTransactionsBySenderFromSeq(
+		sender string,
+		startSeq, limit uint64,
+		createdBefore time.Time,
+	) ([]*mDBApp.BackupTransaction, error)
 	GetBackupTransactionsBySender(
 		sender string,
 		pagination mDBApp.PaginationOfListOfBackupTransactionsInput,
@@ -167,6 +190,11 @@ type BackupDeposits interface {
 	) (*mDBApp.BackupDeposit, error)
 	GetBackupDeposit(conditions []string, values []interface{}) (*mDBApp.BackupDeposit, error)
 	GetBackupDeposits(condition string, value interface{}) ([]*mDBApp.BackupDeposit, error)
+	GetBackupDepositsByRecipientFromSeq(
+		recipient string,
+		startSeq, limit uint64,
+		createdBefore time.Time,
+	) ([]*mDBApp.BackupDeposit, error)
 	GetBackupDepositsByRecipient(
 		recipient string,
 		pagination mDBApp.PaginationOfListOfBackupDepositsInput,
@@ -204,6 +232,7 @@ type Senders interface {
 	SenderByID(id string) (*mDBApp.Sender, error)
 	SenderByAddress(address string) (*mDBApp.Sender, error)
 	SenderByPublicKey(publicKey string) (*mDBApp.Sender, error)
+	UpdateSenderNonce(id string, nonce uint64) error
 }
 
 type Accounts interface {
@@ -211,6 +240,8 @@ type Accounts interface {
 	AccountBySenderID(senderID string) (*mDBApp.Account, error)
 	AccountByAccountID(accountID *uint256.Int) (*mDBApp.Account, error)
 	ResetSequenceByAccounts() error
+	UpdateBlockNumberOfNewAccounts(blockNumber uint64) error
+	DelAccountsAfterBlockNumber(blockNumber uint64) error
 	DelAllAccounts() error
 }
 
@@ -233,3 +264,57 @@ type GasPriceOracle interface {
 	CreateGasPriceOracle(name string, value *uint256.Int) error
 	GasPriceOracle(name string) (*mDBApp.GasPriceOracle, error)
 }
+
+type Deposits interface {
+	CreateDeposit(
+		depositID uint64,
+		depositHash, recipientSaltHash string,
+		tokenIndex uint32,
+		amount *uint256.Int,
+		blockNumber uint64,
+	) (*mDBApp.Deposit, error)
+	UpdateDepositIndexByDepositHash(
+		depositHash string,
+		depositIndex uint32,
+		processedBlockNumber uint64,
+	) (*mDBApp.Deposit, error)
+	DelUnindexedDepositsAfterBlockNumber(blockNumber uint64) error
+	ResetDepositIndexesAfterProcessedBlockNumber(processedBlockNumber uint64) error
+	DepositByDepositID(depositID uint64) (*mDBApp.Deposit, error)
+	DepositByDepositHash(depositHash string) (*mDBApp.Deposit, error)
+	IndexedDeposits() ([]*mDBApp.Deposit, error)
+	IndexedDepositsFromDepositIndex(depositIndex uint32) ([]*mDBApp.Deposit, error)
+}
+
+type BlockHashes interface {
+	CreateBlockHash(
+		blockNumber uint32,
+		blockHash, prevBlockHash, depositTreeRoot, signatureHash string,
+		postedBlockNumber uint64,
+	) (*mDBApp.BlockHash, error)
+	BlockHashByBlockNumber(blockNumber uint32) (*mDBApp.BlockHash, error)
+	BlockHashes() ([]*mDBApp.BlockHash, error)
+	DelBlockHashesAfterPostedBlockNumber(postedBlockNumber uint64) error
+}
+
+type RegisteredAccounts interface {
+	CreateRegisteredAccount(
+		accountID uint64,
+		publicKey string,
+		blockNumber uint32,
+		postedBlockNumber uint64,
+	) (*mDBApp.RegisteredAccount, error)
+	RegisteredAccounts() ([]*mDBApp.RegisteredAccount, error)
+	DelRegisteredAccountsAfterPostedBlockNumber(postedBlockNumber uint64) error
+}
+
+type DepositAMLScreenings interface {
+	UpsertDepositAMLScreening(screening *mDBApp.DepositAMLScreening) (*mDBApp.DepositAMLScreening, error)
+	DepositAMLScreeningByDepositID(depositID uint64) (*mDBApp.DepositAMLScreening, error)
+}
+
+type BlockFees interface {
+	CreateBlockFee(fee *mDBApp.BlockFee) (*mDBApp.BlockFee, error)
+	BlockFeeByID(id string) (*mDBApp.BlockFee, error)
+	BlockFeesByTxRoot(txRoot string) ([]*mDBApp.BlockFee, error)
+}
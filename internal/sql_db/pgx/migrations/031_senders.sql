@@ -0,0 +1,7 @@
+-- +migrate Up
+
+ALTER TABLE senders ADD COLUMN nonce bigint not null default 0;
+
+-- +migrate Down
+
+ALTER TABLE senders DROP COLUMN nonce;
@@ -0,0 +1,21 @@
+-- +migrate Up
+
+CREATE TABLE deposits (
+    id                  uuid not null default uuid_generate_v4(),
+    deposit_id          bigint not null,
+    deposit_hash        varchar(66) not null,
+    recipient_salt_hash varchar(66) not null,
+    token_index         bigint not null,
+    amount              numeric not null,
+    deposit_index       bigint,
+    created_at          timestamptz not null default now(),
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX idx_deposits_deposit_id ON deposits(deposit_id);
+CREATE UNIQUE INDEX idx_deposits_deposit_index ON deposits(deposit_index);
+CREATE INDEX idx_deposits_deposit_hash ON deposits(deposit_hash);
+
+-- +migrate Down
+
+DROP TABLE deposits;
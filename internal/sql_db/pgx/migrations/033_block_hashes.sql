@@ -0,0 +1,19 @@
+-- +migrate Up
+
+CREATE TABLE block_hashes (
+    id                uuid not null default uuid_generate_v4(),
+    block_number      bigint not null,
+    block_hash        varchar(66) not null,
+    prev_block_hash   varchar(66) not null,
+    deposit_tree_root varchar(66) not null,
+    signature_hash    varchar(66) not null,
+    created_at        timestamptz not null default now(),
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX idx_block_hashes_block_number ON block_hashes(block_number);
+CREATE INDEX idx_block_hashes_block_hash ON block_hashes(block_hash);
+
+-- +migrate Down
+
+DROP TABLE block_hashes;
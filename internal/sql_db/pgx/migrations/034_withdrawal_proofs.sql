@@ -0,0 +1,20 @@
+-- +migrate Up
+
+-- pending (0) becomes requested (0), success (1) becomes submitted (3) and failed (2) becomes failed (4).
+UPDATE withdrawals SET status = CASE status WHEN 1 THEN 3 WHEN 2 THEN 4 ELSE status END;
+
+ALTER TABLE withdrawals ADD COLUMN prover_job_id varchar(255);
+ALTER TABLE withdrawals ADD COLUMN withdrawal_proof json;
+ALTER TABLE withdrawals ADD COLUMN updated_at timestamptz not null default now();
+
+CREATE INDEX idx_withdrawals_status ON withdrawals(status);
+
+-- +migrate Down
+
+DROP INDEX idx_withdrawals_status;
+
+ALTER TABLE withdrawals DROP COLUMN updated_at;
+ALTER TABLE withdrawals DROP COLUMN withdrawal_proof;
+ALTER TABLE withdrawals DROP COLUMN prover_job_id;
+
+UPDATE withdrawals SET status = CASE status WHEN 3 THEN 1 WHEN 4 THEN 2 ELSE 0 END;
@@ -0,0 +1,24 @@
+-- +migrate Up
+
+CREATE TABLE deposit_aml_screenings (
+    id            uuid not null default uuid_generate_v4(),
+    deposit_id    bigint not null,
+    sender        varchar(42) not null,
+    token_index   bigint not null,
+    token_address varchar(42) not null,
+    provider      varchar(255) not null,
+    score         bigint not null,
+    threshold     bigint not null,
+    rejected      boolean not null,
+    reason        text not null default '',
+    created_at    timestamptz not null default now(),
+    updated_at    timestamptz not null default now(),
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX idx_deposit_aml_screenings_deposit_id ON deposit_aml_screenings(deposit_id);
+CREATE INDEX idx_deposit_aml_screenings_sender ON deposit_aml_screenings(sender);
+
+-- +migrate Down
+
+DROP TABLE deposit_aml_screenings;
@@ -0,0 +1,19 @@
+-- +migrate Up
+
+CREATE TABLE block_fees (
+    id                uuid not null default uuid_generate_v4(),
+    proposal_block_id uuid not null references blocks(proposal_block_id),
+    tx_hash           varchar(255) not null,
+    sender            varchar(255) not null,
+    token_index       bigint not null,
+    amount            numeric not null,
+    transfer_hash     varchar(255) not null,
+    created_at        timestamptz not null default now(),
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX idx_block_fees_proposal_block_id_tx_hash ON block_fees(proposal_block_id, tx_hash);
+
+-- +migrate Down
+
+DROP TABLE block_fees;
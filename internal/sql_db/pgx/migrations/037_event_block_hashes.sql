@@ -0,0 +1,25 @@
+-- +migrate Up
+
+ALTER TABLE event_block_numbers ADD COLUMN last_processed_block_hash varchar(66) not null default '';
+
+ALTER TABLE deposits ADD COLUMN block_number bigint not null default 0;
+ALTER TABLE deposits ADD COLUMN processed_block_number bigint;
+
+CREATE INDEX idx_deposits_block_number ON deposits(block_number);
+CREATE INDEX idx_deposits_processed_block_number ON deposits(processed_block_number);
+
+ALTER TABLE block_hashes ADD COLUMN posted_block_number bigint not null default 0;
+
+CREATE INDEX idx_block_hashes_posted_block_number ON block_hashes(posted_block_number);
+
+-- +migrate Down
+
+DROP INDEX idx_block_hashes_posted_block_number;
+ALTER TABLE block_hashes DROP COLUMN posted_block_number;
+
+DROP INDEX idx_deposits_processed_block_number;
+DROP INDEX idx_deposits_block_number;
+ALTER TABLE deposits DROP COLUMN processed_block_number;
+ALTER TABLE deposits DROP COLUMN block_number;
+
+ALTER TABLE event_block_numbers DROP COLUMN last_processed_block_hash;
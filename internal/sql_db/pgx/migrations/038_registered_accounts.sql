@@ -0,0 +1,21 @@
+-- +migrate Up
+
+CREATE TABLE registered_accounts (
+    account_id          bigint not null,
+    public_key          varchar(66) not null,
+    block_number        bigint not null,
+    posted_block_number bigint not null,
+    created_at          timestamptz not null default now(),
+    PRIMARY KEY (account_id)
+);
+
+CREATE UNIQUE INDEX idx_registered_accounts_public_key ON registered_accounts(public_key);
+CREATE INDEX idx_registered_accounts_posted_block_number ON registered_accounts(posted_block_number);
+
+-- The account tree is built from the same BlockPosted events as the block hash tree.
+DELETE FROM block_hashes WHERE 1=1;
+DELETE FROM event_block_numbers WHERE event_name = 'BlockHashTree';
+
+-- +migrate Down
+
+DROP TABLE registered_accounts;
@@ -0,0 +1,21 @@
+-- +migrate Up
+
+-- The transaction and transfer backups are stored before the block is posted,
+-- so the sequence number is used as the sync cursor instead of the block number.
+ALTER TABLE backup_transfers ADD COLUMN seq bigserial;
+ALTER TABLE backup_transactions ADD COLUMN seq bigserial;
+ALTER TABLE backup_deposits ADD COLUMN seq bigserial;
+
+CREATE UNIQUE INDEX idx_backup_transfers_seq ON backup_transfers (seq);
+CREATE UNIQUE INDEX idx_backup_transactions_seq ON backup_transactions (seq);
+CREATE UNIQUE INDEX idx_backup_deposits_seq ON backup_deposits (seq);
+
+-- +migrate Down
+
+DROP INDEX idx_backup_transfers_seq;
+DROP INDEX idx_backup_transactions_seq;
+DROP INDEX idx_backup_deposits_seq;
+
+ALTER TABLE backup_transfers DROP COLUMN seq;
+ALTER TABLE backup_transactions DROP COLUMN seq;
+ALTER TABLE backup_deposits DROP COLUMN seq;
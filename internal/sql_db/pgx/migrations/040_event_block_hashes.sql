@@ -0,0 +1,25 @@
+-- +migrate Up
+
+-- The hashes of the last blocks of the scanned ranges are kept to find the fork point of a reorg.
+CREATE TABLE event_block_hashes (
+    event_name   varchar(255) not null,
+    block_number bigint not null,
+    block_hash   varchar(66) not null,
+    created_at   timestamptz not null default now(),
+    PRIMARY KEY (event_name, block_number)
+);
+
+-- The accounts are registered again from the block of the deployment of the Rollup contract,
+-- so that the Scroll block number of every account is known.
+ALTER TABLE accounts ADD COLUMN block_number bigint;
+
+CREATE INDEX idx_accounts_block_number ON accounts(block_number);
+
+DELETE FROM event_block_numbers WHERE event_name = 'BlockPosted';
+
+-- +migrate Down
+
+DROP INDEX idx_accounts_block_number;
+ALTER TABLE accounts DROP COLUMN block_number;
+
+DROP TABLE event_block_hashes;
@@ -12,6 +12,7 @@ type BackupDeposit struct {
 	EncryptedDeposit  string         `json:"encrypted_deposit"`
 	BlockNumber       int64          `json:"block_number"`
 	CreatedAt         time.Time      `json:"created_at"`
+	Seq               int64          `json:"seq"`
 }
 
 type ListOfBackupDeposit []BackupDeposit
@@ -13,6 +13,7 @@ type BackupTransaction struct {
 	BlockNumber  int64          `json:"block_number"`
 	Signature    string         `json:"signature"`
 	CreatedAt    time.Time      `json:"created_at"`
+	Seq          int64          `json:"seq"`
 }
 
 type ListOfBackupTransaction []BackupTransaction
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type DepositAMLScreening struct {
+	ID           string
+	DepositID    int64
+	Sender       string
+	TokenIndex   int64
+	TokenAddress string
+	Provider     string
+	Score        int64
+	Threshold    int64
+	Rejected     bool
+	Reason       string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/holiman/uint256"
+)
+
+type Deposit struct {
+	ID                string
+	DepositID         int64
+	DepositHash       string
+	RecipientSaltHash string
+	TokenIndex        int64
+	Amount            uint256.Int
+	DepositIndex      sql.NullInt64
+	CreatedAt         time.Time
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type EventBlockHash struct {
+	EventName   string
+	BlockNumber int64
+	BlockHash   string
+	CreatedAt   time.Time
+}
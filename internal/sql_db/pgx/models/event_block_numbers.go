@@ -7,4 +7,5 @@ const (
 type EventBlockNumber struct {
 	EventName                string
 	LastProcessedBlockNumber uint64
+	LastProcessedBlockHash   string
 }
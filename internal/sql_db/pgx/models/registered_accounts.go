@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type RegisteredAccount struct {
+	AccountID         int64
+	PublicKey         string
+	BlockNumber       int64
+	PostedBlockNumber int64
+	CreatedAt         time.Time
+}
@@ -6,5 +6,6 @@ type Sender struct {
 	ID        string
 	Address   string
 	PublicKey string
+	Nonce     int64
 	CreatedAt time.Time
 }
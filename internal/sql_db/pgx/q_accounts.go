@@ -75,9 +75,12 @@ func (p *pgx) AccountByAccountID(accountID *uint256.Int) (*mDBApp.Account, error
 	return &accountDBApp, nil
 }
 
+// ResetSequenceByAccounts restarts the sequence of the account IDs after the latest stored account ID.
+// The account ID 1 is reserved, so the sequence restarts with 2 when no account is stored.
 func (p *pgx) ResetSequenceByAccounts() error {
 	const (
-		q = ` ALTER SEQUENCE accounts_account_id_seq RESTART WITH 2 `
+		q = ` SELECT setval('accounts_account_id_seq', COALESCE(MAX(account_id), 1)::bigint)
+              FROM accounts `
 	)
 
 	_, err := p.exec(p.ctx, q)
@@ -88,6 +91,36 @@ func (p *pgx) ResetSequenceByAccounts() error {
 	return nil
 }
 
+// UpdateBlockNumberOfNewAccounts sets the Scroll block number of the posted block
+// to the accounts which have no block number yet.
+func (p *pgx) UpdateBlockNumberOfNewAccounts(blockNumber uint64) error {
+	const (
+		q = ` UPDATE accounts SET block_number = $1 WHERE block_number IS NULL `
+	)
+
+	_, err := p.exec(p.ctx, q, blockNumber)
+	if err != nil {
+		return errPgx.Err(err)
+	}
+
+	return nil
+}
+
+// DelAccountsAfterBlockNumber deletes the accounts registered after the Scroll block number
+// and the accounts which have no block number yet.
+func (p *pgx) DelAccountsAfterBlockNumber(blockNumber uint64) error {
+	const (
+		q = ` DELETE FROM accounts WHERE block_number IS NULL OR block_number > $1 `
+	)
+
+	_, err := p.exec(p.ctx, q, blockNumber)
+	if err != nil {
+		return errPgx.Err(err)
+	}
+
+	return nil
+}
+
 func (p *pgx) DelAllAccounts() error {
 	const (
 		q = ` DELETE FROM accounts WHERE 1=1 `
@@ -2,7 +2,9 @@ package pgx
 
 import (
 	"database/sql"
+	"fmt"
 	errPgx "intmax2-node/internal/sql_db/pgx/errors"
+	"time"
 )
 
 func (p *pgx) createBackupEntry(query string, args ...interface{}) error {
@@ -15,7 +17,11 @@ func (p *pgx) createBackupEntry(query string, args ...interface{}) error {
 }
 
 func (p *pgx) getBackupEntries(query string, value interface{}, scanFunc func(*sql.Rows) error) error {
-	rows, err := p.query(p.ctx, query, value)
+	return p.getBackupEntriesByArgs(query, []interface{}{value}, scanFunc)
+}
+
+func (p *pgx) getBackupEntriesByArgs(query string, args []interface{}, scanFunc func(*sql.Rows) error) error {
+	rows, err := p.query(p.ctx, query, args...)
 	if err != nil {
 		return err
 	}
@@ -32,3 +38,35 @@ func (p *pgx) getBackupEntries(query string, value interface{}, scanFunc func(*s
 
 	return rows.Err()
 }
+
+// fromSeqQuery returns the query of the backups of the owner from the start sequence number
+// up to the limit of them (the NULL limit is unlimited). The sequence number is allocated before
+// the backup is committed, so the backup with the lower sequence number may be committed later.
+// Only the backups before the first backup created after the createdBefore are returned, so that
+// the backups committed within the lag are not skipped by the next page.
+// The arguments are the owner, the start sequence number, the limit and the createdBefore.
+func fromSeqQuery(columns, table, ownerColumn string) string {
+	const q = `
+        SELECT %[1]s
+        FROM %[2]s
+        WHERE %[3]s = $1 AND seq >= $2 AND seq < (
+            SELECT COALESCE(min(seq), 9223372036854775807)
+            FROM %[2]s
+            WHERE created_at >= $4
+        )
+        ORDER BY seq
+        LIMIT $3
+    `
+
+	return fmt.Sprintf(q, columns, table, ownerColumn)
+}
+
+// fromSeqArgs returns the arguments of the fromSeqQuery, the zero limit is unlimited.
+func fromSeqArgs(owner string, startSeq, limit uint64, createdBefore time.Time) []interface{} {
+	var l interface{}
+	if limit > 0 {
+		l = int64(limit)
+	}
+
+	return []interface{}{owner, int64(startSeq), l, createdBefore.UTC()}
+}
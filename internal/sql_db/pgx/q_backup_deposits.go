@@ -44,7 +44,7 @@ func (p *pgx) GetBackupDepositByRecipientAndDepositDoubleHash(
 ) (*mDBApp.BackupDeposit, error) {
 	const (
 		q = `
-        SELECT id, recipient, deposit_double_hash, encrypted_deposit, block_number, created_at
+        SELECT id, recipient, deposit_double_hash, encrypted_deposit, block_number, created_at, seq
         FROM backup_deposits
         WHERE recipient = $1 AND deposit_double_hash = $2 `
 	)
@@ -58,6 +58,7 @@ func (p *pgx) GetBackupDepositByRecipientAndDepositDoubleHash(
 			&b.EncryptedDeposit,
 			&b.BlockNumber,
 			&b.CreatedAt,
+			&b.Seq,
 		))
 	if err != nil {
 		return nil, err
@@ -68,7 +69,7 @@ func (p *pgx) GetBackupDepositByRecipientAndDepositDoubleHash(
 
 func (p *pgx) GetBackupDeposit(conditions []string, values []interface{}) (*mDBApp.BackupDeposit, error) {
 	const baseQuery = `
-        SELECT id, recipient, deposit_double_hash, encrypted_deposit, block_number, created_at 
+        SELECT id, recipient, deposit_double_hash, encrypted_deposit, block_number, created_at, seq
         FROM backup_deposits 
         WHERE %s`
 
@@ -88,6 +89,7 @@ func (p *pgx) GetBackupDeposit(conditions []string, values []interface{}) (*mDBA
 			&b.EncryptedDeposit,
 			&b.BlockNumber,
 			&b.CreatedAt,
+			&b.Seq,
 		))
 	if err != nil {
 		return nil, err
@@ -99,7 +101,7 @@ func (p *pgx) GetBackupDeposit(conditions []string, values []interface{}) (*mDBA
 
 func (p *pgx) GetBackupDeposits(condition string, value interface{}) ([]*mDBApp.BackupDeposit, error) {
 	const baseQuery = `
-        SELECT id, recipient, deposit_double_hash, encrypted_deposit, block_number, created_at
+        SELECT id, recipient, deposit_double_hash, encrypted_deposit, block_number, created_at, seq
         FROM backup_deposits
         WHERE %s = $1
     `
@@ -107,7 +109,33 @@ func (p *pgx) GetBackupDeposits(condition string, value interface{}) ([]*mDBApp.
 	var deposits []*mDBApp.BackupDeposit
 	err := p.getBackupEntries(query, value, func(rows *sql.Rows) error {
 		var b models.BackupDeposit
-		err := rows.Scan(&b.ID, &b.Recipient, &b.DepositDoubleHash, &b.EncryptedDeposit, &b.BlockNumber, &b.CreatedAt)
+		err := rows.Scan(&b.ID, &b.Recipient, &b.DepositDoubleHash, &b.EncryptedDeposit, &b.BlockNumber, &b.CreatedAt, &b.Seq)
+		if err != nil {
+			return err
+		}
+		deposit := p.backupDepositToDBApp(&b)
+		deposits = append(deposits, &deposit)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deposits, nil
+}
+
+func (p *pgx) GetBackupDepositsByRecipientFromSeq(
+	recipient string,
+	startSeq, limit uint64,
+	createdBefore time.Time,
+) ([]*mDBApp.BackupDeposit, error) {
+	query := fromSeqQuery(
+		"id, recipient, deposit_double_hash, encrypted_deposit, block_number, created_at, seq",
+		"backup_deposits", "recipient",
+	)
+	var deposits []*mDBApp.BackupDeposit
+	err := p.getBackupEntriesByArgs(query, fromSeqArgs(recipient, startSeq, limit, createdBefore), func(rows *sql.Rows) error {
+		var b models.BackupDeposit
+		err := rows.Scan(&b.ID, &b.Recipient, &b.DepositDoubleHash, &b.EncryptedDeposit, &b.BlockNumber, &b.CreatedAt, &b.Seq)
 		if err != nil {
 			return err
 		}
@@ -133,7 +161,7 @@ func (p *pgx) GetBackupDepositsByRecipient(
 ) {
 	var (
 		q = `
-SELECT id ,recipient ,deposit_double_hash ,encrypted_deposit ,block_number ,created_at
+SELECT id ,recipient ,deposit_double_hash ,encrypted_deposit ,block_number ,created_at ,seq
 FROM backup_deposits
 WHERE recipient = @recipient %s
 `
@@ -239,6 +267,7 @@ WHERE recipient = @recipient %s
 			&b.EncryptedDeposit,
 			&b.BlockNumber,
 			&b.CreatedAt,
+			&b.Seq,
 		)
 		if err != nil {
 			return nil, nil, err
@@ -301,5 +330,6 @@ func (p *pgx) backupDepositToDBApp(b *models.BackupDeposit) mDBApp.BackupDeposit
 		EncryptedDeposit:  b.EncryptedDeposit,
 		BlockNumber:       b.BlockNumber,
 		CreatedAt:         b.CreatedAt,
+		Seq:               b.Seq,
 	}
 }
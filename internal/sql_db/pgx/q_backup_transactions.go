@@ -43,7 +43,7 @@ func (p *pgx) CreateBackupTransaction(
 
 func (p *pgx) GetBackupTransaction(condition, value string) (*mDBApp.BackupTransaction, error) {
 	const baseQuery = `
-        SELECT id, sender, tx_double_hash, encrypted_tx, block_number, signature, created_at
+        SELECT id, sender, tx_double_hash, encrypted_tx, block_number, signature, created_at, seq
         FROM backup_transactions
         WHERE %s = $1
     `
@@ -59,6 +59,7 @@ func (p *pgx) GetBackupTransaction(condition, value string) (*mDBApp.BackupTrans
 			&b.BlockNumber,
 			&b.Signature,
 			&b.CreatedAt,
+			&b.Seq,
 		))
 	if err != nil {
 		return nil, err
@@ -70,7 +71,7 @@ func (p *pgx) GetBackupTransaction(condition, value string) (*mDBApp.BackupTrans
 func (p *pgx) GetBackupTransactionBySenderAndTxDoubleHash(sender, txDoubleHash string) (*mDBApp.BackupTransaction, error) {
 	const (
 		q = `
-        SELECT id, sender, tx_double_hash, encrypted_tx, block_number, signature, created_at
+        SELECT id, sender, tx_double_hash, encrypted_tx, block_number, signature, created_at, seq
         FROM backup_transactions
         WHERE sender = $1 AND tx_double_hash = $2 `
 	)
@@ -85,6 +86,7 @@ func (p *pgx) GetBackupTransactionBySenderAndTxDoubleHash(sender, txDoubleHash s
 			&b.BlockNumber,
 			&b.Signature,
 			&b.CreatedAt,
+			&b.Seq,
 		))
 	if err != nil {
 		return nil, err
@@ -95,7 +97,7 @@ func (p *pgx) GetBackupTransactionBySenderAndTxDoubleHash(sender, txDoubleHash s
 
 func (p *pgx) GetBackupTransactions(condition string, value interface{}) ([]*mDBApp.BackupTransaction, error) {
 	const baseQuery = `
-        SELECT id, sender, tx_double_hash, encrypted_tx, block_number, signature, created_at
+        SELECT id, sender, tx_double_hash, encrypted_tx, block_number, signature, created_at, seq
         FROM backup_transactions
         WHERE %s = $1
 `
@@ -103,7 +105,33 @@ func (p *pgx) GetBackupTransactions(condition string, value interface{}) ([]*mDB
 	var transactions []*mDBApp.BackupTransaction
 	err := p.getBackupEntries(query, value, func(rows *sql.Rows) error {
 		var b models.BackupTransaction
-		err := rows.Scan(&b.ID, &b.Sender, &b.TxDoubleHash, &b.EncryptedTx, &b.BlockNumber, &b.Signature, &b.CreatedAt)
+		err := rows.Scan(&b.ID, &b.Sender, &b.TxDoubleHash, &b.EncryptedTx, &b.BlockNumber, &b.Signature, &b.CreatedAt, &b.Seq)
+		if err != nil {
+			return err
+		}
+		transaction := p.backupTransactionToDBApp(&b)
+		transactions = append(transactions, &transaction)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+func (p *pgx) GetBackupTransactionsBySenderFromSeq(
+	sender string,
+	startSeq, limit uint64,
+	createdBefore time.Time,
+) ([]*mDBApp.BackupTransaction, error) {
+	query := fromSeqQuery(
+		"id, sender, tx_double_hash, encrypted_tx, block_number, signature, created_at, seq",
+		"backup_transactions", "sender",
+	)
+	var transactions []*mDBApp.BackupTransaction
+	err := p.getBackupEntriesByArgs(query, fromSeqArgs(sender, startSeq, limit, createdBefore), func(rows *sql.Rows) error {
+		var b models.BackupTransaction
+		err := rows.Scan(&b.ID, &b.Sender, &b.TxDoubleHash, &b.EncryptedTx, &b.BlockNumber, &b.Signature, &b.CreatedAt, &b.Seq)
 		if err != nil {
 			return err
 		}
@@ -129,7 +157,7 @@ func (p *pgx) GetBackupTransactionsBySender(
 ) {
 	var (
 		q = `
-SELECT id, sender, tx_double_hash, encrypted_tx, block_number, signature, created_at
+SELECT id, sender, tx_double_hash, encrypted_tx, block_number, signature, created_at, seq
 FROM backup_transactions
 WHERE sender = @sender %s
 `
@@ -236,6 +264,7 @@ WHERE sender = @sender %s
 			&b.BlockNumber,
 			&b.Signature,
 			&b.CreatedAt,
+			&b.Seq,
 		)
 		if err != nil {
 			return nil, nil, err
@@ -299,5 +328,6 @@ func (p *pgx) backupTransactionToDBApp(b *models.BackupTransaction) mDBApp.Backu
 		BlockNumber:  b.BlockNumber,
 		Signature:    b.Signature,
 		CreatedAt:    b.CreatedAt,
+		Seq:          b.Seq,
 	}
 }
@@ -41,7 +41,7 @@ func (p *pgx) CreateBackupTransfer(
 
 func (p *pgx) GetBackupTransfer(condition, value string) (*mDBApp.BackupTransfer, error) {
 	const baseQuery = `
-        SELECT id, recipient, transfer_double_hash, encrypted_transfer, block_number, created_at
+        SELECT id, recipient, transfer_double_hash, encrypted_transfer, block_number, created_at, seq
         FROM backup_transfers
         WHERE %s = $1
     `
@@ -56,6 +56,7 @@ func (p *pgx) GetBackupTransfer(condition, value string) (*mDBApp.BackupTransfer
 			&b.EncryptedTransfer,
 			&b.BlockNumber,
 			&b.CreatedAt,
+			&b.Seq,
 		))
 	if err != nil {
 		return nil, err
@@ -69,7 +70,7 @@ func (p *pgx) GetBackupTransferByRecipientAndTransferDoubleHash(
 ) (*mDBApp.BackupTransfer, error) {
 	const (
 		q = `
-        SELECT id, recipient, transfer_double_hash, encrypted_transfer, block_number, created_at
+        SELECT id, recipient, transfer_double_hash, encrypted_transfer, block_number, created_at, seq
         FROM backup_transfers
         WHERE recipient = $1 AND transfer_double_hash = $2 `
 	)
@@ -83,6 +84,7 @@ func (p *pgx) GetBackupTransferByRecipientAndTransferDoubleHash(
 			&b.EncryptedTransfer,
 			&b.BlockNumber,
 			&b.CreatedAt,
+			&b.Seq,
 		))
 	if err != nil {
 		return nil, err
@@ -93,7 +95,7 @@ func (p *pgx) GetBackupTransferByRecipientAndTransferDoubleHash(
 
 func (p *pgx) GetBackupTransfers(condition string, value interface{}) ([]*mDBApp.BackupTransfer, error) {
 	const baseQuery = `
-        SELECT id, recipient, transfer_double_hash, encrypted_transfer, block_number, created_at
+        SELECT id, recipient, transfer_double_hash, encrypted_transfer, block_number, created_at, seq
         FROM backup_transfers
         WHERE %s = $1
     `
@@ -101,7 +103,33 @@ func (p *pgx) GetBackupTransfers(condition string, value interface{}) ([]*mDBApp
 	var transfers []*mDBApp.BackupTransfer
 	err := p.getBackupEntries(query, value, func(rows *sql.Rows) error {
 		var b models.BackupTransfer
-		err := rows.Scan(&b.ID, &b.Recipient, &b.TransferDoubleHash, &b.EncryptedTransfer, &b.BlockNumber, &b.CreatedAt)
+		err := rows.Scan(&b.ID, &b.Recipient, &b.TransferDoubleHash, &b.EncryptedTransfer, &b.BlockNumber, &b.CreatedAt, &b.Seq)
+		if err != nil {
+			return err
+		}
+		transfer := p.backupTransferToDBApp(&b)
+		transfers = append(transfers, &transfer)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+func (p *pgx) GetBackupTransfersByRecipientFromSeq(
+	recipient string,
+	startSeq, limit uint64,
+	createdBefore time.Time,
+) ([]*mDBApp.BackupTransfer, error) {
+	query := fromSeqQuery(
+		"id, recipient, transfer_double_hash, encrypted_transfer, block_number, created_at, seq",
+		"backup_transfers", "recipient",
+	)
+	var transfers []*mDBApp.BackupTransfer
+	err := p.getBackupEntriesByArgs(query, fromSeqArgs(recipient, startSeq, limit, createdBefore), func(rows *sql.Rows) error {
+		var b models.BackupTransfer
+		err := rows.Scan(&b.ID, &b.Recipient, &b.TransferDoubleHash, &b.EncryptedTransfer, &b.BlockNumber, &b.CreatedAt, &b.Seq)
 		if err != nil {
 			return err
 		}
@@ -127,7 +155,7 @@ func (p *pgx) GetBackupTransfersByRecipient(
 ) {
 	var (
 		q = `
-SELECT id, recipient, transfer_double_hash, encrypted_transfer, block_number, created_at
+SELECT id, recipient, transfer_double_hash, encrypted_transfer, block_number, created_at, seq
 FROM backup_transfers
 WHERE recipient = @recipient %s
 `
@@ -233,6 +261,7 @@ WHERE recipient = @recipient %s
 			&b.EncryptedTransfer,
 			&b.BlockNumber,
 			&b.CreatedAt,
+			&b.Seq,
 		)
 		if err != nil {
 			return nil, nil, err
@@ -295,5 +324,6 @@ func (p *pgx) backupTransferToDBApp(b *models.BackupTransfer) mDBApp.BackupTrans
 		EncryptedTransfer:  b.EncryptedTransfer,
 		BlockNumber:        b.BlockNumber,
 		CreatedAt:          b.CreatedAt,
+		Seq:                b.Seq,
 	}
 }
@@ -0,0 +1,142 @@
+package pgx
+
+import (
+	errPgx "intmax2-node/internal/sql_db/pgx/errors"
+	"intmax2-node/internal/sql_db/pgx/models"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	intMaxUtils "intmax2-node/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateBlockFee records the fee paid to the block builder by the transaction of the block.
+func (p *pgx) CreateBlockFee(fee *mDBApp.BlockFee) (*mDBApp.BlockFee, error) {
+	bf := models.BlockFee{
+		ID:              uuid.New().String(),
+		ProposalBlockID: fee.ProposalBlockID,
+		TxHash:          fee.TxHash,
+		Sender:          fee.Sender,
+		TokenIndex:      int64(fee.TokenIndex),
+		Amount:          fee.Amount,
+		TransferHash:    fee.TransferHash,
+		CreatedAt:       time.Now().UTC(),
+	}
+
+	amount, _ := bf.Amount.Value()
+
+	const (
+		q = ` INSERT INTO block_fees
+              (id ,proposal_block_id ,tx_hash ,sender ,token_index
+              ,amount ,transfer_hash ,created_at)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8) `
+	)
+
+	_, err := p.exec(
+		p.ctx, q,
+		bf.ID, bf.ProposalBlockID, bf.TxHash, bf.Sender, bf.TokenIndex,
+		amount, bf.TransferHash, bf.CreatedAt,
+	)
+	if err != nil {
+		return nil, errPgx.Err(err)
+	}
+
+	var bfDBApp *mDBApp.BlockFee
+	bfDBApp, err = p.BlockFeeByID(bf.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return bfDBApp, nil
+}
+
+func (p *pgx) BlockFeeByID(id string) (*mDBApp.BlockFee, error) {
+	const (
+		q = ` SELECT id ,proposal_block_id ,tx_hash ,sender ,token_index
+              ,amount ,transfer_hash ,created_at
+              FROM block_fees WHERE id = $1 `
+	)
+
+	var bf models.BlockFee
+	err := errPgx.Err(p.queryRow(p.ctx, q, id).
+		Scan(
+			&bf.ID,
+			&bf.ProposalBlockID,
+			&bf.TxHash,
+			&bf.Sender,
+			&bf.TokenIndex,
+			&bf.Amount,
+			&bf.TransferHash,
+			&bf.CreatedAt,
+		))
+	if err != nil {
+		return nil, err
+	}
+
+	bfDBApp := p.blockFeeToDBApp(&bf)
+
+	return &bfDBApp, nil
+}
+
+// BlockFeesByTxRoot returns the fees collected in the block with the tx tree root, posted or not.
+func (p *pgx) BlockFeesByTxRoot(txRoot string) ([]*mDBApp.BlockFee, error) {
+	const (
+		q = ` SELECT bf.id ,bf.proposal_block_id ,bf.tx_hash ,bf.sender ,bf.token_index
+              ,bf.amount ,bf.transfer_hash ,bf.created_at
+              FROM block_fees bf
+              JOIN blocks b ON b.proposal_block_id = bf.proposal_block_id
+              WHERE b.tx_root = $1
+              ORDER BY bf.created_at, bf.id `
+	)
+
+	txRootWithoutPrefix := strings.ToUpper(intMaxUtils.RemoveZeroX(txRoot))
+
+	rows, err := p.query(p.ctx, q, txRootWithoutPrefix)
+	if err != nil {
+		return nil, errPgx.Err(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var fees []*mDBApp.BlockFee
+	for rows.Next() {
+		var bf models.BlockFee
+		err = rows.Scan(
+			&bf.ID,
+			&bf.ProposalBlockID,
+			&bf.TxHash,
+			&bf.Sender,
+			&bf.TokenIndex,
+			&bf.Amount,
+			&bf.TransferHash,
+			&bf.CreatedAt,
+		)
+		if err != nil {
+			return nil, errPgx.Err(err)
+		}
+
+		bfDBApp := p.blockFeeToDBApp(&bf)
+		fees = append(fees, &bfDBApp)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errPgx.Err(err)
+	}
+
+	return fees, nil
+}
+
+func (p *pgx) blockFeeToDBApp(bf *models.BlockFee) mDBApp.BlockFee {
+	return mDBApp.BlockFee{
+		ID:              bf.ID,
+		ProposalBlockID: bf.ProposalBlockID,
+		TxHash:          bf.TxHash,
+		Sender:          bf.Sender,
+		TokenIndex:      uint32(bf.TokenIndex),
+		Amount:          bf.Amount,
+		TransferHash:    bf.TransferHash,
+		CreatedAt:       bf.CreatedAt,
+	}
+}
@@ -0,0 +1,130 @@
+package pgx
+
+import (
+	errPgx "intmax2-node/internal/sql_db/pgx/errors"
+	"intmax2-node/internal/sql_db/pgx/models"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+)
+
+func (p *pgx) CreateBlockHash(
+	blockNumber uint32,
+	blockHash, prevBlockHash, depositTreeRoot, signatureHash string,
+	postedBlockNumber uint64,
+) (*mDBApp.BlockHash, error) {
+	const (
+		q = ` INSERT INTO block_hashes
+              (block_number ,block_hash ,prev_block_hash ,deposit_tree_root ,signature_hash ,posted_block_number)
+              VALUES ($1, $2, $3, $4, $5, $6)
+              ON CONFLICT (block_number) DO NOTHING `
+	)
+
+	_, err := p.exec(p.ctx, q, blockNumber, blockHash, prevBlockHash, depositTreeRoot, signatureHash, postedBlockNumber)
+	if err != nil {
+		return nil, errPgx.Err(err)
+	}
+
+	var bhDBApp *mDBApp.BlockHash
+	bhDBApp, err = p.BlockHashByBlockNumber(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return bhDBApp, nil
+}
+
+func (p *pgx) BlockHashByBlockNumber(blockNumber uint32) (*mDBApp.BlockHash, error) {
+	const (
+		q = ` SELECT id ,block_number ,block_hash ,prev_block_hash
+              ,deposit_tree_root ,signature_hash ,created_at
+              FROM block_hashes WHERE block_number = $1 `
+	)
+
+	var bh models.BlockHash
+	err := errPgx.Err(p.queryRow(p.ctx, q, blockNumber).
+		Scan(
+			&bh.ID,
+			&bh.BlockNumber,
+			&bh.BlockHash,
+			&bh.PrevBlockHash,
+			&bh.DepositTreeRoot,
+			&bh.SignatureHash,
+			&bh.CreatedAt,
+		))
+	if err != nil {
+		return nil, err
+	}
+
+	bhDBApp := p.blockHashToDBApp(&bh)
+
+	return &bhDBApp, nil
+}
+
+// BlockHashes returns the block hashes ordered by their block number, which is the leaf index
+// of the block hash tree.
+func (p *pgx) BlockHashes() ([]*mDBApp.BlockHash, error) {
+	const (
+		q = ` SELECT id ,block_number ,block_hash ,prev_block_hash
+              ,deposit_tree_root ,signature_hash ,created_at
+              FROM block_hashes ORDER BY block_number ASC `
+	)
+
+	rows, err := p.query(p.ctx, q)
+	if err != nil {
+		return nil, errPgx.Err(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var results []*mDBApp.BlockHash
+	for rows.Next() {
+		var bh models.BlockHash
+		err = rows.Scan(
+			&bh.ID,
+			&bh.BlockNumber,
+			&bh.BlockHash,
+			&bh.PrevBlockHash,
+			&bh.DepositTreeRoot,
+			&bh.SignatureHash,
+			&bh.CreatedAt,
+		)
+		if err != nil {
+			return nil, errPgx.Err(err)
+		}
+		bhDBApp := p.blockHashToDBApp(&bh)
+		results = append(results, &bhDBApp)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errPgx.Err(err)
+	}
+
+	return results, nil
+}
+
+// DelBlockHashesAfterPostedBlockNumber deletes the block hashes of the blocks posted
+// after the block number of the Rollup contract.
+func (p *pgx) DelBlockHashesAfterPostedBlockNumber(postedBlockNumber uint64) error {
+	const (
+		q = ` DELETE FROM block_hashes WHERE posted_block_number > $1 `
+	)
+
+	_, err := p.exec(p.ctx, q, postedBlockNumber)
+	if err != nil {
+		return errPgx.Err(err)
+	}
+
+	return nil
+}
+
+func (p *pgx) blockHashToDBApp(bh *models.BlockHash) mDBApp.BlockHash {
+	return mDBApp.BlockHash{
+		ID:              bh.ID,
+		BlockNumber:     uint32(bh.BlockNumber),
+		BlockHash:       bh.BlockHash,
+		PrevBlockHash:   bh.PrevBlockHash,
+		DepositTreeRoot: bh.DepositTreeRoot,
+		SignatureHash:   bh.SignatureHash,
+		CreatedAt:       bh.CreatedAt,
+	}
+}
@@ -0,0 +1,94 @@
+package pgx
+
+import (
+	errPgx "intmax2-node/internal/sql_db/pgx/errors"
+	"intmax2-node/internal/sql_db/pgx/models"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+)
+
+// UpsertDepositAMLScreening records the screening of the deposit, the deposit screened again
+// (the previous relay of the deposits failed) keeps the latest decision.
+func (p *pgx) UpsertDepositAMLScreening(screening *mDBApp.DepositAMLScreening) (*mDBApp.DepositAMLScreening, error) {
+	const (
+		q = ` INSERT INTO deposit_aml_screenings
+              (deposit_id ,sender ,token_index ,token_address ,provider
+              ,score ,threshold ,rejected ,reason)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+              ON CONFLICT (deposit_id) DO UPDATE SET
+                sender = EXCLUDED.sender
+                ,token_index = EXCLUDED.token_index
+                ,token_address = EXCLUDED.token_address
+                ,provider = EXCLUDED.provider
+                ,score = EXCLUDED.score
+                ,threshold = EXCLUDED.threshold
+                ,rejected = EXCLUDED.rejected
+                ,reason = EXCLUDED.reason
+                ,updated_at = now() `
+	)
+
+	_, err := p.exec(
+		p.ctx, q,
+		screening.DepositID, screening.Sender, screening.TokenIndex, screening.TokenAddress, screening.Provider,
+		screening.Score, screening.Threshold, screening.Rejected, screening.Reason,
+	)
+	if err != nil {
+		return nil, errPgx.Err(err)
+	}
+
+	var sDBApp *mDBApp.DepositAMLScreening
+	sDBApp, err = p.DepositAMLScreeningByDepositID(screening.DepositID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sDBApp, nil
+}
+
+func (p *pgx) DepositAMLScreeningByDepositID(depositID uint64) (*mDBApp.DepositAMLScreening, error) {
+	const (
+		q = ` SELECT id ,deposit_id ,sender ,token_index ,token_address ,provider
+              ,score ,threshold ,rejected ,reason ,created_at ,updated_at
+              FROM deposit_aml_screenings WHERE deposit_id = $1 `
+	)
+
+	var s models.DepositAMLScreening
+	err := errPgx.Err(p.queryRow(p.ctx, q, depositID).
+		Scan(
+			&s.ID,
+			&s.DepositID,
+			&s.Sender,
+			&s.TokenIndex,
+			&s.TokenAddress,
+			&s.Provider,
+			&s.Score,
+			&s.Threshold,
+			&s.Rejected,
+			&s.Reason,
+			&s.CreatedAt,
+			&s.UpdatedAt,
+		))
+	if err != nil {
+		return nil, err
+	}
+
+	sDBApp := p.depositAMLScreeningToDBApp(&s)
+
+	return &sDBApp, nil
+}
+
+func (p *pgx) depositAMLScreeningToDBApp(s *models.DepositAMLScreening) mDBApp.DepositAMLScreening {
+	return mDBApp.DepositAMLScreening{
+		ID:           s.ID,
+		DepositID:    uint64(s.DepositID),
+		Sender:       s.Sender,
+		TokenIndex:   uint32(s.TokenIndex),
+		TokenAddress: s.TokenAddress,
+		Provider:     s.Provider,
+		Score:        uint32(s.Score),
+		Threshold:    uint32(s.Threshold),
+		Rejected:     s.Rejected,
+		Reason:       s.Reason,
+		CreatedAt:    s.CreatedAt,
+		UpdatedAt:    s.UpdatedAt,
+	}
+}
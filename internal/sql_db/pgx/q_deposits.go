@@ -0,0 +1,235 @@
+package pgx
+
+import (
+	errPgx "intmax2-node/internal/sql_db/pgx/errors"
+	"intmax2-node/internal/sql_db/pgx/models"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+
+	"github.com/holiman/uint256"
+)
+
+func (p *pgx) CreateDeposit(
+	depositID uint64,
+	depositHash, recipientSaltHash string,
+	tokenIndex uint32,
+	amount *uint256.Int,
+	blockNumber uint64,
+) (*mDBApp.Deposit, error) {
+	const (
+		q = ` INSERT INTO deposits
+              (deposit_id ,deposit_hash ,recipient_salt_hash ,token_index ,amount ,block_number)
+              VALUES ($1, $2, $3, $4, $5, $6)
+              ON CONFLICT (deposit_id) DO NOTHING `
+	)
+
+	a, _ := amount.Value()
+
+	_, err := p.exec(p.ctx, q, depositID, depositHash, recipientSaltHash, tokenIndex, a, blockNumber)
+	if err != nil {
+		return nil, errPgx.Err(err)
+	}
+
+	var dDBApp *mDBApp.Deposit
+	dDBApp, err = p.DepositByDepositID(depositID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dDBApp, nil
+}
+
+// UpdateDepositIndexByDepositHash assigns the index of the deposit tree leaf to the earliest
+// not yet indexed deposit with the given hash.
+func (p *pgx) UpdateDepositIndexByDepositHash(
+	depositHash string,
+	depositIndex uint32,
+	processedBlockNumber uint64,
+) (*mDBApp.Deposit, error) {
+	const (
+		q = ` UPDATE deposits SET deposit_index = $2 ,processed_block_number = $3
+              WHERE id = (
+                SELECT id FROM deposits
+                WHERE deposit_hash = $1 AND deposit_index IS NULL
+                ORDER BY deposit_id LIMIT 1
+              )
+              RETURNING id ,deposit_id ,deposit_hash ,recipient_salt_hash
+              ,token_index ,amount ,deposit_index ,created_at `
+	)
+
+	var d models.Deposit
+	err := errPgx.Err(p.queryRow(p.ctx, q, depositHash, depositIndex, processedBlockNumber).
+		Scan(
+			&d.ID,
+			&d.DepositID,
+			&d.DepositHash,
+			&d.RecipientSaltHash,
+			&d.TokenIndex,
+			&d.Amount,
+			&d.DepositIndex,
+			&d.CreatedAt,
+		))
+	if err != nil {
+		return nil, err
+	}
+
+	dDBApp := p.depositToDBApp(&d)
+
+	return &dDBApp, nil
+}
+
+// DelUnindexedDepositsAfterBlockNumber deletes the deposits made after the block number of the Liquidity contract
+// that are not included in the deposit tree.
+func (p *pgx) DelUnindexedDepositsAfterBlockNumber(blockNumber uint64) error {
+	const (
+		q = ` DELETE FROM deposits WHERE block_number > $1 AND deposit_index IS NULL `
+	)
+
+	_, err := p.exec(p.ctx, q, blockNumber)
+	if err != nil {
+		return errPgx.Err(err)
+	}
+
+	return nil
+}
+
+// ResetDepositIndexesAfterProcessedBlockNumber removes from the deposit tree the deposits
+// processed after the block number of the Rollup contract.
+func (p *pgx) ResetDepositIndexesAfterProcessedBlockNumber(processedBlockNumber uint64) error {
+	const (
+		q = ` UPDATE deposits SET deposit_index = NULL ,processed_block_number = NULL
+              WHERE processed_block_number > $1 `
+	)
+
+	_, err := p.exec(p.ctx, q, processedBlockNumber)
+	if err != nil {
+		return errPgx.Err(err)
+	}
+
+	return nil
+}
+
+func (p *pgx) DepositByDepositID(depositID uint64) (*mDBApp.Deposit, error) {
+	const (
+		q = ` SELECT id ,deposit_id ,deposit_hash ,recipient_salt_hash
+              ,token_index ,amount ,deposit_index ,created_at
+              FROM deposits WHERE deposit_id = $1 `
+	)
+
+	var d models.Deposit
+	err := errPgx.Err(p.queryRow(p.ctx, q, depositID).
+		Scan(
+			&d.ID,
+			&d.DepositID,
+			&d.DepositHash,
+			&d.RecipientSaltHash,
+			&d.TokenIndex,
+			&d.Amount,
+			&d.DepositIndex,
+			&d.CreatedAt,
+		))
+	if err != nil {
+		return nil, err
+	}
+
+	dDBApp := p.depositToDBApp(&d)
+
+	return &dDBApp, nil
+}
+
+func (p *pgx) DepositByDepositHash(depositHash string) (*mDBApp.Deposit, error) {
+	const (
+		q = ` SELECT id ,deposit_id ,deposit_hash ,recipient_salt_hash
+              ,token_index ,amount ,deposit_index ,created_at
+              FROM deposits WHERE deposit_hash = $1
+              ORDER BY deposit_id LIMIT 1 `
+	)
+
+	var d models.Deposit
+	err := errPgx.Err(p.queryRow(p.ctx, q, depositHash).
+		Scan(
+			&d.ID,
+			&d.DepositID,
+			&d.DepositHash,
+			&d.RecipientSaltHash,
+			&d.TokenIndex,
+			&d.Amount,
+			&d.DepositIndex,
+			&d.CreatedAt,
+		))
+	if err != nil {
+		return nil, err
+	}
+
+	dDBApp := p.depositToDBApp(&d)
+
+	return &dDBApp, nil
+}
+
+// IndexedDeposits returns the deposits included in the deposit tree ordered by their leaf index.
+func (p *pgx) IndexedDeposits() ([]*mDBApp.Deposit, error) {
+	return p.IndexedDepositsFromDepositIndex(0)
+}
+
+// IndexedDepositsFromDepositIndex returns the deposits included in the deposit tree from the leaf index
+// ordered by their leaf index.
+func (p *pgx) IndexedDepositsFromDepositIndex(depositIndex uint32) ([]*mDBApp.Deposit, error) {
+	const (
+		q = ` SELECT id ,deposit_id ,deposit_hash ,recipient_salt_hash
+              ,token_index ,amount ,deposit_index ,created_at
+              FROM deposits WHERE deposit_index >= $1
+              ORDER BY deposit_index ASC `
+	)
+
+	rows, err := p.query(p.ctx, q, depositIndex)
+	if err != nil {
+		return nil, errPgx.Err(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var results []*mDBApp.Deposit
+	for rows.Next() {
+		var d models.Deposit
+		err = rows.Scan(
+			&d.ID,
+			&d.DepositID,
+			&d.DepositHash,
+			&d.RecipientSaltHash,
+			&d.TokenIndex,
+			&d.Amount,
+			&d.DepositIndex,
+			&d.CreatedAt,
+		)
+		if err != nil {
+			return nil, errPgx.Err(err)
+		}
+		dDBApp := p.depositToDBApp(&d)
+		results = append(results, &dDBApp)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errPgx.Err(err)
+	}
+
+	return results, nil
+}
+
+func (p *pgx) depositToDBApp(d *models.Deposit) mDBApp.Deposit {
+	m := mDBApp.Deposit{
+		ID:                d.ID,
+		DepositID:         uint64(d.DepositID),
+		DepositHash:       d.DepositHash,
+		RecipientSaltHash: d.RecipientSaltHash,
+		TokenIndex:        uint32(d.TokenIndex),
+		Amount:            &d.Amount,
+		CreatedAt:         d.CreatedAt,
+	}
+
+	if d.DepositIndex.Valid {
+		depositIndex := uint32(d.DepositIndex.Int64)
+		m.DepositIndex = &depositIndex
+	}
+
+	return m
+}
@@ -0,0 +1,107 @@
+package pgx
+
+import (
+	errPgx "intmax2-node/internal/sql_db/pgx/errors"
+	"intmax2-node/internal/sql_db/pgx/models"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+)
+
+// UpsertEventBlockHash stores the hash of the last block of the range of the blocks scanned for the event.
+func (p *pgx) UpsertEventBlockHash(eventName string, blockNumber uint64, blockHash string) error {
+	const (
+		q = ` INSERT INTO event_block_hashes (event_name ,block_number ,block_hash)
+              VALUES ($1, $2, $3)
+              ON CONFLICT (event_name, block_number)
+              DO UPDATE SET block_hash = EXCLUDED.block_hash `
+	)
+
+	_, err := p.exec(p.ctx, q, eventName, blockNumber, blockHash)
+	if err != nil {
+		return errPgx.Err(err)
+	}
+
+	return nil
+}
+
+// EventBlockHashesByEventName returns the stored block hashes of the event
+// ordered by their block number from the latest one.
+func (p *pgx) EventBlockHashesByEventName(eventName string) ([]*mDBApp.EventBlockHash, error) {
+	const (
+		q = ` SELECT event_name ,block_number ,block_hash ,created_at
+              FROM event_block_hashes WHERE event_name = $1
+              ORDER BY block_number DESC `
+	)
+
+	rows, err := p.query(p.ctx, q, eventName)
+	if err != nil {
+		return nil, errPgx.Err(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var results []*mDBApp.EventBlockHash
+	for rows.Next() {
+		var ebh models.EventBlockHash
+		err = rows.Scan(
+			&ebh.EventName,
+			&ebh.BlockNumber,
+			&ebh.BlockHash,
+			&ebh.CreatedAt,
+		)
+		if err != nil {
+			return nil, errPgx.Err(err)
+		}
+		ebhDBApp := p.eventBlockHashToDBApp(&ebh)
+		results = append(results, &ebhDBApp)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errPgx.Err(err)
+	}
+
+	return results, nil
+}
+
+// DelEventBlockHashesAfterBlockNumber deletes the stored block hashes of the event
+// of the blocks after the block number.
+func (p *pgx) DelEventBlockHashesAfterBlockNumber(eventName string, blockNumber uint64) error {
+	const (
+		q = ` DELETE FROM event_block_hashes WHERE event_name = $1 AND block_number > $2 `
+	)
+
+	_, err := p.exec(p.ctx, q, eventName, blockNumber)
+	if err != nil {
+		return errPgx.Err(err)
+	}
+
+	return nil
+}
+
+// DelEventBlockHashesBeforeBlockNumber deletes the stored block hashes of the event of the blocks
+// before the latest stored block which is not after the block number. The hash of that block is kept,
+// so that the fork point is found even when the ranges are longer than the reorg depth.
+func (p *pgx) DelEventBlockHashesBeforeBlockNumber(eventName string, blockNumber uint64) error {
+	const (
+		q = ` DELETE FROM event_block_hashes WHERE event_name = $1 AND block_number < (
+                SELECT COALESCE(MAX(block_number), 0) FROM event_block_hashes
+                WHERE event_name = $1 AND block_number <= $2
+              ) `
+	)
+
+	_, err := p.exec(p.ctx, q, eventName, blockNumber)
+	if err != nil {
+		return errPgx.Err(err)
+	}
+
+	return nil
+}
+
+func (p *pgx) eventBlockHashToDBApp(ebh *models.EventBlockHash) mDBApp.EventBlockHash {
+	return mDBApp.EventBlockHash{
+		EventName:   ebh.EventName,
+		BlockNumber: uint64(ebh.BlockNumber),
+		BlockHash:   ebh.BlockHash,
+		CreatedAt:   ebh.CreatedAt,
+	}
+}
@@ -12,20 +12,31 @@ import (
 )
 
 func (p *pgx) UpsertEventBlockNumber(eventName string, blockNumber uint64) (*mDBApp.EventBlockNumber, error) {
+	return p.UpsertEventBlockNumberAndHash(eventName, blockNumber, "")
+}
+
+// UpsertEventBlockNumberAndHash stores the number and the hash of the last processed block of the event.
+// The empty hash means that the hash of the block is unknown.
+func (p *pgx) UpsertEventBlockNumberAndHash(
+	eventName string,
+	blockNumber uint64,
+	blockHash string,
+) (*mDBApp.EventBlockNumber, error) {
 	id := uuid.New().String()
 	now := time.Now().UTC()
 
 	const (
 		q = ` INSERT INTO event_block_numbers
-              (id, event_name, last_processed_block_number ,created_at)
-              VALUES ($1, $2, $3, $4)
+              (id, event_name, last_processed_block_number, last_processed_block_hash, created_at)
+              VALUES ($1, $2, $3, $4, $5)
               ON CONFLICT (event_name)
               DO UPDATE SET
-			    last_processed_block_number = EXCLUDED.last_processed_block_number
+			    last_processed_block_number = EXCLUDED.last_processed_block_number,
+			    last_processed_block_hash = EXCLUDED.last_processed_block_hash
               RETURNING *`
 	)
 
-	_, err := p.exec(p.ctx, q, id, eventName, blockNumber, now)
+	_, err := p.exec(p.ctx, q, id, eventName, blockNumber, blockHash, now)
 	if err != nil {
 		return nil, errPgx.Err(err)
 	}
@@ -47,7 +58,7 @@ func (p *pgx) EventBlockNumbersByEventNames(eventNames []string) ([]*mDBApp.Even
 	placeholderStr := strings.Join(placeholder, ", ")
 
 	q := fmt.Sprintf(`
-        SELECT event_name, last_processed_block_number
+        SELECT event_name, last_processed_block_number, last_processed_block_hash
         FROM event_block_numbers
         WHERE event_name IN (%s)
     `, placeholderStr)
@@ -69,6 +80,7 @@ func (p *pgx) EventBlockNumbersByEventNames(eventNames []string) ([]*mDBApp.Even
 		err = rows.Scan(
 			&e.EventName,
 			&e.LastProcessedBlockNumber,
+			&e.LastProcessedBlockHash,
 		)
 		if err != nil {
 			return nil, errPgx.Err(err)
@@ -86,7 +98,7 @@ func (p *pgx) EventBlockNumbersByEventNames(eventNames []string) ([]*mDBApp.Even
 
 func (p *pgx) EventBlockNumberByEventName(eventName string) (*mDBApp.EventBlockNumber, error) {
 	const q = `
-	    SELECT event_name, last_processed_block_number
+	    SELECT event_name, last_processed_block_number, last_processed_block_hash
 	    FROM event_block_numbers
 	    WHERE event_name = $1
     `
@@ -96,6 +108,7 @@ func (p *pgx) EventBlockNumberByEventName(eventName string) (*mDBApp.EventBlockN
 		Scan(
 			&e.EventName,
 			&e.LastProcessedBlockNumber,
+			&e.LastProcessedBlockHash,
 		))
 	if err != nil {
 		return nil, err
@@ -109,5 +122,6 @@ func (p *pgx) eToDBApp(e *models.EventBlockNumber) mDBApp.EventBlockNumber {
 	return mDBApp.EventBlockNumber{
 		EventName:                e.EventName,
 		LastProcessedBlockNumber: e.LastProcessedBlockNumber,
+		LastProcessedBlockHash:   e.LastProcessedBlockHash,
 	}
 }
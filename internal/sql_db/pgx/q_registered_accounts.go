@@ -0,0 +1,103 @@
+package pgx
+
+import (
+	errPgx "intmax2-node/internal/sql_db/pgx/errors"
+	"intmax2-node/internal/sql_db/pgx/models"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+)
+
+func (p *pgx) CreateRegisteredAccount(
+	accountID uint64,
+	publicKey string,
+	blockNumber uint32,
+	postedBlockNumber uint64,
+) (*mDBApp.RegisteredAccount, error) {
+	const (
+		q = ` INSERT INTO registered_accounts
+              (account_id ,public_key ,block_number ,posted_block_number)
+              VALUES ($1, $2, $3, $4)
+              RETURNING account_id ,public_key ,block_number ,posted_block_number ,created_at `
+	)
+
+	var ra models.RegisteredAccount
+	err := errPgx.Err(p.queryRow(p.ctx, q, accountID, publicKey, blockNumber, postedBlockNumber).
+		Scan(
+			&ra.AccountID,
+			&ra.PublicKey,
+			&ra.BlockNumber,
+			&ra.PostedBlockNumber,
+			&ra.CreatedAt,
+		))
+	if err != nil {
+		return nil, err
+	}
+
+	raDBApp := p.registeredAccountToDBApp(&ra)
+
+	return &raDBApp, nil
+}
+
+// RegisteredAccounts returns the registered accounts ordered by their account ID, which is the leaf index
+// of the account tree.
+func (p *pgx) RegisteredAccounts() ([]*mDBApp.RegisteredAccount, error) {
+	const (
+		q = ` SELECT account_id ,public_key ,block_number ,posted_block_number ,created_at
+              FROM registered_accounts ORDER BY account_id ASC `
+	)
+
+	rows, err := p.query(p.ctx, q)
+	if err != nil {
+		return nil, errPgx.Err(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var results []*mDBApp.RegisteredAccount
+	for rows.Next() {
+		var ra models.RegisteredAccount
+		err = rows.Scan(
+			&ra.AccountID,
+			&ra.PublicKey,
+			&ra.BlockNumber,
+			&ra.PostedBlockNumber,
+			&ra.CreatedAt,
+		)
+		if err != nil {
+			return nil, errPgx.Err(err)
+		}
+		raDBApp := p.registeredAccountToDBApp(&ra)
+		results = append(results, &raDBApp)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errPgx.Err(err)
+	}
+
+	return results, nil
+}
+
+// DelRegisteredAccountsAfterPostedBlockNumber deletes the accounts registered by the blocks posted
+// after the block number of the Rollup contract.
+func (p *pgx) DelRegisteredAccountsAfterPostedBlockNumber(postedBlockNumber uint64) error {
+	const (
+		q = ` DELETE FROM registered_accounts WHERE posted_block_number > $1 `
+	)
+
+	_, err := p.exec(p.ctx, q, postedBlockNumber)
+	if err != nil {
+		return errPgx.Err(err)
+	}
+
+	return nil
+}
+
+func (p *pgx) registeredAccountToDBApp(ra *models.RegisteredAccount) mDBApp.RegisteredAccount {
+	return mDBApp.RegisteredAccount{
+		AccountID:         uint64(ra.AccountID),
+		PublicKey:         ra.PublicKey,
+		BlockNumber:       uint32(ra.BlockNumber),
+		PostedBlockNumber: uint64(ra.PostedBlockNumber),
+		CreatedAt:         ra.CreatedAt,
+	}
+}
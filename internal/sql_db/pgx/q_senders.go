@@ -32,7 +32,7 @@ func (p *pgx) CreateSenders(
 
 func (p *pgx) SenderByID(id string) (*mDBApp.Sender, error) {
 	const (
-		q = ` SELECT id ,address ,public_key ,created_at
+		q = ` SELECT id ,address ,public_key ,nonce ,created_at
               FROM senders
               WHERE id = $1 `
 	)
@@ -43,6 +43,7 @@ func (p *pgx) SenderByID(id string) (*mDBApp.Sender, error) {
 			&sender.ID,
 			&sender.Address,
 			&sender.PublicKey,
+			&sender.Nonce,
 			&sender.CreatedAt,
 		))
 	if err != nil {
@@ -56,7 +57,7 @@ func (p *pgx) SenderByID(id string) (*mDBApp.Sender, error) {
 
 func (p *pgx) SenderByAddress(address string) (*mDBApp.Sender, error) {
 	const (
-		q = ` SELECT id ,address ,public_key ,created_at
+		q = ` SELECT id ,address ,public_key ,nonce ,created_at
               FROM senders
               WHERE address = $1 `
 	)
@@ -67,6 +68,7 @@ func (p *pgx) SenderByAddress(address string) (*mDBApp.Sender, error) {
 			&sender.ID,
 			&sender.Address,
 			&sender.PublicKey,
+			&sender.Nonce,
 			&sender.CreatedAt,
 		))
 	if err != nil {
@@ -80,7 +82,7 @@ func (p *pgx) SenderByAddress(address string) (*mDBApp.Sender, error) {
 
 func (p *pgx) SenderByPublicKey(publicKey string) (*mDBApp.Sender, error) {
 	const (
-		q = ` SELECT id ,address ,public_key ,created_at
+		q = ` SELECT id ,address ,public_key ,nonce ,created_at
               FROM senders
               WHERE public_key = $1 `
 	)
@@ -91,6 +93,7 @@ func (p *pgx) SenderByPublicKey(publicKey string) (*mDBApp.Sender, error) {
 			&sender.ID,
 			&sender.Address,
 			&sender.PublicKey,
+			&sender.Nonce,
 			&sender.CreatedAt,
 		))
 	if err != nil {
@@ -102,11 +105,27 @@ func (p *pgx) SenderByPublicKey(publicKey string) (*mDBApp.Sender, error) {
 	return &senderDBApp, nil
 }
 
+func (p *pgx) UpdateSenderNonce(id string, nonce uint64) error {
+	const (
+		q = ` UPDATE senders
+              SET nonce = $2
+              WHERE id = $1 AND nonce < $2 `
+	)
+
+	_, err := p.exec(p.ctx, q, id, int64(nonce))
+	if err != nil {
+		return errPgx.Err(err)
+	}
+
+	return nil
+}
+
 func (p *pgx) senderToDBApp(sender *models.Sender) mDBApp.Sender {
 	return mDBApp.Sender{
 		ID:        sender.ID,
 		Address:   sender.Address,
 		PublicKey: sender.PublicKey,
+		Nonce:     uint64(sender.Nonce),
 		CreatedAt: sender.CreatedAt,
 	}
 }
@@ -60,7 +60,7 @@ func (p *pgx) CreateWithdrawal(
 		p.ctx,
 		query,
 		id,
-		mDBApp.WS_PENDING,
+		mDBApp.WS_REQUESTED,
 		jsonData[transferDataKey],
 		jsonData[transferMerkleProofKey],
 		jsonData[transactionKey],
@@ -86,7 +86,7 @@ func (p *pgx) CreateWithdrawal(
 
 func (p *pgx) UpdateWithdrawalsStatus(ids []string, status mDBApp.WithdrawalStatus) error {
 	const (
-		q = ` UPDATE withdrawals SET status = %d WHERE id IN (%s) `
+		q = ` UPDATE withdrawals SET status = %d, updated_at = now() WHERE id IN (%s) `
 
 		maskPlaceholderKey = "$%d"
 		maskJoinKey        = ", "
@@ -111,13 +111,48 @@ func (p *pgx) UpdateWithdrawalsStatus(ids []string, status mDBApp.WithdrawalStat
 	return nil
 }
 
+func (p *pgx) UpdateWithdrawalsProverJob(ids []string, status mDBApp.WithdrawalStatus, proverJobID string) error {
+	const (
+		q = ` UPDATE withdrawals SET status = $1, prover_job_id = $2, updated_at = now() WHERE id = ANY($3) `
+	)
+
+	_, err := p.exec(p.ctx, q, status, proverJobID, ids)
+	if err != nil {
+		return errPgx.Err(err)
+	}
+
+	return nil
+}
+
+// UpdateWithdrawalProof stores the withdrawal proof without changing the status of the withdrawal,
+// which is proved only after the withdrawal proofs of its batch are chained.
+func (p *pgx) UpdateWithdrawalProof(id string, withdrawalProof *mDBApp.WithdrawalProof) error {
+	const (
+		q = ` UPDATE withdrawals SET withdrawal_proof = $1, updated_at = now() WHERE id = $2 `
+	)
+
+	withdrawalProofJSON, err := json.Marshal(withdrawalProof)
+	if err != nil {
+		const msg = "error encoding WithdrawalProof: %w"
+		return fmt.Errorf(msg, err)
+	}
+
+	_, err = p.exec(p.ctx, q, withdrawalProofJSON, id)
+	if err != nil {
+		return errPgx.Err(err)
+	}
+
+	return nil
+}
+
 func (p *pgx) WithdrawalByID(id string) (*mDBApp.Withdrawal, error) {
 	const (
 		q = `
 SELECT
 id ,status ,transfer_data ,transfer_merkle_proof ,transaction
 ,tx_merkle_proof ,enough_balance_proof ,transfer_hash
-,block_number ,block_hash ,created_at
+,block_number ,block_hash ,prover_job_id ,withdrawal_proof
+,created_at ,updated_at
 FROM withdrawals
 WHERE id = $1
 `
@@ -127,6 +162,7 @@ WHERE id = $1
 		tmp models.Withdrawal
 
 		transferDataJSON, transferMerkleProofJSON, transactionJSON, txMerkleProofJSON, enoughBalanceProofJSON []byte
+		withdrawalProofJSON                                                                                   []byte
 	)
 	err := errPgx.Err(p.queryRow(p.ctx, q, id).
 		Scan(
@@ -140,7 +176,10 @@ WHERE id = $1
 			&tmp.TransferHash,
 			&tmp.BlockNumber,
 			&tmp.BlockHash,
+			&tmp.ProverJobID,
+			&withdrawalProofJSON,
 			&tmp.CreatedAt,
+			&tmp.UpdatedAt,
 		))
 	if err != nil {
 		return nil, err
@@ -153,6 +192,7 @@ WHERE id = $1
 		transactionJSON,
 		txMerkleProofJSON,
 		enoughBalanceProofJSON,
+		withdrawalProofJSON,
 	)
 	if err != nil {
 		return nil, err
@@ -165,7 +205,7 @@ WHERE id = $1
 
 func (p *pgx) WithdrawalsByHashes(transferHashes []string) (*[]mDBApp.Withdrawal, error) {
 	const query = `
-        SELECT id, status, transfer_data, transfer_merkle_proof, transaction, tx_merkle_proof, enough_balance_proof, transfer_hash, block_number, block_hash, created_at
+        SELECT id, status, transfer_data, transfer_merkle_proof, transaction, tx_merkle_proof, enough_balance_proof, transfer_hash, block_number, block_hash, prover_job_id, withdrawal_proof, created_at, updated_at
         FROM withdrawals
         WHERE transfer_hash = ANY($1)
         ORDER BY created_at ASC`
@@ -182,18 +222,18 @@ func (p *pgx) WithdrawalsByHashes(transferHashes []string) (*[]mDBApp.Withdrawal
 	var withdrawals []mDBApp.Withdrawal
 	for rows.Next() {
 		var w models.Withdrawal
-		var transferDataJSON, transferMerkleProofJSON, transactionJSON, txMerkleProofJSON, enoughBalanceProofJSON []byte
+		var transferDataJSON, transferMerkleProofJSON, transactionJSON, txMerkleProofJSON, enoughBalanceProofJSON, withdrawalProofJSON []byte
 
 		err = rows.Scan(
 			&w.ID, &w.Status, &transferDataJSON, &transferMerkleProofJSON,
 			&transactionJSON, &txMerkleProofJSON, &enoughBalanceProofJSON,
-			&w.TransferHash, &w.BlockNumber, &w.BlockHash, &w.CreatedAt,
+			&w.TransferHash, &w.BlockNumber, &w.BlockHash, &w.ProverJobID, &withdrawalProofJSON, &w.CreatedAt, &w.UpdatedAt,
 		)
 		if err != nil {
 			return nil, errPgx.Err(err)
 		}
 
-		err = unmarshalWithdrawalData(&w, transferDataJSON, transferMerkleProofJSON, transactionJSON, txMerkleProofJSON, enoughBalanceProofJSON)
+		err = unmarshalWithdrawalData(&w, transferDataJSON, transferMerkleProofJSON, transactionJSON, txMerkleProofJSON, enoughBalanceProofJSON, withdrawalProofJSON)
 		if err != nil {
 			return nil, err
 		}
@@ -210,7 +250,7 @@ func (p *pgx) WithdrawalsByHashes(transferHashes []string) (*[]mDBApp.Withdrawal
 
 func (p *pgx) WithdrawalsByStatus(status mDBApp.WithdrawalStatus, limit *int) (*[]mDBApp.Withdrawal, error) {
 	baseQuery := `
-        SELECT id, status, transfer_data, transfer_merkle_proof, transaction, tx_merkle_proof, enough_balance_proof, transfer_hash, block_number, block_hash, created_at
+        SELECT id, status, transfer_data, transfer_merkle_proof, transaction, tx_merkle_proof, enough_balance_proof, transfer_hash, block_number, block_hash, prover_job_id, withdrawal_proof, created_at, updated_at
         FROM withdrawals
         WHERE status = $1
         ORDER BY created_at ASC`
@@ -235,18 +275,18 @@ func (p *pgx) WithdrawalsByStatus(status mDBApp.WithdrawalStatus, limit *int) (*
 	var withdrawals []mDBApp.Withdrawal
 	for rows.Next() {
 		var w models.Withdrawal
-		var transferDataJSON, transferMerkleProofJSON, transactionJSON, txMerkleProofJSON, enoughBalanceProofJSON []byte
+		var transferDataJSON, transferMerkleProofJSON, transactionJSON, txMerkleProofJSON, enoughBalanceProofJSON, withdrawalProofJSON []byte
 
 		err = rows.Scan(
 			&w.ID, &w.Status, &transferDataJSON, &transferMerkleProofJSON,
 			&transactionJSON, &txMerkleProofJSON, &enoughBalanceProofJSON,
-			&w.TransferHash, &w.BlockNumber, &w.BlockHash, &w.CreatedAt,
+			&w.TransferHash, &w.BlockNumber, &w.BlockHash, &w.ProverJobID, &withdrawalProofJSON, &w.CreatedAt, &w.UpdatedAt,
 		)
 		if err != nil {
 			return nil, errPgx.Err(err)
 		}
 
-		err = unmarshalWithdrawalData(&w, transferDataJSON, transferMerkleProofJSON, transactionJSON, txMerkleProofJSON, enoughBalanceProofJSON)
+		err = unmarshalWithdrawalData(&w, transferDataJSON, transferMerkleProofJSON, transactionJSON, txMerkleProofJSON, enoughBalanceProofJSON, withdrawalProofJSON)
 		if err != nil {
 			return nil, err
 		}
@@ -263,7 +303,8 @@ func (p *pgx) WithdrawalsByStatus(status mDBApp.WithdrawalStatus, limit *int) (*
 
 func (p *pgx) wToDBApp(w *models.Withdrawal) mDBApp.Withdrawal {
 	m := mDBApp.Withdrawal{
-		ID: w.ID,
+		ID:     w.ID,
+		Status: w.Status,
 		TransferData: mDBApp.TransferData{
 			Recipient:  w.TransferData.Recipient,
 			TokenIndex: w.TransferData.TokenIndex,
@@ -290,12 +331,31 @@ func (p *pgx) wToDBApp(w *models.Withdrawal) mDBApp.Withdrawal {
 		BlockNumber:  w.BlockNumber,
 		BlockHash:    w.BlockHash,
 		CreatedAt:    w.CreatedAt,
+		UpdatedAt:    w.UpdatedAt,
+	}
+
+	if w.ProverJobID.Valid {
+		m.ProverJobID = w.ProverJobID.String
+	}
+
+	if w.WithdrawalProof != nil {
+		m.WithdrawalProof = &mDBApp.WithdrawalProof{
+			Proof: w.WithdrawalProof.Proof,
+			Withdrawal: mDBApp.ChainedWithdrawal{
+				Recipient:   w.WithdrawalProof.Withdrawal.Recipient,
+				TokenIndex:  w.WithdrawalProof.Withdrawal.TokenIndex,
+				Amount:      w.WithdrawalProof.Withdrawal.Amount,
+				Nullifier:   w.WithdrawalProof.Withdrawal.Nullifier,
+				BlockHash:   w.WithdrawalProof.Withdrawal.BlockHash,
+				BlockNumber: w.WithdrawalProof.Withdrawal.BlockNumber,
+			},
+		}
 	}
 
 	return m
 }
 
-func unmarshalWithdrawalData(w *models.Withdrawal, transferDataJSON, transferMerkleProofJSON, transactionJSON, txMerkleProofJSON, enoughBalanceProofJSON []byte) error {
+func unmarshalWithdrawalData(w *models.Withdrawal, transferDataJSON, transferMerkleProofJSON, transactionJSON, txMerkleProofJSON, enoughBalanceProofJSON, withdrawalProofJSON []byte) error {
 	var err error
 	if err = json.Unmarshal(transferDataJSON, &w.TransferData); err != nil {
 		return fmt.Errorf("failed to unmarshal TransferData: %w", err)
@@ -312,5 +372,10 @@ func unmarshalWithdrawalData(w *models.Withdrawal, transferDataJSON, transferMer
 	if err = json.Unmarshal(enoughBalanceProofJSON, &w.EnoughBalanceProof); err != nil {
 		return fmt.Errorf("failed to unmarshal EnoughBalanceProof: %w", err)
 	}
+	if withdrawalProofJSON != nil {
+		if err = json.Unmarshal(withdrawalProofJSON, &w.WithdrawalProof); err != nil {
+			return fmt.Errorf("failed to unmarshal WithdrawalProof: %w", err)
+		}
+	}
 	return nil
 }
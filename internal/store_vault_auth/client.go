@@ -0,0 +1,139 @@
+package store_vault_auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"intmax2-node/configs"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	// HeaderKey is the header of the session token of the store vault requests.
+	HeaderKey    = "Authorization"
+	bearerPrefix = "Bearer "
+
+	// sessionRenewBefore is the time before the expiration after which the session token is renewed.
+	sessionRenewBefore = time.Minute
+)
+
+// BearerToken returns the token of the value of the Authorization header.
+func BearerToken(authorization string) (string, error) {
+	if len(authorization) <= len(bearerPrefix) || !strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		return "", ErrAuthorizationRequired
+	}
+
+	return strings.TrimSpace(authorization[len(bearerPrefix):]), nil
+}
+
+var sessions = struct {
+	sync.Mutex
+	list map[string]*Session
+}{list: make(map[string]*Session)}
+
+// Authorization returns the value of the Authorization header for the store vault requests of the signer.
+// The session token is cached until shortly before its expiration.
+func Authorization(ctx context.Context, cfg *configs.Config, signer Signer) (string, error) {
+	key := cfg.API.DataStoreVaultUrl + payloadDelimiter + strings.ToLower(signer.Address())
+
+	sessions.Lock()
+	defer sessions.Unlock()
+
+	if session, ok := sessions.list[key]; ok && time.Now().UTC().Add(sessionRenewBefore).Before(session.ExpiresAt) {
+		return bearerPrefix + session.Token, nil
+	}
+
+	session, err := login(ctx, cfg, signer)
+	if err != nil {
+		return "", err
+	}
+	sessions.list[key] = session
+
+	return bearerPrefix + session.Token, nil
+}
+
+type challengeResponse struct {
+	Success bool       `json:"success"`
+	Data    *Challenge `json:"data"`
+}
+
+type loginResponse struct {
+	Success bool     `json:"success"`
+	Data    *Session `json:"data"`
+}
+
+func login(ctx context.Context, cfg *configs.Config, signer Signer) (*Session, error) {
+	challenge := new(challengeResponse)
+	err := post(ctx, fmt.Sprintf("%s/v1/auth/challenge", cfg.API.DataStoreVaultUrl), map[string]interface{}{
+		"address": signer.Address(),
+	}, challenge)
+	if err != nil {
+		return nil, errors.Join(ErrChallengeFail, err)
+	}
+	if !challenge.Success || challenge.Data == nil {
+		return nil, ErrChallengeFail
+	}
+
+	var signature string
+	signature, err = signer.SignNonce(challenge.Data.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	session := new(loginResponse)
+	err = post(ctx, fmt.Sprintf("%s/v1/auth/login", cfg.API.DataStoreVaultUrl), map[string]interface{}{
+		"address":   signer.Address(),
+		"nonce":     challenge.Data.Nonce,
+		"signature": signature,
+	}, session)
+	if err != nil {
+		return nil, errors.Join(ErrLoginFail, err)
+	}
+	if !session.Success || session.Data == nil {
+		return nil, ErrLoginFail
+	}
+
+	return session.Data, nil
+}
+
+func post(ctx context.Context, apiUrl string, body map[string]interface{}, result interface{}) error {
+	const (
+		contentType = "Content-Type"
+		appJSON     = "application/json"
+		messageKey  = "message"
+	)
+
+	r := resty.New().R()
+	resp, err := r.SetContext(ctx).SetHeaders(map[string]string{
+		contentType: appJSON,
+	}).SetBody(body).Post(apiUrl)
+	if err != nil {
+		const msg = "failed to send the request: %w"
+		return fmt.Errorf(msg, err)
+	}
+
+	if resp == nil {
+		const msg = "send request error occurred"
+		return errors.New(msg)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		const msg = "failed to get response: status %d: %s"
+		return fmt.Errorf(msg, resp.StatusCode(), strings.ToLower(gjson.GetBytes(resp.Body(), messageKey).String()))
+	}
+
+	err = json.Unmarshal(resp.Body(), result)
+	if err != nil {
+		const msg = "failed to unmarshal response: %w"
+		return fmt.Errorf(msg, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package store_vault_auth
+
+import "errors"
+
+// ErrAddressInvalid error: the address must be the INTMAX or Ethereum address.
+var ErrAddressInvalid = errors.New("the address must be the INTMAX or Ethereum address")
+
+// ErrSecretEmpty error: the STORE_VAULT_AUTH_SECRET must not be empty.
+var ErrSecretEmpty = errors.New("the STORE_VAULT_AUTH_SECRET must not be empty")
+
+// ErrRandomFail error: failed to read the random bytes.
+var ErrRandomFail = errors.New("failed to read the random bytes")
+
+// ErrTokenInvalid error: the token is invalid.
+var ErrTokenInvalid = errors.New("the token is invalid")
+
+// ErrTokenExpired error: the token is expired.
+var ErrTokenExpired = errors.New("the token is expired")
+
+// ErrNonceUsed error: the nonce is already used.
+var ErrNonceUsed = errors.New("the nonce is already used")
+
+// ErrNonceAddressMismatch error: the nonce is issued for another address.
+var ErrNonceAddressMismatch = errors.New("the nonce is issued for another address")
+
+// ErrSignatureInvalid error: the signature of the nonce is invalid.
+var ErrSignatureInvalid = errors.New("the signature of the nonce is invalid")
+
+// ErrAuthorizationRequired error: the authorization header with the bearer token is required.
+var ErrAuthorizationRequired = errors.New("the authorization header with the bearer token is required")
+
+// ErrAddressNotAuthorized error: the session is not authorized to read the data of the address.
+var ErrAddressNotAuthorized = errors.New("the session is not authorized to read the data of the address")
+
+// ErrChallengeFail error: failed to get the nonce from the store vault.
+var ErrChallengeFail = errors.New("failed to get the nonce from the store vault")
+
+// ErrLoginFail error: failed to log in to the store vault.
+var ErrLoginFail = errors.New("failed to log in to the store vault")
+
+// ErrSignNonceFail error: failed to sign the nonce.
+var ErrSignNonceFail = errors.New("failed to sign the nonce")
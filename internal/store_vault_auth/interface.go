@@ -0,0 +1,30 @@
+package store_vault_auth
+
+import "time"
+
+//go:generate mockgen -destination=../mocks/mock_store_vault_auth.go -package=mocks -source=interface.go
+
+// Challenge is the nonce to be signed by the owner of the address.
+type Challenge struct {
+	Nonce     string    `json:"nonce"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Session is the token of the owner of the address for reading the store vault.
+type Session struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// StoreVaultAuth issues the nonces and the session tokens of the owners of the addresses.
+// The nonces and the tokens are signed by the secret of the store vault, so only the used nonces
+// are kept in memory until they expire.
+type StoreVaultAuth interface {
+	// Challenge returns the nonce to be signed by the owner of the address.
+	Challenge(address string) (*Challenge, error)
+	// Login verifies the signature of the nonce by the key of the address
+	// and returns the session token of the address. The nonce is used once.
+	Login(address, nonce, signature string) (*Session, error)
+	// Authenticate returns the address of the session token.
+	Authenticate(token string) (address string, err error)
+}
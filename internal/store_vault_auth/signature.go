@@ -0,0 +1,148 @@
+package store_vault_auth
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	intMaxAcc "intmax2-node/internal/accounts"
+	"intmax2-node/internal/finite_field"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	ethAccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iden3/go-iden3-crypto/ffg"
+)
+
+const (
+	messagePrefix       = "INTMAX store vault login:"
+	ethereumAddressSize = 42
+	ethereumRecoveryID  = 64
+	ethereumVOffset     = 27
+)
+
+// NormalizeAddress returns the INTMAX or Ethereum address in the lower case.
+func NormalizeAddress(address string) (string, error) {
+	address = strings.ToLower(strings.TrimSpace(address))
+
+	if isEthereumAddress(address) {
+		return address, nil
+	}
+
+	if _, err := intMaxAcc.NewPublicKeyFromAddressHex(address); err != nil {
+		return "", errors.Join(ErrAddressInvalid, err)
+	}
+
+	return address, nil
+}
+
+func isEthereumAddress(address string) bool {
+	return len(address) == ethereumAddressSize && common.IsHexAddress(address)
+}
+
+// MakeMessage returns the message of the nonce signed by the INTMAX key.
+func MakeMessage(nonce string) []ffg.Element {
+	return finite_field.BytesToFieldElementSlice(crypto.Keccak256([]byte(messagePrefix), []byte(nonce)))
+}
+
+// makeEthereumHash returns the hash of the nonce signed by the Ethereum key
+// as the personal message of the Ethereum wallets.
+func makeEthereumHash(nonce string) []byte {
+	return ethAccounts.TextHash([]byte(messagePrefix + nonce))
+}
+
+// VerifySignature verifies the signature of the nonce by the key of the INTMAX or Ethereum address.
+func VerifySignature(address, nonce, signature string) error {
+	sb, err := hexutil.Decode(signature)
+	if err != nil {
+		return ErrSignatureInvalid
+	}
+
+	if isEthereumAddress(address) {
+		if len(sb) != crypto.SignatureLength {
+			return ErrSignatureInvalid
+		}
+
+		if sb[ethereumRecoveryID] >= ethereumVOffset {
+			sb[ethereumRecoveryID] -= ethereumVOffset
+		}
+
+		var publicKey *ecdsa.PublicKey
+		publicKey, err = crypto.SigToPub(makeEthereumHash(nonce), sb)
+		if err != nil || !strings.EqualFold(crypto.PubkeyToAddress(*publicKey).Hex(), address) {
+			return ErrSignatureInvalid
+		}
+
+		return nil
+	}
+
+	publicKey, err := intMaxAcc.NewPublicKeyFromAddressHex(address)
+	if err != nil {
+		return ErrSignatureInvalid
+	}
+
+	sign := bn254.G2Affine{}
+	err = sign.Unmarshal(sb)
+	if err != nil {
+		return ErrSignatureInvalid
+	}
+
+	err = intMaxAcc.VerifySignature(&sign, publicKey, MakeMessage(nonce))
+	if err != nil {
+		return ErrSignatureInvalid
+	}
+
+	return nil
+}
+
+// Signer signs the nonces of the store vault by the key of the address.
+type Signer interface {
+	Address() string
+	SignNonce(nonce string) (string, error)
+}
+
+type intMaxSigner struct {
+	pk *intMaxAcc.PrivateKey
+}
+
+// NewINTMAXSigner returns the Signer of the INTMAX address of the private key.
+func NewINTMAXSigner(pk *intMaxAcc.PrivateKey) Signer {
+	return &intMaxSigner{pk: pk}
+}
+
+func (s *intMaxSigner) Address() string {
+	return s.pk.ToAddress().String()
+}
+
+func (s *intMaxSigner) SignNonce(nonce string) (string, error) {
+	sign, err := s.pk.Sign(MakeMessage(nonce))
+	if err != nil {
+		return "", errors.Join(ErrSignNonceFail, err)
+	}
+
+	return hexutil.Encode(sign.Marshal()), nil
+}
+
+type ethereumSigner struct {
+	pk *ecdsa.PrivateKey
+}
+
+// NewEthereumSigner returns the Signer of the Ethereum address of the private key.
+func NewEthereumSigner(pk *ecdsa.PrivateKey) Signer {
+	return &ethereumSigner{pk: pk}
+}
+
+func (s *ethereumSigner) Address() string {
+	return crypto.PubkeyToAddress(s.pk.PublicKey).Hex()
+}
+
+func (s *ethereumSigner) SignNonce(nonce string) (string, error) {
+	sign, err := crypto.Sign(makeEthereumHash(nonce), s.pk)
+	if err != nil {
+		return "", errors.Join(ErrSignNonceFail, err)
+	}
+	sign[ethereumRecoveryID] += ethereumVOffset
+
+	return hexutil.Encode(sign), nil
+}
@@ -0,0 +1,211 @@
+package store_vault_auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"intmax2-node/configs"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	kindChallenge    = "challenge"
+	kindSession      = "session"
+	secretSize       = 32
+	randomSize       = 16
+	tokenDelimiter   = "."
+	payloadDelimiter = ":"
+	payloadFields    = 4
+)
+
+type storeVaultAuth struct {
+	cfg    *configs.Config
+	secret []byte
+
+	// usedNonces keeps the nonces of the logins until they expire, so the signed nonce
+	// cannot be replayed to the same instance of the store vault.
+	mu         sync.Mutex
+	usedNonces map[string]time.Time
+}
+
+// New returns the StoreVaultAuth with the STORE_VAULT_AUTH_SECRET.
+// The empty STORE_VAULT_AUTH_SECRET is rejected, unless the STORE_VAULT_AUTH_DEV_MODE
+// allows the random secret.
+func New(cfg *configs.Config) (StoreVaultAuth, error) {
+	secret := []byte(cfg.StoreVaultAuth.Secret)
+	if len(secret) == 0 {
+		if !cfg.StoreVaultAuth.DevMode {
+			return nil, ErrSecretEmpty
+		}
+
+		secret = make([]byte, secretSize)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, errors.Join(ErrRandomFail, err)
+		}
+	}
+
+	return &storeVaultAuth{
+		cfg:        cfg,
+		secret:     secret,
+		usedNonces: make(map[string]time.Time),
+	}, nil
+}
+
+func (a *storeVaultAuth) Challenge(address string) (*Challenge, error) {
+	address, err := NormalizeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, expiresAt, err := a.issue(kindChallenge, address, a.cfg.StoreVaultAuth.ChallengeTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Challenge{
+		Nonce:     nonce,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (a *storeVaultAuth) Login(address, nonce, signature string) (*Session, error) {
+	address, err := NormalizeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		nonceAddress   string
+		nonceExpiresAt time.Time
+	)
+	nonceAddress, nonceExpiresAt, err = a.parse(kindChallenge, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	if nonceAddress != address {
+		return nil, ErrNonceAddressMismatch
+	}
+
+	err = VerifySignature(address, nonce, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.useNonce(nonce, nonceExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		token     string
+		expiresAt time.Time
+	)
+	token, expiresAt, err = a.issue(kindSession, address, a.cfg.StoreVaultAuth.SessionTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Session{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (a *storeVaultAuth) Authenticate(token string) (address string, err error) {
+	address, _, err = a.parse(kindSession, token)
+	return address, err
+}
+
+// useNonce marks the nonce as used and forgets the used nonces which are expired.
+func (a *storeVaultAuth) useNonce(nonce string, expiresAt time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now().UTC()
+	for key := range a.usedNonces {
+		if !now.Before(a.usedNonces[key]) {
+			delete(a.usedNonces, key)
+		}
+	}
+
+	if _, ok := a.usedNonces[nonce]; ok {
+		return ErrNonceUsed
+	}
+	a.usedNonces[nonce] = expiresAt
+
+	return nil
+}
+
+// issue returns the token as the payload "kind:address:expiration:random" and its HMAC-SHA256
+// by the secret, both in the base64url encoding.
+func (a *storeVaultAuth) issue(kind, address string, ttl time.Duration) (token string, expiresAt time.Time, err error) {
+	random := make([]byte, randomSize)
+	if _, err = rand.Read(random); err != nil {
+		return "", time.Time{}, errors.Join(ErrRandomFail, err)
+	}
+
+	expiresAt = time.Unix(time.Now().UTC().Add(ttl).Unix(), 0).UTC()
+
+	payload := []byte(strings.Join([]string{
+		kind,
+		address,
+		strconv.FormatInt(expiresAt.Unix(), 10),
+		hex.EncodeToString(random),
+	}, payloadDelimiter))
+
+	token = base64.RawURLEncoding.EncodeToString(payload) +
+		tokenDelimiter +
+		base64.RawURLEncoding.EncodeToString(a.sum(payload))
+
+	return token, expiresAt, nil
+}
+
+// parse verifies the token of the kind and returns its address and expiration.
+func (a *storeVaultAuth) parse(kind, token string) (address string, expiresAt time.Time, err error) {
+	parts := strings.Split(token, tokenDelimiter)
+	if len(parts) != 2 {
+		return "", time.Time{}, ErrTokenInvalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", time.Time{}, ErrTokenInvalid
+	}
+
+	var mac []byte
+	mac, err = base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(mac, a.sum(payload)) {
+		return "", time.Time{}, ErrTokenInvalid
+	}
+
+	fields := strings.Split(string(payload), payloadDelimiter)
+	if len(fields) != payloadFields || fields[0] != kind {
+		return "", time.Time{}, ErrTokenInvalid
+	}
+
+	var expiration int64
+	expiration, err = strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return "", time.Time{}, ErrTokenInvalid
+	}
+
+	if time.Now().UTC().Unix() >= expiration {
+		return "", time.Time{}, ErrTokenExpired
+	}
+
+	return fields[1], time.Unix(expiration, 0).UTC(), nil
+}
+
+func (a *storeVaultAuth) sum(payload []byte) []byte {
+	h := hmac.New(sha256.New, a.secret)
+	_, _ = h.Write(payload)
+
+	return h.Sum(nil)
+}
@@ -0,0 +1,184 @@
+package store_vault_auth_test
+
+import (
+	"intmax2-node/configs"
+	intMaxAcc "intmax2-node/internal/accounts"
+	"intmax2-node/internal/store_vault_auth"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreVaultAuth(t *testing.T) {
+	ethKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	intMaxKey, err := intMaxAcc.NewINTMAXAccountFromECDSAKey(ethKey)
+	require.NoError(t, err)
+
+	otherEthKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherIntMaxKey, err := intMaxAcc.NewINTMAXAccountFromECDSAKey(otherEthKey)
+	require.NoError(t, err)
+
+	cfg := &configs.Config{
+		StoreVaultAuth: configs.StoreVaultAuth{
+			DevMode:      true,
+			ChallengeTTL: time.Minute,
+			SessionTTL:   time.Hour,
+		},
+	}
+
+	auth, err := store_vault_auth.New(cfg)
+	require.NoError(t, err)
+
+	cases := []struct {
+		desc     string
+		signer   store_vault_auth.Signer
+		address  string
+		loginErr error
+	}{
+		{
+			desc:    "login by the INTMAX key",
+			signer:  store_vault_auth.NewINTMAXSigner(intMaxKey),
+			address: intMaxKey.ToAddress().String(),
+		},
+		{
+			desc:    "login by the Ethereum key",
+			signer:  store_vault_auth.NewEthereumSigner(ethKey),
+			address: crypto.PubkeyToAddress(ethKey.PublicKey).Hex(),
+		},
+		{
+			desc:     "the nonce is signed by another INTMAX key",
+			signer:   store_vault_auth.NewINTMAXSigner(otherIntMaxKey),
+			address:  intMaxKey.ToAddress().String(),
+			loginErr: store_vault_auth.ErrSignatureInvalid,
+		},
+		{
+			desc:     "the nonce is signed by another Ethereum key",
+			signer:   store_vault_auth.NewEthereumSigner(otherEthKey),
+			address:  crypto.PubkeyToAddress(ethKey.PublicKey).Hex(),
+			loginErr: store_vault_auth.ErrSignatureInvalid,
+		},
+	}
+
+	for i := range cases {
+		t.Run(cases[i].desc, func(t *testing.T) {
+			challenge, err := auth.Challenge(cases[i].address)
+			require.NoError(t, err)
+
+			signature, err := cases[i].signer.SignNonce(challenge.Nonce)
+			require.NoError(t, err)
+
+			session, err := auth.Login(cases[i].address, challenge.Nonce, signature)
+			if cases[i].loginErr != nil {
+				assert.ErrorIs(t, err, cases[i].loginErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, session.ExpiresAt.After(challenge.ExpiresAt))
+
+			address, err := auth.Authenticate(session.Token)
+			require.NoError(t, err)
+			assert.Equal(t, strings.ToLower(cases[i].address), address)
+
+			_, err = auth.Authenticate(challenge.Nonce)
+			assert.ErrorIs(t, err, store_vault_auth.ErrTokenInvalid)
+
+			_, err = auth.Login(cases[i].address, session.Token, signature)
+			assert.ErrorIs(t, err, store_vault_auth.ErrTokenInvalid)
+
+			// The signed nonce cannot be replayed.
+			_, err = auth.Login(cases[i].address, challenge.Nonce, signature)
+			assert.ErrorIs(t, err, store_vault_auth.ErrNonceUsed)
+		})
+	}
+
+	t.Run("the nonce with the invalid signature is not used up", func(t *testing.T) {
+		challenge, err := auth.Challenge(intMaxKey.ToAddress().String())
+		require.NoError(t, err)
+
+		signature, err := store_vault_auth.NewINTMAXSigner(otherIntMaxKey).SignNonce(challenge.Nonce)
+		require.NoError(t, err)
+		_, err = auth.Login(intMaxKey.ToAddress().String(), challenge.Nonce, signature)
+		assert.ErrorIs(t, err, store_vault_auth.ErrSignatureInvalid)
+
+		signature, err = store_vault_auth.NewINTMAXSigner(intMaxKey).SignNonce(challenge.Nonce)
+		require.NoError(t, err)
+		_, err = auth.Login(intMaxKey.ToAddress().String(), challenge.Nonce, signature)
+		assert.NoError(t, err)
+	})
+
+	t.Run("the secret is required out of the dev mode", func(t *testing.T) {
+		_, err := store_vault_auth.New(&configs.Config{})
+		assert.ErrorIs(t, err, store_vault_auth.ErrSecretEmpty)
+	})
+
+	t.Run("the nonce is issued for another address", func(t *testing.T) {
+		challenge, err := auth.Challenge(otherIntMaxKey.ToAddress().String())
+		require.NoError(t, err)
+
+		signature, err := store_vault_auth.NewINTMAXSigner(intMaxKey).SignNonce(challenge.Nonce)
+		require.NoError(t, err)
+
+		_, err = auth.Login(intMaxKey.ToAddress().String(), challenge.Nonce, signature)
+		assert.ErrorIs(t, err, store_vault_auth.ErrNonceAddressMismatch)
+	})
+
+	t.Run("the token is signed by another secret", func(t *testing.T) {
+		other, err := store_vault_auth.New(cfg)
+		require.NoError(t, err)
+
+		challenge, err := other.Challenge(intMaxKey.ToAddress().String())
+		require.NoError(t, err)
+
+		signature, err := store_vault_auth.NewINTMAXSigner(intMaxKey).SignNonce(challenge.Nonce)
+		require.NoError(t, err)
+
+		_, err = auth.Login(intMaxKey.ToAddress().String(), challenge.Nonce, signature)
+		assert.ErrorIs(t, err, store_vault_auth.ErrTokenInvalid)
+	})
+
+	t.Run("the nonce is expired", func(t *testing.T) {
+		expired, err := store_vault_auth.New(&configs.Config{
+			StoreVaultAuth: configs.StoreVaultAuth{
+				Secret:       "secret",
+				ChallengeTTL: -time.Second,
+			},
+		})
+		require.NoError(t, err)
+
+		challenge, err := expired.Challenge(intMaxKey.ToAddress().String())
+		require.NoError(t, err)
+
+		signature, err := store_vault_auth.NewINTMAXSigner(intMaxKey).SignNonce(challenge.Nonce)
+		require.NoError(t, err)
+
+		_, err = expired.Login(intMaxKey.ToAddress().String(), challenge.Nonce, signature)
+		assert.ErrorIs(t, err, store_vault_auth.ErrTokenExpired)
+	})
+
+	t.Run("the address is invalid", func(t *testing.T) {
+		_, err := auth.Challenge("0x1234")
+		assert.ErrorIs(t, err, store_vault_auth.ErrAddressInvalid)
+	})
+}
+
+func TestBearerToken(t *testing.T) {
+	token, err := store_vault_auth.BearerToken("Bearer abc.def")
+	require.NoError(t, err)
+	assert.Equal(t, "abc.def", token)
+
+	token, err = store_vault_auth.BearerToken("bearer abc.def")
+	require.NoError(t, err)
+	assert.Equal(t, "abc.def", token)
+
+	_, err = store_vault_auth.BearerToken("abc.def")
+	assert.ErrorIs(t, err, store_vault_auth.ErrAuthorizationRequired)
+
+	_, err = store_vault_auth.BearerToken("")
+	assert.ErrorIs(t, err, store_vault_auth.ErrAuthorizationRequired)
+}
@@ -3,6 +3,7 @@ package store_vault_service
 import (
 	"context"
 	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	"time"
 )
 
 //go:generate mockgen -destination=mock_db_app_test.go -package=store_vault_service_test -source=db_app.go
@@ -29,6 +30,11 @@ type BackupTransfers interface {
 		recipient, transferDoubleHash string,
 	) (*mDBApp.BackupTransfer, error)
 	GetBackupTransfers(condition string, value interface{}) ([]*mDBApp.BackupTransfer, error)
+	GetBackupTransfersByRecipientFromSeq(
+		recipient string,
+		startSeq, limit uint64,
+		createdBefore time.Time,
+	) ([]*mDBApp.BackupTransfer, error)
 }
 
 type BackupTransactions interface {
@@ -39,6 +45,11 @@ type BackupTransactions interface {
 	GetBackupTransaction(condition string, value string) (*mDBApp.BackupTransaction, error)
 	GetBackupTransactionBySenderAndTxDoubleHash(sender, txDoubleHash string) (*mDBApp.BackupTransaction, error)
 	GetBackupTransactions(condition string, value interface{}) ([]*mDBApp.BackupTransaction, error)
+	GetBackupTransactionsBySenderFromSeq(
+		sender string,
+		startSeq, limit uint64,
+		createdBefore time.Time,
+	) ([]*mDBApp.BackupTransaction, error)
 }
 
 type BackupDeposits interface {
@@ -51,6 +62,11 @@ type BackupDeposits interface {
 	) (*mDBApp.BackupDeposit, error)
 	GetBackupDeposit(conditions []string, values []interface{}) (*mDBApp.BackupDeposit, error)
 	GetBackupDeposits(condition string, value interface{}) ([]*mDBApp.BackupDeposit, error)
+	GetBackupDepositsByRecipientFromSeq(
+		recipient string,
+		startSeq, limit uint64,
+		createdBefore time.Time,
+	) ([]*mDBApp.BackupDeposit, error)
 }
 
 type BackupBalances interface {
@@ -8,6 +8,8 @@ import (
 	getBackupDepositByHash "intmax2-node/internal/use_cases/get_backup_deposit_by_hash"
 	backupDeposit "intmax2-node/internal/use_cases/get_backup_deposits"
 	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	"sort"
+	"time"
 )
 
 func GetBackupDeposits(
@@ -17,11 +19,35 @@ func GetBackupDeposits(
 	db SQLDriverApp,
 	input *backupDeposit.UCGetBackupDepositsInput,
 ) ([]*mDBApp.BackupDeposit, error) {
+	if input.StartSeq > 0 {
+		// the backups are paged by the sequence numbers in the db,
+		// the ones created within the commit lag are left for the next page
+		deposits, err := db.GetBackupDepositsByRecipientFromSeq(
+			input.Sender, input.StartSeq, input.Limit,
+			time.Now().UTC().Add(-cfg.StoreVaultBalances.CommitLag),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get backup deposits from db: %w", err)
+		}
+
+		return deposits, nil
+	}
+
 	deposits, err := db.GetBackupDeposits("recipient", input.Sender)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get backup deposits from db: %w", err)
 	}
-	return deposits, nil
+
+	sort.SliceStable(deposits, func(i, j int) bool {
+		return deposits[i].BlockNumber < deposits[j].BlockNumber
+	})
+	blockNumbers := make([]uint64, len(deposits))
+	for key := range deposits {
+		blockNumbers[key] = uint64(deposits[key].BlockNumber)
+	}
+	from, to := sortedRange(blockNumbers, input.StartBlockNumber, input.Limit)
+
+	return deposits[from:to], nil
 }
 
 func GetBackupDepositByHash(
@@ -8,6 +8,8 @@ import (
 	getBackupTransactionByHash "intmax2-node/internal/use_cases/get_backup_transaction_by_hash"
 	getBackupTransactions "intmax2-node/internal/use_cases/get_backup_transactions"
 	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	"sort"
+	"time"
 )
 
 func GetBackupTransactions(
@@ -17,11 +19,35 @@ func GetBackupTransactions(
 	db SQLDriverApp,
 	input *getBackupTransactions.UCGetBackupTransactionsInput,
 ) ([]*mDBApp.BackupTransaction, error) {
+	if input.StartSeq > 0 {
+		// the backups are paged by the sequence numbers in the db,
+		// the ones created within the commit lag are left for the next page
+		transactions, err := db.GetBackupTransactionsBySenderFromSeq(
+			input.Sender, input.StartSeq, input.Limit,
+			time.Now().UTC().Add(-cfg.StoreVaultBalances.CommitLag),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get backup transactions from db: %w", err)
+		}
+
+		return transactions, nil
+	}
+
 	transactions, err := db.GetBackupTransactions("sender", input.Sender)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get backup transactions from db: %w", err)
 	}
-	return transactions, nil
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].BlockNumber < transactions[j].BlockNumber
+	})
+	blockNumbers := make([]uint64, len(transactions))
+	for key := range transactions {
+		blockNumbers[key] = uint64(transactions[key].BlockNumber)
+	}
+	from, to := sortedRange(blockNumbers, input.StartBlockNumber, input.Limit)
+
+	return transactions[from:to], nil
 }
 
 func GetBackupTransactionByHash(
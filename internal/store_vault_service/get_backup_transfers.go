@@ -8,6 +8,8 @@ import (
 	getBackupTransferByHash "intmax2-node/internal/use_cases/get_backup_transfer_by_hash"
 	getBackupTransfers "intmax2-node/internal/use_cases/get_backup_transfers"
 	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	"sort"
+	"time"
 )
 
 func GetBackupTransfers(
@@ -17,12 +19,35 @@ func GetBackupTransfers(
 	db SQLDriverApp,
 	input *getBackupTransfers.UCGetBackupTransfersInput,
 ) ([]*mDBApp.BackupTransfer, error) {
+	if input.StartSeq > 0 {
+		// the backups are paged by the sequence numbers in the db,
+		// the ones created within the commit lag are left for the next page
+		transfers, err := db.GetBackupTransfersByRecipientFromSeq(
+			input.Sender, input.StartSeq, input.Limit,
+			time.Now().UTC().Add(-cfg.StoreVaultBalances.CommitLag),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get backup transfers from db: %w", err)
+		}
+
+		return transfers, nil
+	}
+
 	transfers, err := db.GetBackupTransfers("recipient", input.Sender)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get backup transfers from db: %w", err)
 	}
-	fmt.Println("transfers", transfers)
-	return transfers, nil
+
+	sort.SliceStable(transfers, func(i, j int) bool {
+		return transfers[i].BlockNumber < transfers[j].BlockNumber
+	})
+	blockNumbers := make([]uint64, len(transfers))
+	for key := range transfers {
+		blockNumbers[key] = transfers[key].BlockNumber
+	}
+	from, to := sortedRange(blockNumbers, input.StartBlockNumber, input.Limit)
+
+	return transfers[from:to], nil
 }
 
 func GetBackupTransferByHash(
@@ -0,0 +1,50 @@
+package store_vault_service_test
+
+import (
+	"context"
+	"intmax2-node/configs"
+	service "intmax2-node/internal/store_vault_service"
+	getBackupTransfers "intmax2-node/internal/use_cases/get_backup_transfers"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetBackupTransfersBySeq(t *testing.T) {
+	const (
+		recipient = "recipient"
+		commitLag = time.Minute
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := NewMockSQLDriverApp(ctrl)
+
+	cfg := &configs.Config{}
+	cfg.StoreVaultBalances.CommitLag = commitLag
+
+	transfers := []*mDBApp.BackupTransfer{{Seq: 5}, {Seq: 7}}
+
+	// the backups created within the commit lag are left for the next page
+	before := time.Now().UTC().Add(-commitLag)
+	db.EXPECT().GetBackupTransfersByRecipientFromSeq(recipient, uint64(5), uint64(2), gomock.Any()).DoAndReturn(
+		func(_ string, _, _ uint64, createdBefore time.Time) ([]*mDBApp.BackupTransfer, error) {
+			assert.False(t, createdBefore.Before(before))
+			assert.False(t, createdBefore.After(time.Now().UTC().Add(-commitLag)))
+			return transfers, nil
+		},
+	)
+
+	res, err := service.GetBackupTransfers(context.Background(), cfg, nil, db, &getBackupTransfers.UCGetBackupTransfersInput{
+		Sender:   recipient,
+		StartSeq: 5,
+		Limit:    2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, transfers, res)
+}
@@ -6,8 +6,22 @@ import (
 	"intmax2-node/configs"
 	"intmax2-node/internal/logger"
 	backupBalance "intmax2-node/internal/use_cases/backup_balance"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
 )
 
+const (
+	int10Key = 10
+	int64Key = 64
+)
+
+// GetBalances returns the page of the backups of the address starting from the sequence number
+// of the cursor. The backups of the blocks before the since block are skipped. The backups of the deposits,
+// the transfers and the transactions are fetched concurrently, so the db must not be a transaction of the DB App.
+// The backups created within the commit lag are left for the next pages, which start from the next cursor.
 func GetBalances(
 	ctx context.Context,
 	cfg *configs.Config,
@@ -15,54 +29,175 @@ func GetBalances(
 	db SQLDriverApp,
 	input *backupBalance.UCGetBalancesInput,
 ) (*backupBalance.UCGetBalances, error) {
-	// TODO: get these data concurrently
-	deposits, err := db.GetBackupDeposits("recipient", input.Address)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create get backup depsits from db: %w", err)
+	var startSeq uint64
+	if input.Cursor != "" {
+		cursor, err := strconv.ParseUint(input.Cursor, int10Key, int64Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse cursor: %w", err)
+		}
+		startSeq = cursor
 	}
 
-	transactions, err := db.GetBackupTransactions("sender", input.Address)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create get backup transactions from db: %w", err)
+	limit := cfg.StoreVaultBalances.PageLimit
+	if input.Limit > 0 && (limit == 0 || input.Limit < limit) {
+		limit = input.Limit
 	}
 
-	transfers, err := db.GetBackupTransfers("recipient", input.Address)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create get backup transfers from db: %w", err)
+	createdBefore := time.Now().UTC().Add(-cfg.StoreVaultBalances.CommitLag)
+
+	var (
+		wg                                         sync.WaitGroup
+		deposits                                   []*mDBApp.BackupDeposit
+		transfers                                  []*mDBApp.BackupTransfer
+		transactions                               []*mDBApp.BackupTransaction
+		depositsErr, transfersErr, transactionsErr error
+	)
+
+	const numOfKinds = 3
+	wg.Add(numOfKinds)
+	go func() {
+		defer wg.Done()
+		deposits, depositsErr = db.GetBackupDepositsByRecipientFromSeq(input.Address, startSeq, limit, createdBefore)
+	}()
+	go func() {
+		defer wg.Done()
+		transfers, transfersErr = db.GetBackupTransfersByRecipientFromSeq(input.Address, startSeq, limit, createdBefore)
+	}()
+	go func() {
+		defer wg.Done()
+		transactions, transactionsErr = db.GetBackupTransactionsBySenderFromSeq(input.Address, startSeq, limit, createdBefore)
+	}()
+	wg.Wait()
+
+	if depositsErr != nil {
+		return nil, fmt.Errorf("failed to get backup deposits from db: %w", depositsErr)
+	}
+	if transfersErr != nil {
+		return nil, fmt.Errorf("failed to get backup transfers from db: %w", transfersErr)
+	}
+	if transactionsErr != nil {
+		return nil, fmt.Errorf("failed to get backup transactions from db: %w", transactionsErr)
 	}
 
-	resDeposits := make([]*backupBalance.BackupDeposit, len(deposits))
-	for i, deposit := range deposits {
-		resDeposits[i] = &backupBalance.BackupDeposit{
+	depositSeqs := make([]uint64, len(deposits))
+	for key := range deposits {
+		depositSeqs[key] = uint64(deposits[key].Seq)
+	}
+	transferSeqs := make([]uint64, len(transfers))
+	for key := range transfers {
+		transferSeqs[key] = uint64(transfers[key].Seq)
+	}
+	transactionSeqs := make([]uint64, len(transactions))
+	for key := range transactions {
+		transactionSeqs[key] = uint64(transactions[key].Seq)
+	}
+
+	lastSeq, hasMore := balancesPageEnd(limit, depositSeqs, transferSeqs, transactionSeqs)
+
+	var numOfBackups int
+	resDeposits := make([]*backupBalance.BackupDeposit, 0, len(deposits))
+	for _, deposit := range deposits {
+		if uint64(deposit.Seq) > lastSeq {
+			break
+		}
+		numOfBackups++
+		if uint64(deposit.BlockNumber) < input.SinceBlock {
+			continue
+		}
+		resDeposits = append(resDeposits, &backupBalance.BackupDeposit{
 			Recipient:        deposit.Recipient,
 			EncryptedDeposit: deposit.EncryptedDeposit,
 			BlockNumber:      uint64(deposit.BlockNumber),
 			CreatedAt:        deposit.CreatedAt,
-		}
+		})
 	}
 
-	resTransfers := make([]*backupBalance.BackupTransfer, len(transfers))
-	for i, transfer := range transfers {
-		resTransfers[i] = &backupBalance.BackupTransfer{
+	resTransfers := make([]*backupBalance.BackupTransfer, 0, len(transfers))
+	for _, transfer := range transfers {
+		if uint64(transfer.Seq) > lastSeq {
+			break
+		}
+		numOfBackups++
+		if transfer.BlockNumber < input.SinceBlock {
+			continue
+		}
+		resTransfers = append(resTransfers, &backupBalance.BackupTransfer{
 			EncryptedTransfer: transfer.EncryptedTransfer,
 			Recipient:         transfer.Recipient,
 			BlockNumber:       transfer.BlockNumber,
 			CreatedAt:         transfer.CreatedAt,
-		}
+		})
 	}
-	resTransactions := make([]*backupBalance.BackupTransaction, len(transactions))
-	for i, transaction := range transactions {
-		resTransactions[i] = &backupBalance.BackupTransaction{
+
+	resTransactions := make([]*backupBalance.BackupTransaction, 0, len(transactions))
+	for _, transaction := range transactions {
+		if uint64(transaction.Seq) > lastSeq {
+			break
+		}
+		numOfBackups++
+		if uint64(transaction.BlockNumber) < input.SinceBlock {
+			continue
+		}
+		resTransactions = append(resTransactions, &backupBalance.BackupTransaction{
 			Sender:      transaction.Sender,
 			EncryptedTx: transaction.EncryptedTx,
 			BlockNumber: uint64(transaction.BlockNumber),
 			CreatedAt:   transaction.CreatedAt,
-		}
+		})
+	}
+
+	nextCursor := startSeq
+	if numOfBackups > 0 {
+		nextCursor = lastSeq + 1
 	}
 
 	return &backupBalance.UCGetBalances{
 		Deposits:     resDeposits,
 		Transactions: resTransactions,
 		Transfers:    resTransfers,
+		Meta: &backupBalance.UCGetBalancesMeta{
+			HasMore:    hasMore,
+			NextCursor: strconv.FormatUint(nextCursor, int10Key),
+		},
 	}, nil
 }
+
+// balancesPageEnd returns the last sequence number of the page of the backups of all kinds
+// from their ascending sequence numbers fetched up to the limit (zero is unlimited) each.
+// The kinds, that reached the limit, may have the backups after their last sequence numbers,
+// so the page ends no later than the least of them and holds up to the limit of the backups.
+// The kinds have their own sequences, so the backups of the different kinds with the same
+// sequence number are kept in the same page (at least one sequence number is in the page).
+func balancesPageEnd(limit uint64, kinds ...[]uint64) (lastSeq uint64, hasMore bool) {
+	const maxUint64 = ^uint64(0)
+
+	bound := maxUint64
+	for _, seqs := range kinds {
+		if limit > 0 && uint64(len(seqs)) >= limit {
+			hasMore = true
+			if last := seqs[len(seqs)-1]; last < bound {
+				bound = last
+			}
+		}
+	}
+
+	var all []uint64
+	for _, seqs := range kinds {
+		for key := range seqs {
+			if seqs[key] <= bound {
+				all = append(all, seqs[key])
+			}
+		}
+	}
+	if len(all) == 0 {
+		return 0, hasMore
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+
+	_, to := sortedRange(all, 0, limit)
+	if to < len(all) {
+		hasMore = true
+	}
+
+	return all[to-1], hasMore
+}
@@ -0,0 +1,76 @@
+package store_vault_service_test
+
+import (
+	"context"
+	"intmax2-node/configs"
+	service "intmax2-node/internal/store_vault_service"
+	backupBalance "intmax2-node/internal/use_cases/backup_balance"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetBalances(t *testing.T) {
+	const (
+		address   = "address"
+		commitLag = time.Minute
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := NewMockSQLDriverApp(ctrl)
+
+	cfg := &configs.Config{}
+	cfg.StoreVaultBalances.PageLimit = 3
+	cfg.StoreVaultBalances.CommitLag = commitLag
+
+	// the backups created within the commit lag are left for the next pages
+	createdBefore := gomock.Cond(func(x any) bool {
+		createdBefore, ok := x.(time.Time)
+		return ok && !createdBefore.After(time.Now().UTC().Add(-commitLag))
+	})
+
+	// the deposits reach the limit, so the page ends no later than the sequence number 4
+	db.EXPECT().GetBackupDepositsByRecipientFromSeq(address, uint64(2), uint64(3), createdBefore).Return(
+		[]*mDBApp.BackupDeposit{{Seq: 2, BlockNumber: 1}, {Seq: 3, BlockNumber: 2}, {Seq: 4, BlockNumber: 3}}, nil,
+	)
+	db.EXPECT().GetBackupTransfersByRecipientFromSeq(address, uint64(2), uint64(3), createdBefore).Return(
+		[]*mDBApp.BackupTransfer{{Seq: 2, BlockNumber: 0}, {Seq: 5, BlockNumber: 3}}, nil,
+	)
+	db.EXPECT().GetBackupTransactionsBySenderFromSeq(address, uint64(2), uint64(3), createdBefore).Return(
+		[]*mDBApp.BackupTransaction{{Seq: 3, BlockNumber: 2}}, nil,
+	)
+
+	result, err := service.GetBalances(context.Background(), cfg, nil, db, &backupBalance.UCGetBalancesInput{
+		Address:    address,
+		SinceBlock: 1,
+		Cursor:     "2",
+		Limit:      10,
+	})
+	require.NoError(t, err)
+
+	// the sequence numbers 2 and 3 hold 4 backups, so the page ends at the sequence number 3,
+	// the transfer of the block before the since block is skipped
+	assert.Len(t, result.Deposits, 2)
+	assert.Empty(t, result.Transfers)
+	assert.Len(t, result.Transactions, 1)
+	assert.Equal(t, &backupBalance.UCGetBalancesMeta{HasMore: true, NextCursor: "4"}, result.Meta)
+
+	db.EXPECT().GetBackupDepositsByRecipientFromSeq(address, uint64(0), uint64(2), createdBefore).Return(nil, nil)
+	db.EXPECT().GetBackupTransfersByRecipientFromSeq(address, uint64(0), uint64(2), createdBefore).Return(nil, nil)
+	db.EXPECT().GetBackupTransactionsBySenderFromSeq(address, uint64(0), uint64(2), createdBefore).Return(nil, nil)
+
+	result, err = service.GetBalances(context.Background(), cfg, nil, db, &backupBalance.UCGetBalancesInput{
+		Address:    address,
+		SinceBlock: 6,
+		Limit:      2,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Deposits)
+	assert.Equal(t, &backupBalance.UCGetBalancesMeta{HasMore: false, NextCursor: "0"}, result.Meta)
+}
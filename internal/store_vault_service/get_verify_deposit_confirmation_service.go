@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"intmax2-node/configs"
 	"intmax2-node/internal/bindings"
+	"intmax2-node/internal/log_scanner"
 	"intmax2-node/internal/logger"
 	verifyDepositConfirmation "intmax2-node/internal/use_cases/verify_deposit_confirmation"
 	"intmax2-node/pkg/utils"
@@ -140,22 +141,33 @@ func (v *VerifyDepositConfirmationService) checkDepositDataExists(depositId *big
 
 func (v *VerifyDepositConfirmationService) checkIfDepositCanceled(depositId *big.Int) (bool, error) {
 	depositIds := []*big.Int{depositId}
-	iterator, err := v.liquidity.FilterDepositCanceled(&bind.FilterOpts{
-		Start: 0,
-		End:   nil,
-	}, depositIds)
-	if err != nil {
-		return false, fmt.Errorf("failed to filter logs: %v", err)
-	}
-
-	defer iterator.Close()
 
 	isCanceled := false
-	for iterator.Next() {
-		if iterator.Error() != nil {
-			return false, fmt.Errorf("error encountered while iterating: %v", iterator.Error())
-		}
-		isCanceled = true
+	err := log_scanner.FilterByRanges(
+		v.ctx, v.client, v.cfg.Blockchain.LiquidityContractDeployedBlockNumber, v.cfg.LogScanner.MaxBlockRange,
+		func(opts *bind.FilterOpts) (bool, error) {
+			iterator, err := v.liquidity.FilterDepositCanceled(opts, depositIds)
+			if err != nil {
+				return false, fmt.Errorf("failed to filter logs: %w", err)
+			}
+
+			defer func() {
+				_ = iterator.Close()
+			}()
+
+			for iterator.Next() {
+				isCanceled = true
+			}
+
+			if err = iterator.Error(); err != nil {
+				return false, fmt.Errorf("error encountered while iterating: %w", err)
+			}
+
+			return isCanceled, nil
+		},
+	)
+	if err != nil {
+		return false, err
 	}
 
 	return isCanceled, nil
@@ -0,0 +1,25 @@
+package store_vault_service
+
+import "sort"
+
+// sortedRange returns the range [from, to) of the ascending values (the block numbers or the sequence numbers)
+// that starts from the start value and holds up to the limit of them (zero is unlimited).
+// The range is extended over the values equal to its last one, so that the next range
+// starts from the next value.
+func sortedRange(values []uint64, start, limit uint64) (from, to int) {
+	from = sort.Search(len(values), func(i int) bool {
+		return values[i] >= start
+	})
+
+	to = len(values)
+	if limit == 0 || uint64(to-from) <= limit {
+		return from, to
+	}
+
+	to = from + int(limit)
+	for to < len(values) && values[to] == values[to-1] {
+		to++
+	}
+
+	return from, to
+}
@@ -0,0 +1,212 @@
+package tree
+
+import (
+	"intmax2-node/internal/finite_field"
+	"intmax2-node/internal/hash/goldenposeidon"
+	"math/big"
+	"sort"
+
+	"github.com/iden3/go-iden3-crypto/ffg"
+)
+
+const ACCOUNT_TREE_HEIGHT = 40
+
+const (
+	// DefaultAccountID is the account ID of the leaf with the zero key, which is the lowest key of the account tree.
+	DefaultAccountID = 0
+	// DummyAccountID is the account ID of the dummy public key (x = 1).
+	DummyAccountID = 1
+
+	numKeyBytes = 32
+	numKeyLimbs = numKeyBytes / 4
+)
+
+// IndexedMerkleLeaf is the leaf of the indexed Merkle tree. The leaves are linked
+// in the ascending order of their keys by the next index and the next key.
+type IndexedMerkleLeaf struct {
+	NextIndex uint64
+	Key       *big.Int
+	NextKey   *big.Int
+	Value     uint64
+}
+
+func (l *IndexedMerkleLeaf) Set(other *IndexedMerkleLeaf) *IndexedMerkleLeaf {
+	l.NextIndex = other.NextIndex
+	l.Key = new(big.Int).Set(other.Key)
+	l.NextKey = new(big.Int).Set(other.NextKey)
+	l.Value = other.Value
+
+	return l
+}
+
+func (l *IndexedMerkleLeaf) ToFieldElementSlice() []ffg.Element {
+	const numElements = 2 + 2*numKeyLimbs
+	buf := finite_field.NewBuffer(make([]ffg.Element, numElements))
+	_ = finite_field.WriteUint64(buf, l.NextIndex)
+	finite_field.WriteFixedSizeBytes(buf, l.Key.FillBytes(make([]byte, numKeyBytes)), numKeyBytes)
+	finite_field.WriteFixedSizeBytes(buf, l.NextKey.FillBytes(make([]byte, numKeyBytes)), numKeyBytes)
+	_ = finite_field.WriteUint64(buf, l.Value)
+
+	return buf.Inner()
+}
+
+func (l *IndexedMerkleLeaf) Hash() *PoseidonHashOut {
+	return goldenposeidon.HashNoPad(l.ToFieldElementSlice())
+}
+
+// AccountTree is the indexed Merkle tree of the public keys of the registered accounts.
+// The leaf index is the account ID, the key is the x coordinate of the public key
+// and the value is the block number in which the account was registered.
+type AccountTree struct {
+	Leaves     []*IndexedMerkleLeaf
+	height     uint8
+	zeroHashes []*PoseidonHashOut
+	// nodes[h] holds the non-empty nodes at the height h, where the height 0 is the leaves.
+	nodes []map[uint64]*PoseidonHashOut
+	// sortedIndices holds the leaf indices in the ascending order of their keys.
+	sortedIndices []uint64
+	indexByKey    map[string]uint64
+}
+
+// NewAccountTree creates the account tree with the default leaf and the leaf of the dummy public key.
+func NewAccountTree(height uint8) (*AccountTree, error) {
+	defaultLeaf := IndexedMerkleLeaf{
+		Key:     new(big.Int),
+		NextKey: new(big.Int),
+	}
+
+	t := AccountTree{
+		height:     height,
+		zeroHashes: generateZeroHashes(height, defaultLeaf.Hash()),
+		nodes:      make([]map[uint64]*PoseidonHashOut, height+1),
+		indexByKey: make(map[string]uint64),
+	}
+	for h := range t.nodes {
+		t.nodes[h] = make(map[uint64]*PoseidonHashOut)
+	}
+
+	t.Leaves = append(t.Leaves, &defaultLeaf)
+	t.sortedIndices = append(t.sortedIndices, DefaultAccountID)
+	t.indexByKey[defaultLeaf.Key.String()] = DefaultAccountID
+	t.updateLeaf(DefaultAccountID)
+
+	_, err := t.Insert(big.NewInt(DummyAccountID), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// Insert adds the key to the account tree and returns its leaf index.
+func (t *AccountTree) Insert(key *big.Int, value uint64) (index uint64, err error) {
+	if key.Sign() <= 0 || key.BitLen() > numKeyBytes*8 {
+		return 0, ErrAccountTreeKeyInvalid
+	}
+
+	if _, ok := t.indexByKey[key.String()]; ok {
+		return 0, ErrAccountTreeKeyExists
+	}
+
+	const int1Key = 1
+	index = uint64(len(t.Leaves))
+	if index >= int1Key<<t.height {
+		return 0, ErrAccountTreeFull
+	}
+
+	// The low leaf is the leaf with the largest key that is less than the new key.
+	position := sort.Search(len(t.sortedIndices), func(i int) bool {
+		return t.Leaves[t.sortedIndices[i]].Key.Cmp(key) > 0
+	})
+	lowIndex := t.sortedIndices[position-1]
+	lowLeaf := t.Leaves[lowIndex]
+
+	t.Leaves = append(t.Leaves, &IndexedMerkleLeaf{
+		NextIndex: lowLeaf.NextIndex,
+		Key:       new(big.Int).Set(key),
+		NextKey:   new(big.Int).Set(lowLeaf.NextKey),
+		Value:     value,
+	})
+	lowLeaf.NextIndex = index
+	lowLeaf.NextKey = new(big.Int).Set(key)
+
+	t.sortedIndices = append(t.sortedIndices, 0)
+	copy(t.sortedIndices[position+1:], t.sortedIndices[position:])
+	t.sortedIndices[position] = index
+	t.indexByKey[key.String()] = index
+
+	t.updateLeaf(lowIndex)
+	t.updateLeaf(index)
+
+	return index, nil
+}
+
+// Index returns the leaf index of the key.
+func (t *AccountTree) Index(key *big.Int) (index uint64, ok bool) {
+	index, ok = t.indexByKey[key.String()]
+	if !ok || index == DefaultAccountID {
+		return 0, false
+	}
+
+	return index, true
+}
+
+// Leaf returns the leaf of the index.
+func (t *AccountTree) Leaf(index uint64) (*IndexedMerkleLeaf, error) {
+	if index >= uint64(len(t.Leaves)) {
+		return nil, ErrAccountTreeIndexOutOfRange
+	}
+
+	return new(IndexedMerkleLeaf).Set(t.Leaves[index]), nil
+}
+
+// GetCurrentRootAndCount returns the latest root and the count of the leaves.
+func (t *AccountTree) GetCurrentRootAndCount() (root PoseidonHashOut, count uint64) {
+	return *t.node(uint64(t.height), 0), uint64(len(t.Leaves))
+}
+
+// ComputeMerkleProof returns the siblings of the leaf of the index and the current root.
+func (t *AccountTree) ComputeMerkleProof(index uint64) (siblings []*PoseidonHashOut, root PoseidonHashOut, err error) {
+	if index >= uint64(len(t.Leaves)) {
+		return nil, PoseidonHashOut{}, ErrAccountTreeIndexOutOfRange
+	}
+
+	const int1Key = 1
+	siblings = make([]*PoseidonHashOut, t.height)
+	for h := uint8(0); h < t.height; h++ {
+		siblings[h] = new(PoseidonHashOut).Set(t.node(uint64(h), index^int1Key))
+		index >>= int1Key
+	}
+
+	root, _ = t.GetCurrentRootAndCount()
+
+	return siblings, root, nil
+}
+
+func (t *AccountTree) node(height, index uint64) *PoseidonHashOut {
+	if node, ok := t.nodes[height][index]; ok {
+		return node
+	}
+
+	return t.zeroHashes[height]
+}
+
+// updateLeaf recomputes the nodes on the path from the leaf of the index to the root.
+func (t *AccountTree) updateLeaf(index uint64) {
+	const (
+		int0Key = 0
+		int1Key = 1
+	)
+	cur := t.Leaves[index].Hash()
+	t.nodes[int0Key][index] = cur
+	for h := uint8(int0Key); h < t.height; h++ {
+		if index&int1Key == int1Key {
+			// If it is odd
+			cur = goldenposeidon.Compress(t.node(uint64(h), index-int1Key), cur)
+		} else {
+			cur = goldenposeidon.Compress(cur, t.node(uint64(h), index+int1Key))
+		}
+		index >>= int1Key
+		t.nodes[h+int1Key][index] = cur
+	}
+}
@@ -0,0 +1,75 @@
+package tree
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountTree(t *testing.T) {
+	const height = 4
+
+	accountTree, err := NewAccountTree(height)
+	require.NoError(t, err)
+
+	keys := []int64{50, 7, 30, 100}
+	for i, key := range keys {
+		var index uint64
+		index, err = accountTree.Insert(big.NewInt(key), uint64(i+1))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(i+2), index)
+	}
+
+	_, err = accountTree.Insert(big.NewInt(30), 5)
+	assert.ErrorIs(t, err, ErrAccountTreeKeyExists)
+	_, err = accountTree.Insert(big.NewInt(0), 5)
+	assert.ErrorIs(t, err, ErrAccountTreeKeyInvalid)
+
+	index, ok := accountTree.Index(big.NewInt(30))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(4), index)
+	_, ok = accountTree.Index(big.NewInt(31))
+	assert.False(t, ok)
+	_, ok = accountTree.Index(big.NewInt(0))
+	assert.False(t, ok)
+
+	// The leaves are linked in the ascending order of their keys: 0, 1, 7, 30, 50, 100.
+	expectedKeys := []int64{0, 1, 7, 30, 50, 100}
+	leaf := accountTree.Leaves[DefaultAccountID]
+	for i := range expectedKeys {
+		assert.Equal(t, big.NewInt(expectedKeys[i]), leaf.Key)
+		if i == len(expectedKeys)-1 {
+			assert.Equal(t, uint64(DefaultAccountID), leaf.NextIndex)
+			assert.Equal(t, 0, leaf.NextKey.Sign())
+			break
+		}
+		assert.Equal(t, big.NewInt(expectedKeys[i+1]), leaf.NextKey)
+		leaf = accountTree.Leaves[leaf.NextIndex]
+	}
+
+	leafHashes := make([]*PoseidonHashOut, len(accountTree.Leaves))
+	for i := range accountTree.Leaves {
+		leafHashes[i] = accountTree.Leaves[i].Hash()
+	}
+	defaultLeaf := IndexedMerkleLeaf{Key: new(big.Int), NextKey: new(big.Int)}
+	mt, err := NewPoseidonMerkleTree(height, nil, defaultLeaf.Hash())
+	require.NoError(t, err)
+	expectedRoot, err := mt.BuildMerkleRoot(leafHashes)
+	require.NoError(t, err)
+
+	root, count := accountTree.GetCurrentRootAndCount()
+	assert.Equal(t, *expectedRoot, root)
+	assert.Equal(t, uint64(len(keys)+2), count)
+
+	for i := range accountTree.Leaves {
+		siblings, proofRoot, errProof := accountTree.ComputeMerkleProof(uint64(i))
+		require.NoError(t, errProof)
+		assert.Equal(t, root, proofRoot)
+		assert.Equal(t, root, ComputeMerkleRootFromProof(leafHashes[i], uint64(i), siblings))
+	}
+
+	_, _, err = accountTree.ComputeMerkleProof(count)
+	assert.ErrorIs(t, err, ErrAccountTreeIndexOutOfRange)
+}
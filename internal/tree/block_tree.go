@@ -6,6 +6,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
+const BLOCK_HASH_TREE_HEIGHT = 32
+
 type BlockHashTree struct {
 	Leaves [][32]byte
 	inner  *KeccakMerkleTree
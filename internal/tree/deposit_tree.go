@@ -0,0 +1,70 @@
+package tree
+
+import (
+	"errors"
+	"intmax2-node/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type DepositTree struct {
+	Leaves []*types.DepositLeaf
+	inner  *KeccakMerkleTree
+}
+
+const DEPOSIT_TREE_HEIGHT = 32
+
+func NewDepositTree(height uint8, initialLeaves []*types.DepositLeaf) (*DepositTree, error) {
+	initialLeafHashes := make([][numHashBytes]byte, len(initialLeaves))
+	for i, leaf := range initialLeaves {
+		initialLeafHashes[i] = leaf.Hash()
+	}
+
+	zeroHash := new(types.DepositLeaf).SetZero().Hash()
+	t, err := NewKeccakMerkleTreeWithZeroHash(height, initialLeafHashes, zeroHash)
+	if err != nil {
+		return nil, err
+	}
+
+	leaves := make([]*types.DepositLeaf, len(initialLeaves))
+	for i, leaf := range initialLeaves {
+		leaves[i] = new(types.DepositLeaf).Set(leaf)
+	}
+
+	return &DepositTree{
+		Leaves: leaves,
+		inner:  t,
+	}, nil
+}
+
+func (t *DepositTree) BuildMerkleRoot(leaves [][numHashBytes]byte) (common.Hash, error) {
+	return t.inner.BuildMerkleRoot(leaves)
+}
+
+// GetCurrentRootCountAndSiblings returns the latest root, count and sibblings
+func (t *DepositTree) GetCurrentRootCountAndSiblings() (root common.Hash, nextIndex uint32, siblings [][numHashBytes]byte) {
+	return t.inner.GetCurrentRootCountAndSiblings()
+}
+
+func (t *DepositTree) AddLeaf(index uint32, leaf *types.DepositLeaf) (root common.Hash, err error) {
+	root, err = t.inner.AddLeaf(index, leaf.Hash())
+	if err != nil {
+		return common.Hash{}, errors.Join(ErrAddLeafFail, err)
+	}
+
+	if int(index) != len(t.Leaves) {
+		return common.Hash{}, ErrLeafInputIndexInvalid
+	}
+	t.Leaves = append(t.Leaves, new(types.DepositLeaf).Set(leaf))
+
+	return root, nil
+}
+
+func (t *DepositTree) ComputeMerkleProof(index uint32) (siblings [][numHashBytes]byte, root common.Hash, err error) {
+	leaves := make([][numHashBytes]byte, len(t.Leaves))
+	for i, leaf := range t.Leaves {
+		leaves[i] = leaf.Hash()
+	}
+
+	return t.inner.ComputeMerkleProof(index, leaves)
+}
@@ -0,0 +1,75 @@
+package tree_test
+
+import (
+	"encoding/binary"
+	intMaxTree "intmax2-node/internal/tree"
+	intMaxTypes "intmax2-node/internal/types"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepositTree(t *testing.T) {
+	// The deposit tree root of the Rollup contract before any deposit was processed.
+	const defaultDepositTreeRoot = "0xb6155ab566bbd2e341525fd88c43b4d69572bf4afe7df45cd74d6901a172e41c"
+
+	mt, err := intMaxTree.NewDepositTree(intMaxTree.DEPOSIT_TREE_HEIGHT, nil)
+	require.NoError(t, err)
+
+	root, count, _ := mt.GetCurrentRootCountAndSiblings()
+	assert.Equal(t, defaultDepositTreeRoot, root.Hex())
+	assert.Equal(t, uint32(0), count)
+
+	r := rand.New(rand.NewSource(0))
+	leaves := make([]*intMaxTypes.DepositLeaf, 5)
+	for i := range leaves {
+		leaves[i] = &intMaxTypes.DepositLeaf{
+			TokenIndex: uint32(i),
+			Amount:     new(big.Int).SetUint64(r.Uint64()),
+		}
+		_, err = r.Read(leaves[i].RecipientSaltHash[:])
+		require.NoError(t, err)
+
+		// abi.encodePacked(recipientSaltHash, tokenIndex, amount)
+		packed := make([]byte, 0, 68)
+		packed = append(packed, leaves[i].RecipientSaltHash[:]...)
+		packed = binary.BigEndian.AppendUint32(packed, leaves[i].TokenIndex)
+		packed = append(packed, common.LeftPadBytes(leaves[i].Amount.Bytes(), 32)...)
+		assert.Equal(t, crypto.Keccak256Hash(packed), leaves[i].Hash())
+
+		root, err = mt.AddLeaf(uint32(i), leaves[i])
+		require.NoError(t, err)
+	}
+
+	rebuilt, err := intMaxTree.NewDepositTree(intMaxTree.DEPOSIT_TREE_HEIGHT, leaves)
+	require.NoError(t, err)
+	rebuiltRoot, _, _ := rebuilt.GetCurrentRootCountAndSiblings()
+	assert.Equal(t, root, rebuiltRoot)
+
+	for i := range leaves {
+		siblings, proofRoot, err := mt.ComputeMerkleProof(uint32(i))
+		require.NoError(t, err)
+		assert.Equal(t, root, proofRoot)
+		assert.Len(t, siblings, intMaxTree.DEPOSIT_TREE_HEIGHT)
+
+		node := [32]byte(leaves[i].Hash())
+		index := i
+		for _, sibling := range siblings {
+			if index%2 == 0 {
+				node = intMaxTree.Hash(node, sibling)
+			} else {
+				node = intMaxTree.Hash(sibling, node)
+			}
+			index /= 2
+		}
+		assert.Equal(t, root, common.Hash(node))
+	}
+
+	_, err = mt.AddLeaf(10, leaves[0])
+	assert.ErrorIs(t, err, intMaxTree.ErrAddLeafFail)
+}
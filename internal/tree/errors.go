@@ -24,3 +24,15 @@ var ErrAddLeafFail = errors.New("failed to add")
 
 // ErrLeafInputIndexInvalid error: index is not equal to the length of leaves.
 var ErrLeafInputIndexInvalid = errors.New("index is not equal to the length of leaves")
+
+// ErrAccountTreeKeyInvalid error: the key of the account tree must be positive and fit in 32 bytes.
+var ErrAccountTreeKeyInvalid = errors.New("the key of the account tree must be positive and fit in 32 bytes")
+
+// ErrAccountTreeKeyExists error: the key already exists in the account tree.
+var ErrAccountTreeKeyExists = errors.New("the key already exists in the account tree")
+
+// ErrAccountTreeFull error: the account tree is full.
+var ErrAccountTreeFull = errors.New("the account tree is full")
+
+// ErrAccountTreeIndexOutOfRange error: the index is out of range of the account tree.
+var ErrAccountTreeIndexOutOfRange = errors.New("the index is out of range of the account tree")
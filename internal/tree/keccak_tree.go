@@ -27,8 +27,17 @@ type KeccakMerkleTree struct {
 
 // NewKeccakMerkleTree creates new KeccakMerkleTree.
 func NewKeccakMerkleTree(height uint8, initialLeaves [][numHashBytes]byte) (*KeccakMerkleTree, error) {
+	return NewKeccakMerkleTreeWithZeroHash(height, initialLeaves, common.Hash{})
+}
+
+// NewKeccakMerkleTreeWithZeroHash creates new KeccakMerkleTree whose empty leaves are equal to zeroHash.
+func NewKeccakMerkleTreeWithZeroHash(
+	height uint8,
+	initialLeaves [][numHashBytes]byte,
+	zeroHash [numHashBytes]byte,
+) (*KeccakMerkleTree, error) {
 	mt := &KeccakMerkleTree{
-		zeroHashes: generateKeccakZeroHashes(height),
+		zeroHashes: generateKeccakZeroHashes(height, zeroHash),
 		height:     height,
 		count:      uint32(len(initialLeaves)),
 	}
@@ -200,9 +209,26 @@ func Hash(data ...[numHashBytes]byte) [numHashBytes]byte {
 	return res
 }
 
-func generateKeccakZeroHashes(height uint8) [][numHashBytes]byte {
+// ComputeKeccakMerkleRootFromProof computes the root of the KeccakMerkleTree given the leaf, its index and the merkleProof
+func ComputeKeccakMerkleRootFromProof(leaf [numHashBytes]byte, index uint32, siblings []common.Hash) common.Hash {
+	const int1Key = 1
+	root := leaf
+	for _, sibling := range siblings {
+		if index%int2Key == int1Key {
+			// If it is odd
+			root = Hash(sibling, root)
+		} else {
+			root = Hash(root, sibling)
+		}
+		index /= int2Key
+	}
+
+	return root
+}
+
+func generateKeccakZeroHashes(height uint8, zeroHash [numHashBytes]byte) [][numHashBytes]byte {
 	var zeroHashes = [][numHashBytes]byte{
-		common.Hash{},
+		zeroHash,
 	}
 	// This generates a leaf = HashZero in position 0. In the rest of the positions that are equivalent to the ascending levels,
 	// we set the hashes of the nodes. So all nodes from level i=5 will have the same value and same children nodes.
@@ -56,3 +56,38 @@ func TestTransferTree(t *testing.T) {
 	)
 	assert.Equal(t, expectedRoot.Elements, transferRoot.Elements)
 }
+
+func TestTransferTreeMerkleProof(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	transfers := make([]*intMaxTypes.Transfer, 3)
+
+	for i := range transfers {
+		address := make([]byte, 32)
+		_, err := r.Read(address)
+		assert.NoError(t, err)
+		address[0] &= 0b00011111
+		recipient, err := intMaxTypes.NewINTMAXAddress(address)
+		assert.NoError(t, err)
+		transfers[i] = intMaxTypes.NewTransferWithRandomSalt(recipient, 0, new(big.Int).Rand(r, maxUint256))
+	}
+
+	zeroTransfer := new(intMaxTypes.Transfer).SetZero()
+	transferTree, err := intMaxTree.NewTransferTree(intMaxTree.TRANSFER_TREE_HEIGHT, transfers, zeroTransfer.Hash())
+	assert.NoError(t, err)
+
+	transferRoot, _, _ := transferTree.GetCurrentRootCountAndSiblings()
+
+	for i := range transfers {
+		siblings, root, err := transferTree.ComputeMerkleProof(uint64(i))
+		assert.NoError(t, err)
+		assert.Len(t, siblings, intMaxTree.TRANSFER_TREE_HEIGHT)
+		assert.Equal(t, transferRoot.Elements, root.Elements)
+
+		computedRoot := intMaxTree.ComputeMerkleRootFromProof(transfers[i].Hash(), uint64(i), siblings)
+		assert.Equal(t, transferRoot.Elements, computedRoot.Elements)
+
+		otherIndex := uint64(i+1) % uint64(len(transfers))
+		computedRoot = intMaxTree.ComputeMerkleRootFromProof(transfers[i].Hash(), otherIndex, siblings)
+		assert.NotEqual(t, transferRoot.Elements, computedRoot.Elements)
+	}
+}
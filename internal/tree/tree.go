@@ -148,6 +148,26 @@ func (mt *PoseidonMerkleTree) ComputeMerkleProof(index uint64, leaves []*Poseido
 	return siblings, *ns[int0Key][int0Key], nil
 }
 
+// ComputeMerkleRootFromProof computes the root of the tree given the leaf, its index and the merkleProof
+func ComputeMerkleRootFromProof(leaf *PoseidonHashOut, index uint64, siblings []*PoseidonHashOut) PoseidonHashOut {
+	const (
+		int1Key = 1
+		int2Key = 2
+	)
+	root := new(PoseidonHashOut).Set(leaf)
+	for _, sibling := range siblings {
+		if index%int2Key == int1Key {
+			// If it is odd
+			root = goldenposeidon.Compress(sibling, root)
+		} else {
+			root = goldenposeidon.Compress(root, sibling)
+		}
+		index /= int2Key
+	}
+
+	return *root
+}
+
 // AddLeaf adds new leaves to the tree and computes the new root
 func (mt *PoseidonMerkleTree) AddLeaf(index uint64, leaf *PoseidonHashOut) (*PoseidonHashOut, error) {
 	if index != mt.count {
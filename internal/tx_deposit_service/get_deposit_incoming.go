@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"intmax2-node/configs"
 	intMaxAcc "intmax2-node/internal/accounts"
+	"intmax2-node/internal/store_vault_auth"
 	intMaxTypes "intmax2-node/internal/types"
 	"net/http"
 	"net/url"
@@ -176,9 +177,16 @@ func getDepositsListRawRequest(
 		return nil, fmt.Errorf("failed to unmarshal body for send to route with deposits list: %w", err)
 	}
 
+	var authorization string
+	authorization, err = store_vault_auth.Authorization(ctx, cfg, store_vault_auth.NewINTMAXSigner(recipientAccount))
+	if err != nil {
+		return nil, err
+	}
+
 	r := resty.New().R()
 	resp, err := r.SetContext(ctx).SetHeaders(map[string]string{
-		contentType: appJSON,
+		contentType:                appJSON,
+		store_vault_auth.HeaderKey: authorization,
 	}).SetBody(body).Post(apiUrl)
 	if err != nil {
 		const msg = "failed to send of the deposit request: %w"
@@ -291,9 +299,15 @@ func getDepositByHashIncomingRawRequest(
 		url.QueryEscape(depositHash),
 	)
 
+	authorization, err := store_vault_auth.Authorization(ctx, cfg, store_vault_auth.NewINTMAXSigner(recipientAccount))
+	if err != nil {
+		return nil, err
+	}
+
 	r := resty.New().R()
 	resp, err := r.SetContext(ctx).SetHeaders(map[string]string{
-		contentType: appJSON,
+		contentType:                appJSON,
+		store_vault_auth.HeaderKey: authorization,
 	}).SetQueryParams(map[string]string{
 		"recipient": recipientAccount.ToAddress().String(),
 	}).Get(apiUrl)
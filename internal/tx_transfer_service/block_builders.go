@@ -0,0 +1,300 @@
+package tx_transfer_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"intmax2-node/configs"
+	"intmax2-node/internal/bindings"
+	"intmax2-node/internal/log_scanner"
+	"intmax2-node/pkg/utils"
+	"math/big"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BlockBuilder describes the block builder to which the client can send the transaction.
+type BlockBuilder struct {
+	Url        string
+	NumSlashes *big.Int
+	Info       *BlockInfoResponseData
+}
+
+// Config returns the copy of the config with the API URL of the block builder.
+func (bb *BlockBuilder) Config(cfg *configs.Config) *configs.Config {
+	bbCfg := *cfg
+	bbCfg.API.BlockBuilderUrl = bb.Url
+
+	return &bbCfg
+}
+
+// TransferFee returns the transfer fee of the block builder in the token.
+// The nil fee is returned when the block builder does not accept the token.
+func (bb *BlockBuilder) TransferFee(tokenIndex uint32) *big.Int {
+	if bb.Info == nil {
+		return nil
+	}
+
+	fee, ok := bb.Info.TransferFee[new(big.Int).SetUint64(uint64(tokenIndex)).String()]
+	if !ok {
+		return nil
+	}
+
+	amount, ok := new(big.Int).SetString(fee, base10Key)
+	if !ok {
+		return nil
+	}
+
+	return amount
+}
+
+// GetBlockBuilders returns the block builders ranked for the transaction in the token.
+// Only the block builder of the API_BLOCK_BUILDER_URL is returned when the discovery is turned off.
+// Otherwise, the active block builders of the block builder registry contract and the block builder
+// of the API_BLOCK_BUILDER_URL are asked for the block info, the unavailable ones are skipped.
+func GetBlockBuilders(
+	ctx context.Context,
+	cfg *configs.Config,
+	sb ServiceBlockchain,
+	tokenIndex uint32,
+) ([]*BlockBuilder, error) {
+	if !cfg.API.BlockBuilderDiscovery {
+		return []*BlockBuilder{{
+			Url:        cfg.API.BlockBuilderUrl,
+			NumSlashes: new(big.Int),
+		}}, nil
+	}
+
+	fmt.Println("Discovering block builders...")
+	candidates, err := GetActiveBlockBuildersFromRegistryContract(ctx, cfg, sb)
+	if err != nil {
+		return nil, err
+	}
+
+	staticUrl := strings.TrimSuffix(cfg.API.BlockBuilderUrl, "/")
+	isRegistered := false
+	for i := range candidates {
+		if candidates[i].Url == staticUrl {
+			isRegistered = true
+			break
+		}
+	}
+	if !isRegistered && staticUrl != "" {
+		candidates = append(candidates, &BlockBuilder{
+			Url:        staticUrl,
+			NumSlashes: new(big.Int),
+		})
+	}
+
+	var wg sync.WaitGroup
+	for i := range candidates {
+		wg.Add(1)
+		go func(bb *BlockBuilder) {
+			defer wg.Done()
+
+			info, errInfo := GetBlockInfo(ctx, bb.Config(cfg))
+			if errInfo != nil {
+				fmt.Printf("The block builder %s is unavailable: %v\n", bb.Url, errInfo)
+				return
+			}
+			bb.Info = info
+		}(candidates[i])
+	}
+	wg.Wait()
+
+	blockBuilders := make([]*BlockBuilder, 0, len(candidates))
+	for i := range candidates {
+		if candidates[i].Info != nil {
+			blockBuilders = append(blockBuilders, candidates[i])
+		}
+	}
+	if len(blockBuilders) == 0 {
+		return nil, ErrNoAvailableBlockBuilders
+	}
+
+	SortBlockBuilders(blockBuilders, tokenIndex)
+
+	return blockBuilders, nil
+}
+
+// SortBlockBuilders sorts the block builders by the transfer fee in the token,
+// then by the PoW difficulty and then by the number of slashes.
+// The block builders which do not accept the token go last.
+func SortBlockBuilders(blockBuilders []*BlockBuilder, tokenIndex uint32) {
+	fees := make(map[*BlockBuilder]*big.Int, len(blockBuilders))
+	for i := range blockBuilders {
+		fees[blockBuilders[i]] = blockBuilders[i].TransferFee(tokenIndex)
+	}
+
+	difficulty := func(bb *BlockBuilder) int64 {
+		if bb.Info == nil {
+			return 0
+		}
+		return bb.Info.Difficulty
+	}
+
+	numSlashes := func(bb *BlockBuilder) *big.Int {
+		if bb.NumSlashes == nil {
+			return new(big.Int)
+		}
+		return bb.NumSlashes
+	}
+
+	sort.SliceStable(blockBuilders, func(i, j int) bool {
+		feeI, feeJ := fees[blockBuilders[i]], fees[blockBuilders[j]]
+		switch {
+		case feeI == nil && feeJ != nil:
+			return false
+		case feeI != nil && feeJ == nil:
+			return true
+		case feeI != nil && feeJ != nil && feeI.Cmp(feeJ) != 0:
+			return feeI.Cmp(feeJ) < 0
+		}
+
+		if difficulty(blockBuilders[i]) != difficulty(blockBuilders[j]) {
+			return difficulty(blockBuilders[i]) < difficulty(blockBuilders[j])
+		}
+
+		return numSlashes(blockBuilders[i]).Cmp(numSlashes(blockBuilders[j])) < 0
+	})
+}
+
+// GetActiveBlockBuildersFromRegistryContract returns the block builders which have been
+// registered in the block builder registry contract, are valid, are not stopped and have
+// the stake equal to or greater than the BLOCKCHAIN_SCROLL_STAKE_BALANCE.
+// The registry does not list the block builders, so they are found by the BlockBuilderUpdated events
+// of the blocks after the cached ones, up to API_BLOCK_BUILDER_DISCOVERY_BLOCKS latest blocks.
+func GetActiveBlockBuildersFromRegistryContract(
+	ctx context.Context,
+	cfg *configs.Config,
+	sb ServiceBlockchain,
+) ([]*BlockBuilder, error) {
+	link, err := sb.ScrollNetworkChainLinkEvmJSONRPC(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the EVM JSON RPC link: %w", err)
+	}
+
+	client, err := utils.NewClient(link)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new client: %w", err)
+	}
+	defer client.Close()
+
+	registryAddress := common.HexToAddress(cfg.Blockchain.BlockBuilderRegistryContractAddress)
+	registry, err := bindings.NewBlockBuilderRegistry(registryAddress, client)
+	if err != nil {
+		return nil, errors.Join(ErrNewBlockBuilderRegistryFail, err)
+	}
+
+	latestBlock, err := client.BlockNumber(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrFilterBlockBuilderUpdatedFail, err)
+	}
+
+	cache := LoadBlockBuildersCache(cfg.API.BlockBuilderDiscoveryCache, registryAddress)
+	start := cache.StartBlock(
+		cfg.Blockchain.BlockBuilderRegistryContractDeployedBlockNumber, latestBlock, cfg.API.BlockBuilderDiscoveryBlocks,
+	)
+	err = log_scanner.FilterByRanges(
+		ctx, client, start, cfg.LogScanner.MaxBlockRange,
+		func(opts *bind.FilterOpts) (bool, error) {
+			iterator, errFilter := registry.FilterBlockBuilderUpdated(opts, nil)
+			if errFilter != nil {
+				return false, errFilter
+			}
+			defer func() {
+				_ = iterator.Close()
+			}()
+
+			for iterator.Next() {
+				cache.Add(iterator.Event.BlockBuilder)
+			}
+			if errFilter = iterator.Error(); errFilter != nil {
+				return false, errFilter
+			}
+
+			cache.NextBlock = *opts.End + 1
+			return *opts.End >= latestBlock, nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Join(ErrFilterBlockBuilderUpdatedFail, err)
+	}
+
+	if err = cache.Store(cfg.API.BlockBuilderDiscoveryCache); err != nil {
+		fmt.Printf("Failed to store the block builders found in the registry: %v\n", err)
+	}
+
+	addresses := cache.Addresses
+
+	blockBuilders := make([]*BlockBuilder, 0, len(addresses))
+	isKnownUrl := make(map[string]bool)
+	for i := range addresses {
+		info, errInfo := registry.BlockBuilders(&bind.CallOpts{Context: ctx}, addresses[i])
+		if errInfo != nil {
+			return nil, errors.Join(ErrGetBlockBuilderInfoFail, errInfo)
+		}
+
+		url := strings.TrimSuffix(info.BlockBuilderUrl, "/")
+		if !info.IsValid ||
+			info.StopTime.Sign() != 0 ||
+			info.StakeAmount.Cmp(&cfg.Blockchain.ScrollNetworkStakeBalance) < 0 ||
+			url == "" ||
+			isKnownUrl[url] {
+			continue
+		}
+		isKnownUrl[url] = true
+
+		blockBuilders = append(blockBuilders, &BlockBuilder{
+			Url:        url,
+			NumSlashes: info.NumSlashes,
+		})
+	}
+
+	return blockBuilders, nil
+}
+
+// SendTransactionError returns the error of sending the transaction to the block builder.
+// The error is the ErrBlockBuilderUnavailable error only when the block builder surely did not accept
+// the transaction: it rejected the transaction, or it was not reached. Otherwise, the same nonce
+// would be sent to the next block builder while the transaction is still in the block of this one.
+func SendTransactionError(err error) error {
+	const dialOp = "dial"
+
+	var opErr *net.OpError
+	if errors.Is(err, ErrTransactionRejected) || (errors.As(err, &opErr) && opErr.Op == dialOp) {
+		return errors.Join(ErrBlockBuilderUnavailable, fmt.Errorf("failed to send transaction: %w", err))
+	}
+
+	return errors.Join(ErrTransactionNotProposed, fmt.Errorf("failed to send transaction: %w", err))
+}
+
+// FailoverBlockBuilders calls the function with the config of each block builder in turn
+// while the function returns the ErrBlockBuilderUnavailable error. The function must not return
+// this error once the transaction may be accepted by the block builder (see SendTransactionError).
+func FailoverBlockBuilders(
+	ctx context.Context,
+	cfg *configs.Config,
+	blockBuilders []*BlockBuilder,
+	f func(bbCfg *configs.Config) error,
+) error {
+	err := ErrNoAvailableBlockBuilders
+	for i := range blockBuilders {
+		err = f(blockBuilders[i].Config(cfg))
+		if err == nil || !errors.Is(err, ErrBlockBuilderUnavailable) || ctx.Err() != nil {
+			return err
+		}
+
+		if i+1 < len(blockBuilders) {
+			fmt.Printf("The block builder %s is unavailable: %v\n", blockBuilders[i].Url, err)
+			fmt.Printf("Switching to the block builder %s...\n", blockBuilders[i+1].Url)
+		}
+	}
+
+	return err
+}
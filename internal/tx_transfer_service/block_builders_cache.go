@@ -0,0 +1,84 @@
+package tx_transfer_service
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BlockBuildersCache keeps the addresses of the block builders found in the block builder registry contract
+// with the next block to scan, so the next discovery scans only the new blocks.
+type BlockBuildersCache struct {
+	Registry  common.Address   `json:"registry"`
+	NextBlock uint64           `json:"nextBlock"`
+	Addresses []common.Address `json:"addresses"`
+}
+
+// LoadBlockBuildersCache reads the cache of the registry from the file.
+// The empty cache is returned when the file is missing, is broken or is written for another registry.
+func LoadBlockBuildersCache(path string, registry common.Address) *BlockBuildersCache {
+	empty := BlockBuildersCache{Registry: registry}
+	if strings.TrimSpace(path) == "" {
+		return &empty
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return &empty
+	}
+
+	var cache BlockBuildersCache
+	if err = json.Unmarshal(data, &cache); err != nil || cache.Registry != registry {
+		return &empty
+	}
+
+	return &cache
+}
+
+// Store writes the cache to the file. Nothing is written when the path is empty.
+func (c *BlockBuildersCache) Store(path string) error {
+	const (
+		dirPerm  = 0o700
+		filePerm = 0o600
+	)
+
+	if strings.TrimSpace(path) == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, filePerm)
+}
+
+// StartBlock returns the first block to scan: the next block of the cache, but not earlier than
+// the deployment of the registry and than the lookback blocks before the latest block (0 is unbounded).
+func (c *BlockBuildersCache) StartBlock(deployedBlock, latestBlock, lookbackBlocks uint64) uint64 {
+	start := max(deployedBlock, c.NextBlock)
+	if lookbackBlocks > 0 && latestBlock >= lookbackBlocks && latestBlock-lookbackBlocks+1 > start {
+		start = latestBlock - lookbackBlocks + 1
+	}
+
+	return start
+}
+
+// Add adds the address of the block builder, if it is not known.
+func (c *BlockBuildersCache) Add(address common.Address) {
+	for i := range c.Addresses {
+		if c.Addresses[i] == address {
+			return
+		}
+	}
+
+	c.Addresses = append(c.Addresses, address)
+}
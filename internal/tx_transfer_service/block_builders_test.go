@@ -0,0 +1,171 @@
+package tx_transfer_service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"intmax2-node/configs"
+	"intmax2-node/internal/tx_transfer_service"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortBlockBuilders(t *testing.T) {
+	const tokenIndex = 1
+
+	newBlockBuilder := func(url string, fees map[string]string, difficulty, numSlashes int64) *tx_transfer_service.BlockBuilder {
+		return &tx_transfer_service.BlockBuilder{
+			Url:        url,
+			NumSlashes: big.NewInt(numSlashes),
+			Info: &tx_transfer_service.BlockInfoResponseData{
+				TransferFee: fees,
+				Difficulty:  difficulty,
+			},
+		}
+	}
+
+	blockBuilders := []*tx_transfer_service.BlockBuilder{
+		newBlockBuilder("no-fee", map[string]string{"2": "1"}, 1, 0),
+		newBlockBuilder("expensive", map[string]string{"1": "300"}, 1, 0),
+		newBlockBuilder("default-fee", map[string]string{"0": "200"}, 1, 0),
+		newBlockBuilder("hard", map[string]string{"1": "100"}, 4000, 0),
+		newBlockBuilder("slashed", map[string]string{"1": "100"}, 1000, 2),
+		newBlockBuilder("best", map[string]string{"0": "1", "1": "100"}, 1000, 0),
+	}
+
+	tx_transfer_service.SortBlockBuilders(blockBuilders, tokenIndex)
+
+	urls := make([]string, len(blockBuilders))
+	for i := range blockBuilders {
+		urls[i] = blockBuilders[i].Url
+	}
+	assert.Equal(t, []string{"best", "slashed", "hard", "expensive", "no-fee", "default-fee"}, urls)
+	assert.Equal(t, "100", blockBuilders[0].TransferFee(tokenIndex).String())
+	// The fee in the token 0 is not the fee in the other tokens.
+	assert.Nil(t, blockBuilders[len(blockBuilders)-2].TransferFee(tokenIndex))
+	assert.Nil(t, blockBuilders[len(blockBuilders)-1].TransferFee(tokenIndex))
+	assert.Equal(t, "200", blockBuilders[len(blockBuilders)-1].TransferFee(0).String())
+}
+
+func TestFailoverBlockBuilders(t *testing.T) {
+	cfg := &configs.Config{API: configs.Api{BlockBuilderUrl: "static"}}
+	blockBuilders := []*tx_transfer_service.BlockBuilder{{Url: "first"}, {Url: "second"}, {Url: "third"}}
+
+	errRejected := errors.New("rejected")
+	errLocal := errors.New("local")
+
+	cases := []struct {
+		desc     string
+		errs     map[string]error
+		calls    []string
+		expected error
+	}{
+		{
+			desc:  "first block builder accepts",
+			calls: []string{"first"},
+		},
+		{
+			desc: "fail over to the next block builder",
+			errs: map[string]error{
+				"first":  errors.Join(tx_transfer_service.ErrBlockBuilderUnavailable, errRejected),
+				"second": errors.Join(tx_transfer_service.ErrBlockBuilderUnavailable, errRejected),
+			},
+			calls: []string{"first", "second", "third"},
+		},
+		{
+			desc: "no fail over for the other errors",
+			errs: map[string]error{
+				"first": errLocal,
+			},
+			calls:    []string{"first"},
+			expected: errLocal,
+		},
+		{
+			desc: "all block builders are unavailable",
+			errs: map[string]error{
+				"first":  errors.Join(tx_transfer_service.ErrBlockBuilderUnavailable, errRejected),
+				"second": errors.Join(tx_transfer_service.ErrBlockBuilderUnavailable, errRejected),
+				"third":  errors.Join(tx_transfer_service.ErrBlockBuilderUnavailable, errRejected),
+			},
+			calls:    []string{"first", "second", "third"},
+			expected: tx_transfer_service.ErrBlockBuilderUnavailable,
+		},
+	}
+
+	for i := range cases {
+		t.Run(cases[i].desc, func(t *testing.T) {
+			var calls []string
+			err := tx_transfer_service.FailoverBlockBuilders(
+				context.Background(), cfg, blockBuilders,
+				func(bbCfg *configs.Config) error {
+					calls = append(calls, bbCfg.API.BlockBuilderUrl)
+					return cases[i].errs[bbCfg.API.BlockBuilderUrl]
+				},
+			)
+			if cases[i].expected == nil {
+				require.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, cases[i].expected)
+			}
+			assert.Equal(t, cases[i].calls, calls)
+			assert.Equal(t, "static", cfg.API.BlockBuilderUrl)
+		})
+	}
+
+	err := tx_transfer_service.FailoverBlockBuilders(context.Background(), cfg, nil, func(*configs.Config) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, tx_transfer_service.ErrNoAvailableBlockBuilders)
+}
+
+func TestSendTransactionError(t *testing.T) {
+	rejected := fmt.Errorf("%w: nonce out of order", tx_transfer_service.ErrTransactionRejected)
+	err := tx_transfer_service.SendTransactionError(rejected)
+	assert.ErrorIs(t, err, tx_transfer_service.ErrBlockBuilderUnavailable)
+
+	notReached := fmt.Errorf("failed to send: %w", &net.OpError{Op: "dial", Err: errors.New("connection refused")})
+	err = tx_transfer_service.SendTransactionError(notReached)
+	assert.ErrorIs(t, err, tx_transfer_service.ErrBlockBuilderUnavailable)
+
+	// The transaction may be accepted when the response is lost.
+	timeout := fmt.Errorf("failed to send: %w", &net.OpError{Op: "read", Err: errors.New("i/o timeout")})
+	err = tx_transfer_service.SendTransactionError(timeout)
+	assert.NotErrorIs(t, err, tx_transfer_service.ErrBlockBuilderUnavailable)
+	assert.ErrorIs(t, err, tx_transfer_service.ErrTransactionNotProposed)
+}
+
+func TestBlockBuildersCache(t *testing.T) {
+	const (
+		deployedBlock  = 100
+		latestBlock    = 10000
+		lookbackBlocks = 1000
+	)
+
+	registry := common.HexToAddress("0x1")
+	path := filepath.Join(t.TempDir(), "cache", "block_builders.json")
+
+	cache := tx_transfer_service.LoadBlockBuildersCache(path, registry)
+	assert.Equal(t, uint64(latestBlock-lookbackBlocks+1), cache.StartBlock(deployedBlock, latestBlock, lookbackBlocks))
+	assert.Equal(t, uint64(deployedBlock), cache.StartBlock(deployedBlock, latestBlock, 0))
+
+	cache.Add(common.HexToAddress("0x2"))
+	cache.Add(common.HexToAddress("0x2"))
+	cache.NextBlock = latestBlock + 1
+	require.NoError(t, cache.Store(path))
+
+	cache = tx_transfer_service.LoadBlockBuildersCache(path, registry)
+	assert.Equal(t, []common.Address{common.HexToAddress("0x2")}, cache.Addresses)
+	assert.Equal(t, uint64(latestBlock+1), cache.StartBlock(deployedBlock, latestBlock+10, lookbackBlocks))
+
+	// The cache of another registry is not used.
+	cache = tx_transfer_service.LoadBlockBuildersCache(path, common.HexToAddress("0x3"))
+	assert.Empty(t, cache.Addresses)
+	assert.Equal(t, uint64(deployedBlock), cache.StartBlock(deployedBlock, latestBlock, 0))
+}
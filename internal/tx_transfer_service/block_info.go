@@ -9,6 +9,7 @@ import (
 	intMaxTypes "intmax2-node/internal/types"
 	"intmax2-node/internal/use_cases/block_info"
 	"net/http"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 )
@@ -19,10 +20,11 @@ type BlockInfoResponse struct {
 }
 
 type BlockInfoResponseData struct {
-	ScrollAddress string            `json:"scrollAddress"`
-	IntMaxAddress string            `json:"intMaxAddress"`
-	TransferFee   map[string]string `json:"transferFee"`
-	Difficulty    int64             `json:"difficulty"`
+	ScrollAddress        string            `json:"scrollAddress"`
+	IntMaxAddress        string            `json:"intMaxAddress"`
+	TransferFee          map[string]string `json:"transferFee"`
+	Difficulty           int64             `json:"difficulty"`
+	DifficultyValidUntil time.Time         `json:"difficultyValidUntil"`
 }
 
 func GetBlockInfo(
@@ -36,7 +38,7 @@ func GetBlockInfo(
 
 	apiUrl := fmt.Sprintf("%s/v1/info", cfg.API.BlockBuilderUrl)
 
-	r := resty.New().R()
+	r := resty.New().SetTimeout(cfg.API.BlockBuilderTimeout).R()
 	resp, err := r.SetContext(ctx).SetHeaders(map[string]string{
 		contentType: appJSON,
 	}).Get(apiUrl)
@@ -81,9 +83,10 @@ func GetBlockInfo(
 	}
 
 	return &BlockInfoResponseData{
-		ScrollAddress: res.Data.ScrollAddress,
-		IntMaxAddress: res.Data.IntMaxAddress,
-		TransferFee:   res.Data.TransferFee,
-		Difficulty:    res.Data.Difficulty,
+		ScrollAddress:        res.Data.ScrollAddress,
+		IntMaxAddress:        res.Data.IntMaxAddress,
+		TransferFee:          res.Data.TransferFee,
+		Difficulty:           res.Data.Difficulty,
+		DifficultyValidUntil: res.Data.DifficultyValidUntil,
 	}, nil
 }
@@ -97,6 +97,10 @@ func retryRequest(
 				return response, nil
 			}
 
+			if errors.Is(err, ErrTooManyRequests) {
+				continue
+			}
+
 			const ErrTxTreeNotBuild = "txHash: the tx tree not build."
 			if err.Error() == ErrTxTreeNotBuild {
 				if firstTime {
@@ -164,7 +168,7 @@ func getBlockProposedRawRequest(
 
 	apiUrl := fmt.Sprintf("%s/v1/block/proposed", cfg.API.BlockBuilderUrl)
 
-	r := resty.New().R()
+	r := resty.New().SetTimeout(cfg.API.BlockBuilderTimeout).R()
 	var resp *resty.Response
 	resp, err = r.SetContext(ctx).SetHeaders(map[string]string{
 		contentType: appJSON,
@@ -179,6 +183,10 @@ func getBlockProposedRawRequest(
 		return nil, fmt.Errorf(msg)
 	}
 
+	if resp.StatusCode() == http.StatusTooManyRequests {
+		return nil, ErrTooManyRequests
+	}
+
 	if resp.StatusCode() != http.StatusOK {
 		respJSON := intMaxTypes.ErrorResponse{}
 		err = json.Unmarshal([]byte(resp.String()), &respJSON)
@@ -259,7 +259,7 @@ func postBlockSignatureRawRequest(
 
 	apiUrl := fmt.Sprintf("%s/v1/block/signature", cfg.API.BlockBuilderUrl)
 
-	r := resty.New().R()
+	r := resty.New().SetTimeout(cfg.API.BlockBuilderTimeout).R()
 	var resp *resty.Response
 	resp, err = r.SetContext(ctx).SetHeaders(map[string]string{
 		contentType: appJSON,
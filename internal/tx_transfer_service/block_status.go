@@ -109,7 +109,7 @@ func getBlockStatusRawRequest(
 
 	apiUrl := fmt.Sprintf("%s/v1/block/status/%s", cfg.API.BlockBuilderUrl, txTreeRoot)
 
-	r := resty.New().R()
+	r := resty.New().SetTimeout(cfg.API.BlockBuilderTimeout).R()
 	resp, err := r.SetContext(ctx).SetHeaders(map[string]string{
 		contentType: appJSON,
 	}).Get(apiUrl)
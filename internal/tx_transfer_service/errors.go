@@ -7,3 +7,42 @@ var ErrFailedToGetBalance = errors.New("failed to get balance")
 var ErrRecoverWalletFromPrivateKey = errors.New("fail to recover INTMAX private key")
 
 var ErrBlockNotFound = errors.New("block not found")
+
+var ErrFailedToGetSenderNonce = errors.New("failed to get sender nonce")
+
+var ErrTooManyRequests = errors.New("too many requests to the block builder")
+
+var ErrBlockBuilderUnavailable = errors.New("the block builder is unavailable")
+
+var ErrNoAvailableBlockBuilders = errors.New("no available block builders")
+
+// ErrTransactionRejected error: the transaction is rejected by the block builder.
+var ErrTransactionRejected = errors.New("the transaction is rejected by the block builder")
+
+// ErrTransactionNotProposed error: the transaction may be accepted by the block builder, but its block is not proposed.
+var ErrTransactionNotProposed = errors.New(
+	"the transaction may be accepted by the block builder, but its block is not proposed",
+)
+
+var ErrTransactionCanceled = errors.New("the transaction is canceled")
+
+var ErrNumTransfersInTxInvalid = errors.New("the number of the transfers of the transaction is invalid")
+
+var ErrNewBlockBuilderRegistryFail = errors.New("failed to instantiate a BlockBuilderRegistry contract")
+
+var ErrFilterBlockBuilderUpdatedFail = errors.New("failed to filter the BlockBuilderUpdated events")
+
+var ErrGetBlockBuilderInfoFail = errors.New("failed to get block builder info")
+
+var ErrReadBatchFileFail = errors.New("failed to read the batch file")
+
+var ErrBatchFileFormatInvalid = errors.New("the batch file must be the .json or .csv file")
+
+var ErrBatchFileEmpty = errors.New("the batch file has no transfers")
+
+var ErrBatchTransferInvalid = errors.New("the transfer of the batch is invalid")
+
+var ErrBatchTransfersNotSent = errors.New("not all transfers of the batch are sent")
+
+// ErrTransferFeeTokenNotSupported error: the block builder does not accept the transfer fee in the token.
+var ErrTransferFeeTokenNotSupported = errors.New("the block builder does not accept the transfer fee in the token")
@@ -7,6 +7,7 @@ import (
 	"intmax2-node/configs"
 	intMaxAcc "intmax2-node/internal/accounts"
 	intMaxAccTypes "intmax2-node/internal/accounts/types"
+	"intmax2-node/internal/store_vault_auth"
 	intMaxTypes "intmax2-node/internal/types"
 	"net/http"
 	"net/url"
@@ -173,9 +174,16 @@ func getTransactionsListRawRequest(
 		return nil, fmt.Errorf("failed to unmarshal body for send to route with tx list: %w", err)
 	}
 
+	var authorization string
+	authorization, err = store_vault_auth.Authorization(ctx, cfg, store_vault_auth.NewINTMAXSigner(senderAccount))
+	if err != nil {
+		return nil, err
+	}
+
 	r := resty.New().R()
 	resp, err := r.SetContext(ctx).SetHeaders(map[string]string{
-		contentType: appJSON,
+		contentType:                appJSON,
+		store_vault_auth.HeaderKey: authorization,
 	}).SetBody(body).Post(apiUrl)
 	if err != nil {
 		const msg = "failed to send of the transaction request: %w"
@@ -329,9 +337,15 @@ func getTransactionByHashRawRequest(
 		url.QueryEscape(txHash),
 	)
 
+	authorization, err := store_vault_auth.Authorization(ctx, cfg, store_vault_auth.NewINTMAXSigner(senderAccount))
+	if err != nil {
+		return nil, err
+	}
+
 	r := resty.New().R()
 	resp, err := r.SetContext(ctx).SetHeaders(map[string]string{
-		contentType: appJSON,
+		contentType:                appJSON,
+		store_vault_auth.HeaderKey: authorization,
 	}).SetQueryParams(map[string]string{
 		"sender": senderAccount.ToAddress().String(),
 	}).Get(apiUrl)
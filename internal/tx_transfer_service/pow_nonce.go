@@ -0,0 +1,27 @@
+package tx_transfer_service
+
+import (
+	"context"
+	"fmt"
+	"intmax2-node/configs"
+	"intmax2-node/internal/pow"
+	"time"
+)
+
+// NewPoWNonce creates the PoW nonce with the current difficulty of the block builder.
+func NewPoWNonce(ctx context.Context, cfg *configs.Config) (pow.PoWNonce, error) {
+	info, err := GetBlockInfo(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the PoW difficulty: %w", err)
+	}
+
+	const progressInterval = time.Second
+
+	pw := pow.New(uint64(info.Difficulty))
+	pWorker := pow.NewWorker(cfg.PoW.Workers, pw, pow.WithProgress(progressInterval, func(hashes uint64, elapsed time.Duration) {
+		const msg = "Computing the PoW nonce: %d hashes (%.0f hashes/s)\n"
+		fmt.Printf(msg, hashes, float64(hashes)/elapsed.Seconds())
+	}))
+
+	return pow.NewPoWNonce(pw, pWorker), nil
+}
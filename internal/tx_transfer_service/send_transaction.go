@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"intmax2-node/configs"
 	intMaxAcc "intmax2-node/internal/accounts"
-	"intmax2-node/internal/pow"
 	intMaxTypes "intmax2-node/internal/types"
 	"intmax2-node/internal/use_cases/transaction"
 	"math/big"
@@ -28,15 +27,17 @@ func SendTransferTransaction(
 	senderAccount *intMaxAcc.PrivateKey,
 	transfersHash intMaxTypes.PoseidonHashOut,
 	nonce uint64,
+	feeTransfer *transaction.FeeTransferTransaction,
 	// encodedEncryptedTx *transaction.BackupTransactionData,
 	// encodedEncryptedTransfers []*transaction.BackupTransferInput,
 ) error {
 	const duration = 300 * time.Minute
 	expiration := time.Now().Add(duration)
 
-	pw := pow.New(cfg.PoW.Difficulty)
-	pWorker := pow.NewWorker(cfg.PoW.Workers, pw)
-	pwNonce := pow.NewPoWNonce(pw, pWorker)
+	pwNonce, err := NewPoWNonce(ctx, cfg)
+	if err != nil {
+		return err
+	}
 
 	tx, err := intMaxTypes.NewTx(
 		&transfersHash,
@@ -75,7 +76,7 @@ func SendTransferTransaction(
 	}
 
 	return SendTransactionWithRawRequest(
-		ctx, cfg, senderAccount, transfersHash, nonce, expiration, powNonceStr, signatureInput,
+		ctx, cfg, senderAccount, transfersHash, nonce, expiration, powNonceStr, signatureInput, feeTransfer,
 		// encodedEncryptedTx, encodedEncryptedTransfers,
 	)
 }
@@ -89,6 +90,7 @@ func SendTransactionWithRawRequest(
 	expiration time.Time,
 	powNonce string,
 	signature *bn254.G2Affine,
+	feeTransfer *transaction.FeeTransferTransaction,
 	// encodedEncryptedTx *transaction.BackupTransactionData,
 	// encodedEncryptedTransfers []*transaction.BackupTransferInput,
 ) error {
@@ -101,6 +103,7 @@ func SendTransactionWithRawRequest(
 		expiration,
 		powNonce,
 		hexutil.Encode(signature.Marshal()),
+		feeTransfer,
 		// encodedEncryptedTx,
 		// encodedEncryptedTransfers,
 	)
@@ -113,6 +116,7 @@ func sendTransactionRawRequest(
 	nonce uint64,
 	expiration time.Time,
 	powNonce, signature string,
+	feeTransfer *transaction.FeeTransferTransaction,
 	// backupTx *transaction.BackupTransactionData,
 	// backupTransfers []*transaction.BackupTransferInput,
 ) error {
@@ -123,6 +127,7 @@ func sendTransactionRawRequest(
 		PowNonce:      powNonce,
 		Expiration:    expiration,
 		Signature:     signature,
+		FeeTransfer:   feeTransfer,
 		// BackupTx:        backupTx,
 		// BackupTransfers: backupTransfers,
 	}
@@ -141,7 +146,7 @@ func sendTransactionRawRequest(
 
 	apiUrl := fmt.Sprintf("%s/v1/transaction", cfg.API.BlockBuilderUrl)
 
-	r := resty.New().R()
+	r := resty.New().SetTimeout(cfg.API.BlockBuilderTimeout).R()
 	var resp *resty.Response
 	resp, err = r.SetContext(ctx).SetHeaders(map[string]string{
 		contentType: appJSON,
@@ -157,7 +162,7 @@ func sendTransactionRawRequest(
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return fmt.Errorf("failed to get response")
+		return fmt.Errorf("%w: the status code is %d", ErrTransactionRejected, resp.StatusCode())
 	}
 
 	response := new(SendTransactionResponse)
@@ -166,13 +171,16 @@ func sendTransactionRawRequest(
 	}
 
 	if !response.Success {
-		return fmt.Errorf("failed to send transaction: %s", response.Data.Message)
+		return fmt.Errorf("%w: %s", ErrTransactionRejected, response.Data.Message)
 	}
 
 	return nil
 }
 
-func transferFee(
+// TransferFee asks the user to approve the transfer fee of the block builder
+// and returns the approved fee with the INTMAX address of the block builder.
+// The nil fee is returned when the user cancels the transaction.
+func TransferFee(
 	ctx context.Context,
 	cfg *configs.Config,
 	tokenIndex uint32,
@@ -188,7 +196,6 @@ func transferFee(
 		msgTimeout         = "Confirmation of transfer fee is expired (time out equal to or greater than 1 minute). Repeat your selection."
 		msgTrFeeIsApproved = "Transfer fee is approved."
 		msgTrIsCanceled    = "Transaction is canceled."
-		defaultTokenIndex  = "0"
 		val1e18Key         = 1e18
 	)
 
@@ -204,11 +211,7 @@ func transferFee(
 		}
 		gasFee, gasOK := dataBlockInfo.TransferFee[new(big.Int).SetUint64(uint64(tokenIndex)).String()]
 		if !gasOK {
-			gasFee, gasOK = dataBlockInfo.TransferFee[defaultTokenIndex]
-		}
-		if !gasOK {
-			const msg = "failed to get default gas fee from the block info data"
-			return nil, emptyKey, fmt.Errorf(msg)
+			return nil, emptyKey, fmt.Errorf("%w: %d", ErrTransferFeeTokenNotSupported, tokenIndex)
 		}
 
 		err = amountGasFee.Scan(gasFee)
@@ -0,0 +1,81 @@
+package tx_transfer_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"intmax2-node/configs"
+	intMaxTypes "intmax2-node/internal/types"
+	"net/http"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type SenderNonceResponse struct {
+	Success bool                     `json:"success"`
+	Data    *SenderNonceResponseData `json:"data"`
+}
+
+type SenderNonceResponseData struct {
+	Sender string `json:"sender"`
+	Nonce  string `json:"nonce"`
+}
+
+func GetSenderNonce(
+	ctx context.Context,
+	cfg *configs.Config,
+	senderAddress string,
+) (uint64, error) {
+	const (
+		contentType = "Content-Type"
+		appJSON     = "application/json"
+	)
+
+	apiUrl := fmt.Sprintf("%s/v1/sender/%s/nonce", cfg.API.BlockBuilderUrl, senderAddress)
+
+	r := resty.New().SetTimeout(cfg.API.BlockBuilderTimeout).R()
+	resp, err := r.SetContext(ctx).SetHeaders(map[string]string{
+		contentType: appJSON,
+	}).Get(apiUrl)
+	if err != nil {
+		const msg = "failed to send of the sender nonce request: %w"
+		return 0, fmt.Errorf(msg, err)
+	}
+
+	if resp == nil {
+		const msg = "send request error occurred"
+		return 0, fmt.Errorf(msg)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		respJSON := intMaxTypes.ErrorResponse{}
+		err = json.Unmarshal([]byte(resp.String()), &respJSON)
+		if err != nil {
+			return 0, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		if respJSON.Message != "" {
+			return 0, errors.New(respJSON.Message)
+		}
+
+		return 0, fmt.Errorf("failed to get response")
+	}
+
+	var res SenderNonceResponse
+	if err = json.Unmarshal(resp.Body(), &res); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if !res.Success || res.Data == nil {
+		return 0, ErrFailedToGetSenderNonce
+	}
+
+	var nonce uint64
+	nonce, err = strconv.ParseUint(res.Data.Nonce, base10Key, uint64Key)
+	if err != nil {
+		return 0, errors.Join(ErrFailedToGetSenderNonce, err)
+	}
+
+	return nonce, nil
+}
@@ -0,0 +1,337 @@
+package tx_transfer_service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"intmax2-node/configs"
+	intMaxAcc "intmax2-node/internal/accounts"
+	"intmax2-node/internal/balance_service"
+	"intmax2-node/internal/logger"
+	"intmax2-node/internal/mnemonic_wallet"
+	intMaxTypes "intmax2-node/internal/types"
+	backupBalance "intmax2-node/internal/use_cases/backup_balance"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	BatchTransferStatusSent     = "sent"
+	BatchTransferStatusFailed   = "failed"
+	BatchTransferStatusCanceled = "canceled"
+)
+
+// BatchTransfer describes the transfer to one recipient of the batch file.
+type BatchTransfer struct {
+	Recipient  string `json:"recipient"`
+	TokenIndex uint32 `json:"tokenIndex"`
+	Amount     string `json:"amount"`
+}
+
+// BatchTransferStatus describes the result of the transfer of the batch.
+type BatchTransferStatus struct {
+	*BatchTransfer
+	Status string
+	TxHash string
+	Error  error
+}
+
+// ReadBatchTransfers reads the transfers of the JSON file (the array of the transfers)
+// or of the CSV file (the rows of the recipient, the token index and the amount
+// with the optional header) and checks them.
+func ReadBatchTransfers(filePath string) ([]*BatchTransfer, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Join(ErrReadBatchFileFail, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var list []*BatchTransfer
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".json":
+		err = json.NewDecoder(f).Decode(&list)
+	case ".csv":
+		list, err = readBatchTransfersCSV(f)
+	default:
+		return nil, ErrBatchFileFormatInvalid
+	}
+	if err != nil {
+		return nil, errors.Join(ErrReadBatchFileFail, err)
+	}
+
+	if len(list) == 0 {
+		return nil, ErrBatchFileEmpty
+	}
+
+	for key := range list {
+		if list[key] == nil {
+			return nil, fmt.Errorf("transfer %d: %w", key+1, ErrBatchTransferInvalid)
+		}
+		if _, err = list[key].transfer(); err != nil {
+			return nil, fmt.Errorf("transfer %d: %w", key+1, err)
+		}
+	}
+
+	return list, nil
+}
+
+func readBatchTransfersCSV(r io.Reader) ([]*BatchTransfer, error) {
+	const numOfColumns = 3
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = numOfColumns
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*BatchTransfer, 0, len(records))
+	for key := range records {
+		tokenIndex, errParse := strconv.ParseUint(strings.TrimSpace(records[key][1]), base10Key, uint32Key)
+		if errParse != nil {
+			if key == 0 {
+				// the header
+				continue
+			}
+			return nil, fmt.Errorf("line %d: failed to parse token index: %w", key+1, errParse)
+		}
+
+		list = append(list, &BatchTransfer{
+			Recipient:  strings.TrimSpace(records[key][0]),
+			TokenIndex: uint32(tokenIndex),
+			Amount:     strings.TrimSpace(records[key][2]),
+		})
+	}
+
+	return list, nil
+}
+
+func (bt *BatchTransfer) transfer() (*intMaxTypes.Transfer, error) {
+	recipient, err := intMaxAcc.NewPublicKeyFromAddressHex(bt.Recipient)
+	if err != nil {
+		return nil, errors.Join(ErrBatchTransferInvalid, fmt.Errorf("failed to parse recipient address: %w", err))
+	}
+
+	recipientAddress, err := intMaxTypes.NewINTMAXAddress(recipient.ToAddress().Bytes())
+	if err != nil {
+		return nil, errors.Join(ErrBatchTransferInvalid, fmt.Errorf("failed to create recipient address: %w", err))
+	}
+
+	amount, ok := new(big.Int).SetString(strings.TrimSpace(bt.Amount), base10Key)
+	if !ok || amount.Sign() <= 0 {
+		return nil, errors.Join(ErrBatchTransferInvalid, fmt.Errorf("invalid amount: %q", bt.Amount))
+	}
+
+	return intMaxTypes.NewTransferWithRandomSalt(recipientAddress, bt.TokenIndex, amount), nil
+}
+
+// TransferBatch sends the transfers of the batch file by the transactions of up to
+// NUM_TRANSFERS_IN_TX - 1 transfers each (the first transfer of the transaction is the fee
+// in the fee token) and prints the status of each transfer.
+// The balances of all tokens with the fees of all transactions are checked before the first transaction is sent.
+// The batch is stopped when the transaction may be accepted by the block builder without its proposed block,
+// so that the next transaction is not sent with the same nonce and balances.
+func TransferBatch(
+	ctx context.Context,
+	cfg *configs.Config,
+	log logger.Logger,
+	sb ServiceBlockchain,
+	filePath string,
+	feeTokenIndex uint32,
+	userEthPrivateKey string,
+) error {
+	wallet, err := mnemonic_wallet.New().WalletFromPrivateKeyHex(userEthPrivateKey)
+	if err != nil {
+		return fmt.Errorf("fail to get wallet from private key: %w", err)
+	}
+
+	userAccount, err := intMaxAcc.NewPrivateKeyFromString(wallet.IntMaxPrivateKey)
+	if err != nil {
+		return fmt.Errorf("fail to parse user private key: %w", err)
+	}
+
+	batch, err := ReadBatchTransfers(filePath)
+	if err != nil {
+		return err
+	}
+
+	transfers := make([]*intMaxTypes.Transfer, len(batch))
+	for key := range batch {
+		transfers[key], err = batch[key].transfer()
+		if err != nil {
+			return fmt.Errorf("transfer %d: %w", key+1, err)
+		}
+	}
+
+	fmt.Printf("User's INTMAX Address: %s\n", userAccount.ToAddress().String())
+	fmt.Println("Fetching balances...")
+	balances, err := balance_service.GetUserBalances(ctx, cfg, userAccount)
+	if err != nil {
+		return fmt.Errorf(ErrFailedToGetBalance.Error()+": %v", err)
+	}
+
+	blockBuilders, err := GetBlockBuilders(ctx, cfg, sb, feeTokenIndex)
+	if err != nil {
+		return fmt.Errorf("failed to get block builders: %v", err)
+	}
+
+	fee, err := BatchTransferFee(ctx, cfg, blockBuilders, feeTokenIndex)
+	if err != nil {
+		return err
+	}
+
+	err = CheckBatchBalances(balances, transfers, feeTokenIndex, fee)
+	if err != nil {
+		return err
+	}
+
+	statuses := make([]*BatchTransferStatus, len(batch))
+	for key := range batch {
+		statuses[key] = &BatchTransferStatus{
+			BatchTransfer: batch[key],
+			Status:        BatchTransferStatusCanceled,
+		}
+	}
+
+	numTransfersInTx := int(backupBalance.NUM_TRANSFERS_IN_TX) - 1
+	numOfTxs := numOfBatchTxs(len(transfers))
+	for from := 0; from < len(transfers); from += numTransfersInTx {
+		to := from + numTransfersInTx
+		if to > len(transfers) {
+			to = len(transfers)
+		}
+
+		fmt.Printf("Sending the transaction %d of %d (transfers %d-%d)...\n", from/numTransfersInTx+1, numOfTxs, from+1, to)
+
+		var txDetails *intMaxTypes.TxDetails
+		txDetails, err = SendTransfers(ctx, cfg, blockBuilders, userAccount, feeTokenIndex, balances, transfers[from:to])
+		if errors.Is(err, ErrTransactionCanceled) || ctx.Err() != nil {
+			break
+		}
+
+		for key := from; key < to; key++ {
+			if err != nil {
+				statuses[key].Status = BatchTransferStatusFailed
+				statuses[key].Error = err
+				continue
+			}
+			statuses[key].Status = BatchTransferStatusSent
+			statuses[key].TxHash = txDetails.Tx.Hash().String()
+		}
+		if errors.Is(err, ErrTransactionNotProposed) {
+			log.Warnf("the batch is stopped after the transaction of the transfers %d-%d: %v", from+1, to, err)
+			break
+		}
+		if err != nil {
+			log.Warnf("failed to send the transaction of the transfers %d-%d: %v", from+1, to, err)
+			continue
+		}
+
+		// the balances are spent by the transfers with the fee
+		for key := range txDetails.Transfers {
+			balance, ok := balances[txDetails.Transfers[key].TokenIndex]
+			if ok {
+				balance.Sub(balance, txDetails.Transfers[key].Amount)
+			}
+		}
+	}
+
+	return printBatchTransferStatuses(statuses)
+}
+
+// CheckBatchBalances checks that the balances cover the total amounts of the transfers of the batch
+// in each token with the fee of each transaction of the batch in the fee token.
+func CheckBatchBalances(
+	balances map[uint32]*big.Int,
+	transfers []*intMaxTypes.Transfer,
+	feeTokenIndex uint32,
+	fee *big.Int,
+) error {
+	totalFee := new(big.Int).Mul(fee, big.NewInt(int64(numOfBatchTxs(len(transfers)))))
+
+	withFees := make([]*intMaxTypes.Transfer, 0, len(transfers)+1)
+	withFees = append(withFees, transfers...)
+	withFees = append(withFees, &intMaxTypes.Transfer{TokenIndex: feeTokenIndex, Amount: totalFee})
+
+	return checkBalances(balances, withFees)
+}
+
+// numOfBatchTxs returns the number of the transactions sending the transfers of the batch.
+func numOfBatchTxs(numOfTransfers int) int {
+	numTransfersInTx := int(backupBalance.NUM_TRANSFERS_IN_TX) - 1
+
+	return (numOfTransfers + numTransfersInTx - 1) / numTransfersInTx
+}
+
+// BatchTransferFee returns the highest transfer fee of the block builders in the fee token,
+// since the transactions of the batch may be sent to any of them in turn.
+// The block info is fetched for the block builder without it, and the block builders
+// that are unavailable or do not accept the fee token are skipped.
+func BatchTransferFee(
+	ctx context.Context,
+	cfg *configs.Config,
+	blockBuilders []*BlockBuilder,
+	feeTokenIndex uint32,
+) (*big.Int, error) {
+	var fee *big.Int
+	for key := range blockBuilders {
+		if blockBuilders[key].Info == nil {
+			info, err := GetBlockInfo(ctx, blockBuilders[key].Config(cfg))
+			if err != nil {
+				fmt.Printf("Failed to get the block info data of the block builder %s: %v\n", blockBuilders[key].Url, err)
+				continue
+			}
+			blockBuilders[key].Info = info
+		}
+
+		bbFee := blockBuilders[key].TransferFee(feeTokenIndex)
+		if bbFee != nil && (fee == nil || bbFee.Cmp(fee) > 0) {
+			fee = bbFee
+		}
+	}
+
+	if fee == nil {
+		return nil, fmt.Errorf("%w: %d", ErrTransferFeeTokenNotSupported, feeTokenIndex)
+	}
+
+	return fee, nil
+}
+
+func printBatchTransferStatuses(statuses []*BatchTransferStatus) error {
+	fmt.Println("Transfers:")
+
+	var numOfSent int
+	for key := range statuses {
+		line := fmt.Sprintf(
+			"%d. recipient %s, token index %d, amount %s: %s",
+			key+1, statuses[key].Recipient, statuses[key].TokenIndex, statuses[key].Amount, statuses[key].Status,
+		)
+		switch {
+		case statuses[key].TxHash != "":
+			line += fmt.Sprintf(" (tx hash %s)", statuses[key].TxHash)
+		case statuses[key].Error != nil:
+			line += fmt.Sprintf(" (%v)", statuses[key].Error)
+		}
+		fmt.Println(line)
+
+		if statuses[key].Status == BatchTransferStatusSent {
+			numOfSent++
+		}
+	}
+
+	if numOfSent < len(statuses) {
+		return fmt.Errorf("%w: %d of %d transfers are sent", ErrBatchTransfersNotSent, numOfSent, len(statuses))
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package tx_transfer_service_test
+
+import (
+	"context"
+	"intmax2-node/configs"
+	intMaxAcc "intmax2-node/internal/accounts"
+	"intmax2-node/internal/tx_transfer_service"
+	intMaxTypes "intmax2-node/internal/types"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBatchTransfers(t *testing.T) {
+	pk, err := intMaxAcc.NewPrivateKey(big.NewInt(2))
+	require.NoError(t, err)
+	recipient := pk.ToAddress().String()
+
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		filePath := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(filePath, []byte(content), 0o600))
+		return filePath
+	}
+
+	want := []*tx_transfer_service.BatchTransfer{
+		{Recipient: recipient, TokenIndex: 0, Amount: "10"},
+		{Recipient: recipient, TokenIndex: 1, Amount: "20"},
+	}
+
+	cases := []struct {
+		desc    string
+		name    string
+		content string
+		want    []*tx_transfer_service.BatchTransfer
+		wantErr error
+	}{
+		{
+			desc:    "CSV with the header",
+			name:    "header.csv",
+			content: "recipient,tokenIndex,amount\n" + recipient + ",0,10\n" + recipient + ", 1, 20\n",
+			want:    want,
+		},
+		{
+			desc:    "CSV without the header",
+			name:    "no_header.csv",
+			content: recipient + ",0,10\n" + recipient + ",1,20\n",
+			want:    want,
+		},
+		{
+			desc: "JSON",
+			name: "transfers.json",
+			content: `[{"recipient":"` + recipient + `","tokenIndex":0,"amount":"10"},` +
+				`{"recipient":"` + recipient + `","tokenIndex":1,"amount":"20"}]`,
+			want: want,
+		},
+		{
+			desc:    "the amount is invalid",
+			name:    "amount.csv",
+			content: recipient + ",0,-10\n",
+			wantErr: tx_transfer_service.ErrBatchTransferInvalid,
+		},
+		{
+			desc:    "the recipient is invalid",
+			name:    "recipient.json",
+			content: `[{"recipient":"0x01","tokenIndex":0,"amount":"10"}]`,
+			wantErr: tx_transfer_service.ErrBatchTransferInvalid,
+		},
+		{
+			desc:    "the token index is invalid",
+			name:    "token_index.csv",
+			content: recipient + ",0,10\n" + recipient + ",x,10\n",
+			wantErr: tx_transfer_service.ErrReadBatchFileFail,
+		},
+		{
+			desc:    "no transfers",
+			name:    "empty.json",
+			content: `[]`,
+			wantErr: tx_transfer_service.ErrBatchFileEmpty,
+		},
+		{
+			desc:    "the format is unknown",
+			name:    "transfers.txt",
+			content: recipient + ",0,10\n",
+			wantErr: tx_transfer_service.ErrBatchFileFormatInvalid,
+		},
+	}
+
+	for i := range cases {
+		t.Run(cases[i].desc, func(t *testing.T) {
+			list, err := tx_transfer_service.ReadBatchTransfers(writeFile(cases[i].name, cases[i].content))
+			if cases[i].wantErr != nil {
+				assert.ErrorIs(t, err, cases[i].wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, cases[i].want, list)
+		})
+	}
+}
+
+func TestCheckBatchBalances(t *testing.T) {
+	const feeTokenIndex = 0
+
+	transfers := func(n int, tokenIndex uint32) []*intMaxTypes.Transfer {
+		list := make([]*intMaxTypes.Transfer, n)
+		for i := range list {
+			list[i] = &intMaxTypes.Transfer{TokenIndex: tokenIndex, Amount: big.NewInt(1)}
+		}
+		return list
+	}
+
+	cases := []struct {
+		desc      string
+		balances  map[uint32]*big.Int
+		transfers []*intMaxTypes.Transfer
+		wantErr   bool
+	}{
+		{
+			desc:      "the fees of two transactions are covered",
+			balances:  map[uint32]*big.Int{0: big.NewInt(20), 1: big.NewInt(64)},
+			transfers: transfers(64, 1),
+		},
+		{
+			desc:      "the fee of the second transaction is not covered",
+			balances:  map[uint32]*big.Int{0: big.NewInt(19), 1: big.NewInt(64)},
+			transfers: transfers(64, 1),
+			wantErr:   true,
+		},
+		{
+			desc:      "the fee is added to the transfers in the fee token",
+			balances:  map[uint32]*big.Int{0: big.NewInt(73)},
+			transfers: transfers(63, feeTokenIndex),
+		},
+		{
+			desc:      "the transfers in the fee token with the fee are not covered",
+			balances:  map[uint32]*big.Int{0: big.NewInt(72)},
+			transfers: transfers(63, feeTokenIndex),
+			wantErr:   true,
+		},
+	}
+
+	for i := range cases {
+		t.Run(cases[i].desc, func(t *testing.T) {
+			err := tx_transfer_service.CheckBatchBalances(cases[i].balances, cases[i].transfers, feeTokenIndex, big.NewInt(10))
+			if cases[i].wantErr {
+				assert.ErrorContains(t, err, "insufficient funds")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestBatchTransferFee(t *testing.T) {
+	const feeTokenIndex = 1
+
+	blockBuilder := func(url string, transferFee map[string]string) *tx_transfer_service.BlockBuilder {
+		return &tx_transfer_service.BlockBuilder{
+			Url:  url,
+			Info: &tx_transfer_service.BlockInfoResponseData{TransferFee: transferFee},
+		}
+	}
+
+	fee, err := tx_transfer_service.BatchTransferFee(context.Background(), new(configs.Config), []*tx_transfer_service.BlockBuilder{
+		blockBuilder("http://bb1", map[string]string{"1": "10"}),
+		blockBuilder("http://bb2", map[string]string{"0": "100"}),
+		blockBuilder("http://bb3", map[string]string{"1": "30"}),
+		blockBuilder("http://bb4", map[string]string{"1": "20"}),
+	}, feeTokenIndex)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(30), fee)
+
+	_, err = tx_transfer_service.BatchTransferFee(context.Background(), new(configs.Config), []*tx_transfer_service.BlockBuilder{
+		blockBuilder("http://bb2", map[string]string{"0": "100"}),
+	}, feeTokenIndex)
+	assert.ErrorIs(t, err, tx_transfer_service.ErrTransferFeeTokenNotSupported)
+}
@@ -18,6 +18,7 @@ import (
 	"intmax2-node/internal/mnemonic_wallet"
 	intMaxTree "intmax2-node/internal/tree"
 	intMaxTypes "intmax2-node/internal/types"
+	backupBalance "intmax2-node/internal/use_cases/backup_balance"
 	"intmax2-node/internal/use_cases/transaction"
 	"math/big"
 	"strconv"
@@ -31,6 +32,7 @@ import (
 
 const (
 	base10Key = 10
+	uint32Key = 32
 	uint64Key = 64
 )
 
@@ -85,98 +87,160 @@ func TransferTransaction(
 		return fmt.Errorf("insufficient funds for total amount: balance %s, total amount %s", balance, amount)
 	}
 
-	var (
-		amountGasFee  *uint256.Int
-		intMaxAddress string
-	)
-	amountGasFee, intMaxAddress, err = transferFee(ctx, cfg, tokenIndex)
+	// Send transfer transaction
+	recipient, err := intMaxAcc.NewPublicKeyFromAddressHex(recipientAddressStr)
 	if err != nil {
-		return fmt.Errorf("failed to get transfer fee: %v", err)
-	}
-	if amountGasFee == nil {
-		return nil
-	}
-
-	totalAmountWithGas := new(big.Int).Add(amount, amountGasFee.ToBig())
-	if balance.Cmp(totalAmountWithGas) < 0 {
-		return fmt.Errorf("insufficient funds for tx cost: balance %s, tx cost %s", balance, totalAmountWithGas)
+		return fmt.Errorf("failed to parse recipient address: %v", err)
 	}
 
-	zeroTransfer := new(intMaxTypes.Transfer).SetZero()
-	var initialLeaves []*intMaxTypes.Transfer
-
-	// Send transfer transaction
-	var recipientGasFee *intMaxAcc.PublicKey
-	recipientGasFee, err = intMaxAcc.NewPublicKeyFromAddressHex(intMaxAddress)
+	recipientAddress, err := intMaxTypes.NewINTMAXAddress(recipient.ToAddress().Bytes())
 	if err != nil {
-		return fmt.Errorf("failed to parse recipient address of gas fee: %v", err)
+		return fmt.Errorf("failed to create recipient address: %v", err)
 	}
 
-	var recipientAddressGasFee *intMaxTypes.GenericAddress
-	recipientAddressGasFee, err = intMaxTypes.NewINTMAXAddress(recipientGasFee.ToAddress().Bytes())
+	blockBuilders, err := GetBlockBuilders(ctx, cfg, sb, tokenIndex)
 	if err != nil {
-		return fmt.Errorf("failed to create recipient address of gas fee: %v", err)
+		return fmt.Errorf("failed to get block builders: %v", err)
 	}
 
-	transferGasFee := intMaxTypes.NewTransferWithRandomSalt(
-		recipientAddressGasFee,
-		tokenIndex,
-		amountGasFee.ToBig(),
+	_, err = SendTransfers(
+		ctx, cfg, blockBuilders, userAccount, tokenIndex,
+		map[uint32]*big.Int{tokenIndex: balance},
+		[]*intMaxTypes.Transfer{
+			intMaxTypes.NewTransferWithRandomSalt(recipientAddress, tokenIndex, amount),
+		},
 	)
-
-	initialLeaves = append(initialLeaves, transferGasFee)
-
-	// Send transfer transaction
-	var recipient *intMaxAcc.PublicKey
-	recipient, err = intMaxAcc.NewPublicKeyFromAddressHex(recipientAddressStr)
-	if err != nil {
-		return fmt.Errorf("failed to parse recipient address: %v", err)
+	if errors.Is(err, ErrTransactionCanceled) {
+		return nil
 	}
 
-	var recipientAddress *intMaxTypes.GenericAddress
-	recipientAddress, err = intMaxTypes.NewINTMAXAddress(recipient.ToAddress().Bytes())
-	if err != nil {
-		return fmt.Errorf("failed to create recipient address: %v", err)
+	return err
+}
+
+// SendTransfers sends the transaction of the transfers preceded by the fee transfer in the fee token
+// to the block builders in turn, signs its proposed block and backs up the transaction and the transfers.
+// Up to NUM_TRANSFERS_IN_TX - 1 transfers are sent, and the balances must cover them with the fee.
+// The details of the sent transaction with the fee transfer are returned, and the ErrTransactionCanceled
+// error is returned when the user does not approve the transfer fee.
+func SendTransfers(
+	ctx context.Context,
+	cfg *configs.Config,
+	blockBuilders []*BlockBuilder,
+	userAccount *intMaxAcc.PrivateKey,
+	feeTokenIndex uint32,
+	balances map[uint32]*big.Int,
+	transfers []*intMaxTypes.Transfer,
+) (*intMaxTypes.TxDetails, error) {
+	const feeTransferIndex = 0
+	if len(transfers) == 0 || len(transfers) > int(backupBalance.NUM_TRANSFERS_IN_TX)-1 {
+		return nil, ErrNumTransfersInTxInvalid
 	}
 
-	transfer := intMaxTypes.NewTransferWithRandomSalt(
-		recipientAddress,
-		tokenIndex,
-		amount,
+	var (
+		blockBuilderCfg *configs.Config
+		initialLeaves   []*intMaxTypes.Transfer
+		transfersHash   intMaxTypes.PoseidonHashOut
+		nonce           uint64
+		proposedBlock   *BlockProposedResponseData
+		isCanceled      bool
 	)
+	// The transaction is sent to the next block builder when the current one rejects it or times out
+	// until the proposed block is received.
+	err := FailoverBlockBuilders(ctx, cfg, blockBuilders, func(bbCfg *configs.Config) (err error) {
+		fmt.Printf("Block Builder: %s\n", bbCfg.API.BlockBuilderUrl)
+
+		var (
+			amountGasFee  *uint256.Int
+			intMaxAddress string
+		)
+		amountGasFee, intMaxAddress, err = TransferFee(ctx, bbCfg, feeTokenIndex)
+		if err != nil {
+			return errors.Join(ErrBlockBuilderUnavailable, fmt.Errorf("failed to get transfer fee: %v", err))
+		}
+		if amountGasFee == nil {
+			isCanceled = true
+			return nil
+		}
 
-	initialLeaves = append(initialLeaves, transfer)
+		// Send transfer transaction
+		var recipientGasFee *intMaxAcc.PublicKey
+		recipientGasFee, err = intMaxAcc.NewPublicKeyFromAddressHex(intMaxAddress)
+		if err != nil {
+			return fmt.Errorf("failed to parse recipient address of gas fee: %v", err)
+		}
 
-	var transferTree *intMaxTree.TransferTree
-	transferTree, err = intMaxTree.NewTransferTree(intMaxTree.TRANSFER_TREE_HEIGHT, initialLeaves, zeroTransfer.Hash())
-	if err != nil {
-		return fmt.Errorf("failed to create transfer tree: %v", err)
-	}
+		var recipientAddressGasFee *intMaxTypes.GenericAddress
+		recipientAddressGasFee, err = intMaxTypes.NewINTMAXAddress(recipientGasFee.ToAddress().Bytes())
+		if err != nil {
+			return fmt.Errorf("failed to create recipient address of gas fee: %v", err)
+		}
 
-	transfersHash, _, _ := transferTree.GetCurrentRootCountAndSiblings()
+		transferGasFee := intMaxTypes.NewTransferWithRandomSalt(
+			recipientAddressGasFee,
+			feeTokenIndex,
+			amountGasFee.ToBig(),
+		)
 
-	var nonce uint64 = 1 // TODO: Incremented with each transaction
+		initialLeaves = append([]*intMaxTypes.Transfer{transferGasFee}, transfers...)
 
-	err = SendTransferTransaction(
-		ctx,
-		cfg,
-		userAccount,
-		transfersHash,
-		nonce,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to send transaction: %v", err)
-	}
+		err = checkBalances(balances, initialLeaves)
+		if err != nil {
+			return err
+		}
 
-	fmt.Println("The transaction request has been successfully sent. Please wait for the server's response.")
+		zeroTransfer := new(intMaxTypes.Transfer).SetZero()
 
-	// Get proposed block
-	var proposedBlock *BlockProposedResponseData
-	proposedBlock, err = GetBlockProposed(
-		ctx, cfg, userAccount, transfersHash, nonce,
-	)
+		var transferTree *intMaxTree.TransferTree
+		transferTree, err = intMaxTree.NewTransferTree(intMaxTree.TRANSFER_TREE_HEIGHT, initialLeaves, zeroTransfer.Hash())
+		if err != nil {
+			return fmt.Errorf("failed to create transfer tree: %v", err)
+		}
+
+		transfersHash, _, _ = transferTree.GetCurrentRootCountAndSiblings()
+
+		var feeTransfer *transaction.FeeTransferTransaction
+		feeTransfer, err = MakeFeeTransfer(transferTree, feeTransferIndex, intMaxAddress)
+		if err != nil {
+			return fmt.Errorf("failed to make fee transfer: %v", err)
+		}
+
+		nonce, err = GetSenderNonce(ctx, bbCfg, userAccount.ToAddress().String())
+		if err != nil {
+			return errors.Join(ErrBlockBuilderUnavailable, fmt.Errorf("failed to get sender nonce: %v", err))
+		}
+
+		err = SendTransferTransaction(
+			ctx,
+			bbCfg,
+			userAccount,
+			transfersHash,
+			nonce,
+			feeTransfer,
+		)
+		if err != nil {
+			return SendTransactionError(err)
+		}
+
+		fmt.Println("The transaction request has been successfully sent. Please wait for the server's response.")
+
+		// Get proposed block
+		proposedBlock, err = GetBlockProposed(
+			ctx, bbCfg, userAccount, transfersHash, nonce,
+		)
+		if err != nil {
+			// the transaction is accepted, so it is not sent to the next block builder with the same nonce
+			return errors.Join(ErrTransactionNotProposed, fmt.Errorf("failed to get proposed block: %w", err))
+		}
+
+		blockBuilderCfg = bbCfg
+
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to send transaction: %v", err)
+		return nil, err
+	}
+	if isCanceled {
+		return nil, ErrTransactionCanceled
 	}
 
 	fmt.Println("The proposed block has been successfully received.")
@@ -187,7 +251,7 @@ func TransferTransaction(
 		nonce,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create new tx: %w", err)
+		return nil, fmt.Errorf("failed to create new tx: %w", err)
 	}
 
 	txHash := tx.Hash()
@@ -208,7 +272,7 @@ func TransferTransaction(
 		encodedTx,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to encrypt transaction: %w", err)
+		return nil, fmt.Errorf("failed to encrypt transaction: %w", err)
 	}
 
 	encodedEncryptedTx := base64.StdEncoding.EncodeToString(encryptedTx)
@@ -222,21 +286,49 @@ func TransferTransaction(
 	for i := range initialLeaves {
 		backupTransfers[i], err = MakeTransferBackupData(initialLeaves[i])
 		if err != nil {
-			return fmt.Errorf("failed to make backup data: %v", err)
+			return nil, fmt.Errorf("failed to make backup data: %v", err)
 		}
 	}
 
 	// Accept proposed block
 	err = SendSignedProposedBlock(
-		ctx, cfg, userAccount, proposedBlock.TxTreeRoot, *txHash, proposedBlock.PublicKeys,
+		ctx, blockBuilderCfg, userAccount, proposedBlock.TxTreeRoot, *txHash, proposedBlock.PublicKeys,
 		&backupTx, backupTransfers,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to send transaction: %v", err)
+		return nil, fmt.Errorf("failed to send transaction: %v", err)
 	}
 
 	fmt.Println("The transaction has been successfully sent.")
 
+	return &txDetails, nil
+}
+
+// checkBalances checks that the balances cover the total amounts of the transfers in each token.
+func checkBalances(balances map[uint32]*big.Int, transfers []*intMaxTypes.Transfer) error {
+	totals := make(map[uint32]*big.Int)
+	for key := range transfers {
+		total, ok := totals[transfers[key].TokenIndex]
+		if !ok {
+			total = new(big.Int)
+			totals[transfers[key].TokenIndex] = total
+		}
+		total.Add(total, transfers[key].Amount)
+	}
+
+	for tokenIndex, total := range totals {
+		balance, ok := balances[tokenIndex]
+		if !ok {
+			balance = new(big.Int)
+		}
+		if balance.Cmp(total) < 0 {
+			return fmt.Errorf(
+				"insufficient funds for tx cost of token index %d: balance %s, tx cost %s",
+				tokenIndex, balance, total,
+			)
+		}
+	}
+
 	return nil
 }
 
@@ -277,6 +369,39 @@ func MakeTransferBackupData(transfer *intMaxTypes.Transfer) (backupTransfer *tra
 	}, nil
 }
 
+// MakeFeeTransfer returns the fee transfer of the transfer tree with its Merkle proof,
+// which is checked by the block builder against the transfers hash of the transaction.
+func MakeFeeTransfer(
+	transferTree *intMaxTree.TransferTree,
+	feeTransferIndex uint64,
+	recipient string,
+) (*transaction.FeeTransferTransaction, error) {
+	if feeTransferIndex >= uint64(len(transferTree.Leaves)) {
+		return nil, errors.New("fee transfer index is out of range")
+	}
+
+	merkleProof, _, err := transferTree.ComputeMerkleProof(feeTransferIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute merkle proof: %w", err)
+	}
+
+	merkleProofStr := make([]string, len(merkleProof))
+	for i := range merkleProof {
+		merkleProofStr[i] = merkleProof[i].String()
+	}
+
+	fee := transferTree.Leaves[feeTransferIndex]
+
+	return &transaction.FeeTransferTransaction{
+		Recipient:     recipient,
+		TokenIndex:    fee.TokenIndex,
+		Amount:        fee.Amount.String(),
+		Salt:          fee.Salt.String(),
+		TransferIndex: feeTransferIndex,
+		MerkleProof:   merkleProofStr,
+	}, nil
+}
+
 func GetTransactionFromBackupData(
 	encryptedTransaction *GetTransactionData,
 	senderAccount *intMaxAcc.PrivateKey,
@@ -2,6 +2,7 @@ package tx_withdrawal_service
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -11,12 +12,14 @@ import (
 	intMaxAccTypes "intmax2-node/internal/accounts/types"
 	errorsB "intmax2-node/internal/blockchain/errors"
 	"intmax2-node/internal/mnemonic_wallet"
+	"intmax2-node/internal/store_vault_auth"
 	"intmax2-node/internal/tx_transfer_service"
 	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/go-resty/resty/v2"
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
@@ -200,9 +203,22 @@ func getTransfersListRawRequest(
 		return nil, fmt.Errorf("failed to unmarshal body for send to route with transfers list: %w", err)
 	}
 
+	var ethKey *ecdsa.PrivateKey
+	ethKey, err = crypto.HexToECDSA(userEthPrivateKey)
+	if err != nil {
+		return nil, errors.Join(errorsB.ErrWalletAddressNotRecognized, err)
+	}
+
+	var authorization string
+	authorization, err = store_vault_auth.Authorization(ctx, cfg, store_vault_auth.NewEthereumSigner(ethKey))
+	if err != nil {
+		return nil, err
+	}
+
 	r := resty.New().R()
 	resp, err := r.SetContext(ctx).SetHeaders(map[string]string{
-		contentType: appJSON,
+		contentType:                appJSON,
+		store_vault_auth.HeaderKey: authorization,
 	}).SetBody(body).Post(apiUrl)
 	if err != nil {
 		const msg = "failed to send of the transfer request: %w"
@@ -358,9 +374,22 @@ func getTransferByHashRawRequest(
 		url.QueryEscape(transferHash),
 	)
 
+	var ethKey *ecdsa.PrivateKey
+	ethKey, err = crypto.HexToECDSA(userEthPrivateKey)
+	if err != nil {
+		return nil, errors.Join(errorsB.ErrWalletAddressNotRecognized, err)
+	}
+
+	var authorization string
+	authorization, err = store_vault_auth.Authorization(ctx, cfg, store_vault_auth.NewEthereumSigner(ethKey))
+	if err != nil {
+		return nil, err
+	}
+
 	r := resty.New().R()
 	resp, err := r.SetContext(ctx).SetHeaders(map[string]string{
-		contentType: appJSON,
+		contentType:                appJSON,
+		store_vault_auth.HeaderKey: authorization,
 	}).SetQueryParams(map[string]string{
 		"recipient": wallet.WalletAddress.String(),
 	}).Get(apiUrl)
@@ -9,7 +9,6 @@ import (
 	intMaxAcc "intmax2-node/internal/accounts"
 	"intmax2-node/internal/hash/goldenposeidon"
 	"intmax2-node/internal/logger"
-	"intmax2-node/internal/pow"
 	"intmax2-node/internal/tx_transfer_service"
 	intMaxTypes "intmax2-node/internal/types"
 	"intmax2-node/internal/use_cases/transaction"
@@ -33,13 +32,15 @@ func SendWithdrawalTransaction(
 	senderAccount *intMaxAcc.PrivateKey,
 	transfersHash intMaxTypes.PoseidonHashOut,
 	nonce uint64,
+	feeTransfer *transaction.FeeTransferTransaction,
 ) error {
 	const duration = 300 * time.Minute
 	expiration := time.Now().Add(duration)
 
-	pw := pow.New(cfg.PoW.Difficulty)
-	pWorker := pow.NewWorker(cfg.PoW.Workers, pw)
-	pwNonce := pow.NewPoWNonce(pw, pWorker)
+	pwNonce, err := tx_transfer_service.NewPoWNonce(ctx, cfg)
+	if err != nil {
+		return err
+	}
 
 	tx, err := intMaxTypes.NewTx(
 		&transfersHash,
@@ -78,7 +79,7 @@ func SendWithdrawalTransaction(
 	}
 
 	err = tx_transfer_service.SendTransactionWithRawRequest(
-		ctx, cfg, senderAccount, transfersHash, nonce, expiration, powNonceStr, signatureInput,
+		ctx, cfg, senderAccount, transfersHash, nonce, expiration, powNonceStr, signatureInput, feeTransfer,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to send transaction: %w", err)
@@ -100,6 +101,7 @@ func SendWithdrawalWithRawRequest(
 	txIndex int32,
 	blockNumber uint32,
 	blockHash common.Hash,
+	sender intMaxAcc.Address,
 ) error {
 	transferMerkleProofStr := make([]string, len(transferMerkleProof))
 	for i, v := range transferMerkleProof {
@@ -122,6 +124,7 @@ func SendWithdrawalWithRawRequest(
 		txIndex,
 		blockNumber,
 		blockHash.Hex(),
+		sender.String(),
 	)
 }
 
@@ -138,6 +141,7 @@ func sendWithdrawalRawRequest(
 	txIndex int32,
 	blockNumber uint32,
 	blockHash string,
+	sender string,
 ) error {
 	if transfer.Recipient.TypeOfAddress == "INTMAX" {
 		return fmt.Errorf("intmax address is not supported")
@@ -173,6 +177,7 @@ func sendWithdrawalRawRequest(
 			Proof:        "AA==", // dummy
 			PublicInputs: "AA==", // dummy
 		},
+		Sender: sender,
 	}
 
 	bd, err := json.Marshal(ucInput)
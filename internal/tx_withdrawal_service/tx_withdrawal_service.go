@@ -14,6 +14,7 @@ import (
 	"intmax2-node/internal/logger"
 	"intmax2-node/internal/mnemonic_wallet"
 	"intmax2-node/internal/open_telemetry"
+	"intmax2-node/internal/store_vault_auth"
 	intMaxTree "intmax2-node/internal/tree"
 	"intmax2-node/internal/tx_transfer_service"
 	intMaxTypes "intmax2-node/internal/types"
@@ -26,6 +27,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
 )
 
 const (
@@ -101,62 +104,147 @@ func WithdrawalTransaction(
 		amount,
 	)
 
-	zeroTransfer := new(intMaxTypes.Transfer).SetZero()
-	initialLeaves := make([]*intMaxTypes.Transfer, 1)
-	const transferIndex = 0
-	initialLeaves[transferIndex] = transfer
-
-	transferTree, err := intMaxTree.NewTransferTree(intMaxTree.TRANSFER_TREE_HEIGHT, initialLeaves, zeroTransfer.Hash())
+	blockBuilders, err := tx_transfer_service.GetBlockBuilders(ctx, cfg, sb, tokenIndex)
 	if err != nil {
-		return fmt.Errorf("failed to create transfer tree: %v", err)
+		return fmt.Errorf("failed to get block builders: %v", err)
 	}
 
-	transferMerkleProof, transfersHash, err := transferTree.ComputeMerkleProof(0)
-	if err != nil {
-		return fmt.Errorf("failed to compute merkle proof: %v", err)
-	}
+	// The fee transfer of the block builder precedes the withdrawal transfer.
+	const (
+		feeTransferIndex = 0
+		transferIndex    = 1
+	)
+	var (
+		blockBuilderCfg     *configs.Config
+		transferGasFee      *intMaxTypes.Transfer
+		initialLeaves       []*intMaxTypes.Transfer
+		transferMerkleProof []*intMaxTypes.PoseidonHashOut
+		transfersHash       intMaxTypes.PoseidonHashOut
+		nonce               uint64
+		encryptedTx         []byte
+		proposedBlock       *tx_transfer_service.BlockProposedResponseData
+		isCanceled          bool
+	)
+	// The transaction is sent to the next block builder when the current one rejects it or times out
+	// until the proposed block is received.
+	err = tx_transfer_service.FailoverBlockBuilders(ctx, cfg, blockBuilders, func(bbCfg *configs.Config) error {
+		fmt.Printf("Block Builder: %s\n", bbCfg.API.BlockBuilderUrl)
+
+		var (
+			amountGasFee  *uint256.Int
+			intMaxAddress string
+		)
+		amountGasFee, intMaxAddress, err = tx_transfer_service.TransferFee(ctx, bbCfg, tokenIndex)
+		if err != nil {
+			return errors.Join(tx_transfer_service.ErrBlockBuilderUnavailable, fmt.Errorf("failed to get transfer fee: %v", err))
+		}
+		if amountGasFee == nil {
+			isCanceled = true
+			return nil
+		}
 
-	var nonce uint64 = 1 // TODO: Incremented with each transaction
+		totalAmountWithGas := new(big.Int).Add(amount, amountGasFee.ToBig())
+		if balance.Cmp(totalAmountWithGas) < 0 {
+			return fmt.Errorf("insufficient funds for tx cost: balance %s, tx cost %s", balance, totalAmountWithGas)
+		}
 
-	txDetails := intMaxTypes.TxDetails{
-		Tx: intMaxTypes.Tx{
-			TransferTreeRoot: &transfersHash,
-			Nonce:            nonce,
-		},
-		Transfers: initialLeaves,
-	}
+		var recipientGasFee *intMaxAcc.PublicKey
+		recipientGasFee, err = intMaxAcc.NewPublicKeyFromAddressHex(intMaxAddress)
+		if err != nil {
+			return fmt.Errorf("failed to parse recipient address of gas fee: %v", err)
+		}
 
-	encodedTx := txDetails.Marshal()
-	encryptedTx, err := intMaxAcc.EncryptECIES(
-		rand.Reader,
-		userAccount.Public(),
-		encodedTx,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to encrypt deposit: %w", err)
-	}
+		var recipientAddressGasFee *intMaxTypes.GenericAddress
+		recipientAddressGasFee, err = intMaxTypes.NewINTMAXAddress(recipientGasFee.ToAddress().Bytes())
+		if err != nil {
+			return fmt.Errorf("failed to create recipient address of gas fee: %v", err)
+		}
 
-	err = SendWithdrawalTransaction(
-		ctx,
-		cfg,
-		log,
-		sb,
-		userAccount,
-		transfersHash,
-		nonce,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to send transaction: %v", err)
-	}
+		transferGasFee = intMaxTypes.NewTransferWithRandomSalt(
+			recipientAddressGasFee,
+			tokenIndex,
+			amountGasFee.ToBig(),
+		)
 
-	fmt.Println("The transaction request has been successfully sent. Please wait for the server's response.")
+		zeroTransfer := new(intMaxTypes.Transfer).SetZero()
+		initialLeaves = make([]*intMaxTypes.Transfer, transferIndex+1)
+		initialLeaves[feeTransferIndex] = transferGasFee
+		initialLeaves[transferIndex] = transfer
 
-	// Get proposed block
-	proposedBlock, err := tx_transfer_service.GetBlockProposed(
-		ctx, cfg, userAccount, transfersHash, nonce,
-	)
+		var transferTree *intMaxTree.TransferTree
+		transferTree, err = intMaxTree.NewTransferTree(intMaxTree.TRANSFER_TREE_HEIGHT, initialLeaves, zeroTransfer.Hash())
+		if err != nil {
+			return fmt.Errorf("failed to create transfer tree: %v", err)
+		}
+
+		var feeTransfer *transaction.FeeTransferTransaction
+		feeTransfer, err = tx_transfer_service.MakeFeeTransfer(transferTree, feeTransferIndex, intMaxAddress)
+		if err != nil {
+			return fmt.Errorf("failed to make fee transfer: %v", err)
+		}
+
+		transferMerkleProof, transfersHash, err = transferTree.ComputeMerkleProof(transferIndex)
+		if err != nil {
+			return fmt.Errorf("failed to compute merkle proof: %v", err)
+		}
+
+		nonce, err = tx_transfer_service.GetSenderNonce(ctx, bbCfg, userAccount.ToAddress().String())
+		if err != nil {
+			return errors.Join(tx_transfer_service.ErrBlockBuilderUnavailable, fmt.Errorf("failed to get sender nonce: %v", err))
+		}
+
+		txDetails := intMaxTypes.TxDetails{
+			Tx: intMaxTypes.Tx{
+				TransferTreeRoot: &transfersHash,
+				Nonce:            nonce,
+			},
+			Transfers: initialLeaves,
+		}
+
+		encodedTx := txDetails.Marshal()
+		encryptedTx, err = intMaxAcc.EncryptECIES(
+			rand.Reader,
+			userAccount.Public(),
+			encodedTx,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt deposit: %w", err)
+		}
+
+		err = SendWithdrawalTransaction(
+			ctx,
+			bbCfg,
+			log,
+			sb,
+			userAccount,
+			transfersHash,
+			nonce,
+			feeTransfer,
+		)
+		if err != nil {
+			return tx_transfer_service.SendTransactionError(err)
+		}
+
+		fmt.Println("The transaction request has been successfully sent. Please wait for the server's response.")
+
+		// Get proposed block
+		proposedBlock, err = tx_transfer_service.GetBlockProposed(
+			ctx, bbCfg, userAccount, transfersHash, nonce,
+		)
+		if err != nil {
+			// the transaction is accepted, so it is not sent to the next block builder with the same nonce
+			return errors.Join(tx_transfer_service.ErrTransactionNotProposed, fmt.Errorf("failed to get proposed block: %w", err))
+		}
+
+		blockBuilderCfg = bbCfg
+
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to send transaction: %v", err)
+		return err
+	}
+	if isCanceled {
+		return nil
 	}
 
 	fmt.Println("The proposed block has been successfully received.")
@@ -189,8 +277,12 @@ func WithdrawalTransaction(
 	}
 
 	backupTransfers := make([]*transaction.BackupTransferInput, len(initialLeaves))
-	for i, transfer := range initialLeaves {
-		backupTransfers[i], err = tx_transfer_service.MakeWithdrawalBackupData(
+	backupTransfers[feeTransferIndex], err = tx_transfer_service.MakeTransferBackupData(transferGasFee)
+	if err != nil {
+		return fmt.Errorf("failed to make backup data: %v", err)
+	}
+	for i, transfer := range initialLeaves[transferIndex:] {
+		backupTransfers[transferIndex+i], err = tx_transfer_service.MakeWithdrawalBackupData(
 			transfer,
 			userAccount.ToAddress(),
 			transfersHash,
@@ -208,7 +300,7 @@ func WithdrawalTransaction(
 
 	// Accept proposed block
 	err = tx_transfer_service.SendSignedProposedBlock(
-		ctx, cfg, userAccount, proposedBlock.TxTreeRoot, *txHash, proposedBlock.PublicKeys,
+		ctx, blockBuilderCfg, userAccount, proposedBlock.TxTreeRoot, *txHash, proposedBlock.PublicKeys,
 		&backupTx, backupTransfers,
 	)
 	if err != nil {
@@ -218,7 +310,7 @@ func WithdrawalTransaction(
 	fmt.Println("The transaction has been successfully sent.")
 
 	// Send withdrawal request
-	err = SendWithdrawalRequest(ctx, cfg, log, sb, &tx_transfer_service.BackupWithdrawal{
+	err = SendWithdrawalRequest(ctx, blockBuilderCfg, log, sb, &tx_transfer_service.BackupWithdrawal{
 		SenderAddress:       userAccount.ToAddress(),
 		Transfer:            transfer,
 		TransferMerkleProof: transferMerkleProof,
@@ -244,9 +336,22 @@ func ResumeWithdrawalRequest(
 	log logger.Logger,
 	sb ServiceBlockchain,
 	recipientAddressHex string,
+	userEthPrivateKey string,
 	resumeIncompleteWithdrawals bool,
 ) {
-	backupWithdrawals, err := GetBackupWithdrawal(ctx, cfg, common.HexToAddress(recipientAddressHex))
+	ethKey, err := crypto.HexToECDSA(userEthPrivateKey)
+	if err != nil {
+		log.Fatalf("failed to parse user private key: %v", err)
+	}
+
+	// The backups of the withdrawals are readable by the owner of the recipient address only.
+	signer := store_vault_auth.NewEthereumSigner(ethKey)
+	if !strings.EqualFold(signer.Address(), common.HexToAddress(recipientAddressHex).Hex()) {
+		log.Warnf("The incomplete withdrawal requests to %s are not checked, since the recipient is not the user.", recipientAddressHex)
+		return
+	}
+
+	backupWithdrawals, err := GetBackupWithdrawal(ctx, cfg, signer)
 	if err != nil {
 		log.Fatalf("failed to get backup withdrawal: %v", err)
 	}
@@ -392,6 +497,7 @@ func SendWithdrawalRequest(
 		withdrawal.TxIndex,
 		uint32(blockNumber),
 		blockHash,
+		withdrawal.SenderAddress,
 	)
 	if err != nil {
 		if errors.Is(err, withdrawalService.ErrWithdrawalRequestAlreadyExists) {
@@ -407,9 +513,9 @@ func SendWithdrawalRequest(
 func GetBackupWithdrawal(
 	ctx context.Context,
 	cfg *configs.Config,
-	userAddress common.Address,
+	signer store_vault_auth.Signer,
 ) ([]*tx_transfer_service.BackupWithdrawal, error) {
-	userAllData, err := balance_service.GetUserBalancesRawRequest(ctx, cfg, userAddress.Hex())
+	userAllData, err := balance_service.GetUserBalancesRawRequest(ctx, cfg, signer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user balances: %w", err)
 	}
@@ -10,6 +10,7 @@ import (
 	"intmax2-node/internal/bindings"
 	"intmax2-node/internal/finite_field"
 	"intmax2-node/internal/hash/goldenposeidon"
+	"intmax2-node/internal/log_scanner"
 	"intmax2-node/internal/logger"
 	"intmax2-node/pkg/utils"
 	"math/big"
@@ -670,6 +671,9 @@ type RollupContractConfig struct {
 	EthereumPrivateKeyHex string
 
 	RollupContractDeployedBlockNumber uint64
+
+	// MaxBlockRange is the maximum number of blocks in one request for the logs
+	MaxBlockRange uint64
 }
 
 // NewRollupContractConfigFromEnv creates a new RollupContractConfig from the environment variables.
@@ -680,6 +684,7 @@ func NewRollupContractConfigFromEnv(cfg *configs.Config, networkRpcUrl string) *
 		EthereumPrivateKeyHex:             cfg.Blockchain.BuilderPrivateKeyHex,
 		NetworkChainID:                    cfg.Blockchain.ScrollNetworkChainID,
 		RollupContractDeployedBlockNumber: cfg.Blockchain.RollupContractDeployedBlockNumber,
+		MaxBlockRange:                     cfg.LogScanner.MaxBlockRange,
 	}
 }
 
@@ -755,22 +760,22 @@ type BlockSignature struct {
 }
 
 // PostNonRegistrationBlock posts a non-registration block on the Rollup contract.
-// It returns the transaction hash if the block is successfully posted.
-func PostNonRegistrationBlock(cfg *RollupContractConfig, blockContent *BlockContent) (*types.Transaction, error) {
-	client, err := utils.NewClient(cfg.NetworkRpcUrl)
+// It returns the transaction receipt if the block is successfully posted.
+func PostNonRegistrationBlock(cfg *RollupContractConfig, ctx context.Context, log logger.Logger, client *ethclient.Client, blockContent *BlockContent) (*types.Receipt, error) {
+	rollup, err := bindings.NewRollup(common.HexToAddress(cfg.RollupContractAddressHex), client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create new client: %w", err)
+		return nil, fmt.Errorf("failed to instantiate a Liquidity contract: %w", err)
 	}
-	defer client.Close()
 
-	rollup, err := bindings.NewRollup(common.HexToAddress(cfg.RollupContractAddressHex), client)
+	// Check recover block content
+	err = blockContent.IsValid()
 	if err != nil {
-		return nil, fmt.Errorf("failed to instantiate a Liquidity contract: %w", err)
+		return nil, fmt.Errorf("block content is invalid: %w", err)
 	}
 
 	input, err := MakePostNonRegistrationBlockInput(blockContent)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make post registration block input: %w", err)
+		return nil, fmt.Errorf("failed to make post non-registration block input: %w", err)
 	}
 
 	privateKey, err := crypto.HexToECDSA(cfg.EthereumPrivateKeyHex)
@@ -791,16 +796,7 @@ func PostNonRegistrationBlock(cfg *RollupContractConfig, blockContent *BlockCont
 		return nil, fmt.Errorf("failed to create transactor: %w", err)
 	}
 
-	// Output calldata
-	fmt.Printf("Tx tree root: %x\n", input.TxTreeRoot)
-	fmt.Printf("Sender flags: %x\n", input.SenderFlags)
-	fmt.Printf("Aggregated public key: %x\n", input.AggregatedPublicKey)
-	fmt.Printf("Aggregated signature: %x\n", input.AggregatedSignature)
-	fmt.Printf("Message point: %x\n", input.MessagePoint)
-	fmt.Printf("Public keys hash: %x\n", input.PublicKeysHash)
-	fmt.Printf("Sender account IDs: %x\n", input.SenderAccountIds)
-
-	return rollup.PostNonRegistrationBlock(
+	tx, err := rollup.PostNonRegistrationBlock(
 		transactOpts,
 		input.TxTreeRoot,
 		input.SenderFlags,
@@ -810,6 +806,18 @@ func PostNonRegistrationBlock(cfg *RollupContractConfig, blockContent *BlockCont
 		input.PublicKeysHash,
 		input.SenderAccountIds,
 	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to post non-registration block: %w", err)
+	}
+
+	log.Infof("The tx hash of PostNonRegistrationBlock is %s\n", tx.Hash().Hex())
+
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return receipt, nil
 }
 
 func FetchDepositRoot(cfg *RollupContractConfig, ctx context.Context) ([int32Key]byte, error) {
@@ -916,33 +924,38 @@ func FetchPostedBlocks(
 		return nil, nil, errors.Join(ErrFilterLogsFail, err)
 	}
 
+	var events []*bindings.RollupBlockPosted
+	maxBlockNumber := new(big.Int)
+
 	nextBlock := startBlock + 1
-	iterator, err := rollup.FilterBlockPosted(&bind.FilterOpts{
-		Start:   nextBlock,
-		End:     nil,
-		Context: ctx,
-	}, prevBlockHash, blockBuilder)
-	if err != nil {
-		return nil, nil, errors.Join(ErrFilterLogsFail, err)
-	}
+	err = log_scanner.FilterByRanges(ctx, client, nextBlock, cfg.MaxBlockRange,
+		func(opts *bind.FilterOpts) (bool, error) {
+			iterator, errFilter := rollup.FilterBlockPosted(opts, prevBlockHash, blockBuilder)
+			if errFilter != nil {
+				return false, errors.Join(ErrFilterLogsFail, errFilter)
+			}
 
-	defer func() {
-		_ = iterator.Close()
-	}()
+			defer func() {
+				_ = iterator.Close()
+			}()
 
-	var events []*bindings.RollupBlockPosted
-	maxBlockNumber := new(big.Int)
+			for iterator.Next() {
+				event := iterator.Event
+				events = append(events, event)
+				if event.BlockNumber.Cmp(maxBlockNumber) > 0 {
+					maxBlockNumber.Set(event.BlockNumber)
+				}
+			}
 
-	for iterator.Next() {
-		event := iterator.Event
-		events = append(events, event)
-		if event.BlockNumber.Cmp(maxBlockNumber) > 0 {
-			maxBlockNumber.Set(event.BlockNumber)
-		}
-	}
+			if errFilter = iterator.Error(); errFilter != nil {
+				return false, errors.Join(ErrEncounteredWhileIterating, errFilter)
+			}
 
-	if err = iterator.Error(); err != nil {
-		return nil, nil, errors.Join(ErrEncounteredWhileIterating, err)
+			return false, nil
+		},
+	)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	return events, maxBlockNumber, nil
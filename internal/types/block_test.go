@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"fmt"
 	"intmax2-node/internal/accounts"
+	"intmax2-node/internal/bindings"
 	"intmax2-node/internal/finite_field"
 	intMaxTypes "intmax2-node/internal/types"
 	"math/big"
@@ -178,6 +179,132 @@ func TestAccountIDBlockValidation(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPostNonRegistrationBlockCalldata(t *testing.T) {
+	const numOfAccounts = 10
+
+	keyPairs := make([]*accounts.PrivateKey, numOfAccounts)
+	for i := 0; i < len(keyPairs); i++ {
+		privateKey, err := rand.Int(rand.Reader, new(big.Int).Sub(fr.Modulus(), big.NewInt(1)))
+		require.NoError(t, err)
+		privateKey.Add(privateKey, big.NewInt(1))
+		keyPairs[i], err = accounts.NewPrivateKeyWithReCalcPubKeyIfPkNegates(privateKey)
+		require.NoError(t, err)
+	}
+
+	// Sort by x-coordinate of public key
+	sort.Slice(keyPairs, func(i, j int) bool {
+		return keyPairs[i].Pk.X.Cmp(&keyPairs[j].Pk.X) > 0
+	})
+
+	accountIDs := make([]uint64, intMaxTypes.NumOfSenders)
+	senders := make([]intMaxTypes.Sender, intMaxTypes.NumOfSenders)
+	for i, keyPair := range keyPairs {
+		senders[i] = intMaxTypes.Sender{
+			PublicKey: keyPair.Public(),
+			AccountID: uint64(i) + 2,
+			IsSigned:  true,
+		}
+		accountIDs[i] = senders[i].AccountID
+	}
+
+	defaultSender := intMaxTypes.NewDummySender()
+	for i := len(keyPairs); i < len(senders); i++ {
+		senders[i] = defaultSender
+		accountIDs[i] = defaultSender.AccountID
+	}
+
+	txRoot, err := new(intMaxTypes.PoseidonHashOut).SetRandom()
+	require.NoError(t, err)
+
+	blockContent := intMaxTypes.NewBlockContent(
+		intMaxTypes.AccountIDSenderType,
+		senders,
+		*txRoot,
+		new(bn254.G2Affine),
+	)
+
+	input, err := intMaxTypes.MakePostNonRegistrationBlockInput(blockContent)
+	require.NoError(t, err)
+
+	decodedAccountIDs, err := intMaxTypes.UnmarshalAccountIds(input.SenderAccountIds)
+	require.NoError(t, err)
+	require.Equal(t, accountIDs, decodedAccountIDs)
+	require.Len(t, input.SenderAccountIds, intMaxTypes.NumOfSenders*intMaxTypes.NumAccountIDBytes)
+
+	rollupAbi, err := bindings.RollupMetaData.GetAbi()
+	require.NoError(t, err)
+
+	calldata, err := rollupAbi.Pack(
+		"postNonRegistrationBlock",
+		input.TxTreeRoot,
+		input.SenderFlags,
+		input.AggregatedPublicKey,
+		input.AggregatedSignature,
+		input.MessagePoint,
+		input.PublicKeysHash,
+		input.SenderAccountIds,
+	)
+	require.NoError(t, err)
+	require.Equal(t, common.FromHex("0x842f1bfc"), calldata[:4])
+
+	method, err := rollupAbi.MethodById(calldata[:4])
+	require.NoError(t, err)
+	args, err := method.Inputs.Unpack(calldata[4:])
+	require.NoError(t, err)
+	require.Equal(t, input.TxTreeRoot, args[0].([32]byte))
+	require.Equal(t, input.SenderFlags, args[1].([16]byte))
+	require.Equal(t, input.PublicKeysHash, args[5].([32]byte))
+	require.Equal(t, input.SenderAccountIds, args[6].([]byte))
+
+	registrationInput, err := intMaxTypes.MakePostRegistrationBlockInput(blockContent)
+	require.NoError(t, err)
+
+	registrationCalldata, err := rollupAbi.Pack(
+		"postRegistrationBlock",
+		registrationInput.TxTreeRoot,
+		registrationInput.SenderFlags,
+		registrationInput.AggregatedPublicKey,
+		registrationInput.AggregatedSignature,
+		registrationInput.MessagePoint,
+		registrationInput.SenderPublicKeys,
+	)
+	require.NoError(t, err)
+	require.Less(t, len(calldata), len(registrationCalldata))
+}
+
+func TestMakeAccountIdsWithPublicKeySenderType(t *testing.T) {
+	privateKey, err := rand.Int(rand.Reader, new(big.Int).Sub(fr.Modulus(), big.NewInt(1)))
+	require.NoError(t, err)
+	privateKey.Add(privateKey, big.NewInt(1))
+	keyPair, err := accounts.NewPrivateKeyWithReCalcPubKeyIfPkNegates(privateKey)
+	require.NoError(t, err)
+
+	senders := make([]intMaxTypes.Sender, intMaxTypes.NumOfSenders)
+	senders[0] = intMaxTypes.Sender{
+		PublicKey: keyPair.Public(),
+		IsSigned:  true,
+	}
+	for i := 1; i < len(senders); i++ {
+		senders[i] = intMaxTypes.NewDummySender()
+	}
+
+	txRoot, err := new(intMaxTypes.PoseidonHashOut).SetRandom()
+	require.NoError(t, err)
+
+	blockContent := intMaxTypes.NewBlockContent(
+		intMaxTypes.PublicKeySenderType,
+		senders,
+		*txRoot,
+		new(bn254.G2Affine),
+	)
+
+	_, err = intMaxTypes.MakeAccountIds(blockContent)
+	require.ErrorIs(t, err, intMaxTypes.ErrBlockContentSenderTypeInvalid)
+
+	_, err = intMaxTypes.MakePostNonRegistrationBlockInput(blockContent)
+	require.ErrorIs(t, err, intMaxTypes.ErrBlockContentSenderTypeInvalid)
+}
+
 func TestMarshalAccountIds(t *testing.T) {
 	accountIds := []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
 	encodedAccountIds, err := intMaxTypes.MarshalAccountIds(accountIds)
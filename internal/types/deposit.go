@@ -37,16 +37,13 @@ func (dd *DepositLeaf) SetZero() *DepositLeaf {
 func (dd *DepositLeaf) Marshal() []byte {
 	const (
 		int4Key  = 4
-		int31Key = 31
 		int32Key = 32
 	)
 
 	tokenIndexBytes := make([]byte, int4Key)
 	binary.BigEndian.PutUint32(tokenIndexBytes, dd.TokenIndex)
 	amountBytes := make([]byte, int32Key)
-	for i, v := range dd.Amount.Bytes() {
-		amountBytes[int31Key-i] = v
-	}
+	dd.Amount.FillBytes(amountBytes)
 
 	return append(
 		append(dd.RecipientSaltHash[:], tokenIndexBytes...),
@@ -36,6 +36,7 @@ type GenericAddress struct {
 
 func (ga *GenericAddress) Set(genericAddress *GenericAddress) *GenericAddress {
 	ga.TypeOfAddress = genericAddress.TypeOfAddress
+	ga.Address = make([]byte, len(genericAddress.Address))
 	copy(ga.Address, genericAddress.Address)
 	return ga
 }
@@ -0,0 +1,22 @@
+package auth_challenge
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -destination=../mocks/mock_auth_challenge.go -package=mocks -source=auth_challenge.go
+
+type UCAuthChallenge struct {
+	Nonce     string    `json:"nonce"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type UCAuthChallengeInput struct {
+	Address string `json:"address"`
+}
+
+// UseCaseAuthChallenge describes AuthChallenge contract.
+type UseCaseAuthChallenge interface {
+	Do(ctx context.Context, input *UCAuthChallengeInput) (*UCAuthChallenge, error)
+}
@@ -0,0 +1,24 @@
+package auth_login
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -destination=../mocks/mock_auth_login.go -package=mocks -source=auth_login.go
+
+type UCAuthLogin struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type UCAuthLoginInput struct {
+	Address   string `json:"address"`
+	Nonce     string `json:"nonce"`
+	Signature string `json:"signature"`
+}
+
+// UseCaseAuthLogin describes AuthLogin contract.
+type UseCaseAuthLogin interface {
+	Do(ctx context.Context, input *UCAuthLoginInput) (*UCAuthLogin, error)
+}
@@ -0,0 +1,30 @@
+package auth_login
+
+import (
+	"errors"
+	"intmax2-node/internal/store_vault_auth"
+
+	"github.com/prodadidb/go-validation"
+)
+
+// ErrValueInvalid error: value must be valid.
+var ErrValueInvalid = errors.New("must be a valid value")
+
+func (input *UCAuthLoginInput) Valid() error {
+	return validation.ValidateStruct(input,
+		validation.Field(&input.Address, validation.Required, validation.By(func(value interface{}) error {
+			v, ok := value.(string)
+			if !ok {
+				return ErrValueInvalid
+			}
+
+			if _, err := store_vault_auth.NormalizeAddress(v); err != nil {
+				return ErrValueInvalid
+			}
+
+			return nil
+		})),
+		validation.Field(&input.Nonce, validation.Required),
+		validation.Field(&input.Signature, validation.Required),
+	)
+}
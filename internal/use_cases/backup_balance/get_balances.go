@@ -32,10 +32,22 @@ type UCGetBalances struct {
 	Deposits     []*BackupDeposit     `json:"deposits"`
 	Transfers    []*BackupTransfer    `json:"transfers"`
 	Transactions []*BackupTransaction `json:"transactions"`
+	Meta         *UCGetBalancesMeta   `json:"meta"`
+}
+
+type UCGetBalancesMeta struct {
+	HasMore bool `json:"hasMore"`
+	// NextCursor is the sequence number, from which the next page starts.
+	NextCursor string `json:"nextCursor"`
 }
 
 type UCGetBalancesInput struct {
-	Address string `json:"address"`
+	Address    string `json:"address"`
+	// SinceBlock is the block number, before which the backups are skipped.
+	SinceBlock uint64 `json:"sinceBlock"`
+	// Cursor is the NextCursor of the previous page.
+	Cursor string `json:"cursor"`
+	Limit  uint64 `json:"limit"`
 }
 
 // UseCaseGetBalances describes GetBalances contract.
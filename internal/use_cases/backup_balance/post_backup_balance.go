@@ -2,6 +2,8 @@ package backup_balance
 
 import (
 	"context"
+	"encoding/binary"
+	"errors"
 	intMaxAcc "intmax2-node/internal/accounts"
 	"intmax2-node/internal/finite_field"
 	"intmax2-node/internal/hash/goldenposeidon"
@@ -24,6 +26,20 @@ const (
 const NUM_TRANSFERS_IN_TX uint = 64
 const INSUFFICIENT_FLAGS_LEN uint = NUM_TRANSFERS_IN_TX / 32
 
+const (
+	U256_LEN    uint = 8
+	BYTES32_LEN uint = 8
+
+	PUBLIC_STATE_LEN          uint = 3*goldenposeidon.NUM_HASH_OUT_ELTS + 2*BYTES32_LEN + 1
+	BALANCE_PUBLIC_INPUTS_LEN uint = U256_LEN + 2*goldenposeidon.NUM_HASH_OUT_ELTS + INSUFFICIENT_FLAGS_LEN + PUBLIC_STATE_LEN
+)
+
+// ErrBalancePublicInputsLengthInvalid error: the length of balance public inputs is invalid.
+var ErrBalancePublicInputsLengthInvalid = errors.New("the length of balance public inputs is invalid")
+
+// ErrBalancePublicInputsLimbInvalid error: the limb of balance public inputs is not a 32-bit value.
+var ErrBalancePublicInputsLimbInvalid = errors.New("the limb of balance public inputs is not a 32-bit value")
+
 type InsufficientFlags struct {
 	Limbs [INSUFFICIENT_FLAGS_LEN]uint32
 }
@@ -68,7 +84,11 @@ type BalancePublicInputs struct {
 }
 
 func (pis *BalancePublicInputs) Equal(other *BalancePublicInputs) bool {
-	if pis.PublicKey.Cmp(other.PublicKey) != 0 {
+	if pis.PublicKey == nil || other.PublicKey == nil {
+		if pis.PublicKey != other.PublicKey {
+			return false
+		}
+	} else if pis.PublicKey.Cmp(other.PublicKey) != 0 {
 		return false
 	}
 	if !pis.PrivateCommitment.Equal(&other.PrivateCommitment) {
@@ -91,8 +111,12 @@ func VerifyEnoughBalanceProof(enoughBalanceProof *block_signature.Plonky2Proof)
 	for i, publicInput := range enoughBalanceProof.PublicInputs {
 		publicInputs[i].SetUint64(publicInput)
 	}
-	decodedPublicInputs := new(BalancePublicInputs).FromPublicInputs(publicInputs)
-	err := decodedPublicInputs.Verify()
+	decodedPublicInputs, err := new(BalancePublicInputs).FromPublicInputs(publicInputs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = decodedPublicInputs.Verify()
 	if err != nil {
 		return nil, err
 	}
@@ -102,16 +126,90 @@ func VerifyEnoughBalanceProof(enoughBalanceProof *block_signature.Plonky2Proof)
 	// TODO: Verify enough balance proof by using Balance Validity Prover.
 	return decodedPublicInputs, nil
 }
-func (pis *BalancePublicInputs) FromPublicInputs(publicInputs []ffg.Element) *BalancePublicInputs {
-	// TODO: Implement this function
 
-	return pis
+// FromPublicInputs decodes the balance public inputs from the head of the public inputs of the balance proof.
+// The public inputs of the balance proof are followed by the verifier data of the balance circuit.
+func (pis *BalancePublicInputs) FromPublicInputs(publicInputs []ffg.Element) (*BalancePublicInputs, error) {
+	if uint(len(publicInputs)) < BALANCE_PUBLIC_INPUTS_LEN {
+		return nil, ErrBalancePublicInputsLengthInvalid
+	}
+
+	r := publicInputsReader{publicInputs: publicInputs}
+
+	pubKey := r.limbs(U256_LEN)
+	pis.PublicKey = new(big.Int)
+	for i := range pubKey {
+		pis.PublicKey.Lsh(pis.PublicKey, int32Key)
+		pis.PublicKey.Or(pis.PublicKey, new(big.Int).SetUint64(uint64(pubKey[i])))
+	}
+	pis.PrivateCommitment = r.poseidonHashOut()
+	pis.LastTxHash = r.poseidonHashOut()
+	copy(pis.LastTxInsufficientFlags.Limbs[:], r.limbs(INSUFFICIENT_FLAGS_LEN))
+
+	pis.PublicState.BlockTreeRoot = r.poseidonHashOut()
+	pis.PublicState.PrevAccountTreeRoot = r.poseidonHashOut()
+	pis.PublicState.AccountTreeRoot = r.poseidonHashOut()
+	pis.PublicState.DepositTreeRoot = r.bytes32()
+	pis.PublicState.BlockHash = r.bytes32()
+	pis.PublicState.BlockNumber = r.limbs(1)[0]
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return pis, nil
 }
 
 func (pis *BalancePublicInputs) Verify() error {
 	return nil
 }
 
+const int32Key = 32
+
+type publicInputsReader struct {
+	publicInputs []ffg.Element
+	offset       int
+	err          error
+}
+
+func (r *publicInputsReader) next() uint64 {
+	v := r.publicInputs[r.offset].ToUint64Regular()
+	r.offset++
+
+	return v
+}
+
+// limbs reads the 32-bit limbs, the most significant limb first.
+func (r *publicInputsReader) limbs(n uint) []uint32 {
+	limbs := make([]uint32, n)
+	for i := range limbs {
+		v := r.next()
+		if v>>int32Key != 0 {
+			r.err = ErrBalancePublicInputsLimbInvalid
+		}
+		limbs[i] = uint32(v)
+	}
+
+	return limbs
+}
+
+func (r *publicInputsReader) bytes32() (b [32]byte) {
+	const numLimbBytes = 4
+	for i, limb := range r.limbs(BYTES32_LEN) {
+		binary.BigEndian.PutUint32(b[i*numLimbBytes:], limb)
+	}
+
+	return b
+}
+
+func (r *publicInputsReader) poseidonHashOut() (h goldenposeidon.PoseidonHashOut) {
+	for i := range h.Elements {
+		h.Elements[i].SetUint64(r.next())
+	}
+
+	return h
+}
+
 type EncryptedPlonky2Proof struct {
 	Proof                 string `json:"proof"`
 	EncryptedPublicInputs string `json:"publicInputs"`
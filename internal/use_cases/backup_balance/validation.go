@@ -2,6 +2,7 @@ package backup_balance
 
 import (
 	"errors"
+	"strconv"
 
 	"github.com/prodadidb/go-validation"
 )
@@ -16,6 +17,18 @@ var ErrValueInvalid = errors.New("must be a valid value")
 func (input *UCGetBalancesInput) Valid() error {
 	return validation.ValidateStruct(input,
 		validation.Field(&input.Address, validation.Required),
+		validation.Field(&input.Cursor, validation.By(func(value interface{}) error {
+			v, _ := value.(string)
+			if v == "" {
+				return nil
+			}
+
+			if _, err := strconv.ParseUint(v, Base10, 64); err != nil {
+				return ErrValueInvalid
+			}
+
+			return nil
+		})),
 	)
 }
 
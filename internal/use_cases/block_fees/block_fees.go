@@ -0,0 +1,35 @@
+package block_fees
+
+import (
+	"context"
+)
+
+//go:generate mockgen -destination=../mocks/mock_block_fees.go -package=mocks -source=block_fees.go
+
+type UCBlockFeesInput struct {
+	TxTreeRoot string `json:"txTreeRoot"`
+}
+
+type UCBlockFee struct {
+	TxHash       string `json:"txHash"`
+	Sender       string `json:"sender"`
+	TokenIndex   uint32 `json:"tokenIndex"`
+	Amount       string `json:"amount"`
+	TransferHash string `json:"transferHash"`
+}
+
+type UCBlockFeeTotal struct {
+	TokenIndex uint32 `json:"tokenIndex"`
+	Amount     string `json:"amount"`
+}
+
+type UCBlockFees struct {
+	TxTreeRoot string             `json:"txTreeRoot"`
+	Fees       []*UCBlockFee      `json:"fees"`
+	Totals     []*UCBlockFeeTotal `json:"totals"`
+}
+
+// UseCaseBlockFees describes BlockFees contract.
+type UseCaseBlockFees interface {
+	Do(ctx context.Context, input *UCBlockFeesInput) (*UCBlockFees, error)
+}
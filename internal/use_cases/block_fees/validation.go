@@ -0,0 +1,40 @@
+package block_fees
+
+import (
+	"errors"
+	intMaxTypes "intmax2-node/internal/types"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prodadidb/go-validation"
+)
+
+// ErrValueInvalid error: value must be valid.
+var ErrValueInvalid = errors.New("must be a valid value")
+
+func (input *UCBlockFeesInput) Valid() error {
+	return validation.ValidateStruct(input,
+		validation.Field(&input.TxTreeRoot, validation.Required, input.isPoseidonHashOut()),
+	)
+}
+
+func (input *UCBlockFeesInput) isPoseidonHashOut() validation.Rule {
+	return validation.By(func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return ErrValueInvalid
+		}
+
+		d, err := hexutil.Decode(v)
+		if err != nil {
+			return ErrValueInvalid
+		}
+
+		var ph intMaxTypes.PoseidonHashOut
+		err = ph.Unmarshal(d)
+		if err != nil {
+			return ErrValueInvalid
+		}
+
+		return nil
+	})
+}
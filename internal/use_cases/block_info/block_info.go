@@ -1,6 +1,9 @@
 package block_info
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 //go:generate mockgen -destination=../mocks/mock_block_info.go -package=mocks -source=block_info.go
 
@@ -8,7 +11,10 @@ type UCBlockInfo struct {
 	ScrollAddress string            `json:"scrollAddress"`
 	IntMaxAddress string            `json:"intMaxAddress"`
 	TransferFee   map[string]string `json:"transferFee"`
-	Difficulty    int64             `json:"difficulty"`
+	// QuotedTransferFee is the lowest transfer fee quoted to the clients within GAS_PRICE_ORACLE_QUOTE_TTL.
+	QuotedTransferFee    map[string]string `json:"quotedTransferFee"`
+	Difficulty           int64             `json:"difficulty"`
+	DifficultyValidUntil time.Time         `json:"difficultyValidUntil"`
 }
 
 // UseCaseBlockInfo describes BlockInfo contract.
@@ -0,0 +1,29 @@
+package block_merkle_proof
+
+import (
+	"context"
+)
+
+//go:generate mockgen -destination=../mocks/mock_block_merkle_proof.go -package=mocks -source=block_merkle_proof.go
+
+const (
+	NotFoundMessage = "Root block not found in the block hash tree yet."
+)
+
+type UCBlockMerkleProofInput struct {
+	BlockNumber     uint32 `json:"blockNumber"`
+	RootBlockNumber uint32 `json:"rootBlockNumber"`
+}
+
+type UCBlockMerkleProof struct {
+	BlockNumber     uint32   `json:"blockNumber"`
+	BlockHash       string   `json:"blockHash"`
+	RootBlockNumber uint32   `json:"rootBlockNumber"`
+	Siblings        []string `json:"siblings"`
+	Root            string   `json:"root"`
+}
+
+// UseCaseBlockMerkleProof describes BlockMerkleProof contract.
+type UseCaseBlockMerkleProof interface {
+	Do(ctx context.Context, input *UCBlockMerkleProofInput) (*UCBlockMerkleProof, error)
+}
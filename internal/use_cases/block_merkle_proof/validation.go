@@ -0,0 +1,31 @@
+package block_merkle_proof
+
+import (
+	"errors"
+
+	"github.com/prodadidb/go-validation"
+)
+
+// ErrValueInvalid error: value must be valid.
+var ErrValueInvalid = errors.New("must be a valid value")
+
+func (input *UCBlockMerkleProofInput) Valid() error {
+	return validation.ValidateStruct(input,
+		validation.Field(&input.RootBlockNumber, input.isRootBlockNumber()),
+	)
+}
+
+func (input *UCBlockMerkleProofInput) isRootBlockNumber() validation.Rule {
+	return validation.By(func(value interface{}) error {
+		v, ok := value.(uint32)
+		if !ok {
+			return ErrValueInvalid
+		}
+
+		if v < input.BlockNumber {
+			return ErrValueInvalid
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,32 @@
+package block_status_subscribe
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -destination=../mocks/mock_block_status_subscribe.go -package=mocks -source=block_status_subscribe.go
+
+type UCBlockStatusSubscribeInput struct {
+	TxHash     string `json:"txHash"`
+	TxTreeRoot string `json:"txTreeRoot"`
+}
+
+type UCBlockStatusEvent struct {
+	Event       string    `json:"event"`
+	TxHash      string    `json:"txHash"`
+	TxTreeRoot  string    `json:"txTreeRoot"`
+	BlockNumber uint64    `json:"blockNumber"`
+	BlockHash   string    `json:"blockHash"`
+	Reason      string    `json:"reason"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// UseCaseBlockStatusSubscribe describes BlockStatusSubscribe contract.
+type UseCaseBlockStatusSubscribe interface {
+	Do(
+		ctx context.Context,
+		input *UCBlockStatusSubscribeInput,
+		send func(event *UCBlockStatusEvent) error,
+	) error
+}
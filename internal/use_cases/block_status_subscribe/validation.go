@@ -0,0 +1,53 @@
+package block_status_subscribe
+
+import (
+	"errors"
+	intMaxTypes "intmax2-node/internal/types"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prodadidb/go-validation"
+)
+
+// ErrValueInvalid error: value must be valid.
+var ErrValueInvalid = errors.New("must be a valid value")
+
+func (input *UCBlockStatusSubscribeInput) Valid() error {
+	return validation.ValidateStruct(input,
+		validation.Field(&input.TxHash,
+			validation.When(input.TxTreeRoot == "", validation.Required),
+			// the tx hash and the tx tree root are not used together
+			validation.When(input.TxTreeRoot != "", validation.Empty),
+			input.isPoseidonHashOut(),
+		),
+		validation.Field(&input.TxTreeRoot,
+			validation.When(input.TxHash == "", validation.Required),
+			input.isPoseidonHashOut(),
+		),
+	)
+}
+
+func (input *UCBlockStatusSubscribeInput) isPoseidonHashOut() validation.Rule {
+	return validation.By(func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return ErrValueInvalid
+		}
+
+		if v == "" {
+			return nil
+		}
+
+		d, err := hexutil.Decode(v)
+		if err != nil {
+			return ErrValueInvalid
+		}
+
+		var ph intMaxTypes.PoseidonHashOut
+		err = ph.Unmarshal(d)
+		if err != nil {
+			return ErrValueInvalid
+		}
+
+		return nil
+	})
+}
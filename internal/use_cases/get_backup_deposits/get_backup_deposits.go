@@ -11,6 +11,7 @@ type UCGetBackupDepositsInput struct {
 	Sender           string `json:"sender"`
 	StartBlockNumber uint64 `json:"startBlockNumber"`
 	Limit            uint64 `json:"limit"`
+	StartSeq         uint64 `json:"startSeq"`
 }
 
 // UseCaseGetBackupDeposits describes GetBackupDeposits contract.
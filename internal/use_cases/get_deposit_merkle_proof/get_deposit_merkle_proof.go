@@ -0,0 +1,23 @@
+package get_deposit_merkle_proof
+
+import (
+	"context"
+	node "intmax2-node/internal/pb/gen/store_vault_service/node"
+)
+
+//go:generate mockgen -destination=../mocks/mock_get_deposit_merkle_proof.go -package=mocks -source=get_deposit_merkle_proof.go
+
+const (
+	NotFoundMessage = "Deposit not found or not included in the deposit tree yet."
+)
+
+type UCGetDepositMerkleProofInput struct {
+	DepositID   string `json:"depositId"`
+	DepositHash string `json:"depositHash"`
+}
+
+type UseCaseGetDepositMerkleProof interface {
+	Do(
+		ctx context.Context, input *UCGetDepositMerkleProofInput,
+	) (*node.GetDepositMerkleProofResponse_Data, error)
+}
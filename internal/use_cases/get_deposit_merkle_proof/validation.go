@@ -0,0 +1,65 @@
+package get_deposit_merkle_proof
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prodadidb/go-validation"
+)
+
+const (
+	base10        = 10
+	int32Key      = 32
+	int64Key      = 64
+	emptyValueKey = ""
+)
+
+// ErrValueInvalid error: value must be valid.
+var ErrValueInvalid = errors.New("must be a valid value")
+
+// ErrDepositIDOrDepositHashRequired error: exactly one of the deposit ID and the deposit hash must be set.
+var ErrDepositIDOrDepositHashRequired = errors.New("exactly one of the deposit ID and the deposit hash must be set")
+
+func (input *UCGetDepositMerkleProofInput) Valid() error {
+	if (input.DepositID == emptyValueKey) == (input.DepositHash == emptyValueKey) {
+		return ErrDepositIDOrDepositHashRequired
+	}
+
+	return validation.ValidateStruct(input,
+		validation.Field(&input.DepositID, input.isDepositID()),
+		validation.Field(&input.DepositHash, input.isDepositHash()),
+	)
+}
+
+func (input *UCGetDepositMerkleProofInput) isDepositID() validation.Rule {
+	return validation.By(func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok || v == emptyValueKey {
+			return nil
+		}
+
+		_, err := strconv.ParseUint(v, base10, int64Key)
+		if err != nil {
+			return ErrValueInvalid
+		}
+
+		return nil
+	})
+}
+
+func (input *UCGetDepositMerkleProofInput) isDepositHash() validation.Rule {
+	return validation.By(func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok || v == emptyValueKey {
+			return nil
+		}
+
+		b, err := hexutil.Decode(v)
+		if err != nil || len(b) != int32Key {
+			return ErrValueInvalid
+		}
+
+		return nil
+	})
+}
@@ -46,6 +46,7 @@ type UCPostWithdrawalRequestInput struct {
 	BlockNumber         int64                                            `json:"blockNumber"`
 	BlockHash           string                                           `json:"blockHash"`
 	EnoughBalanceProof  *UCPostWithdrawalRequestEnoughBalanceProofInput  `json:"enoughBalanceProof"`
+	Sender              string                                           `json:"sender"`
 }
 
 /**
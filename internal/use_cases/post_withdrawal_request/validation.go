@@ -2,6 +2,7 @@ package post_withdrawal_request
 
 import (
 	"errors"
+	intMaxAcc "intmax2-node/internal/accounts"
 	"math/big"
 
 	"github.com/prodadidb/go-validation"
@@ -30,6 +31,7 @@ func (input *UCPostWithdrawalRequestInput) Valid() error {
 		validation.Field(&input.BlockNumber, validation.Required),
 		validation.Field(&input.BlockHash, validation.Required),
 		validation.Field(&input.EnoughBalanceProof, validation.Required, input.validateEnoughBalanceProof()),
+		validation.Field(&input.Sender, validation.Required, input.validateSender()),
 	)
 }
 
@@ -163,3 +165,19 @@ func (input *UCPostWithdrawalRequestInput) validateEnoughBalanceProof() validati
 		)
 	})
 }
+
+func (input *UCPostWithdrawalRequestInput) validateSender() validation.Rule {
+	return validation.By(func(value interface{}) error {
+		sender, ok := value.(string)
+		if !ok {
+			return ErrValueInvalid
+		}
+
+		_, err := intMaxAcc.NewAddressFromHex(sender)
+		if err != nil {
+			return ErrValueInvalid
+		}
+
+		return nil
+	})
+}
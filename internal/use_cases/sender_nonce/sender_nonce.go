@@ -0,0 +1,23 @@
+package sender_nonce
+
+import (
+	"context"
+	intMaxAcc "intmax2-node/internal/accounts"
+)
+
+//go:generate mockgen -destination=../mocks/mock_sender_nonce.go -package=mocks -source=sender_nonce.go
+
+type UCSenderNonceInput struct {
+	Sender       string               `json:"sender"`
+	DecodeSender *intMaxAcc.PublicKey `json:"-"`
+}
+
+type UCSenderNonce struct {
+	Sender string `json:"sender"`
+	Nonce  uint64 `json:"nonce"`
+}
+
+// UseCaseSenderNonce describes SenderNonce contract.
+type UseCaseSenderNonce interface {
+	Do(ctx context.Context, input *UCSenderNonceInput) (*UCSenderNonce, error)
+}
@@ -0,0 +1,35 @@
+package sender_nonce
+
+import (
+	"errors"
+	intMaxAcc "intmax2-node/internal/accounts"
+
+	"github.com/prodadidb/go-validation"
+)
+
+// ErrValueInvalid error: value must be valid.
+var ErrValueInvalid = errors.New("must be a valid value")
+
+func (input *UCSenderNonceInput) Valid() error {
+	return validation.ValidateStruct(input,
+		validation.Field(&input.Sender, validation.Required, input.isSender()),
+	)
+}
+
+func (input *UCSenderNonceInput) isSender() validation.Rule {
+	return validation.By(func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return ErrValueInvalid
+		}
+
+		publicKey, err := intMaxAcc.NewPublicKeyFromAddressHex(v)
+		if err != nil {
+			return ErrValueInvalid
+		}
+
+		input.DecodeSender = publicKey
+
+		return nil
+	})
+}
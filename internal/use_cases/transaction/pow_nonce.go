@@ -7,4 +7,5 @@ import "context"
 type PoWNonce interface {
 	Nonce(ctx context.Context, msg []byte) (nonce string, err error)
 	Verify(nonce string, msg []byte) error
+	VerifyWithDifficulty(nonce string, msg []byte, difficulty uint64) error
 }
@@ -0,0 +1,7 @@
+package transaction
+
+//go:generate mockgen -destination=mock_sender_nonce_test.go -package=transaction_test -source=sender_nonce.go
+
+type SenderNonce interface {
+	NextNonce(sender string) (nonce uint64, err error)
+}
@@ -45,16 +45,28 @@ type BackupTransferInput struct {
 	EncodedEncryptedTransfer string `json:"encryptedTransfer"`
 }
 
+type FeeTransferTransaction struct {
+	Recipient      string                `json:"recipient"`
+	TokenIndex     uint32                `json:"tokenIndex"`
+	Amount         string                `json:"amount"`
+	Salt           string                `json:"salt"`
+	TransferIndex  uint64                `json:"transferIndex"`
+	MerkleProof    []string              `json:"merkleProof"`
+	DecodeTransfer *intMaxTypes.Transfer `json:"-"`
+}
+
 type UCTransactionInput struct {
 	Sender             string                     `json:"sender"`
 	DecodeSender       *intMaxAcc.PublicKey       `json:"-"`
 	TransfersHash      string                     `json:"transfersHash"`
 	Nonce              uint64                     `json:"nonce"`
 	PowNonce           string                     `json:"powNonce"`
+	PowDifficulty      uint64                     `json:"-"`
 	TransferData       []*TransferDataTransaction `json:"transferData"`
 	DecodeTransferData []*intMaxTypes.Transfer    `json:"-"`
 	Expiration         time.Time                  `json:"expiration"`
 	Signature          string                     `json:"signature"`
+	FeeTransfer        *FeeTransferTransaction    `json:"feeTransfer"`
 }
 
 // UseCaseTransaction describes Transaction contract.
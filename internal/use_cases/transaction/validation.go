@@ -5,7 +5,12 @@ import (
 	"fmt"
 	"intmax2-node/configs"
 	intMaxAcc "intmax2-node/internal/accounts"
+	intMaxTree "intmax2-node/internal/tree"
 	intMaxTypes "intmax2-node/internal/types"
+	blockInfo "intmax2-node/internal/use_cases/block_info"
+	"math/big"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/consensys/gnark-crypto/ecc/bn254"
@@ -27,10 +32,40 @@ var ErrFailToDecodeTransfersHash = errors.New("failed to decode transfers hash")
 // ErrFailToVerifyPoWNonce error: failed to verify PoW nonce.
 var ErrFailToVerifyPoWNonce = errors.New("failed to verify PoW nonce")
 
+const powNonceDifficultyMsg = "%w: the difficulty required from the sender is %d"
+
 // ErrMoreThenZero error: must be more then 0.
 var ErrMoreThenZero = errors.New("must be more then 0")
 
-func (input *UCTransactionInput) Valid(cfg *configs.Config, pow PoWNonce) error {
+// ErrNonceAlreadyUsed error: nonce has already been used.
+var ErrNonceAlreadyUsed = errors.New("nonce has already been used")
+
+// ErrNonceOutOfOrder error: nonce must be equal to the next nonce of sender.
+var ErrNonceOutOfOrder = errors.New("nonce must be equal to the next nonce of sender")
+
+// ErrFailToGetNextNonce error: failed to get the next nonce of sender.
+var ErrFailToGetNextNonce = errors.New("failed to get the next nonce of sender")
+
+// ErrFeeRecipientInvalid error: must be the INTMAX address of the block builder.
+var ErrFeeRecipientInvalid = errors.New("must be the INTMAX address of the block builder")
+
+// ErrFeeAmountInsufficient error: must be equal to or greater than the transfer fee of the block builder.
+var ErrFeeAmountInsufficient = errors.New("must be equal to or greater than the transfer fee of the block builder")
+
+// ErrFeeTokenNotSupported error: the transfer fee of the token is not supported by the block builder.
+var ErrFeeTokenNotSupported = errors.New("the transfer fee of the token is not supported by the block builder")
+
+// ErrFeeTransferMerkleProofInvalid error: the Merkle proof of the fee transfer does not lead to the transfers hash.
+var ErrFeeTransferMerkleProofInvalid = errors.New(
+	"the Merkle proof of the fee transfer does not lead to the transfers hash",
+)
+
+func (input *UCTransactionInput) Valid(
+	cfg *configs.Config,
+	pow PoWNonce,
+	sn SenderNonce,
+	info *blockInfo.UCBlockInfo,
+) error {
 	// var (
 	// 	iTxData int
 	// )
@@ -60,7 +95,7 @@ func (input *UCTransactionInput) Valid(cfg *configs.Config, pow PoWNonce) error
 		// 		return input.TransferData[iTxData-1]
 		// 	}()),
 		// ), input.checkHashWithData(&input.TransfersHash)),
-		validation.Field(&input.Nonce, validation.Required, input.nonceMaxLength(cfg)),
+		validation.Field(&input.Nonce, validation.Required, input.nonceMaxLength(cfg), input.isNextNonce(sn)),
 		validation.Field(&input.Expiration, validation.Required, validation.By(func(value interface{}) error {
 			v, ok := value.(time.Time)
 			if !ok {
@@ -73,6 +108,7 @@ func (input *UCTransactionInput) Valid(cfg *configs.Config, pow PoWNonce) error
 
 			return nil
 		})),
+		validation.Field(&input.FeeTransfer, validation.Required, input.isFeeTransfer(info)),
 		validation.Field(&input.Signature, validation.Required, validation.By(func(value interface{}) error {
 			v, ok := value.(string)
 			if !ok {
@@ -120,6 +156,169 @@ func (input *UCTransactionInput) Valid(cfg *configs.Config, pow PoWNonce) error
 	)
 }
 
+func (input *UCTransactionInput) isFeeTransfer(info *blockInfo.UCBlockInfo) validation.Rule {
+	return validation.By(func(value interface{}) error {
+		var isNil bool
+		value, isNil = validation.Indirect(value)
+		if isNil || validation.IsEmpty(value) {
+			return ErrValueInvalid
+		}
+
+		ft, ok := value.(FeeTransferTransaction)
+		if !ok {
+			return ErrValueInvalid
+		}
+
+		err := validation.ValidateStruct(&ft,
+			validation.Field(&ft.Recipient, validation.Required, input.isFeeRecipient(info)),
+			validation.Field(&ft.Amount, validation.Required, input.isFeeAmount(info, ft.TokenIndex)),
+			validation.Field(&ft.Salt, validation.Required, input.isPoseidonHashOut()),
+			validation.Field(&ft.TransferIndex, validation.Max(uint64(1<<intMaxTree.TRANSFER_TREE_HEIGHT-1))),
+			validation.Field(&ft.MerkleProof, validation.Required,
+				validation.Length(intMaxTree.TRANSFER_TREE_HEIGHT, intMaxTree.TRANSFER_TREE_HEIGHT),
+				validation.Each(input.isPoseidonHashOut()),
+			),
+		)
+		if err != nil {
+			return err
+		}
+
+		transfer, err := ft.transfer()
+		if err != nil {
+			return ErrValueInvalid
+		}
+
+		transfersHash := new(intMaxTypes.PoseidonHashOut)
+		err = transfersHash.FromString(input.TransfersHash)
+		if err != nil {
+			// the transfers hash is validated separately
+			return nil
+		}
+
+		siblings := make([]*intMaxTypes.PoseidonHashOut, len(ft.MerkleProof))
+		for key := range ft.MerkleProof {
+			siblings[key] = new(intMaxTypes.PoseidonHashOut)
+			err = siblings[key].FromString(ft.MerkleProof[key])
+			if err != nil {
+				return ErrValueInvalid
+			}
+		}
+
+		root := intMaxTree.ComputeMerkleRootFromProof(transfer.Hash(), ft.TransferIndex, siblings)
+		if !root.Equal(transfersHash) {
+			return ErrFeeTransferMerkleProofInvalid
+		}
+
+		input.FeeTransfer.DecodeTransfer = transfer
+
+		return nil
+	})
+}
+
+func (input *UCTransactionInput) isFeeRecipient(info *blockInfo.UCBlockInfo) validation.Rule {
+	return validation.By(func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return ErrValueInvalid
+		}
+
+		address, err := intMaxAcc.NewAddressFromHex(v)
+		if err != nil {
+			return ErrValueInvalid
+		}
+
+		if !strings.EqualFold(address.String(), info.IntMaxAddress) {
+			return ErrFeeRecipientInvalid
+		}
+
+		return nil
+	})
+}
+
+func (input *UCTransactionInput) isFeeAmount(info *blockInfo.UCBlockInfo, tokenIndex uint32) validation.Rule {
+	const int10Key = 10
+
+	return validation.By(func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return ErrValueInvalid
+		}
+
+		amount, ok := new(big.Int).SetString(v, int10Key)
+		if !ok || amount.Sign() < 0 {
+			return ErrValueInvalid
+		}
+
+		// Only the tokens with the configured fee are accepted as the fee.
+		// The fee quoted to the client before the last update of the gas price oracle is still accepted.
+		fee, ok := info.QuotedTransferFee[strconv.FormatUint(uint64(tokenIndex), int10Key)]
+		if !ok {
+			return ErrFeeTokenNotSupported
+		}
+
+		minAmount, ok := new(big.Int).SetString(fee, int10Key)
+		if !ok {
+			return ErrFeeTokenNotSupported
+		}
+
+		if amount.Cmp(minAmount) < 0 {
+			return ErrFeeAmountInsufficient
+		}
+
+		return nil
+	})
+}
+
+func (input *UCTransactionInput) isPoseidonHashOut() validation.Rule {
+	return validation.By(func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return ErrValueInvalid
+		}
+
+		d, err := hexutil.Decode(v)
+		if err != nil {
+			return ErrValueInvalid
+		}
+
+		var ph intMaxTypes.PoseidonHashOut
+		err = ph.Unmarshal(d)
+		if err != nil {
+			return ErrValueInvalid
+		}
+
+		return nil
+	})
+}
+
+// transfer returns the fee transfer that is the leaf of the transfer tree.
+func (ft *FeeTransferTransaction) transfer() (*intMaxTypes.Transfer, error) {
+	const int10Key = 10
+
+	publicKey, err := intMaxAcc.NewPublicKeyFromAddressHex(ft.Recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	recipient, err := intMaxTypes.NewINTMAXAddress(publicKey.ToAddress().Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	amount, ok := new(big.Int).SetString(ft.Amount, int10Key)
+	if !ok {
+		return nil, ErrValueInvalid
+	}
+
+	salt := new(intMaxTypes.PoseidonHashOut)
+	err = salt.FromString(ft.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	return intMaxTypes.NewTransfer(recipient, ft.TokenIndex, amount, salt), nil
+}
+
 func (input *UCTransactionInput) isHexDecode() validation.Rule {
 	return validation.By(func(value interface{}) error {
 		v, ok := value.(string)
@@ -182,6 +381,15 @@ func (input *UCTransactionInput) isPoW(pow PoWNonce) validation.Rule {
 		txHash := tx.Hash()
 
 		messageForPow := txHash.Marshal()
+		if input.PowDifficulty > 0 {
+			err = pow.VerifyWithDifficulty(v, messageForPow, input.PowDifficulty)
+			if err != nil {
+				return fmt.Errorf(powNonceDifficultyMsg, ErrFailToVerifyPoWNonce, input.PowDifficulty)
+			}
+
+			return nil
+		}
+
 		err = pow.Verify(v, messageForPow)
 		if err != nil {
 			return ErrFailToVerifyPoWNonce
@@ -318,6 +526,36 @@ func (input *UCTransactionInput) nonceMaxLength(*configs.Config) validation.Rule
 	})
 }
 
+func (input *UCTransactionInput) isNextNonce(sn SenderNonce) validation.Rule {
+	return validation.By(func(value interface{}) error {
+		v, ok := value.(uint64)
+		if !ok {
+			return ErrValueInvalid
+		}
+
+		_, err := intMaxAcc.NewPublicKeyFromAddressHex(input.Sender)
+		if err != nil {
+			// the sender is validated separately
+			return nil
+		}
+
+		var nextNonce uint64
+		nextNonce, err = sn.NextNonce(input.Sender)
+		if err != nil {
+			return ErrFailToGetNextNonce
+		}
+
+		switch {
+		case v < nextNonce:
+			return ErrNonceAlreadyUsed
+		case v > nextNonce:
+			return ErrNonceOutOfOrder
+		}
+
+		return nil
+	})
+}
+
 // func (input *UCTransactionInput) transferDataLength(cfg *configs.Config) validation.Rule {
 // 	return validation.By(func(value interface{}) error {
 // 		var isNil bool
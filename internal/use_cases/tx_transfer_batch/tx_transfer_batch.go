@@ -0,0 +1,11 @@
+package tx_transfer_batch
+
+import (
+	"context"
+)
+
+//go:generate mockgen -destination=../mocks/mock_tx_transfer_batch.go -package=mocks -source=tx_transfer_batch.go
+
+type UseCaseTxTransferBatch interface {
+	Do(ctx context.Context, filePath string, feeTokenIndex uint32, userPrivateKey string) error
+}
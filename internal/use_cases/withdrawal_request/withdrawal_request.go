@@ -23,6 +23,7 @@ type UCWithdrawalInput struct {
 	BlockNumber         uint32                   `json:"blockNumber"`
 	BlockHash           string                   `json:"blockHash"`
 	EnoughBalanceProof  EnoughBalanceProof       `json:"enoughBalanceProof"`
+	Sender              string                   `json:"sender"`
 }
 
 type TransferDataTransaction struct {
@@ -0,0 +1,24 @@
+package withdrawal_prover
+
+import "errors"
+
+// ErrProverURLEmpty error: the URL of the withdrawal prover must not be empty.
+var ErrProverURLEmpty = errors.New("the URL of the withdrawal prover must not be empty")
+
+// ErrSendRequestFail error: failed to send the request to the withdrawal prover.
+var ErrSendRequestFail = errors.New("failed to send the request to the withdrawal prover")
+
+// ErrUnexpectedStatusCode error: unexpected status code of the withdrawal prover.
+var ErrUnexpectedStatusCode = errors.New("unexpected status code of the withdrawal prover")
+
+// ErrUnmarshalResponseFail error: failed to unmarshal the response of the withdrawal prover.
+var ErrUnmarshalResponseFail = errors.New("failed to unmarshal the response of the withdrawal prover")
+
+// ErrRequestRejected error: the request was rejected by the withdrawal prover.
+var ErrRequestRejected = errors.New("the request was rejected by the withdrawal prover")
+
+// ErrProofNotReady error: the proof is not generated yet.
+var ErrProofNotReady = errors.New("the proof is not generated yet")
+
+// ErrWaitForProofTimeout error: timeout of waiting for the proof.
+var ErrWaitForProofTimeout = errors.New("timeout of waiting for the proof")
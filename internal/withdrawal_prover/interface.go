@@ -0,0 +1,68 @@
+package withdrawal_prover
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:generate mockgen -destination=../mocks/mock_withdrawal_prover.go -package=mocks -source=interface.go
+
+// WithdrawalProver describes the client of the withdrawal aggregator prover.
+// The proofs are generated asynchronously: a proof is requested with a job ID
+// and is polled by the same job ID until the prover stores it.
+type WithdrawalProver interface {
+	RequestWithdrawalProof(ctx context.Context, jobID, singleWithdrawalProof string, prevWithdrawalProof *string) error
+	WithdrawalProof(ctx context.Context, jobID string) (*WithdrawalProofContent, error)
+	WaitForWithdrawalProof(ctx context.Context, jobID string) (*WithdrawalProofContent, error)
+	RequestWrapperProof(ctx context.Context, jobID string, withdrawalAggregator common.Address, withdrawalProof string) error
+	WrapperProof(ctx context.Context, jobID string) (string, error)
+	WaitForWrapperProof(ctx context.Context, jobID string) (string, error)
+}
+
+// ChainedWithdrawal describes the withdrawal proved by the withdrawal proof.
+type ChainedWithdrawal struct {
+	Recipient   string `json:"recipient"`
+	TokenIndex  uint32 `json:"tokenIndex"`
+	Amount      string `json:"amount"`
+	Nullifier   string `json:"nullifier"`
+	BlockHash   string `json:"blockHash"`
+	BlockNumber uint32 `json:"blockNumber"`
+}
+
+// WithdrawalProofContent describes the withdrawal proof (with public inputs, base64 encoded)
+// and the withdrawal proved by it.
+type WithdrawalProofContent struct {
+	Proof      string            `json:"proof"`
+	Withdrawal ChainedWithdrawal `json:"withdrawal"`
+}
+
+type WithdrawalProofRequest struct {
+	ID                    string  `json:"id"`
+	SingleWithdrawalProof string  `json:"singleWithdrawalProof"`
+	PrevWithdrawalProof   *string `json:"prevWithdrawalProof"`
+}
+
+type WithdrawalWrapperProofRequest struct {
+	ID                   string `json:"id"`
+	WithdrawalProof      string `json:"withdrawalProof"`
+	WithdrawalAggregator string `json:"withdrawalAggregator"`
+}
+
+type GenerateProofResponse struct {
+	Success      bool    `json:"success"`
+	Message      string  `json:"message,omitempty"`
+	ErrorMessage *string `json:"errorMessage,omitempty"`
+}
+
+type WithdrawalProofResponse struct {
+	Success      bool                    `json:"success"`
+	Proof        *WithdrawalProofContent `json:"proof"`
+	ErrorMessage *string                 `json:"errorMessage"`
+}
+
+type WrapperProofResponse struct {
+	Success      bool    `json:"success"`
+	Proof        *string `json:"proof"`
+	ErrorMessage *string `json:"errorMessage"`
+}
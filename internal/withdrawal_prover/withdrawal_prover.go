@@ -0,0 +1,247 @@
+package withdrawal_prover
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"intmax2-node/configs"
+	"intmax2-node/internal/logger"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-resty/resty/v2"
+)
+
+type withdrawalProver struct {
+	cfg    *configs.Config
+	log    logger.Logger
+	client *resty.Client
+}
+
+func New(cfg *configs.Config, log logger.Logger) (WithdrawalProver, error) {
+	const (
+		contentType = "Content-Type"
+		appJSON     = "application/json"
+	)
+
+	if cfg.API.WithdrawalProverUrl == "" {
+		return nil, ErrProverURLEmpty
+	}
+
+	// The transport errors and the server errors are retried, the rejected requests are not.
+	client := resty.New().
+		SetBaseURL(strings.TrimSuffix(cfg.API.WithdrawalProverUrl, "/")).
+		SetHeader(contentType, appJSON).
+		SetTimeout(cfg.Withdrawal.ProverRequestTimeout).
+		SetRetryCount(cfg.Withdrawal.ProverRetryCount).
+		SetRetryWaitTime(cfg.Withdrawal.ProverRetryWaitTime).
+		AddRetryCondition(func(resp *resty.Response, err error) bool {
+			return err != nil || resp.StatusCode() >= http.StatusInternalServerError
+		})
+
+	return &withdrawalProver{
+		cfg:    cfg,
+		log:    log,
+		client: client,
+	}, nil
+}
+
+// RequestWithdrawalProof requests the proof of the withdrawal chained with the proof of the previous withdrawals.
+// The request of the job that already has the proof is accepted too.
+func (p *withdrawalProver) RequestWithdrawalProof(
+	ctx context.Context,
+	jobID, singleWithdrawalProof string,
+	prevWithdrawalProof *string,
+) error {
+	const path = "/proof/withdrawal"
+
+	return p.requestProof(ctx, path, &WithdrawalProofRequest{
+		ID:                    jobID,
+		SingleWithdrawalProof: singleWithdrawalProof,
+		PrevWithdrawalProof:   prevWithdrawalProof,
+	})
+}
+
+// WithdrawalProof returns ErrProofNotReady until the withdrawal proof of the job is generated.
+func (p *withdrawalProver) WithdrawalProof(ctx context.Context, jobID string) (*WithdrawalProofContent, error) {
+	const path = "/proof/withdrawal/%s"
+
+	var res WithdrawalProofResponse
+	err := p.get(ctx, fmt.Sprintf(path, url.PathEscape(jobID)), &res)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, rejected(res.ErrorMessage)
+	}
+
+	if res.Proof == nil {
+		return nil, ErrProofNotReady
+	}
+
+	return res.Proof, nil
+}
+
+func (p *withdrawalProver) WaitForWithdrawalProof(ctx context.Context, jobID string) (*WithdrawalProofContent, error) {
+	var proof *WithdrawalProofContent
+	err := p.wait(ctx, func(ctx context.Context) (err error) {
+		proof, err = p.WithdrawalProof(ctx, jobID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return proof, nil
+}
+
+// RequestWrapperProof requests the proof that wraps the withdrawal proof for the withdrawal aggregator.
+func (p *withdrawalProver) RequestWrapperProof(
+	ctx context.Context,
+	jobID string,
+	withdrawalAggregator common.Address,
+	withdrawalProof string,
+) error {
+	const path = "/proof/wrapper"
+
+	return p.requestProof(ctx, path, &WithdrawalWrapperProofRequest{
+		ID:                   jobID,
+		WithdrawalProof:      withdrawalProof,
+		WithdrawalAggregator: withdrawalAggregator.Hex(),
+	})
+}
+
+// WrapperProof returns ErrProofNotReady until the wrapper proof of the job is generated.
+func (p *withdrawalProver) WrapperProof(ctx context.Context, jobID string) (string, error) {
+	const path = "/proof/wrapper/%s"
+
+	var res WrapperProofResponse
+	err := p.get(ctx, fmt.Sprintf(path, url.PathEscape(jobID)), &res)
+	if err != nil {
+		return "", err
+	}
+
+	// The prover answers without success while the proof is not generated.
+	if res.Proof == nil {
+		if res.ErrorMessage != nil {
+			return "", rejected(res.ErrorMessage)
+		}
+
+		return "", ErrProofNotReady
+	}
+
+	return *res.Proof, nil
+}
+
+func (p *withdrawalProver) WaitForWrapperProof(ctx context.Context, jobID string) (string, error) {
+	var proof string
+	err := p.wait(ctx, func(ctx context.Context) (err error) {
+		proof, err = p.WrapperProof(ctx, jobID)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return proof, nil
+}
+
+func (p *withdrawalProver) requestProof(ctx context.Context, path string, body interface{}) error {
+	resp, err := p.client.R().SetContext(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return errors.Join(ErrSendRequestFail, err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return statusCodeError(resp)
+	}
+
+	var res GenerateProofResponse
+	err = json.Unmarshal(resp.Body(), &res)
+	if err != nil {
+		return errors.Join(ErrUnmarshalResponseFail, err)
+	}
+
+	if !res.Success {
+		return rejected(res.ErrorMessage)
+	}
+
+	return nil
+}
+
+func (p *withdrawalProver) get(ctx context.Context, path string, res interface{}) error {
+	resp, err := p.client.R().SetContext(ctx).Get(path)
+	if err != nil {
+		return errors.Join(ErrSendRequestFail, err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return statusCodeError(resp)
+	}
+
+	err = json.Unmarshal(resp.Body(), res)
+	if err != nil {
+		return errors.Join(ErrUnmarshalResponseFail, err)
+	}
+
+	return nil
+}
+
+// wait polls the proof until it is generated or the timeout of the withdrawal prover is over.
+func (p *withdrawalProver) wait(ctx context.Context, poll func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.Withdrawal.ProverTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(p.cfg.Withdrawal.ProverPollingInterval)
+	defer ticker.Stop()
+
+	for {
+		err := poll(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return waitError(ctx)
+		}
+
+		if !errors.Is(err, ErrProofNotReady) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return waitError(ctx)
+		case <-ticker.C:
+		}
+	}
+}
+
+func waitError(ctx context.Context) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return ErrWaitForProofTimeout
+	}
+
+	return ctx.Err()
+}
+
+func statusCodeError(resp *resty.Response) error {
+	if resp.StatusCode() >= http.StatusBadRequest && resp.StatusCode() < http.StatusInternalServerError {
+		return fmt.Errorf("%w: status code %d: %s", ErrRequestRejected, resp.StatusCode(), resp.String())
+	}
+
+	return fmt.Errorf("%w: %d", ErrUnexpectedStatusCode, resp.StatusCode())
+}
+
+func rejected(errorMessage *string) error {
+	if errorMessage == nil {
+		return ErrRequestRejected
+	}
+
+	return fmt.Errorf("%w: %s", ErrRequestRejected, *errorMessage)
+}
@@ -0,0 +1,248 @@
+package withdrawal_prover_test
+
+import (
+	"context"
+	"encoding/json"
+	"intmax2-node/configs"
+	"intmax2-node/internal/withdrawal_prover"
+	"intmax2-node/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProver imitates the withdrawal aggregator prover:
+// a proof is available after the configured number of polls of its job.
+type fakeProver struct {
+	mu sync.Mutex
+
+	pollsUntilReady int
+	failures        int
+	rejectMessage   string
+
+	withdrawalRequests map[string]withdrawal_prover.WithdrawalProofRequest
+	wrapperRequests    map[string]withdrawal_prover.WithdrawalWrapperProofRequest
+	polls              map[string]int
+}
+
+func newFakeProver(pollsUntilReady int) *fakeProver {
+	return &fakeProver{
+		pollsUntilReady:    pollsUntilReady,
+		withdrawalRequests: make(map[string]withdrawal_prover.WithdrawalProofRequest),
+		wrapperRequests:    make(map[string]withdrawal_prover.WithdrawalWrapperProofRequest),
+		polls:              make(map[string]int),
+	}
+}
+
+func (f *fakeProver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.failures > 0 {
+		f.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	const (
+		withdrawalPath = "/proof/withdrawal"
+		wrapperPath    = "/proof/wrapper"
+	)
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == withdrawalPath:
+		var req withdrawal_prover.WithdrawalProofRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.withdrawalRequests[req.ID] = req
+		f.writeGenerateProofResponse(w)
+	case r.Method == http.MethodPost && r.URL.Path == wrapperPath:
+		var req withdrawal_prover.WithdrawalWrapperProofRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.wrapperRequests[req.ID] = req
+		f.writeGenerateProofResponse(w)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, withdrawalPath+"/"):
+		id := strings.TrimPrefix(r.URL.Path, withdrawalPath+"/")
+		req, ok := f.withdrawalRequests[id]
+		if !ok {
+			writeJSON(w, withdrawal_prover.WithdrawalProofResponse{ErrorMessage: ptr("job not found")})
+			return
+		}
+		res := withdrawal_prover.WithdrawalProofResponse{Success: true}
+		if f.ready(id) {
+			res.Proof = &withdrawal_prover.WithdrawalProofContent{
+				Proof: "withdrawal:" + req.SingleWithdrawalProof,
+				Withdrawal: withdrawal_prover.ChainedWithdrawal{
+					Recipient:   common.HexToAddress("0x1").Hex(),
+					TokenIndex:  1,
+					Amount:      "100",
+					Nullifier:   common.HexToHash("0x2").Hex(),
+					BlockHash:   common.HexToHash("0x3").Hex(),
+					BlockNumber: 4,
+				},
+			}
+		}
+		writeJSON(w, res)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, wrapperPath+"/"):
+		id := strings.TrimPrefix(r.URL.Path, wrapperPath+"/")
+		req, ok := f.wrapperRequests[id]
+		if !ok {
+			writeJSON(w, withdrawal_prover.WrapperProofResponse{ErrorMessage: ptr("job not found")})
+			return
+		}
+		var res withdrawal_prover.WrapperProofResponse
+		if f.ready(id) {
+			res.Success = true
+			res.Proof = ptr("wrapper:" + req.WithdrawalAggregator)
+		}
+		writeJSON(w, res)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeProver) writeGenerateProofResponse(w http.ResponseWriter) {
+	if f.rejectMessage != "" {
+		writeJSON(w, withdrawal_prover.GenerateProofResponse{ErrorMessage: ptr(f.rejectMessage)})
+		return
+	}
+
+	writeJSON(w, withdrawal_prover.GenerateProofResponse{Success: true, Message: "proof request accepted"})
+}
+
+func (f *fakeProver) ready(id string) bool {
+	f.polls[id]++
+	return f.polls[id] > f.pollsUntilReady
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+func newWithdrawalProver(t *testing.T, url string) withdrawal_prover.WithdrawalProver {
+	const int2Key = 2
+	assert.NoError(t, configs.LoadDotEnv(int2Key))
+
+	cfg := *configs.New()
+	cfg.API.WithdrawalProverUrl = url
+	cfg.Withdrawal.ProverRequestTimeout = time.Second
+	cfg.Withdrawal.ProverRetryCount = 2
+	cfg.Withdrawal.ProverRetryWaitTime = time.Millisecond
+	cfg.Withdrawal.ProverPollingInterval = time.Millisecond
+	cfg.Withdrawal.ProverTimeout = time.Second
+
+	log := logger.New(cfg.LOG.Level, cfg.LOG.TimeFormat, cfg.LOG.JSON, cfg.LOG.IsLogLine)
+
+	prover, err := withdrawal_prover.New(&cfg, log)
+	require.NoError(t, err)
+
+	return prover
+}
+
+func TestWithdrawalProver(t *testing.T) {
+	ctx := context.Background()
+	aggregator := common.HexToAddress("0x5")
+
+	t.Run("Proofs are chained and wrapped", func(t *testing.T) {
+		fake := newFakeProver(2)
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		prover := newWithdrawalProver(t, server.URL)
+
+		require.NoError(t, prover.RequestWithdrawalProof(ctx, "job-1", "single-1", nil))
+		proof, err := prover.WaitForWithdrawalProof(ctx, "job-1")
+		require.NoError(t, err)
+		assert.Equal(t, "withdrawal:single-1", proof.Proof)
+		assert.Equal(t, "100", proof.Withdrawal.Amount)
+		assert.Equal(t, uint32(4), proof.Withdrawal.BlockNumber)
+		assert.Equal(t, 3, fake.polls["job-1"])
+
+		require.NoError(t, prover.RequestWithdrawalProof(ctx, "job-2", "single-2", &proof.Proof))
+		assert.Equal(t, proof.Proof, *fake.withdrawalRequests["job-2"].PrevWithdrawalProof)
+		assert.Nil(t, fake.withdrawalRequests["job-1"].PrevWithdrawalProof)
+
+		require.NoError(t, prover.RequestWrapperProof(ctx, "wrapper-1", aggregator, proof.Proof))
+		wrapperProof, err := prover.WaitForWrapperProof(ctx, "wrapper-1")
+		require.NoError(t, err)
+		assert.Equal(t, "wrapper:"+aggregator.Hex(), wrapperProof)
+	})
+
+	t.Run("Proof is not ready", func(t *testing.T) {
+		fake := newFakeProver(1)
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		prover := newWithdrawalProver(t, server.URL)
+
+		require.NoError(t, prover.RequestWrapperProof(ctx, "wrapper-1", aggregator, "proof"))
+		_, err := prover.WrapperProof(ctx, "wrapper-1")
+		assert.ErrorIs(t, err, withdrawal_prover.ErrProofNotReady)
+	})
+
+	t.Run("Server errors are retried", func(t *testing.T) {
+		fake := newFakeProver(0)
+		fake.failures = 2
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		prover := newWithdrawalProver(t, server.URL)
+
+		require.NoError(t, prover.RequestWithdrawalProof(ctx, "job-1", "single-1", nil))
+
+		fake.failures = 3
+		_, err := prover.WithdrawalProof(ctx, "job-1")
+		assert.ErrorIs(t, err, withdrawal_prover.ErrUnexpectedStatusCode)
+	})
+
+	t.Run("Request is rejected", func(t *testing.T) {
+		fake := newFakeProver(0)
+		fake.rejectMessage = "invalid single withdrawal proof"
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		prover := newWithdrawalProver(t, server.URL)
+
+		err := prover.RequestWithdrawalProof(ctx, "job-1", "single-1", nil)
+		assert.ErrorIs(t, err, withdrawal_prover.ErrRequestRejected)
+
+		_, err = prover.WaitForWithdrawalProof(ctx, "unknown")
+		assert.ErrorIs(t, err, withdrawal_prover.ErrRequestRejected)
+	})
+
+	t.Run("Waiting for the proof times out", func(t *testing.T) {
+		fake := newFakeProver(1 << 30)
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		prover := newWithdrawalProver(t, server.URL)
+
+		require.NoError(t, prover.RequestWithdrawalProof(ctx, "job-1", "single-1", nil))
+		_, err := prover.WaitForWithdrawalProof(ctx, "job-1")
+		assert.ErrorIs(t, err, withdrawal_prover.ErrWaitForProofTimeout)
+	})
+
+	t.Run("Prover URL is empty", func(t *testing.T) {
+		cfg := configs.Config{}
+
+		_, err := withdrawal_prover.New(&cfg, nil)
+		assert.ErrorIs(t, err, withdrawal_prover.ErrProverURLEmpty)
+	})
+}
@@ -0,0 +1,246 @@
+package withdrawal_service
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	intMaxAcc "intmax2-node/internal/accounts"
+	"intmax2-node/internal/balance_validity_prover"
+	"intmax2-node/internal/hash/goldenposeidon"
+	intMaxTypes "intmax2-node/internal/types"
+	"intmax2-node/internal/use_cases/backup_balance"
+	postWithdrwalRequest "intmax2-node/internal/use_cases/post_withdrawal_request"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iden3/go-iden3-crypto/ffg"
+)
+
+const (
+	// goldilocksOrder is the order of the field of the plonky2 public inputs.
+	goldilocksOrder uint64 = 0xFFFFFFFF00000001
+
+	hexBase = 16
+)
+
+var ErrInvalidBalanceProof = errors.New("invalid balance proof")
+
+var ErrBalanceProofDecodeFail = errors.New("failed to decode the balance proof")
+
+var ErrBalanceProofEmpty = errors.New("the balance proof is empty")
+
+var ErrBalancePublicInputsDecodeFail = errors.New("failed to decode the public inputs of the balance proof")
+
+var ErrBalancePublicInputsInvalid = errors.New("the public inputs of the balance proof are invalid")
+
+var ErrBalanceCircuitDigestNotConfigured = errors.New("the circuit digest of the balance circuit is not configured")
+
+var ErrBalanceCircuitDigestMismatch = errors.New("the balance proof is not generated by the balance circuit")
+
+var ErrBalanceProofStale = errors.New("the balance proof does not include the block of the withdrawal")
+
+var ErrBalanceProofBlockHashMismatch = errors.New("the block hash of the balance proof is not posted")
+
+var ErrBalanceProofSenderMismatch = errors.New("the public key of the balance proof is not the sender of the transaction")
+
+var ErrTransactionInvalid = errors.New("the transaction of the withdrawal is invalid")
+
+var ErrSenderInvalid = errors.New("the sender of the withdrawal is invalid")
+
+var ErrVerifyBalanceProofFail = errors.New("failed to verify the balance proof with the balance validity prover")
+
+// BalanceProof describes the decoded balance proof of the withdrawal request.
+type BalanceProof struct {
+	Proof         []byte
+	PublicInputs  *backup_balance.BalancePublicInputs
+	CircuitDigest goldenposeidon.PoseidonHashOut
+}
+
+// DecodeBalanceProof decodes the base64 encoded plonky2 proof and public inputs of the balance proof.
+// The public inputs are the little-endian 64-bit field elements of the balance public inputs
+// followed by the verifier data (circuit digest and constants sigmas cap) of the balance circuit.
+func DecodeBalanceProof(
+	input *postWithdrwalRequest.UCPostWithdrawalRequestEnoughBalanceProofInput,
+) (*BalanceProof, error) {
+	const numUint64Bytes = 8
+
+	proof, err := base64.StdEncoding.DecodeString(input.Proof)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidBalanceProof, ErrBalanceProofDecodeFail, err)
+	}
+	if len(proof) == 0 {
+		return nil, errors.Join(ErrInvalidBalanceProof, ErrBalanceProofEmpty)
+	}
+
+	var publicInputsBin []byte
+	publicInputsBin, err = base64.StdEncoding.DecodeString(input.PublicInputs)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidBalanceProof, ErrBalancePublicInputsDecodeFail, err)
+	}
+	if len(publicInputsBin)%numUint64Bytes != 0 {
+		return nil, errors.Join(ErrInvalidBalanceProof, ErrBalancePublicInputsDecodeFail)
+	}
+
+	publicInputs := make([]ffg.Element, len(publicInputsBin)/numUint64Bytes)
+	for i := range publicInputs {
+		v := binary.LittleEndian.Uint64(publicInputsBin[numUint64Bytes*i : numUint64Bytes*(i+1)])
+		if v >= goldilocksOrder {
+			return nil, errors.Join(ErrInvalidBalanceProof, ErrBalancePublicInputsInvalid)
+		}
+		publicInputs[i].SetUint64(v)
+	}
+
+	if uint(len(publicInputs)) < backup_balance.BALANCE_PUBLIC_INPUTS_LEN+goldenposeidon.NUM_HASH_OUT_ELTS {
+		return nil, errors.Join(ErrInvalidBalanceProof, ErrBalancePublicInputsInvalid)
+	}
+
+	var balancePublicInputs *backup_balance.BalancePublicInputs
+	balancePublicInputs, err = new(backup_balance.BalancePublicInputs).FromPublicInputs(publicInputs)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidBalanceProof, ErrBalancePublicInputsInvalid, err)
+	}
+
+	balanceProof := BalanceProof{
+		Proof:        proof,
+		PublicInputs: balancePublicInputs,
+	}
+	copy(balanceProof.CircuitDigest.Elements[:], publicInputs[backup_balance.BALANCE_PUBLIC_INPUTS_LEN:])
+
+	return &balanceProof, nil
+}
+
+// CheckBalanceCircuitDigest checks the configured circuit digest of the balance circuit.
+func CheckBalanceCircuitDigest(circuitDigest string) error {
+	_, err := balanceCircuitDigest(circuitDigest)
+	return err
+}
+
+func balanceCircuitDigest(circuitDigest string) (*goldenposeidon.PoseidonHashOut, error) {
+	if circuitDigest == "" {
+		return nil, ErrBalanceCircuitDigestNotConfigured
+	}
+
+	digest := new(goldenposeidon.PoseidonHashOut)
+	err := digest.FromString(circuitDigest)
+	if err != nil {
+		return nil, errors.Join(ErrBalanceCircuitDigestNotConfigured, err)
+	}
+
+	return digest, nil
+}
+
+// VerifyCircuitDigest checks that the balance proof is generated by the balance circuit with the expected digest.
+// The circuit digest of the public inputs can be trusted only after the proof itself is verified.
+func (p *BalanceProof) VerifyCircuitDigest(circuitDigest string) error {
+	expected, err := balanceCircuitDigest(circuitDigest)
+	if err != nil {
+		return err
+	}
+
+	if !p.CircuitDigest.Equal(expected) {
+		return errors.Join(ErrInvalidBalanceProof, ErrBalanceCircuitDigestMismatch)
+	}
+
+	return nil
+}
+
+// VerifySender checks that the public key of the balance proof is the sender of the withdrawal request
+// and that the proof is of the transaction of the withdrawal.
+// The balance circuit sets the last tx hash only to the hash of the transaction sent by the public key.
+func (p *BalanceProof) VerifySender(
+	sender string,
+	transaction *postWithdrwalRequest.UCPostWithdrawalRequestTransactionInput,
+) error {
+	senderAddress, err := intMaxAcc.NewAddressFromHex(sender)
+	if err != nil {
+		return errors.Join(ErrInvalidBalanceProof, ErrSenderInvalid, err)
+	}
+
+	// The address is the x coordinate of the public key.
+	if new(big.Int).SetBytes(senderAddress[:]).Cmp(p.PublicInputs.PublicKey) != 0 {
+		return errors.Join(ErrInvalidBalanceProof, fmt.Errorf(
+			"%w: public key %s, sender %s",
+			ErrBalanceProofSenderMismatch, p.PublicInputs.PublicKey.Text(hexBase), senderAddress.String(),
+		))
+	}
+
+	transferTreeRoot := new(intMaxTypes.PoseidonHashOut)
+	err = transferTreeRoot.FromString(transaction.TransferTreeRoot)
+	if err != nil {
+		return errors.Join(ErrInvalidBalanceProof, ErrTransactionInvalid, err)
+	}
+
+	var tx *intMaxTypes.Tx
+	tx, err = intMaxTypes.NewTx(transferTreeRoot, uint64(transaction.Nonce))
+	if err != nil {
+		return errors.Join(ErrInvalidBalanceProof, ErrTransactionInvalid, err)
+	}
+
+	if !p.PublicInputs.LastTxHash.Equal(tx.Hash()) {
+		return errors.Join(ErrInvalidBalanceProof, fmt.Errorf(
+			"%w: public key %s, tx hash %s",
+			ErrBalanceProofSenderMismatch, p.PublicInputs.PublicKey.Text(hexBase), tx.Hash().String(),
+		))
+	}
+
+	return nil
+}
+
+// verifyBalanceProof checks that the balance proof is verified by the balance validity prover, that it is generated
+// by the configured balance circuit, that its public key is the sender of the withdrawal request
+// and that it proves the balance at a block posted to the Rollup contract that is not older than the block
+// of the withdrawal.
+func (s *WithdrawalRequestService) verifyBalanceProof(input *postWithdrwalRequest.UCPostWithdrawalRequestInput) error {
+	balanceProof, err := DecodeBalanceProof(input.EnoughBalanceProof)
+	if err != nil {
+		return err
+	}
+
+	err = s.bvp.VerifyBalanceProof(s.ctx, input.EnoughBalanceProof.Proof)
+	if errors.Is(err, balance_validity_prover.ErrRequestRejected) {
+		return errors.Join(ErrInvalidBalanceProof, err)
+	}
+	if err != nil {
+		return errors.Join(ErrVerifyBalanceProofFail, err)
+	}
+
+	err = balanceProof.VerifyCircuitDigest(s.cfg.Withdrawal.BalanceCircuitDigest)
+	if err != nil {
+		return err
+	}
+
+	err = balanceProof.VerifySender(input.Sender, input.Transaction)
+	if err != nil {
+		return err
+	}
+
+	publicState := &balanceProof.PublicInputs.PublicState
+	if int64(publicState.BlockNumber) < input.BlockNumber {
+		return errors.Join(ErrInvalidBalanceProof, fmt.Errorf(
+			"%w: balance proof block number %d, withdrawal block number %d",
+			ErrBalanceProofStale, publicState.BlockNumber, input.BlockNumber,
+		))
+	}
+
+	opts := bind.CallOpts{
+		Pending: false,
+		Context: s.ctx,
+	}
+
+	var postedBlockHash [int32Key]byte
+	postedBlockHash, err = s.rollup.GetBlockHash(&opts, publicState.BlockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get block hash: %w", err)
+	}
+
+	if postedBlockHash != publicState.BlockHash {
+		return errors.Join(ErrInvalidBalanceProof, fmt.Errorf(
+			"%w: block number %d, block hash %s",
+			ErrBalanceProofBlockHashMismatch, publicState.BlockNumber, common.Hash(publicState.BlockHash).Hex(),
+		))
+	}
+
+	return nil
+}
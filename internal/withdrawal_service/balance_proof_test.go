@@ -0,0 +1,164 @@
+package withdrawal_service_test
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+
+	postWithdrwalRequest "intmax2-node/internal/use_cases/post_withdrawal_request"
+	"intmax2-node/internal/withdrawal_service"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeBalanceProof(t *testing.T) {
+	const (
+		circuitDigest = "0x35c0707f2d7781d5e0e76f85aa328498b64b3c533c7ad73c57f7dcaf5d8b6524"
+		pubKey        = "16852635987553306264079586511908463717029781027077523067792456538228210163282"
+	)
+
+	proofBin, err := os.ReadFile("../../pkg/data/balance_proof.bin")
+	assert.NoError(t, err)
+	publicInputsBin, err := os.ReadFile("../../pkg/data/balance_proof_public_inputs.bin")
+	assert.NoError(t, err)
+
+	input := postWithdrwalRequest.UCPostWithdrawalRequestEnoughBalanceProofInput{
+		Proof:        base64.StdEncoding.EncodeToString(proofBin),
+		PublicInputs: base64.StdEncoding.EncodeToString(publicInputsBin),
+	}
+
+	balanceProof, err := withdrawal_service.DecodeBalanceProof(&input)
+	assert.NoError(t, err)
+	assert.Equal(t, proofBin, balanceProof.Proof)
+	assert.Equal(t, circuitDigest, balanceProof.CircuitDigest.String())
+
+	expectedPubKey, _ := new(big.Int).SetString(pubKey, 10)
+	pis := balanceProof.PublicInputs
+	assert.Equal(t, 0, expectedPubKey.Cmp(pis.PublicKey))
+	assert.Equal(t,
+		"0x74a07e05286b01fed423e316cec91c002c104d1a7908767e63cbde833628d8e6",
+		pis.PrivateCommitment.String(),
+	)
+	assert.Equal(t,
+		"0x4453395f08cdad3204a3f70d518b528a5d8644a16bfea699154e3a4fc923cda4",
+		pis.PublicState.PrevAccountTreeRoot.String(),
+	)
+	assert.Equal(t,
+		common.HexToHash("0x446a90aa66d22caa90efb6aa496c4a811d8573daf4e6b3de1996baf0eec29427"),
+		common.Hash(pis.PublicState.BlockHash),
+	)
+	assert.Equal(t, uint32(1), pis.PublicState.BlockNumber)
+
+	assert.NoError(t, balanceProof.VerifyCircuitDigest(circuitDigest))
+	assert.ErrorIs(t,
+		balanceProof.VerifyCircuitDigest(common.Hash{}.Hex()),
+		withdrawal_service.ErrBalanceCircuitDigestMismatch,
+	)
+	assert.ErrorIs(t,
+		balanceProof.VerifyCircuitDigest(""),
+		withdrawal_service.ErrBalanceCircuitDigestNotConfigured,
+	)
+
+	assert.NoError(t, withdrawal_service.CheckBalanceCircuitDigest(circuitDigest))
+	assert.ErrorIs(t,
+		withdrawal_service.CheckBalanceCircuitDigest(""),
+		withdrawal_service.ErrBalanceCircuitDigestNotConfigured,
+	)
+	assert.ErrorIs(t,
+		withdrawal_service.CheckBalanceCircuitDigest("0x01"),
+		withdrawal_service.ErrBalanceCircuitDigestNotConfigured,
+	)
+
+	// The balance proof of the test data is not generated by the sender of an arbitrary transaction.
+	sender := fmt.Sprintf("0x%064x", expectedPubKey)
+	err = balanceProof.VerifySender(sender, &postWithdrwalRequest.UCPostWithdrawalRequestTransactionInput{
+		TransferTreeRoot: common.Hash{}.Hex(),
+		Nonce:            1,
+	})
+	assert.ErrorIs(t, err, withdrawal_service.ErrBalanceProofSenderMismatch)
+	assert.ErrorIs(t, err, withdrawal_service.ErrInvalidBalanceProof)
+	err = balanceProof.VerifySender(sender, &postWithdrwalRequest.UCPostWithdrawalRequestTransactionInput{
+		TransferTreeRoot: "0x01",
+	})
+	assert.ErrorIs(t, err, withdrawal_service.ErrTransactionInvalid)
+
+	// The balance proof of the public key is not the proof of the other sender.
+	err = balanceProof.VerifySender(common.Hash{1}.Hex(), &postWithdrwalRequest.UCPostWithdrawalRequestTransactionInput{
+		TransferTreeRoot: common.Hash{}.Hex(),
+		Nonce:            1,
+	})
+	assert.ErrorIs(t, err, withdrawal_service.ErrBalanceProofSenderMismatch)
+	assert.ErrorContains(t, err, "sender")
+	err = balanceProof.VerifySender("0x01", &postWithdrwalRequest.UCPostWithdrawalRequestTransactionInput{
+		TransferTreeRoot: common.Hash{}.Hex(),
+		Nonce:            1,
+	})
+	assert.ErrorIs(t, err, withdrawal_service.ErrSenderInvalid)
+
+	nonCanonical := make([]byte, len(publicInputsBin))
+	copy(nonCanonical, publicInputsBin)
+	binary.LittleEndian.PutUint64(nonCanonical, ^uint64(0))
+
+	limbOverflow := make([]byte, len(publicInputsBin))
+	copy(limbOverflow, publicInputsBin)
+	binary.LittleEndian.PutUint64(limbOverflow, uint64(1)<<32)
+
+	cases := []struct {
+		desc  string
+		input postWithdrwalRequest.UCPostWithdrawalRequestEnoughBalanceProofInput
+		err   error
+	}{
+		{
+			desc:  "Proof is not base64",
+			input: postWithdrwalRequest.UCPostWithdrawalRequestEnoughBalanceProofInput{Proof: "!", PublicInputs: input.PublicInputs},
+			err:   withdrawal_service.ErrBalanceProofDecodeFail,
+		},
+		{
+			desc:  "Proof is empty",
+			input: postWithdrwalRequest.UCPostWithdrawalRequestEnoughBalanceProofInput{PublicInputs: input.PublicInputs},
+			err:   withdrawal_service.ErrBalanceProofEmpty,
+		},
+		{
+			desc:  "Public inputs are not field elements",
+			input: postWithdrwalRequest.UCPostWithdrawalRequestEnoughBalanceProofInput{Proof: input.Proof, PublicInputs: "AA=="},
+			err:   withdrawal_service.ErrBalancePublicInputsDecodeFail,
+		},
+		{
+			desc: "Public inputs are too short",
+			input: postWithdrwalRequest.UCPostWithdrawalRequestEnoughBalanceProofInput{
+				Proof:        input.Proof,
+				PublicInputs: base64.StdEncoding.EncodeToString(publicInputsBin[:8*47]),
+			},
+			err: withdrawal_service.ErrBalancePublicInputsInvalid,
+		},
+		{
+			desc: "Public input is not canonical",
+			input: postWithdrwalRequest.UCPostWithdrawalRequestEnoughBalanceProofInput{
+				Proof:        input.Proof,
+				PublicInputs: base64.StdEncoding.EncodeToString(nonCanonical),
+			},
+			err: withdrawal_service.ErrBalancePublicInputsInvalid,
+		},
+		{
+			desc: "Public key limb overflows",
+			input: postWithdrwalRequest.UCPostWithdrawalRequestEnoughBalanceProofInput{
+				Proof:        input.Proof,
+				PublicInputs: base64.StdEncoding.EncodeToString(limbOverflow),
+			},
+			err: withdrawal_service.ErrBalancePublicInputsInvalid,
+		},
+	}
+
+	for i := range cases {
+		t.Run(cases[i].desc, func(t *testing.T) {
+			_, err = withdrawal_service.DecodeBalanceProof(&cases[i].input)
+			assert.True(t, errors.Is(err, cases[i].err))
+			assert.True(t, errors.Is(err, withdrawal_service.ErrInvalidBalanceProof))
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package withdrawal_service
+
+import (
+	"context"
+)
+
+//go:generate mockgen -destination=mock_balance_validity_prover_test.go -package=withdrawal_service_test -source=balance_validity_prover.go
+
+type BalanceValidityProver interface {
+	VerifyBalanceProof(ctx context.Context, proof string) error
+}
@@ -0,0 +1,16 @@
+package withdrawal_service
+
+import (
+	"context"
+	"intmax2-node/internal/balance_validity_prover"
+)
+
+//go:generate mockgen -destination=mock_single_withdrawal_prover_test.go -package=withdrawal_service_test -source=single_withdrawal_prover.go
+
+type SingleWithdrawalProver interface {
+	SingleWithdrawalProof(
+		ctx context.Context,
+		jobID string,
+		witness *balance_validity_prover.SingleWithdrawalWitness,
+	) (string, error)
+}
@@ -4,19 +4,24 @@ package withdrawal_service
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"intmax2-node/configs"
+	"intmax2-node/internal/balance_validity_prover"
 	"intmax2-node/internal/bindings"
 	"intmax2-node/internal/hash/goldenposeidon"
 	"intmax2-node/internal/logger"
 	intMaxTypes "intmax2-node/internal/types"
+	"intmax2-node/internal/withdrawal_prover"
 	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
 	"intmax2-node/pkg/utils"
 	"log"
 	"math/big"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -25,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/google/uuid"
 )
 
 const (
@@ -32,11 +38,31 @@ const (
 	int32Key = 32
 )
 
+const WithdrawalAggregatorJob = "withdrawal_aggregator"
+
+var ErrWithdrawalProofMismatch = errors.New("the withdrawal proof does not prove the requested withdrawal")
+
+var ErrDecodeWrapperProofFail = errors.New("failed to decode the wrapper proof")
+
+var ErrWithdrawalInvalid = errors.New("the withdrawal request is invalid")
+
+var ErrWithdrawalProofNotFound = errors.New("the withdrawal proof of the proved withdrawal is not found")
+
 type WithdrawalAggregatorService struct {
+	ctx       context.Context
+	cfg       *configs.Config
+	log       logger.Logger
+	db        SQLDriverApp
+	swp       SingleWithdrawalProver
+	prover    WithdrawalProver
+	submitter WithdrawalProofSubmitter
+}
+
+// withdrawalContractSubmitter submits the withdrawal proofs to the Withdrawal contract.
+type withdrawalContractSubmitter struct {
 	ctx                context.Context
 	cfg                *configs.Config
 	log                logger.Logger
-	db                 SQLDriverApp
 	scrollClient       *ethclient.Client
 	withdrawalContract *bindings.Withdrawal
 }
@@ -63,100 +89,161 @@ func newWithdrawalAggregatorService(
 		return nil, fmt.Errorf("failed to instantiate ScrollMessenger contract: %w", err)
 	}
 
-	return &WithdrawalAggregatorService{
+	bvp, err := balance_validity_prover.New(cfg, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create balance validity prover client: %w", err)
+	}
+
+	prover, err := withdrawal_prover.New(cfg, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create withdrawal prover client: %w", err)
+	}
+
+	submitter := withdrawalContractSubmitter{
 		ctx:                ctx,
 		cfg:                cfg,
 		log:                log,
-		db:                 db,
 		scrollClient:       scrollClient,
 		withdrawalContract: withdrawalContract,
-	}, nil
+	}
+
+	return NewWithdrawalAggregatorService(ctx, cfg, log, db, bvp, prover, &submitter), nil
+}
+
+// NewWithdrawalAggregatorService returns the withdrawal aggregator, which proves the single withdrawals
+// with swp, chains and wraps their withdrawal proofs with prover and submits the wrapped proofs with submitter.
+func NewWithdrawalAggregatorService(
+	ctx context.Context,
+	cfg *configs.Config,
+	log logger.Logger,
+	db SQLDriverApp,
+	swp SingleWithdrawalProver,
+	prover WithdrawalProver,
+	submitter WithdrawalProofSubmitter,
+) *WithdrawalAggregatorService {
+	return &WithdrawalAggregatorService{
+		ctx:       ctx,
+		cfg:       cfg,
+		log:       log,
+		db:        db,
+		swp:       swp,
+		prover:    prover,
+		submitter: submitter,
+	}
 }
 
-func WithdrawalAggregator(ctx context.Context, cfg *configs.Config, log logger.Logger, db SQLDriverApp, sb ServiceBlockchain) {
+func WithdrawalAggregator(ctx context.Context, cfg *configs.Config, log logger.Logger, db SQLDriverApp, sb ServiceBlockchain) error {
 	service, err := newWithdrawalAggregatorService(ctx, cfg, log, db, sb)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to initialize WithdrawalAggregatorService: %v", err.Error()))
+		return fmt.Errorf("failed to initialize WithdrawalAggregatorService: %w", err)
 	}
 
-	pendingWithdrawals, err := service.fetchPendingWithdrawals()
+	return service.Aggregate()
+}
+
+// Aggregate submits the batches proved by an interrupted run first, then proves and submits the pending withdrawals.
+func (w *WithdrawalAggregatorService) Aggregate() error {
+	err := w.resumeProvedWithdrawals()
 	if err != nil {
-		panic(fmt.Sprintf("Failed to retrieve withdrawals %v", err.Error()))
+		return err
 	}
 
-	if len(*pendingWithdrawals) == 0 {
-		log.Infof("No pending withdrawal requests found")
-		return
+	var pendingWithdrawals []mDBApp.Withdrawal
+	pendingWithdrawals, err = w.fetchPendingWithdrawals()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve withdrawals: %w", err)
 	}
 
-	shouldSubmit := service.shouldProcessWithdrawals(*pendingWithdrawals)
+	if len(pendingWithdrawals) == 0 {
+		w.log.Infof("No pending withdrawal requests found")
+		return nil
+	}
+
+	shouldSubmit := w.shouldProcessWithdrawals(pendingWithdrawals)
 	if !shouldSubmit {
-		log.Infof("Not enough pending withdrawal requests to process")
-		return
+		w.log.Infof("Not enough pending withdrawal requests to process")
+		return nil
 	}
 
-	// proofs, err := service.fetchWithdrawalProofsFromProver(*pendingWithdrawals)
-	// if err != nil {
-	// 	panic(fmt.Sprintf("Failed to fetch withdrawal proofs %v", err.Error()))
-	// }
+	proved, wrapperJobID, err := w.proveWithdrawals(pendingWithdrawals)
+	if err != nil {
+		return fmt.Errorf("failed to prove withdrawals: %w", err)
+	}
 
-	// withdrawalInfo, err := service.buildSubmitWithdrawalProofData(*pendingWithdrawals, proofs)
-	// if err != nil {
-	// 	panic("NEED_TO_BE_IMPLEMENTED")
-	// }
+	if len(proved) == 0 {
+		w.log.Infof("None of %d pending withdrawals is proved", len(pendingWithdrawals))
+		return nil
+	}
+
+	return w.submitWithdrawals(wrapperJobID, proved)
+}
 
-	withdrawalInfo, err := service.buildMockSubmitWithdrawalProofData(*pendingWithdrawals)
+// resumeProvedWithdrawals submits the batches whose withdrawal proofs were chained by an interrupted run.
+// The withdrawals of a batch share the job ID of its wrapper proof and are ordered as they are chained.
+func (w *WithdrawalAggregatorService) resumeProvedWithdrawals() error {
+	proved, err := w.db.WithdrawalsByStatus(mDBApp.WS_PROVED, nil)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to build withdrawal proof data: %v", err.Error()))
+		return fmt.Errorf("failed to find proved withdrawals: %w", err)
+	}
+	if proved == nil {
+		return nil
 	}
 
-	receipt, err := service.submitWithdrawalProof(
-		withdrawalInfo.Withdrawals,
-		withdrawalInfo.WithdrawalProofPublicInputs,
-		withdrawalInfo.Proof,
-	)
-	if err != nil {
-		// TODO: NEED_TO_BE_CHANGED change status depends on the result of the proof
-		if err.Error() == "WithdrawalProofVerificationFailed" {
-			log.Errorf("Failed to submit withdrawal proof: %v", err.Error())
-			err = db.UpdateWithdrawalsStatus(extractIds(*pendingWithdrawals), mDBApp.WS_FAILED)
-			if err != nil {
-				panic(fmt.Sprintf("Failed to update withdrawal status: %v", err.Error()))
-			}
-			return
+	var wrapperJobIDs []string
+	batches := make(map[string][]mDBApp.Withdrawal)
+	for key := range *proved {
+		wrapperJobID := (*proved)[key].ProverJobID
+		if _, ok := batches[wrapperJobID]; !ok {
+			wrapperJobIDs = append(wrapperJobIDs, wrapperJobID)
 		}
-		panic(fmt.Sprintf("Failed to submit withdrawal proof: %v", err.Error()))
+		batches[wrapperJobID] = append(batches[wrapperJobID], (*proved)[key])
 	}
 
-	if receipt == nil {
-		panic("Received nil receipt for transaction")
-	}
+	for key := range wrapperJobIDs {
+		batch := batches[wrapperJobIDs[key]]
+		w.log.Infof("Resuming the submission of %d proved withdrawals", len(batch))
 
-	switch receipt.Status {
-	case types.ReceiptStatusSuccessful:
-		log.Infof("Successfully submit withdrawal proof %d withdrawals. Transaction Hash: %v", len(*pendingWithdrawals), receipt.TxHash.Hex())
-	case types.ReceiptStatusFailed:
-		panic(fmt.Sprintf("Transaction failed: submit withdrawal proof unsuccessful. Transaction Hash: %v", receipt.TxHash.Hex()))
-	default:
-		panic(fmt.Sprintf("Unexpected transaction status: %d. Transaction Hash: %v", receipt.Status, receipt.TxHash.Hex()))
+		err = w.submitWithdrawals(wrapperJobIDs[key], batch)
+		if err != nil {
+			return err
+		}
 	}
 
-	err = db.UpdateWithdrawalsStatus(extractIds(*pendingWithdrawals), mDBApp.WS_SUCCESS)
-	if err != nil {
-		panic(fmt.Sprintf("Failed to update withdrawal status: %v", err.Error()))
-	}
+	return nil
 }
 
-func (w *WithdrawalAggregatorService) fetchPendingWithdrawals() (*[]mDBApp.Withdrawal, error) {
+// fetchPendingWithdrawals returns the requested withdrawals and the withdrawals left proving by an interrupted run
+// in the order of their requests. The chain of the withdrawal proofs of the interrupted run is lost,
+// so these withdrawals are proved again.
+func (w *WithdrawalAggregatorService) fetchPendingWithdrawals() ([]mDBApp.Withdrawal, error) {
 	limit := int(w.cfg.Blockchain.WithdrawalAggregatorThreshold)
-	withdrawals, err := w.db.WithdrawalsByStatus(mDBApp.WS_PENDING, &limit)
+	proving, err := w.db.WithdrawalsByStatus(mDBApp.WS_PROVING, &limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find pending withdrawals: %w", err)
+		return nil, fmt.Errorf("failed to find proving withdrawals: %w", err)
 	}
-	if withdrawals == nil {
-		return nil, fmt.Errorf("failed to get pending withdrawals because withdrawals is nil")
+	if proving == nil {
+		return nil, fmt.Errorf("failed to get proving withdrawals because withdrawals is nil")
 	}
-	return withdrawals, nil
+
+	pendingWithdrawals := *proving
+	limit -= len(pendingWithdrawals)
+	if limit > 0 {
+		var requested *[]mDBApp.Withdrawal
+		requested, err = w.db.WithdrawalsByStatus(mDBApp.WS_REQUESTED, &limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find pending withdrawals: %w", err)
+		}
+		if requested == nil {
+			return nil, fmt.Errorf("failed to get pending withdrawals because withdrawals is nil")
+		}
+		pendingWithdrawals = append(pendingWithdrawals, *requested...)
+	}
+
+	sort.SliceStable(pendingWithdrawals, func(i, j int) bool {
+		return pendingWithdrawals[i].CreatedAt.Before(pendingWithdrawals[j].CreatedAt)
+	})
+
+	return pendingWithdrawals, nil
 }
 
 func (w *WithdrawalAggregatorService) shouldProcessWithdrawals(pendingWithdrawals []mDBApp.Withdrawal) bool {
@@ -268,117 +355,390 @@ func MakeWithdrawalInfo(
 	}, nil
 }
 
-// func (w *WithdrawalAggregatorService) fetchWithdrawalProofsFromProver(pendingWithdrawals []mDBApp.Withdrawal) ([]ProofValue, error) {
-// 	var idsQuery string
-// 	for _, pendingWithdrawal := range pendingWithdrawals {
-// 		idsQuery += fmt.Sprintf("ids=%s&", pendingWithdrawal.ID)
-// 	}
-// 	if len(idsQuery) > 0 {
-// 		idsQuery = idsQuery[:len(idsQuery)-1]
-// 	}
-// 	apiUrl := fmt.Sprintf("%s/proofs?%s",
-// 		w.cfg.API.WithdrawalProverUrl,
-// 		idsQuery,
-// 	)
-
-// 	resp, err := http.Get(apiUrl) // nolint:gosec
-// 	if err != nil {
-// 		return nil, fmt.Errorf("failed to request API: %w", err)
-// 	}
-// 	defer func() {
-// 		_ = resp.Body.Close()
-// 	}()
-
-// 	var res ProofsResponse
-// 	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
-// 		return nil, fmt.Errorf("failed to decode JSON response: %w", err)
-// 	}
-
-// 	if !res.Success {
-// 		return nil, fmt.Errorf("prover request failed %s", res.ErrorMessage)
-// 	}
-
-// 	return res.Values, nil
-// }
-
-// func (w *WithdrawalAggregatorService) buildSubmitWithdrawalProofData(pendingWithdrawals []mDBApp.Withdrawal, proofs []ProofValue) error {
-// 	return nil
-// }
-
-func (w *WithdrawalAggregatorService) buildMockSubmitWithdrawalProofData(pendingWithdrawals []mDBApp.Withdrawal) (*WithdrawalInfo, error) {
-	// private key to address
-	decKey, err := hex.DecodeString(w.cfg.Blockchain.WithdrawalPrivateKeyHex)
-	if err != nil {
-		return nil, err
+// proveWithdrawals proves the single withdrawals of the pending withdrawals concurrently and chains their
+// withdrawal proofs in order. A withdrawal rejected by the provers is failed and a withdrawal that can not be
+// proved now is requested again, neither stops the other withdrawals from being chained.
+// It returns the withdrawals with the chained withdrawal proofs and the job ID of their wrapper proof.
+func (w *WithdrawalAggregatorService) proveWithdrawals(
+	pendingWithdrawals []mDBApp.Withdrawal,
+) (proved []mDBApp.Withdrawal, wrapperJobID string, err error) {
+	// The proofs of an interrupted run are chained with other proofs, so every run proves with new jobs.
+	jobIDs := make([]string, len(pendingWithdrawals))
+	for key := range pendingWithdrawals {
+		jobIDs[key] = uuid.New().String()
+		err = w.db.UpdateWithdrawalsProverJob([]string{pendingWithdrawals[key].ID}, mDBApp.WS_PROVING, jobIDs[key])
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to update withdrawal prover job: %w", err)
+		}
 	}
 
-	pk, err := crypto.ToECDSA(decKey)
-	if err != nil {
-		return nil, err
+	var wg sync.WaitGroup
+	singleWithdrawalProofs := make([]string, len(pendingWithdrawals))
+	singleWithdrawalErrs := make([]error, len(pendingWithdrawals))
+	wg.Add(len(pendingWithdrawals))
+	for key := range pendingWithdrawals {
+		go func(key int) {
+			defer wg.Done()
+			singleWithdrawalProofs[key], singleWithdrawalErrs[key] = w.proveSingleWithdrawal(
+				jobIDs[key], &pendingWithdrawals[key],
+			)
+		}(key)
 	}
+	wg.Wait()
 
-	aggregator := crypto.PubkeyToAddress(pk.PublicKey)
-	fmt.Printf("Aggregator address: %s\n", aggregator.String())
+	proved = make([]mDBApp.Withdrawal, 0, len(pendingWithdrawals))
+	var prevWithdrawalProof *string
+	for key := range pendingWithdrawals {
+		withdrawal := pendingWithdrawals[key]
 
-	withdrawals := make([]bindings.ChainedWithdrawalLibChainedWithdrawal, 0, len(pendingWithdrawals))
-	for _, withdrawal := range pendingWithdrawals {
-		amount, ok := new(big.Int).SetString(withdrawal.TransferData.Amount, int10Key)
-		if !ok {
-			return nil, fmt.Errorf("failed to set amount")
+		if singleWithdrawalErrs[key] != nil {
+			err = w.skipWithdrawal(withdrawal.ID, singleWithdrawalErrs[key])
+			if err != nil {
+				return nil, "", err
+			}
+			continue
 		}
 
-		var recipientBytes []byte
-		recipientBytes, err = hexutil.Decode(withdrawal.TransferData.Recipient)
+		var withdrawalProof *mDBApp.WithdrawalProof
+		withdrawalProof, err = w.chainWithdrawalProof(
+			jobIDs[key], &withdrawal, singleWithdrawalProofs[key], prevWithdrawalProof,
+		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to decode recipient address: %w", err)
+			err = w.skipWithdrawal(withdrawal.ID, err)
+			if err != nil {
+				return nil, "", err
+			}
+			continue
 		}
-		var recipient *intMaxTypes.GenericAddress
-		recipient, err = intMaxTypes.NewEthereumAddress(recipientBytes)
+
+		err = w.db.UpdateWithdrawalProof(withdrawal.ID, withdrawalProof)
 		if err != nil {
-			return nil, fmt.Errorf("failed to convert recipient address: %w", err)
+			return nil, "", fmt.Errorf("failed to update withdrawal proof: %w", err)
 		}
 
-		var saltBytes []byte
-		saltBytes, err = hexutil.Decode(withdrawal.TransferData.Salt)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode salt: %w", err)
+		withdrawal.WithdrawalProof = withdrawalProof
+		proved = append(proved, withdrawal)
+		prevWithdrawalProof = &withdrawalProof.Proof
+	}
+
+	if len(proved) == 0 {
+		return nil, "", nil
+	}
+
+	wrapperJobID = uuid.New().String()
+	err = w.db.UpdateWithdrawalsProverJob(extractIds(proved), mDBApp.WS_PROVED, wrapperJobID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to update withdrawal prover job: %w", err)
+	}
+
+	return proved, wrapperJobID, nil
+}
+
+// proveSingleWithdrawal proves the transfer of the withdrawal with the balance proof of its sender.
+// The single withdrawal proof is the input of the withdrawal circuit.
+func (w *WithdrawalAggregatorService) proveSingleWithdrawal(jobID string, withdrawal *mDBApp.Withdrawal) (string, error) {
+	proof, err := w.swp.SingleWithdrawalProof(w.ctx, jobID, makeSingleWithdrawalWitness(withdrawal))
+	if err != nil {
+		return "", fmt.Errorf("failed to get single withdrawal proof: %w", err)
+	}
+
+	return proof, nil
+}
+
+// chainWithdrawalProof proves the withdrawal chained with the proof of the previous withdrawals
+// and checks that the withdrawal proof proves the requested withdrawal.
+func (w *WithdrawalAggregatorService) chainWithdrawalProof(
+	jobID string,
+	withdrawal *mDBApp.Withdrawal,
+	singleWithdrawalProof string,
+	prevWithdrawalProof *string,
+) (*mDBApp.WithdrawalProof, error) {
+	expected, err := makeChainedWithdrawal(withdrawal)
+	if err != nil {
+		return nil, errors.Join(ErrWithdrawalInvalid, err)
+	}
+
+	err = w.prover.RequestWithdrawalProof(w.ctx, jobID, singleWithdrawalProof, prevWithdrawalProof)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request withdrawal proof: %w", err)
+	}
+
+	var proof *withdrawal_prover.WithdrawalProofContent
+	proof, err = w.prover.WaitForWithdrawalProof(w.ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get withdrawal proof: %w", err)
+	}
+
+	withdrawalProof := mDBApp.WithdrawalProof{
+		Proof: proof.Proof,
+		Withdrawal: mDBApp.ChainedWithdrawal{
+			Recipient:   proof.Withdrawal.Recipient,
+			TokenIndex:  proof.Withdrawal.TokenIndex,
+			Amount:      proof.Withdrawal.Amount,
+			Nullifier:   proof.Withdrawal.Nullifier,
+			BlockHash:   proof.Withdrawal.BlockHash,
+			BlockNumber: proof.Withdrawal.BlockNumber,
+		},
+	}
+
+	var proved *bindings.ChainedWithdrawalLibChainedWithdrawal
+	proved, err = provedChainedWithdrawal(&withdrawalProof.Withdrawal)
+	if err != nil {
+		return nil, err
+	}
+
+	if !equalChainedWithdrawals(expected, proved) {
+		return nil, ErrWithdrawalProofMismatch
+	}
+
+	return &withdrawalProof, nil
+}
+
+// skipWithdrawal fails the withdrawal rejected by the provers and requests again the withdrawal
+// that can not be proved now.
+func (w *WithdrawalAggregatorService) skipWithdrawal(id string, cause error) error {
+	status := mDBApp.WS_REQUESTED
+	if isRejected(cause) {
+		status = mDBApp.WS_FAILED
+	}
+
+	w.log.WithError(cause).Warnf("Failed to prove withdrawal %s, the withdrawal is %s", id, status)
+
+	err := w.db.UpdateWithdrawalsStatus([]string{id}, status)
+	if err != nil {
+		return fmt.Errorf("failed to update withdrawal status: %w", err)
+	}
+
+	return nil
+}
+
+// submitWithdrawals wraps the last withdrawal proof of the proved withdrawals for the withdrawal aggregator
+// and submits the wrapped proof with the withdrawals to the Withdrawal contract.
+// The withdrawals are taken from the proofs, so that only the proved withdrawals are submitted.
+func (w *WithdrawalAggregatorService) submitWithdrawals(wrapperJobID string, proved []mDBApp.Withdrawal) error {
+	ids := extractIds(proved)
+
+	aggregator, err := w.withdrawalAggregatorAddress()
+	if err != nil {
+		return err
+	}
+
+	withdrawals := make([]bindings.ChainedWithdrawalLibChainedWithdrawal, len(proved))
+	for key := range proved {
+		if proved[key].WithdrawalProof == nil {
+			return fmt.Errorf("%w: withdrawal %s", ErrWithdrawalProofNotFound, proved[key].ID)
 		}
 
-		salt := new(goldenposeidon.PoseidonHashOut)
-		err = salt.Unmarshal(saltBytes)
+		var withdrawal *bindings.ChainedWithdrawalLibChainedWithdrawal
+		withdrawal, err = provedChainedWithdrawal(&proved[key].WithdrawalProof.Withdrawal)
 		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal salt: %w", err)
+			return w.failWithdrawals(ids, err)
 		}
+		withdrawals[key] = *withdrawal
+	}
 
-		withdrawalTransfer := intMaxTypes.Transfer{
-			Recipient:  recipient,
-			TokenIndex: uint32(withdrawal.TransferData.TokenIndex),
-			Amount:     amount,
-			Salt:       salt,
-		}
+	err = w.prover.RequestWrapperProof(w.ctx, wrapperJobID, aggregator, proved[len(proved)-1].WithdrawalProof.Proof)
+	if err != nil {
+		return w.failWithdrawals(ids, fmt.Errorf("failed to request wrapper proof: %w", err))
+	}
 
-		nullifier := [int32Key]byte{}
-		copy(nullifier[:], withdrawalTransfer.GetWithdrawalNullifier().Marshal())
-		singleWithdrawal := bindings.ChainedWithdrawalLibChainedWithdrawal{
-			Recipient:   common.HexToAddress(withdrawal.TransferData.Recipient),
-			TokenIndex:  uint32(withdrawal.TransferData.TokenIndex),
-			Amount:      amount,
-			Nullifier:   nullifier,
-			BlockHash:   common.HexToHash(withdrawal.BlockHash),
-			BlockNumber: uint32(withdrawal.BlockNumber),
+	var wrapperProof string
+	wrapperProof, err = w.prover.WaitForWrapperProof(w.ctx, wrapperJobID)
+	if err != nil {
+		return w.failWithdrawals(ids, fmt.Errorf("failed to get wrapper proof: %w", err))
+	}
+
+	var proof []byte
+	proof, err = base64.StdEncoding.DecodeString(wrapperProof)
+	if err != nil {
+		return w.failWithdrawals(ids, errors.Join(ErrDecodeWrapperProofFail, err))
+	}
+
+	var withdrawalInfo *WithdrawalInfo
+	withdrawalInfo, err = MakeWithdrawalInfo(aggregator, withdrawals, proof)
+	if err != nil {
+		return err
+	}
+
+	var receipt *types.Receipt
+	receipt, err = w.submitter.SubmitWithdrawalProof(
+		withdrawalInfo.Withdrawals,
+		withdrawalInfo.WithdrawalProofPublicInputs,
+		withdrawalInfo.Proof,
+	)
+	if err != nil {
+		if err.Error() == "WithdrawalProofVerificationFailed" {
+			w.log.Errorf("Failed to submit withdrawal proof: %v", err.Error())
+			err = w.db.UpdateWithdrawalsStatus(ids, mDBApp.WS_FAILED)
+			if err != nil {
+				return fmt.Errorf("failed to update withdrawal status: %w", err)
+			}
+			return nil
 		}
-		withdrawals = append(withdrawals, singleWithdrawal)
+		return fmt.Errorf("failed to submit withdrawal proof: %w", err)
+	}
+
+	if receipt == nil {
+		return errors.New("received nil receipt for transaction")
 	}
 
-	withdrawalInfo, err := MakeWithdrawalInfo(aggregator, withdrawals, []byte{})
+	switch receipt.Status {
+	case types.ReceiptStatusSuccessful:
+		w.log.Infof("Successfully submit withdrawal proof %d withdrawals. Transaction Hash: %v", len(proved), receipt.TxHash.Hex())
+	case types.ReceiptStatusFailed:
+		return fmt.Errorf("transaction failed: submit withdrawal proof unsuccessful. Transaction Hash: %v", receipt.TxHash.Hex())
+	default:
+		return fmt.Errorf("unexpected transaction status: %d. Transaction Hash: %v", receipt.Status, receipt.TxHash.Hex())
+	}
+
+	err = w.db.UpdateWithdrawalsStatus(ids, mDBApp.WS_SUBMITTED)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to update withdrawal status: %w", err)
 	}
 
-	return withdrawalInfo, nil
+	return nil
+}
+
+// failWithdrawals fails the proved withdrawals, if their wrapper proof is rejected by the withdrawal prover.
+// Otherwise the cause is returned and the proved withdrawals are submitted by the next run.
+func (w *WithdrawalAggregatorService) failWithdrawals(ids []string, cause error) error {
+	if !isRejected(cause) {
+		return cause
+	}
+
+	w.log.WithError(cause).Errorf("Failed to wrap %d withdrawal proofs, the withdrawals are failed", len(ids))
+
+	err := w.db.UpdateWithdrawalsStatus(ids, mDBApp.WS_FAILED)
+	if err != nil {
+		return fmt.Errorf("failed to update withdrawal status: %w", err)
+	}
+
+	return nil
+}
+
+// isRejected reports whether the withdrawal can not be proved by retrying.
+func isRejected(err error) bool {
+	return errors.Is(err, balance_validity_prover.ErrRequestRejected) ||
+		errors.Is(err, withdrawal_prover.ErrRequestRejected) ||
+		errors.Is(err, ErrWithdrawalInvalid) ||
+		errors.Is(err, ErrWithdrawalProofMismatch) ||
+		errors.Is(err, ErrDecodeWrapperProofFail)
+}
+
+func (w *WithdrawalAggregatorService) withdrawalAggregatorAddress() (common.Address, error) {
+	decKey, err := hex.DecodeString(w.cfg.Blockchain.WithdrawalPrivateKeyHex)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	pk, err := crypto.ToECDSA(decKey)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return crypto.PubkeyToAddress(pk.PublicKey), nil
+}
+
+// makeSingleWithdrawalWitness makes the witness of the single withdrawal circuit from the withdrawal request.
+func makeSingleWithdrawalWitness(withdrawal *mDBApp.Withdrawal) *balance_validity_prover.SingleWithdrawalWitness {
+	return &balance_validity_prover.SingleWithdrawalWitness{
+		TransferWitness: balance_validity_prover.TransferWitness{
+			Tx: balance_validity_prover.SingleWithdrawalTx{
+				TransferTreeRoot: withdrawal.Transaction.TransferTreeRoot,
+				Nonce:            uint64(withdrawal.Transaction.Nonce),
+			},
+			Transfer: balance_validity_prover.SingleWithdrawalTransfer{
+				Recipient:  withdrawal.TransferData.Recipient,
+				TokenIndex: uint32(withdrawal.TransferData.TokenIndex),
+				Amount:     withdrawal.TransferData.Amount,
+				Salt:       withdrawal.TransferData.Salt,
+			},
+			TransferIndex: uint32(withdrawal.TransferMerkleProof.Index),
+			TransferMerkleProof: balance_validity_prover.TransferMerkleProof{
+				Siblings: withdrawal.TransferMerkleProof.Siblings,
+			},
+		},
+		BalanceProof: withdrawal.EnoughBalanceProof.Proof,
+	}
+}
+
+// makeChainedWithdrawal makes the withdrawal expected in the withdrawal proof from the withdrawal request.
+func makeChainedWithdrawal(withdrawal *mDBApp.Withdrawal) (*bindings.ChainedWithdrawalLibChainedWithdrawal, error) {
+	amount, ok := new(big.Int).SetString(withdrawal.TransferData.Amount, int10Key)
+	if !ok {
+		return nil, fmt.Errorf("failed to set amount")
+	}
+
+	recipientBytes, err := hexutil.Decode(withdrawal.TransferData.Recipient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode recipient address: %w", err)
+	}
+	var recipient *intMaxTypes.GenericAddress
+	recipient, err = intMaxTypes.NewEthereumAddress(recipientBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert recipient address: %w", err)
+	}
+
+	var saltBytes []byte
+	saltBytes, err = hexutil.Decode(withdrawal.TransferData.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode salt: %w", err)
+	}
+
+	salt := new(goldenposeidon.PoseidonHashOut)
+	err = salt.Unmarshal(saltBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal salt: %w", err)
+	}
+
+	withdrawalTransfer := intMaxTypes.Transfer{
+		Recipient:  recipient,
+		TokenIndex: uint32(withdrawal.TransferData.TokenIndex),
+		Amount:     amount,
+		Salt:       salt,
+	}
+
+	nullifier := [int32Key]byte{}
+	copy(nullifier[:], withdrawalTransfer.GetWithdrawalNullifier().Marshal())
+
+	return &bindings.ChainedWithdrawalLibChainedWithdrawal{
+		Recipient:   common.HexToAddress(withdrawal.TransferData.Recipient),
+		TokenIndex:  uint32(withdrawal.TransferData.TokenIndex),
+		Amount:      amount,
+		Nullifier:   nullifier,
+		BlockHash:   common.HexToHash(withdrawal.BlockHash),
+		BlockNumber: uint32(withdrawal.BlockNumber),
+	}, nil
+}
+
+func provedChainedWithdrawal(
+	withdrawal *mDBApp.ChainedWithdrawal,
+) (*bindings.ChainedWithdrawalLibChainedWithdrawal, error) {
+	amount, ok := new(big.Int).SetString(withdrawal.Amount, int10Key)
+	if !ok {
+		return nil, fmt.Errorf("%w: amount %s", ErrWithdrawalProofMismatch, withdrawal.Amount)
+	}
+
+	return &bindings.ChainedWithdrawalLibChainedWithdrawal{
+		Recipient:   common.HexToAddress(withdrawal.Recipient),
+		TokenIndex:  withdrawal.TokenIndex,
+		Amount:      amount,
+		Nullifier:   common.HexToHash(withdrawal.Nullifier),
+		BlockHash:   common.HexToHash(withdrawal.BlockHash),
+		BlockNumber: withdrawal.BlockNumber,
+	}, nil
+}
+
+func equalChainedWithdrawals(a, b *bindings.ChainedWithdrawalLibChainedWithdrawal) bool {
+	return a.Recipient == b.Recipient &&
+		a.TokenIndex == b.TokenIndex &&
+		a.Amount.Cmp(b.Amount) == 0 &&
+		a.Nullifier == b.Nullifier &&
+		a.BlockHash == b.BlockHash &&
+		a.BlockNumber == b.BlockNumber
 }
 
-func (w *WithdrawalAggregatorService) submitWithdrawalProof(
+func (w *withdrawalContractSubmitter) SubmitWithdrawalProof(
 	withdrawals []bindings.ChainedWithdrawalLibChainedWithdrawal,
 	publicInputs bindings.WithdrawalProofPublicInputsLibWithdrawalProofPublicInputs,
 	proof []byte,
@@ -0,0 +1,324 @@
+package withdrawal_service_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"intmax2-node/configs"
+	"intmax2-node/internal/balance_validity_prover"
+	"intmax2-node/internal/bindings"
+	"intmax2-node/internal/hash/goldenposeidon"
+	intMaxTypes "intmax2-node/internal/types"
+	"intmax2-node/internal/withdrawal_prover"
+	"intmax2-node/internal/withdrawal_service"
+	"intmax2-node/pkg/logger"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// withdrawalStore imitates the withdrawals table ordered by the creation time.
+type withdrawalStore struct {
+	withdrawals []*mDBApp.Withdrawal
+}
+
+func (s *withdrawalStore) expect(db *MockSQLDriverApp) {
+	byID := func(id string) *mDBApp.Withdrawal {
+		for key := range s.withdrawals {
+			if s.withdrawals[key].ID == id {
+				return s.withdrawals[key]
+			}
+		}
+		panic(fmt.Sprintf("unknown withdrawal %s", id))
+	}
+
+	db.EXPECT().WithdrawalsByStatus(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(status mDBApp.WithdrawalStatus, limit *int) (*[]mDBApp.Withdrawal, error) {
+			withdrawals := make([]mDBApp.Withdrawal, 0)
+			for key := range s.withdrawals {
+				if limit != nil && len(withdrawals) == *limit {
+					break
+				}
+				if s.withdrawals[key].Status == int64(status) {
+					withdrawals = append(withdrawals, *s.withdrawals[key])
+				}
+			}
+			return &withdrawals, nil
+		},
+	).AnyTimes()
+	db.EXPECT().UpdateWithdrawalsProverJob(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ids []string, status mDBApp.WithdrawalStatus, proverJobID string) error {
+			for key := range ids {
+				byID(ids[key]).Status = int64(status)
+				byID(ids[key]).ProverJobID = proverJobID
+			}
+			return nil
+		},
+	).AnyTimes()
+	db.EXPECT().UpdateWithdrawalsStatus(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ids []string, status mDBApp.WithdrawalStatus) error {
+			for key := range ids {
+				byID(ids[key]).Status = int64(status)
+			}
+			return nil
+		},
+	).AnyTimes()
+	db.EXPECT().UpdateWithdrawalProof(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(id string, withdrawalProof *mDBApp.WithdrawalProof) error {
+			byID(id).WithdrawalProof = withdrawalProof
+			return nil
+		},
+	).AnyTimes()
+}
+
+func (s *withdrawalStore) statuses() map[string]mDBApp.WithdrawalStatus {
+	statuses := make(map[string]mDBApp.WithdrawalStatus)
+	for key := range s.withdrawals {
+		statuses[s.withdrawals[key].ID] = mDBApp.WithdrawalStatus(s.withdrawals[key].Status)
+	}
+	return statuses
+}
+
+// fakeProvers imitates the balance validity prover and the withdrawal prover.
+// The proofs are the strings naming the recipients of the proved withdrawals.
+type fakeProvers struct {
+	mu sync.Mutex
+
+	singleWithdrawalErrs map[string]error
+	chainedWithdrawals   map[string]mDBApp.ChainedWithdrawal
+	chainJobs            map[string]*withdrawal_prover.WithdrawalProofContent
+	prevWithdrawalProofs []*string
+	wrapperJobs          map[string]string
+	submitted            [][]bindings.ChainedWithdrawalLibChainedWithdrawal
+}
+
+func (f *fakeProvers) expect(
+	swp *MockSingleWithdrawalProver,
+	prover *MockWithdrawalProver,
+	submitter *MockWithdrawalProofSubmitter,
+) {
+	swp.EXPECT().SingleWithdrawalProof(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, witness *balance_validity_prover.SingleWithdrawalWitness) (string, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+
+			recipient := witness.TransferWitness.Transfer.Recipient
+			err := f.singleWithdrawalErrs[recipient]
+			if err != nil {
+				return "", err
+			}
+
+			return "single:" + recipient, nil
+		},
+	).AnyTimes()
+
+	prover.EXPECT().RequestWithdrawalProof(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, jobID, singleWithdrawalProof string, prevWithdrawalProof *string) error {
+			recipient := singleWithdrawalProof[len("single:"):]
+			f.chainJobs[jobID] = &withdrawal_prover.WithdrawalProofContent{
+				Proof: "chain:" + recipient,
+				Withdrawal: withdrawal_prover.ChainedWithdrawal{
+					Recipient:   f.chainedWithdrawals[recipient].Recipient,
+					TokenIndex:  f.chainedWithdrawals[recipient].TokenIndex,
+					Amount:      f.chainedWithdrawals[recipient].Amount,
+					Nullifier:   f.chainedWithdrawals[recipient].Nullifier,
+					BlockHash:   f.chainedWithdrawals[recipient].BlockHash,
+					BlockNumber: f.chainedWithdrawals[recipient].BlockNumber,
+				},
+			}
+			f.prevWithdrawalProofs = append(f.prevWithdrawalProofs, prevWithdrawalProof)
+			return nil
+		},
+	).AnyTimes()
+	prover.EXPECT().WaitForWithdrawalProof(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, jobID string) (*withdrawal_prover.WithdrawalProofContent, error) {
+			return f.chainJobs[jobID], nil
+		},
+	).AnyTimes()
+	prover.EXPECT().RequestWrapperProof(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, jobID string, _ common.Address, withdrawalProof string) error {
+			f.wrapperJobs[jobID] = withdrawalProof
+			return nil
+		},
+	).AnyTimes()
+	prover.EXPECT().WaitForWrapperProof(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, jobID string) (string, error) {
+			return base64.StdEncoding.EncodeToString([]byte("wrapper:" + f.wrapperJobs[jobID])), nil
+		},
+	).AnyTimes()
+
+	submitter.EXPECT().SubmitWithdrawalProof(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(
+			withdrawals []bindings.ChainedWithdrawalLibChainedWithdrawal,
+			_ bindings.WithdrawalProofPublicInputsLibWithdrawalProofPublicInputs,
+			_ []byte,
+		) (*types.Receipt, error) {
+			f.submitted = append(f.submitted, withdrawals)
+			return &types.Receipt{Status: types.ReceiptStatusSuccessful}, nil
+		},
+	).AnyTimes()
+}
+
+func newWithdrawal(t *testing.T, i int) (*mDBApp.Withdrawal, mDBApp.ChainedWithdrawal) {
+	recipient := common.BigToAddress(big.NewInt(int64(i + 1)))
+	blockHash := common.BigToHash(big.NewInt(int64(i + 100)))
+	amount := big.NewInt(int64(i + 1000))
+
+	salt := new(goldenposeidon.PoseidonHashOut)
+	require.NoError(t, salt.FromString(common.BigToHash(big.NewInt(int64(i+10))).Hex()))
+
+	genericRecipient, err := intMaxTypes.NewEthereumAddress(recipient.Bytes())
+	require.NoError(t, err)
+	transfer := intMaxTypes.Transfer{
+		Recipient:  genericRecipient,
+		TokenIndex: 0,
+		Amount:     amount,
+		Salt:       salt,
+	}
+
+	withdrawal := mDBApp.Withdrawal{
+		ID:     fmt.Sprintf("withdrawal-%d", i),
+		Status: int64(mDBApp.WS_REQUESTED),
+		TransferData: mDBApp.TransferData{
+			Recipient: recipient.Hex(),
+			Amount:    amount.String(),
+			Salt:      hexutil.Encode(salt.Marshal()),
+		},
+		BlockNumber: int64(i + 1),
+		BlockHash:   blockHash.Hex(),
+		CreatedAt:   time.Unix(int64(i), 0),
+	}
+
+	return &withdrawal, mDBApp.ChainedWithdrawal{
+		Recipient:   recipient.Hex(),
+		Amount:      amount.String(),
+		Nullifier:   common.BytesToHash(transfer.GetWithdrawalNullifier().Marshal()).Hex(),
+		BlockHash:   blockHash.Hex(),
+		BlockNumber: uint32(i + 1),
+	}
+}
+
+func TestWithdrawalAggregatorService(t *testing.T) {
+	const numOfWithdrawals = 4
+
+	cfg := configs.Config{}
+	cfg.Blockchain.WithdrawalPrivateKeyHex = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcaf784d7bf4f2ff80"
+	cfg.Blockchain.WithdrawalAggregatorThreshold = numOfWithdrawals
+
+	log := logger.New("error", time.RFC3339, false, false)
+
+	setup := func(t *testing.T) (*withdrawal_service.WithdrawalAggregatorService, *withdrawalStore, *fakeProvers) {
+		ctrl := gomock.NewController(t)
+		db := NewMockSQLDriverApp(ctrl)
+		swp := NewMockSingleWithdrawalProver(ctrl)
+		prover := NewMockWithdrawalProver(ctrl)
+		submitter := NewMockWithdrawalProofSubmitter(ctrl)
+
+		store := withdrawalStore{}
+		store.expect(db)
+
+		provers := fakeProvers{
+			singleWithdrawalErrs: make(map[string]error),
+			chainedWithdrawals:   make(map[string]mDBApp.ChainedWithdrawal),
+			chainJobs:            make(map[string]*withdrawal_prover.WithdrawalProofContent),
+			wrapperJobs:          make(map[string]string),
+		}
+		provers.expect(swp, prover, submitter)
+
+		for i := 0; i < numOfWithdrawals; i++ {
+			withdrawal, chainedWithdrawal := newWithdrawal(t, i)
+			store.withdrawals = append(store.withdrawals, withdrawal)
+			provers.chainedWithdrawals[withdrawal.TransferData.Recipient] = chainedWithdrawal
+		}
+
+		service := withdrawal_service.NewWithdrawalAggregatorService(
+			context.Background(), &cfg, log, db, swp, prover, submitter,
+		)
+
+		return service, &store, &provers
+	}
+
+	t.Run("Failed withdrawals do not fail the other withdrawals", func(t *testing.T) {
+		service, store, provers := setup(t)
+
+		rejected := store.withdrawals[1]
+		provers.singleWithdrawalErrs[rejected.TransferData.Recipient] = balance_validity_prover.ErrRequestRejected
+		unavailable := store.withdrawals[2]
+		provers.singleWithdrawalErrs[unavailable.TransferData.Recipient] = balance_validity_prover.ErrSendRequestFail
+
+		require.NoError(t, service.Aggregate())
+
+		assert.Equal(t, map[string]mDBApp.WithdrawalStatus{
+			"withdrawal-0": mDBApp.WS_SUBMITTED,
+			"withdrawal-1": mDBApp.WS_FAILED,
+			"withdrawal-2": mDBApp.WS_REQUESTED,
+			"withdrawal-3": mDBApp.WS_SUBMITTED,
+		}, store.statuses())
+
+		// The proof of the last withdrawal is chained with the proof of the first withdrawal.
+		require.Len(t, provers.prevWithdrawalProofs, 2)
+		assert.Nil(t, provers.prevWithdrawalProofs[0])
+		assert.Equal(t, "chain:"+store.withdrawals[0].TransferData.Recipient, *provers.prevWithdrawalProofs[1])
+
+		require.Len(t, provers.submitted, 1)
+		require.Len(t, provers.submitted[0], 2)
+		assert.Equal(t, common.HexToAddress(store.withdrawals[0].TransferData.Recipient), provers.submitted[0][0].Recipient)
+		assert.Equal(t, common.HexToAddress(store.withdrawals[3].TransferData.Recipient), provers.submitted[0][1].Recipient)
+	})
+
+	t.Run("Mismatched withdrawal proof fails the withdrawal", func(t *testing.T) {
+		service, store, provers := setup(t)
+
+		mismatched := provers.chainedWithdrawals[store.withdrawals[0].TransferData.Recipient]
+		mismatched.Amount = "1"
+		provers.chainedWithdrawals[store.withdrawals[0].TransferData.Recipient] = mismatched
+
+		require.NoError(t, service.Aggregate())
+
+		assert.Equal(t, mDBApp.WS_FAILED, store.statuses()["withdrawal-0"])
+		require.Len(t, provers.submitted, 1)
+		assert.Len(t, provers.submitted[0], numOfWithdrawals-1)
+		assert.Nil(t, provers.prevWithdrawalProofs[1])
+	})
+
+	t.Run("Interrupted withdrawals are resumed", func(t *testing.T) {
+		service, store, provers := setup(t)
+
+		// The first two withdrawals were chained and the next one was proving, when the last run was interrupted.
+		const wrapperJobID = "wrapper-job"
+		for key := range store.withdrawals[:2] {
+			chainedWithdrawal := provers.chainedWithdrawals[store.withdrawals[key].TransferData.Recipient]
+			store.withdrawals[key].Status = int64(mDBApp.WS_PROVED)
+			store.withdrawals[key].ProverJobID = wrapperJobID
+			store.withdrawals[key].WithdrawalProof = &mDBApp.WithdrawalProof{
+				Proof:      "chain:" + store.withdrawals[key].TransferData.Recipient,
+				Withdrawal: chainedWithdrawal,
+			}
+		}
+		store.withdrawals[2].Status = int64(mDBApp.WS_PROVING)
+		store.withdrawals[2].ProverJobID = "interrupted-job"
+
+		require.NoError(t, service.Aggregate())
+
+		for id, status := range store.statuses() {
+			assert.Equal(t, mDBApp.WS_SUBMITTED, status, id)
+		}
+
+		// The wrapper proof of the interrupted batch wraps the last chained withdrawal proof.
+		assert.Equal(t, "chain:"+store.withdrawals[1].TransferData.Recipient, provers.wrapperJobs[wrapperJobID])
+		require.Len(t, provers.submitted, 2)
+		assert.Len(t, provers.submitted[0], 2)
+		assert.Len(t, provers.submitted[1], 2)
+		assert.Equal(t, common.HexToAddress(store.withdrawals[2].TransferData.Recipient), provers.submitted[1][0].Recipient)
+		assert.NotEqual(t, "interrupted-job", store.withdrawals[2].ProverJobID)
+	})
+}
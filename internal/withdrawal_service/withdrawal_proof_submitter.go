@@ -0,0 +1,17 @@
+package withdrawal_service
+
+import (
+	"intmax2-node/internal/bindings"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//go:generate mockgen -destination=mock_withdrawal_proof_submitter_test.go -package=withdrawal_service_test -source=withdrawal_proof_submitter.go
+
+type WithdrawalProofSubmitter interface {
+	SubmitWithdrawalProof(
+		withdrawals []bindings.ChainedWithdrawalLibChainedWithdrawal,
+		publicInputs bindings.WithdrawalProofPublicInputsLibWithdrawalProofPublicInputs,
+		proof []byte,
+	) (*types.Receipt, error)
+}
@@ -0,0 +1,17 @@
+package withdrawal_service
+
+import (
+	"context"
+	"intmax2-node/internal/withdrawal_prover"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:generate mockgen -destination=mock_withdrawal_prover_test.go -package=withdrawal_service_test -source=withdrawal_prover.go
+
+type WithdrawalProver interface {
+	RequestWithdrawalProof(ctx context.Context, jobID, singleWithdrawalProof string, prevWithdrawalProof *string) error
+	WaitForWithdrawalProof(ctx context.Context, jobID string) (*withdrawal_prover.WithdrawalProofContent, error)
+	RequestWrapperProof(ctx context.Context, jobID string, withdrawalAggregator common.Address, withdrawalProof string) error
+	WaitForWrapperProof(ctx context.Context, jobID string) (string, error)
+}
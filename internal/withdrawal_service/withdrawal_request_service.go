@@ -34,10 +34,18 @@ type WithdrawalRequestService struct {
 	log    logger.Logger
 	db     SQLDriverApp
 	sb     ServiceBlockchain
+	bvp    BalanceValidityProver
 	rollup *bindings.Rollup
 }
 
-func newWithdrawalRequestService(ctx context.Context, cfg *configs.Config, log logger.Logger, db SQLDriverApp, sb ServiceBlockchain) (*WithdrawalRequestService, error) {
+func newWithdrawalRequestService(
+	ctx context.Context,
+	cfg *configs.Config,
+	log logger.Logger,
+	db SQLDriverApp,
+	sb ServiceBlockchain,
+	bvp BalanceValidityProver,
+) (*WithdrawalRequestService, error) {
 	scrollLink, err := sb.ScrollNetworkChainLinkEvmJSONRPC(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Scroll network chain link: %w", err)
@@ -59,6 +67,7 @@ func newWithdrawalRequestService(ctx context.Context, cfg *configs.Config, log l
 		log:    log,
 		db:     db,
 		sb:     sb,
+		bvp:    bvp,
 		rollup: rollup,
 	}, nil
 }
@@ -70,14 +79,15 @@ func PostWithdrawalRequest(
 	log logger.Logger,
 	db SQLDriverApp,
 	sb ServiceBlockchain,
+	bvp BalanceValidityProver,
 	input *postWithdrwalRequest.UCPostWithdrawalRequestInput,
 ) error {
-	service, err := newWithdrawalRequestService(ctx, cfg, log, db, sb)
+	service, err := newWithdrawalRequestService(ctx, cfg, log, db, sb, bvp)
 	if err != nil {
 		return fmt.Errorf("failed to create new withdrawal request service: %w", err)
 	}
 
-	err = service.verifyBalanceProof()
+	err = service.verifyBalanceProof(input)
 	if err != nil {
 		return fmt.Errorf("failed to verify balance proof: %w", err)
 	}
@@ -87,12 +97,9 @@ func PostWithdrawalRequest(
 		return fmt.Errorf("failed to send withdrawal request to prover: %w", err)
 	}
 
+	// The withdrawal proof is requested by the withdrawal aggregator,
+	// which chains the proofs of the requested withdrawals in order.
 	id := uuid.New().String()
-	err = service.requestWithdrawalProofToProver(id, input)
-	if err != nil {
-		return fmt.Errorf("failed to send withdrawal request to prover: %w", err)
-	}
-
 	_, err = db.CreateWithdrawal(
 		id,
 		&mDBApp.TransferData{
@@ -131,12 +138,6 @@ func PostWithdrawalRequest(
 	return nil
 }
 
-// TODO: NEED_TO_BE_IMPLEMENTED
-func (s *WithdrawalRequestService) verifyBalanceProof() error {
-	// Access to the Balance Validatity Prover
-	return nil
-}
-
 // Check the block number
 func (s *WithdrawalRequestService) checkBlockNumber(input *postWithdrwalRequest.UCPostWithdrawalRequestInput) error {
 	if input.BlockNumber >= int64(1)<<int32Key {
@@ -160,48 +161,3 @@ func (s *WithdrawalRequestService) checkBlockNumber(input *postWithdrwalRequest.
 
 	return nil
 }
-
-// TODO: NEED_TO_BE_CHANGED
-func (w *WithdrawalRequestService) requestWithdrawalProofToProver(id string, input *postWithdrwalRequest.UCPostWithdrawalRequestInput) error {
-	return nil
-	/*
-		requestBody := map[string]interface{}{
-			"id":         id,
-			"recipient":  input.TransferData.Recipient,
-			"tokenIndex": input.TransferData.TokenIndex,
-			"amount":     input.TransferData.Amount,
-			"salt":       input.TransferData.Salt,
-			"blockHash":  input.BlockHash,
-		}
-		jsonBody, err := json.Marshal(requestBody)
-		if err != nil {
-			return fmt.Errorf("failed to marshal JSON request body: %w", err)
-		}
-
-		apiUrl := fmt.Sprintf("%s/proof",
-			w.cfg.API.WithdrawalProverUrl,
-		)
-		resp, err := http.Post(apiUrl, "application/json", bytes.NewBuffer(jsonBody)) // nolint:gosec
-		if err != nil {
-			return fmt.Errorf("failed to request API: %w", err)
-		}
-		defer func() {
-			_ = resp.Body.Close()
-		}()
-
-		if resp.StatusCode != http.StatusOK {
-			return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-		}
-
-		var res GenerateProofResponse
-		if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
-			return fmt.Errorf("failed to decode JSON response: %w", err)
-		}
-
-		if !res.Success {
-			return fmt.Errorf("prover request failed %s", res.ErrorMessage)
-		}
-
-		return nil
-	*/
-}
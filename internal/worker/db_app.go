@@ -16,6 +16,7 @@ type SQLDriverApp interface {
 	Blocks
 	Signatures
 	TxMerkleProofs
+	BlockFees
 	EventBlockNumbers
 	CtrlEventBlockNumbersJobs
 	EventBlockNumbersErrors
@@ -52,10 +53,19 @@ type TxMerkleProofs interface {
 	TxMerkleProofsByID(id string) (*mDBApp.TxMerkleProofs, error)
 }
 
+type BlockFees interface {
+	CreateBlockFee(fee *mDBApp.BlockFee) (*mDBApp.BlockFee, error)
+}
+
 type EventBlockNumbers interface {
 	UpsertEventBlockNumber(eventName string, blockNumber uint64) (*mDBApp.EventBlockNumber, error)
+	UpsertEventBlockNumberAndHash(eventName string, blockNumber uint64, blockHash string) (*mDBApp.EventBlockNumber, error)
 	EventBlockNumberByEventName(eventName string) (*mDBApp.EventBlockNumber, error)
 	EventBlockNumbersByEventNames(eventNames []string) ([]*mDBApp.EventBlockNumber, error)
+	UpsertEventBlockHash(eventName string, blockNumber uint64, blockHash string) error
+	EventBlockHashesByEventName(eventName string) ([]*mDBApp.EventBlockHash, error)
+	DelEventBlockHashesAfterBlockNumber(eventName string, blockNumber uint64) error
+	DelEventBlockHashesBeforeBlockNumber(eventName string, blockNumber uint64) error
 }
 
 type CtrlEventBlockNumbersJobs interface {
@@ -83,6 +93,7 @@ type Senders interface {
 	SenderByID(id string) (*mDBApp.Sender, error)
 	SenderByAddress(address string) (*mDBApp.Sender, error)
 	SenderByPublicKey(publicKey string) (*mDBApp.Sender, error)
+	UpdateSenderNonce(id string, nonce uint64) error
 }
 
 type Accounts interface {
@@ -90,5 +101,7 @@ type Accounts interface {
 	AccountBySenderID(senderID string) (*mDBApp.Account, error)
 	AccountByAccountID(accountID *uint256.Int) (*mDBApp.Account, error)
 	ResetSequenceByAccounts() error
+	UpdateBlockNumberOfNewAccounts(blockNumber uint64) error
+	DelAccountsAfterBlockNumber(blockNumber uint64) error
 	DelAllAccounts() error
 }
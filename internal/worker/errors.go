@@ -20,8 +20,8 @@ var ErrCreateNewTempFileFail = errors.New("failed to create new temp file")
 // ErrCreateNewTempDirFail error: failed to create new temp directory.
 var ErrCreateNewTempDirFail = errors.New("failed to create new temp directory")
 
-// ErrStatCurrentFileFail error: failed to get stat of current file.
-var ErrStatCurrentFileFail = errors.New("failed to get stat of current file")
+// ErrStatFileFail error: failed to get stat of file.
+var ErrStatFileFail = errors.New("failed to get stat of file")
 
 // ErrReceiverWorkerEmpty error: the Receiver worker must not be empty.
 var ErrReceiverWorkerEmpty = errors.New("the Receiver worker must not be empty")
@@ -120,3 +120,57 @@ var ErrAvailableFilesProcessing = errors.New("failed to processing of the availa
 
 // ErrMakeRegistrationBlockFail error: failed to make registration block.
 var ErrMakeRegistrationBlockFail = errors.New("failed to make registration block")
+
+// ErrNonceAlreadyUsed error: the nonce has already been used by the sender.
+var ErrNonceAlreadyUsed = errors.New("the nonce has already been used by the sender")
+
+// ErrSenderByAddressFail error: failed to get sender by address.
+var ErrSenderByAddressFail = errors.New("failed to get sender by address")
+
+// ErrCreateSendersFail error: failed to create senders.
+var ErrCreateSendersFail = errors.New("failed to create senders")
+
+// ErrUpdateSenderNonceFail error: failed to update nonce of sender.
+var ErrUpdateSenderNonceFail = errors.New("failed to update nonce of sender")
+
+// ErrRestoreFilesFail error: failed to restore files of worker.
+var ErrRestoreFilesFail = errors.New("failed to restore files of worker")
+
+// ErrWorkerIDFail error: failed to get worker ID.
+var ErrWorkerIDFail = errors.New("failed to get worker ID")
+
+// ErrReadFileFail error: failed to read file.
+var ErrReadFileFail = errors.New("failed to read file")
+
+// ErrWriteFileFail error: failed to write file.
+var ErrWriteFileFail = errors.New("failed to write file")
+
+// ErrReadDirFail error: failed to read directory.
+var ErrReadDirFail = errors.New("failed to read directory")
+
+// ErrOpenFileFail error: failed to open file.
+var ErrOpenFileFail = errors.New("failed to open file")
+
+// ErrStoreTimestampFail error: failed to store timestamp of file.
+var ErrStoreTimestampFail = errors.New("failed to store timestamp of file")
+
+// ErrStorePostedBlockFail error: failed to store the posted block of file.
+var ErrStorePostedBlockFail = errors.New("failed to store the posted block of file")
+
+// ErrPostedBlockFail error: failed to get the posted block of file.
+var ErrPostedBlockFail = errors.New("failed to get the posted block of file")
+
+// ErrStoreLeafsFail error: failed to store leafs of tx tree.
+var ErrStoreLeafsFail = errors.New("failed to store leafs of tx tree")
+
+// ErrStoreSignatureFail error: failed to store signature.
+var ErrStoreSignatureFail = errors.New("failed to store signature")
+
+// ErrStoredSignaturesFail error: failed to get stored signatures.
+var ErrStoredSignaturesFail = errors.New("failed to get stored signatures")
+
+// ErrFeeAmountInvalid error: the amount of the fee must be a valid value.
+var ErrFeeAmountInvalid = errors.New("the amount of the fee must be a valid value")
+
+// ErrCreateBlockFeeFail error: failed to create the fee of the block.
+var ErrCreateBlockFeeFail = errors.New("failed to create the fee of the block")
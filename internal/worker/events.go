@@ -0,0 +1,98 @@
+package worker
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// The types of the lifecycle events of the transaction.
+const (
+	TxEventAccepted = "accepted"
+	TxEventProposed = "proposed"
+	TxEventSigned   = "signed"
+	TxEventPosted   = "posted"
+	TxEventDropped  = "dropped"
+)
+
+// The reasons of the dropped transactions.
+const (
+	ReasonTxNotSigned       = "the transaction is not signed by the sender in time"
+	ReasonTxTreeNotSigned   = "the signatures of the tx tree are not collected in time"
+	ReasonTxTreeNotIncluded = "the tx tree is not included into the block"
+)
+
+// TxEvent describes the lifecycle event of the transaction.
+// The event of the whole tx tree has the empty transaction hash.
+type TxEvent struct {
+	Type        string
+	TxHash      string
+	TxTreeRoot  string
+	BlockNumber uint64
+	BlockHash   string
+	Reason      string
+	CreatedAt   time.Time
+}
+
+// txEvents delivers the events to the subscribers of the transaction hash and of the tx tree root.
+type txEvents struct {
+	sync.Mutex
+	bufferSize  int
+	subscribers map[string]map[chan *TxEvent]struct{}
+}
+
+func newTxEvents(bufferSize int) *txEvents {
+	return &txEvents{
+		bufferSize:  bufferSize,
+		subscribers: make(map[string]map[chan *TxEvent]struct{}),
+	}
+}
+
+func (e *txEvents) subscribe(key string) (events <-chan *TxEvent, unsubscribe func()) {
+	key = strings.ToLower(key)
+	ch := make(chan *TxEvent, e.bufferSize)
+
+	e.Lock()
+	defer e.Unlock()
+
+	if _, ok := e.subscribers[key]; !ok {
+		e.subscribers[key] = make(map[chan *TxEvent]struct{})
+	}
+	e.subscribers[key][ch] = struct{}{}
+
+	return ch, func() {
+		e.Lock()
+		defer e.Unlock()
+
+		delete(e.subscribers[key], ch)
+		if len(e.subscribers[key]) == 0 {
+			delete(e.subscribers, key)
+		}
+	}
+}
+
+// publish does not wait for the subscribers: the event is lost for the subscriber with the full buffer.
+func (e *txEvents) publish(event *TxEvent) (lost int) {
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now().UTC()
+	}
+
+	e.Lock()
+	defer e.Unlock()
+
+	for _, key := range []string{event.TxHash, event.TxTreeRoot} {
+		if key == "" {
+			continue
+		}
+
+		for ch := range e.subscribers[strings.ToLower(key)] {
+			select {
+			case ch <- event:
+			default:
+				lost++
+			}
+		}
+	}
+
+	return lost
+}
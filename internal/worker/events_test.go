@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxEvents(t *testing.T) {
+	t.Parallel()
+
+	const (
+		bufferSize = 1
+		txHash     = "0xAB"
+		txTreeRoot = "0xCD"
+	)
+
+	e := newTxEvents(bufferSize)
+
+	byTxHash, unsubscribeTxHash := e.subscribe(txHash)
+	byTxTreeRoot, unsubscribeTxTreeRoot := e.subscribe(txTreeRoot)
+	defer unsubscribeTxTreeRoot()
+
+	lost := e.publish(&TxEvent{Type: TxEventSigned, TxHash: "0xab", TxTreeRoot: "0xcd"})
+	assert.Equal(t, 0, lost)
+
+	ev := <-byTxHash
+	assert.Equal(t, TxEventSigned, ev.Type)
+	assert.False(t, ev.CreatedAt.IsZero())
+	ev = <-byTxTreeRoot
+	assert.Equal(t, TxEventSigned, ev.Type)
+
+	lost = e.publish(&TxEvent{Type: TxEventPosted, TxTreeRoot: txTreeRoot})
+	assert.Equal(t, 0, lost)
+	lost = e.publish(&TxEvent{Type: TxEventDropped, TxTreeRoot: txTreeRoot})
+	assert.Equal(t, 1, lost)
+	ev = <-byTxTreeRoot
+	assert.Equal(t, TxEventPosted, ev.Type)
+
+	unsubscribeTxHash()
+	lost = e.publish(&TxEvent{Type: TxEventDropped, TxHash: txHash})
+	assert.Equal(t, 0, lost)
+	assert.Len(t, e.subscribers, 1)
+}
@@ -6,7 +6,6 @@ import (
 	intMaxTree "intmax2-node/internal/tree"
 	intMaxTypes "intmax2-node/internal/types"
 	"os"
-	"time"
 )
 
 type ComputeMerkleProof struct {
@@ -20,11 +19,19 @@ type CurrentRootCountAndSiblings struct {
 	Siblings         []*intMaxTree.PoseidonHashOut `json:"siblings"`
 }
 
+// ReceiverWorkerFee describes the verified transfer of the fee to the block builder.
+type ReceiverWorkerFee struct {
+	TokenIndex   uint32
+	Amount       string
+	TransferHash string
+}
+
 type ReceiverWorker struct {
 	Sender        string
 	Nonce         uint64
 	TxHash        *intMaxTypes.Tx
 	TransfersHash string
+	Fee           *ReceiverWorkerFee
 }
 
 type SenderTxs map[string]*ReceiverWorker
@@ -55,20 +62,28 @@ type TxTree struct {
 	Signature string `json:"signature"`
 }
 
+// Load describes the transactions waiting in the worker.
+type Load struct {
+	// PendingTxs is the number of the transactions waiting for the tx tree.
+	PendingTxs int
+	// SignatureBacklog is the number of the transactions of the tx trees waiting for the signature of the sender.
+	SignatureBacklog int
+}
+
 type Worker interface {
 	Init() (err error)
-	Start(
-		ctx context.Context,
-		tickerCurrentFile, tickerSignaturesAvailableFiles *time.Ticker,
-	) error
+	Start(ctx context.Context) error
 	Receiver(input *ReceiverWorker) error
 	AvailableFiles() (list []*os.File, err error)
 	TrHash(trHash string) (*TransactionHashesWithSenderAndFile, error)
+	NextNonce(sender string) (nonce uint64, err error)
 	TxTreeByAvailableFile(sf *TransactionHashesWithSenderAndFile) (txTreeRoot *TxTree, err error)
 	SignTxTreeByAvailableFile(
 		signature string,
 		sf *TransactionHashesWithSenderAndFile,
 		leafIndex uint64,
 	) error
+	Subscribe(key string) (events <-chan *TxEvent, unsubscribe func())
+	Load() *Load
 	// ExistsTxTreeRoot(txTreeRoot string) error
 }
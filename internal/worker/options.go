@@ -0,0 +1,18 @@
+package worker
+
+// Option configures the worker.
+type Option func(w *worker)
+
+// WithClock sets the clock of the lifetime of the files and of the timeouts of the signature collection.
+func WithClock(clock Clock) Option {
+	return func(w *worker) {
+		w.clock = clock
+	}
+}
+
+// WithFS sets the filesystem of the worker directory.
+func WithFS(fsys FS) Option {
+	return func(w *worker) {
+		w.fs = fsys
+	}
+}
@@ -0,0 +1,412 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	errorsDB "intmax2-node/pkg/sql_db/errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	bolt "go.etcd.io/bbolt"
+)
+
+const workerIDFileName = "worker_id"
+
+func newFileInfo(kv *bolt.DB) *fileInfo {
+	ctxFileInfo, cancelFileInfo := context.WithCancel(context.Background())
+
+	return &fileInfo{
+		kvInfo: kvInfo{
+			Ctx:       ctxFileInfo,
+			CtxCancel: cancelFileInfo,
+			KvDB:      kv,
+			Receiver:  make(chan func() error, int1024Key),
+		},
+		UsersCounter: make(map[string]string),
+		Hashes:       make(map[string]*leafsOfHash),
+	}
+}
+
+// receiverLoop stores the received transactions into the file until the file is delivered.
+// The kv store stays open to keep the signatures until the file is removed.
+func (w *worker) receiverLoop(fi *fileInfo) {
+	for {
+		select {
+		case <-fi.Ctx.Done():
+			w.files.Lock()
+			fi.Delivered = true
+			w.files.Unlock()
+			return
+		case fn := <-fi.Receiver:
+			errTx := fn()
+			if errTx != nil {
+				w.log.Errorf("%+v", errTx)
+			}
+		}
+	}
+}
+
+// workerID returns the ID stored in the worker path, generating it on the first start,
+// so that the worker finds its files again after a restart.
+func workerID(fsys FS, path string) (string, error) {
+	const F0600 os.FileMode = 0600
+
+	name := filepath.Join(path, workerIDFileName)
+	b, err := fsys.ReadFile(name)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", errors.Join(ErrReadFileFail, err)
+	}
+
+	id := strings.TrimSpace(string(b))
+	if id != "" {
+		return id, nil
+	}
+
+	err = fsys.MkdirAll(path, fs.ModePerm)
+	if err != nil {
+		return "", errors.Join(ErrMkdirFail, err)
+	}
+
+	id = uuid.New().String()
+	err = fsys.WriteFile(name, []byte(id), F0600)
+	if err != nil {
+		return "", errors.Join(ErrWriteFileFail, err)
+	}
+
+	return id, nil
+}
+
+// restoreFiles reopens the files left in the worker directory by the previous run.
+// The restored files no longer accept transactions: their tx trees are rebuilt
+// and the signature collection is resumed.
+func (w *worker) restoreFiles() error {
+	entries, err := w.fs.ReadDir(w.files.CurrentDir)
+	if err != nil {
+		return errors.Join(ErrReadDirFail, err)
+	}
+
+	for key := range entries {
+		if entries[key].IsDir() {
+			continue
+		}
+
+		err = w.restoreFile(filepath.Join(w.files.CurrentDir, entries[key].Name()))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *worker) restoreFile(name string) error {
+	const F0600 os.FileMode = 0600
+
+	file, err := w.fs.OpenFile(name, os.O_RDWR, F0600)
+	if err != nil {
+		return errors.Join(ErrOpenFileFail, err)
+	}
+
+	var kv *bolt.DB
+	kv, err = w.kvStore(name)
+	if err != nil {
+		_ = file.Close()
+		return errors.Join(ErrKVStoreFail, err)
+	}
+
+	fi := newFileInfo(kv)
+
+	var (
+		txs             []*ReceiverWorker
+		proposalBlockID string
+	)
+	err = kv.View(func(tx *bolt.Tx) error {
+		if mb := tx.Bucket([]byte(bucketMeta)); mb != nil {
+			if v := mb.Get([]byte(timestampKey)); v != nil {
+				var tm time.Time
+				if err = tm.UnmarshalBinary(v); err != nil {
+					return errors.Join(ErrUnmarshalFail, err)
+				}
+				fi.Timestamp = &tm
+			}
+			proposalBlockID = string(mb.Get([]byte(postedBlockKey)))
+		}
+
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+
+		return b.ForEach(func(_, v []byte) error {
+			var info SenderInfo
+			if err = json.Unmarshal(v, &info); err != nil {
+				return errors.Join(ErrUnmarshalFail, err)
+			}
+
+			for key := range info.TxsList {
+				txs = append(txs, info.TxsList[key])
+			}
+
+			return nil
+		})
+	})
+	if err != nil {
+		_ = kv.Close()
+		_ = file.Close()
+		return err
+	}
+
+	var isPosted bool
+	isPosted, err = w.isBlockPosted(proposalBlockID)
+	if err != nil {
+		_ = kv.Close()
+		_ = file.Close()
+		return err
+	}
+
+	// The block of the file was committed before the file was removed by the previous run.
+	if len(txs) == 0 || isPosted {
+		_ = kv.Close()
+		_ = file.Close()
+		_ = w.fs.Remove(name)
+		return nil
+	}
+
+	// The restored files are ordered by the time of their creation in the previous run.
+	st, err := w.fs.Stat(name)
+	if err != nil {
+		_ = kv.Close()
+		_ = file.Close()
+		return errors.Join(ErrStatFileFail, err)
+	}
+	fi.CreatedAt = st.ModTime().UTC()
+
+	if fi.Timestamp == nil {
+		// The file was the current file of the previous run.
+		tm := w.clock.Now().UTC()
+		fi.Timestamp = &tm
+		err = storeTimestamp(kv, tm)
+		if err != nil {
+			_ = kv.Close()
+			_ = file.Close()
+			return errors.Join(ErrStoreTimestampFail, err)
+		}
+	}
+
+	w.trHashes.Lock()
+	defer w.trHashes.Unlock()
+
+	w.files.Lock()
+	defer w.files.Unlock()
+
+	for key := range txs {
+		txHash := txs[key].TxHash.Hash().String()
+		fi.UsersCounter[txs[key].Sender] = txs[key].Sender
+		fi.Hashes[txHash] = nil
+		w.trHashes.Hashes[txHash] = &TransactionHashesWithSenderAndFile{
+			Sender: txs[key].Sender,
+			TxHash: txHash,
+			Nonce:  txs[key].Nonce,
+			File:   file,
+		}
+	}
+	w.files.FilesList[file] = fi
+
+	go w.receiverLoop(fi)
+
+	w.log.Infof("Restored %d transactions from %s\n", len(txs), name)
+
+	return nil
+}
+
+// isBlockPosted reports whether the block marked as posted in the file was committed.
+// The mark without the block is left by the commit failed after the mark was written.
+func (w *worker) isBlockPosted(proposalBlockID string) (bool, error) {
+	if proposalBlockID == "" {
+		return false, nil
+	}
+
+	_, err := w.dbApp.Block(proposalBlockID)
+	if errors.Is(err, errorsDB.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Join(ErrPostedBlockFail, err)
+	}
+
+	return true, nil
+}
+
+// removeFile forgets the file and removes it from the worker directory.
+// The caller must hold the lock of the files list.
+func (w *worker) removeFile(f *os.File) {
+	fi, ok := w.files.FilesList[f]
+	if !ok {
+		return
+	}
+
+	delete(w.files.FilesList, f)
+	fi.CtxCancel()
+	w.files.Cleaner <- func() {
+		_ = fi.KvDB.Close()
+		_ = f.Close()
+		_ = w.fs.Remove(f.Name())
+	}
+}
+
+// closeFiles releases the files of the worker on shutdown.
+// The files are kept in the worker directory to be restored by the next Init.
+func (w *worker) closeFiles() {
+	for done := false; !done; {
+		select {
+		case fn := <-w.files.Cleaner:
+			fn()
+		default:
+			done = true
+		}
+	}
+
+	w.files.Lock()
+	defer w.files.Unlock()
+
+	for key := range w.files.FilesList {
+		w.files.FilesList[key].CtxCancel()
+		_ = w.files.FilesList[key].KvDB.Close()
+		_ = key.Close()
+	}
+}
+
+func storeTimestamp(kv *bolt.DB, tm time.Time) error {
+	v, err := tm.MarshalBinary()
+	if err != nil {
+		return errors.Join(ErrMarshalFail, err)
+	}
+
+	return kv.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucketMeta))
+		if err != nil {
+			return errors.Join(ErrCreateBucketKVStoreFail, err)
+		}
+
+		err = b.Put([]byte(timestampKey), v)
+		if err != nil {
+			return errors.Join(ErrPutBucketKVStoreFail, err)
+		}
+
+		return nil
+	})
+}
+
+func storePostedBlock(kv *bolt.DB, proposalBlockID string) error {
+	return kv.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucketMeta))
+		if err != nil {
+			return errors.Join(ErrCreateBucketKVStoreFail, err)
+		}
+
+		err = b.Put([]byte(postedBlockKey), []byte(proposalBlockID))
+		if err != nil {
+			return errors.Join(ErrPutBucketKVStoreFail, err)
+		}
+
+		return nil
+	})
+}
+
+func storeLeafs(kv *bolt.DB, hashes map[string]*leafsOfHash) error {
+	return kv.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucketLeafs))
+		if err != nil {
+			return errors.Join(ErrCreateBucketKVStoreFail, err)
+		}
+
+		for key := range hashes {
+			if hashes[key] == nil {
+				continue
+			}
+
+			var v []byte
+			v, err = json.Marshal(hashes[key])
+			if err != nil {
+				return errors.Join(ErrMarshalFail, err)
+			}
+
+			err = b.Put([]byte(key), v)
+			if err != nil {
+				return errors.Join(ErrPutBucketKVStoreFail, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// leafOfHash returns the leaf stored for the transaction, or nil if the leaf was not assigned yet.
+func leafOfHash(b *bolt.Bucket, txHash string) (*leafsOfHash, error) {
+	if b == nil {
+		return nil, nil
+	}
+
+	v := b.Get([]byte(txHash))
+	if v == nil {
+		return nil, nil
+	}
+
+	var lfh leafsOfHash
+	err := json.Unmarshal(v, &lfh)
+	if err != nil {
+		return nil, errors.Join(ErrUnmarshalFail, err)
+	}
+
+	return &lfh, nil
+}
+
+func storeSignature(kv *bolt.DB, s *signaturesByLeafIndex) error {
+	v, err := json.Marshal(s)
+	if err != nil {
+		return errors.Join(ErrMarshalFail, err)
+	}
+
+	return kv.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucketSignatures))
+		if err != nil {
+			return errors.Join(ErrCreateBucketKVStoreFail, err)
+		}
+
+		err = b.Put([]byte(s.TxHash), v)
+		if err != nil {
+			return errors.Join(ErrPutBucketKVStoreFail, err)
+		}
+
+		return nil
+	})
+}
+
+func storedSignatures(kv *bolt.DB) (signatures []*signaturesByLeafIndex, err error) {
+	err = kv.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucketSignatures))
+		if b == nil {
+			return nil
+		}
+
+		return b.ForEach(func(_, v []byte) error {
+			var s signaturesByLeafIndex
+			if err = json.Unmarshal(v, &s); err != nil {
+				return errors.Join(ErrUnmarshalFail, err)
+			}
+			signatures = append(signatures, &s)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return signatures, nil
+}
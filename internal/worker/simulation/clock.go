@@ -0,0 +1,93 @@
+package simulation
+
+import (
+	"intmax2-node/internal/worker"
+	"sync"
+	"time"
+)
+
+// Clock is the manual clock of the simulation: the time moves only with Advance
+// and the tickers tick only with Tick.
+type Clock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers map[*ticker]time.Duration
+}
+
+// NewClock creates the clock stopped at the start time.
+func NewClock(start time.Time) *Clock {
+	return &Clock{
+		now:     start,
+		tickers: make(map[*ticker]time.Duration),
+	}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// NewTicker creates the ticker of the period d, it ticks only with Tick of the same period.
+func (c *Clock) NewTicker(d time.Duration) worker.Ticker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := ticker{
+		clock: c,
+		c:     make(chan time.Time),
+	}
+	c.tickers[&t] = d
+
+	return &t
+}
+
+// Advance moves the clock forward by d.
+func (c *Clock) Advance(d time.Duration) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	return c.now
+}
+
+// Tick delivers the current time to the tickers of the period d one by one.
+// The ticks are not buffered, so Tick returns after the tickers have received them
+// or after done is closed.
+func (c *Clock) Tick(d time.Duration, done <-chan struct{}) {
+	c.mu.Lock()
+	now := c.now
+	var list []*ticker
+	for t, period := range c.tickers {
+		if period == d {
+			list = append(list, t)
+		}
+	}
+	c.mu.Unlock()
+
+	for key := range list {
+		select {
+		case list[key].c <- now:
+		case <-done:
+			return
+		}
+	}
+}
+
+type ticker struct {
+	clock *Clock
+	c     chan time.Time
+}
+
+func (t *ticker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *ticker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	delete(t.clock.tickers, t)
+}
@@ -0,0 +1,415 @@
+package simulation
+
+import (
+	"context"
+	"encoding/json"
+	intMaxTypes "intmax2-node/internal/types"
+	"intmax2-node/internal/worker"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	errorsDB "intmax2-node/pkg/sql_db/errors"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/holiman/uint256"
+)
+
+// DB keeps the blocks posted by the worker in memory.
+// The tables not used by the worker are left empty.
+type DB struct {
+	mu             sync.Mutex
+	clock          worker.Clock
+	blocks         []*mDBApp.Block
+	blockSenders   map[string][]intMaxTypes.ColumnSender
+	signatures     map[string]*mDBApp.Signature
+	txMerkleProofs []*mDBApp.TxMerkleProofs
+	blockFees      []*mDBApp.BlockFee
+	senders        map[string]*mDBApp.Sender
+	accounts       map[string]*mDBApp.Account
+	nextAccountID  uint64
+}
+
+var _ worker.SQLDriverApp = (*DB)(nil)
+
+// NewDB creates the empty DB.
+func NewDB(clock worker.Clock) *DB {
+	const firstAccountID = 2 // the account ID 1 is reserved for the dummy sender
+
+	return &DB{
+		clock:         clock,
+		blockSenders:  make(map[string][]intMaxTypes.ColumnSender),
+		signatures:    make(map[string]*mDBApp.Signature),
+		senders:       make(map[string]*mDBApp.Sender),
+		accounts:      make(map[string]*mDBApp.Account),
+		nextAccountID: firstAccountID,
+	}
+}
+
+// Register creates the sender with the account, so that its transactions go to the tx tree of the accounts.
+func (db *DB) Register(address, publicKey string) error {
+	sender, err := db.CreateSenders(address, publicKey)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.CreateAccount(sender.ID)
+
+	return err
+}
+
+// Blocks returns the blocks in the order of the creation.
+func (db *DB) Blocks() []*mDBApp.Block {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return append([]*mDBApp.Block(nil), db.blocks...)
+}
+
+// BlockSenders returns the senders of the block.
+func (db *DB) BlockSenders(proposalBlockID string) []intMaxTypes.ColumnSender {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return db.blockSenders[proposalBlockID]
+}
+
+// TxMerkleProofs returns the merkle proofs of the transactions of the posted blocks.
+func (db *DB) TxMerkleProofs() []*mDBApp.TxMerkleProofs {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return append([]*mDBApp.TxMerkleProofs(nil), db.txMerkleProofs...)
+}
+
+func (db *DB) Exec(
+	_ context.Context,
+	input interface{},
+	executor func(d interface{}, input interface{}) error,
+) error {
+	return executor(db, input)
+}
+
+func (db *DB) CreateBlock(
+	builderPublicKey, txRoot, aggregatedSignature, aggregatedPublicKey string,
+	senders []intMaxTypes.ColumnSender,
+	senderType uint,
+	options []byte,
+) (*mDBApp.Block, error) {
+	bSenders, err := json.Marshal(senders)
+	if err != nil {
+		return nil, err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	block := mDBApp.Block{
+		ProposalBlockID:     uuid.New().String(),
+		BuilderPublicKey:    builderPublicKey,
+		TxRoot:              txRoot,
+		AggregatedSignature: aggregatedSignature,
+		AggregatedPublicKey: aggregatedPublicKey,
+		Senders:             bSenders,
+		CreatedAt:           db.clock.Now().UTC(),
+		SenderType:          int64(senderType),
+		Options:             options,
+	}
+	db.blocks = append(db.blocks, &block)
+	db.blockSenders[block.ProposalBlockID] = senders
+
+	return &block, nil
+}
+
+func (db *DB) Block(proposalBlockID string) (*mDBApp.Block, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for key := range db.blocks {
+		if db.blocks[key].ProposalBlockID == proposalBlockID {
+			return db.blocks[key], nil
+		}
+	}
+
+	return nil, errorsDB.ErrNotFound
+}
+
+func (db *DB) CreateSignature(signature, proposalBlockID string) (*mDBApp.Signature, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	sign := mDBApp.Signature{
+		SignatureID:     uuid.New().String(),
+		Signature:       signature,
+		ProposalBlockID: proposalBlockID,
+		CreatedAt:       db.clock.Now().UTC(),
+	}
+	db.signatures[sign.SignatureID] = &sign
+
+	return &sign, nil
+}
+
+func (db *DB) SignatureByID(signatureID string) (*mDBApp.Signature, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	sign, ok := db.signatures[signatureID]
+	if !ok {
+		return nil, errorsDB.ErrNotFound
+	}
+
+	return sign, nil
+}
+
+func (db *DB) CreateTxMerkleProofs(
+	senderPublicKey, txHash, signatureID string,
+	txTreeIndex *uint256.Int,
+	txMerkleProof json.RawMessage,
+	txTreeRoot string,
+	proposalBlockID string,
+) (*mDBApp.TxMerkleProofs, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	proof := mDBApp.TxMerkleProofs{
+		ID:              uuid.New().String(),
+		SenderPublicKey: senderPublicKey,
+		SignatureID:     signatureID,
+		TxHash:          txHash,
+		TxTreeIndex:     txTreeIndex,
+		TxMerkleProof:   txMerkleProof,
+		TxTreeRoot:      txTreeRoot,
+		ProposalBlockID: proposalBlockID,
+		CreatedAt:       db.clock.Now().UTC(),
+	}
+	db.txMerkleProofs = append(db.txMerkleProofs, &proof)
+
+	return &proof, nil
+}
+
+func (db *DB) TxMerkleProofsByID(id string) (*mDBApp.TxMerkleProofs, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for key := range db.txMerkleProofs {
+		if db.txMerkleProofs[key].ID == id {
+			return db.txMerkleProofs[key], nil
+		}
+	}
+
+	return nil, errorsDB.ErrNotFound
+}
+
+func (db *DB) CreateBlockFee(fee *mDBApp.BlockFee) (*mDBApp.BlockFee, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	bf := *fee
+	bf.ID = uuid.New().String()
+	bf.CreatedAt = db.clock.Now().UTC()
+	db.blockFees = append(db.blockFees, &bf)
+
+	return &bf, nil
+}
+
+func (db *DB) UpsertEventBlockNumber(eventName string, blockNumber uint64) (*mDBApp.EventBlockNumber, error) {
+	return &mDBApp.EventBlockNumber{
+		EventName:                eventName,
+		LastProcessedBlockNumber: blockNumber,
+	}, nil
+}
+
+func (db *DB) UpsertEventBlockNumberAndHash(
+	eventName string,
+	blockNumber uint64,
+	blockHash string,
+) (*mDBApp.EventBlockNumber, error) {
+	return &mDBApp.EventBlockNumber{
+		EventName:                eventName,
+		LastProcessedBlockNumber: blockNumber,
+		LastProcessedBlockHash:   blockHash,
+	}, nil
+}
+
+func (db *DB) EventBlockNumberByEventName(_ string) (*mDBApp.EventBlockNumber, error) {
+	return nil, errorsDB.ErrNotFound
+}
+
+func (db *DB) EventBlockNumbersByEventNames(_ []string) ([]*mDBApp.EventBlockNumber, error) {
+	return nil, nil
+}
+
+func (db *DB) UpsertEventBlockHash(_ string, _ uint64, _ string) error {
+	return nil
+}
+
+func (db *DB) EventBlockHashesByEventName(_ string) ([]*mDBApp.EventBlockHash, error) {
+	return nil, nil
+}
+
+func (db *DB) DelEventBlockHashesAfterBlockNumber(_ string, _ uint64) error {
+	return nil
+}
+
+func (db *DB) DelEventBlockHashesBeforeBlockNumber(_ string, _ uint64) error {
+	return nil
+}
+
+func (db *DB) CreateCtrlEventBlockNumbersJobs(_ string) error {
+	return nil
+}
+
+func (db *DB) CtrlEventBlockNumbersJobs(_ string) (*mDBApp.CtrlEventBlockNumbersJobs, error) {
+	return nil, errorsDB.ErrNotFound
+}
+
+func (db *DB) UpsertEventBlockNumbersErrors(
+	_ string,
+	_ *uint256.Int,
+	_ []byte,
+	_ error,
+) error {
+	return nil
+}
+
+func (db *DB) EventBlockNumbersErrors(
+	_ string,
+	_ *uint256.Int,
+) (*mDBApp.EventBlockNumbersErrors, error) {
+	return nil, errorsDB.ErrNotFound
+}
+
+func (db *DB) CreateSenders(address, publicKey string) (*mDBApp.Sender, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if sender, ok := db.senders[address]; ok {
+		return sender, nil
+	}
+
+	sender := mDBApp.Sender{
+		ID:        uuid.New().String(),
+		Address:   address,
+		PublicKey: publicKey,
+		CreatedAt: db.clock.Now().UTC(),
+	}
+	db.senders[address] = &sender
+
+	return &sender, nil
+}
+
+func (db *DB) SenderByID(id string) (*mDBApp.Sender, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for key := range db.senders {
+		if db.senders[key].ID == id {
+			return db.senders[key], nil
+		}
+	}
+
+	return nil, errorsDB.ErrNotFound
+}
+
+func (db *DB) SenderByAddress(address string) (*mDBApp.Sender, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	sender, ok := db.senders[address]
+	if !ok {
+		return nil, errorsDB.ErrNotFound
+	}
+
+	return sender, nil
+}
+
+func (db *DB) SenderByPublicKey(publicKey string) (*mDBApp.Sender, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for key := range db.senders {
+		if db.senders[key].PublicKey == publicKey {
+			return db.senders[key], nil
+		}
+	}
+
+	return nil, errorsDB.ErrNotFound
+}
+
+func (db *DB) UpdateSenderNonce(id string, nonce uint64) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for key := range db.senders {
+		if db.senders[key].ID == id {
+			db.senders[key].Nonce = nonce
+			return nil
+		}
+	}
+
+	return errorsDB.ErrNotFound
+}
+
+func (db *DB) CreateAccount(senderID string) (*mDBApp.Account, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if acc, ok := db.accounts[senderID]; ok {
+		return acc, nil
+	}
+
+	acc := mDBApp.Account{
+		ID:        uuid.New().String(),
+		AccountID: uint256.NewInt(db.nextAccountID),
+		SenderID:  senderID,
+		CreatedAt: db.clock.Now().UTC(),
+	}
+	db.accounts[senderID] = &acc
+	db.nextAccountID++
+
+	return &acc, nil
+}
+
+func (db *DB) AccountBySenderID(senderID string) (*mDBApp.Account, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	acc, ok := db.accounts[senderID]
+	if !ok {
+		return nil, errorsDB.ErrNotFound
+	}
+
+	return acc, nil
+}
+
+func (db *DB) AccountByAccountID(accountID *uint256.Int) (*mDBApp.Account, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for key := range db.accounts {
+		if db.accounts[key].AccountID.Eq(accountID) {
+			return db.accounts[key], nil
+		}
+	}
+
+	return nil, errorsDB.ErrNotFound
+}
+
+func (db *DB) ResetSequenceByAccounts() error {
+	return nil
+}
+
+func (db *DB) UpdateBlockNumberOfNewAccounts(_ uint64) error {
+	return nil
+}
+
+func (db *DB) DelAccountsAfterBlockNumber(_ uint64) error {
+	return nil
+}
+
+func (db *DB) DelAllAccounts() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.accounts = make(map[string]*mDBApp.Account)
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package simulation
+
+import "errors"
+
+// ErrMaxCounterOfUsersInvalid error: the max counter of users must be positive and less than the number of senders of the block.
+var ErrMaxCounterOfUsersInvalid = errors.New(
+	"the max counter of users must be positive and less than the number of senders of the block",
+)
+
+// ErrWorkerInitFail error: failed to init the worker.
+var ErrWorkerInitFail = errors.New("failed to init the worker")
+
+// ErrWorkerStartFail error: the worker is stopped with error.
+var ErrWorkerStartFail = errors.New("the worker is stopped with error")
+
+// ErrNewPrivateKeyFail error: failed to create new private key.
+var ErrNewPrivateKeyFail = errors.New("failed to create new private key")
+
+// ErrNewTxFail error: failed to create new tx.
+var ErrNewTxFail = errors.New("failed to create new tx")
+
+// ErrNewTxTreeFail error: failed to create new tx tree.
+var ErrNewTxTreeFail = errors.New("failed to create new tx tree")
+
+// ErrReceiverFail error: failed to receive the transaction by the worker.
+var ErrReceiverFail = errors.New("failed to receive the transaction by the worker")
+
+// ErrTrHashFail error: failed to get the transaction hash from the worker.
+var ErrTrHashFail = errors.New("failed to get the transaction hash from the worker")
+
+// ErrTxTreeByAvailableFileFail error: failed to get the tx tree by available file.
+var ErrTxTreeByAvailableFileFail = errors.New("failed to get the tx tree by available file")
+
+// ErrSignTxTreeByAvailableFileFail error: failed to sign the tx tree by available file.
+var ErrSignTxTreeByAvailableFileFail = errors.New("failed to sign the tx tree by available file")
+
+// ErrSignFail error: failed to sign the tx tree root.
+var ErrSignFail = errors.New("failed to sign the tx tree root")
+
+// ErrWaitTimeout error: the worker has not finished in the wait timeout.
+var ErrWaitTimeout = errors.New("the worker has not finished in the wait timeout")
+
+// ErrInvariantViolated error: the invariants of the worker are violated.
+var ErrInvariantViolated = errors.New("the invariants of the worker are violated")
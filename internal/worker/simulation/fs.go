@@ -0,0 +1,55 @@
+package simulation
+
+import (
+	"intmax2-node/internal/worker"
+	"os"
+	"sync/atomic"
+)
+
+// FS keeps the worker directory under the root of the simulation
+// and counts the files of the worker.
+type FS struct {
+	worker.FS
+	root    string
+	created int64
+	removed int64
+}
+
+// NewFS creates FS with the temporary directories under the root.
+func NewFS(root string) *FS {
+	return &FS{
+		FS:   worker.NewOSFS(),
+		root: root,
+	}
+}
+
+func (fsys *FS) MkdirTemp(dir, pattern string) (string, error) {
+	if dir == "" {
+		dir = fsys.root
+	}
+
+	return fsys.FS.MkdirTemp(dir, pattern)
+}
+
+func (fsys *FS) CreateTemp(dir, pattern string) (*os.File, error) {
+	f, err := fsys.FS.CreateTemp(dir, pattern)
+	if err == nil {
+		atomic.AddInt64(&fsys.created, 1)
+	}
+
+	return f, err
+}
+
+func (fsys *FS) Remove(name string) error {
+	err := fsys.FS.Remove(name)
+	if err == nil {
+		atomic.AddInt64(&fsys.removed, 1)
+	}
+
+	return err
+}
+
+// Files returns the number of the files created and not yet removed by the worker.
+func (fsys *FS) Files() int64 {
+	return atomic.LoadInt64(&fsys.created) - atomic.LoadInt64(&fsys.removed)
+}
@@ -0,0 +1,750 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"intmax2-node/configs"
+	intMaxAcc "intmax2-node/internal/accounts"
+	"intmax2-node/internal/finite_field"
+	"intmax2-node/internal/logger"
+	intMaxTree "intmax2-node/internal/tree"
+	intMaxTypes "intmax2-node/internal/types"
+	"intmax2-node/internal/worker"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iden3/go-iden3-crypto/ffg"
+	"github.com/status-im/keycard-go/hexutils"
+)
+
+const (
+	// builderPrivateKeyHex is the key of the block builder signing the simulated blocks.
+	builderPrivateKeyHex = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	eventsBufferSize     = 16
+	pollInterval         = time.Millisecond
+	// checkCurrentFilePeriod is the period of the ticker of the current file.
+	checkCurrentFilePeriod = time.Second
+)
+
+// Config describes the load of the simulation.
+type Config struct {
+	// Dir is the directory of the worker files.
+	Dir string
+	// Seed makes the senders and the order of their transactions reproducible.
+	Seed int64
+	// Senders is the number of the synthetic senders, every second sender has the account.
+	Senders int
+	// TxsPerSender is the number of the transactions sent by every sender, one per file.
+	TxsPerSender int
+	// MaxCounterOfUsers is the number of the senders closing the file before its lifetime expires.
+	// Every second file is closed by the number of the senders, the other files are closed by the lifetime.
+	MaxCounterOfUsers int
+	// NotSignedEvery makes every n-th transaction miss the signature timeout.
+	NotSignedEvery int
+	// RetryEvery makes every n-th transaction be sent twice.
+	RetryEvery          int
+	CurrentFileLifetime time.Duration
+	SignaturesTimeout   time.Duration
+	// WaitTimeout limits the real time waiting for the goroutines of the worker.
+	WaitTimeout time.Duration
+}
+
+// Report is the result of the simulation.
+type Report struct {
+	Txs        int
+	Files      int
+	Blocks     int
+	Signed     int
+	Dropped    int
+	Violations []string
+}
+
+// Err returns the violations of the invariants as the error.
+func (r *Report) Err() error {
+	if len(r.Violations) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w:\n%s", ErrInvariantViolated, strings.Join(r.Violations, "\n"))
+}
+
+type sender struct {
+	key        *intMaxAcc.PrivateKey
+	address    string
+	registered bool
+	nonce      uint64
+}
+
+type tx struct {
+	sender      *sender
+	rw          *worker.ReceiverWorker
+	leaf        *intMaxTypes.Tx
+	hash        string
+	signs       bool
+	events      <-chan *worker.TxEvent
+	unsubscribe func()
+	sf          *worker.TransactionHashesWithSenderAndFile
+	tree        *worker.TxTree
+	root        *intMaxTree.PoseidonHashOut
+	index       uint64
+	posted      bool
+	terminal    *worker.TxEvent
+}
+
+type simulation struct {
+	cfg     *Config
+	clock   *Clock
+	fs      *FS
+	db      *DB
+	w       worker.Worker
+	rng     *rand.Rand
+	report  Report
+	roots   map[string]bool
+	hashes  []string
+	done    chan struct{}
+	errDone error
+}
+
+// Run drives the synthetic senders through the worker: the transactions are received,
+// the tx trees are proposed and signed, the signature collection times out and the blocks are posted.
+// The time of the worker is simulated, so the timeouts are reached without waiting.
+// The error is returned if the simulation cannot be completed; the violations of the invariants are reported.
+func Run(ctx context.Context, log logger.Logger, cfg *Config) (*Report, error) {
+	if cfg.MaxCounterOfUsers <= 0 || cfg.MaxCounterOfUsers >= intMaxTypes.NumOfSenders {
+		return nil, ErrMaxCounterOfUsersInvalid
+	}
+
+	s := simulation{
+		cfg:   cfg,
+		clock: NewClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)),
+		fs:    NewFS(cfg.Dir),
+		rng:   rand.New(rand.NewSource(cfg.Seed)), // nolint:gosec
+		roots: make(map[string]bool),
+		done:  make(chan struct{}),
+	}
+	s.db = NewDB(s.clock)
+
+	wCfg := configs.Config{}
+	wCfg.Worker.MaxCounter = 1
+	wCfg.Worker.PathCleanInStart = true
+	wCfg.Worker.CurrentFileLifetime = cfg.CurrentFileLifetime
+	wCfg.Worker.TimeoutForCheckCurrentFile = checkCurrentFilePeriod
+	wCfg.Worker.TimeoutForSignaturesAvailableFiles = cfg.SignaturesTimeout
+	wCfg.Worker.MaxCounterOfUsers = cfg.MaxCounterOfUsers
+	wCfg.Worker.EventsBufferSize = eventsBufferSize
+	wCfg.Blockchain.BuilderPrivateKeyHex = builderPrivateKeyHex
+
+	s.w = worker.New(&wCfg, log, s.db, worker.WithClock(s.clock), worker.WithFS(s.fs))
+	err := s.w.Init()
+	if err != nil {
+		return nil, errors.Join(ErrWorkerInitFail, err)
+	}
+
+	senders, err := s.senders()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		defer close(s.done)
+		s.errDone = s.w.Start(ctx)
+	}()
+
+	err = s.run(senders)
+	cancel()
+	<-s.done
+	if err != nil {
+		return nil, err
+	}
+	if s.errDone != nil {
+		return nil, errors.Join(ErrWorkerStartFail, s.errDone)
+	}
+
+	s.checkBlocks()
+
+	return &s.report, nil
+}
+
+func (s *simulation) senders() (senders []*sender, err error) {
+	senders = make([]*sender, s.cfg.Senders)
+	for key := range senders {
+		var pk *intMaxAcc.PrivateKey
+		pk, err = intMaxAcc.NewPrivateKeyWithReCalcPubKeyIfPkNegates(
+			new(big.Int).SetUint64(s.rng.Uint64() | 1),
+		)
+		if err != nil {
+			return nil, errors.Join(ErrNewPrivateKeyFail, err)
+		}
+
+		senders[key] = &sender{
+			key:        pk,
+			address:    pk.ToAddress().String(),
+			registered: key%2 == 1,
+		}
+		if senders[key].registered {
+			err = s.db.Register(senders[key].address, pk.Public().String())
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return senders, nil
+}
+
+func (s *simulation) run(senders []*sender) error {
+	var queues [2][]*sender
+	for pass := 0; pass < s.cfg.TxsPerSender; pass++ {
+		for _, key := range s.rng.Perm(len(senders)) {
+			if senders[key].registered {
+				queues[1] = append(queues[1], senders[key])
+			} else {
+				queues[0] = append(queues[0], senders[key])
+			}
+		}
+	}
+
+	for round := 0; len(queues[0])+len(queues[1]) > 0; round++ {
+		size := s.cfg.MaxCounterOfUsers
+		byUsers := round%2 == 1 && len(queues[0])+len(queues[1]) > size
+		if byUsers {
+			size++
+		}
+
+		// The file has the transactions of the senders with the account, without the account or both.
+		batch := s.batch(&queues, s.rng.Intn(len(queues)+1), size)
+		if byUsers && len(batch) < size {
+			byUsers = false
+		}
+
+		err := s.round(batch, byUsers)
+		if err != nil {
+			return fmt.Errorf("round %d: %w", round, err)
+		}
+	}
+
+	// The transactions of the processed files are forgotten by the worker.
+	err := s.wait(func() (bool, error) {
+		s.tick(checkCurrentFilePeriod)
+		return s.fs.Files() == 1 && s.w.Load().PendingTxs == 0 && s.forgotten(), nil
+	})
+	if err != nil {
+		s.violation("the files of the processed transactions are not removed: %d files left", s.fs.Files())
+	}
+
+	for key := range s.hashes {
+		_, err = s.w.TrHash(s.hashes[key])
+		if !errors.Is(err, worker.ErrTransactionHashNotFound) {
+			s.violation("the tx %s is not forgotten after the block posting: %v", s.hashes[key], err)
+		}
+	}
+
+	return nil
+}
+
+func (s *simulation) forgotten() bool {
+	for key := range s.hashes {
+		if _, err := s.w.TrHash(s.hashes[key]); err == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// batch takes the senders of one file from the queues, a sender sends one transaction per file.
+// The mode selects the queue of the senders without the account, with the account or both of them.
+func (s *simulation) batch(queues *[2][]*sender, mode, size int) []*sender {
+	const modeBoth = 2
+
+	var batch []*sender
+	inBatch := make(map[*sender]bool)
+	for len(batch) < size {
+		q := mode
+		if mode == modeBoth {
+			q = len(batch) % len(queues)
+		}
+
+		snd := take(&queues[q], inBatch)
+		if snd == nil {
+			snd = take(&queues[1-q], inBatch)
+		}
+		if snd == nil {
+			break
+		}
+
+		inBatch[snd] = true
+		batch = append(batch, snd)
+	}
+
+	return batch
+}
+
+// take removes the first sender that is not in the batch from the queue.
+func take(queue *[]*sender, inBatch map[*sender]bool) *sender {
+	for key := range *queue {
+		snd := (*queue)[key]
+		if !inBatch[snd] {
+			*queue = append((*queue)[:key], (*queue)[key+1:]...)
+			return snd
+		}
+	}
+
+	return nil
+}
+
+func (s *simulation) round(batch []*sender, byUsers bool) error {
+	txs, err := s.receive(batch)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for key := range txs {
+			txs[key].unsubscribe()
+		}
+	}()
+
+	// The file stops receiving the transactions.
+	if !byUsers {
+		s.clock.Advance(s.cfg.CurrentFileLifetime)
+	}
+	s.tick(checkCurrentFilePeriod)
+	s.report.Files++
+
+	err = s.propose(txs)
+	if err != nil {
+		return err
+	}
+
+	err = s.sign(txs)
+	if err != nil {
+		return err
+	}
+
+	return s.timeout(txs)
+}
+
+func (s *simulation) receive(batch []*sender) (txs []*tx, err error) {
+	for key := range batch {
+		snd := batch[key]
+		snd.nonce++
+
+		var transfersHash intMaxTypes.PoseidonHashOut
+		transfersHash.Elements[0] = *new(ffg.Element).SetUint64(uint64(s.report.Txs))
+		transfersHash.Elements[1] = *new(ffg.Element).SetUint64(snd.nonce)
+		transfersHash.Elements[2] = *new(ffg.Element).SetUint64(uint64(s.cfg.Seed))
+
+		t := tx{
+			sender: snd,
+			rw: &worker.ReceiverWorker{
+				Sender:        snd.address,
+				Nonce:         snd.nonce,
+				TransfersHash: hexutil.Encode(transfersHash.Marshal()),
+			},
+			signs: s.cfg.NotSignedEvery <= 0 || (s.report.Txs+1)%s.cfg.NotSignedEvery != 0,
+		}
+		t.leaf, err = intMaxTypes.NewTx(&transfersHash, snd.nonce)
+		if err != nil {
+			return nil, errors.Join(ErrNewTxFail, err)
+		}
+		t.hash = t.leaf.Hash().String()
+		t.events, t.unsubscribe = s.w.Subscribe(t.hash)
+		txs = append(txs, &t)
+		s.hashes = append(s.hashes, t.hash)
+
+		err = s.w.Receiver(t.rw)
+		if err != nil {
+			return nil, errors.Join(ErrReceiverFail, err)
+		}
+		s.report.Txs++
+
+		if s.cfg.RetryEvery > 0 && s.report.Txs%s.cfg.RetryEvery == 0 {
+			err = s.w.Receiver(&worker.ReceiverWorker{
+				Sender:        t.rw.Sender,
+				Nonce:         t.rw.Nonce,
+				TransfersHash: t.rw.TransfersHash,
+			})
+			if !errors.Is(err, worker.ErrReceiverWorkerDuplicate) {
+				s.violation("the retry of the tx %s is not rejected as the duplicate: %v", t.hash, err)
+			}
+		}
+	}
+
+	return txs, nil
+}
+
+// propose waits for the tx trees of the file and checks them against the tx trees built from the sent transactions.
+func (s *simulation) propose(txs []*tx) error {
+	var (
+		publicKeys, accounts []*tx
+	)
+	for key := range txs {
+		if txs[key].sender.registered {
+			accounts = append(accounts, txs[key])
+		} else {
+			publicKeys = append(publicKeys, txs[key])
+		}
+	}
+
+	for _, group := range [][]*tx{publicKeys, accounts} {
+		root, err := expectedTxTree(group)
+		if err != nil {
+			return err
+		}
+
+		for key := range group {
+			group[key].root = root
+		}
+	}
+
+	for key := range txs {
+		t := txs[key]
+
+		err := s.wait(func() (ok bool, err error) {
+			t.sf, err = s.w.TrHash(t.hash)
+			if err != nil {
+				return false, errors.Join(ErrTrHashFail, err)
+			}
+
+			t.tree, err = s.w.TxTreeByAvailableFile(t.sf)
+			if errors.Is(err, worker.ErrTxTreeNotFound) {
+				s.tick(s.cfg.SignaturesTimeout)
+				return false, nil
+			}
+			if err != nil {
+				return false, errors.Join(ErrTxTreeByAvailableFileFail, err)
+			}
+
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if t.sf.File != txs[0].sf.File {
+			s.violation("the tx %s is not in the file of the other transactions of the same time", t.hash)
+		}
+
+		s.checkTxTree(t, t.tree)
+	}
+
+	return nil
+}
+
+func (s *simulation) checkTxTree(t *tx, tree *worker.TxTree) {
+	if tree.RootHash.String() != t.root.String() {
+		s.violation("the tx tree root %s of the tx %s is not %s", tree.RootHash.String(), t.hash, t.root.String())
+		return
+	}
+
+	proofRoot := intMaxTree.ComputeMerkleRootFromProof(t.leaf.Hash(), t.index, tree.Siblings)
+	if proofRoot.String() != t.root.String() {
+		s.violation("the merkle proof of the tx %s does not lead to the tx tree root %s", t.hash, t.root.String())
+	}
+
+	leafs := make(map[string]int)
+	for key := range tree.SenderPublicKeys {
+		leafs[tree.SenderPublicKeys[key].ToAddress().String()]++
+	}
+	for address, n := range leafs {
+		if n != 1 {
+			s.violation("the sender %s has %d leafs in the tx tree %s", address, n, t.root.String())
+		}
+	}
+	if leafs[t.sender.address] == 0 {
+		s.violation("the sender %s of the tx %s is not in the tx tree %s", t.sender.address, t.hash, t.root.String())
+	}
+
+	s.roots[t.root.String()] = true
+}
+
+func (s *simulation) sign(txs []*tx) error {
+	for key := range txs {
+		t := txs[key]
+		if !t.signs {
+			continue
+		}
+
+		signature, err := signTxTree(t.sender.key, t.tree.RootHash, t.tree.SenderPublicKeys)
+		if err != nil {
+			return err
+		}
+
+		err = s.w.SignTxTreeByAvailableFile(signature, t.sf, t.index)
+		if err != nil {
+			return errors.Join(ErrSignTxTreeByAvailableFileFail, err)
+		}
+	}
+
+	return nil
+}
+
+// timeout moves the time past the signature collection of the file and waits for the result of every transaction.
+func (s *simulation) timeout(txs []*tx) error {
+	s.clock.Advance(s.cfg.SignaturesTimeout + time.Second)
+
+	var postedPublicKeys, postedAccounts bool
+	for key := range txs {
+		if !txs[key].signs {
+			// The late signature is rejected.
+			err := s.w.SignTxTreeByAvailableFile("0x01", txs[key].sf, txs[key].index)
+			if !errors.Is(err, worker.ErrTxTreeSignatureCollectionComplete) {
+				s.violation("the late signature of the tx %s is not rejected: %v", txs[key].hash, err)
+			}
+			continue
+		}
+		if txs[key].sender.registered {
+			postedAccounts = true
+		} else {
+			postedPublicKeys = true
+		}
+	}
+	// Only one tx tree of the file is posted, the tx tree of the public keys goes first.
+	for key := range txs {
+		if txs[key].sender.registered {
+			txs[key].posted = postedAccounts && !postedPublicKeys
+		} else {
+			txs[key].posted = postedPublicKeys
+		}
+	}
+
+	left := len(txs)
+	err := s.wait(func() (bool, error) {
+		for key := range txs {
+			if txs[key].terminal != nil {
+				continue
+			}
+
+			txs[key].terminal = terminalEvent(txs[key].events)
+			if txs[key].terminal != nil {
+				left--
+			}
+		}
+		if left > 0 {
+			s.tick(s.cfg.SignaturesTimeout)
+		}
+
+		return left == 0, nil
+	})
+	if err != nil {
+		for key := range txs {
+			if txs[key].terminal == nil {
+				s.violation("the tx %s is lost: it is neither signed nor dropped", txs[key].hash)
+			}
+		}
+		return nil
+	}
+
+	for key := range txs {
+		s.checkTerminal(txs[key])
+	}
+
+	return nil
+}
+
+// terminalEvent returns the received signed or dropped event of the transaction.
+func terminalEvent(events <-chan *worker.TxEvent) *worker.TxEvent {
+	for {
+		select {
+		case event := <-events:
+			if event.Type == worker.TxEventSigned || event.Type == worker.TxEventDropped {
+				return event
+			}
+		default:
+			return nil
+		}
+	}
+}
+
+func (s *simulation) checkTerminal(t *tx) {
+	if t.terminal.TxTreeRoot != t.root.String() {
+		s.violation("the tx %s is finished in the tx tree %s instead of %s", t.hash, t.terminal.TxTreeRoot, t.root.String())
+	}
+
+	switch {
+	case t.posted && t.signs:
+		if t.terminal.Type != worker.TxEventSigned {
+			s.violation("the signed tx %s of the posted tx tree is %s: %s", t.hash, t.terminal.Type, t.terminal.Reason)
+		}
+		s.report.Signed++
+		return
+	case t.posted:
+		if t.terminal.Type != worker.TxEventDropped || t.terminal.Reason != worker.ReasonTxNotSigned {
+			s.violation("the not signed tx %s of the posted tx tree is %s: %s", t.hash, t.terminal.Type, t.terminal.Reason)
+		}
+	default:
+		if t.terminal.Type != worker.TxEventDropped {
+			s.violation("the tx %s of the not posted tx tree is %s", t.hash, t.terminal.Type)
+		}
+	}
+	s.report.Dropped++
+}
+
+// checkBlocks checks the blocks posted by the worker: one block per signed tx tree,
+// the block has the tx tree root proposed to the senders and one merkle proof per sender.
+func (s *simulation) checkBlocks() {
+	blocks := s.db.Blocks()
+	s.report.Blocks = len(blocks)
+
+	proofs := make(map[string]map[string]int)
+	var signed int
+	for _, proof := range s.db.TxMerkleProofs() {
+		if proofs[proof.ProposalBlockID] == nil {
+			proofs[proof.ProposalBlockID] = make(map[string]int)
+		}
+		proofs[proof.ProposalBlockID][proof.SenderPublicKey]++
+		if proof.SignatureID != "" {
+			signed++
+		}
+	}
+	if signed != s.report.Signed {
+		s.violation("%d signed transactions have the merkle proof in the blocks, %d transactions are signed", signed, s.report.Signed)
+	}
+
+	byRoot := make(map[string]string)
+	for root := range s.roots {
+		var r intMaxTree.PoseidonHashOut
+		if err := r.FromString(root); err == nil {
+			byRoot[hexutils.BytesToHex(r.Marshal())] = root
+		}
+	}
+
+	for _, block := range blocks {
+		root, ok := byRoot[block.TxRoot]
+		if !ok {
+			s.violation("the tx tree root %s of the block %s is not proposed to the senders", block.TxRoot, block.ProposalBlockID)
+			continue
+		}
+
+		senders := s.db.BlockSenders(block.ProposalBlockID)
+		for publicKey, n := range proofs[block.ProposalBlockID] {
+			if n != 1 {
+				s.violation("the sender %s has %d merkle proofs in the block %s", publicKey, n, block.ProposalBlockID)
+			}
+		}
+		var inBlock int
+		for key := range senders {
+			if senders[key].PublicKey != intMaxAcc.NewDummyPublicKey().ToAddress().String() {
+				inBlock++
+			}
+		}
+		if inBlock != len(proofs[block.ProposalBlockID]) {
+			s.violation("the block %s has %d senders and %d merkle proofs", block.ProposalBlockID, inBlock, len(proofs[block.ProposalBlockID]))
+		}
+
+		for _, proof := range s.db.TxMerkleProofs() {
+			if proof.ProposalBlockID == block.ProposalBlockID && proof.TxTreeRoot != root {
+				s.violation("the merkle proof of the tx %s has the tx tree root %s instead of %s", proof.TxHash, proof.TxTreeRoot, root)
+			}
+		}
+	}
+}
+
+// tick sends the tick to the worker and waits until the worker handles it:
+// the second tick is received only after the first one is processed.
+func (s *simulation) tick(period time.Duration) {
+	const ticks = 2
+
+	for i := 0; i < ticks; i++ {
+		s.clock.Tick(period, s.done)
+	}
+}
+
+// wait polls the condition until it is met, the worker stops or the wait timeout expires.
+func (s *simulation) wait(cond func() (bool, error)) error {
+	deadline := time.Now().Add(s.cfg.WaitTimeout)
+	for {
+		ok, err := cond()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+
+		select {
+		case <-s.done:
+			return errors.Join(ErrWorkerStartFail, s.errDone)
+		default:
+		}
+		if time.Now().After(deadline) {
+			return ErrWaitTimeout
+		}
+
+		time.Sleep(pollInterval)
+	}
+}
+
+func (s *simulation) violation(format string, args ...interface{}) {
+	s.report.Violations = append(s.report.Violations, fmt.Sprintf(format, args...))
+}
+
+// expectedTxTree builds the tx tree of the transactions in the order of the worker:
+// by the address of the sender and by the transaction hash.
+func expectedTxTree(txs []*tx) (*intMaxTree.PoseidonHashOut, error) {
+	if len(txs) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(txs, func(i, j int) bool {
+		if txs[i].sender.address != txs[j].sender.address {
+			return txs[i].sender.address < txs[j].sender.address
+		}
+		return txs[i].hash < txs[j].hash
+	})
+
+	tree, err := intMaxTree.NewTxTree(
+		intMaxTree.TX_TREE_HEIGHT, []*intMaxTypes.Tx{}, new(intMaxTypes.PoseidonHashOut).SetZero(),
+	)
+	if err != nil {
+		return nil, errors.Join(ErrNewTxTreeFail, err)
+	}
+
+	for key := range txs {
+		txs[key].index = uint64(key)
+		_, err = tree.AddLeaf(txs[key].index, txs[key].leaf)
+		if err != nil {
+			return nil, errors.Join(ErrNewTxTreeFail, err)
+		}
+	}
+
+	root, _, _ := tree.GetCurrentRootCountAndSiblings()
+
+	return &root, nil
+}
+
+// signTxTree signs the tx tree root by the sender as the client does.
+func signTxTree(
+	key *intMaxAcc.PrivateKey,
+	txTreeRoot *intMaxTree.PoseidonHashOut,
+	publicKeys []*intMaxAcc.PublicKey,
+) (string, error) {
+	const numPublicKeyBytes = intMaxTypes.NumPublicKeyBytes
+
+	dummyPublicKey := intMaxAcc.NewDummyPublicKey()
+	senderPublicKeys := make([]byte, intMaxTypes.NumOfSenders*numPublicKeyBytes)
+	for i := 0; i < intMaxTypes.NumOfSenders; i++ {
+		publicKey := dummyPublicKey
+		if i < len(publicKeys) {
+			publicKey = publicKeys[i]
+		}
+		x := publicKey.Pk.X.Bytes() // Only x coordinate is used
+		copy(senderPublicKeys[numPublicKeyBytes*i:numPublicKeyBytes*(i+1)], x[:])
+	}
+	publicKeysHash := crypto.Keccak256(senderPublicKeys)
+
+	message := finite_field.BytesToFieldElementSlice(txTreeRoot.Marshal())
+	signature, err := key.WeightByHash(publicKeysHash).Sign(message)
+	if err != nil {
+		return "", errors.Join(ErrSignFail, err)
+	}
+
+	return hexutil.Encode(signature.Marshal()), nil
+}
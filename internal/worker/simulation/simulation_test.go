@@ -0,0 +1,61 @@
+package simulation_test
+
+import (
+	"context"
+	"intmax2-node/internal/worker/simulation"
+	"intmax2-node/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulation(t *testing.T) {
+	t.Parallel()
+
+	const (
+		senders      = 2048
+		txsPerSender = 1
+	)
+
+	cfg := simulation.Config{
+		Dir:                 t.TempDir(),
+		Seed:                1,
+		Senders:             senders,
+		TxsPerSender:        txsPerSender,
+		MaxCounterOfUsers:   100,
+		NotSignedEvery:      7,
+		RetryEvery:          5,
+		CurrentFileLifetime: time.Minute,
+		SignaturesTimeout:   15 * time.Second,
+		WaitTimeout:         time.Minute,
+	}
+	if testing.Short() {
+		const (
+			sendersShort      = 256
+			txsPerSenderShort = 2
+		)
+		cfg.Senders = sendersShort
+		cfg.TxsPerSender = txsPerSenderShort
+	}
+
+	const (
+		logLevel      = "error"
+		logTimeFormat = "2006-01-02T15:04:05Z"
+	)
+	log := logger.New(logLevel, logTimeFormat, false, false)
+
+	report, err := simulation.Run(context.Background(), log, &cfg)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, report.Err())
+
+	assert.Equal(t, cfg.Senders*cfg.TxsPerSender, report.Txs)
+	assert.Equal(t, report.Txs, report.Signed+report.Dropped)
+	assert.Greater(t, report.Files, cfg.Senders*cfg.TxsPerSender/(cfg.MaxCounterOfUsers+1))
+	assert.Greater(t, report.Blocks, 0)
+	assert.Greater(t, report.Signed, 0)
+	assert.Greater(t, report.Dropped, 0)
+	t.Logf("%+v", *report)
+}
@@ -27,20 +27,26 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	"github.com/google/uuid"
 	"github.com/holiman/uint256"
 	"github.com/status-im/keycard-go/hexutils"
 	bolt "go.etcd.io/bbolt"
 )
 
 const (
-	bucket     = "transfers"
-	int1024Key = 1024
+	bucket           = "transfers"
+	bucketLeafs      = "leafs"
+	bucketSignatures = "signatures"
+	bucketMeta       = "meta"
+	timestampKey     = "timestamp"
+	postedBlockKey   = "posted_block"
+	int1024Key       = 1024
 )
 
 type signaturesByLeafIndex struct {
 	Sender    string
 	TxHash    string
+	Nonce     uint64
+	Fee       *ReceiverWorkerFee
 	Signature string
 	LeafIndex uint64
 	CreatedAt int64
@@ -66,6 +72,7 @@ type kvInfo struct {
 	TransactionsCounter int32
 	Delivered           bool
 	Processing          bool
+	CreatedAt           time.Time
 	Timestamp           *time.Time
 	Receiver            chan func() error
 	LeafsTreePublicKeys *LeafsTree
@@ -96,6 +103,7 @@ type workerFileList struct {
 type TransactionHashesWithSenderAndFile struct {
 	Sender string
 	TxHash string
+	Nonce  uint64
 	File   *os.File
 }
 
@@ -111,12 +119,15 @@ type worker struct {
 	dbApp      SQLDriverApp
 	files      *workerFileList
 	trHashes   *transactionHashesList
+	events     *txEvents
+	clock      Clock
+	fs         FS
 	numWorkers int32
 	maxWorkers int32
 }
 
-func New(cfg *configs.Config, log logger.Logger, dbApp SQLDriverApp) Worker {
-	return &worker{
+func New(cfg *configs.Config, log logger.Logger, dbApp SQLDriverApp, opts ...Option) Worker {
+	w := worker{
 		cfg:   cfg,
 		log:   log,
 		dbApp: dbApp,
@@ -128,8 +139,16 @@ func New(cfg *configs.Config, log logger.Logger, dbApp SQLDriverApp) Worker {
 			Hashes:  make(map[string]*TransactionHashesWithSenderAndFile),
 			Cleaner: make(chan func(), int1024Key),
 		},
+		events:     newTxEvents(cfg.Worker.EventsBufferSize),
+		clock:      systemClock{},
+		fs:         NewOSFS(),
 		maxWorkers: cfg.Worker.MaxCounter,
 	}
+	for key := range opts {
+		opts[key](&w)
+	}
+
+	return &w
 }
 
 func (w *worker) Init() (err error) {
@@ -138,6 +157,11 @@ func (w *worker) Init() (err error) {
 		return errors.Join(ErrCreateNewTempDirFail, err)
 	}
 
+	err = w.restoreFiles()
+	if err != nil {
+		return errors.Join(ErrRestoreFilesFail, err)
+	}
+
 	err = w.newTempFile(w.files.CurrentDir)
 	if err != nil {
 		return errors.Join(ErrCreateNewTempFileFail, err)
@@ -156,14 +180,14 @@ func (w *worker) newTempDir() (err error) {
 	)
 	w.cfg.Worker.Path = strings.TrimSpace(w.cfg.Worker.Path)
 	if w.cfg.Worker.Path == emptyKey {
-		w.cfg.Worker.Path, err = os.MkdirTemp(emptyKey, zeroPattern)
+		w.cfg.Worker.Path, err = w.fs.MkdirTemp(emptyKey, zeroPattern)
 		if err != nil {
 			return errors.Join(ErrMkdirTempFail, err)
 		}
 	}
 
 	if w.cfg.Worker.PathCleanInStart {
-		err = os.RemoveAll(w.cfg.Worker.Path)
+		err = w.fs.RemoveAll(w.cfg.Worker.Path)
 		if err != nil {
 			return errors.Join(ErrRemoveAllFail, err)
 		}
@@ -171,7 +195,10 @@ func (w *worker) newTempDir() (err error) {
 
 	w.cfg.Worker.ID = strings.TrimSpace(w.cfg.Worker.ID)
 	if w.cfg.Worker.ID == emptyKey {
-		w.cfg.Worker.ID = uuid.New().String()
+		w.cfg.Worker.ID, err = workerID(w.fs, w.cfg.Worker.Path)
+		if err != nil {
+			return errors.Join(ErrWorkerIDFail, err)
+		}
 	}
 
 	const maskMkdir = "%s%s%s"
@@ -179,12 +206,8 @@ func (w *worker) newTempDir() (err error) {
 		maskMkdir, w.cfg.Worker.Path, string(os.PathSeparator), w.cfg.Worker.ID,
 	)
 
-	err = os.RemoveAll(path)
-	if err != nil {
-		return errors.Join(ErrRemoveAllFail, err)
-	}
-
-	err = os.MkdirAll(path, fs.ModePerm)
+	// The files of the previous run are kept to be restored.
+	err = w.fs.MkdirAll(path, fs.ModePerm)
 	if err != nil {
 		return errors.Join(ErrMkdirFail, err)
 	}
@@ -200,7 +223,7 @@ func (w *worker) newTempFile(dir string) error {
 
 	const zeroPattern = "*"
 
-	currentFile, err := os.CreateTemp(dir, zeroPattern)
+	currentFile, err := w.fs.CreateTemp(dir, zeroPattern)
 	if err != nil {
 		return errors.Join(ErrCreateTempFail, err)
 	}
@@ -211,43 +234,22 @@ func (w *worker) newTempFile(dir string) error {
 		return errors.Join(ErrKVStoreFail, err)
 	}
 
-	ctxFileInfo, cancelFileInfo := context.WithCancel(context.Background())
-	w.files.FilesList[currentFile] = &fileInfo{
-		kvInfo: kvInfo{
-			Ctx:       ctxFileInfo,
-			CtxCancel: cancelFileInfo,
-			KvDB:      kv,
-			Receiver:  make(chan func() error, int1024Key),
-		},
-		UsersCounter: make(map[string]string),
-		Hashes:       make(map[string]*leafsOfHash),
-	}
+	w.files.FilesList[currentFile] = newFileInfo(kv)
+	w.files.FilesList[currentFile].CreatedAt = w.clock.Now().UTC()
 
 	if w.files.CurrentFile != nil {
-		if _, ok := w.files.FilesList[w.files.CurrentFile]; ok {
-			tm := time.Now().UTC()
-			w.files.FilesList[w.files.CurrentFile].Timestamp = &tm
+		if fi, ok := w.files.FilesList[w.files.CurrentFile]; ok {
+			tm := w.clock.Now().UTC()
+			fi.Timestamp = &tm
+			err = storeTimestamp(fi.KvDB, tm)
+			if err != nil {
+				return errors.Join(ErrStoreTimestampFail, err)
+			}
 		}
 	}
 	w.files.CurrentFile = currentFile
 
-	go func() {
-		for {
-			select {
-			case <-w.files.FilesList[currentFile].Ctx.Done():
-				w.files.Lock()
-				w.files.FilesList[currentFile].Delivered = true
-				_ = w.files.FilesList[currentFile].KvDB.Close()
-				w.files.Unlock()
-				return
-			case fn := <-w.files.FilesList[currentFile].Receiver:
-				errTx := fn()
-				if errTx != nil {
-					w.log.Errorf("%+v", errTx)
-				}
-			}
-		}
-	}()
+	go w.receiverLoop(w.files.FilesList[currentFile])
 
 	w.files.FilesList[currentFile].Receiver <- func() error {
 		var tx *bolt.Tx
@@ -276,15 +278,23 @@ func (w *worker) newTempFile(dir string) error {
 }
 
 func (w *worker) AvailableFiles() (list []*os.File, err error) {
+	// The files are removed by the post processing running in parallel.
+	w.files.Lock()
+	files := make(map[*os.File]*fileInfo, len(w.files.FilesList))
 	for key := range w.files.FilesList {
+		files[key] = w.files.FilesList[key]
+	}
+	w.files.Unlock()
+
+	for key := range files {
 		w.files.Lock()
 		cond1 := w.files.CurrentFile.Name() != key.Name()
-		cond2 := atomic.LoadInt32(&w.files.FilesList[key].TransactionsCounter) == 0
-		cond3 := !w.files.FilesList[key].Processing
+		cond2 := atomic.LoadInt32(&files[key].TransactionsCounter) == 0
+		cond3 := !files[key].Processing
 		w.files.Unlock()
 		if cond1 && cond2 && cond3 {
 			w.files.Lock()
-			condIsDelivered := !w.files.FilesList[key].Delivered
+			condIsDelivered := !files[key].Delivered
 			w.files.Unlock()
 			if condIsDelivered {
 				err = w.leafsProcessing(key)
@@ -293,19 +303,15 @@ func (w *worker) AvailableFiles() (list []*os.File, err error) {
 				}
 
 				w.files.Lock()
-				w.files.FilesList[key].CtxCancel()
+				files[key].CtxCancel()
 				w.files.Unlock()
 				continue
 			}
 			list = append(list, key)
 		}
 	}
-	w.files.Lock()
-	defer w.files.Unlock()
 	sort.Slice(list, func(i, j int) bool {
-		b, _ := list[i].Stat()
-		b2, _ := list[j].Stat()
-		return b.ModTime().Before(b2.ModTime())
+		return files[list[i]].CreatedAt.Before(files[list[j]].CreatedAt)
 	})
 
 	return list, nil
@@ -334,25 +340,10 @@ func (w *worker) TxTreeByAvailableFile(sf *TransactionHashesWithSenderAndFile) (
 			f.Delivered &&
 			f.Timestamp != nil && f.Timestamp.UTC().Add(
 			w.cfg.Worker.TimeoutForSignaturesAvailableFiles,
-		).UnixNano() <= time.Now().UTC().UnixNano():
-		for {
-			if !f.Processing {
-				// transfersHash exists, tx tree exists, signature collection for tx tree completed
-				return nil, ErrTxTreeSignatureCollectionComplete
-			}
-		}
-	case
-		w.files.CurrentFile.Name() != sf.File.Name() &&
-			atomic.LoadInt32(&f.TransactionsCounter) == 0 &&
-			f.Delivered &&
-			f.Timestamp != nil && f.Timestamp.UTC().Add(
-			w.cfg.Worker.TimeoutForSignaturesAvailableFiles,
-		).UnixNano() > time.Now().UTC().UnixNano():
-		for {
-			if !f.Processing {
-				break
-			}
-		}
+		).UnixNano() <= w.clock.Now().UTC().UnixNano(),
+		f.Processing:
+		// transfersHash exists, tx tree exists, signature collection for tx tree completed
+		return nil, ErrTxTreeSignatureCollectionComplete
 	}
 
 	var (
@@ -414,34 +405,33 @@ func (w *worker) TxTreeByAvailableFile(sf *TransactionHashesWithSenderAndFile) (
 // 	return ErrTxTreeRootNotFound
 // }
 
-func (w *worker) Start(
-	ctx context.Context,
-	tickerCurrentFile, tickerSignaturesAvailableFiles *time.Ticker,
-) error {
+func (w *worker) Start(ctx context.Context) error {
+	tickerCurrentFile := w.clock.NewTicker(w.cfg.Worker.TimeoutForCheckCurrentFile)
+	defer tickerCurrentFile.Stop()
+
+	tickerSignaturesAvailableFiles := w.clock.NewTicker(w.cfg.Worker.TimeoutForSignaturesAvailableFiles)
+	defer tickerSignaturesAvailableFiles.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
-			tickerCurrentFile.Stop()
+			w.closeFiles()
 			return nil
-		case <-tickerCurrentFile.C:
+		case <-tickerCurrentFile.C():
 			w.files.Lock()
-			st, err := w.files.CurrentFile.Stat()
-			if err != nil {
-				w.files.Unlock()
-				return errors.Join(ErrStatCurrentFileFail, err)
-			}
+			createdAt := w.files.FilesList[w.files.CurrentFile].CreatedAt
 			// cond1 - current file lifetime expired
-			cond1 := st.ModTime().UTC().Add(w.cfg.Worker.CurrentFileLifetime).UnixNano()-time.Now().UTC().UnixNano() <= 0
+			cond1 := createdAt.UTC().Add(w.cfg.Worker.CurrentFileLifetime).UnixNano()-w.clock.Now().UTC().UnixNano() <= 0
 			// cond2 - the number of users exceeded the limit
 			cond2 := len(w.files.FilesList[w.files.CurrentFile].UsersCounter) > w.cfg.Worker.MaxCounterOfUsers
 			w.files.Unlock()
 			if cond1 || cond2 {
-				err = w.newTempFile(w.files.CurrentDir)
+				err := w.newTempFile(w.files.CurrentDir)
 				if err != nil {
 					return errors.Join(ErrCreateNewTempFileFail, err)
 				}
 			}
-		case <-tickerSignaturesAvailableFiles.C:
+		case <-tickerSignaturesAvailableFiles.C():
 			list, err := w.AvailableFiles()
 			if err != nil {
 				return errors.Join(ErrAvailableFilesProcessing, err)
@@ -455,7 +445,7 @@ func (w *worker) Start(
 				cond2 := w.files.FilesList[list[key]].Timestamp != nil &&
 					w.files.FilesList[list[key]].Timestamp.UTC().Add(
 						w.cfg.Worker.TimeoutForSignaturesAvailableFiles,
-					).UnixNano() < time.Now().UTC().UnixNano()
+					).UnixNano() < w.clock.Now().UTC().UnixNano()
 				w.files.Unlock()
 				if cond1 && cond2 {
 					if atomic.LoadInt32(&w.numWorkers) < w.maxWorkers {
@@ -513,12 +503,20 @@ func (w *worker) Receiver(input *ReceiverWorker) error {
 		return ErrReceiverWorkerDuplicate
 	}
 
+	for key := range w.trHashes.Hashes {
+		if w.trHashes.Hashes[key].Sender == input.Sender &&
+			w.trHashes.Hashes[key].Nonce == input.Nonce {
+			return ErrNonceAlreadyUsed
+		}
+	}
+
 	input.TxHash = currTx
 
 	w.files.Lock()
 	w.trHashes.Hashes[currTx.Hash().String()] = &TransactionHashesWithSenderAndFile{
 		Sender: input.Sender,
 		TxHash: currTx.Hash().String(),
+		Nonce:  input.Nonce,
 		File:   w.files.CurrentFile,
 	}
 	w.files.Unlock()
@@ -528,10 +526,15 @@ func (w *worker) Receiver(input *ReceiverWorker) error {
 		return errors.Join(ErrRegisterReceiverFail, err)
 	}
 
+	w.publish(&TxEvent{
+		Type:   TxEventAccepted,
+		TxHash: currTx.Hash().String(),
+	})
+
 	return nil
 }
 
-func (w *worker) registerReceiver(input *ReceiverWorker) (err error) {
+func (w *worker) registerReceiver(input *ReceiverWorker) error {
 	if input == nil {
 		return ErrReceiverWorkerEmpty
 	}
@@ -552,7 +555,11 @@ func (w *worker) registerReceiver(input *ReceiverWorker) (err error) {
 			w.files.Unlock()
 		}()
 
-		var tx *bolt.Tx
+		// the receiver runs in the goroutine of the file, so it must not share the variables with the caller
+		var (
+			tx  *bolt.Tx
+			err error
+		)
 		tx, err = w.files.FilesList[current].KvDB.Begin(true)
 		if err != nil {
 			return errors.Join(ErrTxBeginKVStoreFail, err)
@@ -642,6 +649,10 @@ func (w *worker) leafsProcessing(f *os.File) (err error) {
 			return fmt.Errorf("bucket %s not found", bucket)
 		}
 
+		// The leaves assigned before a restart are kept, so that the tx tree root
+		// signed by the senders does not change.
+		lb := tx.Bucket([]byte(bucketLeafs))
+
 		c := b.Cursor()
 		for k, v := c.First(); k != nil; k, v = c.Next() {
 			var info SenderInfo
@@ -651,33 +662,52 @@ func (w *worker) leafsProcessing(f *os.File) (err error) {
 				return err
 			}
 
+			txHashes := make([]string, 0, len(info.TxsList))
 			for key := range info.TxsList {
-				var accID uint256.Int
-				err = w.dbApp.Exec(w.files.FilesList[f].Ctx, &accID, func(d interface{}, in interface{}) error {
-					q := d.(SQLDriverApp)
-
-					ai := block_post_service.NewAccountInfo(q)
-					var accIDInfo *uint256.Int
-					accIDInfo, err = ai.AccountBySenderAddress(info.TxsList[key].Sender)
-					if err != nil && !errors.Is(err, errorsDB.ErrNotFound) {
-						return err
-					}
-					if errors.Is(err, errorsDB.ErrNotFound) {
-						return nil
+				txHashes = append(txHashes, key)
+			}
+			sort.Strings(txHashes)
+
+			for _, key := range txHashes {
+				var (
+					accID      uint256.Int
+					storedLeaf *leafsOfHash
+				)
+				storedLeaf, err = leafOfHash(lb, info.TxsList[key].TxHash.Hash().String())
+				if err != nil {
+					return err
+				}
+				if storedLeaf != nil {
+					if storedLeaf.AccountID != nil {
+						accID = *storedLeaf.AccountID
 					}
+				} else {
+					err = w.dbApp.Exec(w.files.FilesList[f].Ctx, &accID, func(d interface{}, in interface{}) error {
+						q := d.(SQLDriverApp)
+
+						ai := block_post_service.NewAccountInfo(q)
+						var accIDInfo *uint256.Int
+						accIDInfo, err = ai.AccountBySenderAddress(info.TxsList[key].Sender)
+						if err != nil && !errors.Is(err, errorsDB.ErrNotFound) {
+							return err
+						}
+						if errors.Is(err, errorsDB.ErrNotFound) {
+							return nil
+						}
+
+						if inV, ok := in.(*uint256.Int); ok {
+							*inV = *accIDInfo
+						} else {
+							const msg = "failed to convert of account ID from uint256.Int"
+							return fmt.Errorf(msg)
+						}
 
-					if inV, ok := in.(*uint256.Int); ok {
-						*inV = *accIDInfo
-					} else {
-						const msg = "failed to convert of account ID from uint256.Int"
+						return nil
+					})
+					if err != nil {
+						const msg = "failed to get of account ID with DBApp"
 						return fmt.Errorf(msg)
 					}
-
-					return nil
-				})
-				if err != nil {
-					const msg = "failed to get of account ID with DBApp"
-					return fmt.Errorf(msg)
 				}
 
 				lfh := leafsOfHash{
@@ -712,6 +742,8 @@ func (w *worker) leafsProcessing(f *os.File) (err error) {
 					lfhAccIDs = append(lfhAccIDs, &signaturesByLeafIndex{
 						Sender:    info.TxsList[key].Sender,
 						TxHash:    info.TxsList[key].TxHash.Hash().String(),
+						Nonce:     info.TxsList[key].Nonce,
+						Fee:       info.TxsList[key].Fee,
 						LeafIndex: lfh.Index,
 					})
 				} else {
@@ -733,6 +765,8 @@ func (w *worker) leafsProcessing(f *os.File) (err error) {
 					lfhPubKey = append(lfhPubKey, &signaturesByLeafIndex{
 						Sender:    info.TxsList[key].Sender,
 						TxHash:    info.TxsList[key].TxHash.Hash().String(),
+						Nonce:     info.TxsList[key].Nonce,
+						Fee:       info.TxsList[key].Fee,
 						LeafIndex: lfh.Index,
 					})
 				}
@@ -805,7 +839,7 @@ func (w *worker) leafsProcessing(f *os.File) (err error) {
 			keysOfSenderPublicKeys[senderPublicKeys[key].ToAddress().String()] = key
 		}
 
-		txRoot, count, sb := txTreePublicKeys.GetCurrentRootCountAndSiblings()
+		txRoot, count, sb := txTreeAccountIDs.GetCurrentRootCountAndSiblings()
 
 		w.files.FilesList[f].LeafsTreeAccounts = &LeafsTree{
 			TxTree:                 txTreeAccountIDs,
@@ -820,6 +854,48 @@ func (w *worker) leafsProcessing(f *os.File) (err error) {
 		}
 	}
 
+	err = storeLeafs(w.files.FilesList[f].KvDB, w.files.FilesList[f].Hashes)
+	if err != nil {
+		return errors.Join(ErrStoreLeafsFail, err)
+	}
+
+	// The signatures collected before a restart are applied to the restored tx trees.
+	var signatures []*signaturesByLeafIndex
+	signatures, err = storedSignatures(w.files.FilesList[f].KvDB)
+	if err != nil {
+		return errors.Join(ErrStoredSignaturesFail, err)
+	}
+
+	for key := range signatures {
+		applySignature(w.files.FilesList[f], signatures[key])
+	}
+
+	for _, lft := range []*LeafsTree{
+		w.files.FilesList[f].LeafsTreePublicKeys,
+		w.files.FilesList[f].LeafsTreeAccounts,
+	} {
+		w.publishTxTree(lft, func(_ *signaturesByLeafIndex) (string, string) {
+			return TxEventProposed, ""
+		})
+	}
+
+	return nil
+}
+
+// postBlock stores the signatures of the block and marks the file as posted.
+// The mark is written before the block is committed, so that the file of the committed block
+// is not posted again by the restore if the worker stops before the file is removed.
+func postBlock(q SQLDriverApp, kv *bolt.DB, block *mDBApp.Block, lft *LeafsTree) error {
+	err := funcLFT(q, block, lft)
+	if err != nil {
+		return err
+	}
+
+	err = storePostedBlock(kv, block.ProposalBlockID)
+	if err != nil {
+		return errors.Join(ErrStorePostedBlockFail, err)
+	}
+
 	return nil
 }
 
@@ -845,6 +921,15 @@ func funcLFT(q SQLDriverApp, block *mDBApp.Block, lft *LeafsTree) (err error) {
 			return err
 		}
 
+		if sign != nil {
+			err = updateSenderNonce(
+				q, publicKey, lft.SignaturesByLeafIndex[index].Nonce,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
 		var txTreeIndex uint256.Int
 		_ = txTreeIndex.SetUint64(lft.SignaturesByLeafIndex[index].LeafIndex)
 
@@ -878,6 +963,56 @@ func funcLFT(q SQLDriverApp, block *mDBApp.Block, lft *LeafsTree) (err error) {
 		if err != nil {
 			return errors.Join(ErrCreateTxMerkleProofsFail, err)
 		}
+
+		if sign != nil && lft.SignaturesByLeafIndex[index].Fee != nil {
+			err = createBlockFee(q, block, lft.SignaturesByLeafIndex[index])
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// createBlockFee records the fee of the signed transaction in the fee ledger of the block.
+func createBlockFee(q SQLDriverApp, block *mDBApp.Block, s *signaturesByLeafIndex) error {
+	var amount uint256.Int
+	err := amount.SetFromDecimal(s.Fee.Amount)
+	if err != nil {
+		return errors.Join(ErrFeeAmountInvalid, err)
+	}
+
+	_, err = q.CreateBlockFee(&mDBApp.BlockFee{
+		ProposalBlockID: block.ProposalBlockID,
+		TxHash:          s.TxHash,
+		Sender:          s.Sender,
+		TokenIndex:      s.Fee.TokenIndex,
+		Amount:          &amount,
+		TransferHash:    s.Fee.TransferHash,
+	})
+	if err != nil {
+		return errors.Join(ErrCreateBlockFeeFail, err)
+	}
+
+	return nil
+}
+
+func updateSenderNonce(q SQLDriverApp, publicKey *intMaxAcc.PublicKey, nonce uint64) error {
+	sender, err := q.SenderByAddress(publicKey.ToAddress().String())
+	if err != nil && !errors.Is(err, errorsDB.ErrNotFound) {
+		return errors.Join(ErrSenderByAddressFail, err)
+	}
+	if errors.Is(err, errorsDB.ErrNotFound) {
+		sender, err = q.CreateSenders(publicKey.ToAddress().String(), publicKey.String())
+		if err != nil {
+			return errors.Join(ErrCreateSendersFail, err)
+		}
+	}
+
+	err = q.UpdateSenderNonce(sender.ID, nonce)
+	if err != nil {
+		return errors.Join(ErrUpdateSenderNonceFail, err)
 	}
 
 	return nil
@@ -888,10 +1023,7 @@ func (w *worker) postProcessing(ctx context.Context, f *os.File) (err error) {
 
 	w.files.Lock()
 	if len(w.files.FilesList[f].Hashes) == 0 {
-		delete(w.files.FilesList, f)
-		w.files.Cleaner <- func() {
-			_ = os.Remove(f.Name())
-		}
+		w.removeFile(f)
 		w.files.Unlock()
 		return nil
 	}
@@ -901,14 +1033,21 @@ func (w *worker) postProcessing(ctx context.Context, f *os.File) (err error) {
 		w.files.Lock()
 		defer w.files.Unlock()
 		for key := range w.files.FilesList[f].Hashes {
-			delete(w.files.FilesList[f].Hashes, key)
+			// The cleaner runs after the loop, so the hash must not be shared between the iterations.
+			txHash := key
+			delete(w.files.FilesList[f].Hashes, txHash)
 			w.trHashes.Cleaner <- func() {
 				w.trHashes.Lock()
 				defer w.trHashes.Unlock()
-				delete(w.trHashes.Hashes, key)
+				delete(w.trHashes.Hashes, txHash)
 			}
 		}
 		w.files.FilesList[f].Processing = false
+
+		if err == nil {
+			// The transactions of the file are done with, so they must not be restored after a restart.
+			w.removeFile(f)
+		}
 	}()
 
 	w.files.Lock()
@@ -917,6 +1056,7 @@ func (w *worker) postProcessing(ctx context.Context, f *os.File) (err error) {
 		w.files.FilesList[f].LeafsTreePublicKeys.SignaturesCounter <= int0Key) &&
 		(w.files.FilesList[f].LeafsTreeAccounts == nil ||
 			w.files.FilesList[f].LeafsTreeAccounts.SignaturesCounter <= int0Key) {
+		w.publishPostProcessing(w.files.FilesList[f], nil)
 		w.files.Unlock()
 		return nil
 	}
@@ -928,6 +1068,11 @@ func (w *worker) postProcessing(ctx context.Context, f *os.File) (err error) {
 		return errors.Join(errorsB.ErrWalletAddressNotRecognized, err)
 	}
 
+	w.files.Lock()
+	kv := w.files.FilesList[f].KvDB
+	w.files.Unlock()
+
+	var posted *LeafsTree
 	err = w.dbApp.Exec(ctx, nil, func(d interface{}, _ interface{}) (err error) {
 		q := d.(SQLDriverApp)
 
@@ -949,7 +1094,10 @@ func (w *worker) postProcessing(ctx context.Context, f *os.File) (err error) {
 
 			signatures := make([]string, len(lft.SenderPublicKeys))
 			for indexSPK := range lft.SenderPublicKeys {
-				signatures[indexSPK] = lft.Signatures[indexSPK].Signature
+				// The senders not signed in time have no signature.
+				if lft.Signatures[indexSPK] != nil {
+					signatures[indexSPK] = lft.Signatures[indexSPK].Signature
+				}
 			}
 
 			var bc *intMaxTypes.BlockContent
@@ -985,7 +1133,9 @@ func (w *worker) postProcessing(ctx context.Context, f *os.File) (err error) {
 				return errors.Join(ErrCreateBlockFail, err)
 			}
 
-			return funcLFT(q, block, lft)
+			posted = lft
+
+			return postBlock(q, kv, block, lft)
 		}
 
 		w.files.Lock()
@@ -1005,7 +1155,10 @@ func (w *worker) postProcessing(ctx context.Context, f *os.File) (err error) {
 
 			signatures := make([]string, len(lft.SenderPublicKeys))
 			for indexSPK := range lft.SenderPublicKeys {
-				signatures[indexSPK] = lft.Signatures[indexSPK].Signature
+				// The senders not signed in time have no signature.
+				if lft.Signatures[indexSPK] != nil {
+					signatures[indexSPK] = lft.Signatures[indexSPK].Signature
+				}
 			}
 
 			senderAccountIDs := make([]uint64, len(lft.SenderAccountIDs))
@@ -1048,7 +1201,9 @@ func (w *worker) postProcessing(ctx context.Context, f *os.File) (err error) {
 				return errors.Join(ErrCreateBlockFail, err)
 			}
 
-			return funcLFT(q, block, lft)
+			posted = lft
+
+			return postBlock(q, kv, block, lft)
 		}
 
 		return nil
@@ -1057,9 +1212,116 @@ func (w *worker) postProcessing(ctx context.Context, f *os.File) (err error) {
 		return err
 	}
 
+	w.files.Lock()
+	w.publishPostProcessing(w.files.FilesList[f], posted)
+	w.files.Unlock()
+
 	return nil
 }
 
+// publishPostProcessing publishes the result of the signature collection of the tx trees of the file:
+// the transactions of the tx tree included into the block are signed or dropped without the signature,
+// the transactions of the other tx trees are dropped.
+func (w *worker) publishPostProcessing(f *fileInfo, posted *LeafsTree) {
+	for _, lft := range []*LeafsTree{f.LeafsTreePublicKeys, f.LeafsTreeAccounts} {
+		if lft == nil {
+			continue
+		}
+
+		txTreeRoot, _, _ := lft.TxTree.GetCurrentRootCountAndSiblings()
+
+		if lft == posted {
+			w.publishTxTree(lft, func(s *signaturesByLeafIndex) (string, string) {
+				if s.Signature == "" {
+					return TxEventDropped, ReasonTxNotSigned
+				}
+
+				return TxEventSigned, ""
+			})
+			w.publish(&TxEvent{
+				Type:       TxEventSigned,
+				TxTreeRoot: txTreeRoot.String(),
+			})
+			continue
+		}
+
+		reason := ReasonTxTreeNotIncluded
+		if lft.SignaturesCounter == 0 {
+			reason = ReasonTxTreeNotSigned
+		}
+		w.publishTxTree(lft, func(_ *signaturesByLeafIndex) (string, string) {
+			return TxEventDropped, reason
+		})
+		w.publish(&TxEvent{
+			Type:       TxEventDropped,
+			TxTreeRoot: txTreeRoot.String(),
+			Reason:     reason,
+		})
+	}
+}
+
+// publishTxTree publishes the event of every transaction of the tx tree.
+func (w *worker) publishTxTree(
+	lft *LeafsTree,
+	event func(s *signaturesByLeafIndex) (eventType, reason string),
+) {
+	if lft == nil {
+		return
+	}
+
+	txTreeRoot, _, _ := lft.TxTree.GetCurrentRootCountAndSiblings()
+	for key := range lft.SignaturesByLeafIndex {
+		eventType, reason := event(lft.SignaturesByLeafIndex[key])
+		w.publish(&TxEvent{
+			Type:       eventType,
+			TxHash:     lft.SignaturesByLeafIndex[key].TxHash,
+			TxTreeRoot: txTreeRoot.String(),
+			Reason:     reason,
+		})
+	}
+}
+
+func (w *worker) publish(event *TxEvent) {
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = w.clock.Now().UTC()
+	}
+
+	lost := w.events.publish(event)
+	if lost > 0 {
+		const msg = "the %s event of the tx hash %q and the tx tree root %q is lost by %d subscribers"
+		w.log.Warnf(msg, event.Type, event.TxHash, event.TxTreeRoot, lost)
+	}
+}
+
+// Subscribe returns the lifecycle events of the transaction hash or the tx tree root until the unsubscribe.
+func (w *worker) Subscribe(key string) (events <-chan *TxEvent, unsubscribe func()) {
+	return w.events.subscribe(key)
+}
+
+// Load returns the number of the transactions waiting for the tx tree and for the signatures.
+func (w *worker) Load() *Load {
+	w.files.Lock()
+	defer w.files.Unlock()
+
+	var load Load
+	for key := range w.files.FilesList {
+		f := w.files.FilesList[key]
+		if f.LeafsTreePublicKeys == nil && f.LeafsTreeAccounts == nil {
+			load.PendingTxs += len(f.Hashes)
+			continue
+		}
+
+		for _, lft := range []*LeafsTree{f.LeafsTreePublicKeys, f.LeafsTreeAccounts} {
+			if lft == nil {
+				continue
+			}
+			load.SignatureBacklog += len(lft.SenderPublicKeys) - lft.SignaturesCounter
+		}
+	}
+
+	return &load
+}
+
 func (w *worker) TrHash(trHash string) (*TransactionHashesWithSenderAndFile, error) {
 	w.trHashes.Lock()
 	defer w.trHashes.Unlock()
@@ -1072,6 +1334,29 @@ func (w *worker) TrHash(trHash string) (*TransactionHashesWithSenderAndFile, err
 	return info, nil
 }
 
+func (w *worker) NextNonce(sender string) (nonce uint64, err error) {
+	var info *mDBApp.Sender
+	info, err = w.dbApp.SenderByAddress(sender)
+	if err != nil && !errors.Is(err, errorsDB.ErrNotFound) {
+		return 0, errors.Join(ErrSenderByAddressFail, err)
+	}
+	if err == nil {
+		nonce = info.Nonce
+	}
+
+	w.trHashes.Lock()
+	defer w.trHashes.Unlock()
+
+	for key := range w.trHashes.Hashes {
+		if w.trHashes.Hashes[key].Sender == sender &&
+			w.trHashes.Hashes[key].Nonce > nonce {
+			nonce = w.trHashes.Hashes[key].Nonce
+		}
+	}
+
+	return nonce + 1, nil
+}
+
 func (w *worker) SignTxTreeByAvailableFile(
 	signature string,
 	sf *TransactionHashesWithSenderAndFile,
@@ -1099,63 +1384,66 @@ func (w *worker) SignTxTreeByAvailableFile(
 			f.Delivered &&
 			f.Timestamp != nil && f.Timestamp.UTC().Add(
 			w.cfg.Worker.TimeoutForSignaturesAvailableFiles,
-		).UnixNano() <= time.Now().UTC().UnixNano():
-		for {
-			if !f.Processing {
-				// transfersHash exists, tx tree exists, signature collection for tx tree completed
-				return ErrTxTreeSignatureCollectionComplete
-			}
-		}
-	case
-		w.files.CurrentFile.Name() != sf.File.Name() &&
-			atomic.LoadInt32(&f.TransactionsCounter) == 0 &&
-			f.Delivered &&
-			f.Timestamp != nil && f.Timestamp.UTC().Add(
-			w.cfg.Worker.TimeoutForSignaturesAvailableFiles,
-		).UnixNano() > time.Now().UTC().UnixNano():
-		for {
-			if !f.Processing {
-				break
-			}
-		}
+		).UnixNano() <= w.clock.Now().UTC().UnixNano(),
+		f.Processing:
+		// transfersHash exists, tx tree exists, signature collection for tx tree completed
+		return ErrTxTreeSignatureCollectionComplete
 	}
 
-	tm := time.Now().UTC().UnixNano()
-	if w.files.FilesList[sf.File].Hashes[sf.TxHash].AccountID != nil &&
-		w.files.FilesList[sf.File].LeafsTreeAccounts != nil {
-		if vKeys, okKeys := w.files.FilesList[sf.File].LeafsTreeAccounts.KeysOfSenderPublicKeys[sf.Sender]; okKeys {
-			w.files.FilesList[sf.File].LeafsTreeAccounts.Signatures[vKeys] = &signaturesByLeafIndex{
-				Sender:    sf.Sender,
-				TxHash:    sf.TxHash,
-				Signature: signature,
-				LeafIndex: leafIndex,
-				CreatedAt: tm,
-			}
-			w.files.FilesList[sf.File].LeafsTreeAccounts.SignaturesByLeafIndex[leafIndex].Signature = signature
-			w.files.FilesList[sf.File].LeafsTreeAccounts.SignaturesByLeafIndex[leafIndex].CreatedAt = tm
-			w.files.FilesList[sf.File].LeafsTreeAccounts.SignaturesCounter++
-		}
+	s := signaturesByLeafIndex{
+		Sender:    sf.Sender,
+		TxHash:    sf.TxHash,
+		Signature: signature,
+		LeafIndex: leafIndex,
+		CreatedAt: w.clock.Now().UTC().UnixNano(),
+	}
+	if !applySignature(f, &s) {
+		return nil
 	}
 
-	if w.files.FilesList[sf.File].Hashes[sf.TxHash].AccountID == nil &&
-		w.files.FilesList[sf.File].LeafsTreePublicKeys != nil {
-		if vKeys, okKeys := w.files.FilesList[sf.File].LeafsTreePublicKeys.KeysOfSenderPublicKeys[sf.Sender]; okKeys {
-			w.files.FilesList[sf.File].LeafsTreePublicKeys.Signatures[vKeys] = &signaturesByLeafIndex{
-				Sender:    sf.Sender,
-				TxHash:    sf.TxHash,
-				Signature: signature,
-				LeafIndex: leafIndex,
-				CreatedAt: tm,
-			}
-			w.files.FilesList[sf.File].LeafsTreePublicKeys.SignaturesByLeafIndex[leafIndex].Signature = signature
-			w.files.FilesList[sf.File].LeafsTreePublicKeys.SignaturesByLeafIndex[leafIndex].CreatedAt = tm
-			w.files.FilesList[sf.File].LeafsTreePublicKeys.SignaturesCounter++
-		}
+	// The signature is stored to resume the signature collection after a restart.
+	err := storeSignature(f.KvDB, &s)
+	if err != nil {
+		return errors.Join(ErrStoreSignatureFail, err)
 	}
 
 	return nil
 }
 
+// applySignature adds the signature of the sender to the tx tree that contains the transaction.
+func applySignature(f *fileInfo, s *signaturesByLeafIndex) (applied bool) {
+	lfh, ok := f.Hashes[s.TxHash]
+	if !ok || lfh == nil {
+		return false
+	}
+
+	lft := f.LeafsTreePublicKeys
+	if lfh.AccountID != nil {
+		lft = f.LeafsTreeAccounts
+	}
+	if lft == nil || s.LeafIndex >= uint64(len(lft.SignaturesByLeafIndex)) {
+		return false
+	}
+
+	vKeys, okKeys := lft.KeysOfSenderPublicKeys[s.Sender]
+	if !okKeys {
+		return false
+	}
+
+	lft.Signatures[vKeys] = &signaturesByLeafIndex{
+		Sender:    s.Sender,
+		TxHash:    s.TxHash,
+		Signature: s.Signature,
+		LeafIndex: s.LeafIndex,
+		CreatedAt: s.CreatedAt,
+	}
+	lft.SignaturesByLeafIndex[s.LeafIndex].Signature = s.Signature
+	lft.SignaturesByLeafIndex[s.LeafIndex].CreatedAt = s.CreatedAt
+	lft.SignaturesCounter++
+
+	return true
+}
+
 func (w *worker) kvStore(filename string) (*bolt.DB, error) {
 	const F0600 os.FileMode = 0600
 	db, err := bolt.Open(filename, F0600, nil)
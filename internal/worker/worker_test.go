@@ -5,15 +5,19 @@ import (
 	"errors"
 	"intmax2-node/configs"
 	intMaxAcc "intmax2-node/internal/accounts"
+	"intmax2-node/internal/finite_field"
 	"intmax2-node/internal/mnemonic_wallet"
 	"intmax2-node/internal/mnemonic_wallet/models"
 	intMaxTree "intmax2-node/internal/tree"
 	intMaxTypes "intmax2-node/internal/types"
 	"intmax2-node/internal/worker"
+	"intmax2-node/internal/worker/simulation"
 	"intmax2-node/pkg/logger"
+	errorsDB "intmax2-node/pkg/sql_db/errors"
 	"math/big"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -21,6 +25,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/iden3/go-iden3-crypto/ffg"
 	"github.com/iden3/go-iden3-crypto/keccak256"
 	"github.com/stretchr/testify/assert"
@@ -58,26 +63,12 @@ func TestWorkerReceiver(t *testing.T) {
 	err = w.Init()
 	assert.NoError(t, err)
 
-	tickerCurrentFile := time.NewTicker(cfg.Worker.TimeoutForCheckCurrentFile)
-	defer func() {
-		if tickerCurrentFile != nil {
-			tickerCurrentFile.Stop()
-		}
-	}()
-
-	tickerSignaturesAvailableFiles := time.NewTicker(cfg.Worker.TimeoutForSignaturesAvailableFiles)
-	defer func() {
-		if tickerSignaturesAvailableFiles != nil {
-			tickerSignaturesAvailableFiles.Stop()
-		}
-	}()
-
 	wg := sync.WaitGroup{}
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		err = w.Start(ctx, tickerCurrentFile, tickerSignaturesAvailableFiles)
+		err = w.Start(ctx)
 		assert.NoError(t, err)
 	}()
 
@@ -210,26 +201,12 @@ func TestWorkerReceiverNotUniqueTransfer(t *testing.T) {
 	err = w.Init()
 	assert.NoError(t, err)
 
-	tickerCurrentFile := time.NewTicker(cfg.Worker.TimeoutForCheckCurrentFile)
-	defer func() {
-		if tickerCurrentFile != nil {
-			tickerCurrentFile.Stop()
-		}
-	}()
-
-	tickerSignaturesAvailableFiles := time.NewTicker(cfg.Worker.TimeoutForSignaturesAvailableFiles)
-	defer func() {
-		if tickerSignaturesAvailableFiles != nil {
-			tickerSignaturesAvailableFiles.Stop()
-		}
-	}()
-
 	wg := sync.WaitGroup{}
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		err = w.Start(ctx, tickerCurrentFile, tickerSignaturesAvailableFiles)
+		err = w.Start(ctx)
 		assert.NoError(t, err)
 	}()
 
@@ -319,26 +296,12 @@ func TestWorkerReceiverUniqueTransferByNonce(t *testing.T) {
 	err = w.Init()
 	assert.NoError(t, err)
 
-	tickerCurrentFile := time.NewTicker(cfg.Worker.TimeoutForCheckCurrentFile)
-	defer func() {
-		if tickerCurrentFile != nil {
-			tickerCurrentFile.Stop()
-		}
-	}()
-
-	tickerSignaturesAvailableFiles := time.NewTicker(cfg.Worker.TimeoutForSignaturesAvailableFiles)
-	defer func() {
-		if tickerSignaturesAvailableFiles != nil {
-			tickerSignaturesAvailableFiles.Stop()
-		}
-	}()
-
 	wg := sync.WaitGroup{}
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		err = w.Start(ctx, tickerCurrentFile, tickerSignaturesAvailableFiles)
+		err = w.Start(ctx)
 		assert.NoError(t, err)
 	}()
 
@@ -418,3 +381,304 @@ func TestWorkerReceiverUniqueTransferByNonce(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestWorkerRestart(t *testing.T) {
+	const int2Key = 2
+	assert.NoError(t, configs.LoadDotEnv(int2Key))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		derivation = "m/44'/60'/0'/0/0"
+		emptyKey   = ""
+		tick       = 50 * time.Millisecond
+		waitFor    = 5 * time.Second
+	)
+
+	builder, err := mnemonic_wallet.New().WalletGenerator(derivation, emptyKey)
+	assert.NoError(t, err)
+
+	path := "./mocks/worker/" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	newConfig := func() *configs.Config {
+		cfg := *configs.New()
+		cfg.Worker.Path = path
+		cfg.Worker.ID = emptyKey
+		cfg.Worker.PathCleanInStart = false
+		cfg.Worker.CurrentFileLifetime = 4 * tick
+		cfg.Worker.TimeoutForSignaturesAvailableFiles = 3 * time.Second
+		cfg.Blockchain.BuilderPrivateKeyHex = builder.PrivateKey
+		return &cfg
+	}
+
+	var posted int32
+	dbApp := NewMockSQLDriverApp(ctrl)
+	dbApp.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, input interface{}, _ func(d interface{}, input interface{}) error) error {
+			if input == nil {
+				// Only the block posting runs without input.
+				atomic.AddInt32(&posted, 1)
+			}
+			return nil
+		}).AnyTimes()
+	dbApp.EXPECT().SenderByAddress(gomock.Any()).Return(nil, errorsDB.ErrNotFound).AnyTimes()
+
+	start := func(w worker.Worker) (stop func()) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, w.Start(ctx))
+		}()
+
+		return func() {
+			cancel()
+			wg.Wait()
+		}
+	}
+
+	txTree := func(w worker.Worker, txHash string) *worker.TxTree {
+		var tree *worker.TxTree
+		assert.Eventually(t, func() bool {
+			sf, err := w.TrHash(txHash)
+			if err != nil {
+				return false
+			}
+			tree, err = w.TxTreeByAvailableFile(sf)
+			return err == nil
+		}, waitFor, tick/int2Key)
+
+		return tree
+	}
+
+	cfg := newConfig()
+	log := logger.New(cfg.LOG.Level, cfg.LOG.TimeFormat, cfg.LOG.JSON, cfg.LOG.IsLogLine)
+
+	w := worker.New(cfg, log, dbApp, worker.WithClock(tickClock{tick: tick}))
+	assert.NoError(t, w.Init())
+	stop := start(w)
+
+	sender, err := mnemonic_wallet.New().WalletGenerator(derivation, emptyKey)
+	assert.NoError(t, err)
+
+	recipient, err := mnemonic_wallet.New().WalletGenerator(derivation, emptyKey)
+	assert.NoError(t, err)
+	recipientAddress, err := intMaxTypes.NewEthereumAddress(recipient.WalletAddress.Bytes())
+	assert.NoError(t, err)
+
+	transfer := intMaxTypes.Transfer{
+		Recipient:  recipientAddress,
+		TokenIndex: 0,
+		Amount:     big.NewInt(1),
+		Salt:       new(intMaxTypes.PoseidonHashOut),
+	}
+	rw := &worker.ReceiverWorker{
+		Sender:        sender.IntMaxWalletAddress,
+		Nonce:         1,
+		TransfersHash: hexutil.Encode(keccak256.Hash(transfer.Hash().Marshal())),
+	}
+	assert.NoError(t, w.Receiver(rw))
+	txHash := rw.TxHash.Hash().String()
+
+	// The tx tree is built once the file stops accepting transactions.
+	treeBeforeRestart := txTree(w, txHash)
+	assert.NotNil(t, treeBeforeRestart)
+
+	sf, err := w.TrHash(txHash)
+	assert.NoError(t, err)
+	assert.NoError(t, w.SignTxTreeByAvailableFile("0x01", sf, 0))
+
+	// Kill the worker before the block is posted.
+	stop()
+	assert.Equal(t, int32(0), atomic.LoadInt32(&posted))
+
+	w = worker.New(newConfig(), log, dbApp, worker.WithClock(tickClock{tick: tick}))
+	assert.NoError(t, w.Init())
+
+	_, err = w.TrHash(txHash)
+	assert.NoError(t, err)
+
+	nonce, err := w.NextNonce(rw.Sender)
+	assert.NoError(t, err)
+	assert.Equal(t, rw.Nonce+1, nonce)
+
+	err = w.Receiver(&worker.ReceiverWorker{
+		Sender:        rw.Sender,
+		Nonce:         rw.Nonce,
+		TransfersHash: rw.TransfersHash,
+	})
+	assert.True(t, errors.Is(err, worker.ErrReceiverWorkerDuplicate))
+
+	stop = start(w)
+	defer stop()
+
+	// The restored tx tree has the root signed before the restart.
+	treeAfterRestart := txTree(w, txHash)
+	if assert.NotNil(t, treeBeforeRestart) && assert.NotNil(t, treeAfterRestart) {
+		assert.Equal(t, treeBeforeRestart.RootHash.String(), treeAfterRestart.RootHash.String())
+	}
+
+	// The block is posted with the signature collected before the restart.
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&posted) == 1
+	}, waitFor, tick)
+}
+
+func TestWorkerRestartAfterBlockCommitted(t *testing.T) {
+	const int2Key = 2
+	assert.NoError(t, configs.LoadDotEnv(int2Key))
+
+	const (
+		derivation = "m/44'/60'/0'/0/0"
+		emptyKey   = ""
+		tick       = 50 * time.Millisecond
+		waitFor    = 5 * time.Second
+	)
+
+	builder, err := mnemonic_wallet.New().WalletGenerator(derivation, emptyKey)
+	assert.NoError(t, err)
+
+	path := "./mocks/worker/" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	newConfig := func() *configs.Config {
+		cfg := *configs.New()
+		cfg.Worker.Path = path
+		cfg.Worker.ID = emptyKey
+		cfg.Worker.PathCleanInStart = false
+		cfg.Worker.CurrentFileLifetime = 4 * tick
+		cfg.Worker.TimeoutForSignaturesAvailableFiles = 3 * time.Second
+		cfg.Blockchain.BuilderPrivateKeyHex = builder.PrivateKey
+		return &cfg
+	}
+
+	clock := tickClock{tick: tick}
+	db := simulation.NewDB(clock)
+
+	cfg := newConfig()
+	log := logger.New(cfg.LOG.Level, cfg.LOG.TimeFormat, cfg.LOG.JSON, cfg.LOG.IsLogLine)
+
+	// The worker stops after the block is committed, but before its file is removed.
+	fsys := &keepFilesFS{FS: worker.NewOSFS()}
+	w := worker.New(cfg, log, db, worker.WithClock(clock), worker.WithFS(fsys))
+	assert.NoError(t, w.Init())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, w.Start(ctx))
+	}()
+
+	key, err := intMaxAcc.NewPrivateKeyWithReCalcPubKeyIfPkNegates(big.NewInt(int2Key + 1))
+	assert.NoError(t, err)
+
+	recipient, err := mnemonic_wallet.New().WalletGenerator(derivation, emptyKey)
+	assert.NoError(t, err)
+	recipientAddress, err := intMaxTypes.NewEthereumAddress(recipient.WalletAddress.Bytes())
+	assert.NoError(t, err)
+
+	transfer := intMaxTypes.Transfer{
+		Recipient:  recipientAddress,
+		TokenIndex: 0,
+		Amount:     big.NewInt(1),
+		Salt:       new(intMaxTypes.PoseidonHashOut),
+	}
+	rw := &worker.ReceiverWorker{
+		Sender:        key.ToAddress().String(),
+		Nonce:         1,
+		TransfersHash: hexutil.Encode(keccak256.Hash(transfer.Hash().Marshal())),
+	}
+	assert.NoError(t, w.Receiver(rw))
+	txHash := rw.TxHash.Hash().String()
+
+	var (
+		sf   *worker.TransactionHashesWithSenderAndFile
+		tree *worker.TxTree
+	)
+	assert.Eventually(t, func() bool {
+		sf, err = w.TrHash(txHash)
+		if err != nil {
+			return false
+		}
+		tree, err = w.TxTreeByAvailableFile(sf)
+		return err == nil
+	}, waitFor, tick/int2Key)
+
+	signature, err := signTxTree(key, tree)
+	assert.NoError(t, err)
+	assert.NoError(t, w.SignTxTreeByAvailableFile(signature, sf, tree.LeafIndex))
+
+	assert.Eventually(t, func() bool {
+		return len(db.Blocks()) == 1 && atomic.LoadInt32(&fsys.removed) > 0
+	}, waitFor, tick)
+
+	cancel()
+	<-done
+
+	w = worker.New(newConfig(), log, db, worker.WithClock(clock))
+	assert.NoError(t, w.Init())
+
+	// The transactions of the committed block are not restored to be posted again.
+	_, err = w.TrHash(txHash)
+	assert.Error(t, err)
+	assert.Len(t, db.Blocks(), 1)
+}
+
+// keepFilesFS keeps the removed files on the disk, as if the worker stopped before removing them.
+type keepFilesFS struct {
+	worker.FS
+	removed int32
+}
+
+func (fsys *keepFilesFS) Remove(_ string) error {
+	atomic.AddInt32(&fsys.removed, 1)
+	return nil
+}
+
+func signTxTree(key *intMaxAcc.PrivateKey, tree *worker.TxTree) (string, error) {
+	dummyPublicKey := intMaxAcc.NewDummyPublicKey()
+	senderPublicKeys := make([]byte, intMaxTypes.NumOfSenders*intMaxTypes.NumPublicKeyBytes)
+	for i := 0; i < intMaxTypes.NumOfSenders; i++ {
+		publicKey := dummyPublicKey
+		if i < len(tree.SenderPublicKeys) {
+			publicKey = tree.SenderPublicKeys[i]
+		}
+		x := publicKey.Pk.X.Bytes() // Only x coordinate is used
+		copy(senderPublicKeys[intMaxTypes.NumPublicKeyBytes*i:intMaxTypes.NumPublicKeyBytes*(i+1)], x[:])
+	}
+
+	message := finite_field.BytesToFieldElementSlice(tree.RootHash.Marshal())
+	signature, err := key.WeightByHash(crypto.Keccak256(senderPublicKeys)).Sign(message)
+	if err != nil {
+		return "", err
+	}
+
+	return hexutil.Encode(signature.Marshal()), nil
+}
+
+// tickClock is the system clock with the tickers of the same short period,
+// so the signature collection is checked more often than it times out.
+type tickClock struct {
+	tick time.Duration
+}
+
+func (c tickClock) Now() time.Time {
+	return time.Now()
+}
+
+func (c tickClock) NewTicker(_ time.Duration) worker.Ticker {
+	return &timeTicker{ticker: time.NewTicker(c.tick)}
+}
+
+type timeTicker struct {
+	ticker *time.Ticker
+}
+
+func (t *timeTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+func (t *timeTicker) Stop() {
+	t.ticker.Stop()
+}
@@ -9,6 +9,8 @@ import (
 	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
 	errorsDB "intmax2-node/pkg/sql_db/errors"
 	"math/big"
+	"sync"
+	"time"
 
 	"github.com/holiman/uint256"
 )
@@ -18,6 +20,17 @@ type storage struct {
 	log   logger.Logger
 	dbApp SQLDriverApp
 	sb    ServiceBlockchain
+
+	valuesMu sync.RWMutex
+	values   map[string]*cachedValue
+	quotes   map[string]*cachedValue
+}
+
+// cachedValue describes the value of the gas price oracle read from the database,
+// which is kept until the next update of the values.
+type cachedValue struct {
+	value     *big.Int
+	expiresAt time.Time
 }
 
 func NewStoreGPO(
@@ -27,10 +40,12 @@ func NewStoreGPO(
 	sb ServiceBlockchain,
 ) Storage {
 	return &storage{
-		cfg:   cfg,
-		log:   log,
-		dbApp: dbApp,
-		sb:    sb,
+		cfg:    cfg,
+		log:    log,
+		dbApp:  dbApp,
+		sb:     sb,
+		values: make(map[string]*cachedValue),
+		quotes: make(map[string]*cachedValue),
 	}
 }
 
@@ -52,7 +67,13 @@ func (s *storage) Init(ctx context.Context) (err error) {
 	return nil
 }
 
+// Value returns the value of the gas price oracle. The value is read from the database
+// at most once per GAS_PRICE_ORACLE_TIMEOUT, which is the interval of the updates of the values.
 func (s *storage) Value(ctx context.Context, name string) (*big.Int, error) {
+	if v, ok := s.cachedValue(name); ok {
+		return v, nil
+	}
+
 	var v big.Int
 	err := s.dbApp.Exec(ctx, &v, func(d interface{}, in interface{}) (err error) {
 		q := d.(SQLDriverApp)
@@ -74,10 +95,64 @@ func (s *storage) Value(ctx context.Context, name string) (*big.Int, error) {
 		return nil, err
 	}
 
+	s.cacheValue(name, &v)
+
 	return &v, nil
 }
 
+// QuotedValue returns the lowest value of the gas price oracle quoted within GAS_PRICE_ORACLE_QUOTE_TTL,
+// so the fee calculated by the client from the replaced value is still accepted for a while.
+func (s *storage) QuotedValue(ctx context.Context, name string) (*big.Int, error) {
+	v, err := s.Value(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	s.valuesMu.RLock()
+	defer s.valuesMu.RUnlock()
+
+	q, ok := s.quotes[name]
+	if ok && time.Now().Before(q.expiresAt) && q.value.Cmp(v) < 0 {
+		return new(big.Int).Set(q.value), nil
+	}
+
+	return v, nil
+}
+
+func (s *storage) cachedValue(name string) (*big.Int, bool) {
+	s.valuesMu.RLock()
+	defer s.valuesMu.RUnlock()
+
+	cv, ok := s.values[name]
+	if !ok || time.Now().After(cv.expiresAt) {
+		return nil, false
+	}
+
+	return new(big.Int).Set(cv.value), true
+}
+
+func (s *storage) cacheValue(name string, value *big.Int) {
+	s.valuesMu.Lock()
+	defer s.valuesMu.Unlock()
+
+	now := time.Now()
+	if prev, ok := s.values[name]; ok && prev.value.Cmp(value) != 0 {
+		q, ok := s.quotes[name]
+		if !ok || now.After(q.expiresAt) || prev.value.Cmp(q.value) < 0 {
+			q = &cachedValue{value: prev.value}
+			s.quotes[name] = q
+		}
+		q.expiresAt = now.Add(s.cfg.GasPriceOracle.QuoteTTL)
+	}
+
+	s.values[name] = &cachedValue{
+		value:     new(big.Int).Set(value),
+		expiresAt: now.Add(s.cfg.GasPriceOracle.Timeout),
+	}
+}
+
 func (s *storage) UpdValue(ctx context.Context, name string) (err error) {
+	var gasFee *big.Int
 	err = s.dbApp.Exec(ctx, nil, func(d interface{}, _ interface{}) (err error) {
 		q := d.(SQLDriverApp)
 
@@ -95,7 +170,6 @@ func (s *storage) UpdValue(ctx context.Context, name string) (err error) {
 			return errors.Join(ErrNewGasPriceOracleFail, err)
 		}
 
-		var gasFee *big.Int
 		gasFee, err = oracle.GasFee(ctx)
 		if err != nil {
 			return errors.Join(ErrGasFeeFail, err)
@@ -115,6 +189,11 @@ func (s *storage) UpdValue(ctx context.Context, name string) (err error) {
 		return err
 	}
 
+	// The value is updated by another replica, if this one does not process the gas price oracle.
+	if gasFee != nil {
+		s.cacheValue(name, gasFee)
+	}
+
 	return nil
 }
 
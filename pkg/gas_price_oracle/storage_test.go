@@ -0,0 +1,120 @@
+package gas_price_oracle_test
+
+import (
+	"context"
+	"intmax2-node/configs"
+	"intmax2-node/internal/gas_price_oracle"
+	gpoStorage "intmax2-node/pkg/gas_price_oracle"
+	"intmax2-node/pkg/logger"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	"testing"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStorageValue(t *testing.T) {
+	const timeout = 50 * time.Millisecond
+
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	dbApp := NewMockSQLDriverApp(ctrl)
+	sb := NewMockServiceBlockchain(ctrl)
+
+	cfg := configs.Config{}
+	cfg.GasPriceOracle.Timeout = timeout
+	log := logger.New("error", time.RFC3339, false, false)
+
+	dbApp.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, input interface{}, executor func(d interface{}, input interface{}) error) error {
+			return executor(dbApp, input)
+		},
+	).AnyTimes()
+
+	reads := 0
+	dbApp.EXPECT().GasPriceOracle(gas_price_oracle.ScrollEthGPO).DoAndReturn(
+		func(name string) (*mDBApp.GasPriceOracle, error) {
+			reads++
+			return &mDBApp.GasPriceOracle{GasPriceOracleName: name, Value: uint256.NewInt(uint64(100 * reads))}, nil
+		},
+	).AnyTimes()
+
+	storage := gpoStorage.NewStoreGPO(&cfg, log, dbApp, sb)
+
+	value, err := storage.Value(ctx, gas_price_oracle.ScrollEthGPO)
+	require.NoError(t, err)
+	assert.Equal(t, "100", value.String())
+
+	// The cached value is not changed by the caller.
+	value.SetInt64(1)
+	value, err = storage.Value(ctx, gas_price_oracle.ScrollEthGPO)
+	require.NoError(t, err)
+	assert.Equal(t, "100", value.String())
+	assert.Equal(t, 1, reads)
+
+	// The value is read again after the interval of the updates.
+	time.Sleep(2 * timeout)
+	value, err = storage.Value(ctx, gas_price_oracle.ScrollEthGPO)
+	require.NoError(t, err)
+	assert.Equal(t, "200", value.String())
+	assert.Equal(t, 2, reads)
+}
+
+func TestStorageQuotedValue(t *testing.T) {
+	const (
+		timeout  = 50 * time.Millisecond
+		quoteTTL = 4 * timeout
+	)
+
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	dbApp := NewMockSQLDriverApp(ctrl)
+	sb := NewMockServiceBlockchain(ctrl)
+
+	cfg := configs.Config{}
+	cfg.GasPriceOracle.Timeout = timeout
+	cfg.GasPriceOracle.QuoteTTL = quoteTTL
+	log := logger.New("error", time.RFC3339, false, false)
+
+	dbApp.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, input interface{}, executor func(d interface{}, input interface{}) error) error {
+			return executor(dbApp, input)
+		},
+	).AnyTimes()
+
+	reads := 0
+	dbApp.EXPECT().GasPriceOracle(gas_price_oracle.ScrollEthGPO).DoAndReturn(
+		func(name string) (*mDBApp.GasPriceOracle, error) {
+			reads++
+			return &mDBApp.GasPriceOracle{GasPriceOracleName: name, Value: uint256.NewInt(uint64(100 * reads))}, nil
+		},
+	).AnyTimes()
+
+	storage := gpoStorage.NewStoreGPO(&cfg, log, dbApp, sb)
+
+	value, err := storage.QuotedValue(ctx, gas_price_oracle.ScrollEthGPO)
+	require.NoError(t, err)
+	assert.Equal(t, "100", value.String())
+
+	// The replaced value is still quoted after the update.
+	time.Sleep(2 * timeout)
+	value, err = storage.Value(ctx, gas_price_oracle.ScrollEthGPO)
+	require.NoError(t, err)
+	assert.Equal(t, "200", value.String())
+	value, err = storage.QuotedValue(ctx, gas_price_oracle.ScrollEthGPO)
+	require.NoError(t, err)
+	assert.Equal(t, "100", value.String())
+
+	// The replaced value is not quoted after the lifetime of the quote,
+	// only the value replaced by the last update is.
+	time.Sleep(2 * quoteTTL)
+	value, err = storage.Value(ctx, gas_price_oracle.ScrollEthGPO)
+	require.NoError(t, err)
+	assert.Equal(t, "300", value.String())
+	value, err = storage.QuotedValue(ctx, gas_price_oracle.ScrollEthGPO)
+	require.NoError(t, err)
+	assert.Equal(t, "200", value.String())
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"intmax2-node/internal/open_telemetry"
+	"intmax2-node/internal/store_vault_auth"
+	"intmax2-node/pkg/grpc_server/utils"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// authorizeOperator checks that the Authorization header carries the operator token of the block builder.
+// It returns the Unauthorized error to be returned by the handler.
+func (s *Server) authorizeOperator(ctx context.Context) error {
+	const authorizationKey = "authorization"
+
+	if s.config.BlockBuilderOperator.Token == "" {
+		err := ErrOperatorTokenNotConfigured
+		open_telemetry.MarkSpanError(ctx, err)
+		return utils.Unauthorized(ctx, err)
+	}
+
+	var authorization string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(authorizationKey); len(v) > 0 {
+			authorization = v[0]
+		}
+	}
+
+	token, err := store_vault_auth.BearerToken(authorization)
+	if err != nil {
+		open_telemetry.MarkSpanError(ctx, err)
+		return utils.Unauthorized(ctx, err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.config.BlockBuilderOperator.Token)) != 1 {
+		err = ErrOperatorTokenInvalid
+		open_telemetry.MarkSpanError(ctx, err)
+		return utils.Unauthorized(ctx, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,7 @@
+package server
+
+//go:generate mockgen -destination=mock_block_builder_registry_service_test.go -package=server_test -source=block_builder_registry_service.go
+
+type BlockBuilderRegistryService interface {
+	Active() error
+}
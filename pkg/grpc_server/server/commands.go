@@ -3,19 +3,27 @@ package server
 import (
 	"intmax2-node/configs"
 	"intmax2-node/internal/logger"
+	blockFees "intmax2-node/internal/use_cases/block_fees"
 	blockInfo "intmax2-node/internal/use_cases/block_info"
+	blockMerkleProof "intmax2-node/internal/use_cases/block_merkle_proof"
 	blockProposed "intmax2-node/internal/use_cases/block_proposed"
 	blockSignature "intmax2-node/internal/use_cases/block_signature"
 	blockStatus "intmax2-node/internal/use_cases/block_status"
+	blockStatusSubscribe "intmax2-node/internal/use_cases/block_status_subscribe"
 	getVersion "intmax2-node/internal/use_cases/get_version"
 	healthCheck "intmax2-node/internal/use_cases/health_check"
+	senderNonce "intmax2-node/internal/use_cases/sender_nonce"
 	"intmax2-node/internal/use_cases/transaction"
+	ucBlockFees "intmax2-node/pkg/use_cases/block_fees"
 	ucBlockInfo "intmax2-node/pkg/use_cases/block_info"
+	ucBlockMerkleProof "intmax2-node/pkg/use_cases/block_merkle_proof"
 	ucBlockProposed "intmax2-node/pkg/use_cases/block_proposed"
 	ucBlockSignature "intmax2-node/pkg/use_cases/block_signature"
 	ucBlockStatus "intmax2-node/pkg/use_cases/block_status"
+	ucBlockStatusSubscribe "intmax2-node/pkg/use_cases/block_status_subscribe"
 	ucGetVersion "intmax2-node/pkg/use_cases/get_version"
 	ucHealthCheck "intmax2-node/pkg/use_cases/health_check"
+	ucSenderNonce "intmax2-node/pkg/use_cases/sender_nonce"
 	ucTransaction "intmax2-node/pkg/use_cases/transaction"
 
 	"github.com/dimiro1/health"
@@ -40,6 +48,7 @@ type Commands interface {
 	BlockInfo(
 		cfg *configs.Config,
 		storageGPO GPOStorage,
+		powDifficulty PoWDifficulty,
 	) blockInfo.UseCaseBlockInfo
 	BlockStatusByTxTreeRoot(
 		cfg *configs.Config,
@@ -47,6 +56,27 @@ type Commands interface {
 		db SQLDriverApp,
 		worker Worker,
 	) blockStatus.UseCaseBlockStatus
+	BlockStatusSubscribe(
+		cfg *configs.Config,
+		log logger.Logger,
+		db SQLDriverApp,
+		worker Worker,
+	) blockStatusSubscribe.UseCaseBlockStatusSubscribe
+	BlockFeesByTxTreeRoot(
+		cfg *configs.Config,
+		log logger.Logger,
+		db SQLDriverApp,
+	) blockFees.UseCaseBlockFees
+	SenderNonce(
+		cfg *configs.Config,
+		log logger.Logger,
+		worker Worker,
+	) senderNonce.UseCaseSenderNonce
+	BlockMerkleProof(
+		cfg *configs.Config,
+		log logger.Logger,
+		bvp BlockValidityProver,
+	) blockMerkleProof.UseCaseBlockMerkleProof
 }
 
 type commands struct{}
@@ -86,8 +116,9 @@ func (c *commands) BlockSignature(
 func (c *commands) BlockInfo(
 	cfg *configs.Config,
 	storageGPO GPOStorage,
+	powDifficulty PoWDifficulty,
 ) blockInfo.UseCaseBlockInfo {
-	return ucBlockInfo.New(cfg, storageGPO)
+	return ucBlockInfo.New(cfg, storageGPO, powDifficulty)
 }
 
 func (c *commands) BlockStatusByTxTreeRoot(
@@ -98,3 +129,36 @@ func (c *commands) BlockStatusByTxTreeRoot(
 ) blockStatus.UseCaseBlockStatus {
 	return ucBlockStatus.New(cfg, log, db, worker)
 }
+
+func (c *commands) BlockStatusSubscribe(
+	cfg *configs.Config,
+	log logger.Logger,
+	db SQLDriverApp,
+	worker Worker,
+) blockStatusSubscribe.UseCaseBlockStatusSubscribe {
+	return ucBlockStatusSubscribe.New(cfg, log, db, worker)
+}
+
+func (c *commands) BlockFeesByTxTreeRoot(
+	cfg *configs.Config,
+	log logger.Logger,
+	db SQLDriverApp,
+) blockFees.UseCaseBlockFees {
+	return ucBlockFees.New(cfg, log, db)
+}
+
+func (c *commands) SenderNonce(
+	cfg *configs.Config,
+	log logger.Logger,
+	worker Worker,
+) senderNonce.UseCaseSenderNonce {
+	return ucSenderNonce.New(cfg, log, worker)
+}
+
+func (c *commands) BlockMerkleProof(
+	cfg *configs.Config,
+	log logger.Logger,
+	bvp BlockValidityProver,
+) blockMerkleProof.UseCaseBlockMerkleProof {
+	return ucBlockMerkleProof.New(cfg, log, bvp)
+}
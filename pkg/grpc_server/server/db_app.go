@@ -10,6 +10,8 @@ import (
 type SQLDriverApp interface {
 	GenericCommandsApp
 	Blocks
+	BlockFees
+	TxMerkleProofs
 }
 
 type GenericCommandsApp interface {
@@ -19,3 +21,11 @@ type GenericCommandsApp interface {
 type Blocks interface {
 	BlockByTxRoot(txRoot string) (*mDBApp.Block, error)
 }
+
+type BlockFees interface {
+	BlockFeesByTxRoot(txRoot string) ([]*mDBApp.BlockFee, error)
+}
+
+type TxMerkleProofs interface {
+	TxMerkleProofsByTxHash(txHash string) (*mDBApp.TxMerkleProofs, error)
+}
@@ -0,0 +1,9 @@
+package server
+
+import "errors"
+
+// ErrOperatorTokenNotConfigured error: the operator endpoints are disabled without the operator token.
+var ErrOperatorTokenNotConfigured = errors.New("the operator endpoints are disabled without the operator token")
+
+// ErrOperatorTokenInvalid error: the operator token is invalid.
+var ErrOperatorTokenInvalid = errors.New("the operator token is invalid")
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"intmax2-node/internal/open_telemetry"
+	node "intmax2-node/internal/pb/gen/block_builder_service/node"
+	"intmax2-node/internal/use_cases/block_fees"
+	"intmax2-node/pkg/grpc_server/utils"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func (s *Server) BlockFeesByTxTreeRoot(
+	ctx context.Context,
+	req *node.BlockFeesByTxTreeRootRequest,
+) (*node.BlockFeesByTxTreeRootResponse, error) {
+	resp := node.BlockFeesByTxTreeRootResponse{}
+
+	const (
+		hName      = "Handler BlockFeesByTxTreeRoot"
+		requestKey = "request"
+	)
+
+	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName,
+		trace.WithAttributes(
+			attribute.String(requestKey, req.String()),
+		))
+	defer span.End()
+
+	if err := s.authorizeOperator(spanCtx); err != nil {
+		return &resp, err
+	}
+
+	input := block_fees.UCBlockFeesInput{
+		TxTreeRoot: req.TxTreeRoot,
+	}
+
+	err := input.Valid()
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return &resp, utils.BadRequest(spanCtx, err)
+	}
+
+	fees, err := s.commands.BlockFeesByTxTreeRoot(s.config, s.log, s.dbApp).Do(spanCtx, &input)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		const msg = "failed to get the block fees: %v"
+		return &resp, utils.Internal(spanCtx, s.log, msg, err)
+	}
+
+	resp.Success = true
+	resp.Data = &node.DataBlockFeesByTxTreeRootResponse{
+		TxTreeRoot: fees.TxTreeRoot,
+		Fees:       make([]*node.BlockFeeBlockFeesByTxTreeRootResponse, len(fees.Fees)),
+		Totals:     make([]*node.TotalFeeBlockFeesByTxTreeRootResponse, len(fees.Totals)),
+	}
+	for key := range fees.Fees {
+		resp.Data.Fees[key] = &node.BlockFeeBlockFeesByTxTreeRootResponse{
+			TxHash:       fees.Fees[key].TxHash,
+			Sender:       fees.Fees[key].Sender,
+			TokenIndex:   fees.Fees[key].TokenIndex,
+			Amount:       fees.Fees[key].Amount,
+			TransferHash: fees.Fees[key].TransferHash,
+		}
+	}
+	for key := range fees.Totals {
+		resp.Data.Totals[key] = &node.TotalFeeBlockFeesByTxTreeRootResponse{
+			TokenIndex: fees.Totals[key].TokenIndex,
+			Amount:     fees.Totals[key].Amount,
+		}
+	}
+
+	return &resp, utils.OK(spanCtx)
+}
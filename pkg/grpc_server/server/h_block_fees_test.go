@@ -0,0 +1,153 @@
+package server_test
+
+import (
+	"context"
+	"intmax2-node/configs"
+	"intmax2-node/internal/store_vault_auth"
+	"intmax2-node/internal/use_cases/block_fees"
+	"intmax2-node/internal/use_cases/mocks"
+	"intmax2-node/pkg/grpc_server/server"
+	"intmax2-node/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/dimiro1/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandlerBlockFeesByTxTreeRoot(t *testing.T) {
+	const int3Key = 3
+	assert.NoError(t, configs.LoadDotEnv(int3Key))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := configs.New()
+	log := logger.New(cfg.LOG.Level, cfg.LOG.TimeFormat, cfg.LOG.JSON, cfg.LOG.IsLogLine)
+
+	pw := NewMockPoWNonce(ctrl)
+	pwDifficulty := NewMockPoWDifficulty(ctrl)
+	dbApp := NewMockSQLDriverApp(ctrl)
+	worker := NewMockWorker(ctrl)
+	hc := health.NewHandler()
+	sb := NewMockServiceBlockchain(ctrl)
+	storageGPO := NewMockGPOStorage(ctrl)
+	bbr := NewMockBlockBuilderRegistryService(ctrl)
+	bvp := NewMockBlockValidityProver(ctrl)
+
+	const (
+		path1 = "../../../"
+		path2 = "./"
+	)
+
+	dir := path1
+	if _, err := os.ReadFile(dir + cfg.APP.PEMPathCACert); err != nil {
+		dir = path2
+	}
+	cfg.APP.PEMPathCACert = dir + cfg.APP.PEMPathCACert
+	cfg.APP.PEMPathServCert = dir + cfg.APP.PEMPathServCert
+	cfg.APP.PEMPathServKey = dir + cfg.APP.PEMPathServKey
+	cfg.APP.PEMPAthCACertClient = dir + cfg.APP.PEMPAthCACertClient
+	cfg.APP.PEMPathClientCert = dir + cfg.APP.PEMPathClientCert
+	cfg.APP.PEMPathClientKey = dir + cfg.APP.PEMPathClientKey
+
+	const operatorToken = "operator-token"
+	prevToken := cfg.BlockBuilderOperator.Token
+	cfg.BlockBuilderOperator.Token = operatorToken
+	defer func() { cfg.BlockBuilderOperator.Token = prevToken }()
+
+	cmd := NewMockCommands(ctrl)
+
+	grpcServerStop, gwServer := Start(cmd, ctx, cfg, log, dbApp, &hc, pw, pwDifficulty, worker, sb, storageGPO, bbr, bvp)
+	defer grpcServerStop()
+
+	ucBF := mocks.NewMockUseCaseBlockFees(ctrl)
+
+	const (
+		txTreeRoot = "0x0d8a7a2d7f5d4d4d7c8d7c0bfc8a3c4d6b4f1f3e0d2c1b0a9f8e7d6c5b4a3928"
+		amount     = "100"
+	)
+
+	cases := []struct {
+		desc          string
+		authorization string
+		token         string
+		prepare       func()
+		success       bool
+		message       string
+		wantStatus    int
+	}{
+		{
+			desc:       "No authorization",
+			token:      operatorToken,
+			message:    store_vault_auth.ErrAuthorizationRequired.Error(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			desc:          "Invalid token",
+			authorization: "Bearer invalid",
+			token:         operatorToken,
+			message:       server.ErrOperatorTokenInvalid.Error(),
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			desc:          "Operator token is not configured",
+			authorization: "Bearer " + operatorToken,
+			message:       server.ErrOperatorTokenNotConfigured.Error(),
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			desc:          "Success",
+			authorization: "Bearer " + operatorToken,
+			token:         operatorToken,
+			prepare: func() {
+				cmd.EXPECT().BlockFeesByTxTreeRoot(gomock.Any(), gomock.Any(), gomock.Any()).Return(ucBF)
+				ucBF.EXPECT().Do(gomock.Any(), gomock.Any()).Return(&block_fees.UCBlockFees{
+					TxTreeRoot: txTreeRoot,
+					Fees:       []*block_fees.UCBlockFee{},
+					Totals: []*block_fees.UCBlockFeeTotal{
+						{TokenIndex: 0, Amount: amount},
+					},
+				}, nil)
+			},
+			success:    true,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for i := range cases {
+		t.Run(cases[i].desc, func(t *testing.T) {
+			if cases[i].prepare != nil {
+				cases[i].prepare()
+			}
+			cfg.BlockBuilderOperator.Token = cases[i].token
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(
+				http.MethodGet, "http://"+gwServer.Addr+"/v1/block/fees/"+txTreeRoot, http.NoBody,
+			)
+			if cases[i].authorization != "" {
+				r.Header.Set("Authorization", cases[i].authorization)
+			}
+
+			gwServer.Handler.ServeHTTP(w, r)
+
+			if !assert.Equal(t, cases[i].wantStatus, w.Code) {
+				t.Log(w.Body.String())
+			}
+
+			assert.Equal(t, cases[i].message, gjson.Get(w.Body.String(), "message").String())
+			assert.Equal(t, cases[i].success, gjson.Get(w.Body.String(), "success").Bool())
+			if cases[i].success {
+				assert.Equal(t, amount, gjson.Get(w.Body.String(), "data.totals.0.amount").String())
+			}
+		})
+	}
+}
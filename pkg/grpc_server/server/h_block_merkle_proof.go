@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"intmax2-node/internal/block_validity_prover"
+	"intmax2-node/internal/open_telemetry"
+	node "intmax2-node/internal/pb/gen/block_builder_service/node"
+	"intmax2-node/internal/use_cases/block_merkle_proof"
+	"intmax2-node/pkg/grpc_server/utils"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func (s *Server) BlockMerkleProof(
+	ctx context.Context,
+	req *node.BlockMerkleProofRequest,
+) (*node.BlockMerkleProofResponse, error) {
+	resp := node.BlockMerkleProofResponse{}
+
+	const (
+		hName      = "Handler BlockMerkleProof"
+		requestKey = "request"
+	)
+
+	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName,
+		trace.WithAttributes(
+			attribute.String(requestKey, req.String()),
+		))
+	defer span.End()
+
+	input := block_merkle_proof.UCBlockMerkleProofInput{
+		BlockNumber:     req.BlockNumber,
+		RootBlockNumber: req.RootBlockNumber,
+	}
+
+	err := input.Valid()
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return &resp, utils.BadRequest(spanCtx, err)
+	}
+
+	proof, err := s.commands.BlockMerkleProof(s.config, s.log, s.bvp).Do(spanCtx, &input)
+	if err != nil {
+		if errors.Is(err, block_validity_prover.ErrBlockNumberOutOfRange) {
+			return &resp, utils.NotFound(spanCtx, fmt.Errorf("%s", block_merkle_proof.NotFoundMessage))
+		}
+
+		open_telemetry.MarkSpanError(spanCtx, err)
+		const msg = "failed to get the block Merkle proof: %v"
+		return &resp, utils.Internal(spanCtx, s.log, msg, err)
+	}
+
+	resp.Success = true
+	resp.Data = &node.DataBlockMerkleProofResponse{
+		BlockNumber:     proof.BlockNumber,
+		BlockHash:       proof.BlockHash,
+		RootBlockNumber: proof.RootBlockNumber,
+		Siblings:        proof.Siblings,
+		Root:            proof.Root,
+	}
+
+	return &resp, utils.OK(spanCtx)
+}
@@ -0,0 +1,153 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"intmax2-node/configs"
+	"intmax2-node/internal/block_validity_prover"
+	"intmax2-node/internal/use_cases/block_merkle_proof"
+	"intmax2-node/internal/use_cases/mocks"
+	"intmax2-node/pkg/logger"
+	ucBlockMerkleProof "intmax2-node/pkg/use_cases/block_merkle_proof"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/dimiro1/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandlerBlockMerkleProof(t *testing.T) {
+	const int3Key = 3
+	assert.NoError(t, configs.LoadDotEnv(int3Key))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := configs.New()
+	log := logger.New(cfg.LOG.Level, cfg.LOG.TimeFormat, cfg.LOG.JSON, cfg.LOG.IsLogLine)
+
+	pw := NewMockPoWNonce(ctrl)
+	pwDifficulty := NewMockPoWDifficulty(ctrl)
+	dbApp := NewMockSQLDriverApp(ctrl)
+	worker := NewMockWorker(ctrl)
+	hc := health.NewHandler()
+	sb := NewMockServiceBlockchain(ctrl)
+	storageGPO := NewMockGPOStorage(ctrl)
+	bbr := NewMockBlockBuilderRegistryService(ctrl)
+	bvp := NewMockBlockValidityProver(ctrl)
+
+	const (
+		path1 = "../../../"
+		path2 = "./"
+	)
+
+	dir := path1
+	if _, err := os.ReadFile(dir + cfg.APP.PEMPathCACert); err != nil {
+		dir = path2
+	}
+	cfg.APP.PEMPathCACert = dir + cfg.APP.PEMPathCACert
+	cfg.APP.PEMPathServCert = dir + cfg.APP.PEMPathServCert
+	cfg.APP.PEMPathServKey = dir + cfg.APP.PEMPathServKey
+	cfg.APP.PEMPAthCACertClient = dir + cfg.APP.PEMPAthCACertClient
+	cfg.APP.PEMPathClientCert = dir + cfg.APP.PEMPathClientCert
+	cfg.APP.PEMPathClientKey = dir + cfg.APP.PEMPathClientKey
+
+	cmd := NewMockCommands(ctrl)
+
+	grpcServerStop, gwServer := Start(cmd, ctx, cfg, log, dbApp, &hc, pw, pwDifficulty, worker, sb, storageGPO, bbr, bvp)
+	defer grpcServerStop()
+
+	ucBMP := mocks.NewMockUseCaseBlockMerkleProof(ctrl)
+
+	const (
+		blockHashKey = "0x9a4fa1e69bd8b9a7e3e3ab2a9ff8ab2e1b2bde1a7b4b2f6d7b5e5fe40b5f1b0a"
+		rootKey      = "0x1b8b6b31b0a7f66c2ad7df9c6f6f3e1c5ad0c1b1d94fd1d2b0f2ea3b1bfd3e10"
+	)
+
+	cases := []struct {
+		desc       string
+		query      string
+		prepare    func()
+		success    bool
+		message    string
+		blockHash  string
+		wantStatus int
+	}{
+		{
+			desc:       "Root block number less than block number",
+			query:      "/v1/block/3/merkle-proof?rootBlockNumber=2",
+			message:    "rootBlockNumber: must be a valid value.",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			desc:  "Root block not posted",
+			query: "/v1/block/3/merkle-proof?rootBlockNumber=10",
+			prepare: func() {
+				cmd.EXPECT().BlockMerkleProof(gomock.Any(), gomock.Any(), gomock.Any()).Return(ucBMP)
+				ucBMP.EXPECT().Do(gomock.Any(), gomock.Any()).Return(
+					nil, errors.Join(ucBlockMerkleProof.ErrGetBlockMerkleProofFail, block_validity_prover.ErrBlockNumberOutOfRange),
+				)
+			},
+			message:    block_merkle_proof.NotFoundMessage,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			desc:  "Internal server error",
+			query: "/v1/block/3/merkle-proof?rootBlockNumber=5",
+			prepare: func() {
+				cmd.EXPECT().BlockMerkleProof(gomock.Any(), gomock.Any(), gomock.Any()).Return(ucBMP)
+				ucBMP.EXPECT().Do(gomock.Any(), gomock.Any()).Return(nil, ucBlockMerkleProof.ErrGetBlockMerkleProofFail)
+			},
+			message:    "Internal server error",
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			desc:  "Success",
+			query: "/v1/block/3/merkle-proof?rootBlockNumber=5",
+			prepare: func() {
+				cmd.EXPECT().BlockMerkleProof(gomock.Any(), gomock.Any(), gomock.Any()).Return(ucBMP)
+				ucBMP.EXPECT().Do(gomock.Any(), &block_merkle_proof.UCBlockMerkleProofInput{
+					BlockNumber:     3,
+					RootBlockNumber: 5,
+				}).Return(&block_merkle_proof.UCBlockMerkleProof{
+					BlockNumber:     3,
+					BlockHash:       blockHashKey,
+					RootBlockNumber: 5,
+					Siblings:        []string{rootKey},
+					Root:            rootKey,
+				}, nil)
+			},
+			success:    true,
+			blockHash:  blockHashKey,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for i := range cases {
+		t.Run(cases[i].desc, func(t *testing.T) {
+			if cases[i].prepare != nil {
+				cases[i].prepare()
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "http://"+gwServer.Addr+cases[i].query, http.NoBody)
+
+			gwServer.Handler.ServeHTTP(w, r)
+
+			if !assert.Equal(t, cases[i].wantStatus, w.Code) {
+				t.Log(w.Body.String())
+			}
+
+			assert.Equal(t, cases[i].message, gjson.Get(w.Body.String(), "message").String())
+			assert.Equal(t, cases[i].success, gjson.Get(w.Body.String(), "success").Bool())
+			assert.Equal(t, cases[i].blockHash, gjson.Get(w.Body.String(), "data.blockHash").String())
+		})
+	}
+}
@@ -47,6 +47,8 @@ func TestHandlerBlockSignature(t *testing.T) {
 	hc := health.NewHandler()
 	sb := NewMockServiceBlockchain(ctrl)
 	storageGPO := NewMockGPOStorage(ctrl)
+	bbr := NewMockBlockBuilderRegistryService(ctrl)
+	bvp := NewMockBlockValidityProver(ctrl)
 
 	pw := pow.New(cfg.PoW.Difficulty)
 	pWorker := pow.NewWorker(cfg.PoW.Workers, pw)
@@ -77,7 +79,7 @@ func TestHandlerBlockSignature(t *testing.T) {
 	cmd := NewMockCommands(ctrl)
 	//ucBS := mocks.NewMockUseCaseBlockSignature(ctrl)
 
-	grpcServerStop, gwServer := Start(cmd, ctx, cfg, log, dbApp, &hc, pwNonce, wrk, sb, storageGPO)
+	grpcServerStop, gwServer := Start(cmd, ctx, cfg, log, dbApp, &hc, pwNonce, pow.NewDifficultyAdjuster(cfg, nil), wrk, sb, storageGPO, bbr, bvp)
 	defer grpcServerStop()
 
 	cases := []struct {
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"intmax2-node/internal/open_telemetry"
+	node "intmax2-node/internal/pb/gen/block_builder_service/node"
+	"intmax2-node/internal/use_cases/block_status_subscribe"
+	"intmax2-node/pkg/grpc_server/utils"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) BlockStatusSubscribe(
+	req *node.BlockStatusSubscribeRequest,
+	stream node.BlockBuilderService_BlockStatusSubscribeServer,
+) error {
+	const (
+		hName      = "Handler BlockStatusSubscribe"
+		requestKey = "request"
+	)
+
+	spanCtx, span := open_telemetry.Tracer().Start(stream.Context(), hName,
+		trace.WithAttributes(
+			attribute.String(requestKey, req.String()),
+		))
+	defer span.End()
+
+	input := block_status_subscribe.UCBlockStatusSubscribeInput{
+		TxHash:     req.TxHash,
+		TxTreeRoot: req.TxTreeRoot,
+	}
+
+	err := input.Valid()
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return utils.BadRequest(spanCtx, err)
+	}
+
+	err = s.commands.BlockStatusSubscribe(s.config, s.log, s.dbApp, s.worker).Do(
+		spanCtx, &input,
+		func(event *block_status_subscribe.UCBlockStatusEvent) error {
+			return stream.Send(&node.BlockStatusSubscribeResponse{
+				Event:       event.Event,
+				TxHash:      event.TxHash,
+				TxTreeRoot:  event.TxTreeRoot,
+				BlockNumber: event.BlockNumber,
+				BlockHash:   event.BlockHash,
+				Reason:      event.Reason,
+				CreatedAt:   timestamppb.New(event.CreatedAt),
+			})
+		},
+	)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		const msg = "failed to subscribe to the block status: %v"
+		return utils.Internal(spanCtx, s.log, msg, err)
+	}
+
+	return utils.OK(spanCtx)
+}
@@ -31,10 +31,13 @@ func TestHandlerHealthCheck(t *testing.T) {
 	log := logger.New(cfg.LOG.Level, cfg.LOG.TimeFormat, cfg.LOG.JSON, cfg.LOG.IsLogLine)
 
 	pw := NewMockPoWNonce(ctrl)
+	pwDifficulty := NewMockPoWDifficulty(ctrl)
 	dbApp := NewMockSQLDriverApp(ctrl)
 	worker := NewMockWorker(ctrl)
 	sb := NewMockServiceBlockchain(ctrl)
 	storageGPO := NewMockGPOStorage(ctrl)
+	bbr := NewMockBlockBuilderRegistryService(ctrl)
+	bvp := NewMockBlockValidityProver(ctrl)
 
 	hc := health.NewHandler()
 	hcTestImpl := newHcTest()
@@ -59,7 +62,7 @@ func TestHandlerHealthCheck(t *testing.T) {
 
 	cmd := NewMockCommands(ctrl)
 
-	grpcServerStop, gwServer := Start(cmd, ctx, cfg, log, dbApp, &hc, pw, worker, sb, storageGPO)
+	grpcServerStop, gwServer := Start(cmd, ctx, cfg, log, dbApp, &hc, pw, pwDifficulty, worker, sb, storageGPO, bbr, bvp)
 	defer grpcServerStop()
 
 	uc := mocks.NewMockUseCaseHealthCheck(ctrl)
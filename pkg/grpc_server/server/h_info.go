@@ -5,6 +5,8 @@ import (
 	"intmax2-node/internal/open_telemetry"
 	node "intmax2-node/internal/pb/gen/block_builder_service/node"
 	"intmax2-node/pkg/grpc_server/utils"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *Server) Info(
@@ -20,7 +22,7 @@ func (s *Server) Info(
 	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName)
 	defer span.End()
 
-	info, err := s.commands.BlockInfo(s.config, s.storageGPO).Do(spanCtx)
+	info, err := s.commands.BlockInfo(s.config, s.storageGPO, s.powDifficulty).Do(spanCtx)
 	if err != nil {
 		open_telemetry.MarkSpanError(spanCtx, err)
 		const msg = "failed to get block info: %v"
@@ -28,10 +30,11 @@ func (s *Server) Info(
 	}
 
 	resp.Data = &node.DataInfoResponse{
-		TransferFee:   info.TransferFee,
-		Difficulty:    uint32(info.Difficulty),
-		ScrollAddress: info.ScrollAddress,
-		IntMaxAddress: info.IntMaxAddress,
+		TransferFee:          info.TransferFee,
+		Difficulty:           uint32(info.Difficulty),
+		DifficultyValidUntil: timestamppb.New(info.DifficultyValidUntil),
+		ScrollAddress:        info.ScrollAddress,
+		IntMaxAddress:        info.IntMaxAddress,
 	}
 
 	resp.Success = true
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"intmax2-node/internal/open_telemetry"
+	node "intmax2-node/internal/pb/gen/block_builder_service/node"
+	"intmax2-node/internal/use_cases/sender_nonce"
+	"intmax2-node/pkg/grpc_server/utils"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func (s *Server) SenderNonce(
+	ctx context.Context,
+	req *node.SenderNonceRequest,
+) (*node.SenderNonceResponse, error) {
+	resp := node.SenderNonceResponse{}
+
+	const (
+		hName      = "Handler SenderNonce"
+		requestKey = "request"
+	)
+
+	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName,
+		trace.WithAttributes(
+			attribute.String(requestKey, req.String()),
+		))
+	defer span.End()
+
+	input := sender_nonce.UCSenderNonceInput{
+		Sender: req.Sender,
+	}
+
+	err := input.Valid()
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return &resp, utils.BadRequest(spanCtx, err)
+	}
+
+	info, err := s.commands.SenderNonce(s.config, s.log, s.worker).Do(spanCtx, &input)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		const msg = "failed to get the next nonce of sender: %v"
+		return &resp, utils.Internal(spanCtx, s.log, msg, err)
+	}
+
+	resp.Success = true
+	resp.Data = &node.DataSenderNonceResponse{
+		Sender: info.Sender,
+		Nonce:  info.Nonce,
+	}
+
+	return &resp, utils.OK(spanCtx)
+}
@@ -0,0 +1,136 @@
+package server_test
+
+import (
+	"context"
+	"intmax2-node/configs"
+	"intmax2-node/internal/use_cases/mocks"
+	"intmax2-node/internal/use_cases/sender_nonce"
+	"intmax2-node/pkg/logger"
+	ucSenderNonce "intmax2-node/pkg/use_cases/sender_nonce"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/dimiro1/health"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandlerSenderNonce(t *testing.T) {
+	const int3Key = 3
+	assert.NoError(t, configs.LoadDotEnv(int3Key))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := configs.New()
+	log := logger.New(cfg.LOG.Level, cfg.LOG.TimeFormat, cfg.LOG.JSON, cfg.LOG.IsLogLine)
+
+	pw := NewMockPoWNonce(ctrl)
+	pwDifficulty := NewMockPoWDifficulty(ctrl)
+	dbApp := NewMockSQLDriverApp(ctrl)
+	worker := NewMockWorker(ctrl)
+	hc := health.NewHandler()
+	sb := NewMockServiceBlockchain(ctrl)
+	storageGPO := NewMockGPOStorage(ctrl)
+	bbr := NewMockBlockBuilderRegistryService(ctrl)
+	bvp := NewMockBlockValidityProver(ctrl)
+
+	const (
+		path1 = "../../../"
+		path2 = "./"
+	)
+
+	dir := path1
+	if _, err := os.ReadFile(dir + cfg.APP.PEMPathCACert); err != nil {
+		dir = path2
+	}
+	cfg.APP.PEMPathCACert = dir + cfg.APP.PEMPathCACert
+	cfg.APP.PEMPathServCert = dir + cfg.APP.PEMPathServCert
+	cfg.APP.PEMPathServKey = dir + cfg.APP.PEMPathServKey
+	cfg.APP.PEMPAthCACertClient = dir + cfg.APP.PEMPAthCACertClient
+	cfg.APP.PEMPathClientCert = dir + cfg.APP.PEMPathClientCert
+	cfg.APP.PEMPathClientKey = dir + cfg.APP.PEMPathClientKey
+
+	cmd := NewMockCommands(ctrl)
+
+	grpcServerStop, gwServer := Start(cmd, ctx, cfg, log, dbApp, &hc, pw, pwDifficulty, worker, sb, storageGPO, bbr, bvp)
+	defer grpcServerStop()
+
+	ucSN := mocks.NewMockUseCaseSenderNonce(ctrl)
+
+	const (
+		intMaxAddressKey = "0x2a0a9871a59d52c3d52f57d0ab4324662f39ce14bd2e7a9e2f4c01212b6bea84"
+		nonce            = 5
+	)
+
+	cases := []struct {
+		desc       string
+		sender     string
+		prepare    func()
+		success    bool
+		message    string
+		nonce      uint64
+		wantStatus int
+	}{
+		{
+			desc:       "Invalid sender",
+			sender:     uuid.New().String(),
+			message:    "sender: must be a valid value.",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			desc:   "Internal server error",
+			sender: intMaxAddressKey,
+			prepare: func() {
+				cmd.EXPECT().SenderNonce(gomock.Any(), gomock.Any(), gomock.Any()).Return(ucSN)
+				ucSN.EXPECT().Do(gomock.Any(), gomock.Any()).Return(nil, ucSenderNonce.ErrNextNonceFail)
+			},
+			message:    "Internal server error",
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			desc:   "Success",
+			sender: intMaxAddressKey,
+			prepare: func() {
+				cmd.EXPECT().SenderNonce(gomock.Any(), gomock.Any(), gomock.Any()).Return(ucSN)
+				ucSN.EXPECT().Do(gomock.Any(), gomock.Any()).Return(&sender_nonce.UCSenderNonce{
+					Sender: intMaxAddressKey,
+					Nonce:  nonce,
+				}, nil)
+			},
+			success:    true,
+			nonce:      nonce,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for i := range cases {
+		t.Run(cases[i].desc, func(t *testing.T) {
+			if cases[i].prepare != nil {
+				cases[i].prepare()
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(
+				http.MethodGet, "http://"+gwServer.Addr+"/v1/sender/"+cases[i].sender+"/nonce", http.NoBody,
+			)
+
+			gwServer.Handler.ServeHTTP(w, r)
+
+			if !assert.Equal(t, cases[i].wantStatus, w.Code) {
+				t.Log(w.Body.String())
+			}
+
+			assert.Equal(t, cases[i].message, gjson.Get(w.Body.String(), "message").String())
+			assert.Equal(t, cases[i].success, gjson.Get(w.Body.String(), "success").Bool())
+			assert.Equal(t, cases[i].nonce, gjson.Get(w.Body.String(), "data.nonce").Uint())
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"intmax2-node/internal/open_telemetry"
 	node "intmax2-node/internal/pb/gen/block_builder_service/node"
+	"intmax2-node/internal/rate_limiter"
 	"intmax2-node/internal/use_cases/transaction"
 	"intmax2-node/internal/worker"
 	"intmax2-node/pkg/grpc_server/utils"
@@ -30,6 +31,14 @@ func (s *Server) Transaction(
 	))
 	defer span.End()
 
+	// The transactions are not accepted while the block builder is stopped
+	// or under-staked in the block builder registry contract.
+	err := s.bbr.Active()
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return &resp, utils.ServiceUnavailable(spanCtx, err)
+	}
+
 	input := transaction.UCTransactionInput{
 		Sender:        req.Sender,
 		TransfersHash: req.TransfersHash,
@@ -39,6 +48,25 @@ func (s *Server) Transaction(
 		Signature:     req.Signature,
 	}
 
+	input.PowDifficulty = s.powDifficulty.Accepted()
+	if load, ok := rate_limiter.SenderLoad(ctx); ok {
+		input.PowDifficulty = rate_limiter.ScalePoWDifficulty(
+			input.PowDifficulty, s.config.Throttle.PoWMaxMultiplier, load,
+		)
+	}
+	utils.PoWDifficulty(spanCtx, input.PowDifficulty)
+
+	if req.FeeTransfer != nil {
+		input.FeeTransfer = &transaction.FeeTransferTransaction{
+			Recipient:     req.FeeTransfer.Recipient,
+			TokenIndex:    req.FeeTransfer.TokenIndex,
+			Amount:        req.FeeTransfer.Amount,
+			Salt:          req.FeeTransfer.Salt,
+			TransferIndex: req.FeeTransfer.TransferIndex,
+			MerkleProof:   req.FeeTransfer.MerkleProof,
+		}
+	}
+
 	/**
 	// NOTE: `TransferData` does not need to be sent in the request
 	for key := range req.TransferData {
@@ -58,7 +86,14 @@ func (s *Server) Transaction(
 
 	*/
 
-	err := input.Valid(s.config, s.pow)
+	info, err := s.commands.BlockInfo(s.config, s.storageGPO, s.powDifficulty).Do(spanCtx)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		const msg = "failed to get the block info: %+v"
+		return &resp, utils.Internal(spanCtx, s.log, msg, err)
+	}
+
+	err = input.Valid(s.config, s.pow, s.worker, info)
 	if err != nil {
 		open_telemetry.MarkSpanError(spanCtx, err)
 		return &resp, utils.BadRequest(spanCtx, err)
@@ -71,6 +106,9 @@ func (s *Server) Transaction(
 			const msg = "%s"
 			return &resp, utils.BadRequest(spanCtx, fmt.Errorf(msg, transaction.NotUniqueMsg))
 		}
+		if errors.Is(err, worker.ErrNonceAlreadyUsed) {
+			return &resp, utils.BadRequest(spanCtx, transaction.ErrNonceAlreadyUsed)
+		}
 
 		const msg = "failed to commit transaction: %+v"
 		return &resp, utils.Internal(spanCtx, s.log, msg, err)
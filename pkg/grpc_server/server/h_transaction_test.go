@@ -2,24 +2,29 @@ package server_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"intmax2-node/configs"
 	intMaxAcc "intmax2-node/internal/accounts"
+	"intmax2-node/internal/block_builder_registry_service"
 	intMaxGP "intmax2-node/internal/hash/goldenposeidon"
 	"intmax2-node/internal/mnemonic_wallet"
 	"intmax2-node/internal/pow"
 	intMaxTree "intmax2-node/internal/tree"
 	intMaxTypes "intmax2-node/internal/types"
+	blockInfo "intmax2-node/internal/use_cases/block_info"
 	"intmax2-node/internal/use_cases/mocks"
 	"intmax2-node/internal/use_cases/transaction"
 	"intmax2-node/internal/worker"
 	"intmax2-node/pkg/logger"
+	ucBlockInfo "intmax2-node/pkg/use_cases/block_info"
 	ucTransaction "intmax2-node/pkg/use_cases/transaction"
 	"io"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -52,6 +57,8 @@ func TestHandlerTransaction(t *testing.T) {
 	hc := health.NewHandler()
 	sb := NewMockServiceBlockchain(ctrl)
 	storageGPO := NewMockGPOStorage(ctrl)
+	bbr := NewMockBlockBuilderRegistryService(ctrl)
+	bvp := NewMockBlockValidityProver(ctrl)
 
 	pw := pow.New(cfg.PoW.Difficulty)
 	pWorker := pow.NewWorker(cfg.PoW.Workers, pw)
@@ -75,15 +82,17 @@ func TestHandlerTransaction(t *testing.T) {
 
 	cmd := NewMockCommands(ctrl)
 	ucTr := mocks.NewMockUseCaseTransaction(ctrl)
+	ucBI := mocks.NewMockUseCaseBlockInfo(ctrl)
 
 	const (
-		mnemonic   = "gown situate miss skill figure rain smoke grief giraffe perfect milk gospel casino open mimic egg grace canoe erode skull drip open luggage next"
-		mnPassword = ""
-		derivation = "m/44'/60'/0'/0/0"
+		mnemonic          = "gown situate miss skill figure rain smoke grief giraffe perfect milk gospel casino open mimic egg grace canoe erode skull drip open luggage next"
+		mnPassword        = ""
+		derivation        = "m/44'/60'/0'/0/0"
+		builderDerivation = "m/44'/60'/0'/0/1"
 
 		nonce            = 1
 		amount           = 10
-		trHashKey        = "0x22a09569aeffa766a1c0d8d5dd9d3fb3e5b4567700b8cbac3b4eceedeacee793"
+		feeAmount        = 100
 		ethAddressKey    = "0xD7fa191fB4F255f7Af801966819382edDA19E09C"
 		intMaxAddressKey = "0x2a0a9871a59d52c3d52f57d0ab4324662f39ce14bd2e7a9e2f4c01212b6bea84"
 	)
@@ -92,6 +101,68 @@ func TestHandlerTransaction(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, w.IntMaxWalletAddress, intMaxAddressKey)
 
+	builder, err := mnemonic_wallet.New().WalletFromMnemonic(mnemonic, mnPassword, builderDerivation)
+	assert.NoError(t, err)
+
+	info := blockInfo.UCBlockInfo{
+		IntMaxAddress: builder.IntMaxWalletAddress,
+		// The gas price oracle is updated after the fee of the transaction is quoted.
+		TransferFee:       map[string]string{"0": strconv.Itoa(2 * feeAmount)},
+		QuotedTransferFee: map[string]string{"0": strconv.Itoa(feeAmount)},
+	}
+	var bbrActiveErr error
+	bbr.EXPECT().Active().DoAndReturn(func() error { return bbrActiveErr }).AnyTimes()
+
+	expectBlockInfo := func() {
+		cmd.EXPECT().BlockInfo(gomock.Any(), gomock.Any(), gomock.Any()).Return(ucBI)
+		ucBI.EXPECT().Do(gomock.Any()).Return(&info, nil)
+	}
+
+	var (
+		trHashKey   string
+		feeTransfer transaction.FeeTransferTransaction
+	)
+	{
+		builderPublicKey, err := intMaxAcc.NewPublicKeyFromAddressHex(builder.IntMaxWalletAddress)
+		assert.NoError(t, err)
+
+		builderAddress, err := intMaxTypes.NewINTMAXAddress(builderPublicKey.ToAddress().Bytes())
+		assert.NoError(t, err)
+
+		recipient, err := intMaxTypes.NewEthereumAddress(common.HexToAddress(ethAddressKey).Bytes())
+		assert.NoError(t, err)
+
+		fee := intMaxTypes.NewTransferWithRandomSalt(builderAddress, 0, big.NewInt(feeAmount))
+		transfer := intMaxTypes.NewTransferWithRandomSalt(recipient, 0, big.NewInt(amount))
+
+		zeroTransfer := new(intMaxTypes.Transfer).SetZero()
+		transferTree, err := intMaxTree.NewTransferTree(
+			intMaxTree.TRANSFER_TREE_HEIGHT,
+			[]*intMaxTypes.Transfer{fee, transfer},
+			zeroTransfer.Hash(),
+		)
+		assert.NoError(t, err)
+
+		const feeIndex = 0
+		siblings, transfersHash, err := transferTree.ComputeMerkleProof(feeIndex)
+		assert.NoError(t, err)
+		trHashKey = transfersHash.String()
+
+		merkleProof := make([]string, len(siblings))
+		for key := range siblings {
+			merkleProof[key] = siblings[key].String()
+		}
+
+		feeTransfer = transaction.FeeTransferTransaction{
+			Recipient:     builder.IntMaxWalletAddress,
+			TokenIndex:    fee.TokenIndex,
+			Amount:        fee.Amount.String(),
+			Salt:          fee.Salt.String(),
+			TransferIndex: feeIndex,
+			MerkleProof:   merkleProof,
+		}
+	}
+
 	expiration := time.Now().Add(60 * time.Minute)
 
 	var (
@@ -170,7 +241,18 @@ func TestHandlerTransaction(t *testing.T) {
 		assert.NoError(t, err)
 	}
 
-	grpcServerStop, gwServer := Start(cmd, ctx, cfg, log, dbApp, &hc, pwNonce, wrk, sb, storageGPO)
+	txBody := func(ft transaction.FeeTransferTransaction) string {
+		bd, err := json.Marshal(&ft)
+		assert.NoError(t, err)
+
+		return fmt.Sprintf(
+			`{"sender":%q,"expiration":%q,"signature":%q,"transfersHash":%q,"nonce":%d,"powNonce":%q,"feeTransfer":%s}`,
+			intMaxAddressKey, expiration.Format(time.RFC3339), signature, trHashKey, nonce, noncePW, bd,
+		)
+	}
+
+	pwDifficulty := pow.NewDifficultyAdjuster(cfg, nil)
+	grpcServerStop, gwServer := Start(cmd, ctx, cfg, log, dbApp, &hc, pwNonce, pwDifficulty, wrk, sb, storageGPO, bbr, bvp)
 	defer grpcServerStop()
 
 	cases := []struct {
@@ -184,66 +266,174 @@ func TestHandlerTransaction(t *testing.T) {
 	}{
 		{
 			desc:       "Empty body",
-			prepare:    func() {},
-			message:    "expiration: must be a valid value; nonce: cannot be blank; powNonce: cannot be blank; sender: cannot be blank; signature: cannot be blank; transfersHash: cannot be blank.",
+			prepare:    expectBlockInfo,
+			message:    "expiration: must be a valid value; feeTransfer: cannot be blank; nonce: cannot be blank; powNonce: cannot be blank; sender: cannot be blank; signature: cannot be blank; transfersHash: cannot be blank.",
 			wantStatus: http.StatusBadRequest,
 		},
 		{
 			desc:       "Invalid transfersHash",
-			prepare:    func() {},
+			prepare:    expectBlockInfo,
 			body:       fmt.Sprintf(`{"transfersHash":%q}`, uuid.New().String()),
-			message:    "expiration: must be a valid value; nonce: cannot be blank; powNonce: cannot be blank; sender: cannot be blank; signature: cannot be blank; transfersHash: must be a valid value.",
+			message:    "expiration: must be a valid value; feeTransfer: cannot be blank; nonce: cannot be blank; powNonce: cannot be blank; sender: cannot be blank; signature: cannot be blank; transfersHash: must be a valid value.",
 			wantStatus: http.StatusBadRequest,
 		},
 		{
 			desc:       "Valid nonce",
-			prepare:    func() {},
+			prepare:    expectBlockInfo,
 			body:       fmt.Sprintf(`{"transfersHash":"0x","nonce":%s}`, "10000000000000000000"),
-			message:    "expiration: must be a valid value; powNonce: cannot be blank; sender: cannot be blank; signature: cannot be blank.",
+			message:    "expiration: must be a valid value; feeTransfer: cannot be blank; powNonce: cannot be blank; sender: cannot be blank; signature: cannot be blank.",
 			wantStatus: http.StatusBadRequest,
 		},
 		{
 			desc:       "Invalid powNonce",
-			prepare:    func() {},
+			prepare:    expectBlockInfo,
 			body:       fmt.Sprintf(`{"transfersHash":"0x","nonce":%d,"powNonce":%q}`, 0, uuid.New().String()),
-			message:    "expiration: must be a valid value; nonce: cannot be blank; powNonce: failed to unmarshal transfers hash; sender: cannot be blank; signature: cannot be blank.",
+			message:    "expiration: must be a valid value; feeTransfer: cannot be blank; nonce: cannot be blank; powNonce: failed to unmarshal transfers hash; sender: cannot be blank; signature: cannot be blank.",
 			wantStatus: http.StatusBadRequest,
 		},
 		{
 			desc:       "Invalid signature",
-			prepare:    func() {},
+			prepare:    expectBlockInfo,
 			body:       fmt.Sprintf(`{"transfersHash":"0x","nonce":%d,"powNonce":%q,"signature":%q}`, 0, uuid.New().String(), uuid.New().String()),
-			message:    "expiration: must be a valid value; nonce: cannot be blank; powNonce: failed to unmarshal transfers hash; sender: cannot be blank; signature: must be a valid value.",
+			message:    "expiration: must be a valid value; feeTransfer: cannot be blank; nonce: cannot be blank; powNonce: failed to unmarshal transfers hash; sender: cannot be blank; signature: must be a valid value.",
+			wantStatus: http.StatusBadRequest,
+		},
+		// sender nonce - start
+		{
+			desc: "Nonce already used",
+			prepare: func() {
+				expectBlockInfo()
+				wrk.EXPECT().NextNonce(gomock.Any()).Return(uint64(nonce+1), nil)
+			},
+			body:       txBody(feeTransfer),
+			message:    "nonce: nonce has already been used.",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			desc: "Nonce out of order",
+			prepare: func() {
+				expectBlockInfo()
+				wrk.EXPECT().NextNonce(gomock.Any()).Return(uint64(nonce-1), nil)
+			},
+			body:       txBody(feeTransfer),
+			message:    "nonce: nonce must be equal to the next nonce of sender.",
 			wantStatus: http.StatusBadRequest,
 		},
+		{
+			desc: "Nonce already used by pending transaction",
+			prepare: func() {
+				expectBlockInfo()
+				wrk.EXPECT().NextNonce(gomock.Any()).Return(uint64(nonce), nil)
+				cmd.EXPECT().Transaction(gomock.Any(), gomock.Any(), gomock.Any()).Return(ucTr)
+				ucTr.EXPECT().Do(gomock.Any(), gomock.Any()).Return(worker.ErrNonceAlreadyUsed)
+			},
+			body:       txBody(feeTransfer),
+			message:    transaction.ErrNonceAlreadyUsed.Error(),
+			wantStatus: http.StatusBadRequest,
+		},
+		// sender nonce - finish
+		// fee transfer - start
+		{
+			desc: "Fee transfer to another recipient",
+			prepare: func() {
+				expectBlockInfo()
+				wrk.EXPECT().NextNonce(gomock.Any()).Return(uint64(nonce), nil)
+			},
+			body: func() string {
+				ft := feeTransfer
+				ft.Recipient = intMaxAddressKey
+				return txBody(ft)
+			}(),
+			message:    "feeTransfer: (recipient: must be the INTMAX address of the block builder.).",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			desc: "Fee transfer with insufficient amount",
+			prepare: func() {
+				expectBlockInfo()
+				wrk.EXPECT().NextNonce(gomock.Any()).Return(uint64(nonce), nil)
+			},
+			body: func() string {
+				ft := feeTransfer
+				ft.Amount = strconv.Itoa(feeAmount - 1)
+				return txBody(ft)
+			}(),
+			message:    "feeTransfer: (amount: must be equal to or greater than the transfer fee of the block builder.).",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			desc: "Fee transfer in the token without the transfer fee",
+			prepare: func() {
+				expectBlockInfo()
+				wrk.EXPECT().NextNonce(gomock.Any()).Return(uint64(nonce), nil)
+			},
+			body: func() string {
+				ft := feeTransfer
+				ft.TokenIndex = 1
+				return txBody(ft)
+			}(),
+			message:    "feeTransfer: (amount: the transfer fee of the token is not supported by the block builder.).",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			desc: "Fee transfer is not included in the transfer tree",
+			prepare: func() {
+				expectBlockInfo()
+				wrk.EXPECT().NextNonce(gomock.Any()).Return(uint64(nonce), nil)
+			},
+			body: func() string {
+				ft := feeTransfer
+				ft.TransferIndex = 1
+				return txBody(ft)
+			}(),
+			message:    "feeTransfer: the Merkle proof of the fee transfer does not lead to the transfers hash.",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			desc: "Block info is not available",
+			prepare: func() {
+				cmd.EXPECT().BlockInfo(gomock.Any(), gomock.Any(), gomock.Any()).Return(ucBI)
+				ucBI.EXPECT().Do(gomock.Any()).Return(nil, ucBlockInfo.ErrStorageGPOValueFail)
+			},
+			body:       txBody(feeTransfer),
+			message:    "Internal server error",
+			wantStatus: http.StatusInternalServerError,
+		},
+		// fee transfer - finish
 		// uc error - start
 		{
 			desc: fmt.Sprintf("Error: %s", transaction.NotUniqueMsg),
 			prepare: func() {
+				expectBlockInfo()
+				wrk.EXPECT().NextNonce(gomock.Any()).Return(uint64(nonce), nil)
 				cmd.EXPECT().Transaction(gomock.Any(), gomock.Any(), gomock.Any()).Return(ucTr)
 				ucTr.EXPECT().Do(gomock.Any(), gomock.Any()).Return(worker.ErrReceiverWorkerDuplicate)
 			},
-			body:       fmt.Sprintf(`{"sender":%q,"expiration":%q,"signature":%q,"transfersHash":"0x22a09569aeffa766a1c0d8d5dd9d3fb3e5b4567700b8cbac3b4eceedeacee793","nonce":%d,"powNonce":%q}`, intMaxAddressKey, expiration.Format(time.RFC3339), signature, nonce, noncePW),
+			body:       txBody(feeTransfer),
 			message:    transaction.NotUniqueMsg,
 			wantStatus: http.StatusBadRequest,
 		},
 		{
 			desc: "Internal server error",
 			prepare: func() {
+				expectBlockInfo()
+				wrk.EXPECT().NextNonce(gomock.Any()).Return(uint64(nonce), nil)
 				cmd.EXPECT().Transaction(gomock.Any(), gomock.Any(), gomock.Any()).Return(ucTr)
 				ucTr.EXPECT().Do(gomock.Any(), gomock.Any()).Return(ucTransaction.ErrUCInputEmpty)
 			},
-			body:       fmt.Sprintf(`{"sender":%q,"expiration":%q,"signature":%q,"transfersHash":"0x22a09569aeffa766a1c0d8d5dd9d3fb3e5b4567700b8cbac3b4eceedeacee793","nonce":%d,"powNonce":%q}`, intMaxAddressKey, expiration.Format(time.RFC3339), signature, nonce, noncePW),
+			body:       txBody(feeTransfer),
 			message:    "Internal server error",
 			wantStatus: http.StatusInternalServerError,
 		},
 		{
 			desc: "Internal server error",
 			prepare: func() {
+				expectBlockInfo()
+				wrk.EXPECT().NextNonce(gomock.Any()).Return(uint64(nonce), nil)
 				cmd.EXPECT().Transaction(gomock.Any(), gomock.Any(), gomock.Any()).Return(ucTr)
 				ucTr.EXPECT().Do(gomock.Any(), gomock.Any()).Return(ucTransaction.ErrTransferWorkerReceiverFail)
 			},
-			body:       fmt.Sprintf(`{"sender":%q,"expiration":%q,"signature":%q,"transfersHash":"0x22a09569aeffa766a1c0d8d5dd9d3fb3e5b4567700b8cbac3b4eceedeacee793","nonce":%d,"powNonce":%q}`, intMaxAddressKey, expiration.Format(time.RFC3339), signature, nonce, noncePW),
+			body:       txBody(feeTransfer),
 			message:    "Internal server error",
 			wantStatus: http.StatusInternalServerError,
 		},
@@ -252,19 +442,42 @@ func TestHandlerTransaction(t *testing.T) {
 		{
 			desc: "Valid request with transaction to ETHEREUM address",
 			prepare: func() {
+				expectBlockInfo()
+				wrk.EXPECT().NextNonce(gomock.Any()).Return(uint64(nonce), nil)
 				cmd.EXPECT().Transaction(gomock.Any(), gomock.Any(), gomock.Any()).Return(ucTr)
 				ucTr.EXPECT().Do(gomock.Any(), gomock.Any())
 			},
-			body:       fmt.Sprintf(`{"sender":%q,"expiration":%q,"signature":%q,"transfersHash":"0x22a09569aeffa766a1c0d8d5dd9d3fb3e5b4567700b8cbac3b4eceedeacee793","nonce":%d,"powNonce":%q}`, intMaxAddressKey, expiration.Format(time.RFC3339), signature, nonce, noncePW),
+			body:       txBody(feeTransfer),
 			success:    true,
 			dataMsg:    transaction.SuccessMsg,
 			wantStatus: http.StatusOK,
 		},
 		// check transfersHash with transferData - finish
+		// block builder registry - start
+		{
+			desc: "Block builder is stopped",
+			prepare: func() {
+				bbrActiveErr = block_builder_registry_service.ErrBlockBuilderStopped
+			},
+			body:       txBody(feeTransfer),
+			message:    block_builder_registry_service.ErrBlockBuilderStopped.Error(),
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			desc: "Block builder is under-staked",
+			prepare: func() {
+				bbrActiveErr = block_builder_registry_service.ErrBlockBuilderUnderStaked
+			},
+			body:       txBody(feeTransfer),
+			message:    block_builder_registry_service.ErrBlockBuilderUnderStaked.Error(),
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		// block builder registry - finish
 	}
 
 	for i := range cases {
 		t.Run(cases[i].desc, func(t *testing.T) {
+			bbrActiveErr = nil
 			if cases[i].prepare != nil {
 				cases[i].prepare()
 			}
@@ -291,6 +504,9 @@ func TestHandlerTransaction(t *testing.T) {
 			assert.Equal(t, cases[i].message, gjson.Get(w.Body.String(), "message").String())
 			assert.Equal(t, cases[i].success, gjson.Get(w.Body.String(), "success").Bool())
 			assert.Equal(t, cases[i].dataMsg, gjson.Get(w.Body.String(), "data.message").String())
+			if cases[i].wantStatus != http.StatusServiceUnavailable {
+				assert.Equal(t, strconv.FormatUint(pwDifficulty.Accepted(), 10), w.Header().Get("X-Pow-Difficulty"))
+			}
 		})
 	}
 }
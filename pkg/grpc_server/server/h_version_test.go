@@ -33,11 +33,14 @@ func TestHandlerVersion(t *testing.T) {
 	log := logger.New(cfg.LOG.Level, cfg.LOG.TimeFormat, cfg.LOG.JSON, cfg.LOG.IsLogLine)
 
 	pw := NewMockPoWNonce(ctrl)
+	pwDifficulty := NewMockPoWDifficulty(ctrl)
 	dbApp := NewMockSQLDriverApp(ctrl)
 	worker := NewMockWorker(ctrl)
 	hc := health.NewHandler()
 	sb := NewMockServiceBlockchain(ctrl)
 	storageGPO := NewMockGPOStorage(ctrl)
+	bbr := NewMockBlockBuilderRegistryService(ctrl)
+	bvp := NewMockBlockValidityProver(ctrl)
 
 	const (
 		path1 = "../../../"
@@ -57,7 +60,7 @@ func TestHandlerVersion(t *testing.T) {
 
 	cmd := NewMockCommands(ctrl)
 
-	grpcServerStop, gwServer := Start(cmd, ctx, cfg, log, dbApp, &hc, pw, worker, sb, storageGPO)
+	grpcServerStop, gwServer := Start(cmd, ctx, cfg, log, dbApp, &hc, pw, pwDifficulty, worker, sb, storageGPO, bbr, bvp)
 	defer grpcServerStop()
 
 	getVer := mocks.NewMockUseCaseGetVersion(ctrl)
@@ -15,9 +15,12 @@ type Server struct {
 	cookieForAuthUse bool
 	hc               *health.Handler
 	pow              PoWNonce
+	powDifficulty    PoWDifficulty
 	worker           Worker
 	sb               ServiceBlockchain
 	storageGPO       GPOStorage
+	bbr              BlockBuilderRegistryService
+	bvp              BlockValidityProver
 }
 
 // New initializes a new Server struct.
@@ -29,9 +32,12 @@ func New(
 	cookieForAuthUse bool,
 	hc *health.Handler,
 	pow PoWNonce,
+	powDifficulty PoWDifficulty,
 	worker Worker,
 	sb ServiceBlockchain,
 	storageGPO GPOStorage,
+	bbr BlockBuilderRegistryService,
+	bvp BlockValidityProver,
 ) *Server {
 	const (
 		srv  = "server"
@@ -46,9 +52,12 @@ func New(
 		cookieForAuthUse: cookieForAuthUse,
 		hc:               hc,
 		pow:              pow,
+		powDifficulty:    powDifficulty,
 		worker:           worker,
 		sb:               sb,
 		storageGPO:       storageGPO,
+		bbr:              bbr,
+		bvp:              bvp,
 	}
 }
 
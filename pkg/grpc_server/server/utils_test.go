@@ -31,10 +31,17 @@ func Start(
 	dbApp server.SQLDriverApp,
 	hc *health.Handler,
 	pow server.PoWNonce,
+	powDifficulty server.PoWDifficulty,
 	worker server.Worker,
 	sb server.ServiceBlockchain,
 	storageGPO server.GPOStorage,
+	bbr server.BlockBuilderRegistryService,
+	bvp server.BlockValidityProver,
 ) (gRPCServerStop func(), gwServer *http.Server) {
+	// the handlers are checked without the limits of the senders and of the ip-addresses
+	cfg.Throttle.SenderRate = 0
+	cfg.Throttle.IPRate = 0
+
 	s := httptest.NewServer(nil)
 	s.Close()
 
@@ -57,7 +64,9 @@ func Start(
 		OptionsSuccessStatus: cfg.HTTP.CORSStatusCode,
 	})
 
-	srv := server.New(log, cfg, dbApp, commands, cfg.HTTP.CookieForAuthUse, hc, pow, worker, sb, storageGPO)
+	srv := server.New(
+		log, cfg, dbApp, commands, cfg.HTTP.CookieForAuthUse, hc, pow, powDifficulty, worker, sb, storageGPO, bbr, bvp,
+	)
 	ctx = context.WithValue(ctx, consts.AppConfigs, cfg)
 
 	const (
@@ -9,11 +9,13 @@ import (
 type Worker interface {
 	Receiver(input *worker.ReceiverWorker) error
 	TrHash(trHash string) (*worker.TransactionHashesWithSenderAndFile, error)
+	NextNonce(sender string) (nonce uint64, err error)
 	TxTreeByAvailableFile(sf *worker.TransactionHashesWithSenderAndFile) (txTreeRoot *worker.TxTree, err error)
 	SignTxTreeByAvailableFile(
 		signature string,
 		sf *worker.TransactionHashesWithSenderAndFile,
 		leafIndex uint64,
 	) error
+	Subscribe(key string) (events <-chan *worker.TxEvent, unsubscribe func())
 	// ExistsTxTreeRoot(txTreeRoot string) error
 }
@@ -0,0 +1,55 @@
+package store_vault_server
+
+import (
+	"context"
+	"errors"
+	"intmax2-node/internal/open_telemetry"
+	"intmax2-node/internal/store_vault_auth"
+	"intmax2-node/pkg/grpc_server/utils"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// authorize checks that the session token of the Authorization header is issued for the address.
+// It returns the Unauthorized or Forbidden error to be returned by the handler.
+func (s *StoreVaultServer) authorize(ctx context.Context, address string) error {
+	const authorizationKey = "authorization"
+
+	var authorization string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(authorizationKey); len(v) > 0 {
+			authorization = v[0]
+		}
+	}
+
+	token, err := store_vault_auth.BearerToken(authorization)
+	if err != nil {
+		open_telemetry.MarkSpanError(ctx, err)
+		return utils.Unauthorized(ctx, err)
+	}
+
+	var sessionAddress string
+	sessionAddress, err = s.auth.Authenticate(token)
+	if err != nil {
+		open_telemetry.MarkSpanError(ctx, err)
+		return utils.Unauthorized(ctx, err)
+	}
+
+	if !strings.EqualFold(sessionAddress, strings.TrimSpace(address)) {
+		err = store_vault_auth.ErrAddressNotAuthorized
+		open_telemetry.MarkSpanError(ctx, err)
+		return utils.Forbidden(ctx, err)
+	}
+
+	return nil
+}
+
+// isAuthError reports whether the error is caused by the nonce, the signature or the address of the login.
+func isAuthError(err error) bool {
+	return errors.Is(err, store_vault_auth.ErrAddressInvalid) ||
+		errors.Is(err, store_vault_auth.ErrTokenInvalid) ||
+		errors.Is(err, store_vault_auth.ErrTokenExpired) ||
+		errors.Is(err, store_vault_auth.ErrNonceAddressMismatch) ||
+		errors.Is(err, store_vault_auth.ErrSignatureInvalid)
+}
@@ -3,6 +3,8 @@ package store_vault_server
 import (
 	"intmax2-node/configs"
 	"intmax2-node/internal/logger"
+	authChallenge "intmax2-node/internal/use_cases/auth_challenge"
+	authLogin "intmax2-node/internal/use_cases/auth_login"
 	backupBalance "intmax2-node/internal/use_cases/backup_balance"
 	getBackupDepositByHash "intmax2-node/internal/use_cases/get_backup_deposit_by_hash"
 	getBackupDeposits "intmax2-node/internal/use_cases/get_backup_deposits"
@@ -13,11 +15,14 @@ import (
 	getBackupTransferByHash "intmax2-node/internal/use_cases/get_backup_transfer_by_hash"
 	getBackupTransfers "intmax2-node/internal/use_cases/get_backup_transfers"
 	getBackupTransfersList "intmax2-node/internal/use_cases/get_backup_transfers_list"
+	getDepositMerkleProof "intmax2-node/internal/use_cases/get_deposit_merkle_proof"
 	getVersion "intmax2-node/internal/use_cases/get_version"
 	postBackupDeposit "intmax2-node/internal/use_cases/post_backup_deposit"
 	postBackupTransaction "intmax2-node/internal/use_cases/post_backup_transaction"
 	postBackupTransfer "intmax2-node/internal/use_cases/post_backup_transfer"
 	verifyDepositConfirmation "intmax2-node/internal/use_cases/verify_deposit_confirmation"
+	ucAuthChallenge "intmax2-node/pkg/use_cases/auth_challenge"
+	ucAuthLogin "intmax2-node/pkg/use_cases/auth_login"
 	ucGetBackupBalances "intmax2-node/pkg/use_cases/get_backup_balances"
 	ucGetBackupDepositByHash "intmax2-node/pkg/use_cases/get_backup_deposit_by_hash"
 	ucGetBackupDeposits "intmax2-node/pkg/use_cases/get_backup_deposits"
@@ -29,6 +34,7 @@ import (
 	ucGetBackupTransfers "intmax2-node/pkg/use_cases/get_backup_transfers"
 	ucGetBackupTransfersList "intmax2-node/pkg/use_cases/get_backup_transfers_list"
 	ucGetBalances "intmax2-node/pkg/use_cases/get_balances"
+	ucGetDepositMerkleProof "intmax2-node/pkg/use_cases/get_deposit_merkle_proof"
 	ucVerifyDepositConfirmation "intmax2-node/pkg/use_cases/get_verify_deposit_confirmation"
 	ucGetVersion "intmax2-node/pkg/use_cases/get_version"
 	ucPostBackupBalance "intmax2-node/pkg/use_cases/post_backup_balance"
@@ -105,6 +111,21 @@ type Commands interface {
 	GetBackupBalances(cfg *configs.Config, log logger.Logger, db SQLDriverApp) backupBalance.UseCaseGetBackupBalances
 	GetBalances(cfg *configs.Config, log logger.Logger, db SQLDriverApp) backupBalance.UseCaseGetBalances
 	GetVerifyDepositConfirmation(cfg *configs.Config, log logger.Logger, sb ServiceBlockchain) verifyDepositConfirmation.UseCaseGetVerifyDepositConfirmation
+	GetDepositMerkleProof(
+		cfg *configs.Config,
+		log logger.Logger,
+		depositTree DepositTree,
+	) getDepositMerkleProof.UseCaseGetDepositMerkleProof
+	AuthChallenge(
+		cfg *configs.Config,
+		log logger.Logger,
+		auth StoreVaultAuth,
+	) authChallenge.UseCaseAuthChallenge
+	AuthLogin(
+		cfg *configs.Config,
+		log logger.Logger,
+		auth StoreVaultAuth,
+	) authLogin.UseCaseAuthLogin
 }
 
 type commands struct{}
@@ -228,3 +249,27 @@ func (c *commands) GetBalances(cfg *configs.Config, log logger.Logger, db SQLDri
 func (c *commands) GetVerifyDepositConfirmation(cfg *configs.Config, log logger.Logger, sb ServiceBlockchain) verifyDepositConfirmation.UseCaseGetVerifyDepositConfirmation {
 	return ucVerifyDepositConfirmation.New(cfg, log, sb)
 }
+
+func (c *commands) GetDepositMerkleProof(
+	cfg *configs.Config,
+	log logger.Logger,
+	depositTree DepositTree,
+) getDepositMerkleProof.UseCaseGetDepositMerkleProof {
+	return ucGetDepositMerkleProof.New(cfg, log, depositTree)
+}
+
+func (c *commands) AuthChallenge(
+	cfg *configs.Config,
+	log logger.Logger,
+	auth StoreVaultAuth,
+) authChallenge.UseCaseAuthChallenge {
+	return ucAuthChallenge.New(cfg, log, auth)
+}
+
+func (c *commands) AuthLogin(
+	cfg *configs.Config,
+	log logger.Logger,
+	auth StoreVaultAuth,
+) authLogin.UseCaseAuthLogin {
+	return ucAuthLogin.New(cfg, log, auth)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	mFL "intmax2-node/internal/sql_filter/models"
 	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	"time"
 )
 
 //go:generate mockgen -destination=mock_db_app_test.go -package=store_vault_server_test -source=db_app.go
@@ -30,6 +31,11 @@ type BackupTransfers interface {
 		recipient, transferDoubleHash string,
 	) (*mDBApp.BackupTransfer, error)
 	GetBackupTransfers(condition string, value interface{}) ([]*mDBApp.BackupTransfer, error)
+	GetBackupTransfersByRecipientFromSeq(
+		recipient string,
+		startSeq, limit uint64,
+		createdBefore time.Time,
+	) ([]*mDBApp.BackupTransfer, error)
 	GetBackupTransfersByRecipient(
 		recipient string,
 		pagination mDBApp.PaginationOfListOfBackupTransfersInput,
@@ -50,6 +56,11 @@ type BackupTransactions interface {
 	GetBackupTransaction(condition string, value string) (*mDBApp.BackupTransaction, error)
 	GetBackupTransactionBySenderAndTxDoubleHash(sender, txDoubleHash string) (*mDBApp.BackupTransaction, error)
 	GetBackupTransactions(condition string, value interface{}) ([]*mDBApp.BackupTransaction, error)
+	GetBackupTransactionsBySenderFromSeq(
+		sender string,
+		startSeq, limit uint64,
+		createdBefore time.Time,
+	) ([]*mDBApp.BackupTransaction, error)
 	GetBackupTransactionsBySender(
 		sender string,
 		pagination mDBApp.PaginationOfListOfBackupTransactionsInput,
@@ -72,6 +83,11 @@ type BackupDeposits interface {
 	) (*mDBApp.BackupDeposit, error)
 	GetBackupDeposit(conditions []string, values []interface{}) (*mDBApp.BackupDeposit, error)
 	GetBackupDeposits(condition string, value interface{}) ([]*mDBApp.BackupDeposit, error)
+	GetBackupDepositsByRecipientFromSeq(
+		recipient string,
+		startSeq, limit uint64,
+		createdBefore time.Time,
+	) ([]*mDBApp.BackupDeposit, error)
 	GetBackupDepositsByRecipient(
 		recipient string,
 		pagination mDBApp.PaginationOfListOfBackupDepositsInput,
@@ -0,0 +1,56 @@
+package store_vault_server
+
+import (
+	"context"
+	"intmax2-node/internal/open_telemetry"
+	node "intmax2-node/internal/pb/gen/store_vault_service/node"
+	authChallenge "intmax2-node/internal/use_cases/auth_challenge"
+	"intmax2-node/pkg/grpc_server/utils"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *StoreVaultServer) AuthChallenge(
+	ctx context.Context,
+	req *node.AuthChallengeRequest,
+) (*node.AuthChallengeResponse, error) {
+	resp := node.AuthChallengeResponse{}
+
+	const (
+		hName      = "Handler AuthChallenge"
+		requestKey = "request"
+	)
+
+	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName,
+		trace.WithAttributes(
+			attribute.String(requestKey, req.String()),
+		))
+	defer span.End()
+
+	input := authChallenge.UCAuthChallengeInput{
+		Address: req.Address,
+	}
+
+	err := input.Valid()
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return &resp, utils.BadRequest(spanCtx, err)
+	}
+
+	result, err := s.commands.AuthChallenge(s.config, s.log, s.auth).Do(spanCtx, &input)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		const msg = "failed to issue the nonce: %+v"
+		return &resp, utils.Internal(spanCtx, s.log, msg, err)
+	}
+
+	resp.Success = true
+	resp.Data = &node.AuthChallengeResponse_Data{
+		Nonce:     result.Nonce,
+		ExpiresAt: timestamppb.New(result.ExpiresAt),
+	}
+
+	return &resp, utils.OK(spanCtx)
+}
@@ -0,0 +1,62 @@
+package store_vault_server
+
+import (
+	"context"
+	"intmax2-node/internal/open_telemetry"
+	node "intmax2-node/internal/pb/gen/store_vault_service/node"
+	authLogin "intmax2-node/internal/use_cases/auth_login"
+	"intmax2-node/pkg/grpc_server/utils"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *StoreVaultServer) AuthLogin(
+	ctx context.Context,
+	req *node.AuthLoginRequest,
+) (*node.AuthLoginResponse, error) {
+	resp := node.AuthLoginResponse{}
+
+	const (
+		hName      = "Handler AuthLogin"
+		addressKey = "address"
+	)
+
+	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName,
+		trace.WithAttributes(
+			attribute.String(addressKey, req.Address),
+		))
+	defer span.End()
+
+	input := authLogin.UCAuthLoginInput{
+		Address:   req.Address,
+		Nonce:     req.Nonce,
+		Signature: req.Signature,
+	}
+
+	err := input.Valid()
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return &resp, utils.BadRequest(spanCtx, err)
+	}
+
+	result, err := s.commands.AuthLogin(s.config, s.log, s.auth).Do(spanCtx, &input)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		if isAuthError(err) {
+			return &resp, utils.Unauthorized(spanCtx, err)
+		}
+
+		const msg = "failed to log in: %+v"
+		return &resp, utils.Internal(spanCtx, s.log, msg, err)
+	}
+
+	resp.Success = true
+	resp.Data = &node.AuthLoginResponse_Data{
+		Token:     result.Token,
+		ExpiresAt: timestamppb.New(result.ExpiresAt),
+	}
+
+	return &resp, utils.OK(spanCtx)
+}
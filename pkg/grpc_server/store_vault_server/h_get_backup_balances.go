@@ -38,6 +38,11 @@ func (s *StoreVaultServer) GetBackupBalances(ctx context.Context, req *node.GetB
 		return &resp, utils.BadRequest(spanCtx, err)
 	}
 
+	err = s.authorize(spanCtx, input.Sender)
+	if err != nil {
+		return &resp, err
+	}
+
 	err = s.dbApp.Exec(spanCtx, nil, func(d interface{}, _ interface{}) (err error) {
 		q, _ := d.(SQLDriverApp)
 
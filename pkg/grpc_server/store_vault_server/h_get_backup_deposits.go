@@ -33,6 +33,7 @@ func (s *StoreVaultServer) GetBackupDeposits(
 		Sender:           req.Sender,
 		StartBlockNumber: req.StartBlockNumber,
 		Limit:            req.Limit,
+		StartSeq:         req.StartSeq,
 	}
 
 	err := input.Valid()
@@ -41,6 +42,11 @@ func (s *StoreVaultServer) GetBackupDeposits(
 		return &resp, utils.BadRequest(spanCtx, err)
 	}
 
+	err = s.authorize(spanCtx, input.Sender)
+	if err != nil {
+		return &resp, err
+	}
+
 	err = s.dbApp.Exec(spanCtx, nil, func(d interface{}, _ interface{}) (err error) {
 		q, _ := d.(SQLDriverApp)
 
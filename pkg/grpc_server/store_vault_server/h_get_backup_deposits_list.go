@@ -68,6 +68,11 @@ func (s *StoreVaultServer) GetBackupDepositsList(
 		return &resp, utils.BadRequest(spanCtx, err)
 	}
 
+	err = s.authorize(spanCtx, input.Recipient)
+	if err != nil {
+		return &resp, err
+	}
+
 	var list getBackupDepositsList.UCGetBackupDepositsList
 	err = s.dbApp.Exec(spanCtx, &list, func(d interface{}, in interface{}) (err error) {
 		q, _ := d.(SQLDriverApp)
@@ -30,6 +30,7 @@ func (s *StoreVaultServer) GetBackupTransactions(ctx context.Context, req *node.
 		Sender:           req.Sender,
 		StartBlockNumber: req.StartBlockNumber,
 		Limit:            req.Limit,
+		StartSeq:         req.StartSeq,
 	}
 
 	err := input.Valid()
@@ -38,6 +39,11 @@ func (s *StoreVaultServer) GetBackupTransactions(ctx context.Context, req *node.
 		return &resp, utils.BadRequest(spanCtx, err)
 	}
 
+	err = s.authorize(spanCtx, input.Sender)
+	if err != nil {
+		return &resp, err
+	}
+
 	err = s.dbApp.Exec(spanCtx, nil, func(d interface{}, _ interface{}) (err error) {
 		q, _ := d.(SQLDriverApp)
 
@@ -68,6 +68,11 @@ func (s *StoreVaultServer) GetBackupTransactionsList(
 		return &resp, utils.BadRequest(spanCtx, err)
 	}
 
+	err = s.authorize(spanCtx, input.Sender)
+	if err != nil {
+		return &resp, err
+	}
+
 	var list getBackupTransactionsList.UCGetBackupTransactionsList
 	err = s.dbApp.Exec(spanCtx, &list, func(d interface{}, in interface{}) (err error) {
 		q, _ := d.(SQLDriverApp)
@@ -67,6 +67,11 @@ func (s *StoreVaultServer) GetBackupTransfersList(
 		return &resp, utils.BadRequest(spanCtx, err)
 	}
 
+	err = s.authorize(spanCtx, input.Recipient)
+	if err != nil {
+		return &resp, err
+	}
+
 	var list getBackupTransfersList.UCGetBackupTransfersList
 	err = s.dbApp.Exec(spanCtx, &list, func(d interface{}, in interface{}) (err error) {
 		q, _ := d.(SQLDriverApp)
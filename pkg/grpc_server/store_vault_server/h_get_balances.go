@@ -2,7 +2,6 @@ package store_vault_server
 
 import (
 	"context"
-	"fmt"
 	"intmax2-node/internal/open_telemetry"
 	node "intmax2-node/internal/pb/gen/store_vault_service/node"
 	backupBalance "intmax2-node/internal/use_cases/backup_balance"
@@ -31,7 +30,10 @@ func (s *StoreVaultServer) GetBalances(ctx context.Context, req *node.GetBalance
 	defer span.End()
 
 	input := backupBalance.UCGetBalancesInput{
-		Address: req.Address,
+		Address:    req.Address,
+		SinceBlock: req.SinceBlock,
+		Cursor:     req.Cursor,
+		Limit:      req.Limit,
 	}
 
 	err := input.Valid()
@@ -40,25 +42,29 @@ func (s *StoreVaultServer) GetBalances(ctx context.Context, req *node.GetBalance
 		return &resp, utils.BadRequest(spanCtx, err)
 	}
 
-	err = s.dbApp.Exec(spanCtx, nil, func(d interface{}, _ interface{}) (err error) {
-		q, _ := d.(SQLDriverApp)
+	err = s.authorize(spanCtx, input.Address)
+	if err != nil {
+		return &resp, err
+	}
 
-		results, err := s.commands.GetBalances(s.config, s.log, q).Do(spanCtx, &input)
-		if err != nil {
-			open_telemetry.MarkSpanError(spanCtx, err)
-			const msg = "failed to get balances request: %w"
-			return fmt.Errorf(msg, err)
-		}
-		resp.Deposits = convertToDeposits(results.Deposits)
-		resp.Transfers = convertToTransfers(results.Transfers)
-		resp.Transactions = convertToTransactions(results.Transactions)
-		return nil
-	})
+	// the backups are fetched concurrently, so the request is not wrapped in the transaction
+	results, err := s.commands.GetBalances(s.config, s.log, s.dbApp).Do(spanCtx, &input)
 	if err != nil {
-		const msg = "failed to get balances with DB App: %+v"
+		open_telemetry.MarkSpanError(spanCtx, err)
+		const msg = "failed to get balances: %+v"
 		return &resp, utils.Internal(spanCtx, s.log, msg, err)
 	}
 
+	resp.Deposits = convertToDeposits(results.Deposits)
+	resp.Transfers = convertToTransfers(results.Transfers)
+	resp.Transactions = convertToTransactions(results.Transactions)
+	if results.Meta != nil {
+		resp.Meta = &node.GetBalancesResponse_Meta{
+			HasMore:    results.Meta.HasMore,
+			NextCursor: results.Meta.NextCursor,
+		}
+	}
+
 	return &resp, utils.OK(spanCtx)
 }
 
@@ -0,0 +1,175 @@
+package store_vault_server_test
+
+import (
+	"context"
+	"intmax2-node/configs"
+	intMaxAcc "intmax2-node/internal/accounts"
+	"intmax2-node/internal/store_vault_auth"
+	backupBalance "intmax2-node/internal/use_cases/backup_balance"
+	"intmax2-node/internal/use_cases/mocks"
+	"intmax2-node/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dimiro1/health"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandlerGetBalances(t *testing.T) {
+	const int3Key = 3
+	assert.NoError(t, configs.LoadDotEnv(int3Key))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := configs.New()
+	log := logger.New(cfg.LOG.Level, cfg.LOG.TimeFormat, cfg.LOG.JSON, cfg.LOG.IsLogLine)
+
+	dbApp := NewMockSQLDriverApp(ctrl)
+	hc := health.NewHandler()
+	sb := NewMockServiceBlockchain(ctrl)
+	depositTree := NewMockDepositTree(ctrl)
+
+	cfg.StoreVaultAuth.Secret = "secret"
+	auth, err := store_vault_auth.New(cfg)
+	require.NoError(t, err)
+
+	const (
+		path1 = "../../../"
+		path2 = "./"
+	)
+
+	dir := path1
+	if _, err = os.ReadFile(dir + cfg.APP.PEMPathCACert); err != nil {
+		dir = path2
+	}
+	cfg.APP.PEMPathCACert = dir + cfg.APP.PEMPathCACert
+	cfg.APP.PEMPathServCert = dir + cfg.APP.PEMPathServCert
+	cfg.APP.PEMPathServKey = dir + cfg.APP.PEMPathServKey
+	cfg.APP.PEMPAthCACertClient = dir + cfg.APP.PEMPAthCACertClient
+	cfg.APP.PEMPathClientCert = dir + cfg.APP.PEMPathClientCert
+	cfg.APP.PEMPathClientKey = dir + cfg.APP.PEMPathClientKey
+
+	cmd := NewMockCommands(ctrl)
+
+	grpcServerStop, gwServer := Start(cmd, ctx, cfg, log, dbApp, &hc, sb, auth, depositTree)
+	defer grpcServerStop()
+
+	getBalances := mocks.NewMockUseCaseGetBalances(ctrl)
+
+	login := func(address string, signer store_vault_auth.Signer) string {
+		challenge, err := auth.Challenge(address)
+		require.NoError(t, err)
+
+		signature, err := signer.SignNonce(challenge.Nonce)
+		require.NoError(t, err)
+
+		session, err := auth.Login(address, challenge.Nonce, signature)
+		require.NoError(t, err)
+
+		return "Bearer " + session.Token
+	}
+
+	ethKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	userKey, err := intMaxAcc.NewINTMAXAccountFromECDSAKey(ethKey)
+	require.NoError(t, err)
+	user := userKey.ToAddress().String()
+
+	otherEthKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := intMaxAcc.NewINTMAXAccountFromECDSAKey(otherEthKey)
+	require.NoError(t, err)
+	other := otherKey.ToAddress().String()
+
+	cases := []struct {
+		desc          string
+		query         string
+		authorization string
+		prepare       func()
+		wantStatus    int
+		wantBody      string
+	}{
+		{
+			desc:       "the session token is required",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			desc:          "the session token is invalid",
+			authorization: "Bearer invalid",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			desc:          "the session token is issued for another address",
+			authorization: login(other, store_vault_auth.NewINTMAXSigner(otherKey)),
+			wantStatus:    http.StatusForbidden,
+		},
+		{
+			desc:          "Success",
+			authorization: login(user, store_vault_auth.NewINTMAXSigner(userKey)),
+			prepare: func() {
+				cmd.EXPECT().GetBalances(gomock.Any(), gomock.Any(), dbApp).Return(getBalances)
+				getBalances.EXPECT().Do(gomock.Any(), &backupBalance.UCGetBalancesInput{Address: user}).
+					Return(&backupBalance.UCGetBalances{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			desc:          "the cursor is invalid",
+			query:         "?cursor=invalid",
+			authorization: login(user, store_vault_auth.NewINTMAXSigner(userKey)),
+			wantStatus:    http.StatusBadRequest,
+		},
+		{
+			desc:          "the page",
+			query:         "?since_block=5&cursor=10&limit=20",
+			authorization: login(user, store_vault_auth.NewINTMAXSigner(userKey)),
+			prepare: func() {
+				cmd.EXPECT().GetBalances(gomock.Any(), gomock.Any(), dbApp).Return(getBalances)
+				getBalances.EXPECT().Do(gomock.Any(), &backupBalance.UCGetBalancesInput{
+					Address:    user,
+					SinceBlock: 5,
+					Cursor:     "10",
+					Limit:      20,
+				}).Return(&backupBalance.UCGetBalances{
+					Meta: &backupBalance.UCGetBalancesMeta{HasMore: true, NextCursor: "15"},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `"meta":{"hasMore":true,"nextCursor":"15"}`,
+		},
+	}
+
+	for i := range cases {
+		t.Run(cases[i].desc, func(t *testing.T) {
+			if cases[i].prepare != nil {
+				cases[i].prepare()
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "http://"+gwServer.Addr+"/v1/balances/"+user+cases[i].query, http.NoBody)
+			if cases[i].authorization != "" {
+				r.Header.Set(store_vault_auth.HeaderKey, cases[i].authorization)
+			}
+
+			gwServer.Handler.ServeHTTP(w, r)
+
+			if !assert.Equal(t, cases[i].wantStatus, w.Code) {
+				t.Log(w.Body.String())
+			}
+			if cases[i].wantBody != "" {
+				// protojson randomly adds the spaces to its output.
+				assert.Contains(t, strings.ReplaceAll(w.Body.String(), " ", ""), cases[i].wantBody)
+			}
+		})
+	}
+}
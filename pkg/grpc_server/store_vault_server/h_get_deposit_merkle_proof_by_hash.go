@@ -0,0 +1,58 @@
+package store_vault_server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"intmax2-node/internal/deposit_indexer"
+	"intmax2-node/internal/open_telemetry"
+	node "intmax2-node/internal/pb/gen/store_vault_service/node"
+	getDepositMerkleProof "intmax2-node/internal/use_cases/get_deposit_merkle_proof"
+	"intmax2-node/pkg/grpc_server/utils"
+	errorsDB "intmax2-node/pkg/sql_db/errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func (s *StoreVaultServer) GetDepositMerkleProofByHash(
+	ctx context.Context,
+	req *node.GetDepositMerkleProofByHashRequest,
+) (*node.GetDepositMerkleProofResponse, error) {
+	resp := node.GetDepositMerkleProofResponse{}
+
+	const (
+		hName      = "Handler GetDepositMerkleProofByHash"
+		requestKey = "request"
+	)
+
+	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName,
+		trace.WithAttributes(
+			attribute.String(requestKey, req.String()),
+		))
+	defer span.End()
+
+	input := getDepositMerkleProof.UCGetDepositMerkleProofInput{
+		DepositHash: req.DepositHash,
+	}
+
+	err := input.Valid()
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return &resp, utils.BadRequest(spanCtx, err)
+	}
+
+	resp.Data, err = s.commands.GetDepositMerkleProof(s.config, s.log, s.depositTree).Do(spanCtx, &input)
+	if err != nil {
+		if errors.Is(err, errorsDB.ErrNotFound) || errors.Is(err, deposit_indexer.ErrDepositNotIndexed) {
+			return &resp, utils.NotFound(spanCtx, fmt.Errorf("%s", getDepositMerkleProof.NotFoundMessage))
+		}
+
+		const msg = "failed to get deposit merkle proof with DB App: %+v"
+		return &resp, utils.Internal(spanCtx, s.log, msg, err)
+	}
+
+	resp.Success = true
+
+	return &resp, utils.OK(spanCtx)
+}
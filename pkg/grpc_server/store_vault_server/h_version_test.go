@@ -35,6 +35,8 @@ func TestHandlerVersion(t *testing.T) {
 	dbApp := NewMockSQLDriverApp(ctrl)
 	hc := health.NewHandler()
 	sb := NewMockServiceBlockchain(ctrl)
+	depositTree := NewMockDepositTree(ctrl)
+	auth := NewMockStoreVaultAuth(ctrl)
 
 	const (
 		path1 = "../../../"
@@ -54,7 +56,7 @@ func TestHandlerVersion(t *testing.T) {
 
 	cmd := NewMockCommands(ctrl)
 
-	grpcServerStop, gwServer := Start(cmd, ctx, cfg, log, dbApp, &hc, sb)
+	grpcServerStop, gwServer := Start(cmd, ctx, cfg, log, dbApp, &hc, sb, auth, depositTree)
 	defer grpcServerStop()
 
 	getVer := mocks.NewMockUseCaseGetVersion(ctrl)
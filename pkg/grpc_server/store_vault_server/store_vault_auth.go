@@ -0,0 +1,11 @@
+package store_vault_server
+
+import "intmax2-node/internal/store_vault_auth"
+
+//go:generate mockgen -destination=mock_store_vault_auth_test.go -package=store_vault_server_test -source=store_vault_auth.go
+
+type StoreVaultAuth interface {
+	Challenge(address string) (*store_vault_auth.Challenge, error)
+	Login(address, nonce, signature string) (*store_vault_auth.Session, error)
+	Authenticate(token string) (address string, err error)
+}
@@ -13,6 +13,8 @@ type StoreVaultServer struct {
 	dbApp            SQLDriverApp
 	commands         Commands
 	sb               ServiceBlockchain
+	auth             StoreVaultAuth
+	depositTree      DepositTree
 	cookieForAuthUse bool
 	hc               *health.Handler
 }
@@ -24,6 +26,8 @@ func New(
 	dbApp SQLDriverApp,
 	commands Commands,
 	sb ServiceBlockchain,
+	auth StoreVaultAuth,
+	depositTree DepositTree,
 	cookieForAuthUse bool,
 	hc *health.Handler,
 ) *StoreVaultServer {
@@ -38,6 +42,8 @@ func New(
 		dbApp:            dbApp,
 		commands:         commands,
 		sb:               sb,
+		auth:             auth,
+		depositTree:      depositTree,
 		cookieForAuthUse: cookieForAuthUse,
 		hc:               hc,
 	}
@@ -31,6 +31,8 @@ func Start(
 	dbApp server.SQLDriverApp,
 	hc *health.Handler,
 	sb server.ServiceBlockchain,
+	auth server.StoreVaultAuth,
+	depositTree server.DepositTree,
 ) (gRPCServerStop func(), gwServer *http.Server) {
 	s := httptest.NewServer(nil)
 	s.Close()
@@ -54,7 +56,7 @@ func Start(
 		OptionsSuccessStatus: cfg.HTTP.CORSStatusCode,
 	})
 
-	srv := server.New(log, cfg, dbApp, commands, sb, cfg.HTTP.CookieForAuthUse, hc)
+	srv := server.New(log, cfg, dbApp, commands, sb, auth, depositTree, cfg.HTTP.CookieForAuthUse, hc)
 	ctx = context.WithValue(ctx, consts.AppConfigs, cfg)
 
 	const (
@@ -6,16 +6,20 @@ import (
 	"intmax2-node/internal/logger"
 	"intmax2-node/internal/open_telemetry"
 	"intmax2-node/internal/pb/gateway/http_response_modifier"
+	"math"
 	"net/http"
 	"runtime"
 	"strconv"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 const (
@@ -24,6 +28,8 @@ const (
 	forbidden           = "Forbidden"
 	unauthorized        = "Unauthorized"
 	internalServerError = "Internal server error"
+	tooManyRequests     = "Too many requests"
+	serviceUnavailable  = "Service unavailable"
 )
 
 const (
@@ -149,6 +155,60 @@ func NotFound(ctx context.Context, err error) error {
 	return Custom(spanCtx, codes.NotFound, http.StatusNotFound, notFound, err)
 }
 
+// TooManyRequests sets http-header with status code equal 429 and the time to retry the request.
+func TooManyRequests(ctx context.Context, retryAfter time.Duration, err error) error {
+	spanCtx, span := open_telemetry.Tracer().Start(ctx, name,
+		trace.WithAttributes(
+			attribute.String(httpCode, strconv.Itoa(http.StatusTooManyRequests)),
+		))
+	defer span.End()
+
+	pc, fn, line, _ := runtime.Caller(callerNumber)
+	span.SetAttributes(attribute.Key(errDescription).
+		String(fmt.Sprintf(maskErrMessage, runtime.FuncForPC(pc).Name(), fn, line, err)))
+	span.SetAttributes(attribute.Key(errAttribute).Bool(true))
+
+	retryAfterSeconds := int(math.Ceil(retryAfter.Seconds()))
+	_ = grpc.SetHeader(spanCtx, metadata.Pairs(
+		http_response_modifier.OutRetryAfter, strconv.Itoa(retryAfterSeconds),
+	))
+
+	cErr := Custom(spanCtx, codes.ResourceExhausted, http.StatusTooManyRequests, tooManyRequests, err)
+
+	st, dErr := status.Convert(cErr).WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(time.Duration(retryAfterSeconds) * time.Second),
+	})
+	if dErr != nil {
+		return cErr
+	}
+
+	return st.Err()
+}
+
+// PoWDifficulty sets http-header with the PoW difficulty required from the sender of the request.
+func PoWDifficulty(ctx context.Context, difficulty uint64) {
+	const int10Key = 10
+	_ = grpc.SetHeader(ctx, metadata.Pairs(
+		http_response_modifier.OutPoWDifficulty, strconv.FormatUint(difficulty, int10Key),
+	))
+}
+
+// ServiceUnavailable sets http-header with status code equal 503.
+func ServiceUnavailable(ctx context.Context, err error) error {
+	spanCtx, span := open_telemetry.Tracer().Start(ctx, name,
+		trace.WithAttributes(
+			attribute.String(httpCode, strconv.Itoa(http.StatusServiceUnavailable)),
+		))
+	defer span.End()
+
+	pc, fn, line, _ := runtime.Caller(callerNumber)
+	span.SetAttributes(attribute.Key(errDescription).
+		String(fmt.Sprintf(maskErrMessage, runtime.FuncForPC(pc).Name(), fn, line, err)))
+	span.SetAttributes(attribute.Key(errAttribute).Bool(true))
+
+	return Custom(spanCtx, codes.Unavailable, http.StatusServiceUnavailable, serviceUnavailable, err)
+}
+
 // Custom code.
 func Custom(ctx context.Context, code codes.Code, statusCode int, msg string, err error) error {
 	spanCtx, span := open_telemetry.Tracer().Start(ctx, name,
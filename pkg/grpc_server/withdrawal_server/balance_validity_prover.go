@@ -0,0 +1,11 @@
+package withdrawal_server
+
+import (
+	"context"
+)
+
+//go:generate mockgen -destination=mock_balance_validity_prover_test.go -package=withdrawal_server_test -source=balance_validity_prover.go
+
+type BalanceValidityProver interface {
+	VerifyBalanceProof(ctx context.Context, proof string) error
+}
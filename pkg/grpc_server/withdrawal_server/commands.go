@@ -16,7 +16,13 @@ import (
 
 type Commands interface {
 	GetVersion(version, buildTime string) getVersion.UseCaseGetVersion
-	PostWithdrawalRequest(cfg *configs.Config, log logger.Logger, db SQLDriverApp, sb withdrawal_service.ServiceBlockchain) postWithdrawalRequest.UseCasePostWithdrawalRequest
+	PostWithdrawalRequest(
+		cfg *configs.Config,
+		log logger.Logger,
+		db SQLDriverApp,
+		sb withdrawal_service.ServiceBlockchain,
+		bvp BalanceValidityProver,
+	) postWithdrawalRequest.UseCasePostWithdrawalRequest
 	PostWithdrawalsByHashes(cfg *configs.Config, log logger.Logger, db SQLDriverApp) postWithdrawalsByHashes.UseCasePostWithdrawalsByHashes
 }
 
@@ -30,8 +36,14 @@ func (c *commands) GetVersion(version, buildTime string) getVersion.UseCaseGetVe
 	return ucGetVersion.New(version, buildTime)
 }
 
-func (c *commands) PostWithdrawalRequest(cfg *configs.Config, log logger.Logger, db SQLDriverApp, sb withdrawal_service.ServiceBlockchain) postWithdrawalRequest.UseCasePostWithdrawalRequest {
-	return ucPostWithdrawalRequest.New(cfg, log, db, sb)
+func (c *commands) PostWithdrawalRequest(
+	cfg *configs.Config,
+	log logger.Logger,
+	db SQLDriverApp,
+	sb withdrawal_service.ServiceBlockchain,
+	bvp BalanceValidityProver,
+) postWithdrawalRequest.UseCasePostWithdrawalRequest {
+	return ucPostWithdrawalRequest.New(cfg, log, db, sb, bvp)
 }
 
 func (c *commands) PostWithdrawalsByHashes(cfg *configs.Config, log logger.Logger, db SQLDriverApp) postWithdrawalsByHashes.UseCasePostWithdrawalsByHashes {
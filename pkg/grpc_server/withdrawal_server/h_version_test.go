@@ -34,6 +34,7 @@ func TestHandlerVersion(t *testing.T) {
 
 	dbApp := NewMockSQLDriverApp(ctrl)
 	hc := health.NewHandler()
+	bvp := NewMockBalanceValidityProver(ctrl)
 
 	const (
 		path1 = "../../../"
@@ -53,7 +54,7 @@ func TestHandlerVersion(t *testing.T) {
 
 	cmd := NewMockCommands(ctrl)
 
-	grpcServerStop, gwServer := Start(cmd, ctx, cfg, log, dbApp, &hc)
+	grpcServerStop, gwServer := Start(cmd, ctx, cfg, log, dbApp, &hc, bvp)
 	defer grpcServerStop()
 
 	getVer := mocks.NewMockUseCaseGetVersion(ctrl)
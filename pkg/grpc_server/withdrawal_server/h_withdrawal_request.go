@@ -58,6 +58,7 @@ func (s *WithdrawalServer) WithdrawalRequest(
 			Proof:        req.EnoughBalanceProof.Proof,
 			PublicInputs: req.EnoughBalanceProof.PublicInputs,
 		},
+		Sender: req.Sender,
 	}
 
 	err := input.Valid()
@@ -71,7 +72,7 @@ func (s *WithdrawalServer) WithdrawalRequest(
 	err = s.dbApp.Exec(spanCtx, nil, func(d interface{}, _ interface{}) (err error) {
 		q, _ := d.(SQLDriverApp)
 
-		err = s.commands.PostWithdrawalRequest(s.config, s.log, q, bc).Do(spanCtx, &input)
+		err = s.commands.PostWithdrawalRequest(s.config, s.log, q, bc, s.bvp).Do(spanCtx, &input)
 		if err != nil {
 			open_telemetry.MarkSpanError(spanCtx, err)
 			const msg = "failed to post withdrawal request: %w"
@@ -85,6 +86,10 @@ func (s *WithdrawalServer) WithdrawalRequest(
 			return &resp, utils.BadRequest(spanCtx, withdrawalService.ErrWithdrawalRequestAlreadyExists)
 		}
 
+		if errors.Is(err, withdrawalService.ErrInvalidBalanceProof) {
+			return &resp, utils.BadRequest(spanCtx, err)
+		}
+
 		const msg = "failed to post withdrawal request with DB App: %+v"
 		return &resp, utils.Internal(spanCtx, s.log, msg, err)
 	}
@@ -30,6 +30,7 @@ func Start(
 	log logger.Logger,
 	dbApp server.SQLDriverApp,
 	hc *health.Handler,
+	bvp server.BalanceValidityProver,
 ) (gRPCServerStop func(), gwServer *http.Server) {
 	s := httptest.NewServer(nil)
 	s.Close()
@@ -53,7 +54,7 @@ func Start(
 		OptionsSuccessStatus: cfg.HTTP.CORSStatusCode,
 	})
 
-	srv := server.New(log, cfg, dbApp, commands, cfg.HTTP.CookieForAuthUse, hc)
+	srv := server.New(log, cfg, dbApp, commands, cfg.HTTP.CookieForAuthUse, hc, bvp)
 	ctx = context.WithValue(ctx, consts.AppConfigs, cfg)
 
 	const (
@@ -14,6 +14,7 @@ type WithdrawalServer struct {
 	commands         Commands
 	cookieForAuthUse bool
 	hc               *health.Handler
+	bvp              BalanceValidityProver
 }
 
 // New initializes a new Server struct.
@@ -24,6 +25,7 @@ func New(
 	commands Commands,
 	cookieForAuthUse bool,
 	hc *health.Handler,
+	bvp BalanceValidityProver,
 ) *WithdrawalServer {
 	const (
 		srv  = "withdrawalServer"
@@ -37,6 +39,7 @@ func New(
 		commands:         commands,
 		cookieForAuthUse: cookieForAuthUse,
 		hc:               hc,
+		bvp:              bvp,
 	}
 }
 
@@ -6,6 +6,7 @@ import (
 	mFL "intmax2-node/internal/sql_filter/models"
 	intMaxTypes "intmax2-node/internal/types"
 	"intmax2-node/pkg/sql_db/db_app/models"
+	"time"
 
 	"github.com/dimiro1/health"
 	"github.com/holiman/uint256"
@@ -31,6 +32,11 @@ type SQLDb interface {
 	BackupBalances
 	CtrlProcessingJobs
 	GasPriceOracle
+	Deposits
+	BlockHashes
+	RegisteredAccounts
+	DepositAMLScreenings
+	BlockFees
 }
 
 type GenericCommands interface {
@@ -85,8 +91,13 @@ type TxMerkleProofs interface {
 
 type EventBlockNumbers interface {
 	UpsertEventBlockNumber(eventName string, blockNumber uint64) (*models.EventBlockNumber, error)
+	UpsertEventBlockNumberAndHash(eventName string, blockNumber uint64, blockHash string) (*models.EventBlockNumber, error)
 	EventBlockNumberByEventName(eventName string) (*models.EventBlockNumber, error)
 	EventBlockNumbersByEventNames(eventNames []string) ([]*models.EventBlockNumber, error)
+	UpsertEventBlockHash(eventName string, blockNumber uint64, blockHash string) error
+	EventBlockHashesByEventName(eventName string) ([]*models.EventBlockHash, error)
+	DelEventBlockHashesAfterBlockNumber(eventName string, blockNumber uint64) error
+	DelEventBlockHashesBeforeBlockNumber(eventName string, blockNumber uint64) error
 }
 
 type Balances interface {
@@ -110,6 +121,8 @@ type Withdrawals interface {
 		enoughBalanceProof *models.EnoughBalanceProof,
 	) (*models.Withdrawal, error)
 	UpdateWithdrawalsStatus(ids []string, status models.WithdrawalStatus) error
+	UpdateWithdrawalsProverJob(ids []string, status models.WithdrawalStatus, proverJobID string) error
+	UpdateWithdrawalProof(id string, withdrawalProof *models.WithdrawalProof) error
 	WithdrawalByID(id string) (*models.Withdrawal, error)
 	WithdrawalsByHashes(transferHashes []string) (*[]models.Withdrawal, error)
 	WithdrawalsByStatus(status models.WithdrawalStatus, limit *int) (*[]models.Withdrawal, error)
@@ -125,6 +138,11 @@ type BackupTransfers interface {
 		recipient, transferDoubleHash string,
 	) (*models.BackupTransfer, error)
 	GetBackupTransfers(condition string, value interface{}) ([]*models.BackupTransfer, error)
+	GetBackupTransfersByRecipientFromSeq(
+		recipient string,
+		startSeq, limit uint64,
+		createdBefore time.Time,
+	) ([]*models.BackupTransfer, error)
 	GetBackupTransfersByRecipient(
 		recipient string,
 		pagination models.PaginationOfListOfBackupTransfersInput,
@@ -145,6 +163,11 @@ type BackupTransactions interface {
 	GetBackupTransaction(condition string, value string) (*models.BackupTransaction, error)
 	GetBackupTransactionBySenderAndTxDoubleHash(sender, txDoubleHash string) (*models.BackupTransaction, error)
 	GetBackupTransactions(condition string, value interface{}) ([]*models.BackupTransaction, error)
+	GetBackupTransactionsBySenderFromSeq(
+		sender string,
+		startSeq, limit uint64,
+		createdBefore time.Time,
+	) ([]*models.BackupTransaction, error)
 	GetBackupTransactionsBySender(
 		sender string,
 		pagination models.PaginationOfListOfBackupTransactionsInput,
@@ -167,6 +190,11 @@ type BackupDeposits interface {
 	) (*models.BackupDeposit, error)
 	GetBackupDeposit(conditions []string, values []interface{}) (*models.BackupDeposit, error)
 	GetBackupDeposits(condition string, value interface{}) ([]*models.BackupDeposit, error)
+	GetBackupDepositsByRecipientFromSeq(
+		recipient string,
+		startSeq, limit uint64,
+		createdBefore time.Time,
+	) ([]*models.BackupDeposit, error)
 	GetBackupDepositsByRecipient(
 		recipient string,
 		pagination models.PaginationOfListOfBackupDepositsInput,
@@ -204,6 +232,7 @@ type Senders interface {
 	SenderByID(id string) (*models.Sender, error)
 	SenderByAddress(address string) (*models.Sender, error)
 	SenderByPublicKey(publicKey string) (*models.Sender, error)
+	UpdateSenderNonce(id string, nonce uint64) error
 }
 
 type Accounts interface {
@@ -211,6 +240,8 @@ type Accounts interface {
 	AccountBySenderID(senderID string) (*models.Account, error)
 	AccountByAccountID(accountID *uint256.Int) (*models.Account, error)
 	ResetSequenceByAccounts() error
+	UpdateBlockNumberOfNewAccounts(blockNumber uint64) error
+	DelAccountsAfterBlockNumber(blockNumber uint64) error
 	DelAllAccounts() error
 }
 
@@ -233,3 +264,57 @@ type GasPriceOracle interface {
 	CreateGasPriceOracle(name string, value *uint256.Int) error
 	GasPriceOracle(name string) (*models.GasPriceOracle, error)
 }
+
+type Deposits interface {
+	CreateDeposit(
+		depositID uint64,
+		depositHash, recipientSaltHash string,
+		tokenIndex uint32,
+		amount *uint256.Int,
+		blockNumber uint64,
+	) (*models.Deposit, error)
+	UpdateDepositIndexByDepositHash(
+		depositHash string,
+		depositIndex uint32,
+		processedBlockNumber uint64,
+	) (*models.Deposit, error)
+	DelUnindexedDepositsAfterBlockNumber(blockNumber uint64) error
+	ResetDepositIndexesAfterProcessedBlockNumber(processedBlockNumber uint64) error
+	DepositByDepositID(depositID uint64) (*models.Deposit, error)
+	DepositByDepositHash(depositHash string) (*models.Deposit, error)
+	IndexedDeposits() ([]*models.Deposit, error)
+	IndexedDepositsFromDepositIndex(depositIndex uint32) ([]*models.Deposit, error)
+}
+
+type BlockHashes interface {
+	CreateBlockHash(
+		blockNumber uint32,
+		blockHash, prevBlockHash, depositTreeRoot, signatureHash string,
+		postedBlockNumber uint64,
+	) (*models.BlockHash, error)
+	BlockHashByBlockNumber(blockNumber uint32) (*models.BlockHash, error)
+	BlockHashes() ([]*models.BlockHash, error)
+	DelBlockHashesAfterPostedBlockNumber(postedBlockNumber uint64) error
+}
+
+type RegisteredAccounts interface {
+	CreateRegisteredAccount(
+		accountID uint64,
+		publicKey string,
+		blockNumber uint32,
+		postedBlockNumber uint64,
+	) (*models.RegisteredAccount, error)
+	RegisteredAccounts() ([]*models.RegisteredAccount, error)
+	DelRegisteredAccountsAfterPostedBlockNumber(postedBlockNumber uint64) error
+}
+
+type DepositAMLScreenings interface {
+	UpsertDepositAMLScreening(screening *models.DepositAMLScreening) (*models.DepositAMLScreening, error)
+	DepositAMLScreeningByDepositID(depositID uint64) (*models.DepositAMLScreening, error)
+}
+
+type BlockFees interface {
+	CreateBlockFee(fee *models.BlockFee) (*models.BlockFee, error)
+	BlockFeeByID(id string) (*models.BlockFee, error)
+	BlockFeesByTxRoot(txRoot string) ([]*models.BlockFee, error)
+}
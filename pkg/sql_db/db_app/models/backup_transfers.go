@@ -13,6 +13,7 @@ type BackupTransfer struct {
 	Recipient          string    `json:"recipient"`
 	BlockNumber        uint64    `json:"block_number"`
 	CreatedAt          time.Time `json:"created_at"`
+	Seq                int64     `json:"seq"`
 }
 
 type ListOfBackupTransfer []BackupTransfer
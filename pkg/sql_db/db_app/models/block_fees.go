@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/holiman/uint256"
+)
+
+type BlockFee struct {
+	ID              string
+	ProposalBlockID string
+	TxHash          string
+	Sender          string
+	TokenIndex      uint32
+	Amount          *uint256.Int
+	TransferHash    string
+	CreatedAt       time.Time
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+type BlockHash struct {
+	ID              string
+	BlockNumber     uint32
+	BlockHash       string
+	PrevBlockHash   string
+	DepositTreeRoot string
+	SignatureHash   string
+	CreatedAt       time.Time
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type DepositAMLScreening struct {
+	ID           string
+	DepositID    uint64
+	Sender       string
+	TokenIndex   uint32
+	TokenAddress string
+	Provider     string
+	Score        uint32
+	Threshold    uint32
+	Rejected     bool
+	Reason       string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
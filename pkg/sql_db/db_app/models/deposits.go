@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/holiman/uint256"
+)
+
+type Deposit struct {
+	ID                string
+	DepositID         uint64
+	DepositHash       string
+	RecipientSaltHash string
+	TokenIndex        uint32
+	Amount            *uint256.Int
+	DepositIndex      *uint32
+	CreatedAt         time.Time
+}
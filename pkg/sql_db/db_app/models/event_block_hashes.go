@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// EventBlockHash is the hash of the last block of the range of the blocks scanned for the event.
+type EventBlockHash struct {
+	EventName   string
+	BlockNumber uint64
+	BlockHash   string
+	CreatedAt   time.Time
+}
@@ -6,9 +6,14 @@ const (
 	MessengerSentMessageEvent       = "MessengerSentMessage"
 	WithdrawalsQueuedEvent          = "WithdrawalsQueued"
 	BlockPostedEvent                = "BlockPosted"
+	DepositedEvent                  = "Deposited"
+	DepositsProcessedEvent          = "DepositsProcessed"
+	BlockHashTreeEvent              = "BlockHashTree"
 )
 
 type EventBlockNumber struct {
 	EventName                string
 	LastProcessedBlockNumber uint64
+	// LastProcessedBlockHash is empty when the hash of the last processed block is unknown.
+	LastProcessedBlockHash string
 }
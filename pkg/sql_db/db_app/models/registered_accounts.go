@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type RegisteredAccount struct {
+	AccountID         uint64
+	PublicKey         string
+	BlockNumber       uint32
+	PostedBlockNumber uint64
+	CreatedAt         time.Time
+}
@@ -1,17 +1,35 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type WithdrawalStatus int
 
 const (
-	WS_PENDING WithdrawalStatus = iota
-	WS_SUCCESS
+	WS_REQUESTED WithdrawalStatus = iota
+	WS_PROVING
+	WS_PROVED
+	WS_SUBMITTED
 	WS_FAILED
 )
 
 func (s WithdrawalStatus) String() string {
-	return [...]string{"pending", "success", "failed"}[s]
+	switch s {
+	case WS_REQUESTED:
+		return "requested"
+	case WS_PROVING:
+		return "proving"
+	case WS_PROVED:
+		return "proved"
+	case WS_SUBMITTED:
+		return "submitted"
+	case WS_FAILED:
+		return "failed"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
 }
 
 type TransferData struct {
@@ -41,6 +59,22 @@ type EnoughBalanceProof struct {
 	PublicInputs string `json:"public_inputs"`
 }
 
+// ChainedWithdrawal describes the withdrawal proved by the withdrawal prover.
+type ChainedWithdrawal struct {
+	Recipient   string `json:"recipient"`
+	TokenIndex  uint32 `json:"token_index"`
+	Amount      string `json:"amount"`
+	Nullifier   string `json:"nullifier"`
+	BlockHash   string `json:"block_hash"`
+	BlockNumber uint32 `json:"block_number"`
+}
+
+// WithdrawalProof describes the withdrawal proof chained with the proofs of the previous withdrawals.
+type WithdrawalProof struct {
+	Proof      string            `json:"proof"`
+	Withdrawal ChainedWithdrawal `json:"withdrawal"`
+}
+
 type Withdrawal struct {
 	ID                  string              `json:"id"`
 	Status              int64               `json:"status"`
@@ -52,5 +86,8 @@ type Withdrawal struct {
 	BlockNumber         int64               `json:"block_number"`
 	BlockHash           string              `json:"block_hash"`
 	EnoughBalanceProof  EnoughBalanceProof  `json:"enough_balance_proof"`
+	ProverJobID         string              `json:"prover_job_id"`
+	WithdrawalProof     *WithdrawalProof    `json:"withdrawal_proof"`
 	CreatedAt           time.Time           `json:"created_at"`
+	UpdatedAt           time.Time           `json:"updated_at"`
 }
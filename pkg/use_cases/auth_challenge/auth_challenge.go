@@ -0,0 +1,63 @@
+package auth_challenge
+
+import (
+	"context"
+	"errors"
+	"intmax2-node/configs"
+	"intmax2-node/internal/logger"
+	"intmax2-node/internal/open_telemetry"
+	authChallenge "intmax2-node/internal/use_cases/auth_challenge"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// uc describes use case
+type uc struct {
+	cfg  *configs.Config
+	log  logger.Logger
+	auth StoreVaultAuth
+}
+
+func New(
+	cfg *configs.Config,
+	log logger.Logger,
+	auth StoreVaultAuth,
+) authChallenge.UseCaseAuthChallenge {
+	return &uc{
+		cfg:  cfg,
+		log:  log,
+		auth: auth,
+	}
+}
+
+func (u *uc) Do(
+	ctx context.Context, input *authChallenge.UCAuthChallengeInput,
+) (*authChallenge.UCAuthChallenge, error) {
+	const (
+		hName      = "UseCase AuthChallenge"
+		addressKey = "address"
+	)
+
+	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName)
+	defer span.End()
+
+	if input == nil {
+		open_telemetry.MarkSpanError(spanCtx, ErrUCAuthChallengeInputEmpty)
+		return nil, ErrUCAuthChallengeInputEmpty
+	}
+
+	span.SetAttributes(
+		attribute.String(addressKey, input.Address),
+	)
+
+	challenge, err := u.auth.Challenge(input.Address)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return nil, errors.Join(ErrChallengeFail, err)
+	}
+
+	return &authChallenge.UCAuthChallenge{
+		Nonce:     challenge.Nonce,
+		ExpiresAt: challenge.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,9 @@
+package auth_challenge
+
+import "errors"
+
+// ErrUCAuthChallengeInputEmpty error: ucAuthChallengeInput must not be empty.
+var ErrUCAuthChallengeInputEmpty = errors.New("ucAuthChallengeInput must not be empty")
+
+// ErrChallengeFail error: failed to issue the nonce.
+var ErrChallengeFail = errors.New("failed to issue the nonce")
@@ -0,0 +1,9 @@
+package auth_challenge
+
+import "intmax2-node/internal/store_vault_auth"
+
+//go:generate mockgen -destination=mock_store_vault_auth_test.go -package=auth_challenge_test -source=store_vault_auth.go
+
+type StoreVaultAuth interface {
+	Challenge(address string) (*store_vault_auth.Challenge, error)
+}
@@ -0,0 +1,63 @@
+package auth_login
+
+import (
+	"context"
+	"errors"
+	"intmax2-node/configs"
+	"intmax2-node/internal/logger"
+	"intmax2-node/internal/open_telemetry"
+	authLogin "intmax2-node/internal/use_cases/auth_login"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// uc describes use case
+type uc struct {
+	cfg  *configs.Config
+	log  logger.Logger
+	auth StoreVaultAuth
+}
+
+func New(
+	cfg *configs.Config,
+	log logger.Logger,
+	auth StoreVaultAuth,
+) authLogin.UseCaseAuthLogin {
+	return &uc{
+		cfg:  cfg,
+		log:  log,
+		auth: auth,
+	}
+}
+
+func (u *uc) Do(
+	ctx context.Context, input *authLogin.UCAuthLoginInput,
+) (*authLogin.UCAuthLogin, error) {
+	const (
+		hName      = "UseCase AuthLogin"
+		addressKey = "address"
+	)
+
+	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName)
+	defer span.End()
+
+	if input == nil {
+		open_telemetry.MarkSpanError(spanCtx, ErrUCAuthLoginInputEmpty)
+		return nil, ErrUCAuthLoginInputEmpty
+	}
+
+	span.SetAttributes(
+		attribute.String(addressKey, input.Address),
+	)
+
+	session, err := u.auth.Login(input.Address, input.Nonce, input.Signature)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return nil, errors.Join(ErrLoginFail, err)
+	}
+
+	return &authLogin.UCAuthLogin{
+		Token:     session.Token,
+		ExpiresAt: session.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,9 @@
+package auth_login
+
+import "errors"
+
+// ErrUCAuthLoginInputEmpty error: ucAuthLoginInput must not be empty.
+var ErrUCAuthLoginInputEmpty = errors.New("ucAuthLoginInput must not be empty")
+
+// ErrLoginFail error: failed to log in.
+var ErrLoginFail = errors.New("failed to log in")
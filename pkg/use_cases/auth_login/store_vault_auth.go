@@ -0,0 +1,9 @@
+package auth_login
+
+import "intmax2-node/internal/store_vault_auth"
+
+//go:generate mockgen -destination=mock_store_vault_auth_test.go -package=auth_login_test -source=store_vault_auth.go
+
+type StoreVaultAuth interface {
+	Login(address, nonce, signature string) (*store_vault_auth.Session, error)
+}
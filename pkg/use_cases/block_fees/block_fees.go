@@ -0,0 +1,88 @@
+package block_fees
+
+import (
+	"context"
+	"intmax2-node/configs"
+	"intmax2-node/internal/logger"
+	"intmax2-node/internal/open_telemetry"
+	ucBlockFees "intmax2-node/internal/use_cases/block_fees"
+	"math/big"
+	"sort"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type uc struct {
+	cfg *configs.Config
+	log logger.Logger
+	db  SQLDriverApp
+}
+
+func New(
+	cfg *configs.Config,
+	log logger.Logger,
+	db SQLDriverApp,
+) ucBlockFees.UseCaseBlockFees {
+	return &uc{
+		cfg: cfg,
+		log: log,
+		db:  db,
+	}
+}
+
+// Do returns the fee transfers collected in the block and the totals of the fees per token.
+func (u *uc) Do(
+	ctx context.Context, input *ucBlockFees.UCBlockFeesInput,
+) (*ucBlockFees.UCBlockFees, error) {
+	const (
+		hName         = "UseCase BlockFees"
+		txTreeRootKey = "tx_tree_root"
+	)
+
+	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName,
+		trace.WithAttributes(
+			attribute.String(txTreeRootKey, input.TxTreeRoot),
+		))
+	defer span.End()
+
+	list, err := u.db.BlockFeesByTxRoot(input.TxTreeRoot)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return nil, err
+	}
+
+	fees := ucBlockFees.UCBlockFees{
+		TxTreeRoot: input.TxTreeRoot,
+		Fees:       make([]*ucBlockFees.UCBlockFee, len(list)),
+	}
+
+	totals := make(map[uint32]*big.Int)
+	for key := range list {
+		fees.Fees[key] = &ucBlockFees.UCBlockFee{
+			TxHash:       list[key].TxHash,
+			Sender:       list[key].Sender,
+			TokenIndex:   list[key].TokenIndex,
+			Amount:       list[key].Amount.ToBig().String(),
+			TransferHash: list[key].TransferHash,
+		}
+
+		if _, ok := totals[list[key].TokenIndex]; !ok {
+			totals[list[key].TokenIndex] = new(big.Int)
+		}
+		totals[list[key].TokenIndex].Add(totals[list[key].TokenIndex], list[key].Amount.ToBig())
+	}
+
+	fees.Totals = make([]*ucBlockFees.UCBlockFeeTotal, 0, len(totals))
+	for tokenIndex := range totals {
+		fees.Totals = append(fees.Totals, &ucBlockFees.UCBlockFeeTotal{
+			TokenIndex: tokenIndex,
+			Amount:     totals[tokenIndex].String(),
+		})
+	}
+	sort.Slice(fees.Totals, func(i, j int) bool {
+		return fees.Totals[i].TokenIndex < fees.Totals[j].TokenIndex
+	})
+
+	return &fees, nil
+}
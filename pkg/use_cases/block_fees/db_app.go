@@ -0,0 +1,21 @@
+package block_fees
+
+import (
+	"context"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+)
+
+//go:generate mockgen -destination=mock_db_app_test.go -package=block_fees_test -source=db_app.go
+
+type SQLDriverApp interface {
+	GenericCommandsApp
+	BlockFees
+}
+
+type GenericCommandsApp interface {
+	Exec(ctx context.Context, input interface{}, executor func(d interface{}, input interface{}) error) (err error)
+}
+
+type BlockFees interface {
+	BlockFeesByTxRoot(txRoot string) ([]*mDBApp.BlockFee, error)
+}
@@ -14,17 +14,20 @@ import (
 
 // uc describes use case
 type uc struct {
-	cfg        *configs.Config
-	storageGPO GPOStorage
+	cfg           *configs.Config
+	storageGPO    GPOStorage
+	powDifficulty PoWDifficulty
 }
 
 func New(
 	cfg *configs.Config,
 	storageGPO GPOStorage,
+	powDifficulty PoWDifficulty,
 ) blockInfo.UseCaseBlockInfo {
 	return &uc{
-		cfg:        cfg,
-		storageGPO: storageGPO,
+		cfg:           cfg,
+		storageGPO:    storageGPO,
+		powDifficulty: powDifficulty,
 	}
 }
 
@@ -38,9 +41,13 @@ func (u *uc) Do(
 	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName)
 	defer span.End()
 
+	difficulty := u.powDifficulty.Current()
+
 	info := blockInfo.UCBlockInfo{
-		TransferFee: make(map[string]string),
-		Difficulty:  int64(u.cfg.PoW.Difficulty),
+		TransferFee:          make(map[string]string),
+		QuotedTransferFee:    make(map[string]string),
+		Difficulty:           int64(difficulty.Value),
+		DifficultyValidUntil: difficulty.ValidUntil,
 	}
 
 	w, err := mnemonic_wallet.New().WalletFromPrivateKeyHex(u.cfg.Blockchain.BuilderPrivateKeyHex)
@@ -63,6 +70,13 @@ func (u *uc) Do(
 			return nil, errors.Join(ErrStorageGPOValueFail, err)
 		}
 		info.TransferFee[strconv.Itoa(key)] = v.String()
+
+		v, err = u.storageGPO.QuotedValue(spanCtx, list[key])
+		if err != nil {
+			open_telemetry.MarkSpanError(spanCtx, err)
+			return nil, errors.Join(ErrStorageGPOValueFail, err)
+		}
+		info.QuotedTransferFee[strconv.Itoa(key)] = v.String()
 	}
 
 	return &info, nil
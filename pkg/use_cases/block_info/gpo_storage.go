@@ -9,4 +9,5 @@ import (
 
 type GPOStorage interface {
 	Value(ctx context.Context, name string) (*big.Int, error)
+	QuotedValue(ctx context.Context, name string) (*big.Int, error)
 }
@@ -0,0 +1,9 @@
+package block_info
+
+import "intmax2-node/internal/pow"
+
+//go:generate mockgen -destination=mock_pow_difficulty_test.go -package=block_info_test -source=pow_difficulty.go
+
+type PoWDifficulty interface {
+	Current() *pow.Difficulty
+}
@@ -0,0 +1,73 @@
+package block_merkle_proof
+
+import (
+	"context"
+	"errors"
+	"intmax2-node/configs"
+	"intmax2-node/internal/logger"
+	"intmax2-node/internal/open_telemetry"
+	ucBlockMerkleProof "intmax2-node/internal/use_cases/block_merkle_proof"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// uc describes use case
+type uc struct {
+	cfg *configs.Config
+	log logger.Logger
+	bvp BlockValidityProver
+}
+
+func New(
+	cfg *configs.Config,
+	log logger.Logger,
+	bvp BlockValidityProver,
+) ucBlockMerkleProof.UseCaseBlockMerkleProof {
+	return &uc{
+		cfg: cfg,
+		log: log,
+		bvp: bvp,
+	}
+}
+
+func (u *uc) Do(
+	ctx context.Context, input *ucBlockMerkleProof.UCBlockMerkleProofInput,
+) (*ucBlockMerkleProof.UCBlockMerkleProof, error) {
+	const (
+		hName              = "UseCase BlockMerkleProof"
+		blockNumberKey     = "block_number"
+		rootBlockNumberKey = "root_block_number"
+	)
+
+	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName)
+	defer span.End()
+
+	if input == nil {
+		open_telemetry.MarkSpanError(spanCtx, ErrUCInputEmpty)
+		return nil, ErrUCInputEmpty
+	}
+
+	span.SetAttributes(
+		attribute.Int64(blockNumberKey, int64(input.BlockNumber)),
+		attribute.Int64(rootBlockNumberKey, int64(input.RootBlockNumber)),
+	)
+
+	proof, err := u.bvp.GetBlockMerkleProof(input.BlockNumber, input.RootBlockNumber)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return nil, errors.Join(ErrGetBlockMerkleProofFail, err)
+	}
+
+	resp := ucBlockMerkleProof.UCBlockMerkleProof{
+		BlockNumber:     proof.BlockNumber,
+		BlockHash:       proof.BlockHash.Hex(),
+		RootBlockNumber: proof.RootBlockNumber,
+		Siblings:        make([]string, len(proof.Siblings)),
+		Root:            proof.Root.Hex(),
+	}
+	for key := range proof.Siblings {
+		resp.Siblings[key] = proof.Siblings[key].Hex()
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,9 @@
+package block_merkle_proof
+
+import "intmax2-node/internal/block_validity_prover"
+
+//go:generate mockgen -destination=mock_block_validity_prover_test.go -package=block_merkle_proof_test -source=block_validity_prover.go
+
+type BlockValidityProver interface {
+	GetBlockMerkleProof(blockNumber, rootBlockNumber uint32) (*block_validity_prover.BlockMerkleProof, error)
+}
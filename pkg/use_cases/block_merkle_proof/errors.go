@@ -0,0 +1,9 @@
+package block_merkle_proof
+
+import "errors"
+
+// ErrUCInputEmpty error: uc-input must not be empty.
+var ErrUCInputEmpty = errors.New("uc-input must not be empty")
+
+// ErrGetBlockMerkleProofFail error: failed to get the block Merkle proof.
+var ErrGetBlockMerkleProofFail = errors.New("failed to get the block Merkle proof")
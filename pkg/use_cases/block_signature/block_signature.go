@@ -6,6 +6,7 @@ import (
 	"intmax2-node/configs"
 	intMaxAcc "intmax2-node/internal/accounts"
 	"intmax2-node/internal/block_post_service"
+	"intmax2-node/internal/hash/goldenposeidon"
 	"intmax2-node/internal/logger"
 	"intmax2-node/internal/open_telemetry"
 	intMaxTypes "intmax2-node/internal/types"
@@ -63,20 +64,43 @@ func (u *uc) Do(
 	// NOTICE: Perform signature verification during validation.
 
 	prevBalancePublicInputs, err := backup_balance.VerifyEnoughBalanceProof(input.EnoughBalanceProof.PrevBalanceProof)
-	transferPublicInputs, err := VerifyTransferStepProof(input.EnoughBalanceProof.TransferStepProof)
-	_ = prevBalancePublicInputs.Equal(&transferPublicInputs.PrevBalancePis)
-	/*
-		// TODO: Check public inputs.
-		if !ok {
-			open_telemetry.MarkSpanError(spanCtx, ErrInvalidEnoughBalanceProof)
-			return ErrInvalidEnoughBalanceProof
-		}
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return errors.Join(ucBlockSignature.ErrInvalidEnoughBalanceProof, err)
+	}
+
+	if input.TxInfo == nil {
+		open_telemetry.MarkSpanError(spanCtx, ucBlockSignature.ErrTransactionHashNotFound)
+		return ucBlockSignature.ErrTransactionHashNotFound
+	}
+
+	sender, err := intMaxAcc.NewPublicKeyFromAddressHex(input.Sender)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return errors.Join(ErrDecodeSenderFail, err)
+	}
+
+	var txHash goldenposeidon.PoseidonHashOut
+	err = txHash.FromString(input.TxInfo.TxHash)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return errors.Join(ErrDecodeTxHashFail, err)
+	}
+
+	transferPublicInputs, err := VerifyTransferStepProof(
+		input.EnoughBalanceProof.TransferStepProof, sender, &txHash, input.TxInfo.Nonce,
+	)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return errors.Join(ucBlockSignature.ErrInvalidEnoughBalanceProof, err)
+	}
+
+	// The transfer step must start from the balance proved by the balance proof.
+	if !prevBalancePublicInputs.Equal(&transferPublicInputs.PrevBalancePis) {
+		open_telemetry.MarkSpanError(spanCtx, ErrTransferStepPrevBalanceMismatch)
+		return errors.Join(ucBlockSignature.ErrInvalidEnoughBalanceProof, ErrTransferStepPrevBalanceMismatch)
+	}
 
-		input.TxInfo = &worker.TransactionHashesWithSenderAndFile{
-			Sender: input.Sender,
-			File:   nil,
-		}
-	*/
 	b, err := block_post_service.NewBlockPostService(ctx, u.cfg, u.log)
 	if err != nil {
 		var ErrNewBlockPostServiceFail = errors.New("new block post service fail")
@@ -85,7 +109,6 @@ func (u *uc) Do(
 
 	// Backup transaction and transfer
 	blockNumber := uint64(1) // dummy
-	sender, err := intMaxAcc.NewPublicKeyFromAddressHex(input.Sender)
 	if innerErr := b.BackupTransaction(
 		sender.ToAddress(),
 		input.BackupTx.TxHash,
@@ -162,30 +185,106 @@ func (u *uc) Do(
 	return nil
 }
 
+// TRANSFER_STEP_PUBLIC_INPUTS_LEN is the length of the public inputs of the transfer step proof
+// without the verifier data of the transfer step circuit.
+const TRANSFER_STEP_PUBLIC_INPUTS_LEN uint = 2*backup_balance.BALANCE_PUBLIC_INPUTS_LEN + 2*goldenposeidon.NUM_HASH_OUT_ELTS
+
+// TransferStepPublicInputs describes the public inputs of the transfer step proof that spends
+// the balance of the sender with the tx.
 type TransferStepPublicInputs struct {
-	PrevBalancePis backup_balance.BalancePublicInputs
-	NextBalancePis backup_balance.BalancePublicInputs
+	PrevBalancePis   backup_balance.BalancePublicInputs
+	NextBalancePis   backup_balance.BalancePublicInputs
+	TxHash           goldenposeidon.PoseidonHashOut
+	TransferTreeRoot goldenposeidon.PoseidonHashOut
 }
 
-func (pis *TransferStepPublicInputs) FromPublicInputs(publicInputs []ffg.Element) *TransferStepPublicInputs {
-	return pis
+// FromPublicInputs decodes the public inputs of the transfer step proof. They are laid out as
+// the previous balance public inputs, the next balance public inputs, the tx hash and the transfer tree root
+// of the tx, followed by the verifier data of the transfer step circuit.
+func (pis *TransferStepPublicInputs) FromPublicInputs(publicInputs []ffg.Element) (*TransferStepPublicInputs, error) {
+	if uint(len(publicInputs)) < TRANSFER_STEP_PUBLIC_INPUTS_LEN {
+		return nil, ErrTransferStepPublicInputsLengthInvalid
+	}
+
+	offset := uint(0)
+	_, err := pis.PrevBalancePis.FromPublicInputs(publicInputs[offset : offset+backup_balance.BALANCE_PUBLIC_INPUTS_LEN])
+	if err != nil {
+		return nil, err
+	}
+	offset += backup_balance.BALANCE_PUBLIC_INPUTS_LEN
+
+	_, err = pis.NextBalancePis.FromPublicInputs(publicInputs[offset : offset+backup_balance.BALANCE_PUBLIC_INPUTS_LEN])
+	if err != nil {
+		return nil, err
+	}
+	offset += backup_balance.BALANCE_PUBLIC_INPUTS_LEN
+
+	copy(pis.TxHash.Elements[:], publicInputs[offset:offset+goldenposeidon.NUM_HASH_OUT_ELTS])
+	offset += goldenposeidon.NUM_HASH_OUT_ELTS
+
+	copy(pis.TransferTreeRoot.Elements[:], publicInputs[offset:offset+goldenposeidon.NUM_HASH_OUT_ELTS])
+
+	return pis, nil
 }
 
-func (pis *TransferStepPublicInputs) Verify() error {
+// Verify checks that the transfer step proof spends the balance of the sender with the tx
+// of the given hash and nonce.
+func (pis *TransferStepPublicInputs) Verify(
+	sender *intMaxAcc.PublicKey,
+	txHash *goldenposeidon.PoseidonHashOut,
+	nonce uint64,
+) error {
+	senderPublicKey := sender.BigInt()
+	if pis.PrevBalancePis.PublicKey == nil || pis.PrevBalancePis.PublicKey.Cmp(senderPublicKey) != 0 ||
+		pis.NextBalancePis.PublicKey == nil || pis.NextBalancePis.PublicKey.Cmp(senderPublicKey) != 0 {
+		return ErrTransferStepSenderMismatch
+	}
+
+	if !pis.TxHash.Equal(txHash) {
+		return ErrTransferStepTxHashMismatch
+	}
+
+	tx, err := intMaxTypes.NewTx(&pis.TransferTreeRoot, nonce)
+	if err != nil {
+		return errors.Join(ErrTransferStepTransferTreeRootMismatch, err)
+	}
+	if !tx.Hash().Equal(&pis.TxHash) {
+		return ErrTransferStepTransferTreeRootMismatch
+	}
+
+	if !pis.NextBalancePis.LastTxHash.Equal(&pis.TxHash) {
+		return ErrTransferStepLastTxHashMismatch
+	}
+
+	if !pis.NextBalancePis.PublicState.Equal(&pis.PrevBalancePis.PublicState) {
+		return ErrTransferStepPublicStateMismatch
+	}
+
 	return nil
 }
 
-func VerifyTransferStepProof(transferStepProof *ucBlockSignature.Plonky2Proof) (*TransferStepPublicInputs, error) {
+func VerifyTransferStepProof(
+	transferStepProof *ucBlockSignature.Plonky2Proof,
+	sender *intMaxAcc.PublicKey,
+	txHash *goldenposeidon.PoseidonHashOut,
+	nonce uint64,
+) (*TransferStepPublicInputs, error) {
 	publicInputs := make([]ffg.Element, len(transferStepProof.PublicInputs))
 	for i, publicInput := range transferStepProof.PublicInputs {
 		publicInputs[i].SetUint64(publicInput)
 	}
-	decodedPublicInputs := new(TransferStepPublicInputs).FromPublicInputs(publicInputs)
-	err := decodedPublicInputs.Verify()
+	decodedPublicInputs, err := new(TransferStepPublicInputs).FromPublicInputs(publicInputs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = decodedPublicInputs.Verify(sender, txHash, nonce)
 	if err != nil {
 		return nil, err
 	}
 
+	// TODO: Verify verifier data in public inputs.
+
 	// TODO: Verify enough balance proof by using Balance Validity Prover.
 	return decodedPublicInputs, nil
 }
@@ -5,14 +5,18 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"intmax2-node/configs"
 	intMaxAcc "intmax2-node/internal/accounts"
 	"intmax2-node/internal/block_post_service"
 	"intmax2-node/internal/finite_field"
+	"intmax2-node/internal/hash/goldenposeidon"
 	"intmax2-node/internal/mnemonic_wallet"
 	intMaxTree "intmax2-node/internal/tree"
 	intMaxTypes "intmax2-node/internal/types"
+	"intmax2-node/internal/use_cases/backup_balance"
 	blockSignature "intmax2-node/internal/use_cases/block_signature"
+	"intmax2-node/internal/worker"
 	ucBlockSignature "intmax2-node/pkg/use_cases/block_signature"
 	"os"
 	"strconv"
@@ -30,6 +34,172 @@ var ErrCannotReadBinaryFile = errors.New("cannot read binary file")
 
 var ErrCannotParseJson = errors.New("cannot parse JSON")
 
+// transferStepFixture returns the sender, the tx and the enough balance proof of the transfer step fixture
+// that spends the balance of balance_proof.bin with the zero tx.
+func transferStepFixture(t *testing.T) (string, *intMaxTypes.Tx, *blockSignature.EnoughBalanceProofInput) {
+	balancePublicInputs, err := readPlonky2PublicInputsJson("balance_proof_public_inputs.json")
+	assert.NoError(t, err)
+	proof, err := readPlonky2ProofBinary("balance_proof.bin")
+	assert.NoError(t, err)
+	transferStepPublicInputs, err := readPlonky2PublicInputsJson("../../data/transfer_step_public_inputs.json")
+	assert.NoError(t, err)
+
+	prevBalancePublicInputs, err := backup_balance.VerifyEnoughBalanceProof(&blockSignature.Plonky2Proof{
+		PublicInputs: balancePublicInputs,
+		Proof:        proof,
+	})
+	assert.NoError(t, err)
+	sender := fmt.Sprintf("0x%064x", prevBalancePublicInputs.PublicKey)
+
+	zeroTransfer := new(intMaxTypes.Transfer).SetZero()
+	transferTree, err := intMaxTree.NewTransferTree(6, nil, zeroTransfer.Hash())
+	assert.NoError(t, err)
+	transferTreeRoot, _, _ := transferTree.GetCurrentRootCountAndSiblings()
+	tx, err := intMaxTypes.NewTx(&transferTreeRoot, 0)
+	assert.NoError(t, err)
+
+	return sender, tx, &blockSignature.EnoughBalanceProofInput{
+		PrevBalanceProof: &blockSignature.Plonky2Proof{
+			PublicInputs: balancePublicInputs,
+			Proof:        proof,
+		},
+		TransferStepProof: &blockSignature.Plonky2Proof{
+			PublicInputs: transferStepPublicInputs,
+			Proof:        proof,
+		},
+	}
+}
+
+func TestVerifyTransferStepProof(t *testing.T) {
+	const (
+		nextBalanceOffset = backup_balance.BALANCE_PUBLIC_INPUTS_LEN
+		lastTxHashOffset  = nextBalanceOffset + backup_balance.U256_LEN + goldenposeidon.NUM_HASH_OUT_ELTS
+		blockNumberOffset = nextBalanceOffset + backup_balance.BALANCE_PUBLIC_INPUTS_LEN - 1
+	)
+
+	senderAddress, tx, enoughBalanceProof := transferStepFixture(t)
+	sender, err := intMaxAcc.NewPublicKeyFromAddressHex(senderAddress)
+	assert.NoError(t, err)
+
+	wrongSenderAccount, err := mnemonic_wallet.New().WalletGenerator("m/44'/60'/0'/0/0", "")
+	assert.NoError(t, err)
+	wrongSender, err := intMaxAcc.NewPublicKeyFromAddressHex(wrongSenderAccount.IntMaxWalletAddress)
+	assert.NoError(t, err)
+
+	modify := func(index uint, value uint64) *blockSignature.Plonky2Proof {
+		proof := new(blockSignature.Plonky2Proof).Set(enoughBalanceProof.TransferStepProof)
+		proof.PublicInputs[index] = value
+		return proof
+	}
+	truncated := new(blockSignature.Plonky2Proof).Set(enoughBalanceProof.TransferStepProof)
+	truncated.PublicInputs = truncated.PublicInputs[:ucBlockSignature.TRANSFER_STEP_PUBLIC_INPUTS_LEN-1]
+
+	cases := []struct {
+		desc   string
+		proof  *blockSignature.Plonky2Proof
+		sender *intMaxAcc.PublicKey
+		txHash *intMaxTypes.PoseidonHashOut
+		nonce  uint64
+		err    error
+	}{
+		{
+			desc:   "Success",
+			proof:  enoughBalanceProof.TransferStepProof,
+			sender: sender,
+			txHash: tx.Hash(),
+			nonce:  tx.Nonce,
+		},
+		{
+			desc:   "Public inputs are too short",
+			proof:  truncated,
+			sender: sender,
+			txHash: tx.Hash(),
+			nonce:  tx.Nonce,
+			err:    ucBlockSignature.ErrTransferStepPublicInputsLengthInvalid,
+		},
+		{
+			desc:   "Sender is not the owner of the balance",
+			proof:  enoughBalanceProof.TransferStepProof,
+			sender: wrongSender,
+			txHash: tx.Hash(),
+			nonce:  tx.Nonce,
+			err:    ucBlockSignature.ErrTransferStepSenderMismatch,
+		},
+		{
+			desc:   "Tx hash is not the signed tx",
+			proof:  enoughBalanceProof.TransferStepProof,
+			sender: sender,
+			txHash: new(intMaxTypes.PoseidonHashOut).SetZero(),
+			nonce:  tx.Nonce,
+			err:    ucBlockSignature.ErrTransferStepTxHashMismatch,
+		},
+		{
+			desc:   "Transfer tree root is not the root of the signed tx",
+			proof:  enoughBalanceProof.TransferStepProof,
+			sender: sender,
+			txHash: tx.Hash(),
+			nonce:  tx.Nonce + 1,
+			err:    ucBlockSignature.ErrTransferStepTransferTreeRootMismatch,
+		},
+		{
+			desc:   "Next balance does not include the signed tx",
+			proof:  modify(lastTxHashOffset, 0),
+			sender: sender,
+			txHash: tx.Hash(),
+			nonce:  tx.Nonce,
+			err:    ucBlockSignature.ErrTransferStepLastTxHashMismatch,
+		},
+		{
+			desc:   "Public state is changed",
+			proof:  modify(blockNumberOffset, 1<<31),
+			sender: sender,
+			txHash: tx.Hash(),
+			nonce:  tx.Nonce,
+			err:    ucBlockSignature.ErrTransferStepPublicStateMismatch,
+		},
+	}
+
+	for i := range cases {
+		t.Run(cases[i].desc, func(t *testing.T) {
+			pis, err := ucBlockSignature.VerifyTransferStepProof(
+				cases[i].proof, cases[i].sender, cases[i].txHash, cases[i].nonce,
+			)
+			if cases[i].err != nil {
+				assert.ErrorIs(t, err, cases[i].err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, pis.TxHash.Equal(tx.Hash()))
+			assert.True(t, pis.TransferTreeRoot.Equal(tx.TransferTreeRoot))
+			assert.True(t, pis.NextBalancePis.LastTxHash.Equal(tx.Hash()))
+		})
+	}
+
+	t.Run("Previous balance is not the balance of the balance proof", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uc := ucBlockSignature.New(new(configs.Config), nil, NewMockWorker(ctrl))
+
+		wrongEnoughBalanceProof := new(blockSignature.EnoughBalanceProofInput).Set(enoughBalanceProof)
+		// Change the private commitment of the balance proof.
+		wrongEnoughBalanceProof.PrevBalanceProof.PublicInputs[backup_balance.U256_LEN]++
+
+		err := uc.Do(context.Background(), &blockSignature.UCBlockSignatureInput{
+			Sender: senderAddress,
+			TxInfo: &worker.TransactionHashesWithSenderAndFile{
+				Sender: senderAddress,
+				TxHash: tx.Hash().String(),
+				Nonce:  tx.Nonce,
+			},
+			EnoughBalanceProof: wrongEnoughBalanceProof,
+		})
+		assert.ErrorIs(t, err, blockSignature.ErrInvalidEnoughBalanceProof)
+		assert.ErrorIs(t, err, ucBlockSignature.ErrTransferStepPrevBalanceMismatch)
+	})
+}
+
 func TestUseCaseTransaction(t *testing.T) {
 	const int3Key = 3
 	assert.NoError(t, configs.LoadDotEnv(int3Key))
@@ -51,7 +221,6 @@ func TestUseCaseTransaction(t *testing.T) {
 		derivation, mnPassword,
 	)
 	assert.NoError(t, err)
-	sender := senderAccount.IntMaxWalletAddress
 
 	wrongSenderAccount, err := mnemonic_wallet.New().WalletGenerator(
 		derivation, mnPassword,
@@ -76,23 +245,7 @@ func TestUseCaseTransaction(t *testing.T) {
 	signature, err := signer.Sign(flattenMessage)
 	assert.NoError(t, err)
 
-	publicInputs, err := readPlonky2PublicInputsJson("balance_proof_public_inputs.json")
-	assert.NoError(t, err)
-	proof, err := readPlonky2ProofBinary("balance_proof.bin")
-	assert.NoError(t, err)
-
-	enoughBalanceProof := &blockSignature.EnoughBalanceProofInput{
-		PrevBalanceProof: &blockSignature.Plonky2Proof{
-			PublicInputs: publicInputs,
-			Proof:        proof,
-		},
-		TransferStepProof: &blockSignature.Plonky2Proof{
-			PublicInputs: []uint64{
-				2726224824249046055, 14025881618846813748, 5361314524880173070, 2912484915938769214,
-			},
-			Proof: []byte{0x99, 0x39, 0x6b, 0x28},
-		}, // dummy
-	}
+	balanceSender, balanceTx, enoughBalanceProof := transferStepFixture(t)
 	wrongEnoughBalanceProof := new(blockSignature.EnoughBalanceProofInput).Set(enoughBalanceProof)
 	wrongEnoughBalanceProof.PrevBalanceProof.PublicInputs[0] = 2726224824249046055
 
@@ -104,8 +257,13 @@ func TestUseCaseTransaction(t *testing.T) {
 		{
 			desc: "Success",
 			input: &blockSignature.UCBlockSignatureInput{
-				Sender:             sender,
-				TxHash:             hex.EncodeToString(txHash),
+				Sender: balanceSender,
+				TxHash: hex.EncodeToString(txHash),
+				TxInfo: &worker.TransactionHashesWithSenderAndFile{
+					Sender: balanceSender,
+					TxHash: balanceTx.Hash().String(),
+					Nonce:  balanceTx.Nonce,
+				},
 				Signature:          hex.EncodeToString(signature.Marshal()),
 				EnoughBalanceProof: enoughBalanceProof,
 			},
@@ -4,3 +4,31 @@ import "errors"
 
 // ErrSignTxTreeByAvailableFileFail error: failed to sign of tx tree by available file.
 var ErrSignTxTreeByAvailableFileFail = errors.New("failed to sign of tx tree by available file")
+
+// ErrTransferStepPublicInputsLengthInvalid error: the length of transfer step public inputs is invalid.
+var ErrTransferStepPublicInputsLengthInvalid = errors.New("the length of transfer step public inputs is invalid")
+
+// ErrTransferStepSenderMismatch error: the public key of the transfer step proof is not the sender.
+var ErrTransferStepSenderMismatch = errors.New("the public key of the transfer step proof is not the sender")
+
+// ErrTransferStepTxHashMismatch error: the tx hash of the transfer step proof is not the signed tx.
+var ErrTransferStepTxHashMismatch = errors.New("the tx hash of the transfer step proof is not the signed tx")
+
+// ErrTransferStepTransferTreeRootMismatch error: the transfer tree root of the transfer step proof is not the root of the signed tx.
+var ErrTransferStepTransferTreeRootMismatch = errors.New(
+	"the transfer tree root of the transfer step proof is not the root of the signed tx",
+)
+
+// ErrTransferStepLastTxHashMismatch error: the next balance of the transfer step proof does not include the signed tx.
+var ErrTransferStepLastTxHashMismatch = errors.New("the next balance of the transfer step proof does not include the signed tx")
+
+// ErrTransferStepPublicStateMismatch error: the public state of the transfer step proof is changed.
+var ErrTransferStepPublicStateMismatch = errors.New("the public state of the transfer step proof is changed")
+
+// ErrTransferStepPrevBalanceMismatch error: the previous balance of the transfer step proof is not the balance of the balance proof.
+var ErrTransferStepPrevBalanceMismatch = errors.New(
+	"the previous balance of the transfer step proof is not the balance of the balance proof",
+)
+
+// ErrDecodeSenderFail error: failed to decode the sender.
+var ErrDecodeSenderFail = errors.New("failed to decode the sender")
@@ -0,0 +1,256 @@
+package block_status_subscribe
+
+import (
+	"context"
+	"errors"
+	"intmax2-node/configs"
+	"intmax2-node/internal/logger"
+	"intmax2-node/internal/open_telemetry"
+	ucBlockStatusSubscribe "intmax2-node/internal/use_cases/block_status_subscribe"
+	"intmax2-node/internal/worker"
+	errorsDB "intmax2-node/pkg/sql_db/errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type uc struct {
+	cfg    *configs.Config
+	log    logger.Logger
+	db     SQLDriverApp
+	worker Worker
+}
+
+func New(
+	cfg *configs.Config,
+	log logger.Logger,
+	db SQLDriverApp,
+	w Worker,
+) ucBlockStatusSubscribe.UseCaseBlockStatusSubscribe {
+	return &uc{
+		cfg:    cfg,
+		log:    log,
+		db:     db,
+		worker: w,
+	}
+}
+
+// Do sends the lifecycle events of the transaction or the tx tree root until the posted or the dropped event.
+// The events of the worker are sent as they come, the posted block is polled from the database
+// after the tx tree root is known. The subscription of the transaction follows its tx tree too,
+// so that the drop of the whole tx tree is fanned out to the transaction.
+func (u *uc) Do(
+	ctx context.Context,
+	input *ucBlockStatusSubscribe.UCBlockStatusSubscribeInput,
+	send func(event *ucBlockStatusSubscribe.UCBlockStatusEvent) error,
+) (err error) {
+	const (
+		hName         = "UseCase BlockStatusSubscribe"
+		txHashKey     = "tx_hash"
+		txTreeRootKey = "tx_tree_root"
+	)
+
+	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName)
+	defer span.End()
+
+	if input == nil {
+		open_telemetry.MarkSpanError(spanCtx, ErrUCInputEmpty)
+		return ErrUCInputEmpty
+	}
+
+	span.SetAttributes(
+		attribute.String(txHashKey, input.TxHash),
+		attribute.String(txTreeRootKey, input.TxTreeRoot),
+	)
+
+	spanCtx, cancel := context.WithTimeout(spanCtx, u.cfg.Worker.BlockStatusSubscriptionTimeout)
+	defer cancel()
+
+	key := input.TxTreeRoot
+	if input.TxHash != "" {
+		key = input.TxHash
+	}
+
+	// The subscription precedes the snapshot of the state, so that no event is missed between them.
+	events, unsubscribe := u.worker.Subscribe(key)
+	defer unsubscribe()
+
+	// The nil channel of the tx tree events is never ready until the tx tree of the transaction is known.
+	var treeEvents <-chan *worker.TxEvent
+	unsubscribeTree := func() {}
+	defer func() {
+		unsubscribeTree()
+	}()
+	followTree := func(txTreeRoot string) {
+		if input.TxHash == "" || txTreeRoot == "" || treeEvents != nil {
+			return
+		}
+		treeEvents, unsubscribeTree = u.worker.Subscribe(txTreeRoot)
+	}
+
+	txTreeRoot := input.TxTreeRoot
+	if input.TxHash != "" {
+		var done bool
+		txTreeRoot, done, err = u.snapshot(input.TxHash, send)
+		if err != nil {
+			open_telemetry.MarkSpanError(spanCtx, err)
+			return err
+		}
+		if done {
+			return nil
+		}
+		followTree(txTreeRoot)
+	}
+
+	ticker := time.NewTicker(u.cfg.Worker.BlockStatusPollInterval)
+	defer ticker.Stop()
+
+	poll := txTreeRoot != ""
+	for {
+		if poll {
+			var posted bool
+			posted, err = u.posted(input.TxHash, txTreeRoot, send)
+			if err != nil {
+				open_telemetry.MarkSpanError(spanCtx, err)
+				return err
+			}
+			if posted {
+				return nil
+			}
+			poll = false
+		}
+
+		select {
+		case <-spanCtx.Done():
+			return nil
+		case event := <-events:
+			err = send(toUCEvent(event))
+			if err != nil {
+				return errors.Join(ErrSendEventFail, err)
+			}
+
+			switch event.Type {
+			case worker.TxEventSigned:
+				txTreeRoot = event.TxTreeRoot
+				poll = txTreeRoot != ""
+			case worker.TxEventDropped:
+				// The tx tree root subscription is finished with the drop of the whole tx tree only.
+				if input.TxHash != "" || event.TxHash == "" {
+					return nil
+				}
+			}
+			followTree(event.TxTreeRoot)
+		case event := <-treeEvents:
+			// The events of the other transactions of the tx tree are not sent.
+			if event.TxHash != "" || event.Type != worker.TxEventDropped {
+				continue
+			}
+
+			dropped := toUCEvent(event)
+			dropped.TxHash = input.TxHash
+			err = send(dropped)
+			if err != nil {
+				return errors.Join(ErrSendEventFail, err)
+			}
+
+			return nil
+		case <-ticker.C:
+			poll = txTreeRoot != ""
+		}
+	}
+}
+
+// snapshot sends the state of the transaction known before the subscription.
+func (u *uc) snapshot(
+	txHash string,
+	send func(event *ucBlockStatusSubscribe.UCBlockStatusEvent) error,
+) (txTreeRoot string, done bool, err error) {
+	_, err = u.worker.TrHash(txHash)
+	if err == nil {
+		err = send(&ucBlockStatusSubscribe.UCBlockStatusEvent{
+			Event:     worker.TxEventAccepted,
+			TxHash:    txHash,
+			CreatedAt: time.Now().UTC(),
+		})
+		if err != nil {
+			return "", false, errors.Join(ErrSendEventFail, err)
+		}
+
+		return "", false, nil
+	}
+
+	proofs, err := u.db.TxMerkleProofsByTxHash(txHash)
+	if errors.Is(err, errorsDB.ErrNotFound) {
+		// The transaction is not received yet.
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, errors.Join(ErrTxMerkleProofsByTxHashFail, err)
+	}
+
+	event := ucBlockStatusSubscribe.UCBlockStatusEvent{
+		Event:      worker.TxEventSigned,
+		TxHash:     txHash,
+		TxTreeRoot: proofs.TxTreeRoot,
+		CreatedAt:  proofs.CreatedAt,
+	}
+	if proofs.SignatureID == "" {
+		event.Event = worker.TxEventDropped
+		event.Reason = worker.ReasonTxNotSigned
+		done = true
+	}
+
+	err = send(&event)
+	if err != nil {
+		return "", false, errors.Join(ErrSendEventFail, err)
+	}
+
+	return proofs.TxTreeRoot, done, nil
+}
+
+// posted sends the posted event when the block of the tx tree root is posted.
+func (u *uc) posted(
+	txHash, txTreeRoot string,
+	send func(event *ucBlockStatusSubscribe.UCBlockStatusEvent) error,
+) (bool, error) {
+	block, err := u.db.BlockByTxRoot(txTreeRoot)
+	if errors.Is(err, errorsDB.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Join(ErrBlockByTxRootFail, err)
+	}
+
+	event := ucBlockStatusSubscribe.UCBlockStatusEvent{
+		Event:      worker.TxEventPosted,
+		TxHash:     txHash,
+		TxTreeRoot: txTreeRoot,
+		BlockHash:  block.BlockHash,
+		CreatedAt:  time.Now().UTC(),
+	}
+	if block.BlockNumber != nil {
+		event.BlockNumber = uint64(*block.BlockNumber)
+	}
+	if block.PostedAt != nil {
+		event.CreatedAt = *block.PostedAt
+	}
+
+	err = send(&event)
+	if err != nil {
+		return false, errors.Join(ErrSendEventFail, err)
+	}
+
+	return true, nil
+}
+
+func toUCEvent(event *worker.TxEvent) *ucBlockStatusSubscribe.UCBlockStatusEvent {
+	return &ucBlockStatusSubscribe.UCBlockStatusEvent{
+		Event:       event.Type,
+		TxHash:      event.TxHash,
+		TxTreeRoot:  event.TxTreeRoot,
+		BlockNumber: event.BlockNumber,
+		BlockHash:   event.BlockHash,
+		Reason:      event.Reason,
+		CreatedAt:   event.CreatedAt,
+	}
+}
@@ -0,0 +1,269 @@
+package block_status_subscribe_test
+
+import (
+	"context"
+	"errors"
+	"intmax2-node/configs"
+	ucBlockStatusSubscribe "intmax2-node/internal/use_cases/block_status_subscribe"
+	"intmax2-node/internal/worker"
+	"intmax2-node/pkg/logger"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+	errorsDB "intmax2-node/pkg/sql_db/errors"
+	"intmax2-node/pkg/use_cases/block_status_subscribe"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCaseBlockStatusSubscribe(t *testing.T) {
+	const (
+		txHash     = "0x01"
+		txTreeRoot = "0x02"
+		blockHash  = "0x03"
+		timeout    = 100 * time.Millisecond
+	)
+
+	cfg := configs.Config{}
+	cfg.Worker.BlockStatusPollInterval = time.Hour
+	cfg.Worker.BlockStatusSubscriptionTimeout = timeout
+	log := logger.New("error", time.RFC3339, false, false)
+
+	blockNumber := int64(7)
+	postedBlock := mDBApp.Block{BlockHash: blockHash, BlockNumber: &blockNumber}
+
+	type subscription struct {
+		ch           chan *worker.TxEvent
+		unsubscribed bool
+	}
+
+	type subscriptions struct {
+		mu   sync.Mutex
+		subs map[string]*subscription
+	}
+
+	// wait returns the events channel of the key as soon as the key is subscribed.
+	wait := func(s *subscriptions, key string) chan *worker.TxEvent {
+		for {
+			s.mu.Lock()
+			sub, ok := s.subs[key]
+			s.mu.Unlock()
+			if ok {
+				return sub.ch
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	unsubscribed := func(s *subscriptions, key string) bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		sub, ok := s.subs[key]
+		return ok && sub.unsubscribed
+	}
+
+	setup := func(t *testing.T) (
+		uc ucBlockStatusSubscribe.UseCaseBlockStatusSubscribe,
+		db *MockSQLDriverApp,
+		w *MockWorker,
+		subs *subscriptions,
+	) {
+		ctrl := gomock.NewController(t)
+		db = NewMockSQLDriverApp(ctrl)
+		w = NewMockWorker(ctrl)
+
+		subs = &subscriptions{subs: make(map[string]*subscription)}
+		w.EXPECT().Subscribe(gomock.Any()).DoAndReturn(
+			func(key string) (<-chan *worker.TxEvent, func()) {
+				subs.mu.Lock()
+				defer subs.mu.Unlock()
+				sub, ok := subs.subs[key]
+				if !ok {
+					sub = &subscription{ch: make(chan *worker.TxEvent, 8)}
+					subs.subs[key] = sub
+				}
+				return sub.ch, func() {
+					subs.mu.Lock()
+					defer subs.mu.Unlock()
+					sub.unsubscribed = true
+				}
+			},
+		).AnyTimes()
+
+		return block_status_subscribe.New(&cfg, log, db, w), db, w, subs
+	}
+
+	events := func() (func(event *ucBlockStatusSubscribe.UCBlockStatusEvent) error, *[]string) {
+		var sent []string
+		return func(event *ucBlockStatusSubscribe.UCBlockStatusEvent) error {
+			sent = append(sent, event.Event+":"+event.TxHash+":"+event.TxTreeRoot)
+			return nil
+		}, &sent
+	}
+
+	t.Run("Empty input", func(t *testing.T) {
+		uc, _, _, _ := setup(t)
+
+		send, _ := events()
+		err := uc.Do(context.Background(), nil, send)
+		assert.ErrorIs(t, err, block_status_subscribe.ErrUCInputEmpty)
+	})
+
+	t.Run("Snapshot of the posted transaction", func(t *testing.T) {
+		uc, db, w, subs := setup(t)
+
+		w.EXPECT().TrHash(txHash).Return(nil, worker.ErrTransactionHashNotFound)
+		db.EXPECT().TxMerkleProofsByTxHash(txHash).Return(&mDBApp.TxMerkleProofs{
+			TxHash:      txHash,
+			TxTreeRoot:  txTreeRoot,
+			SignatureID: "signature",
+		}, nil)
+		db.EXPECT().BlockByTxRoot(txTreeRoot).Return(&postedBlock, nil)
+
+		send, sent := events()
+		err := uc.Do(context.Background(), &ucBlockStatusSubscribe.UCBlockStatusSubscribeInput{TxHash: txHash}, send)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			worker.TxEventSigned + ":" + txHash + ":" + txTreeRoot,
+			worker.TxEventPosted + ":" + txHash + ":" + txTreeRoot,
+		}, *sent)
+		assert.True(t, unsubscribed(subs, txHash))
+		assert.True(t, unsubscribed(subs, txTreeRoot))
+	})
+
+	t.Run("Snapshot of the transaction dropped without the signature", func(t *testing.T) {
+		uc, db, w, _ := setup(t)
+
+		w.EXPECT().TrHash(txHash).Return(nil, worker.ErrTransactionHashNotFound)
+		db.EXPECT().TxMerkleProofsByTxHash(txHash).Return(&mDBApp.TxMerkleProofs{
+			TxHash:     txHash,
+			TxTreeRoot: txTreeRoot,
+		}, nil)
+
+		send, sent := events()
+		err := uc.Do(context.Background(), &ucBlockStatusSubscribe.UCBlockStatusSubscribeInput{TxHash: txHash}, send)
+		require.NoError(t, err)
+		assert.Equal(t, []string{worker.TxEventDropped + ":" + txHash + ":" + txTreeRoot}, *sent)
+	})
+
+	t.Run("Updates of the accepted transaction", func(t *testing.T) {
+		uc, db, w, subs := setup(t)
+
+		w.EXPECT().TrHash(txHash).Return(&worker.TransactionHashesWithSenderAndFile{TxHash: txHash}, nil)
+		db.EXPECT().BlockByTxRoot(txTreeRoot).Return(&postedBlock, nil)
+
+		send, sent := events()
+		go func() {
+			// The events are published after the subscription.
+			ch := wait(subs, txHash)
+			ch <- &worker.TxEvent{Type: worker.TxEventProposed, TxHash: txHash, TxTreeRoot: txTreeRoot}
+			ch <- &worker.TxEvent{Type: worker.TxEventSigned, TxHash: txHash, TxTreeRoot: txTreeRoot}
+		}()
+
+		err := uc.Do(context.Background(), &ucBlockStatusSubscribe.UCBlockStatusSubscribeInput{TxHash: txHash}, send)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			worker.TxEventAccepted + ":" + txHash + ":",
+			worker.TxEventProposed + ":" + txHash + ":" + txTreeRoot,
+			worker.TxEventSigned + ":" + txHash + ":" + txTreeRoot,
+			worker.TxEventPosted + ":" + txHash + ":" + txTreeRoot,
+		}, *sent)
+	})
+
+	t.Run("Drop of the tx tree is fanned out to the transaction", func(t *testing.T) {
+		uc, db, w, subs := setup(t)
+
+		w.EXPECT().TrHash(txHash).Return(nil, worker.ErrTransactionHashNotFound)
+		db.EXPECT().TxMerkleProofsByTxHash(txHash).Return(nil, errorsDB.ErrNotFound)
+
+		send, sent := events()
+		go func() {
+			wait(subs, txHash) <- &worker.TxEvent{Type: worker.TxEventProposed, TxHash: txHash, TxTreeRoot: txTreeRoot}
+
+			// The events of the other transactions of the tx tree are skipped.
+			ch := wait(subs, txTreeRoot)
+			ch <- &worker.TxEvent{Type: worker.TxEventDropped, TxHash: "0x04", TxTreeRoot: txTreeRoot}
+			ch <- &worker.TxEvent{
+				Type:       worker.TxEventDropped,
+				TxTreeRoot: txTreeRoot,
+				Reason:     worker.ReasonTxTreeNotIncluded,
+			}
+		}()
+
+		err := uc.Do(context.Background(), &ucBlockStatusSubscribe.UCBlockStatusSubscribeInput{TxHash: txHash}, send)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			worker.TxEventProposed + ":" + txHash + ":" + txTreeRoot,
+			worker.TxEventDropped + ":" + txHash + ":" + txTreeRoot,
+		}, *sent)
+	})
+
+	t.Run("Drop of the tx tree finishes the tx tree root subscription", func(t *testing.T) {
+		uc, db, _, subs := setup(t)
+
+		db.EXPECT().BlockByTxRoot(txTreeRoot).Return(nil, errorsDB.ErrNotFound)
+
+		send, sent := events()
+		go func() {
+			ch := wait(subs, txTreeRoot)
+			ch <- &worker.TxEvent{Type: worker.TxEventDropped, TxHash: txHash, TxTreeRoot: txTreeRoot}
+			ch <- &worker.TxEvent{Type: worker.TxEventDropped, TxTreeRoot: txTreeRoot}
+		}()
+
+		err := uc.Do(context.Background(), &ucBlockStatusSubscribe.UCBlockStatusSubscribeInput{TxTreeRoot: txTreeRoot}, send)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			worker.TxEventDropped + ":" + txHash + ":" + txTreeRoot,
+			worker.TxEventDropped + "::" + txTreeRoot,
+		}, *sent)
+	})
+
+	t.Run("Subscription is finished by the timeout", func(t *testing.T) {
+		uc, db, w, subs := setup(t)
+
+		w.EXPECT().TrHash(txHash).Return(nil, worker.ErrTransactionHashNotFound)
+		db.EXPECT().TxMerkleProofsByTxHash(txHash).Return(nil, errorsDB.ErrNotFound)
+
+		send, sent := events()
+		start := time.Now()
+		err := uc.Do(context.Background(), &ucBlockStatusSubscribe.UCBlockStatusSubscribeInput{TxHash: txHash}, send)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), timeout)
+		assert.Empty(t, *sent)
+		assert.True(t, unsubscribed(subs, txHash))
+	})
+
+	t.Run("Subscription is finished by the cancel", func(t *testing.T) {
+		uc, db, w, subs := setup(t)
+
+		w.EXPECT().TrHash(txHash).Return(nil, worker.ErrTransactionHashNotFound)
+		db.EXPECT().TxMerkleProofsByTxHash(txHash).Return(nil, errorsDB.ErrNotFound)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		send, sent := events()
+		err := uc.Do(ctx, &ucBlockStatusSubscribe.UCBlockStatusSubscribeInput{TxHash: txHash}, send)
+		require.NoError(t, err)
+		assert.Empty(t, *sent)
+		assert.True(t, unsubscribed(subs, txHash))
+	})
+
+	t.Run("Failed send finishes the subscription", func(t *testing.T) {
+		uc, _, w, _ := setup(t)
+
+		w.EXPECT().TrHash(txHash).Return(&worker.TransactionHashesWithSenderAndFile{TxHash: txHash}, nil)
+
+		errSend := errors.New("stream is closed")
+		err := uc.Do(
+			context.Background(),
+			&ucBlockStatusSubscribe.UCBlockStatusSubscribeInput{TxHash: txHash},
+			func(_ *ucBlockStatusSubscribe.UCBlockStatusEvent) error { return errSend },
+		)
+		assert.ErrorIs(t, err, block_status_subscribe.ErrSendEventFail)
+		assert.ErrorIs(t, err, errSend)
+	})
+}
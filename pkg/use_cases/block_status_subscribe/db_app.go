@@ -0,0 +1,26 @@
+package block_status_subscribe
+
+import (
+	"context"
+	mDBApp "intmax2-node/pkg/sql_db/db_app/models"
+)
+
+//go:generate mockgen -destination=mock_db_app_test.go -package=block_status_subscribe_test -source=db_app.go
+
+type SQLDriverApp interface {
+	GenericCommandsApp
+	Blocks
+	TxMerkleProofs
+}
+
+type GenericCommandsApp interface {
+	Exec(ctx context.Context, input interface{}, executor func(d interface{}, input interface{}) error) (err error)
+}
+
+type Blocks interface {
+	BlockByTxRoot(txRoot string) (*mDBApp.Block, error)
+}
+
+type TxMerkleProofs interface {
+	TxMerkleProofsByTxHash(txHash string) (*mDBApp.TxMerkleProofs, error)
+}
@@ -0,0 +1,15 @@
+package block_status_subscribe
+
+import "errors"
+
+// ErrUCInputEmpty error: uc-input must not be empty.
+var ErrUCInputEmpty = errors.New("uc-input must not be empty")
+
+// ErrTxMerkleProofsByTxHashFail error: failed to get the tx merkle proofs by the transaction hash.
+var ErrTxMerkleProofsByTxHashFail = errors.New("failed to get the tx merkle proofs by the transaction hash")
+
+// ErrBlockByTxRootFail error: failed to get the block by the tx tree root.
+var ErrBlockByTxRootFail = errors.New("failed to get the block by the tx tree root")
+
+// ErrSendEventFail error: failed to send the event.
+var ErrSendEventFail = errors.New("failed to send the event")
@@ -0,0 +1,10 @@
+package block_status_subscribe
+
+import "intmax2-node/internal/worker"
+
+//go:generate mockgen -destination=mock_worker_test.go -package=block_status_subscribe_test -source=worker.go
+
+type Worker interface {
+	TrHash(trHash string) (*worker.TransactionHashesWithSenderAndFile, error)
+	Subscribe(key string) (events <-chan *worker.TxEvent, unsubscribe func())
+}
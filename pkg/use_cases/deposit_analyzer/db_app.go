@@ -10,6 +10,7 @@ import (
 type SQLDriverApp interface {
 	GenericCommandsApp
 	EventBlockNumbers
+	DepositAMLScreenings
 }
 
 type GenericCommandsApp interface {
@@ -21,3 +22,8 @@ type EventBlockNumbers interface {
 	EventBlockNumberByEventName(eventName string) (*mDBApp.EventBlockNumber, error)
 	EventBlockNumbersByEventNames(eventNames []string) ([]*mDBApp.EventBlockNumber, error)
 }
+
+type DepositAMLScreenings interface {
+	UpsertDepositAMLScreening(screening *mDBApp.DepositAMLScreening) (*mDBApp.DepositAMLScreening, error)
+	DepositAMLScreeningByDepositID(depositID uint64) (*mDBApp.DepositAMLScreening, error)
+}
@@ -47,12 +47,16 @@ func (u *uc) Do(ctx context.Context) (err error) {
 			const msg = "exec of deposit analyzer error occurred: %w"
 			err = fmt.Errorf(msg, fmt.Errorf("%+v", r))
 			open_telemetry.MarkSpanError(spanCtx, err)
-		} else {
+		} else if err == nil {
 			u.log.Infof("Completed DepositAnalyzer")
 		}
 	}()
 
-	service.DepositAnalyzer(spanCtx, u.cfg, u.log, u.db, u.sb)
+	err = service.DepositAnalyzer(spanCtx, u.cfg, u.log, u.db, u.sb)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return err
+	}
 
-	return err
+	return nil
 }
@@ -60,11 +60,16 @@ func (u *uc) Do(
 		return nil, err
 	}
 
+	var endBlockNumber uint64
+	if len(deposits) > 0 {
+		endBlockNumber = uint64(deposits[len(deposits)-1].BlockNumber)
+	}
+
 	data := node.GetBackupDepositsResponse_Data{
 		Deposits: generateBackupDeposits(deposits),
 		Meta: &node.GetBackupDepositsResponse_Meta{
 			StartBlockNumber: input.StartBlockNumber,
-			EndBlockNumber:   0,
+			EndBlockNumber:   endBlockNumber,
 		},
 	}
 
@@ -83,6 +88,7 @@ func generateBackupDeposits(deposits []*models.BackupDeposit) []*node.GetBackupD
 				Seconds: deposit.CreatedAt.Unix(),
 				Nanos:   int32(deposit.CreatedAt.Nanosecond()),
 			},
+			Seq: uint64(deposit.Seq),
 		}
 		results = append(results, backupDeposit)
 	}
@@ -37,6 +37,7 @@ func (u *uc) Do(
 		senderKey           = "sender"
 		startBlockNumberKey = "start_block_number"
 		limitKey            = "limit"
+		startSeqKey         = "start_seq"
 	)
 
 	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName)
@@ -50,6 +51,7 @@ func (u *uc) Do(
 	span.SetAttributes(
 		attribute.Int64(startBlockNumberKey, int64(input.StartBlockNumber)),
 		attribute.Int64(limitKey, int64(input.Limit)),
+		attribute.Int64(startSeqKey, int64(input.StartSeq)),
 	)
 
 	transactions, err := service.GetBackupTransactions(ctx, u.cfg, u.log, u.db, input)
@@ -57,11 +59,16 @@ func (u *uc) Do(
 		return nil, err
 	}
 
+	var endBlockNumber uint64
+	if len(transactions) > 0 {
+		endBlockNumber = uint64(transactions[len(transactions)-1].BlockNumber)
+	}
+
 	data := node.GetBackupTransactionsResponse_Data{
 		Transactions: generateBackupTransaction(transactions),
 		Meta: &node.GetBackupTransactionsResponse_Meta{
 			StartBlockNumber: input.StartBlockNumber,
-			EndBlockNumber:   0,
+			EndBlockNumber:   endBlockNumber,
 		},
 	}
 
@@ -81,6 +88,7 @@ func generateBackupTransaction(transactions []*models.BackupTransaction) []*node
 				Seconds: transactions[key].CreatedAt.Unix(),
 				Nanos:   int32(transactions[key].CreatedAt.Nanosecond()),
 			},
+			Seq: uint64(transactions[key].Seq),
 		}
 		results = append(results, backupTransaction)
 	}
@@ -41,6 +41,7 @@ func (u *uc) Do(
 		senderKey           = "sender"
 		startBlockNumberKey = "start_block_number"
 		limitKey            = "limit"
+		startSeqKey         = "start_seq"
 	)
 
 	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName)
@@ -55,6 +56,7 @@ func (u *uc) Do(
 		attribute.String(senderKey, input.Sender),
 		attribute.Int64(startBlockNumberKey, int64(input.StartBlockNumber)),
 		attribute.Int64(limitKey, int64(input.Limit)),
+		attribute.Int64(startSeqKey, int64(input.StartSeq)),
 	)
 
 	transfers, err := service.GetBackupTransfers(ctx, u.cfg, u.log, u.db, input)
@@ -62,11 +64,16 @@ func (u *uc) Do(
 		return nil, err
 	}
 
+	var endBlockNumber uint64
+	if len(transfers) > 0 {
+		endBlockNumber = transfers[len(transfers)-1].BlockNumber
+	}
+
 	data := node.GetBackupTransfersResponse_Data{
 		Transfers: generateBackupTransfers(transfers),
 		Meta: &node.GetBackupTransfersResponse_Meta{
 			StartBlockNumber: input.StartBlockNumber,
-			EndBlockNumber:   0,
+			EndBlockNumber:   endBlockNumber,
 		},
 	}
 
@@ -85,6 +92,7 @@ func generateBackupTransfers(transfers []*models.BackupTransfer) []*node.GetBack
 				Seconds: transfers[key].CreatedAt.Unix(),
 				Nanos:   int32(transfers[key].CreatedAt.Nanosecond()),
 			},
+			Seq: uint64(transfers[key].Seq),
 		}
 		results = append(results, backupTransfer)
 	}
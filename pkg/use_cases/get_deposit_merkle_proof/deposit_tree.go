@@ -0,0 +1,14 @@
+package get_deposit_merkle_proof
+
+import (
+	"intmax2-node/internal/deposit_indexer"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:generate mockgen -destination=mock_deposit_tree_test.go -package=get_deposit_merkle_proof_test -source=deposit_tree.go
+
+type DepositTree interface {
+	MerkleProofByDepositID(depositID uint64) (*deposit_indexer.DepositMerkleProof, error)
+	MerkleProofByDepositHash(depositHash common.Hash) (*deposit_indexer.DepositMerkleProof, error)
+}
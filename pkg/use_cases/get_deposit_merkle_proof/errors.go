@@ -0,0 +1,12 @@
+package get_deposit_merkle_proof
+
+import "errors"
+
+// ErrUCGetDepositMerkleProofInputEmpty error: ucGetDepositMerkleProofInput must not be empty.
+var ErrUCGetDepositMerkleProofInputEmpty = errors.New("ucGetDepositMerkleProofInput must not be empty")
+
+// ErrMerkleProofByDepositIDFail error: failed to get deposit Merkle proof by deposit ID.
+var ErrMerkleProofByDepositIDFail = errors.New("failed to get deposit Merkle proof by deposit ID")
+
+// ErrMerkleProofByDepositHashFail error: failed to get deposit Merkle proof by deposit hash.
+var ErrMerkleProofByDepositHashFail = errors.New("failed to get deposit Merkle proof by deposit hash")
@@ -0,0 +1,109 @@
+package get_deposit_merkle_proof
+
+import (
+	"context"
+	"errors"
+	"intmax2-node/configs"
+	"intmax2-node/internal/deposit_indexer"
+	"intmax2-node/internal/logger"
+	"intmax2-node/internal/open_telemetry"
+	node "intmax2-node/internal/pb/gen/store_vault_service/node"
+	getDepositMerkleProof "intmax2-node/internal/use_cases/get_deposit_merkle_proof"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	base10   = 10
+	int64Key = 64
+)
+
+// uc describes use case
+type uc struct {
+	cfg         *configs.Config
+	log         logger.Logger
+	depositTree DepositTree
+}
+
+func New(
+	cfg *configs.Config,
+	log logger.Logger,
+	depositTree DepositTree,
+) getDepositMerkleProof.UseCaseGetDepositMerkleProof {
+	return &uc{
+		cfg:         cfg,
+		log:         log,
+		depositTree: depositTree,
+	}
+}
+
+func (u *uc) Do(
+	ctx context.Context,
+	input *getDepositMerkleProof.UCGetDepositMerkleProofInput,
+) (*node.GetDepositMerkleProofResponse_Data, error) {
+	const (
+		hName          = "UseCase GetDepositMerkleProof"
+		depositIDKey   = "deposit_id"
+		depositHashKey = "deposit_hash"
+	)
+
+	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName)
+	defer span.End()
+
+	if input == nil {
+		open_telemetry.MarkSpanError(spanCtx, ErrUCGetDepositMerkleProofInputEmpty)
+		return nil, ErrUCGetDepositMerkleProofInputEmpty
+	}
+
+	span.SetAttributes(
+		attribute.String(depositIDKey, input.DepositID),
+		attribute.String(depositHashKey, input.DepositHash),
+	)
+
+	proof, err := u.merkleProof(input)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return nil, err
+	}
+
+	data := node.GetDepositMerkleProofResponse_Data{
+		DepositId:    proof.DepositID,
+		DepositHash:  proof.DepositHash.Hex(),
+		DepositIndex: proof.DepositIndex,
+		MerkleProof:  make([]string, len(proof.Siblings)),
+		Root:         proof.Root.Hex(),
+	}
+	for key := range proof.Siblings {
+		data.MerkleProof[key] = proof.Siblings[key].Hex()
+	}
+
+	return &data, nil
+}
+
+func (u *uc) merkleProof(
+	input *getDepositMerkleProof.UCGetDepositMerkleProofInput,
+) (*deposit_indexer.DepositMerkleProof, error) {
+	if input.DepositHash != "" {
+		proof, err := u.depositTree.MerkleProofByDepositHash(common.HexToHash(input.DepositHash))
+		if err != nil {
+			return nil, errors.Join(ErrMerkleProofByDepositHashFail, err)
+		}
+
+		return proof, nil
+	}
+
+	depositID, err := strconv.ParseUint(input.DepositID, base10, int64Key)
+	if err != nil {
+		return nil, errors.Join(ErrMerkleProofByDepositIDFail, err)
+	}
+
+	var proof *deposit_indexer.DepositMerkleProof
+	proof, err = u.depositTree.MerkleProofByDepositID(depositID)
+	if err != nil {
+		return nil, errors.Join(ErrMerkleProofByDepositIDFail, err)
+	}
+
+	return proof, nil
+}
@@ -20,14 +20,22 @@ type uc struct {
 	log logger.Logger
 	db  SQLDriverApp
 	sb  service.ServiceBlockchain
+	bvp service.BalanceValidityProver
 }
 
-func New(cfg *configs.Config, log logger.Logger, db SQLDriverApp, sb service.ServiceBlockchain) postWithdrwalRequest.UseCasePostWithdrawalRequest {
+func New(
+	cfg *configs.Config,
+	log logger.Logger,
+	db SQLDriverApp,
+	sb service.ServiceBlockchain,
+	bvp service.BalanceValidityProver,
+) postWithdrwalRequest.UseCasePostWithdrawalRequest {
 	return &uc{
 		cfg: cfg,
 		log: log,
 		db:  db,
 		sb:  sb,
+		bvp: bvp,
 	}
 }
 
@@ -49,7 +57,7 @@ func (u *uc) Do(ctx context.Context, input *postWithdrwalRequest.UCPostWithdrawa
 		attribute.String(txHashKey, input.TransferHash),
 	)
 
-	err := service.PostWithdrawalRequest(ctx, u.cfg, u.log, u.db, u.sb, input)
+	err := service.PostWithdrawalRequest(ctx, u.cfg, u.log, u.db, u.sb, u.bvp, input)
 	if err != nil {
 		if errors.Is(err, service.ErrWithdrawalRequestAlreadyExists) {
 			return service.ErrWithdrawalRequestAlreadyExists
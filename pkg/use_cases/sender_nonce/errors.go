@@ -0,0 +1,9 @@
+package sender_nonce
+
+import "errors"
+
+// ErrUCInputEmpty error: uc-input must not be empty.
+var ErrUCInputEmpty = errors.New("uc-input must not be empty")
+
+// ErrNextNonceFail error: failed to get the next nonce of sender.
+var ErrNextNonceFail = errors.New("failed to get the next nonce of sender")
@@ -0,0 +1,60 @@
+package sender_nonce
+
+import (
+	"context"
+	"errors"
+	"intmax2-node/configs"
+	"intmax2-node/internal/logger"
+	"intmax2-node/internal/open_telemetry"
+	ucSenderNonce "intmax2-node/internal/use_cases/sender_nonce"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type uc struct {
+	cfg    *configs.Config
+	log    logger.Logger
+	worker Worker
+}
+
+func New(
+	cfg *configs.Config,
+	log logger.Logger,
+	w Worker,
+) ucSenderNonce.UseCaseSenderNonce {
+	return &uc{
+		cfg:    cfg,
+		log:    log,
+		worker: w,
+	}
+}
+
+func (u *uc) Do(
+	ctx context.Context, input *ucSenderNonce.UCSenderNonceInput,
+) (*ucSenderNonce.UCSenderNonce, error) {
+	const (
+		hName     = "UseCase SenderNonce"
+		senderKey = "sender"
+	)
+
+	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName)
+	defer span.End()
+
+	if input == nil {
+		open_telemetry.MarkSpanError(spanCtx, ErrUCInputEmpty)
+		return nil, ErrUCInputEmpty
+	}
+
+	span.SetAttributes(attribute.String(senderKey, input.Sender))
+
+	nonce, err := u.worker.NextNonce(input.Sender)
+	if err != nil {
+		open_telemetry.MarkSpanError(spanCtx, err)
+		return nil, errors.Join(ErrNextNonceFail, err)
+	}
+
+	return &ucSenderNonce.UCSenderNonce{
+		Sender: input.Sender,
+		Nonce:  nonce,
+	}, nil
+}
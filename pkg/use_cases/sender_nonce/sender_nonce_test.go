@@ -0,0 +1,85 @@
+package sender_nonce_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"intmax2-node/configs"
+	intMaxAcc "intmax2-node/internal/accounts"
+	senderNonce "intmax2-node/internal/use_cases/sender_nonce"
+	"intmax2-node/pkg/logger"
+	ucSenderNonce "intmax2-node/pkg/use_cases/sender_nonce"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCaseSenderNonce(t *testing.T) {
+	const int3Key = 3
+	assert.NoError(t, configs.LoadDotEnv(int3Key))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := configs.New()
+	log := logger.New(cfg.LOG.Level, cfg.LOG.TimeFormat, cfg.LOG.JSON, cfg.LOG.IsLogLine)
+	w := NewMockWorker(ctrl)
+
+	uc := ucSenderNonce.New(cfg, log, w)
+
+	senderAccount, err := intMaxAcc.NewPrivateKey(big.NewInt(2))
+	assert.NoError(t, err)
+	sender := senderAccount.ToAddress().String()
+
+	errWorker := errors.New("worker error")
+
+	cases := []struct {
+		desc    string
+		input   *senderNonce.UCSenderNonceInput
+		prepare func()
+		nonce   uint64
+		err     error
+	}{
+		{
+			desc: fmt.Sprintf("Error: %s", ucSenderNonce.ErrUCInputEmpty.Error()),
+			err:  ucSenderNonce.ErrUCInputEmpty,
+		},
+		{
+			desc:  fmt.Sprintf("Error: %s", ucSenderNonce.ErrNextNonceFail.Error()),
+			input: &senderNonce.UCSenderNonceInput{Sender: sender},
+			prepare: func() {
+				w.EXPECT().NextNonce(sender).Return(uint64(0), errWorker)
+			},
+			err: ucSenderNonce.ErrNextNonceFail,
+		},
+		{
+			desc:  "Success",
+			input: &senderNonce.UCSenderNonceInput{Sender: sender},
+			prepare: func() {
+				w.EXPECT().NextNonce(sender).Return(uint64(7), nil)
+			},
+			nonce: 7,
+		},
+	}
+
+	for i := range cases {
+		t.Run(cases[i].desc, func(t *testing.T) {
+			if cases[i].prepare != nil {
+				cases[i].prepare()
+			}
+
+			result, err := uc.Do(context.Background(), cases[i].input)
+			if cases[i].err != nil {
+				assert.True(t, errors.Is(err, cases[i].err))
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, sender, result.Sender)
+			assert.Equal(t, cases[i].nonce, result.Nonce)
+		})
+	}
+}
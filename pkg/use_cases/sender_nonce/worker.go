@@ -0,0 +1,7 @@
+package sender_nonce
+
+//go:generate mockgen -destination=mock_worker_test.go -package=sender_nonce_test -source=worker.go
+
+type Worker interface {
+	NextNonce(sender string) (nonce uint64, err error)
+}
@@ -66,10 +66,20 @@ func (u *uc) Do(ctx context.Context, input *transaction.UCTransactionInput) (err
 	 * }
 	 */
 
+	var fee *worker.ReceiverWorkerFee
+	if input.FeeTransfer != nil && input.FeeTransfer.DecodeTransfer != nil {
+		fee = &worker.ReceiverWorkerFee{
+			TokenIndex:   input.FeeTransfer.DecodeTransfer.TokenIndex,
+			Amount:       input.FeeTransfer.DecodeTransfer.Amount.String(),
+			TransferHash: input.FeeTransfer.DecodeTransfer.Hash().String(),
+		}
+	}
+
 	err = u.w.Receiver(&worker.ReceiverWorker{
 		Sender:        input.DecodeSender.ToAddress().String(),
 		Nonce:         input.Nonce,
 		TransfersHash: input.TransfersHash,
+		Fee:           fee,
 	})
 	if err != nil {
 		open_telemetry.MarkSpanError(spanCtx, err)
@@ -18,4 +18,5 @@ type GenericCommandsSB interface {
 type ChainSB interface {
 	SetupEthereumNetworkChainID(ctx context.Context) error
 	EthereumNetworkChainLinkEvmJSONRPC(ctx context.Context) (string, error)
+	ScrollNetworkChainLinkEvmJSONRPC(ctx context.Context) (string, error)
 }
@@ -0,0 +1,9 @@
+package tx_transfer_batch
+
+import "errors"
+
+// ErrEmptyUserPrivateKey error: user private key is empty.
+var ErrEmptyUserPrivateKey = errors.New("user private key is empty")
+
+// ErrEmptyFilePath error: file path is empty.
+var ErrEmptyFilePath = errors.New("file path is empty")
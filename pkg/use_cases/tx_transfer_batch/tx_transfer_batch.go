@@ -0,0 +1,70 @@
+package tx_transfer_batch
+
+import (
+	"context"
+	"fmt"
+	"intmax2-node/configs"
+	intMaxAcc "intmax2-node/internal/accounts"
+	"intmax2-node/internal/logger"
+	"intmax2-node/internal/mnemonic_wallet"
+	"intmax2-node/internal/open_telemetry"
+	service "intmax2-node/internal/tx_transfer_service"
+	txTransferBatch "intmax2-node/internal/use_cases/tx_transfer_batch"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// uc describes use case
+type uc struct {
+	cfg *configs.Config
+	log logger.Logger
+	sb  ServiceBlockchain
+}
+
+func New(
+	cfg *configs.Config,
+	log logger.Logger,
+	sb ServiceBlockchain,
+) txTransferBatch.UseCaseTxTransferBatch {
+	return &uc{
+		cfg: cfg,
+		log: log,
+		sb:  sb,
+	}
+}
+
+func (u *uc) Do(ctx context.Context, filePath string, feeTokenIndex uint32, userEthPrivateKey string) (err error) {
+	const (
+		hName     = "UseCase TxTransferBatch"
+		senderKey = "sender"
+		fileKey   = "file"
+	)
+
+	spanCtx, span := open_telemetry.Tracer().Start(ctx, hName)
+	defer span.End()
+
+	if userEthPrivateKey == "" {
+		return ErrEmptyUserPrivateKey
+	}
+
+	wallet, err := mnemonic_wallet.New().WalletFromPrivateKeyHex(userEthPrivateKey)
+	if err != nil {
+		return fmt.Errorf("fail to parse user private key: %v", err)
+	}
+
+	userAccount, err := intMaxAcc.NewPrivateKeyFromString(wallet.IntMaxPrivateKey)
+	if err != nil {
+		return err
+	}
+
+	span.SetAttributes(
+		attribute.String(senderKey, userAccount.ToAddress().String()),
+		attribute.String(fileKey, filePath),
+	)
+
+	if filePath == "" {
+		return ErrEmptyFilePath
+	}
+
+	return service.TransferBatch(spanCtx, u.cfg, u.log, u.sb, filePath, feeTokenIndex, userEthPrivateKey)
+}
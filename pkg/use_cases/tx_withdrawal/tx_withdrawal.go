@@ -52,17 +52,17 @@ func (u *uc) Do(ctx context.Context, args []string, recipientAddressHex, amount,
 		return ErrEmptyRecipientAddress
 	}
 
-	service.ResumeWithdrawalRequest(spanCtx, u.cfg, u.log, u.sb, recipientAddressHex, resumeIncompleteWithdrawals)
+	if userEthPrivateKey == "" {
+		return ErrEmptyUserPrivateKey
+	}
+
+	service.ResumeWithdrawalRequest(spanCtx, u.cfg, u.log, u.sb, recipientAddressHex, userEthPrivateKey, resumeIncompleteWithdrawals)
 
 	if resumeIncompleteWithdrawals {
 		u.log.Infof("Complete the withdrawal request")
 		return nil
 	}
 
-	if userEthPrivateKey == "" {
-		return ErrEmptyUserPrivateKey
-	}
-
 	wallet, err := mnemonic_wallet.New().WalletFromPrivateKeyHex(userEthPrivateKey)
 	if err != nil {
 		u.log.Errorf("fail to parse user private key: %v", err)